@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/venus/app/submodule/dagservice"
 	"github.com/filecoin-project/venus/app/submodule/eth"
 	"github.com/filecoin-project/venus/app/submodule/network"
@@ -16,6 +17,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/filecoin-project/venus/app/submodule/actorevent"
+	"github.com/filecoin-project/venus/app/submodule/auth"
 	"github.com/filecoin-project/venus/app/submodule/blockstore"
 	"github.com/filecoin-project/venus/app/submodule/chain"
 	"github.com/filecoin-project/venus/app/submodule/common"
@@ -101,6 +103,7 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 		offlineMode: b.offlineMode,
 		repo:        b.repo,
 		chainClock:  b.chainClock,
+		shutdown:    newShutdownManager(),
 	}
 
 	// modules
@@ -109,6 +112,11 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 	// services
 	nd.configModule = config2.NewConfigModule(b.repo)
 
+	nd.auth, err = auth.NewAuthSubmodule(ctx, b.repo)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build node.auth")
+	}
+
 	nd.blockstore, err = blockstore.NewBlockstoreSubmodule(ctx, (*builder)(b))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build node.blockstore")
@@ -123,6 +131,7 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build node.Network")
 	}
+	nd.blockstore.SetFetcher(nd.network.Bitswap.GetBlock)
 
 	nd.blockservice, err = dagservice.NewDagserviceSubmodule(ctx, (*builder)(b), nd.network)
 	if err != nil {
@@ -148,7 +157,7 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build node.storageNetworking")
 	}
-	nd.mining = mining.NewMiningModule(nd.syncer.Stmgr, (*builder)(b), nd.chain, nd.blockstore, nd.network, nd.syncer, *nd.wallet)
+	nd.mining = mining.NewMiningModule(nd.syncer.Stmgr, (*builder)(b), nd.chain, nd.blockstore, nd.network, nd.syncer, *nd.wallet, nd.mpool)
 
 	mgrps := &paychmgr.ManagerParams{
 		MPoolAPI:     nd.mpool.API(),
@@ -178,8 +187,16 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 
 	apiBuilder := NewBuilder()
 	apiBuilder.NameSpace("Filecoin")
+	apiBuilder.SelectTipSetWith(func(ctx context.Context, tss types.TipSetSelector) (types.TipSetKey, error) {
+		ts, err := nd.chain.ChainReader.SelectTipSet(ctx, tss)
+		if err != nil {
+			return types.EmptyTSK, err
+		}
+		return ts.Key(), nil
+	})
 
 	err = apiBuilder.AddServices(nd.configModule,
+		nd.auth,
 		nd.blockstore,
 		nd.network,
 		nd.blockservice,
@@ -219,7 +236,10 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 		_ = logging.SetLogLevel("rate-limit", "warn")
 	}
 
-	nd.jsonRPCServiceV1 = apiBuilder.Build("v1", ratelimiter)
-	nd.jsonRPCService = apiBuilder.Build("v0", ratelimiter)
+	nd.jsonRPCServices = make(map[string]*jsonrpc.RPCServer, len(APIVersions))
+	for _, version := range APIVersions {
+		nd.jsonRPCServices[version] = apiBuilder.Build(version, ratelimiter)
+	}
+	nd.registerShutdown()
 	return nd, nil
 }
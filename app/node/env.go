@@ -26,6 +26,7 @@ type Env struct {
 	PaychAPI  v1api.IPaychan
 	CommonAPI v1api.ICommon
 	EthAPI    v1api.IETH
+	AuthAPI   v1api.IAuth
 }
 
 var _ cmds.Environment = (*Env)(nil)
@@ -12,6 +12,7 @@ import (
 	"github.com/etherlabsio/healthcheck/v2"
 	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/venus/app/submodule/actorevent"
+	"github.com/filecoin-project/venus/app/submodule/auth"
 	"github.com/filecoin-project/venus/app/submodule/blockstore"
 	chain2 "github.com/filecoin-project/venus/app/submodule/chain"
 	"github.com/filecoin-project/venus/app/submodule/common"
@@ -27,6 +28,7 @@ import (
 	syncer2 "github.com/filecoin-project/venus/app/submodule/syncer"
 	"github.com/filecoin-project/venus/app/submodule/wallet"
 	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/chainsync/slashfilter"
 	"github.com/filecoin-project/venus/pkg/clock"
 	"github.com/filecoin-project/venus/pkg/config"
 	_ "github.com/filecoin-project/venus/pkg/crypto/bls"       // enable bls signatures
@@ -101,16 +103,21 @@ type Node struct {
 
 	common *common.CommonModule
 
+	auth *auth.AuthSubmodule
+
 	eth        *eth.EthSubModule
 	actorEvent *actorevent.ActorEventSubModule
 
 	//
 	// Jsonrpc
 	//
-	jsonRPCService, jsonRPCServiceV1 *jsonrpc.RPCServer
+	// jsonRPCServices are the servers of the api versions, by version
+	jsonRPCServices map[string]*jsonrpc.RPCServer
 
 	jaeger     *tracesdk.TracerProvider
 	remoteAuth jwtclient.IJwtAuthClient
+
+	shutdown *shutdownManager
 }
 
 func (node *Node) Chain() *chain2.ChainSubmodule {
@@ -182,6 +189,18 @@ func (node *Node) Start(ctx context.Context) error {
 		return err
 	}
 
+	if cfg := node.repo.Config().FaultReporter; cfg.EnableConsensusFaultReporter {
+		reporter, err := slashfilter.NewConsensusFaultReporter(ctx, cfg, node.wallet.API(), node.chain.API(),
+			node.mpool.API(), node.syncer.API())
+		if err != nil {
+			return errors.Wrap(err, "failed to create consensus fault reporter")
+		}
+		if err := reporter.Start(syncCtx); err != nil {
+			return errors.Wrap(err, "failed to start consensus fault reporter")
+		}
+		node.syncer.FaultReporter = reporter
+	}
+
 	// Start mpool module to receive new message
 	err = node.mpool.Start(syncCtx)
 	if err != nil {
@@ -193,6 +212,10 @@ func (node *Node) Start(ctx context.Context) error {
 		return err
 	}
 
+	if err := node.wallet.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start wallet tx history %v", err)
+	}
+
 	// network should start late,
 	err = node.network.Start(syncCtx)
 	if err != nil {
@@ -203,53 +226,87 @@ func (node *Node) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to start eth module %v", err)
 	}
 
-	return nil
-}
-
-// Stop initiates the shutdown of the node.
-func (node *Node) Stop(ctx context.Context) {
-	// stop eth submodule
-	log.Infof("closing eth ...")
-	if err := node.eth.Close(ctx); err != nil {
-		log.Warnf("error closing eth: %s", err)
+	if err := node.blockstore.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start blockstore maintenance %v", err)
 	}
 
-	// stop mpool submodule
-	log.Infof("shutting down mpool...")
-	node.mpool.Stop(ctx)
-
-	// stop syncer submodule
-	log.Infof("shutting down chain syncer...")
-	node.syncer.Stop(ctx)
-
-	// Stop network submodule
-	log.Infof("shutting down network...")
-	node.network.Stop(ctx)
+	if err := node.mining.Start(syncCtx); err != nil {
+		return fmt.Errorf("failed to start devnet mining %v", err)
+	}
 
-	// Stop chain submodule
-	log.Infof("shutting down chain...")
-	node.chain.Stop(ctx)
+	return nil
+}
 
-	// Stop paychannel submodule
-	log.Infof("shutting down pay channel...")
-	node.paychan.Stop()
+// registerShutdown registers the teardown of the submodules, in their shutdown order
+func (node *Node) registerShutdown() {
+	sm := node.shutdown
+	sm.register("eth", shutdownOrderServices, 0, node.eth.Close)
+	sm.register("pay channel", shutdownOrderServices, 0, func(context.Context) error {
+		node.paychan.Stop()
+		return nil
+	})
+	sm.register("devnet mining", shutdownOrderServices, 0, func(context.Context) error {
+		node.mining.Stop()
+		return nil
+	})
+	sm.register("blockstore maintenance", shutdownOrderServices, 0, func(context.Context) error {
+		node.blockstore.Stop()
+		return nil
+	})
+	sm.register("wallet tx history", shutdownOrderServices, 0, func(ctx context.Context) error {
+		node.wallet.Stop(ctx)
+		return nil
+	})
+	sm.register("mpool", shutdownOrderMpool, 0, func(ctx context.Context) error {
+		node.mpool.Stop(ctx)
+		return nil
+	})
+	sm.register("chain syncer", shutdownOrderSyncer, 0, func(ctx context.Context) error {
+		node.syncer.Stop(ctx)
+		return nil
+	})
+	sm.register("network", shutdownOrderNetwork, 0, func(ctx context.Context) error {
+		node.network.Stop(ctx)
+		return nil
+	})
+	sm.register("chain", shutdownOrderChain, 0, func(ctx context.Context) error {
+		node.chain.Stop(ctx)
+		return nil
+	})
+	// the datastores flush their pending writes when closed
+	sm.register("repository", shutdownOrderRepo, 2*defaultShutdownTimeout, func(context.Context) error {
+		return node.repo.Close()
+	})
+	sm.register("system logs", shutdownOrderObservability, 0, func(context.Context) error {
+		for _, name := range logging.GetSubsystems() {
+			_ = logging.Logger(name).Sync()
+		}
+		return nil
+	})
+	sm.register("jaeger tracing", shutdownOrderObservability, 0, func(ctx context.Context) error {
+		if node.jaeger == nil {
+			return nil
+		}
+		return metricsPKG.ShutdownJaeger(ctx, node.jaeger)
+	})
+}
 
-	log.Infof("closing repository...")
-	if err := node.repo.Close(); err != nil {
-		log.Warnf("error closing repo: %s", err)
+// Stop tears down the components of the node in their shutdown order, the later calls wait for the first one.
+func (node *Node) Stop(ctx context.Context) {
+	progress := node.shutdown.Shutdown(ctx)
+	for _, c := range progress.Components {
+		if c.State != ShutdownDone {
+			log.Warnf("component %s was not shut down: %s %s", c.Name, c.State, c.Error)
+		}
 	}
-
-	log.Infof("flushing system logs...")
-	sysNames := logging.GetSubsystems()
-	for _, name := range sysNames {
-		_ = logging.Logger(name).Sync()
+	if progress.Done() {
+		log.Infof("shutdown took %s", progress.Finished.Sub(progress.Started))
 	}
+}
 
-	if node.jaeger != nil {
-		if err := metricsPKG.ShutdownJaeger(ctx, node.jaeger); err != nil {
-			log.Warnf("error shutdown jaeger-tracing: %w", err)
-		}
-	}
+// ShutdownProgress reports the teardown of the components of the node, empty while the node runs
+func (node *Node) ShutdownProgress() ShutdownProgress {
+	return node.shutdown.Progress()
 }
 
 // RunRPCAndWait start rpc server and listen to signal to exit
@@ -280,16 +337,16 @@ func (node *Node) RunRPCAndWait(ctx context.Context, rootCmdDaemon *cmds.Command
 		return err
 	}
 
-	localVerifer, token, err := jwtclient.NewLocalAuthClient()
+	token, err := node.auth.DefaultAdminToken(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to generate local auth client: %s", err)
+		return fmt.Errorf("failed to generate admin token: %s", err)
 	}
 	err = node.repo.SetAPIToken(token)
 	if err != nil {
 		return fmt.Errorf("set token fail: %w", err)
 	}
 
-	authMux := jwtclient.NewAuthMux(localVerifer, node.remoteAuth, mux)
+	authMux := jwtclient.NewAuthMux(node.auth, node.remoteAuth, mux)
 	authMux.TrustHandle("/debug/pprof/", http.DefaultServeMux)
 	authMux.TrustHandle("/healthcheck", healthcheck.Handler())
 
@@ -303,6 +360,11 @@ func (node *Node) RunRPCAndWait(ctx context.Context, rootCmdDaemon *cmds.Command
 		},
 	}
 
+	node.shutdown.register("api server", shutdownOrderAPI, 0, func(ctx context.Context) error {
+		defer apiStatusGauge.Set(ctx, 0)
+		return apiServ.Shutdown(ctx)
+	})
+
 	go func() {
 		apiStatusGauge.Set(ctx, 1)
 		err := apiServ.Serve(netListener) // nolint
@@ -324,11 +386,6 @@ func (node *Node) RunRPCAndWait(ctx context.Context, rootCmdDaemon *cmds.Command
 	// todo: design an genterfull
 	memguard.CatchSignal(func(signal os.Signal) {
 		log.Infof("received signal(%s), venus will shutdown...", signal.String())
-		log.Infof("shutting down server...")
-		if err := apiServ.Shutdown(ctx); err != nil {
-			log.Warnf("failed to shutdown server: %v", err)
-		}
-		apiStatusGauge.Set(ctx, 0)
 		node.Stop(ctx)
 		memguard.Purge()
 		log.Infof("venus shutdown gracefully ...")
@@ -359,8 +416,9 @@ func (node *Node) runRestfulAPI(ctx context.Context, handler *http.ServeMux, roo
 }
 
 func (node *Node) runJsonrpcAPI(ctx context.Context, handler *http.ServeMux) error { // nolint
-	handler.Handle("/rpc/v0", node.jsonRPCService)
-	handler.Handle("/rpc/v1", node.jsonRPCServiceV1)
+	for version, server := range node.jsonRPCServices {
+		handler.Handle("/rpc/"+version, server)
+	}
 	return nil
 }
 
@@ -381,6 +439,7 @@ func (node *Node) createServerEnv(ctx context.Context) *Env {
 		MarketAPI:            node.market.API(),
 		CommonAPI:            node.common,
 		EthAPI:               node.eth.API(),
+		AuthAPI:              node.auth.API(),
 	}
 
 	return &env
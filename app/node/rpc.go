@@ -1,24 +1,43 @@
 package node
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/venus/app/submodule/actorevent"
 	"github.com/filecoin-project/venus/app/submodule/eth"
+	"github.com/filecoin-project/venus/venus-shared/api"
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/api/permission"
+	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/ipfs-force-community/metrics/ratelimit"
 )
 
 type RPCService interface{}
 
+// APIVersions are the versions of the api served at /rpc/<version>, all of them by the same services
+var APIVersions = []string{"v0", "v1"}
+
+// v0Renames maps the methods of v0 to the methods of v1 they were renamed to, they are served by the v1 method when
+// the services don't implement them for v0
+var v0Renames = map[string]string{
+	"BeaconGetEntry": "StateGetBeaconEntry",
+}
+
+// TipSetSelectorFunc resolves a tipset selector to the key of the tipset it selects
+type TipSetSelectorFunc func(ctx context.Context, tss types.TipSetSelector) (types.TipSetKey, error)
+
 type RPCBuilder struct {
-	namespace   []string
-	v0APIStruct []interface{}
-	v1APIStruct []interface{}
+	namespace    []string
+	v0APIStruct  []interface{}
+	v1APIStruct  []interface{}
+	selectTipSet TipSetSelectorFunc
 }
 
 func NewBuilder() *RPCBuilder {
@@ -30,6 +49,13 @@ func (builder *RPCBuilder) NameSpace(nameSpaece string) *RPCBuilder {
 	return builder
 }
 
+// SelectTipSetWith lets every api taking a TipSetKey take a TipSetSelector in its place, the selector is resolved
+// by selectTipSet before the api is called
+func (builder *RPCBuilder) SelectTipSetWith(selectTipSet TipSetSelectorFunc) *RPCBuilder {
+	builder.selectTipSet = selectTipSet
+	return builder
+}
+
 func (builder *RPCBuilder) AddServices(services ...RPCService) error {
 	for _, service := range services {
 		err := builder.AddService(service)
@@ -123,6 +149,11 @@ func (builder *RPCBuilder) Build(version string, limiter *ratelimit.RateLimiter)
 	var server *jsonrpc.RPCServer
 	serverOptions := make([]jsonrpc.ServerOption, 0)
 	serverOptions = append(serverOptions, jsonrpc.WithProxyBind(jsonrpc.PBMethod))
+	// every BigInt param is decoded leniently, see bigIntDecoder
+	serverOptions = append(serverOptions, jsonrpc.WithParamDecoder(new(types.BigInt), bigIntDecoder))
+	if builder.selectTipSet != nil {
+		serverOptions = append(serverOptions, jsonrpc.WithParamDecoder(new(types.TipSetKey), tipSetKeyDecoder(builder.selectTipSet)))
+	}
 
 	switch version {
 	case "v0":
@@ -132,6 +163,11 @@ func (builder *RPCBuilder) Build(version string, limiter *ratelimit.RateLimiter)
 		for _, apiStruct := range builder.v0APIStruct {
 			permission.PermissionProxy(apiStruct, &fullNodeV0)
 		}
+		// the methods which did not change are served by the implementation of v1
+		fullNode := builder.fullNodeV1()
+		if _, missing := api.TranslateProxy(&fullNode, &fullNodeV0, v0Renames); len(missing) > 0 {
+			log.Debugf("v0 methods not implemented: %v", missing)
+		}
 
 		if limiter != nil {
 			var rateLimitAPI v0api.FullNodeStruct
@@ -146,11 +182,7 @@ func (builder *RPCBuilder) Build(version string, limiter *ratelimit.RateLimiter)
 		serverOptions = append(serverOptions, jsonrpc.WithReverseClient[v1api.EthSubscriberMethods](v1api.MethodNamespace))
 		server = jsonrpc.NewServer(serverOptions...)
 
-		var fullNode v1api.FullNodeStruct
-		for _, apiStruct := range builder.v1APIStruct {
-			permission.PermissionProxy(apiStruct, &fullNode)
-		}
-
+		fullNode := builder.fullNodeV1()
 		if limiter != nil {
 			var rateLimitAPI v1api.FullNodeStruct
 			limiter.WraperLimiter(fullNode, &rateLimitAPI)
@@ -168,6 +200,53 @@ func (builder *RPCBuilder) Build(version string, limiter *ratelimit.RateLimiter)
 	return server
 }
 
+func (builder *RPCBuilder) fullNodeV1() v1api.FullNodeStruct {
+	var fullNode v1api.FullNodeStruct
+	for _, apiStruct := range builder.v1APIStruct {
+		permission.PermissionProxy(apiStruct, &fullNode)
+	}
+	return fullNode
+}
+
+// tipSetKeyDecoder decodes a TipSetKey param, which is a json array of cids. A json object in its place is decoded
+// as a TipSetSelector and resolved to the key of the tipset it selects, the signatures of the apis are unchanged.
+func tipSetKeyDecoder(selectTipSet TipSetSelectorFunc) jsonrpc.ParamDecoder {
+	return func(ctx context.Context, data []byte) (reflect.Value, error) {
+		if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+			var tsk types.TipSetKey
+			if err := json.Unmarshal(data, &tsk); err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(tsk), nil
+		}
+
+		var tss types.TipSetSelector
+		if err := json.Unmarshal(data, &tss); err != nil {
+			return reflect.Value{}, fmt.Errorf("decoding tipset selector: %w", err)
+		}
+		if err := tss.Validate(); err != nil {
+			return reflect.Value{}, err
+		}
+		tsk, err := selectTipSet(ctx, tss)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("selecting tipset: %w", err)
+		}
+		return reflect.ValueOf(tsk), nil
+	}
+}
+
+// bigIntDecoder decodes a BigInt param, also a TokenAmount, with types.ParseBigInt: third-party clients send the
+// amounts in scientific notation or as json numbers, which the decoding of the big.Int of go-state-types refuses.
+// The decoders of go-jsonrpc are keyed by type, so it applies to every top level BigInt param of every api of both
+// versions, the amt of PaychGet and PaychFund today, the BigInt fields of the struct params are left to their types.
+func bigIntDecoder(_ context.Context, data []byte) (reflect.Value, error) {
+	bi, err := types.ParseBigInt(string(data))
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("decoding big int: %w", err)
+	}
+	return reflect.ValueOf(bi), nil
+}
+
 func aliasETHAPI(rpcServer *jsonrpc.RPCServer) {
 	// TODO: use reflect to automatically register all the eth aliases
 	rpcServer.AliasMethod("eth_accounts", "Filecoin.EthAccounts")
@@ -210,6 +289,10 @@ func aliasETHAPI(rpcServer *jsonrpc.RPCServer) {
 
 	rpcServer.AliasMethod("trace_block", "Filecoin.EthTraceBlock")
 	rpcServer.AliasMethod("trace_replayBlockTransactions", "Filecoin.EthTraceReplayBlockTransactions")
+	rpcServer.AliasMethod("trace_transaction", "Filecoin.EthTraceTransaction")
+
+	rpcServer.AliasMethod("debug_traceTransaction", "Filecoin.EthDebugTraceTransaction")
+	rpcServer.AliasMethod("debug_traceBlockByNumber", "Filecoin.EthDebugTraceBlockByNumber")
 
 	rpcServer.AliasMethod("net_version", "Filecoin.NetVersion")
 	rpcServer.AliasMethod("net_listening", "Filecoin.NetListening")
@@ -4,14 +4,24 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
 	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/api"
+	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/api/permission"
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/ipfs-force-community/sophon-auth/core"
 	"github.com/stretchr/testify/require"
 	"gotest.tools/assert"
 )
@@ -81,6 +91,136 @@ func TestJsonrpc(t *testing.T) {
 	assert.Equal(t, res.Result, "test")
 }
 
+func TestV0TranslatedFromV1(t *testing.T) {
+	tf.UnitTest(t)
+
+	builder := NewBuilder().NameSpace(v1api.MethodNamespace)
+	require.NoError(t, builder.AddService(&tmodule3{}))
+
+	testServ := httptest.NewServer(builder.Build("v0", nil))
+	defer testServ.Close()
+	var client v0api.FullNodeStruct
+	closer, err := jsonrpc.NewMergeClient(context.Background(), "ws://"+testServ.Listener.Addr().String(),
+		v1api.MethodNamespace, api.GetInternalStructs(&client), nil)
+	require.NoError(t, err)
+	defer closer()
+
+	// renamed in v1
+	entry, err := client.BeaconGetEntry(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, entry.Round, uint64(10))
+	// unchanged
+	name, err := client.StateNetworkName(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, name, types.NetworkName("test"))
+}
+
+func TestTipSetSelectorParam(t *testing.T) {
+	tf.UnitTest(t)
+
+	selected := types.NewTipSetKey(testhelpers.CidFromString(t, "selected"))
+	state := &mockState{}
+	builder := NewBuilder().NameSpace(v1api.MethodNamespace)
+	builder.SelectTipSetWith(func(ctx context.Context, tss types.TipSetSelector) (types.TipSetKey, error) {
+		if tss.Tag == nil || *tss.Tag != types.TipSetTagFinalized {
+			return types.EmptyTSK, fmt.Errorf("unexpected selector")
+		}
+		return selected, nil
+	})
+	require.NoError(t, builder.AddService(&tmodule4{state: state}))
+
+	testServ := httptest.NewServer(builder.Build("v1", nil))
+	defer testServ.Close()
+
+	call := func(param string) map[string]interface{} {
+		reqBytes := []byte(`{"jsonrpc":"2.0","id":1,"method":"Filecoin.StateNetworkVersion","params":[` + param + `]}`)
+		httpRes, err := http.Post("http://"+testServ.Listener.Addr().String(), "", bytes.NewReader(reqBytes))
+		require.NoError(t, err)
+		defer httpRes.Body.Close() // nolint
+		res := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(httpRes.Body).Decode(&res))
+		return res
+	}
+
+	// a plain key is passed through
+	other := types.NewTipSetKey(testhelpers.CidFromString(t, "other"))
+	param, err := json.Marshal(other)
+	require.NoError(t, err)
+	res := call(string(param))
+	assert.Assert(t, res["error"] == nil)
+	assert.Assert(t, state.tsk.Equals(other))
+
+	// a selector is resolved to the key of the tipset it selects
+	res = call(`{"Tag":"finalized"}`)
+	assert.Assert(t, res["error"] == nil)
+	assert.Assert(t, state.tsk.Equals(selected))
+
+	// an invalid selector is refused before the api is called
+	state.tsk = types.EmptyTSK
+	res = call(`{"Tag":"latest","Height":{"At":1}}`)
+	assert.Assert(t, res["error"] != nil)
+	assert.Assert(t, state.tsk.IsEmpty())
+}
+
+func TestBigIntParam(t *testing.T) {
+	tf.UnitTest(t)
+
+	paych := &mockPaych{}
+	builder := NewBuilder().NameSpace(v1api.MethodNamespace)
+	require.NoError(t, builder.AddService(&tmodule5{paych: paych}))
+
+	// the paych apis need the sign permission, which the auth middleware of the node puts in the context
+	server := builder.Build("v1", nil)
+	testServ := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.ServeHTTP(w, r.WithContext(core.CtxWithPerm(r.Context(), core.PermSign)))
+	}))
+	defer testServ.Close()
+
+	call := func(method, params string) map[string]interface{} {
+		reqBytes := []byte(`{"jsonrpc":"2.0","id":1,"method":"Filecoin.` + method + `","params":` + params + `}`)
+		httpRes, err := http.Post("http://"+testServ.Listener.Addr().String(), "", bytes.NewReader(reqBytes))
+		require.NoError(t, err)
+		defer httpRes.Body.Close() // nolint
+		res := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(httpRes.Body).Decode(&res))
+		return res
+	}
+
+	// every BigInt param of every api is decoded leniently, not only the one of PaychFund
+	for _, amt := range []string{`"1000000000000000000"`, `"1e18"`, `"1.0E18"`, `1e18`, `1000000000000000000`} {
+		paych.amt = types.EmptyInt
+		res := call("PaychFund", `["f01","f02",`+amt+`]`)
+		assert.Assert(t, res["error"] == nil, "%s: %v", amt, res["error"])
+		assert.Assert(t, paych.amt.Equals(types.NewInt(1e18)), amt)
+
+		paych.amt = types.EmptyInt
+		res = call("PaychGet", `["f01","f02",`+amt+`,{}]`)
+		assert.Assert(t, res["error"] == nil, "%s: %v", amt, res["error"])
+		assert.Assert(t, paych.amt.Equals(types.NewInt(1e18)), amt)
+	}
+
+	// the fractional amounts are refused rather than rounded
+	paych.amt = types.EmptyInt
+	res := call("PaychFund", `["f01","f02","1.5"]`)
+	assert.Assert(t, res["error"] != nil)
+	assert.Assert(t, paych.amt.Nil())
+}
+
+func TestBigIntDecoder(t *testing.T) {
+	tf.UnitTest(t)
+
+	for _, data := range []string{`"1000000000000000000"`, `"1e18"`, `"1.0E18"`, `1e18`, `1000000000000000000`} {
+		v, err := bigIntDecoder(context.Background(), []byte(data))
+		require.NoError(t, err, data)
+		assert.Assert(t, v.Interface().(types.BigInt).Equals(types.NewInt(1e18)), data)
+	}
+
+	for _, data := range []string{`"1.5"`, `"abc"`, `null`} {
+		_, err := bigIntDecoder(context.Background(), []byte(data))
+		require.Error(t, err, data)
+	}
+}
+
 type tmodule1 struct{}
 
 func (m *tmodule1) V0API() MockAPI1 { //nolint
@@ -124,6 +264,78 @@ func (m *mockAPI2) Test2(ctx context.Context) error {
 	return nil
 }
 
+type tmodule3 struct{}
+
+// tmodule3 implements its methods for v1 only
+func (m *tmodule3) V0API() struct{} { //nolint
+	return struct{}{}
+}
+
+func (m *tmodule3) API() *mockChain { //nolint
+	return &mockChain{}
+}
+
+type mockChain struct{}
+
+func (m *mockChain) StateNetworkName(ctx context.Context) (types.NetworkName, error) {
+	return "test", nil
+}
+
+func (m *mockChain) StateGetBeaconEntry(ctx context.Context, epoch abi.ChainEpoch) (*types.BeaconEntry, error) {
+	return &types.BeaconEntry{Round: uint64(epoch)}, nil
+}
+
+type tmodule4 struct {
+	state *mockState
+}
+
+// tmodule4 implements its methods for v1 only
+func (m *tmodule4) V0API() struct{} { //nolint
+	return struct{}{}
+}
+
+func (m *tmodule4) API() *mockState { //nolint
+	return m.state
+}
+
+// mockState records the tipset key it is called with
+type mockState struct {
+	tsk types.TipSetKey
+}
+
+func (m *mockState) StateNetworkVersion(ctx context.Context, tsk types.TipSetKey) (network.Version, error) {
+	m.tsk = tsk
+	return network.Version21, nil
+}
+
+type tmodule5 struct {
+	paych *mockPaych
+}
+
+// tmodule5 implements its methods for v1 only
+func (m *tmodule5) V0API() struct{} { //nolint
+	return struct{}{}
+}
+
+func (m *tmodule5) API() *mockPaych { //nolint
+	return m.paych
+}
+
+// mockPaych records the amount it is called with
+type mockPaych struct {
+	amt types.BigInt
+}
+
+func (m *mockPaych) PaychFund(ctx context.Context, from, to address.Address, amt types.BigInt) (*types.ChannelInfo, error) {
+	m.amt = amt
+	return &types.ChannelInfo{}, nil
+}
+
+func (m *mockPaych) PaychGet(ctx context.Context, from, to address.Address, amt types.BigInt, opts types.PaychGetOpts) (*types.ChannelInfo, error) {
+	m.amt = amt
+	return &types.ChannelInfo{}, nil
+}
+
 type FullAdapter struct {
 	CommonAdapter
 	Adapter2
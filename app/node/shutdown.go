@@ -0,0 +1,178 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultShutdownTimeout bounds the teardown of a component, a component still running after it is left behind so
+// that the others are torn down
+const defaultShutdownTimeout = 30 * time.Second
+
+// The shutdown order of the components, the api server first so that no request reaches a component being torn
+// down, the repo last so that the writes of the others are flushed
+const (
+	shutdownOrderAPI = iota
+	shutdownOrderServices
+	shutdownOrderMpool
+	shutdownOrderSyncer
+	shutdownOrderNetwork
+	shutdownOrderChain
+	shutdownOrderRepo
+	shutdownOrderObservability
+)
+
+// ShutdownState is the state of a component during the shutdown
+type ShutdownState string
+
+const (
+	ShutdownPending  ShutdownState = "pending"
+	ShutdownStopping ShutdownState = "stopping"
+	ShutdownDone     ShutdownState = "done"
+	ShutdownFailed   ShutdownState = "failed"
+	ShutdownTimeout  ShutdownState = "timeout"
+)
+
+// ComponentShutdown is the teardown of a component
+type ComponentShutdown struct {
+	Name  string
+	State ShutdownState
+	Took  time.Duration
+	Error string `json:",omitempty"`
+}
+
+// ShutdownProgress reports the teardown of the components in their shutdown order
+type ShutdownProgress struct {
+	Started    time.Time
+	Finished   time.Time
+	Components []ComponentShutdown
+}
+
+// Done checks whether every component is torn down, some may have failed or timed out
+func (sp ShutdownProgress) Done() bool {
+	return !sp.Finished.IsZero()
+}
+
+type shutdownComponent struct {
+	name    string
+	order   int
+	timeout time.Duration
+	stop    func(context.Context) error
+}
+
+// shutdownManager tears down the registered components one at a time, by order then registration
+type shutdownManager struct {
+	lk         sync.Mutex
+	components []*shutdownComponent
+	progress   ShutdownProgress
+	once       sync.Once
+	done       chan struct{}
+}
+
+func newShutdownManager() *shutdownManager {
+	return &shutdownManager{done: make(chan struct{})}
+}
+
+// register adds a component torn down at order, a timeout of 0 is the default one
+func (sm *shutdownManager) register(name string, order int, timeout time.Duration, stop func(context.Context) error) {
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+
+	sm.lk.Lock()
+	defer sm.lk.Unlock()
+	sm.components = append(sm.components, &shutdownComponent{name: name, order: order, timeout: timeout, stop: stop})
+}
+
+// Shutdown tears down the components, the calls after the first one wait for it to finish
+func (sm *shutdownManager) Shutdown(ctx context.Context) ShutdownProgress {
+	sm.once.Do(func() {
+		defer close(sm.done)
+		sm.shutdown(ctx)
+	})
+
+	select {
+	case <-sm.done:
+	case <-ctx.Done():
+	}
+	return sm.Progress()
+}
+
+func (sm *shutdownManager) shutdown(ctx context.Context) {
+	sm.lk.Lock()
+	components := append([]*shutdownComponent{}, sm.components...)
+	sort.SliceStable(components, func(i, j int) bool {
+		return components[i].order < components[j].order
+	})
+	sm.progress = ShutdownProgress{Started: time.Now(), Components: make([]ComponentShutdown, len(components))}
+	for i, c := range components {
+		sm.progress.Components[i] = ComponentShutdown{Name: c.name, State: ShutdownPending}
+	}
+	sm.lk.Unlock()
+
+	for i, c := range components {
+		log.Infof("shutting down %s ...", c.name)
+		sm.update(i, ShutdownStopping, 0, nil)
+
+		start := time.Now()
+		state, err := c.run(ctx)
+		sm.update(i, state, time.Since(start), err)
+		if err != nil {
+			log.Warnf("shutting down %s: %s", c.name, err)
+		}
+	}
+
+	sm.lk.Lock()
+	sm.progress.Finished = time.Now()
+	sm.lk.Unlock()
+}
+
+func (c *shutdownComponent) run(ctx context.Context) (ShutdownState, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errCh <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		errCh <- c.stop(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			return ShutdownFailed, err
+		}
+		return ShutdownDone, nil
+	case <-ctx.Done():
+		return ShutdownTimeout, fmt.Errorf("not stopped after %s: %w", c.timeout, ctx.Err())
+	}
+}
+
+func (sm *shutdownManager) update(i int, state ShutdownState, took time.Duration, err error) {
+	sm.lk.Lock()
+	defer sm.lk.Unlock()
+
+	cs := &sm.progress.Components[i]
+	cs.State = state
+	cs.Took = took
+	if err != nil {
+		cs.Error = err.Error()
+	}
+}
+
+// Progress returns a copy of the progress of the shutdown, empty before it starts
+func (sm *shutdownManager) Progress() ShutdownProgress {
+	sm.lk.Lock()
+	defer sm.lk.Unlock()
+
+	progress := sm.progress
+	progress.Components = append([]ComponentShutdown(nil), sm.progress.Components...)
+	return progress
+}
@@ -0,0 +1,70 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShutdownManager(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	var lk sync.Mutex
+	var order []string
+	stop := func(name string, err error) func(context.Context) error {
+		return func(context.Context) error {
+			lk.Lock()
+			defer lk.Unlock()
+			order = append(order, name)
+			return err
+		}
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+
+	sm := newShutdownManager()
+	sm.register("repo", shutdownOrderRepo, 0, stop("repo", nil))
+	sm.register("syncer", shutdownOrderSyncer, 0, stop("syncer", errors.New("still syncing")))
+	sm.register("api", shutdownOrderAPI, 0, stop("api", nil))
+	sm.register("mpool", shutdownOrderMpool, 0, stop("mpool", nil))
+	sm.register("stuck", shutdownOrderChain, 10*time.Millisecond, func(context.Context) error {
+		<-release
+		return nil
+	})
+	sm.register("panic", shutdownOrderChain, 0, func(context.Context) error {
+		panic("boom")
+	})
+	require.False(t, sm.Progress().Done())
+
+	progress := sm.Shutdown(ctx)
+	require.True(t, progress.Done())
+	require.Equal(t, []string{"api", "mpool", "syncer", "repo"}, order)
+
+	states := map[string]ShutdownState{}
+	var names []string
+	for _, c := range progress.Components {
+		states[c.Name] = c.State
+		names = append(names, c.Name)
+	}
+	require.Equal(t, []string{"api", "mpool", "syncer", "stuck", "panic", "repo"}, names)
+	require.Equal(t, map[string]ShutdownState{
+		"api":    ShutdownDone,
+		"mpool":  ShutdownDone,
+		"syncer": ShutdownFailed,
+		"stuck":  ShutdownTimeout,
+		"panic":  ShutdownFailed,
+		"repo":   ShutdownDone,
+	}, states)
+	require.Equal(t, "still syncing", progress.Components[2].Error)
+
+	// the components are torn down once
+	require.Equal(t, progress, sm.Shutdown(ctx))
+	require.Len(t, order, 4)
+}
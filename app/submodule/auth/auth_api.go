@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	"github.com/google/uuid"
+
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var _ v1api.IAuth = &authAPI{}
+
+type authAPI struct { //nolint
+	auth *AuthSubmodule
+}
+
+// AuthNew issues a new token carrying `perms` which never expires, it is named after a random uuid
+func (a *authAPI) AuthNew(ctx context.Context, perms []auth.Permission) ([]byte, error) {
+	return a.auth.NewToken(ctx, "token-"+uuid.NewString(), perms, 0)
+}
+
+// AuthNewNamed issues a new token named `name` carrying `perms`, an `expiry` of zero means the token never expires
+func (a *authAPI) AuthNewNamed(ctx context.Context, name string, perms []auth.Permission, expiry time.Duration) ([]byte, error) {
+	return a.auth.NewToken(ctx, name, perms, expiry)
+}
+
+// AuthVerify returns the permissions carried by the token, revoked or expired tokens are rejected
+func (a *authAPI) AuthVerify(ctx context.Context, token string) ([]auth.Permission, error) {
+	return a.auth.VerifyToken(ctx, token)
+}
+
+// AuthList lists all the tokens issued by the node, including revoked ones
+func (a *authAPI) AuthList(ctx context.Context) ([]*types.AuthTokenInfo, error) {
+	return a.auth.ListTokens(ctx)
+}
+
+// AuthRevoke revokes the token named `name`, it will be rejected by all subsequent requests
+func (a *authAPI) AuthRevoke(ctx context.Context, name string) error {
+	return a.auth.RevokeToken(ctx, name)
+}
@@ -0,0 +1,325 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	jwt3 "github.com/gbrlsnchs/jwt/v3"
+	"github.com/ipfs-force-community/sophon-auth/config"
+	"github.com/ipfs-force-community/sophon-auth/core"
+	"github.com/ipfs-force-community/sophon-auth/jwtclient"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/repo/fskeystore"
+	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var log = logging.Logger("auth")
+
+const (
+	// jwtSecretKey is the name of the hmac secret in the repo keystore
+	jwtSecretKey = "jwt-secret"
+
+	// DefaultAdminTokenName is the name of the admin token written to the repo on start. It is tracked by the
+	// token store like the other tokens, a revoked admin token is replaced by a new one named after it on the next
+	// start.
+	DefaultAdminTokenName = "admin-token"
+)
+
+var (
+	ErrTokenNotFound = errors.New("token not found")
+	ErrTokenRevoked  = errors.New("token has been revoked")
+	ErrTokenExpired  = errors.New("token has expired")
+)
+
+var _ jwtclient.IJwtAuthClient = (*AuthSubmodule)(nil)
+
+// jwtPayload is the payload of tokens issued by the node, `name` is kept compatible with sophon-auth
+// so that the caller can be identified from the token
+type jwtPayload struct {
+	Name  string            `json:"name"`
+	Perms []auth.Permission `json:"perms"`
+}
+
+// AuthSubmodule issues, verifies and revokes the api tokens of the node
+type AuthSubmodule struct { //nolint
+	alg *jwt3.HMACSHA
+
+	lk sync.Mutex
+	ds datastore.Batching
+}
+
+// NewAuthSubmodule loads the jwt secret from the keystore, a new one is generated if it does not exist yet
+func NewAuthSubmodule(ctx context.Context, r repo.Repo) (*AuthSubmodule, error) {
+	secret, err := loadOrCreateSecret(r.Keystore())
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthSubmodule{
+		alg: jwt3.NewHS256(secret),
+		ds:  namespace.Wrap(r.MetaDatastore(), datastore.NewKey("/auth/tokens")),
+	}, nil
+}
+
+func loadOrCreateSecret(ks fskeystore.Keystore) ([]byte, error) {
+	secret, err := ks.Get(jwtSecretKey)
+	if err == nil {
+		return secret, nil
+	}
+	if !errors.Is(err, fskeystore.ErrNoSuchKey) {
+		return nil, fmt.Errorf("failed to get jwt secret from keystore: %w", err)
+	}
+
+	secret, err = config.RandSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := ks.Put(jwtSecretKey, secret); err != nil {
+		return nil, fmt.Errorf("failed to save jwt secret: %w", err)
+	}
+
+	return secret, nil
+}
+
+// DefaultAdminToken signs the token used by the local cli, it is the admin token of the token store which is
+// neither revoked nor expired, a new one is recorded when there is none
+func (a *AuthSubmodule) DefaultAdminToken(ctx context.Context) ([]byte, error) {
+	a.lk.Lock()
+	defer a.lk.Unlock()
+
+	infos, err := a.ListTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var admin *types.AuthTokenInfo
+	reserved := false
+	now := time.Now()
+	for _, info := range infos {
+		if !isAdminTokenName(info.Name) {
+			continue
+		}
+		reserved = reserved || info.Name == DefaultAdminTokenName
+		if !info.Revoked && !info.Expired(now) {
+			admin = info
+			break
+		}
+	}
+	if admin == nil {
+		admin = &types.AuthTokenInfo{
+			Name:      DefaultAdminTokenName,
+			Perms:     toAuthPerms(core.AdaptOldStrategy(core.PermAdmin)),
+			CreatedAt: now,
+		}
+		if reserved {
+			// the jwt of a name is always the same, a revoked admin token is replaced under a new name
+			admin.Name = fmt.Sprintf("%s-%d", DefaultAdminTokenName, now.UnixNano())
+		}
+		if err := a.putInfo(ctx, admin); err != nil {
+			return nil, err
+		}
+		log.Infof("issue admin token %s", admin.Name)
+	}
+
+	return jwt3.Sign(&jwtPayload{Name: admin.Name, Perms: admin.Perms}, a.alg)
+}
+
+func isAdminTokenName(name string) bool {
+	return name == DefaultAdminTokenName || strings.HasPrefix(name, DefaultAdminTokenName+"-")
+}
+
+// NewToken issues a token and records it in the token store
+func (a *AuthSubmodule) NewToken(ctx context.Context, name string, perms []auth.Permission, expiry time.Duration) ([]byte, error) {
+	if len(name) == 0 {
+		return nil, errors.New("token name is required")
+	}
+	if strings.Contains(name, "/") {
+		return nil, fmt.Errorf("token name %s must not contain '/'", name)
+	}
+	if isAdminTokenName(name) {
+		return nil, fmt.Errorf("token name %s is reserved", name)
+	}
+	if len(perms) == 0 {
+		return nil, errors.New("at least one permission is required")
+	}
+	for _, perm := range perms {
+		if !core.IsValid(string(perm)) {
+			return nil, fmt.Errorf("unknown permission %s", perm)
+		}
+	}
+	if expiry < 0 {
+		return nil, fmt.Errorf("invalid expiry %s", expiry)
+	}
+
+	a.lk.Lock()
+	defer a.lk.Unlock()
+
+	has, err := a.ds.Has(ctx, datastore.NewKey(name))
+	if err != nil {
+		return nil, err
+	}
+	if has {
+		return nil, fmt.Errorf("token %s already exists", name)
+	}
+
+	info := &types.AuthTokenInfo{
+		Name:      name,
+		Perms:     perms,
+		CreatedAt: time.Now(),
+	}
+	if expiry > 0 {
+		info.ExpiresAt = info.CreatedAt.Add(expiry)
+	}
+
+	token, err := jwt3.Sign(&jwtPayload{Name: name, Perms: perms}, a.alg)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.putInfo(ctx, info); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// VerifyToken checks the signature of the token and that it is neither revoked nor expired
+func (a *AuthSubmodule) VerifyToken(ctx context.Context, token string) ([]auth.Permission, error) {
+	var payload jwtPayload
+	if _, err := jwt3.Verify([]byte(token), a.alg, &payload); err != nil {
+		return nil, fmt.Errorf("JWT Verification failed: %w", err)
+	}
+
+	info, err := a.getInfo(ctx, payload.Name)
+	if err != nil {
+		return nil, err
+	}
+	if info.Revoked {
+		return nil, ErrTokenRevoked
+	}
+	if info.Expired(time.Now()) {
+		return nil, ErrTokenExpired
+	}
+
+	return info.Perms, nil
+}
+
+// ListTokens returns all the tokens in the token store
+func (a *AuthSubmodule) ListTokens(ctx context.Context) ([]*types.AuthTokenInfo, error) {
+	res, err := a.ds.Query(ctx, query.Query{})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close() //nolint:errcheck
+
+	var out []*types.AuthTokenInfo
+	for r := range res.Next() {
+		if r.Error != nil {
+			return nil, r.Error
+		}
+
+		var info types.AuthTokenInfo
+		if err := json.Unmarshal(r.Value, &info); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal token info %s: %w", r.Key, err)
+		}
+		out = append(out, &info)
+	}
+
+	return out, nil
+}
+
+// RevokeToken marks the token as revoked, the record is kept so that the name can not be reused
+func (a *AuthSubmodule) RevokeToken(ctx context.Context, name string) error {
+	a.lk.Lock()
+	defer a.lk.Unlock()
+
+	info, err := a.getInfo(ctx, name)
+	if err != nil {
+		return err
+	}
+	if info.Revoked {
+		return nil
+	}
+	info.Revoked = true
+
+	log.Infof("revoke token %s", name)
+	return a.putInfo(ctx, info)
+}
+
+// Verify implements jwtclient.IJwtAuthClient, it returns the highest permission carried by the token
+func (a *AuthSubmodule) Verify(ctx context.Context, token string) (core.Permission, error) {
+	perms, err := a.VerifyToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+
+	var perm core.Permission
+	for i := len(core.PermArr) - 1; i >= 0 && len(perm) == 0; i-- {
+		for _, p := range perms {
+			if string(p) == core.PermArr[i] {
+				perm = core.PermArr[i]
+				break
+			}
+		}
+	}
+	if len(perm) == 0 {
+		return "", fmt.Errorf("token carries no valid permission")
+	}
+
+	return perm, nil
+}
+
+func (a *AuthSubmodule) getInfo(ctx context.Context, name string) (*types.AuthTokenInfo, error) {
+	data, err := a.ds.Get(ctx, datastore.NewKey(name))
+	if err != nil {
+		if errors.Is(err, datastore.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, name)
+		}
+		return nil, err
+	}
+
+	var info types.AuthTokenInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token info %s: %w", name, err)
+	}
+
+	return &info, nil
+}
+
+func (a *AuthSubmodule) putInfo(ctx context.Context, info *types.AuthTokenInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	return a.ds.Put(ctx, datastore.NewKey(info.Name), data)
+}
+
+func toAuthPerms(perms []core.Permission) []auth.Permission {
+	out := make([]auth.Permission, 0, len(perms))
+	for _, perm := range perms {
+		out = append(out, auth.Permission(perm))
+	}
+	return out
+}
+
+// API create a new auth implement
+func (a *AuthSubmodule) API() v1api.IAuth {
+	return &authAPI{auth: a}
+}
+
+func (a *AuthSubmodule) V0API() v0api.IAuth {
+	return &authAPI{auth: a}
+}
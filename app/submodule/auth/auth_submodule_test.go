@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	jwt3 "github.com/gbrlsnchs/jwt/v3"
+	"github.com/ipfs-force-community/sophon-auth/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/repo"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestAuthTokenLifecycle(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	r := repo.NewInMemoryRepo()
+	a, err := NewAuthSubmodule(ctx, r)
+	require.NoError(t, err)
+
+	perms := []auth.Permission{core.PermRead, core.PermWrite}
+	token, err := a.NewToken(ctx, "test", perms, 0)
+	require.NoError(t, err)
+
+	_, err = a.NewToken(ctx, "test", perms, 0)
+	assert.Error(t, err)
+	_, err = a.NewToken(ctx, DefaultAdminTokenName, perms, 0)
+	assert.Error(t, err)
+	_, err = a.NewToken(ctx, "bad-perm", []auth.Permission{"root"}, 0)
+	assert.Error(t, err)
+
+	got, err := a.VerifyToken(ctx, string(token))
+	require.NoError(t, err)
+	assert.Equal(t, perms, got)
+
+	perm, err := a.Verify(ctx, string(token))
+	require.NoError(t, err)
+	assert.Equal(t, core.PermWrite, perm)
+
+	// tokens survive a restart as long as the repo is kept
+	a2, err := NewAuthSubmodule(ctx, r)
+	require.NoError(t, err)
+	_, err = a2.VerifyToken(ctx, string(token))
+	require.NoError(t, err)
+
+	infos, err := a.ListTokens(ctx)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, "test", infos[0].Name)
+	assert.False(t, infos[0].Revoked)
+
+	require.NoError(t, a.RevokeToken(ctx, "test"))
+	_, err = a.VerifyToken(ctx, string(token))
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+	assert.ErrorIs(t, a.RevokeToken(ctx, "unknown"), ErrTokenNotFound)
+
+	_, err = a.NewToken(ctx, DefaultAdminTokenName+"-1", perms, 0)
+	assert.Error(t, err)
+}
+
+func TestAuthDefaultAdminToken(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	r := repo.NewInMemoryRepo()
+	a, err := NewAuthSubmodule(ctx, r)
+	require.NoError(t, err)
+
+	adminToken, err := a.DefaultAdminToken(ctx)
+	require.NoError(t, err)
+	perm, err := a.Verify(ctx, string(adminToken))
+	require.NoError(t, err)
+	assert.Equal(t, core.PermAdmin, perm)
+
+	// the same token is written on every start while it is valid
+	a2, err := NewAuthSubmodule(ctx, r)
+	require.NoError(t, err)
+	again, err := a2.DefaultAdminToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, adminToken, again)
+
+	// a revoked admin token is rejected and replaced by a new one
+	require.NoError(t, a.RevokeToken(ctx, DefaultAdminTokenName))
+	_, err = a.VerifyToken(ctx, string(adminToken))
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+
+	renewed, err := a.DefaultAdminToken(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, adminToken, renewed)
+	perm, err = a.Verify(ctx, string(renewed))
+	require.NoError(t, err)
+	assert.Equal(t, core.PermAdmin, perm)
+	_, err = a.VerifyToken(ctx, string(adminToken))
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+
+	infos, err := a.ListTokens(ctx)
+	require.NoError(t, err)
+	assert.Len(t, infos, 2)
+}
+
+func TestAuthTokenExpiry(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	a, err := NewAuthSubmodule(ctx, repo.NewInMemoryRepo())
+	require.NoError(t, err)
+
+	token, err := a.NewToken(ctx, "short-lived", []auth.Permission{core.PermRead}, time.Millisecond)
+	require.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+	_, err = a.VerifyToken(ctx, string(token))
+	assert.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func TestAuthNew(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	a, err := NewAuthSubmodule(ctx, repo.NewInMemoryRepo())
+	require.NoError(t, err)
+	api := a.API()
+
+	// the tokens issued like lotus does are recorded under a generated name, and can be revoked
+	perms := []auth.Permission{core.PermRead}
+	t1, err := api.AuthNew(ctx, perms)
+	require.NoError(t, err)
+	t2, err := api.AuthNew(ctx, perms)
+	require.NoError(t, err)
+	assert.NotEqual(t, t1, t2)
+
+	got, err := api.AuthVerify(ctx, string(t1))
+	require.NoError(t, err)
+	assert.Equal(t, perms, got)
+
+	infos, err := api.AuthList(ctx)
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	assert.True(t, infos[0].ExpiresAt.IsZero())
+
+	var payload jwtPayload
+	_, err = jwt3.Verify(t1, a.alg, &payload)
+	require.NoError(t, err)
+	require.NoError(t, api.AuthRevoke(ctx, payload.Name))
+	_, err = api.AuthVerify(ctx, string(t1))
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+	_, err = api.AuthVerify(ctx, string(t2))
+	assert.NoError(t, err)
+}
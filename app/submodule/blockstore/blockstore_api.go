@@ -3,16 +3,12 @@ package blockstore
 import (
 	"context"
 	"fmt"
-	"sync"
 
+	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/venus-shared/types"
 
-	"github.com/ipfs/boxo/blockservice"
-	offline "github.com/ipfs/boxo/exchange/offline"
-	"github.com/ipfs/boxo/ipld/merkledag"
 	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
-	ipld "github.com/ipfs/go-ipld-format"
 
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 )
@@ -32,8 +28,12 @@ func (blockstoreAPI *blockstoreAPI) ChainReadObj(ctx context.Context, ocid cid.C
 	return blk.RawData(), nil
 }
 
+// ChainDeleteObj deletes the object from the blockstore, freeing its quota when it was staged
 func (blockstoreAPI *blockstoreAPI) ChainDeleteObj(ctx context.Context, obj cid.Cid) error {
-	return blockstoreAPI.blockstore.Blockstore.DeleteBlock(ctx, obj)
+	if err := blockstoreAPI.blockstore.Blockstore.DeleteBlock(ctx, obj); err != nil {
+		return err
+	}
+	return blockstoreAPI.blockstore.staged.deleted(ctx, obj)
 }
 
 func (blockstoreAPI *blockstoreAPI) ChainHasObj(ctx context.Context, obj cid.Cid) (bool, error) {
@@ -41,57 +41,68 @@ func (blockstoreAPI *blockstoreAPI) ChainHasObj(ctx context.Context, obj cid.Cid
 }
 
 func (blockstoreAPI *blockstoreAPI) ChainStatObj(ctx context.Context, obj cid.Cid, base cid.Cid) (types.ObjStat, error) {
-	bs := blockstoreAPI.blockstore.Blockstore
-	bsvc := blockservice.New(bs, offline.Exchange(bs))
+	stats, err := chain.StatObj(ctx, blockstoreAPI.blockstore.Blockstore, obj, base, 0)
+	if err != nil {
+		return types.ObjStat{}, err
+	}
+	return stats.ObjStat, nil
+}
 
-	dag := merkledag.NewDAGService(bsvc)
+// ChainStatObjWithDepth is like ChainStatObj, but does not follow the links deeper than maxDepth, 0 is unlimited
+func (blockstoreAPI *blockstoreAPI) ChainStatObjWithDepth(ctx context.Context, obj cid.Cid, base cid.Cid, maxDepth uint64) (types.ObjStatWithDepth, error) {
+	return chain.StatObj(ctx, blockstoreAPI.blockstore.Blockstore, obj, base, maxDepth)
+}
 
-	seen := cid.NewSet()
+// ChainPutObj stages the block in the blockstore, see ChainStageObj
+func (blockstoreAPI *blockstoreAPI) ChainPutObj(ctx context.Context, blk blocks.Block) error {
+	return blockstoreAPI.blockstore.staged.put(ctx, blockstoreAPI.blockstore.Blockstore, blk)
+}
 
-	var statslk sync.Mutex
-	var stats types.ObjStat
-	collect := true
+// ChainStageObj puts the data of the object in the blockstore, the data must hash to the cid. The objects the
+// blockstore did not have count against the staged object quota until they are deleted.
+func (blockstoreAPI *blockstoreAPI) ChainStageObj(ctx context.Context, obj cid.Cid, data []byte) error {
+	blk, err := blocks.NewBlockWithCid(data, obj)
+	if err != nil {
+		return err
+	}
+	return blockstoreAPI.blockstore.staged.put(ctx, blockstoreAPI.blockstore.Blockstore, blk)
+}
 
-	walker := func(ctx context.Context, c cid.Cid) ([]*ipld.Link, error) {
-		if c.Prefix().Codec == cid.FilCommitmentSealed || c.Prefix().Codec == cid.FilCommitmentUnsealed {
-			return []*ipld.Link{}, nil
-		}
+// ChainStagedObjs counts the objects staged in the blockstore
+func (blockstoreAPI *blockstoreAPI) ChainStagedObjs(ctx context.Context) (*types.StagedObjStat, error) {
+	return blockstoreAPI.blockstore.staged.stat(), nil
+}
 
-		nd, err := dag.Get(ctx, c)
-		if err != nil {
-			return nil, err
-		}
+func (blockstoreAPI *blockstoreAPI) PutMany(ctx context.Context, blocks []blocks.Block) error {
+	return blockstoreAPI.blockstore.Blockstore.PutMany(ctx, blocks)
+}
 
-		if collect {
-			s := uint64(len(nd.RawData()))
-			statslk.Lock()
-			stats.Size = stats.Size + s
-			stats.Links = stats.Links + 1
-			statslk.Unlock()
-		}
+// DatastoreGC runs a value log garbage collection of the blockstore and reports the space reclaimed
+func (blockstoreAPI *blockstoreAPI) DatastoreGC(ctx context.Context) (*types.DatastoreGCResult, error) {
+	return blockstoreAPI.blockstore.CollectGarbage(ctx)
+}
 
-		return nd.Links(), nil
+// DatastoreScrub hashes every block of the blockstore again to find the corrupt ones, and sends its progress until
+// it is done, the last progress has the error of the scrub
+func (blockstoreAPI *blockstoreAPI) DatastoreScrub(ctx context.Context, opts *types.DatastoreScrubOptions) (<-chan *types.DatastoreScrubProgress, error) {
+	if opts == nil {
+		opts = &types.DatastoreScrubOptions{}
 	}
-
-	if base != cid.Undef {
-		collect = false
-		if err := merkledag.Walk(ctx, walker, base, seen.Visit, merkledag.Concurrent()); err != nil {
-			return types.ObjStat{}, err
+	out := make(chan *types.DatastoreScrubProgress, 16)
+	send := func(p *types.DatastoreScrubProgress) {
+		select {
+		case out <- p:
+		case <-ctx.Done():
 		}
-		collect = true
 	}
+	go func() {
+		defer close(out)
 
-	if err := merkledag.Walk(ctx, walker, obj, seen.Visit, merkledag.Concurrent()); err != nil {
-		return types.ObjStat{}, err
-	}
-
-	return stats, nil
-}
-
-func (blockstoreAPI *blockstoreAPI) ChainPutObj(ctx context.Context, blk blocks.Block) error {
-	return blockstoreAPI.blockstore.Blockstore.Put(ctx, blk)
-}
-
-func (blockstoreAPI *blockstoreAPI) PutMany(ctx context.Context, blocks []blocks.Block) error {
-	return blockstoreAPI.blockstore.Blockstore.PutMany(ctx, blocks)
+		res, err := blockstoreAPI.blockstore.Scrub(ctx, opts, send)
+		if res == nil {
+			res = &types.DatastoreScrubProgress{Done: true, Error: err.Error()}
+		}
+		send(res)
+	}()
+	return out, nil
 }
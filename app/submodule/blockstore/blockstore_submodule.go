@@ -2,10 +2,43 @@ package blockstore
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"time"
 
+	"github.com/ipfs-force-community/metrics"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/repo"
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var log = logging.Logger("blockstore")
+
+var (
+	gcRuns       = metrics.NewCounter("blockstore/gc_runs", "Number of value log garbage collections of the blockstore")
+	gcReclaimed  = metrics.NewInt64("blockstore/gc_reclaimed", "Bytes reclaimed by the last value log garbage collection", "By")
+	valueLogSize = metrics.NewInt64("blockstore/value_log_size", "Bytes of the value log files after the last garbage collection", "By")
+
+	scrubRuns     = metrics.NewCounter("blockstore/scrub_runs", "Number of scrubs of the blockstore")
+	scrubChecked  = metrics.NewInt64("blockstore/scrub_checked", "Number of blocks hashed again by the last scrub", "")
+	scrubCorrupt  = metrics.NewInt64("blockstore/scrub_corrupt", "Number of corrupt blocks found by the last scrub", "")
+	scrubRepaired = metrics.NewInt64("blockstore/scrub_repaired", "Number of corrupt blocks replaced by the last scrub", "")
+)
+
+const (
+	// scrubProgressEvery is the number of blocks checked between the progresses of a scrub
+	scrubProgressEvery = 10000
+	// scrubFetchTimeout bounds the fetch of a corrupt block from the peers
+	scrubFetchTimeout = time.Minute
 )
 
 // BlockstoreSubmodule enhances the `Node` with local key/value storing capabilities.
@@ -15,6 +48,15 @@ import (
 type BlockstoreSubmodule struct { //nolint
 	// blockstore is the un-networked blocks interface
 	Blockstore blockstoreutil.Blockstore
+
+	cfg    *config.DatastoreConfig
+	staged *stagedObjs
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+
+	// fetch gets a block from the peers, to repair the corrupt blocks found by a scrub
+	fetch   func(context.Context, cid.Cid) (blocks.Block, error)
+	scrubLk sync.Mutex
 }
 
 type blockstoreRepo interface {
@@ -25,12 +67,180 @@ type blockstoreRepo interface {
 func NewBlockstoreSubmodule(ctx context.Context, repo blockstoreRepo) (*BlockstoreSubmodule, error) {
 	// set up block store
 	bs := repo.Repo().Datastore()
+	cfg := repo.Repo().Config().Datastore
+	staged, err := newStagedObjs(ctx, namespace.Wrap(repo.Repo().MetaDatastore(), datastore.NewKey("/blockstore/staged")), cfg.StagedObjQuota)
+	if err != nil {
+		return nil, err
+	}
 	return &BlockstoreSubmodule{
 		Blockstore: bs,
+		cfg:        cfg,
+		staged:     staged,
+	}, nil
+}
+
+// Start runs the value log garbage collection of the blockstore every GCInterval of the config, and the scrub of
+// the blockstore every ScrubInterval
+func (bsm *BlockstoreSubmodule) Start(ctx context.Context) error {
+	ctx, bsm.cancel = context.WithCancel(ctx)
+
+	if interval := time.Duration(bsm.cfg.GCInterval); interval > 0 {
+		if _, ok := bsm.badger(); ok {
+			bsm.every(ctx, interval, func() error {
+				_, err := bsm.CollectGarbage(ctx)
+				return err
+			}, "blockstore garbage collection")
+		} else {
+			log.Warnf("the blockstore does not support garbage collection, ignoring the gc interval")
+		}
+	}
+
+	if interval := time.Duration(bsm.cfg.ScrubInterval); interval > 0 {
+		opts := &types.DatastoreScrubOptions{BytesPerSecond: bsm.cfg.ScrubRate, Repair: bsm.cfg.ScrubRepair}
+		bsm.every(ctx, interval, func() error {
+			_, err := bsm.Scrub(ctx, opts, nil)
+			return err
+		}, "blockstore scrub")
+	}
+	return nil
+}
+
+// every runs f every interval until ctx is done
+func (bsm *BlockstoreSubmodule) every(ctx context.Context, interval time.Duration, f func() error, name string) {
+	bsm.done.Add(1)
+	go func() {
+		defer bsm.done.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := f(); err != nil && ctx.Err() == nil {
+					log.Errorf("%s: %s", name, err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop interrupts the garbage collection and the scrub running
+func (bsm *BlockstoreSubmodule) Stop() {
+	if bsm.cancel != nil {
+		bsm.cancel()
+	}
+	bsm.done.Wait()
+}
+
+// SetFetcher sets how the blocks are fetched from the peers to repair the corrupt ones
+func (bsm *BlockstoreSubmodule) SetFetcher(fetch func(context.Context, cid.Cid) (blocks.Block, error)) {
+	bsm.fetch = fetch
+}
+
+// Scrub hashes every block of the blockstore again, progress is called along the way. It fails when another scrub
+// is running.
+func (bsm *BlockstoreSubmodule) Scrub(ctx context.Context, opts *types.DatastoreScrubOptions, progress func(*types.DatastoreScrubProgress)) (*types.DatastoreScrubProgress, error) {
+	if !bsm.scrubLk.TryLock() {
+		return nil, fmt.Errorf("a scrub of the blockstore is running")
+	}
+	defer bsm.scrubLk.Unlock()
+
+	scrubOpts := blockstoreutil.ScrubOptions{
+		BytesPerSecond: opts.BytesPerSecond,
+		ProgressEvery:  scrubProgressEvery,
+	}
+	if opts.Repair {
+		if bsm.fetch == nil {
+			return nil, fmt.Errorf("the blocks can't be fetched from the peers to repair them")
+		}
+		scrubOpts.Fetch = func(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+			ctx, cancel := context.WithTimeout(ctx, scrubFetchTimeout)
+			defer cancel()
+			return bsm.fetch(ctx, c)
+		}
+	}
+	if progress != nil {
+		scrubOpts.Progress = func(res *blockstoreutil.ScrubResult) {
+			progress(toScrubProgress(res))
+		}
+	}
+
+	log.Infow("blockstore scrub started", "rate", opts.BytesPerSecond, "repair", opts.Repair)
+	res, err := blockstoreutil.Scrub(ctx, bsm.Blockstore, scrubOpts)
+	if res == nil {
+		return nil, err
+	}
+	out := toScrubProgress(res)
+	out.Done = true
+	if err != nil {
+		out.Error = err.Error()
+	}
+
+	scrubRuns.Tick(ctx)
+	scrubChecked.Set(ctx, res.Checked)
+	scrubCorrupt.Set(ctx, res.Corrupt)
+	scrubRepaired.Set(ctx, res.Repaired)
+	if res.Corrupt > 0 {
+		log.Errorw("blockstore scrub found corrupt blocks", "checked", res.Checked, "corrupt", res.Corrupt,
+			"repaired", res.Repaired, "cids", res.CorruptCids, "took", res.Duration)
+	} else {
+		log.Infow("blockstore scrub", "checked", res.Checked, "bytes", res.Bytes, "took", res.Duration)
+	}
+	return out, err
+}
+
+func toScrubProgress(res *blockstoreutil.ScrubResult) *types.DatastoreScrubProgress {
+	return &types.DatastoreScrubProgress{
+		Checked:     res.Checked,
+		Bytes:       res.Bytes,
+		Largest:     res.Largest,
+		Skipped:     res.Skipped,
+		Corrupt:     res.Corrupt,
+		CorruptCids: append([]cid.Cid(nil), res.CorruptCids...),
+		Repaired:    res.Repaired,
+		Duration:    res.Duration,
+	}
+}
+
+// badger returns the badger blockstore under the blockstore, the hot one when the chain offload is enabled
+func (bsm *BlockstoreSubmodule) badger() (*blockstoreutil.BadgerBlockstore, bool) {
+	bs := bsm.Blockstore
+	if offload, ok := bs.(*blockstoreutil.OffloadBlockstore); ok {
+		bs = offload.Hot()
+	}
+	badger, ok := bs.(*blockstoreutil.BadgerBlockstore)
+	return badger, ok
+}
+
+// CollectGarbage runs a value log garbage collection of the blockstore
+func (bsm *BlockstoreSubmodule) CollectGarbage(ctx context.Context) (*types.DatastoreGCResult, error) {
+	bs, ok := bsm.badger()
+	if !ok {
+		return nil, fmt.Errorf("the blockstore does not support garbage collection")
+	}
+
+	res, err := bs.CollectGarbage(ctx, bsm.cfg.GCDiscardRatio)
+	if err != nil {
+		return nil, err
+	}
+	gcRuns.Tick(ctx)
+	gcReclaimed.Set(ctx, res.Reclaimed)
+	valueLogSize.Set(ctx, res.SizeAfter)
+	log.Infow("blockstore garbage collection", "rewritten", res.Rewritten, "reclaimed", res.Reclaimed,
+		"size", res.SizeAfter, "took", res.Duration)
+
+	return &types.DatastoreGCResult{
+		Rewritten:  res.Rewritten,
+		SizeBefore: res.SizeBefore,
+		SizeAfter:  res.SizeAfter,
+		Reclaimed:  res.Reclaimed,
+		Duration:   res.Duration,
 	}, nil
 }
 
-func (bsm *BlockstoreSubmodule) API() v0api.IBlockStore {
+func (bsm *BlockstoreSubmodule) API() v1api.IBlockStore {
 	return &blockstoreAPI{blockstore: bsm}
 }
 
@@ -0,0 +1,117 @@
+package blockstore
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// stagedObjs records the objects put in the blockstore through the api, so that they count against the quota until
+// they are deleted. The objects the blockstore had already, such as the chain ones, are not staged.
+type stagedObjs struct {
+	ds    datastore.Datastore
+	quota int64
+
+	lk    sync.Mutex
+	count int64
+	bytes int64
+}
+
+func newStagedObjs(ctx context.Context, ds datastore.Datastore, quota int64) (*stagedObjs, error) {
+	s := &stagedObjs{ds: ds, quota: quota}
+
+	res, err := ds.Query(ctx, query.Query{})
+	if err != nil {
+		return nil, fmt.Errorf("list the staged objects: %w", err)
+	}
+	defer res.Close() //nolint:errcheck
+	for entry := range res.Next() {
+		if entry.Error != nil {
+			return nil, fmt.Errorf("list the staged objects: %w", entry.Error)
+		}
+		size, n := binary.Uvarint(entry.Value)
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid size of the staged object %s", entry.Key)
+		}
+		s.count++
+		s.bytes += int64(size)
+	}
+	return s, nil
+}
+
+func stagedKey(c cid.Cid) datastore.Key {
+	return datastore.NewKey(c.String())
+}
+
+// put writes blk to bs when its data matches its cid and the quota allows it. Putting an object bs has already
+// does nothing.
+func (s *stagedObjs) put(ctx context.Context, bs blockstoreutil.Blockstore, blk blocks.Block) error {
+	c := blk.Cid()
+	sum, err := c.Prefix().Sum(blk.RawData())
+	if err != nil {
+		return fmt.Errorf("hash the object %s: %w", c, err)
+	}
+	if !sum.Equals(c) {
+		return fmt.Errorf("the data of the object does not match its cid %s, it hashes to %s", c, sum)
+	}
+
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	has, err := bs.Has(ctx, c)
+	if err != nil || has {
+		return err
+	}
+	size := int64(len(blk.RawData()))
+	if s.quota > 0 && s.bytes+size > s.quota {
+		return fmt.Errorf("staging the object %s of %d bytes exceeds the quota, %d of %d bytes are used", c, size, s.bytes, s.quota)
+	}
+
+	if err := s.ds.Put(ctx, stagedKey(c), binary.AppendUvarint(nil, uint64(size))); err != nil {
+		return fmt.Errorf("record the staged object %s: %w", c, err)
+	}
+	if err := bs.Put(ctx, blk); err != nil {
+		if err := s.ds.Delete(ctx, stagedKey(c)); err != nil {
+			log.Warnf("forget the staged object %s: %v", c, err)
+		}
+		return err
+	}
+	s.count++
+	s.bytes += size
+	return nil
+}
+
+// deleted frees the quota used by c when it was staged
+func (s *stagedObjs) deleted(ctx context.Context, c cid.Cid) error {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	val, err := s.ds.Get(ctx, stagedKey(c))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.ds.Delete(ctx, stagedKey(c)); err != nil {
+		return err
+	}
+	size, _ := binary.Uvarint(val)
+	s.count--
+	s.bytes -= int64(size)
+	return nil
+}
+
+func (s *stagedObjs) stat() *types.StagedObjStat {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	return &types.StagedObjStat{Count: s.count, Bytes: s.bytes, Quota: s.quota}
+}
@@ -0,0 +1,72 @@
+package blockstore
+
+import (
+	"context"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func newStagedBlock(t *testing.T, data string) blocks.Block {
+	c, err := cid.V1Builder{Codec: cid.Raw, MhType: multihash.BLAKE2B_MIN + 31}.Sum([]byte(data))
+	require.NoError(t, err)
+	blk, err := blocks.NewBlockWithCid([]byte(data), c)
+	require.NoError(t, err)
+	return blk
+}
+
+func TestStagedObjs(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+	bs := blockstoreutil.NewMemory()
+	staged, err := newStagedObjs(ctx, ds, 10)
+	require.NoError(t, err)
+
+	// an object the blockstore had already, such as a chain one, is not counted
+	chainBlk := newStagedBlock(t, "chain")
+	require.NoError(t, bs.Put(ctx, chainBlk))
+	require.NoError(t, staged.put(ctx, bs, chainBlk))
+	assert.Equal(t, &types.StagedObjStat{Quota: 10}, staged.stat())
+
+	// the data must hash to the cid
+	forged, err := blocks.NewBlockWithCid([]byte("forged"), newStagedBlock(t, "other").Cid())
+	require.NoError(t, err)
+	assert.ErrorContains(t, staged.put(ctx, bs, forged), "does not match its cid")
+
+	blk1, blk2 := newStagedBlock(t, "object"), newStagedBlock(t, "large")
+	require.NoError(t, staged.put(ctx, bs, blk1))
+	// putting it again does not count it twice
+	require.NoError(t, staged.put(ctx, bs, blk1))
+	assert.Equal(t, &types.StagedObjStat{Count: 1, Bytes: 6, Quota: 10}, staged.stat())
+
+	// the quota is enforced
+	assert.ErrorContains(t, staged.put(ctx, bs, blk2), "exceeds the quota")
+	has, err := bs.Has(ctx, blk2.Cid())
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// the counts are reloaded after a restart
+	staged, err = newStagedObjs(ctx, ds, 10)
+	require.NoError(t, err)
+	assert.Equal(t, &types.StagedObjStat{Count: 1, Bytes: 6, Quota: 10}, staged.stat())
+
+	// deleting a staged object frees its quota, deleting another one changes nothing
+	require.NoError(t, staged.deleted(ctx, chainBlk.Cid()))
+	require.NoError(t, staged.deleted(ctx, blk1.Cid()))
+	require.NoError(t, bs.DeleteBlock(ctx, blk1.Cid()))
+	assert.Equal(t, &types.StagedObjStat{Quota: 10}, staged.stat())
+	require.NoError(t, staged.put(ctx, bs, blk2))
+	assert.Equal(t, &types.StagedObjStat{Count: 1, Bytes: 5, Quota: 10}, staged.stat())
+}
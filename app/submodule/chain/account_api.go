@@ -29,3 +29,12 @@ func (accountAPI *accountAPI) StateAccountKey(ctx context.Context, addr address.
 	}
 	return accountAPI.chain.Stmgr.ResolveToDeterministicAddress(ctx, addr, ts)
 }
+
+// StateAccountKeyBySelector returns the public key address of the given ID address at the tipset selected by tss
+func (accountAPI *accountAPI) StateAccountKeyBySelector(ctx context.Context, addr address.Address, tss types.TipSetSelector) (address.Address, error) {
+	ts, err := accountAPI.chain.ChainReader.SelectTipSet(ctx, tss)
+	if err != nil {
+		return address.Undef, fmt.Errorf("selecting tipset: %w", err)
+	}
+	return accountAPI.chain.Stmgr.ResolveToDeterministicAddress(ctx, addr, ts)
+}
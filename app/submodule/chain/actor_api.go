@@ -2,8 +2,12 @@ package chain
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/pkg/chain"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
@@ -24,7 +28,26 @@ func (actorAPI *actorAPI) StateGetActor(ctx context.Context, actor address.Addre
 	return actorAPI.chain.Stmgr.GetActorAtTsk(ctx, actor, tsk)
 }
 
+// StateGetActorBySelector returns the indicated actor's nonce and balance at the tipset selected by tss.
+func (actorAPI *actorAPI) StateGetActorBySelector(ctx context.Context, actor address.Address, tss types.TipSetSelector) (*types.Actor, error) {
+	ts, err := actorAPI.chain.ChainReader.SelectTipSet(ctx, tss)
+	if err != nil {
+		return nil, fmt.Errorf("selecting tipset: %w", err)
+	}
+	return actorAPI.chain.Stmgr.GetActorAt(ctx, actor, ts)
+}
+
 // ActorLs returns a channel with actors from the latest state on the chain
 func (actorAPI *actorAPI) ListActor(ctx context.Context) (map[address.Address]*types.Actor, error) {
 	return actorAPI.chain.ChainReader.LsActors(ctx)
 }
+
+// StateActorStatObj returns the number of blocks and bytes reachable from the head of the actor, links
+// deeper than maxDepth are not followed, 0 is unlimited
+func (actorAPI *actorAPI) StateActorStatObj(ctx context.Context, actor address.Address, maxDepth uint64, tsk types.TipSetKey) (types.ObjStatWithDepth, error) {
+	act, err := actorAPI.chain.Stmgr.GetActorAtTsk(ctx, actor, tsk)
+	if err != nil {
+		return types.ObjStatWithDepth{}, err
+	}
+	return chain.StatObj(ctx, actorAPI.chain.ChainReader.Blockstore(), act.Head, cid.Undef, maxDepth)
+}
@@ -2,9 +2,11 @@ package chain
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
 	cbor "github.com/ipfs/go-ipld-cbor"
 
 	apiwrapper "github.com/filecoin-project/venus/app/submodule/chain/v0api"
@@ -13,14 +15,17 @@ import (
 	"github.com/filecoin-project/venus/pkg/consensus"
 	"github.com/filecoin-project/venus/pkg/consensus/chainselector"
 	"github.com/filecoin-project/venus/pkg/consensusfault"
+	"github.com/filecoin-project/venus/pkg/events/bus"
 	"github.com/filecoin-project/venus/pkg/fork"
 	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/state"
 	"github.com/filecoin-project/venus/pkg/statemanger"
 	"github.com/filecoin-project/venus/pkg/util/ffiwrapper"
 	"github.com/filecoin-project/venus/pkg/vm"
 	"github.com/filecoin-project/venus/pkg/vmsupport"
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
@@ -38,8 +43,22 @@ type ChainSubmodule struct { //nolint
 	config chainConfig
 
 	Stmgr *statemanger.Stmgr
+	// Serve heavy rpc state reads without contending with block validation
+	StateReaders *statemanger.ReadOnlyStmgrPool
 	// Wait for confirm message
 	Waiter *chain.Waiter
+	// Reject messages replaying the nonces applied in the recent tipsets
+	NonceIndex *chain.NonceIndex
+	// Delete the orphaned branches left by the reorgs
+	OrphanGC *chain.OrphanGC
+	// Move the old chain data to the object storage, nil when the chain offload is disabled
+	ChainOffloader *chain.ChainOffloader
+	// Locate the sectors of the miners without scanning their deadlines
+	SectorIndex *state.SectorIndexCache
+	// Keep the supplies computed for the tipsets
+	SupplyHistory *chain.SupplyHistory
+	// Push the chain events to the sinks of the config, nil without sinks
+	EventBus *bus.Bus
 }
 
 type chainConfig interface {
@@ -89,6 +108,30 @@ func NewChainSubmodule(ctx context.Context,
 		config:       config,
 		Waiter:       waiter,
 		CheckPoint:   chainStore.GetCheckPoint(),
+		NonceIndex:   chain.NewNonceIndex(chain.DefaultNonceIndexEpochs),
+		SectorIndex:  state.NewSectorIndexCache(state.DefaultSectorIndexMiners),
+	}
+	var supplyDs datastore.Batching
+	if repo.Config().SupplyHistory.Persist {
+		supplyDs = repo.MetaDatastore()
+	}
+	store.SupplyHistory = chain.NewSupplyHistory(store.circulatingSupply, supplyDs)
+	gcCfg := repo.Config().ChainGC
+	store.OrphanGC = chain.NewOrphanGC(chainStore, gcCfg.Depth, gcCfg.Interval, gcCfg.DryRun)
+	if offloadCfg := repo.Config().ChainOffload; offloadCfg != nil && offloadCfg.Enable {
+		bs, ok := repo.Datastore().(*blockstoreutil.OffloadBlockstore)
+		if !ok {
+			return nil, fmt.Errorf("chain offload is enabled but the blockstore does not offload")
+		}
+		store.ChainOffloader, err = chain.NewChainOffloader(ctx, chainStore, bs, repo.MetaDatastore(), offloadCfg.Depth, offloadCfg.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the chain offloader: %w", err)
+		}
+	}
+	if busCfg := repo.Config().EventBus; busCfg != nil && len(busCfg.Sinks) > 0 {
+		if store.EventBus, err = bus.NewBus(busCfg, chainStore, messageStore); err != nil {
+			return nil, fmt.Errorf("failed to create the event bus: %w", err)
+		}
 	}
 	err = store.ChainReader.Load(context.TODO())
 	if err != nil {
@@ -97,13 +140,40 @@ func NewChainSubmodule(ctx context.Context,
 	return store, nil
 }
 
+// circulatingSupply computes the supply reported to the actors at ts, from the state of its parent
+func (chain *ChainSubmodule) circulatingSupply(ctx context.Context, ts *types.TipSet) (types.CirculatingSupply, error) {
+	_, sTree, err := chain.Stmgr.ParentState(ctx, ts)
+	if err != nil {
+		return types.CirculatingSupply{}, err
+	}
+
+	return chain.ChainReader.GetCirculatingSupplyDetailed(ctx, ts.Height(), sTree)
+}
+
 // Start loads the chain from disk.
 func (chain *ChainSubmodule) Start(ctx context.Context) error {
+	if err := chain.NonceIndex.Load(ctx, chain.ChainReader, chain.MessageStore, chain.ChainReader.GetHead()); err != nil {
+		log.Warnf("failed to load the nonce index: %v", err)
+	}
+	chain.ChainReader.SubscribeHeadChanges(chain.NonceIndex.HeadChange(chain.ChainReader, chain.MessageStore))
+	if chain.config.Repo().Config().ChainGC.Enable {
+		chain.OrphanGC.Start(ctx)
+	}
+	if chain.ChainOffloader != nil {
+		chain.ChainOffloader.Start(ctx)
+	}
+	if chain.EventBus != nil {
+		chain.EventBus.Start(ctx)
+	}
+
 	return chain.Fork.Start(ctx)
 }
 
 // Stop stop the chain head event
 func (chain *ChainSubmodule) Stop(ctx context.Context) {
+	if chain.EventBus != nil {
+		_ = chain.EventBus.Close()
+	}
 	chain.ChainReader.Stop()
 }
 
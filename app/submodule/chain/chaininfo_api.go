@@ -9,6 +9,7 @@ import (
 	"io"
 	"math"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/filecoin-project/go-address"
@@ -31,6 +32,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/statemanger"
 	"github.com/filecoin-project/venus/venus-shared/actors"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/filecoin-project/venus/venus-shared/utils"
 )
@@ -105,6 +107,27 @@ func (cia *chainInfoAPI) ChainHead(ctx context.Context) (*types.TipSet, error) {
 	return cia.chain.ChainReader.GetHead(), nil
 }
 
+// ChainGetFinalizedHead returns the latest tipset the node considers final, which is ChainFinality epochs
+// below the head
+func (cia *chainInfoAPI) ChainGetFinalizedHead(ctx context.Context) (*types.FinalizedHead, error) {
+	head := cia.chain.ChainReader.GetHead()
+	height := head.Height() - constants.Finality
+	if height < 0 {
+		height = 0
+	}
+	ts, err := cia.chain.ChainReader.GetTipSetByHeight(ctx, head, height, true)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset at %d below %s: %w", height, head.Key(), err)
+	}
+
+	return &types.FinalizedHead{
+		TipSet: ts,
+		Source: types.FinalityEC,
+		Head:   head.Key(),
+		Depth:  head.Height() - ts.Height(),
+	}, nil
+}
+
 // ChainSetHead sets `key` as the new head of this chain iff it exists in the nodes chain store.
 func (cia *chainInfoAPI) ChainSetHead(ctx context.Context, key types.TipSetKey) error {
 	ts, err := cia.chain.ChainReader.GetTipSet(ctx, key)
@@ -141,6 +164,11 @@ func (cia *chainInfoAPI) ChainGetTipSetAfterHeight(ctx context.Context, h abi.Ch
 	return cia.chain.ChainReader.GetTipSetByHeight(ctx, ts, h, false)
 }
 
+// ChainGetTipSetBySelector returns the tipset selected by its key, its height or a tag
+func (cia *chainInfoAPI) ChainGetTipSetBySelector(ctx context.Context, tss types.TipSetSelector) (*types.TipSet, error) {
+	return cia.chain.ChainReader.SelectTipSet(ctx, tss)
+}
+
 // GetActor get the ts ParentStateRoot actor
 func (cia *chainInfoAPI) GetActor(ctx context.Context, addr address.Address) (*types.Actor, error) {
 	return cia.chain.Stmgr.GetActorAtTsk(ctx, addr, types.EmptyTSK)
@@ -251,6 +279,70 @@ func (cia *chainInfoAPI) ChainGetMessagesInTipset(ctx context.Context, key types
 	return out, nil
 }
 
+// ChainGetResolvedMessagesInTipset returns the messages of the tipset with their senders and receivers resolved
+// to ID addresses in the parent state of the tipset
+func (cia *chainInfoAPI) ChainGetResolvedMessagesInTipset(ctx context.Context, key types.TipSetKey) ([]types.ResolvedMessage, error) {
+	ts, err := cia.chain.ChainReader.GetTipSet(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if ts.Height() == 0 {
+		return nil, nil
+	}
+
+	cm, err := cia.chain.MessageStore.MessagesForTipset(ts)
+	if err != nil {
+		return nil, err
+	}
+	_, st, err := cia.chain.Stmgr.ParentState(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("load parent state of %s: %w", ts.Key(), err)
+	}
+
+	return resolveMessages(cm, st.LookupID)
+}
+
+// resolveMessages pairs the messages with the ID addresses of their senders and receivers, each address is looked up
+// once, an address without actor resolves to undef
+func resolveMessages(msgs []types.ChainMsg, lookupID func(address.Address) (address.Address, error)) ([]types.ResolvedMessage, error) {
+	ids := make(map[address.Address]address.Address)
+	resolve := func(addr address.Address) (address.Address, error) {
+		if id, ok := ids[addr]; ok {
+			return id, nil
+		}
+		id, err := lookupID(addr)
+		if err != nil {
+			if !errors.Is(err, types.ErrActorNotFound) {
+				return address.Undef, fmt.Errorf("resolve %s: %w", addr, err)
+			}
+			id = address.Undef
+		}
+		ids[addr] = id
+		return id, nil
+	}
+
+	out := make([]types.ResolvedMessage, 0, len(msgs))
+	for _, m := range msgs {
+		msg := m.VMMessage()
+		fromID, err := resolve(msg.From)
+		if err != nil {
+			return nil, err
+		}
+		toID, err := resolve(msg.To)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, types.ResolvedMessage{
+			Cid:     m.Cid(),
+			Message: msg,
+			FromID:  fromID,
+			ToID:    toID,
+		})
+	}
+
+	return out, nil
+}
+
 // ChainGetParentMessages returns messages stored in parent tipset of the
 // specified block.
 func (cia *chainInfoAPI) ChainGetParentMessages(ctx context.Context, bcid cid.Cid) ([]types.MessageCID, error) {
@@ -746,12 +838,70 @@ func (cia *chainInfoAPI) StateGetNetworkParams(ctx context.Context) (*types.Netw
 			UpgradeDragonHeight:      cfg.NetworkParams.ForkUpgradeParam.UpgradeDragonHeight,
 			UpgradePhoenixHeight:     cfg.NetworkParams.ForkUpgradeParam.UpgradePhoenixHeight,
 		},
-		Eip155ChainID: cfg.NetworkParams.Eip155ChainID,
+		Eip155ChainID:   cfg.NetworkParams.Eip155ChainID,
+		NetworkVersions: cia.chain.Fork.NetworkVersions(),
+	}
+
+	if params.SectorSizes, err = sectorSizes(params.SupportedProofTypes); err != nil {
+		return nil, err
 	}
 
 	return params, nil
 }
 
+// sectorSizes returns the sizes of the sectors of the proof types in ascending order, once each
+func sectorSizes(proofTypes []abi.RegisteredSealProof) ([]abi.SectorSize, error) {
+	var out []abi.SectorSize
+	seen := map[abi.SectorSize]struct{}{}
+	for _, spt := range proofTypes {
+		size, err := spt.SectorSize()
+		if err != nil {
+			return nil, fmt.Errorf("sector size of proof type %d: %w", spt, err)
+		}
+		if _, ok := seen[size]; !ok {
+			seen[size] = struct{}{}
+			out = append(out, size)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out, nil
+}
+
+// StateNetworkUpgradeSchedule returns the network versions the chain goes through, in order, with their first
+// epoch, their actors version and the cid of their actors bundle
+func (cia *chainInfoAPI) StateNetworkUpgradeSchedule(ctx context.Context) ([]*types.NetworkUpgrade, error) {
+	return upgradeSchedule(cia.chain.Fork.NetworkVersions())
+}
+
+func upgradeSchedule(versions []types.NetworkVersionEpoch) ([]*types.NetworkUpgrade, error) {
+	out := make([]*types.NetworkUpgrade, 0, len(versions))
+	for _, nve := range versions {
+		av, err := actorstypes.VersionForNetwork(nve.Version)
+		if err != nil {
+			return nil, fmt.Errorf("actors version of network version %d: %w", nve.Version, err)
+		}
+		upgrade := &types.NetworkUpgrade{NetworkVersionEpoch: nve, ActorsVersion: av}
+		if av >= actorstypes.Version8 {
+			manifest, ok := actors.GetManifest(av)
+			if !ok {
+				return nil, fmt.Errorf("could not find manifest cid for network version %d, actors version %d", nve.Version, av)
+			}
+			upgrade.Manifest = manifest
+		}
+		out = append(out, upgrade)
+	}
+	return out, nil
+}
+
+// StateNetworkVersionAt returns the network version of the epoch from the upgrade schedule, the epoch does not need
+// to be on the chain
+func (cia *chainInfoAPI) StateNetworkVersionAt(ctx context.Context, height abi.ChainEpoch) (network.Version, error) {
+	if height < 0 {
+		return network.VersionMax, fmt.Errorf("invalid epoch %d", height)
+	}
+	return cia.chain.Fork.GetNetworkVersion(ctx, height), nil
+}
+
 // StateActorCodeCIDs returns the CIDs of all the builtin actors for the given network version
 func (cia *chainInfoAPI) StateActorCodeCIDs(ctx context.Context, nv network.Version) (map[string]cid.Cid, error) {
 	actorVersion, err := actorstypes.VersionForNetwork(nv)
@@ -767,6 +917,17 @@ func (cia *chainInfoAPI) StateActorCodeCIDs(ctx context.Context, nv network.Vers
 	return cids, nil
 }
 
+// StateActorNames returns the name and version of the builtin actors of the codes, nil for an unknown code
+func (cia *chainInfoAPI) StateActorNames(ctx context.Context, codes []cid.Cid) ([]*types.ActorCodeName, error) {
+	out := make([]*types.ActorCodeName, len(codes))
+	for i, c := range codes {
+		if code, ok := actors.LookupActorCode(c); ok {
+			out[i] = &types.ActorCodeName{Code: c, Name: code.Name, Version: code.Version, Networks: code.Networks}
+		}
+	}
+	return out, nil
+}
+
 // ChainGetGenesis returns the genesis tipset.
 func (cia *chainInfoAPI) ChainGetGenesis(ctx context.Context) (*types.TipSet, error) {
 	genb, err := cia.chain.ChainReader.GetGenesisBlock(ctx)
@@ -802,7 +963,22 @@ func (cia *chainInfoAPI) StateCall(ctx context.Context, msg *types.Message, tsk
 	if err != nil {
 		return nil, fmt.Errorf("loading tipset %s: %v", tsk, err)
 	}
+	return cia.call(ctx, msg, ts)
+}
+
+// StateCallBySelector runs the given message on the parent state of the tipset selected by tss, as StateCall does.
+func (cia *chainInfoAPI) StateCallBySelector(ctx context.Context, msg *types.Message, tss types.TipSetSelector) (*types.InvocResult, error) {
+	ts, err := cia.chain.ChainReader.SelectTipSet(ctx, tss)
+	if err != nil {
+		return nil, fmt.Errorf("selecting tipset: %w", err)
+	}
+	return cia.call(ctx, msg, ts)
+}
+
+// call runs msg on the parent state of ts, going back to the parents while the state of ts is an expensive fork
+func (cia *chainInfoAPI) call(ctx context.Context, msg *types.Message, ts *types.TipSet) (*types.InvocResult, error) {
 	var res *types.InvocResult
+	var err error
 	for {
 		res, err = cia.chain.Stmgr.Call(ctx, msg, ts)
 		if err != fork.ErrExpensiveFork {
@@ -926,3 +1102,52 @@ func (cia *chainInfoAPI) StateCompute(ctx context.Context, height abi.ChainEpoch
 		Trace: t,
 	}, nil
 }
+
+// ChainGCStatus returns the state of the garbage collection of the orphaned chain branches
+func (cia *chainInfoAPI) ChainGCStatus(ctx context.Context) (types.ChainGCStatus, error) {
+	return cia.chain.OrphanGC.Status(), nil
+}
+
+// ChainOffloadStatus returns the state of the offload of the old chain data to the object storage
+func (cia *chainInfoAPI) ChainOffloadStatus(ctx context.Context) (types.ChainOffloadStatus, error) {
+	if cia.chain.ChainOffloader == nil {
+		return types.ChainOffloadStatus{}, nil
+	}
+	return cia.chain.ChainOffloader.Status(), nil
+}
+
+// ChainHotGC offloads the chain data deeper than the offload depth, then reclaims its space in the hot blockstore
+func (cia *chainInfoAPI) ChainHotGC(ctx context.Context) (*types.ChainOffloadStats, error) {
+	if cia.chain.ChainOffloader == nil {
+		return nil, fmt.Errorf("chain offload is not enabled")
+	}
+	stats, err := cia.chain.ChainOffloader.Offload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := cia.chain.config.Repo()
+	if offload, ok := repo.Datastore().(*blockstoreutil.OffloadBlockstore); ok {
+		if badger, ok := offload.Hot().(*blockstoreutil.BadgerBlockstore); ok {
+			res, err := badger.CollectGarbage(ctx, repo.Config().Datastore.GCDiscardRatio)
+			if err != nil {
+				return &stats, fmt.Errorf("collect the garbage of the hot blockstore: %w", err)
+			}
+			log.Infof("chain hot gc reclaimed %d bytes", res.Reclaimed)
+		}
+	}
+	return &stats, nil
+}
+
+// ChainOffloadRestore copies the offloaded chain data of the canonical tipsets between the heights from and to,
+// included, back to the hot blockstore
+func (cia *chainInfoAPI) ChainOffloadRestore(ctx context.Context, from, to abi.ChainEpoch) (*types.ChainOffloadStats, error) {
+	if cia.chain.ChainOffloader == nil {
+		return nil, fmt.Errorf("chain offload is not enabled")
+	}
+	stats, err := cia.chain.ChainOffloader.Restore(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
@@ -0,0 +1,135 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestUpgradeSchedule(t *testing.T) {
+	tf.UnitTest(t)
+
+	schedule, err := upgradeSchedule([]types.NetworkVersionEpoch{
+		{Version: network.Version0, Epoch: 0},
+		{Version: network.Version16, Epoch: 10},
+		{Version: network.Version21, Epoch: 20},
+	})
+	require.NoError(t, err)
+	require.Len(t, schedule, 3)
+
+	// no bundle before the actors v8
+	assert.Equal(t, actorstypes.Version0, schedule[0].ActorsVersion)
+	assert.False(t, schedule[0].Manifest.Defined())
+
+	assert.Equal(t, abi.ChainEpoch(10), schedule[1].Epoch)
+	assert.Equal(t, actorstypes.Version8, schedule[1].ActorsVersion)
+	manifest, ok := actors.GetManifest(actorstypes.Version8)
+	require.True(t, ok)
+	assert.Equal(t, manifest, schedule[1].Manifest)
+	assert.Equal(t, actorstypes.Version12, schedule[2].ActorsVersion)
+
+	_, err = upgradeSchedule([]types.NetworkVersionEpoch{{Version: network.VersionMax}})
+	assert.Error(t, err)
+}
+
+func TestResolveMessages(t *testing.T) {
+	tf.UnitTest(t)
+
+	addrs := testhelpers.NewForTestGetter()
+	alice, bob, newcomer := addrs(), addrs(), addrs()
+	aliceID, _ := address.NewIDAddress(100)
+	bobID, _ := address.NewIDAddress(101)
+	carolID, _ := address.NewIDAddress(102)
+	ids := map[address.Address]address.Address{alice: aliceID, bob: bobID, carolID: carolID}
+
+	var lookups int
+	lookupID := func(addr address.Address) (address.Address, error) {
+		lookups++
+		if id, ok := ids[addr]; ok {
+			return id, nil
+		}
+		return address.Undef, types.ErrActorNotFound
+	}
+
+	bls := &types.Message{From: alice, To: bob, Nonce: 1}
+	secp := &types.SignedMessage{Message: types.Message{From: bob, To: newcomer}}
+	toID := &types.Message{From: alice, To: carolID, Nonce: 2}
+	out, err := resolveMessages([]types.ChainMsg{bls, secp, toID}, lookupID)
+	require.NoError(t, err)
+	assert.Equal(t, []types.ResolvedMessage{
+		{Cid: bls.Cid(), Message: bls, FromID: aliceID, ToID: bobID},
+		// the signed message keeps its own cid, the receiver without actor is undef
+		{Cid: secp.Cid(), Message: &secp.Message, FromID: bobID, ToID: address.Undef},
+		{Cid: toID.Cid(), Message: toID, FromID: aliceID, ToID: carolID},
+	}, out)
+	// every address is looked up once
+	assert.Equal(t, 4, lookups)
+
+	broken := errors.New("broken state")
+	_, err = resolveMessages([]types.ChainMsg{bls}, func(address.Address) (address.Address, error) {
+		return address.Undef, broken
+	})
+	assert.ErrorIs(t, err, broken)
+}
+
+func TestSectorSizes(t *testing.T) {
+	tf.UnitTest(t)
+
+	sizes, err := sectorSizes([]abi.RegisteredSealProof{
+		abi.RegisteredSealProof_StackedDrg64GiBV1_1,
+		abi.RegisteredSealProof_StackedDrg32GiBV1_1,
+		abi.RegisteredSealProof_StackedDrg32GiBV1_1_Feat_SyntheticPoRep,
+		abi.RegisteredSealProof_StackedDrg2KiBV1_1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []abi.SectorSize{2 << 10, 32 << 30, 64 << 30}, sizes)
+
+	sizes, err = sectorSizes(nil)
+	require.NoError(t, err)
+	assert.Empty(t, sizes)
+
+	_, err = sectorSizes([]abi.RegisteredSealProof{abi.RegisteredSealProof(-1)})
+	assert.Error(t, err)
+}
+
+func TestChainGetFinalizedHead(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	builder := chain.NewBuilder(t, address.Undef)
+	store := builder.Store()
+	cia := &chainInfoAPI{chain: &ChainSubmodule{ChainReader: store}}
+
+	// a chain shorter than the finality is final from its genesis
+	head := builder.AppendManyOn(ctx, 10, builder.Genesis())
+	require.NoError(t, store.SetHead(ctx, head))
+	finalized, err := cia.ChainGetFinalizedHead(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &types.FinalizedHead{TipSet: builder.Genesis(), Source: types.FinalityEC, Head: head.Key(), Depth: 10}, finalized)
+
+	// the epoch at the finality below the head is a null round, the tipset before it is final
+	beforeNulls := head
+	head = builder.BuildOneOn(ctx, head, func(b *chain.BlockBuilder) { b.IncHeight(10) })
+	head = builder.AppendManyOn(ctx, int(constants.Finality)-5, head)
+	require.NoError(t, store.SetHead(ctx, head))
+	require.Equal(t, abi.ChainEpoch(16), head.Height()-constants.Finality)
+	finalized, err = cia.ChainGetFinalizedHead(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, beforeNulls.Key(), finalized.TipSet.Key())
+	assert.Equal(t, head.Key(), finalized.Head)
+	assert.Equal(t, constants.Finality+6, finalized.Depth)
+}
@@ -0,0 +1,214 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/market"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// watchedDeals is the state of the watched deals at a tipset, a deal missing from the market actor is nil
+type watchedDeals struct {
+	ts    *types.TipSet
+	mas   market.State
+	deals map[abi.DealID]*types.MarketDeal
+}
+
+// SubscribeDealUpdates emits the activation, slashing and expiry of the given deals as the chain advances. The
+// market actor is diffed at each head change, the deals are only read when its deal states or proposals changed.
+func (msa *minerStateAPI) SubscribeDealUpdates(ctx context.Context, dealIDs []abi.DealID) (<-chan []*types.DealUpdate, error) {
+	if len(dealIDs) == 0 {
+		return nil, fmt.Errorf("no deal to watch")
+	}
+
+	cur, err := msa.loadWatchedDeals(ctx, msa.ChainReader.GetHead(), dealIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan []*types.DealUpdate, 16)
+	go func() {
+		defer close(out)
+
+		notifs := msa.ChainReader.SubHeadChanges(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case changes, ok := <-notifs:
+				if !ok {
+					return
+				}
+				next, updates, err := msa.applyDealHeadChanges(ctx, cur, dealIDs, changes)
+				if err != nil {
+					log.Errorf("failed to diff the watched deals: %v", err)
+					return
+				}
+				cur = next
+				if len(updates) == 0 {
+					continue
+				}
+
+				select {
+				case out <- updates:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (msa *minerStateAPI) applyDealHeadChanges(ctx context.Context,
+	cur *watchedDeals,
+	dealIDs []abi.DealID,
+	changes []*types.HeadChange,
+) (*watchedDeals, []*types.DealUpdate, error) {
+	var reverted *types.TipSet
+	var applied []*types.TipSet
+	for _, change := range changes {
+		switch change.Type {
+		case types.HCRevert:
+			// reverts are ordered from the old head down
+			reverted = change.Val
+		case types.HCApply, types.HCCurrent:
+			applied = append(applied, change.Val)
+		}
+	}
+
+	var updates []*types.DealUpdate
+	if reverted != nil {
+		parent, err := msa.ChainReader.GetTipSet(ctx, reverted.Parents())
+		if err != nil {
+			return cur, nil, err
+		}
+		next, err := msa.loadWatchedDeals(ctx, parent, dealIDs, cur)
+		if err != nil {
+			return cur, nil, err
+		}
+		// the changes between the new and the old state are the ones the reorg undid
+		for _, id := range dealIDs {
+			updates = append(updates, next.updates(id, diffDeal(next.deals[id], cur.deals[id], cur.ts.Height()), true)...)
+		}
+		cur = next
+	}
+
+	// applies are ordered from the new head down
+	for i := len(applied) - 1; i >= 0; i-- {
+		if applied[i].Equals(cur.ts) {
+			continue
+		}
+		next, err := msa.loadWatchedDeals(ctx, applied[i], dealIDs, cur)
+		if err != nil {
+			return cur, nil, err
+		}
+		for _, id := range dealIDs {
+			updates = append(updates, next.updates(id, diffDeal(cur.deals[id], next.deals[id], next.ts.Height()), false)...)
+		}
+		cur = next
+	}
+	return cur, updates, nil
+}
+
+// loadWatchedDeals reads the watched deals in the parent state of ts, the deals of prev are reused when the
+// market actor did not change them
+func (msa *minerStateAPI) loadWatchedDeals(ctx context.Context, ts *types.TipSet, dealIDs []abi.DealID, prev *watchedDeals) (*watchedDeals, error) {
+	_, view, err := msa.Stmgr.ParentStateView(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateView failed:%v", err)
+	}
+	mas, err := view.LoadMarketState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load market actor state: %v", err)
+	}
+
+	if prev != nil {
+		statesChanged, err := mas.StatesChanged(prev.mas)
+		if err != nil {
+			return nil, err
+		}
+		proposalsChanged, err := mas.ProposalsChanged(prev.mas)
+		if err != nil {
+			return nil, err
+		}
+		if !statesChanged && !proposalsChanged {
+			return &watchedDeals{ts: ts, mas: mas, deals: prev.deals}, nil
+		}
+	}
+
+	proposals, err := mas.Proposals()
+	if err != nil {
+		return nil, err
+	}
+	states, err := mas.States()
+	if err != nil {
+		return nil, err
+	}
+
+	deals := make(map[abi.DealID]*types.MarketDeal, len(dealIDs))
+	for _, id := range dealIDs {
+		proposal, found, err := proposals.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			deals[id] = nil
+			continue
+		}
+
+		st, found, err := states.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			st = market.EmptyDealState()
+		}
+		deals[id] = &types.MarketDeal{Proposal: *proposal, State: types.MakeDealState(st)}
+	}
+	return &watchedDeals{ts: ts, mas: mas, deals: deals}, nil
+}
+
+// diffDeal returns the changes of a deal going from prev to cur at height
+func diffDeal(prev, cur *types.MarketDeal, height abi.ChainEpoch) []types.DealUpdateType {
+	var typs []types.DealUpdateType
+	switch {
+	case cur != nil:
+		if cur.State.SectorStartEpoch >= 0 && (prev == nil || prev.State.SectorStartEpoch < 0) {
+			typs = append(typs, types.DealActivated)
+		}
+		if cur.State.SlashEpoch >= 0 && (prev == nil || prev.State.SlashEpoch < 0) {
+			typs = append(typs, types.DealSlashed)
+		}
+	case prev != nil:
+		// the deal is removed from the market actor, a slashed deal was already reported
+		switch {
+		case prev.State.SlashEpoch >= 0:
+		case prev.State.SectorStartEpoch < 0 || height >= prev.Proposal.EndEpoch:
+			// not activated in time or at its end
+			typs = append(typs, types.DealExpired)
+		default:
+			typs = append(typs, types.DealSlashed)
+		}
+	}
+	return typs
+}
+
+// updates reports the changes of a deal with the deal as read in wd
+func (wd *watchedDeals) updates(id abi.DealID, typs []types.DealUpdateType, reverted bool) []*types.DealUpdate {
+	updates := make([]*types.DealUpdate, 0, len(typs))
+	for _, typ := range typs {
+		updates = append(updates, &types.DealUpdate{
+			DealID:   id,
+			Type:     typ,
+			Reverted: reverted,
+			TipSet:   wd.ts.Key(),
+			Height:   wd.ts.Height(),
+			Deal:     wd.deals[id],
+		})
+	}
+	return updates
+}
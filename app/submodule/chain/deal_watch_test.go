@@ -0,0 +1,37 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestDiffDeal(t *testing.T) {
+	tf.UnitTest(t)
+
+	mkDeal := func(start, slash abi.ChainEpoch) *types.MarketDeal {
+		deal := &types.MarketDeal{State: types.MarketDealState{SectorStartEpoch: start, LastUpdatedEpoch: -1, SlashEpoch: slash}}
+		deal.Proposal.EndEpoch = 100
+		return deal
+	}
+
+	published, active, slashed := mkDeal(-1, -1), mkDeal(10, -1), mkDeal(10, 50)
+
+	require.Empty(t, diffDeal(nil, nil, 10))
+	require.Empty(t, diffDeal(nil, published, 10))
+	require.Empty(t, diffDeal(active, active, 20))
+	require.Equal(t, []types.DealUpdateType{types.DealActivated}, diffDeal(published, active, 10))
+	require.Equal(t, []types.DealUpdateType{types.DealActivated}, diffDeal(nil, active, 10))
+	require.Equal(t, []types.DealUpdateType{types.DealSlashed}, diffDeal(active, slashed, 50))
+	require.Equal(t, []types.DealUpdateType{types.DealActivated, types.DealSlashed}, diffDeal(published, slashed, 50))
+
+	// removed from the market actor
+	require.Equal(t, []types.DealUpdateType{types.DealExpired}, diffDeal(active, nil, 100))
+	require.Equal(t, []types.DealUpdateType{types.DealExpired}, diffDeal(published, nil, 20))
+	require.Equal(t, []types.DealUpdateType{types.DealSlashed}, diffDeal(active, nil, 50))
+	require.Empty(t, diffDeal(slashed, nil, 51))
+}
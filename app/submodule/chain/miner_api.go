@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"sort"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-bitfield"
@@ -25,6 +26,7 @@ import (
 	market12 "github.com/filecoin-project/go-state-types/builtin/v12/market"
 	market2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/market"
 	market5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/market"
+	appstate "github.com/filecoin-project/venus/pkg/state"
 	"github.com/filecoin-project/venus/pkg/state/tree"
 	"github.com/filecoin-project/venus/pkg/vm/register"
 	"github.com/filecoin-project/venus/venus-shared/actors"
@@ -96,7 +98,11 @@ func (msa *minerStateAPI) StateSectorPartition(ctx context.Context, maddr addres
 		return nil, fmt.Errorf("loadParentStateViewTsk(%s) failed:%v", tsk.String(), err)
 	}
 
-	return view.StateSectorPartition(ctx, maddr, sectorNumber)
+	mas, err := view.LoadMinerState(ctx, maddr)
+	if err != nil {
+		return nil, fmt.Errorf("(get sset) failed to load miner actor: %v", err)
+	}
+	return msa.SectorIndex.FindSector(ctx, maddr, mas, sectorNumber)
 }
 
 // StateMinerSectorSize get miner sector size
@@ -222,11 +228,62 @@ func (msa *minerStateAPI) StateMinerProvingDeadline(ctx context.Context, maddr a
 	return di.NextNotElapsed(), nil
 }
 
+// StateMinerProvingDeadlineWithPartitions calculates the deadline at some epoch for a proving period
+// and returns the proving windows of the partitions in that deadline
+func (msa *minerStateAPI) StateMinerProvingDeadlineWithPartitions(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*types.ProvingDeadline, error) {
+	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("GetTipset failed:%v", err)
+	}
+
+	_, view, err := msa.Stmgr.ParentStateView(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+	}
+	mas, err := view.LoadMinerState(ctx, maddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load miner actor state: %v", err)
+	}
+
+	di, err := mas.DeadlineInfo(ts.Height())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deadline info: %v", err)
+	}
+	di = di.NextNotElapsed()
+
+	dl, err := mas.LoadDeadline(di.Index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load deadline %d: %v", di.Index, err)
+	}
+
+	out := &types.ProvingDeadline{Info: *di, Partitions: []types.PartitionProvingWindow{}}
+	err = dl.ForEachPartition(func(idx uint64, _ lminer.Partition) error {
+		out.Partitions = append(out.Partitions, types.PartitionProvingWindow{
+			Index:       idx,
+			Open:        di.Open,
+			Close:       di.Close,
+			FaultCutoff: di.FaultCutoff,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate partitions of deadline %d: %v", di.Index, err)
+	}
+
+	return out, nil
+}
+
 // StateMinerPartitions returns all partitions in the specified deadline
 func (msa *minerStateAPI) StateMinerPartitions(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error) {
-	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	reader, err := msa.StateReaders.Acquire(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+		return nil, err
+	}
+	defer reader.Release()
+
+	_, view, err := reader.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("ParentStateViewTsk failed:%v", err)
 	}
 
 	mas, err := view.LoadMinerState(ctx, maddr)
@@ -281,9 +338,15 @@ func (msa *minerStateAPI) StateMinerPartitions(ctx context.Context, maddr addres
 
 // StateMinerDeadlines returns all the proving deadlines for the given miner
 func (msa *minerStateAPI) StateMinerDeadlines(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.Deadline, error) {
-	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	reader, err := msa.StateReaders.Acquire(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+		return nil, err
+	}
+	defer reader.Release()
+
+	_, view, err := reader.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("ParentStateViewTsk failed:%v", err)
 	}
 
 	mas, err := view.LoadMinerState(ctx, maddr)
@@ -321,9 +384,15 @@ func (msa *minerStateAPI) StateMinerDeadlines(ctx context.Context, maddr address
 
 // StateMinerSectors returns info about the given miner's sectors. If the filter bitfield is nil, all sectors are included.
 func (msa *minerStateAPI) StateMinerSectors(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error) {
-	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	reader, err := msa.StateReaders.Acquire(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+		return nil, err
+	}
+	defer reader.Release()
+
+	_, view, err := reader.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("ParentStateViewTsk failed:%v", err)
 	}
 
 	mas, err := view.LoadMinerState(ctx, maddr)
@@ -447,9 +516,15 @@ func (msa *minerStateAPI) StateGetAllocation(ctx context.Context, clientAddr add
 }
 
 func (msa *minerStateAPI) StateGetAllAllocations(ctx context.Context, tsk types.TipSetKey) (map[types.AllocationId]types.Allocation, error) {
-	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	reader, err := msa.StateReaders.Acquire(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+		return nil, err
+	}
+	defer reader.Release()
+
+	_, view, err := reader.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("ParentStateViewTsk failed:%v", err)
 	}
 
 	st, err := view.LoadVerifregActor(ctx)
@@ -544,9 +619,15 @@ func (msa *minerStateAPI) StateGetClaims(ctx context.Context, providerAddr addre
 }
 
 func (msa *minerStateAPI) StateGetAllClaims(ctx context.Context, tsk types.TipSetKey) (map[verifreg.ClaimId]verifreg.Claim, error) {
-	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	reader, err := msa.StateReaders.Acquire(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+		return nil, err
+	}
+	defer reader.Release()
+
+	_, view, err := reader.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("ParentStateViewTsk failed:%v", err)
 	}
 
 	st, err := view.LoadVerifregActor(ctx)
@@ -785,17 +866,9 @@ func (msa *minerStateAPI) StateMinerInitialPledgeCollateral(ctx context.Context,
 	}
 
 	store := msa.ChainReader.Store(ctx)
-	var sectorWeight abi.StoragePower
-	if act, found, err := state.GetActor(ctx, market.Address); err != nil || !found {
-		return big.Int{}, fmt.Errorf("loading miner actor %s: %v", maddr, err)
-	} else if s, err := market.Load(store, act); err != nil {
-		return big.Int{}, fmt.Errorf("loading market actor state %s: %v", maddr, err)
-	} else if w, vw, err := s.VerifyDealsForActivation(maddr, pci.DealIDs, ts.Height(), pci.Expiration); err != nil {
-		return big.Int{}, fmt.Errorf("verifying deals for activation: %v", err)
-	} else {
-		// NB: not exactly accurate, but should always lead us to *over* estimate, not under
-		duration := pci.Expiration - ts.Height()
-		sectorWeight = builtin.QAPowerForWeight(ssize, duration, w, vw)
+	sectorWeight, err := sectorQAPower(store, state, maddr, pci, ssize, ts.Height())
+	if err != nil {
+		return big.Int{}, err
 	}
 
 	var (
@@ -843,6 +916,117 @@ func (msa *minerStateAPI) StateMinerInitialPledgeCollateral(ctx context.Context,
 	return big.Div(big.Mul(initialPledge, initialPledgeNum), initialPledgeDen), nil
 }
 
+// sectorQAPower returns the quality adjusted power of the sector with the deals activated at height
+func sectorQAPower(store adt.Store, state tree.Tree, maddr address.Address, pci types.SectorPreCommitInfo, ssize abi.SectorSize, height abi.ChainEpoch) (abi.StoragePower, error) {
+	act, found, err := state.GetActor(store.Context(), market.Address)
+	if err != nil || !found {
+		return big.Int{}, fmt.Errorf("loading miner actor %s: %v", maddr, err)
+	}
+	s, err := market.Load(store, act)
+	if err != nil {
+		return big.Int{}, fmt.Errorf("loading market actor state %s: %v", maddr, err)
+	}
+	w, vw, err := s.VerifyDealsForActivation(maddr, pci.DealIDs, height, pci.Expiration)
+	if err != nil {
+		return big.Int{}, fmt.Errorf("verifying deals for activation: %v", err)
+	}
+	// NB: not exactly accurate, but should always lead us to *over* estimate, not under
+	duration := pci.Expiration - height
+	return builtin.QAPowerForWeight(ssize, duration, w, vw), nil
+}
+
+// StateMinerSectorCollateral returns the deposit, the pledge and the termination penalties of the sector, as if it
+// were activated at the tipset
+func (msa *minerStateAPI) StateMinerSectorCollateral(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (*types.SectorCollateral, error) {
+	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset %s: %v", tsk, err)
+	}
+
+	var out types.SectorCollateral
+	if out.PreCommitDeposit, err = msa.StateMinerPreCommitDepositForPower(ctx, maddr, pci, ts.Key()); err != nil {
+		return nil, err
+	}
+	if out.InitialPledge, err = msa.StateMinerInitialPledgeCollateral(ctx, maddr, pci, ts.Key()); err != nil {
+		return nil, err
+	}
+
+	_, state, err := msa.Stmgr.ParentState(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset(%s) parent state failed: %v", tsk, err)
+	}
+	ssize, err := pci.SealProof.SectorSize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resolve size: %v", err)
+	}
+	store := msa.ChainReader.Store(ctx)
+	if out.QAPower, err = sectorQAPower(store, state, maddr, pci, ssize, ts.Height()); err != nil {
+		return nil, err
+	}
+
+	var powerSmoothed, rewardSmoothed builtin.FilterEstimate
+	if act, found, err := state.GetActor(ctx, power.Address); err != nil || !found {
+		return nil, fmt.Errorf("loading power actor: %v", err)
+	} else if s, err := power.Load(store, act); err != nil {
+		return nil, fmt.Errorf("loading power actor state: %v", err)
+	} else if powerSmoothed, err = s.TotalPowerSmoothed(); err != nil {
+		return nil, fmt.Errorf("failed to determine total power: %v", err)
+	}
+	if act, found, err := state.GetActor(ctx, reward.Address); err != nil || !found {
+		return nil, fmt.Errorf("loading reward actor: %v", err)
+	} else if s, err := reward.Load(store, act); err != nil {
+		return nil, fmt.Errorf("loading reward actor state: %v", err)
+	} else if rewardSmoothed, err = s.ThisEpochRewardSmoothed(); err != nil {
+		return nil, fmt.Errorf("failed to determine the smoothed reward: %v", err)
+	}
+
+	nv := msa.Fork.GetNetworkVersion(ctx, ts.Height())
+	if err := setSectorRewards(&out, nv, rewardSmoothed, powerSmoothed, pci.Expiration-ts.Height()); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// StateSectorBatchEstimate returns the bounds and the network fee of a batch of sector messages
+func (msa *minerStateAPI) StateSectorBatchEstimate(ctx context.Context, kind types.SectorBatchKind, size int, tsk types.TipSetKey) (*types.SectorBatchEstimate, error) {
+	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset %s: %v", tsk, err)
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid batch size %d", size)
+	}
+
+	nv := msa.Fork.GetNetworkVersion(ctx, ts.Height())
+	est := &types.SectorBatchEstimate{
+		Kind:           kind,
+		NetworkVersion: nv,
+		Size:           size,
+		BaseFee:        ts.Blocks()[0].ParentBaseFee,
+	}
+	switch kind {
+	case types.SectorBatchPreCommit:
+		if est.MinSize, est.MaxSize, err = lminer.PreCommitBatchBounds(nv); err != nil {
+			return nil, err
+		}
+		est.NetworkFee, err = policy.AggregatePreCommitNetworkFee(nv, size, est.BaseFee)
+	case types.SectorBatchProveCommit:
+		if est.MinSize, est.MaxSize, est.MaxProofSize, err = lminer.ProveCommitAggregateBounds(nv); err != nil {
+			return nil, err
+		}
+		est.NetworkFee, err = policy.AggregateProveCommitNetworkFee(nv, size, est.BaseFee)
+	default:
+		return nil, fmt.Errorf("unknown sector batch kind %q", kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("computing the network fee: %v", err)
+	}
+	est.NetworkFeePerSector = big.Div(est.NetworkFee, big.NewInt(int64(size)))
+
+	return est, nil
+}
+
 // StateVMCirculatingSupplyInternal returns an approximation of the circulating supply of Filecoin at the given tipset.
 // This is the value reported by the runtime interface to actors code.
 func (msa *minerStateAPI) StateVMCirculatingSupplyInternal(ctx context.Context, tsk types.TipSetKey) (types.CirculatingSupply, error) {
@@ -851,12 +1035,18 @@ func (msa *minerStateAPI) StateVMCirculatingSupplyInternal(ctx context.Context,
 		return types.CirculatingSupply{}, err
 	}
 
-	_, sTree, err := msa.Stmgr.ParentState(ctx, ts)
+	return msa.SupplyHistory.Supply(ctx, ts)
+}
+
+// StateSupplyHistory returns the circulating supply and the burnt funds every step epochs from from to to, on the
+// chain of tsk. The supplies are those reported to the actors, the ones already computed are not computed again.
+func (msa *minerStateAPI) StateSupplyHistory(ctx context.Context, from, to, step abi.ChainEpoch, tsk types.TipSetKey) ([]*types.SupplyPoint, error) {
+	head, err := msa.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
-		return types.CirculatingSupply{}, err
+		return nil, err
 	}
 
-	return msa.ChainReader.GetCirculatingSupplyDetailed(ctx, ts.Height(), sTree)
+	return msa.SupplyHistory.History(ctx, msa.ChainReader, head, from, to, step)
 }
 
 // StateCirculatingSupply returns the exact circulating supply of Filecoin at the given tipset.
@@ -874,18 +1064,30 @@ func (msa *minerStateAPI) StateCirculatingSupply(ctx context.Context, tsk types.
 
 // StateMarketDeals returns information about every deal in the Storage Market
 func (msa *minerStateAPI) StateMarketDeals(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error) {
-	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	reader, err := msa.StateReaders.Acquire(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%w", err)
+		return nil, err
+	}
+	defer reader.Release()
+
+	_, view, err := reader.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("ParentStateViewTsk failed:%w", err)
 	}
 	return view.StateMarketDeals(ctx, tsk)
 }
 
 // StateMinerActiveSectors returns info about sectors that a given miner is actively proving.
 func (msa *minerStateAPI) StateMinerActiveSectors(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error) { // TODO: only used in cli
-	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	reader, err := msa.StateReaders.Acquire(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+		return nil, err
+	}
+	defer reader.Release()
+
+	_, view, err := reader.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("ParentStateViewTsk failed:%v", err)
 	}
 	return view.StateMinerActiveSectors(ctx, maddr, tsk)
 }
@@ -900,6 +1102,20 @@ func (msa *minerStateAPI) StateLookupID(ctx context.Context, addr address.Addres
 	return state.LookupID(addr)
 }
 
+// StateLookupIDBySelector retrieves the ID address of the given address at the tipset selected by tss
+func (msa *minerStateAPI) StateLookupIDBySelector(ctx context.Context, addr address.Address, tss types.TipSetSelector) (address.Address, error) {
+	ts, err := msa.ChainReader.SelectTipSet(ctx, tss)
+	if err != nil {
+		return address.Undef, fmt.Errorf("selecting tipset: %w", err)
+	}
+	_, state, err := msa.Stmgr.ParentState(ctx, ts)
+	if err != nil {
+		return address.Undef, fmt.Errorf("load state failed: %v", err)
+	}
+
+	return state.LookupID(addr)
+}
+
 func (msa *minerStateAPI) StateLookupRobustAddress(ctx context.Context, idAddr address.Address, tsk types.TipSetKey) (address.Address, error) {
 	idAddrDecoded, err := address.IDFromAddress(idAddr)
 	if err != nil {
@@ -947,9 +1163,15 @@ func (msa *minerStateAPI) StateLookupRobustAddress(ctx context.Context, idAddr a
 
 // StateListMiners returns the addresses of every miner that has claimed power in the Power Actor
 func (msa *minerStateAPI) StateListMiners(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error) {
-	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	reader, err := msa.StateReaders.Acquire(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+		return nil, err
+	}
+	defer reader.Release()
+
+	_, view, err := reader.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("ParentStateViewTsk failed:%v", err)
 	}
 
 	return view.StateListMiners(ctx, tsk)
@@ -957,7 +1179,13 @@ func (msa *minerStateAPI) StateListMiners(ctx context.Context, tsk types.TipSetK
 
 // StateListActors returns the addresses of every actor in the state
 func (msa *minerStateAPI) StateListActors(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error) {
-	_, stat, err := msa.Stmgr.TipsetStateTsk(ctx, tsk)
+	reader, err := msa.StateReaders.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Release()
+
+	_, stat, err := reader.TipsetStateTsk(ctx, tsk)
 	if err != nil {
 		return nil, fmt.Errorf("load tipset state from key:%s failed:%v",
 			tsk.String(), err)
@@ -1018,9 +1246,15 @@ func (msa *minerStateAPI) StateSectorExpiration(ctx context.Context, maddr addre
 
 // StateMinerSectorCount returns the number of sectors in a miner's sector set and proving set
 func (msa *minerStateAPI) StateMinerSectorCount(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MinerSectors, error) {
-	_, view, err := msa.Stmgr.ParentStateViewTsk(ctx, tsk)
+	reader, err := msa.StateReaders.Acquire(ctx)
 	if err != nil {
-		return types.MinerSectors{}, fmt.Errorf("Stmgr.ParentStateViewTsk failed:%v", err)
+		return types.MinerSectors{}, err
+	}
+	defer reader.Release()
+
+	_, view, err := reader.ParentStateViewTsk(ctx, tsk)
+	if err != nil {
+		return types.MinerSectors{}, fmt.Errorf("ParentStateViewTsk failed:%v", err)
 	}
 
 	mas, err := view.LoadMinerState(ctx, addr)
@@ -1209,6 +1443,121 @@ func (msa *minerStateAPI) StateVerifiedClientStatus(ctx context.Context, addr ad
 	return &dcap, nil
 }
 
+// StateListVerifiers returns the verifiers with their remaining allowance
+func (msa *minerStateAPI) StateListVerifiers(ctx context.Context, tsk types.TipSetKey) ([]types.DataCapEntry, error) {
+	reader, err := msa.StateReaders.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Release()
+
+	_, _, view, err := reader.StateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading state view %s: %v", tsk, err)
+	}
+	vrs, err := view.LoadVerifregActor(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load verified registry state: %v", err)
+	}
+
+	var entries []types.DataCapEntry
+	err = vrs.ForEachVerifier(func(addr address.Address, dcap abi.StoragePower) error {
+		entries = append(entries, types.DataCapEntry{Address: addr, DataCap: dcap})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing verifiers: %w", err)
+	}
+	sortDataCapEntries(entries)
+	return entries, nil
+}
+
+// StateListVerifiedClients returns the verified clients with their datacap, held by the datacap actor from the actors v9
+func (msa *minerStateAPI) StateListVerifiedClients(ctx context.Context, tsk types.TipSetKey) ([]types.DataCapEntry, error) {
+	reader, err := msa.StateReaders.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Release()
+
+	_, _, view, err := reader.StateViewTsk(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading state view %s: %v", tsk, err)
+	}
+
+	nv, err := msa.ChainSubmodule.API().StateNetworkVersion(ctx, tsk)
+	if err != nil {
+		return nil, err
+	}
+	av, err := actorstypes.VersionForNetwork(nv)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []types.DataCapEntry
+	cb := func(addr address.Address, dcap abi.StoragePower) error {
+		entries = append(entries, types.DataCapEntry{Address: addr, DataCap: dcap})
+		return nil
+	}
+	var clients interface {
+		ForEachClient(func(addr address.Address, dcap abi.StoragePower) error) error
+	}
+	if av <= 8 {
+		if clients, err = view.LoadVerifregActor(ctx); err != nil {
+			return nil, fmt.Errorf("failed to load verified registry state: %v", err)
+		}
+	} else {
+		if clients, err = view.LoadDatacapState(ctx); err != nil {
+			return nil, fmt.Errorf("failed to load datacap actor state: %w", err)
+		}
+	}
+	if err := clients.ForEachClient(cb); err != nil {
+		return nil, fmt.Errorf("listing verified clients: %w", err)
+	}
+	sortDataCapEntries(entries)
+	return entries, nil
+}
+
+// StateRemoveDataCapProposalID returns the id of the next proposal to remove datacap of client, the verifiers sign
+// it so that a proposal is used once
+func (msa *minerStateAPI) StateRemoveDataCapProposalID(ctx context.Context, verifier address.Address, client address.Address, tsk types.TipSetKey) (uint64, error) {
+	_, _, view, err := msa.Stmgr.StateViewTsk(ctx, tsk)
+	if err != nil {
+		return 0, fmt.Errorf("loading state view %s: %v", tsk, err)
+	}
+	verifierID, err := view.LookupID(ctx, verifier)
+	if err != nil {
+		return 0, fmt.Errorf("loook up id of %s : %v", verifier, err)
+	}
+	clientID, err := view.LookupID(ctx, client)
+	if err != nil {
+		return 0, fmt.Errorf("loook up id of %s : %v", client, err)
+	}
+	vrs, err := view.LoadVerifregActor(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load verified registry state: %v", err)
+	}
+
+	// the first proposal of a pair has the id 0
+	_, id, err := vrs.RemoveDataCapProposalID(verifierID, clientID)
+	if err != nil {
+		return 0, fmt.Errorf("looking up the remove datacap proposal id: %w", err)
+	}
+	return id, nil
+}
+
+func sortDataCapEntries(entries []types.DataCapEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].Address, entries[j].Address
+		if a.Protocol() == address.ID && b.Protocol() == address.ID {
+			aid, _ := address.IDFromAddress(a)
+			bid, _ := address.IDFromAddress(b)
+			return aid < bid
+		}
+		return a.String() < b.String()
+	})
+}
+
 func (msa *minerStateAPI) StateChangedActors(ctx context.Context, old cid.Cid, new cid.Cid) (map[string]types.Actor, error) {
 	store := msa.ChainReader.Store(ctx)
 
@@ -1259,6 +1608,10 @@ func (msa *minerStateAPI) StateDecodeParams(ctx context.Context, toAddr address.
 		return nil, fmt.Errorf("loading tipset:%s parent state view: %v", tsk, err)
 	}
 
+	return decodeMethodParams(ctx, view, toAddr, method, params)
+}
+
+func decodeMethodParams(ctx context.Context, view *appstate.View, toAddr address.Address, method abi.MethodNum, params []byte) (interface{}, error) {
 	act, err := view.LoadActor(ctx, toAddr)
 	if err != nil {
 		return nil, err
@@ -1299,64 +1652,113 @@ func (msa *minerStateAPI) StateEncodeParams(ctx context.Context, toActCode cid.C
 }
 
 func (msa *minerStateAPI) StateListMessages(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toheight abi.ChainEpoch) ([]cid.Cid, error) {
+	var out []cid.Cid
+	err := msa.walkMatchedMessages(ctx, match, tsk, toheight, func(ts *types.TipSet, msg types.ChainMsg) error {
+		out = append(out, msg.Cid())
+		return nil
+	})
+	return out, err
+}
+
+// StateListMatchedMessages looks back and returns the messages matching the filter with their tipset, stopping at
+// the given height
+func (msa *minerStateAPI) StateListMatchedMessages(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toheight abi.ChainEpoch, decodeParams bool) ([]*types.MatchedMessage, error) {
+	var (
+		out     []*types.MatchedMessage
+		viewTS  *types.TipSet
+		view    *appstate.View
+		viewErr error
+	)
+	err := msa.walkMatchedMessages(ctx, match, tsk, toheight, func(ts *types.TipSet, msg types.ChainMsg) error {
+		vmsg := msg.VMMessage()
+		matched := &types.MatchedMessage{
+			Cid:     msg.Cid(),
+			Height:  ts.Height(),
+			TipSet:  ts.Key(),
+			Message: vmsg,
+		}
+		out = append(out, matched)
+
+		if !decodeParams || len(vmsg.Params) == 0 {
+			return nil
+		}
+		// the messages of a tipset are decoded against its parent state, loaded once per tipset
+		if viewTS != ts {
+			viewTS = ts
+			_, view, viewErr = msa.Stmgr.ParentStateView(ctx, ts)
+		}
+		if viewErr != nil {
+			matched.DecodeErr = viewErr.Error()
+			return nil
+		}
+		params, err := decodeMethodParams(ctx, view, vmsg.To, vmsg.Method, vmsg.Params)
+		if err != nil {
+			matched.DecodeErr = err.Error()
+			return nil
+		}
+		matched.DecodedParams = params
+		return nil
+	})
+	return out, err
+}
+
+// walkMatchedMessages calls cb with the messages matching the filter, from the tipset down to the given height
+func (msa *minerStateAPI) walkMatchedMessages(ctx context.Context,
+	match *types.MessageMatch,
+	tsk types.TipSetKey,
+	toheight abi.ChainEpoch,
+	cb func(*types.TipSet, types.ChainMsg) error,
+) error {
 	ts, err := msa.ChainReader.GetTipSet(ctx, tsk)
 	if err != nil {
-		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
+		return fmt.Errorf("loading tipset %s: %w", tsk, err)
 	}
 
 	if ts == nil {
 		ts = msa.ChainReader.GetHead()
 	}
 
-	if match.To == address.Undef && match.From == address.Undef {
-		return nil, fmt.Errorf("must specify at least To or From in message filter")
-	} else if match.To != address.Undef {
-		_, err := msa.StateLookupID(ctx, match.To, tsk)
-
-		// if the recipient doesn't exist at the start point, we're not gonna find any matches
-		if errors.Is(err, types.ErrActorNotFound) {
-			return nil, nil
-		}
-
-		if err != nil {
-			return nil, fmt.Errorf("looking up match.To: %w", err)
+	if match.To == address.Undef && match.From == address.Undef && match.Address == address.Undef {
+		return fmt.Errorf("must specify at least To, From or Address in message filter")
+	}
+	for _, addr := range []address.Address{match.To, match.From, match.Address} {
+		if addr == address.Undef {
+			continue
 		}
-	} else if match.From != address.Undef {
-		_, err := msa.StateLookupID(ctx, match.From, tsk)
+		_, err := msa.StateLookupID(ctx, addr, tsk)
 
-		// if the sender doesn't exist at the start point, we're not gonna find any matches
+		// if the address doesn't exist at the start point, we're not gonna find any matches
 		if errors.Is(err, types.ErrActorNotFound) {
-			return nil, nil
+			return nil
 		}
 
 		if err != nil {
-			return nil, fmt.Errorf("looking up match.From: %w", err)
+			return fmt.Errorf("looking up %s: %w", addr, err)
 		}
 	}
+	switch match.Direction {
+	case types.MessageDirectionBoth, types.MessageDirectionIn, types.MessageDirectionOut:
+	default:
+		return fmt.Errorf("unknown message direction %q", match.Direction)
+	}
 
-	// TODO: This should probably match on both ID and robust address, no?
-	matchFunc := func(msg *types.Message) bool {
-		if match.From != address.Undef && match.From != msg.From {
-			return false
-		}
-
-		if match.To != address.Undef && match.To != msg.To {
-			return false
+	if match.MaxHeight != nil && ts.Height() > *match.MaxHeight {
+		if ts, err = msa.ChainReader.GetTipSetByHeight(ctx, ts, *match.MaxHeight, true); err != nil {
+			return fmt.Errorf("loading tipset at height %d: %w", *match.MaxHeight, err)
 		}
-
-		return true
 	}
 
-	var out []cid.Cid
 	for ts.Height() >= toheight {
 		msgs, err := msa.MessageStore.MessagesForTipset(ts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get messages for tipset (%s): %w", ts.Key(), err)
+			return fmt.Errorf("failed to get messages for tipset (%s): %w", ts.Key(), err)
 		}
 
 		for _, msg := range msgs {
-			if matchFunc(msg.VMMessage()) {
-				out = append(out, msg.Cid())
+			if messageMatches(match, msg.VMMessage()) {
+				if err := cb(ts, msg); err != nil {
+					return err
+				}
 			}
 		}
 
@@ -1366,13 +1768,64 @@ func (msa *minerStateAPI) StateListMessages(ctx context.Context, match *types.Me
 
 		next, err := msa.ChainReader.GetTipSet(ctx, ts.Parents())
 		if err != nil {
-			return nil, fmt.Errorf("loading next tipset: %w", err)
+			return fmt.Errorf("loading next tipset: %w", err)
 		}
 
 		ts = next
 	}
 
-	return out, nil
+	return nil
+}
+
+// TODO: This should probably match on both ID and robust address, no?
+func messageMatches(match *types.MessageMatch, msg *types.Message) bool {
+	if match.From != address.Undef && match.From != msg.From {
+		return false
+	}
+
+	if match.To != address.Undef && match.To != msg.To {
+		return false
+	}
+
+	if match.Address != address.Undef {
+		in, out := match.Address == msg.To, match.Address == msg.From
+		switch match.Direction {
+		case types.MessageDirectionIn:
+			if !in {
+				return false
+			}
+		case types.MessageDirectionOut:
+			if !out {
+				return false
+			}
+		default:
+			if !in && !out {
+				return false
+			}
+		}
+	}
+
+	if len(match.Methods) > 0 {
+		found := false
+		for _, method := range match.Methods {
+			if method == msg.Method {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if match.MinValue != nil && msg.Value.LessThan(*match.MinValue) {
+		return false
+	}
+	if match.MaxValue != nil && msg.Value.GreaterThan(*match.MaxValue) {
+		return false
+	}
+
+	return true
 }
 
 // StateMinerAllocated returns a bitfield containing all sector numbers marked as allocated in miner state
@@ -0,0 +1,39 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestMessageMatches(t *testing.T) {
+	tf.UnitTest(t)
+
+	alice, _ := address.NewIDAddress(100)
+	bob, _ := address.NewIDAddress(101)
+	msg := &types.Message{From: alice, To: bob, Method: builtin.MethodSend, Value: abi.NewTokenAmount(50)}
+
+	require.True(t, messageMatches(&types.MessageMatch{From: alice}, msg))
+	require.False(t, messageMatches(&types.MessageMatch{To: alice}, msg))
+
+	// the direction of the address
+	require.True(t, messageMatches(&types.MessageMatch{Address: alice}, msg))
+	require.True(t, messageMatches(&types.MessageMatch{Address: bob}, msg))
+	require.True(t, messageMatches(&types.MessageMatch{Address: alice, Direction: types.MessageDirectionOut}, msg))
+	require.False(t, messageMatches(&types.MessageMatch{Address: alice, Direction: types.MessageDirectionIn}, msg))
+
+	require.True(t, messageMatches(&types.MessageMatch{From: alice, Methods: []abi.MethodNum{builtin.MethodSend, 2}}, msg))
+	require.False(t, messageMatches(&types.MessageMatch{From: alice, Methods: []abi.MethodNum{2}}, msg))
+
+	// the bounds of the value are included
+	low, high := abi.NewTokenAmount(50), abi.NewTokenAmount(49)
+	require.True(t, messageMatches(&types.MessageMatch{From: alice, MinValue: &low}, msg))
+	require.False(t, messageMatches(&types.MessageMatch{From: alice, MaxValue: &high}, msg))
+	require.True(t, messageMatches(&types.MessageMatch{From: alice, MaxValue: &low}, msg))
+}
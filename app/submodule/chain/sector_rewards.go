@@ -0,0 +1,111 @@
+package chain
+
+import (
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	miner0 "github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	smoothing0 "github.com/filecoin-project/specs-actors/actors/util/smoothing"
+	miner2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/miner"
+	smoothing2 "github.com/filecoin-project/specs-actors/v2/actors/util/smoothing"
+	miner3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
+	smoothing3 "github.com/filecoin-project/specs-actors/v3/actors/util/smoothing"
+	miner4 "github.com/filecoin-project/specs-actors/v4/actors/builtin/miner"
+	smoothing4 "github.com/filecoin-project/specs-actors/v4/actors/util/smoothing"
+	miner5 "github.com/filecoin-project/specs-actors/v5/actors/builtin/miner"
+	smoothing5 "github.com/filecoin-project/specs-actors/v5/actors/util/smoothing"
+	miner6 "github.com/filecoin-project/specs-actors/v6/actors/builtin/miner"
+	smoothing6 "github.com/filecoin-project/specs-actors/v6/actors/util/smoothing"
+	miner7 "github.com/filecoin-project/specs-actors/v7/actors/builtin/miner"
+	smoothing7 "github.com/filecoin-project/specs-actors/v7/actors/util/smoothing"
+	miner8 "github.com/filecoin-project/specs-actors/v8/actors/builtin/miner"
+	smoothing8 "github.com/filecoin-project/specs-actors/v8/actors/util/smoothing"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// setSectorRewards sets the expected rewards of the sector of out and its termination penalties, right after its
+// activation and after lifetime epochs, as computed by the miner actor of the network version. The builtin actors
+// from v9 on compute them as v8, go-state-types has no termination penalty of its own.
+func setSectorRewards(out *types.SectorCollateral, nv network.Version, rewardSmoothed, powerSmoothed builtin.FilterEstimate, lifetime abi.ChainEpoch) error {
+	av, err := actorstypes.VersionForNetwork(nv)
+	if err != nil {
+		return err
+	}
+
+	switch av {
+	case actorstypes.Version0:
+		reward, power := smoothing0.FilterEstimate(rewardSmoothed), smoothing0.FilterEstimate(powerSmoothed)
+		out.ExpectedDayReward = miner0.ExpectedRewardForPower(&reward, &power, out.QAPower, builtin.EpochsInDay)
+		out.ExpectedStoragePledge = miner0.ExpectedRewardForPower(&reward, &power, out.QAPower, miner0.InitialPledgeProjectionPeriod)
+		penalty := func(age abi.ChainEpoch) abi.TokenAmount {
+			return miner0.PledgePenaltyForTermination(out.ExpectedDayReward, out.ExpectedStoragePledge, age, &reward, &power, out.QAPower, nv)
+		}
+		out.TerminationPenalty, out.MaxTerminationPenalty = penalty(0), penalty(lifetime)
+	case actorstypes.Version2:
+		reward, power := smoothing2.FilterEstimate(rewardSmoothed), smoothing2.FilterEstimate(powerSmoothed)
+		out.ExpectedDayReward = miner2.ExpectedRewardForPower(reward, power, out.QAPower, builtin.EpochsInDay)
+		out.ExpectedStoragePledge = miner2.ExpectedRewardForPower(reward, power, out.QAPower, miner2.InitialPledgeProjectionPeriod)
+		penalty := func(age abi.ChainEpoch) abi.TokenAmount {
+			return miner2.PledgePenaltyForTermination(out.ExpectedDayReward, age, out.ExpectedStoragePledge, power, out.QAPower, reward, big.Zero(), 0)
+		}
+		out.TerminationPenalty, out.MaxTerminationPenalty = penalty(0), penalty(lifetime)
+	case actorstypes.Version3:
+		reward, power := smoothing3.FilterEstimate(rewardSmoothed), smoothing3.FilterEstimate(powerSmoothed)
+		out.ExpectedDayReward = miner3.ExpectedRewardForPower(reward, power, out.QAPower, builtin.EpochsInDay)
+		out.ExpectedStoragePledge = miner3.ExpectedRewardForPower(reward, power, out.QAPower, miner3.InitialPledgeProjectionPeriod)
+		penalty := func(age abi.ChainEpoch) abi.TokenAmount {
+			return miner3.PledgePenaltyForTermination(out.ExpectedDayReward, age, out.ExpectedStoragePledge, power, out.QAPower, reward, big.Zero(), 0)
+		}
+		out.TerminationPenalty, out.MaxTerminationPenalty = penalty(0), penalty(lifetime)
+	case actorstypes.Version4:
+		reward, power := smoothing4.FilterEstimate(rewardSmoothed), smoothing4.FilterEstimate(powerSmoothed)
+		out.ExpectedDayReward = miner4.ExpectedRewardForPower(reward, power, out.QAPower, builtin.EpochsInDay)
+		out.ExpectedStoragePledge = miner4.ExpectedRewardForPower(reward, power, out.QAPower, miner4.InitialPledgeProjectionPeriod)
+		penalty := func(age abi.ChainEpoch) abi.TokenAmount {
+			return miner4.PledgePenaltyForTermination(out.ExpectedDayReward, age, out.ExpectedStoragePledge, power, out.QAPower, reward, big.Zero(), 0)
+		}
+		out.TerminationPenalty, out.MaxTerminationPenalty = penalty(0), penalty(lifetime)
+	case actorstypes.Version5:
+		reward, power := smoothing5.FilterEstimate(rewardSmoothed), smoothing5.FilterEstimate(powerSmoothed)
+		out.ExpectedDayReward = miner5.ExpectedRewardForPower(reward, power, out.QAPower, builtin.EpochsInDay)
+		out.ExpectedStoragePledge = miner5.ExpectedRewardForPower(reward, power, out.QAPower, miner5.InitialPledgeProjectionPeriod)
+		penalty := func(age abi.ChainEpoch) abi.TokenAmount {
+			return miner5.PledgePenaltyForTermination(out.ExpectedDayReward, age, out.ExpectedStoragePledge, power, out.QAPower, reward, big.Zero(), 0)
+		}
+		out.TerminationPenalty, out.MaxTerminationPenalty = penalty(0), penalty(lifetime)
+	case actorstypes.Version6:
+		reward, power := smoothing6.FilterEstimate(rewardSmoothed), smoothing6.FilterEstimate(powerSmoothed)
+		out.ExpectedDayReward = miner6.ExpectedRewardForPower(reward, power, out.QAPower, builtin.EpochsInDay)
+		out.ExpectedStoragePledge = miner6.ExpectedRewardForPower(reward, power, out.QAPower, miner6.InitialPledgeProjectionPeriod)
+		penalty := func(age abi.ChainEpoch) abi.TokenAmount {
+			return miner6.PledgePenaltyForTermination(out.ExpectedDayReward, age, out.ExpectedStoragePledge, power, out.QAPower, reward, big.Zero(), 0)
+		}
+		out.TerminationPenalty, out.MaxTerminationPenalty = penalty(0), penalty(lifetime)
+	case actorstypes.Version7:
+		reward, power := smoothing7.FilterEstimate(rewardSmoothed), smoothing7.FilterEstimate(powerSmoothed)
+		out.ExpectedDayReward = miner7.ExpectedRewardForPower(reward, power, out.QAPower, builtin.EpochsInDay)
+		out.ExpectedStoragePledge = miner7.ExpectedRewardForPower(reward, power, out.QAPower, miner7.InitialPledgeProjectionPeriod)
+		penalty := func(age abi.ChainEpoch) abi.TokenAmount {
+			return miner7.PledgePenaltyForTermination(out.ExpectedDayReward, age, out.ExpectedStoragePledge, power, out.QAPower, reward, big.Zero(), 0)
+		}
+		out.TerminationPenalty, out.MaxTerminationPenalty = penalty(0), penalty(lifetime)
+	case actorstypes.Version8, actorstypes.Version9, actorstypes.Version10, actorstypes.Version11, actorstypes.Version12,
+		actorstypes.Version13:
+		reward, power := smoothing8.FilterEstimate(rewardSmoothed), smoothing8.FilterEstimate(powerSmoothed)
+		out.ExpectedDayReward = miner8.ExpectedRewardForPower(reward, power, out.QAPower, builtin.EpochsInDay)
+		out.ExpectedStoragePledge = miner8.ExpectedRewardForPower(reward, power, out.QAPower, miner8.InitialPledgeProjectionPeriod)
+		penalty := func(age abi.ChainEpoch) abi.TokenAmount {
+			return miner8.PledgePenaltyForTermination(out.ExpectedDayReward, age, out.ExpectedStoragePledge, power, out.QAPower, reward, big.Zero(), 0)
+		}
+		out.TerminationPenalty, out.MaxTerminationPenalty = penalty(0), penalty(lifetime)
+	default:
+		return fmt.Errorf("the sector rewards of actors version %d are not known", av)
+	}
+	return nil
+}
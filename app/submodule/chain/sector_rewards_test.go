@@ -0,0 +1,44 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestSetSectorRewards(t *testing.T) {
+	tf.UnitTest(t)
+
+	// the estimates are Q.128 fixed point numbers: 20 FIL per epoch for 10 EiB of quality adjusted power
+	q128 := func(v big.Int) big.Int { return big.Lsh(v, 128) }
+	reward := builtin.FilterEstimate{PositionEstimate: q128(big.Mul(big.NewInt(20), big.NewInt(1e18))), VelocityEstimate: big.Zero()}
+	power := builtin.FilterEstimate{PositionEstimate: q128(big.Lsh(big.NewInt(10), 60)), VelocityEstimate: big.Zero()}
+	lifetime := abi.ChainEpoch(540 * builtin.EpochsInDay)
+
+	byVersion := make(map[network.Version]*types.SectorCollateral)
+	for nv := network.Version0; nv <= network.Version22; nv++ {
+		out := &types.SectorCollateral{QAPower: abi.NewStoragePower(32 << 30)}
+		require.NoError(t, setSectorRewards(out, nv, reward, power, lifetime), "network version %d", nv)
+		assert.True(t, out.ExpectedDayReward.GreaterThan(big.Zero()), "network version %d", nv)
+		assert.True(t, out.ExpectedStoragePledge.GreaterThan(out.ExpectedDayReward), "network version %d", nv)
+		assert.True(t, out.TerminationPenalty.GreaterThan(big.Zero()), "network version %d", nv)
+		assert.True(t, out.MaxTerminationPenalty.GreaterThan(out.TerminationPenalty), "network version %d", nv)
+		byVersion[nv] = out
+	}
+
+	// the builtin actors compute the rewards and the penalties as the actors v8
+	for nv := network.Version17; nv <= network.Version22; nv++ {
+		assert.Equal(t, byVersion[network.Version16], byVersion[nv], "network version %d", nv)
+	}
+
+	err := setSectorRewards(&types.SectorCollateral{QAPower: abi.NewStoragePower(32 << 30)}, network.Version22+10, reward, power, lifetime)
+	assert.Error(t, err)
+}
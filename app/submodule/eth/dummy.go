@@ -13,7 +13,7 @@ import (
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
-var ErrModuleDisabled = errors.New("module disabled, enable with Fevm.EnableEthRPC / VENUS_FEVM_ENABLEETHRPC")
+var ErrModuleDisabled = errors.New("module disabled, enable with Fevm.EnableEthRPC / VENUS_FEVM_ENABLE_ETH_RPC")
 
 type ethAPIDummy struct{}
 
@@ -153,6 +153,18 @@ func (e *ethAPIDummy) EthTraceReplayBlockTransactions(ctx context.Context, blkNu
 	return nil, ErrModuleDisabled
 }
 
+func (e *ethAPIDummy) EthTraceTransaction(ctx context.Context, txHash string) ([]*types.EthTraceTransaction, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthDebugTraceTransaction(ctx context.Context, p jsonrpc.RawParams) (*types.EthCallFrame, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthDebugTraceBlockByNumber(ctx context.Context, p jsonrpc.RawParams) ([]*types.EthTxTraceResult, error) {
+	return nil, ErrModuleDisabled
+}
+
 func (e *ethAPIDummy) start(_ context.Context) error {
 	return nil
 }
@@ -1198,6 +1198,109 @@ func (a *ethAPI) EthTraceReplayBlockTransactions(ctx context.Context, blkNum str
 	return allTraces, nil
 }
 
+func (a *ethAPI) EthTraceTransaction(ctx context.Context, txHash string) ([]*types.EthTraceTransaction, error) {
+	hash, err := types.ParseEthHash(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse eth hash: %w", err)
+	}
+
+	tx, err := a.EthGetTransactionByHash(ctx, &hash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get transaction by hash: %w", err)
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("transaction not found")
+	}
+	// the block number is not set while the transaction is pending
+	if tx.BlockNumber == nil {
+		return nil, fmt.Errorf("no trace for pending transactions")
+	}
+
+	blockTraces, err := a.EthTraceBlock(ctx, strconv.FormatUint(uint64(*tx.BlockNumber), 10))
+	if err != nil {
+		return nil, fmt.Errorf("failed to trace block: %w", err)
+	}
+
+	txTraces := make([]*types.EthTraceTransaction, 0, len(blockTraces))
+	for _, blockTrace := range blockTraces {
+		if blockTrace.TransactionHash == hash {
+			txTraces = append(txTraces, &types.EthTraceTransaction{
+				EthTrace:            blockTrace.EthTrace,
+				BlockHash:           blockTrace.BlockHash,
+				BlockNumber:         blockTrace.BlockNumber,
+				TransactionHash:     blockTrace.TransactionHash,
+				TransactionPosition: blockTrace.TransactionPosition,
+			})
+		}
+	}
+	return txTraces, nil
+}
+
+func (a *ethAPI) EthDebugTraceTransaction(ctx context.Context, p jsonrpc.RawParams) (*types.EthCallFrame, error) {
+	params, err := jsonrpc.DecodeParams[types.EthDebugTraceParams](p)
+	if err != nil {
+		return nil, fmt.Errorf("decoding params: %w", err)
+	}
+	onlyTopCall, err := checkTraceConfig(params.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	txTraces, err := a.EthTraceTransaction(ctx, params.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	traces := make([]*types.EthTrace, 0, len(txTraces))
+	for _, t := range txTraces {
+		traces = append(traces, t.EthTrace)
+	}
+	return buildCallFrame(traces, onlyTopCall)
+}
+
+func (a *ethAPI) EthDebugTraceBlockByNumber(ctx context.Context, p jsonrpc.RawParams) ([]*types.EthTxTraceResult, error) {
+	params, err := jsonrpc.DecodeParams[types.EthDebugTraceParams](p)
+	if err != nil {
+		return nil, fmt.Errorf("decoding params: %w", err)
+	}
+	onlyTopCall, err := checkTraceConfig(params.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	blockTraces, err := a.EthTraceBlock(ctx, params.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	// the traces of a transaction follow each other
+	var results []*types.EthTxTraceResult
+	var traces []*types.EthTrace
+	flush := func(hash types.EthHash) error {
+		frame, err := buildCallFrame(traces, onlyTopCall)
+		if err != nil {
+			return fmt.Errorf("transaction %s: %w", hash, err)
+		}
+		results = append(results, &types.EthTxTraceResult{TxHash: hash, Result: frame})
+		traces = traces[:0]
+		return nil
+	}
+	for i, blockTrace := range blockTraces {
+		if i > 0 && blockTrace.TransactionHash != blockTraces[i-1].TransactionHash {
+			if err := flush(blockTraces[i-1].TransactionHash); err != nil {
+				return nil, err
+			}
+		}
+		traces = append(traces, blockTrace.EthTrace)
+	}
+	if len(traces) > 0 {
+		if err := flush(blockTraces[len(blockTraces)-1].TransactionHash); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
 func calculateRewardsAndGasUsed(rewardPercentiles []float64, txGasRewards gasRewardSorter) ([]types.EthBigInt, int64) {
 	var gasUsedTotal int64
 	for _, tx := range txGasRewards {
@@ -49,7 +49,7 @@ func newEthEventAPI(ctx context.Context, em *EthSubModule) (*ethEventAPI, error)
 		ChainAPI:     chainAPI,
 		messageStore: ee.em.chainModule.MessageStore,
 	}
-	ee.FilterStore = filter.NewMemFilterStore(cfg.Event.MaxFilters)
+	ee.FilterStore = filter.NewMemFilterStore(cfg.Event.MaxFilters, cfg.Event.MaxFiltersPerToken)
 
 	// Enable indexing of actor events
 	var eventIndex *filter.EventIndex
@@ -329,7 +329,7 @@ func (e *ethEventAPI) EthNewFilter(ctx context.Context, filterSpec *types.EthFil
 
 	if err := e.FilterStore.Add(ctx, f); err != nil {
 		// Could not record in store, attempt to delete filter to clean up
-		err2 := e.TipSetFilterManager.Remove(ctx, f.ID())
+		err2 := e.EventFilterManager.Remove(ctx, f.ID())
 		if err2 != nil {
 			return types.EthFilterID{}, fmt.Errorf("encountered error %v while removing new filter due to %v", err2, err)
 		}
@@ -533,7 +533,12 @@ func (e *ethEventAPI) GC(ctx context.Context, ttl time.Duration) {
 		return
 	}
 
-	tt := time.NewTicker(time.Minute * 30)
+	// check at least once per ttl window, so that short ttls are honoured
+	interval := time.Minute * 30
+	if ttl > 0 && ttl < interval {
+		interval = ttl
+	}
+	tt := time.NewTicker(interval)
 	defer tt.Stop()
 
 	for {
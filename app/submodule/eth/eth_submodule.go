@@ -9,7 +9,6 @@ import (
 	"github.com/filecoin-project/venus/app/submodule/chain"
 	"github.com/filecoin-project/venus/app/submodule/mpool"
 	"github.com/filecoin-project/venus/pkg/config"
-	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/events/filter"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 )
@@ -38,7 +37,7 @@ func NewEthSubModule(ctx context.Context,
 	em.ethEventAPI = ee
 
 	em.ethAPIAdapter = &ethAPIDummy{}
-	if em.cfg.FevmConfig.EnableEthRPC || constants.FevmEnableEthRPC {
+	if em.cfg.FevmConfig.EnableEthRPC {
 		log.Debug("enable eth rpc")
 		em.ethAPIAdapter, err = newEthAPI(em)
 		if err != nil {
@@ -3,6 +3,7 @@ package eth
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	cbg "github.com/whyrusleeping/cbor-gen"
 
+	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/venus/pkg/messagepool"
@@ -292,3 +294,79 @@ func TestDecodePayload(t *testing.T) {
 	_, err = decodePayload(w.Bytes(), 42)
 	require.Error(t, err)
 }
+
+func TestBuildCallFrame(t *testing.T) {
+	from, to, child := types.EthAddress{1}, types.EthAddress{2}, types.EthAddress{3}
+	call := func(callType string, from, to types.EthAddress, addr ...int) *types.EthTrace {
+		return &types.EthTrace{
+			Type:         "call",
+			TraceAddress: addr,
+			Action:       &types.EthCallTraceAction{CallType: callType, From: from, To: to, Gas: 100},
+			Result:       &types.EthCallTraceResult{GasUsed: 10},
+		}
+	}
+	traces := []*types.EthTrace{
+		call("call", from, to),
+		call("staticcall", to, child, 0),
+		call("call", child, from, 0, 0),
+		call("delegatecall", to, child, 1),
+	}
+
+	root, err := buildCallFrame(traces, false)
+	require.NoError(t, err)
+	require.Equal(t, "CALL", root.Type)
+	require.Equal(t, from, root.From)
+	require.Equal(t, to, *root.To)
+	require.NotNil(t, root.Value)
+	require.Len(t, root.Calls, 2)
+	require.Equal(t, "STATICCALL", root.Calls[0].Type)
+	require.Nil(t, root.Calls[0].Value)
+	require.Len(t, root.Calls[0].Calls, 1)
+	require.Equal(t, from, *root.Calls[0].Calls[0].To)
+	require.Equal(t, "DELEGATECALL", root.Calls[1].Type)
+
+	root, err = buildCallFrame(traces, true)
+	require.NoError(t, err)
+	require.Empty(t, root.Calls)
+
+	// a call without its parent
+	_, err = buildCallFrame([]*types.EthTrace{traces[0], traces[2]}, false)
+	require.Error(t, err)
+	_, err = buildCallFrame(traces[1:], false)
+	require.Error(t, err)
+}
+
+func TestCheckTraceConfig(t *testing.T) {
+	onlyTop, err := checkTraceConfig(nil)
+	require.NoError(t, err)
+	require.False(t, onlyTop)
+
+	onlyTop, err = checkTraceConfig(&types.EthTraceConfig{Tracer: "callTracer", TracerConfig: &types.EthCallTracerConfig{OnlyTopCall: true}})
+	require.NoError(t, err)
+	require.True(t, onlyTop)
+
+	_, err = checkTraceConfig(&types.EthTraceConfig{Tracer: "prestateTracer"})
+	require.Error(t, err)
+}
+
+func TestEthDebugTraceParams(t *testing.T) {
+	// the tracer config is optional, as in the calls of the ethereum tooling
+	params, err := jsonrpc.DecodeParams[types.EthDebugTraceParams](jsonrpc.RawParams(`["0x01"]`))
+	require.NoError(t, err)
+	require.Equal(t, "0x01", params.Target)
+	require.Nil(t, params.Config)
+
+	params, err = jsonrpc.DecodeParams[types.EthDebugTraceParams](jsonrpc.RawParams(`["latest",{"tracer":"callTracer","tracerConfig":{"onlyTopCall":true}}]`))
+	require.NoError(t, err)
+	require.Equal(t, "latest", params.Target)
+	require.Equal(t, &types.EthTraceConfig{Tracer: "callTracer", TracerConfig: &types.EthCallTracerConfig{OnlyTopCall: true}}, params.Config)
+
+	data, err := json.Marshal(params)
+	require.NoError(t, err)
+	require.JSONEq(t, `["latest",{"tracer":"callTracer","tracerConfig":{"onlyTopCall":true}}]`, string(data))
+
+	for _, raw := range []string{`[]`, `["0x01",{},1]`, `[1]`} {
+		_, err = jsonrpc.DecodeParams[types.EthDebugTraceParams](jsonrpc.RawParams(raw))
+		require.Error(t, err, raw)
+	}
+}
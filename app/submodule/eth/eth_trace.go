@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/multiformats/go-multicodec"
 	cbg "github.com/whyrusleeping/cbor-gen"
@@ -603,3 +604,84 @@ func traceEVMPrivate(env *environment, addr []int, et *types.ExecutionTrace) (*t
 	// 1024 (exclusive), so any calls in this range must be implementation details.
 	return nil, nil, nil
 }
+
+// checkTraceConfig refuses the tracers other than the "callTracer", and returns whether only the top call is traced
+func checkTraceConfig(config *types.EthTraceConfig) (bool, error) {
+	if config == nil {
+		return false, nil
+	}
+	if config.Tracer != "" && config.Tracer != "callTracer" {
+		return false, fmt.Errorf("tracer %q is not supported, only the callTracer is", config.Tracer)
+	}
+	return config.TracerConfig != nil && config.TracerConfig.OnlyTopCall, nil
+}
+
+func traceAddressKey(addr []int) string {
+	return fmt.Sprint(addr)
+}
+
+// buildCallFrame nests the traces of a transaction, in the order they are built, into the call frames of the
+// "callTracer" of geth
+func buildCallFrame(traces []*types.EthTrace, onlyTopCall bool) (*types.EthCallFrame, error) {
+	if len(traces) == 0 || len(traces[0].TraceAddress) != 0 {
+		return nil, fmt.Errorf("no top call in the traces")
+	}
+
+	root := callFrame(traces[0])
+	if onlyTopCall {
+		return root, nil
+	}
+
+	frames := map[string]*types.EthCallFrame{traceAddressKey(nil): root}
+	for _, trace := range traces[1:] {
+		addr := trace.TraceAddress
+		if len(addr) == 0 {
+			return nil, fmt.Errorf("more than one top call in the traces")
+		}
+		parent, ok := frames[traceAddressKey(addr[:len(addr)-1])]
+		if !ok {
+			return nil, fmt.Errorf("no parent for the call at %v", addr)
+		}
+
+		frame := callFrame(trace)
+		parent.Calls = append(parent.Calls, frame)
+		frames[traceAddressKey(addr)] = frame
+	}
+	return root, nil
+}
+
+func callFrame(trace *types.EthTrace) *types.EthCallFrame {
+	frame := &types.EthCallFrame{Error: trace.Error}
+
+	switch action := trace.Action.(type) {
+	case *types.EthCallTraceAction:
+		frame.Type = strings.ToUpper(action.CallType)
+		frame.From = action.From
+		to := action.To
+		frame.To = &to
+		if action.CallType != "staticcall" {
+			value := action.Value
+			frame.Value = &value
+		}
+		frame.Gas = action.Gas
+		frame.Input = action.Input
+	case *types.EthCreateTraceAction:
+		frame.Type = "CREATE"
+		frame.From = action.From
+		value := action.Value
+		frame.Value = &value
+		frame.Gas = action.Gas
+		frame.Input = action.Init
+	}
+
+	switch result := trace.Result.(type) {
+	case *types.EthCallTraceResult:
+		frame.GasUsed = result.GasUsed
+		frame.Output = result.Output
+	case *types.EthCreateTraceResult:
+		frame.To = result.Address
+		frame.GasUsed = result.GasUsed
+		frame.Output = result.Code
+	}
+	return frame
+}
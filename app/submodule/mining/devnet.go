@@ -0,0 +1,252 @@
+package mining
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	acrypto "github.com/filecoin-project/go-state-types/crypto"
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/consensus"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var log = logging.Logger("mining")
+
+// headWaitTimeout bounds the wait for the local chain to take the block produced as its head
+const headWaitTimeout = time.Minute
+
+// ErrDevnetMiningDisabled is returned when the node is not allowed to produce blocks itself
+var ErrDevnetMiningDisabled = errors.New("devnet mining is disabled")
+
+// devnetMiner produces the blocks of a miner on the local networks, where the winning PoSts are not verified, so
+// that the tests do not wait for the block time. It skips the rounds the miner loses as null rounds, and it produces
+// the blocks as fast as they are validated, the validators of these networks accept a chain ahead of the wall clock.
+type devnetMiner struct {
+	m   *MiningModule
+	cfg *config.DevnetMiningConfig
+
+	// one block at a time, the blocks of two rounds would get the miner slashed
+	lk sync.Mutex
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newDevnetMiner(m *MiningModule) *devnetMiner {
+	return &devnetMiner{m: m, cfg: m.Config.Repo().Config().DevnetMining}
+}
+
+// allowed returns an error unless the config enables the devnet mining on a local network validating the winning
+// PoSts insecurely
+func (dm *devnetMiner) allowed() error {
+	if dm.cfg == nil || !dm.cfg.Enable {
+		return ErrDevnetMiningDisabled
+	}
+	netType := dm.m.Config.Repo().Config().NetworkParams.NetworkType
+	if netType != types.Network2k && netType != types.NetworkForce {
+		return fmt.Errorf("%w: only the 2k and force networks may be mined by the node, not network type %d",
+			ErrDevnetMiningDisabled, netType)
+	}
+	if !constants.InsecurePoStValidation {
+		return fmt.Errorf("%w: INSECURE_POST_VALIDATION=1 is required as the node does not compute winning PoSts",
+			ErrDevnetMiningDisabled)
+	}
+	return nil
+}
+
+// start produces a block of the configured miner every interval until stop
+func (dm *devnetMiner) start(ctx context.Context) error {
+	if dm.cfg == nil || !dm.cfg.Enable || dm.cfg.Miner.Empty() {
+		return nil
+	}
+	if err := dm.allowed(); err != nil {
+		return err
+	}
+	interval := time.Duration(dm.cfg.Interval)
+	if interval <= 0 {
+		interval = time.Duration(dm.m.Config.Repo().Config().NetworkParams.BlockDelay) * time.Second
+	}
+
+	ctx, dm.cancel = context.WithCancel(ctx)
+	dm.done = make(chan struct{})
+	go func() {
+		defer close(dm.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			res, err := dm.mineOne(ctx, dm.cfg.Miner)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Warnf("mine a block of %s: %v", dm.cfg.Miner, err)
+				}
+				continue
+			}
+			log.Infof("mined block %s of %s at %d after %d null rounds", res.Block, dm.cfg.Miner, res.Height, res.NullRounds)
+		}
+	}()
+	log.Infof("mining the blocks of %s every %s", dm.cfg.Miner, interval)
+	return nil
+}
+
+func (dm *devnetMiner) stop() {
+	if dm.cancel == nil {
+		return
+	}
+	dm.cancel()
+	<-dm.done
+}
+
+// mineOne produces the next block of maddr on the head, after the null rounds the miner loses, and waits for the
+// chain to take it as its head
+func (dm *devnetMiner) mineOne(ctx context.Context, maddr address.Address) (*types.MinedBlock, error) {
+	if err := dm.allowed(); err != nil {
+		return nil, err
+	}
+	dm.lk.Lock()
+	defer dm.lk.Unlock()
+
+	base := dm.m.ChainModule.ChainReader.GetHead()
+	blk, round, msgCount, err := nextWinningRound(base, dm.cfg.MaxNullRounds, func(round abi.ChainEpoch) (*types.BlockMsg, int, error) {
+		return dm.tryRound(ctx, maddr, base, round)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("miner %s: %w", maddr, err)
+	}
+
+	if err := dm.m.SyncModule.API().SyncSubmitBlock(ctx, blk); err != nil {
+		return nil, fmt.Errorf("submit block %s: %w", blk.Cid(), err)
+	}
+	if err := dm.waitHead(ctx, round); err != nil {
+		return nil, fmt.Errorf("wait for block %s: %w", blk.Cid(), err)
+	}
+	return &types.MinedBlock{Block: blk.Cid(), Height: round, NullRounds: round - base.Height() - 1, Messages: msgCount}, nil
+}
+
+// nextWinningRound tries the rounds following base until try returns the block of one, the rounds lost in between
+// are null rounds
+func nextWinningRound(base *types.TipSet, maxNullRounds abi.ChainEpoch, try func(round abi.ChainEpoch) (*types.BlockMsg, int, error)) (*types.BlockMsg, abi.ChainEpoch, int, error) {
+	for round := base.Height() + 1; ; round++ {
+		if nulls := round - base.Height() - 1; nulls > maxNullRounds {
+			return nil, 0, 0, fmt.Errorf("lost %d rounds in a row on %s", nulls, base.Key())
+		}
+
+		blk, msgCount, err := try(round)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("mine round %d: %w", round, err)
+		}
+		if blk != nil {
+			return blk, round, msgCount, nil
+		}
+	}
+}
+
+// roundTimestamp is the timestamp of a block at round on base, one block delay after base for each epoch, the null
+// rounds included
+func roundTimestamp(base *types.TipSet, round abi.ChainEpoch, blockDelay uint64) uint64 {
+	return base.MinTimestamp() + blockDelay*uint64(round-base.Height())
+}
+
+// tryRound creates the block of maddr at round on base, nil when the miner loses the round
+func (dm *devnetMiner) tryRound(ctx context.Context, maddr address.Address, base *types.TipSet, round abi.ChainEpoch) (*types.BlockMsg, int, error) {
+	api := &MiningAPI{Ming: dm.m}
+	info, err := api.MinerGetBaseInfo(ctx, maddr, round, base.Key())
+	if err != nil {
+		return nil, 0, fmt.Errorf("get mining base info: %w", err)
+	}
+	if info == nil || len(info.Sectors) == 0 {
+		return nil, 0, fmt.Errorf("miner %s has no sectors to prove", maddr)
+	}
+	if !info.EligibleForMining {
+		return nil, 0, fmt.Errorf("miner %s is not eligible to mine", maddr)
+	}
+
+	rbase := info.PrevBeaconEntry
+	if len(info.BeaconEntries) > 0 {
+		rbase = info.BeaconEntries[len(info.BeaconEntries)-1]
+	}
+	buf := new(bytes.Buffer)
+	if err := maddr.MarshalCBOR(buf); err != nil {
+		return nil, 0, fmt.Errorf("failed to marshal miner address: %w", err)
+	}
+	electionRand, err := chain.DrawRandomnessFromBase(rbase.Data, acrypto.DomainSeparationTag_ElectionProofProduction, round, buf.Bytes())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to draw election randomness: %w", err)
+	}
+	signer := dm.m.Wallet.Signer
+	vrf, err := signer.SignBytes(ctx, electionRand, info.WorkerKey)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to compute election proof: %w", err)
+	}
+	eproof := &types.ElectionProof{VRFProof: vrf.Data}
+	if eproof.WinCount = eproof.ComputeWinCount(info.MinerPower, info.NetworkPower); eproof.WinCount < 1 {
+		return nil, 0, nil
+	}
+
+	netParams := dm.m.Config.Repo().Config().NetworkParams
+	bSmokeHeight := round > netParams.ForkUpgradeParam.UpgradeSmokeHeight
+	ticket, err := consensus.NewTicketMachine(dm.m.ChainModule.ChainReader).MakeTicket(ctx, base.Key(),
+		round-constants.TicketRandomnessLookback, maddr, &rbase, bSmokeHeight, info.WorkerKey, signer)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	wpt, err := info.Sectors[0].SealProof.RegisteredWinningPoStProof()
+	if err != nil {
+		return nil, 0, err
+	}
+	msgs, err := dm.m.MessagePool.MPool.SelectMessages(ctx, base, ticket.Quality())
+	if err != nil {
+		return nil, 0, fmt.Errorf("select messages: %w", err)
+	}
+
+	blk, err := api.MinerCreateBlock(ctx, &types.BlockTemplate{
+		Miner:        maddr,
+		Parents:      base.Key(),
+		Ticket:       &ticket,
+		Eproof:       eproof,
+		BeaconValues: info.BeaconEntries,
+		Messages:     msgs,
+		Epoch:        round,
+		Timestamp:    roundTimestamp(base, round, netParams.BlockDelay),
+		// the proof is accepted as is with INSECURE_POST_VALIDATION=1
+		WinningPoStProof: []builtin.PoStProof{{PoStProof: wpt, ProofBytes: []byte("valid proof")}},
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("create block: %w", err)
+	}
+	if blk.Header.BlockSig == nil {
+		return nil, 0, fmt.Errorf("the wallet does not have the worker %s of miner %s", info.WorkerKey, maddr)
+	}
+	return blk, len(msgs), nil
+}
+
+func (dm *devnetMiner) waitHead(ctx context.Context, height abi.ChainEpoch) error {
+	ctx, cancel := context.WithTimeout(ctx, headWaitTimeout)
+	defer cancel()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for dm.m.ChainModule.ChainReader.GetHead().Height() < height {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package mining
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestNextWinningRound(t *testing.T) {
+	tf.UnitTest(t)
+
+	addrs := testhelpers.NewForTestGetter()
+	newCid := testhelpers.NewCidForTestGetter()
+	header := func(height abi.ChainEpoch, timestamp uint64, parents ...*types.BlockHeader) *types.BlockHeader {
+		bh := &types.BlockHeader{
+			Miner:                 addrs(),
+			Height:                height,
+			Timestamp:             timestamp,
+			Messages:              newCid(),
+			ParentStateRoot:       newCid(),
+			ParentMessageReceipts: newCid(),
+		}
+		for _, p := range parents {
+			bh.Parents = append(bh.Parents, p.Cid())
+		}
+		return bh
+	}
+	// the base follows 4 null rounds itself
+	const blockDelay = 30
+	parent := header(5, 1000)
+	base, err := types.NewTipSet([]*types.BlockHeader{header(10, 1000+5*blockDelay, parent)})
+	require.NoError(t, err)
+
+	// the miner loses the two rounds after the base, the block of the third one is timed after them
+	var tried []abi.ChainEpoch
+	blk, round, msgCount, err := nextWinningRound(base, 5, func(round abi.ChainEpoch) (*types.BlockMsg, int, error) {
+		tried = append(tried, round)
+		if round < 13 {
+			return nil, 0, nil
+		}
+		return &types.BlockMsg{Header: header(round, roundTimestamp(base, round, blockDelay), base.Blocks()...)}, 3, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []abi.ChainEpoch{11, 12, 13}, tried)
+	assert.Equal(t, abi.ChainEpoch(13), round)
+	assert.Equal(t, 3, msgCount)
+	assert.Equal(t, uint64(1000+8*blockDelay), blk.Header.Timestamp)
+
+	// the miner gives up after losing more than the max null rounds
+	tried = nil
+	_, _, _, err = nextWinningRound(base, 2, func(round abi.ChainEpoch) (*types.BlockMsg, int, error) {
+		tried = append(tried, round)
+		return nil, 0, nil
+	})
+	assert.ErrorContains(t, err, "lost 3 rounds in a row")
+	assert.Equal(t, []abi.ChainEpoch{11, 12, 13}, tried)
+
+	broken := errors.New("no sectors")
+	_, _, _, err = nextWinningRound(base, 2, func(round abi.ChainEpoch) (*types.BlockMsg, int, error) {
+		return nil, 0, broken
+	})
+	assert.ErrorIs(t, err, broken)
+	assert.ErrorContains(t, err, "mine round 11")
+}
@@ -165,10 +165,27 @@ func (miningAPI *MiningAPI) MinerCreateBlock(ctx context.Context, bt *types.Bloc
 	return &out, nil
 }
 
+// checkBlockTemplate rejects the templates which can not make a block, they may come from an external block
+// producer and a short bls signature would not aggregate
+func checkBlockTemplate(bt *types.BlockTemplate) error {
+	if bt.Parents.IsEmpty() {
+		return fmt.Errorf("block template of miner %s has no parents", bt.Miner)
+	}
+	for _, msg := range bt.Messages {
+		if msg.Signature.Type == crypto.SigTypeBLS && len(msg.Signature.Data) != ffi.SignatureBytes {
+			return fmt.Errorf("invalid bls signature length %d of message %s", len(msg.Signature.Data), msg.Cid())
+		}
+	}
+	return nil
+}
+
 func (miningAPI *MiningAPI) minerCreateBlock(ctx context.Context, bt *types.BlockTemplate) (*types.FullBlock, error) {
 	chainStore := miningAPI.Ming.ChainModule.ChainReader
 	messageStore := miningAPI.Ming.ChainModule.MessageStore
 	cfg := miningAPI.Ming.Config.Repo().Config()
+	if err := checkBlockTemplate(bt); err != nil {
+		return nil, err
+	}
 	pts, err := chainStore.GetTipSet(ctx, bt.Parents)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load parent tipset: %v", err)
@@ -290,6 +307,11 @@ func (miningAPI *MiningAPI) minerCreateBlock(ctx context.Context, bt *types.Bloc
 	return fullBlock, nil
 }
 
+// MineOne produces the next block of maddr on the head, see the devnet mining config
+func (miningAPI *MiningAPI) MineOne(ctx context.Context, maddr address.Address) (*types.MinedBlock, error) {
+	return miningAPI.Ming.devnet.mineOne(ctx, maddr)
+}
+
 func aggregateSignatures(sigs []crypto.Signature) (*crypto.Signature, error) {
 	sigsS := make([]ffi.Signature, len(sigs))
 	for i := 0; i < len(sigs); i++ {
@@ -0,0 +1,42 @@
+package mining
+
+import (
+	"testing"
+
+	ffi "github.com/filecoin-project/filecoin-ffi"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestCheckBlockTemplate(t *testing.T) {
+	tf.UnitTest(t)
+
+	addrs := testhelpers.NewForTestGetter()
+	newCid := testhelpers.NewCidForTestGetter()
+	signed := func(sigType crypto.SigType, size int) *types.SignedMessage {
+		return &types.SignedMessage{
+			Message:   types.Message{From: addrs(), To: addrs(), Value: types.NewInt(1)},
+			Signature: crypto.Signature{Type: sigType, Data: make([]byte, size)},
+		}
+	}
+
+	bt := &types.BlockTemplate{
+		Miner:    addrs(),
+		Parents:  types.NewTipSetKey(newCid()),
+		Messages: []*types.SignedMessage{signed(crypto.SigTypeBLS, ffi.SignatureBytes), signed(crypto.SigTypeSecp256k1, 65)},
+	}
+	assert.NoError(t, checkBlockTemplate(bt))
+
+	// a short bls signature is rejected, the other signatures are left to the block validation
+	bt.Messages = append(bt.Messages, signed(crypto.SigTypeBLS, ffi.SignatureBytes-1))
+	assert.ErrorContains(t, checkBlockTemplate(bt), "invalid bls signature length")
+	bt.Messages = []*types.SignedMessage{signed(crypto.SigTypeSecp256k1, 10)}
+	assert.NoError(t, checkBlockTemplate(bt))
+
+	bt.Parents = types.EmptyTSK
+	assert.ErrorContains(t, checkBlockTemplate(bt), "has no parents")
+}
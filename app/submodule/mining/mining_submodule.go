@@ -1,8 +1,11 @@
 package mining
 
 import (
+	"context"
+
 	"github.com/filecoin-project/venus/app/submodule/blockstore"
 	chain2 "github.com/filecoin-project/venus/app/submodule/chain"
+	"github.com/filecoin-project/venus/app/submodule/mpool"
 	"github.com/filecoin-project/venus/app/submodule/network"
 	"github.com/filecoin-project/venus/app/submodule/syncer"
 	"github.com/filecoin-project/venus/app/submodule/wallet"
@@ -26,8 +29,11 @@ type MiningModule struct { //nolint
 	NetworkModule *network.NetworkSubmodule
 	SyncModule    *syncer.SyncerSubmodule
 	Wallet        wallet.WalletSubmodule
+	MessagePool   *mpool.MessagePoolSubmodule
 	proofVerifier ffiwrapper.Verifier
 	Stmgr         *statemanger.Stmgr
+
+	devnet *devnetMiner
 }
 
 // API create new miningAPi implement
@@ -48,8 +54,9 @@ func NewMiningModule(
 	networkModule *network.NetworkSubmodule,
 	syncModule *syncer.SyncerSubmodule,
 	wallet wallet.WalletSubmodule,
+	messagePool *mpool.MessagePoolSubmodule,
 ) *MiningModule {
-	return &MiningModule{
+	m := &MiningModule{
 		Stmgr:         stmgr,
 		Config:        conf,
 		ChainModule:   chainModule,
@@ -57,6 +64,19 @@ func NewMiningModule(
 		NetworkModule: networkModule,
 		SyncModule:    syncModule,
 		Wallet:        wallet,
+		MessagePool:   messagePool,
 		proofVerifier: conf.Verifier(),
 	}
+	m.devnet = newDevnetMiner(m)
+	return m
+}
+
+// Start produces the blocks of the miner of the devnet mining config automatically, when it is set
+func (miningModule *MiningModule) Start(ctx context.Context) error {
+	return miningModule.devnet.start(ctx)
+}
+
+// Stop stops producing blocks automatically
+func (miningModule *MiningModule) Stop() {
+	miningModule.devnet.stop()
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/big"
@@ -41,6 +42,14 @@ func (a *MessagePoolAPI) MpoolPush(ctx context.Context, smsg *types.SignedMessag
 	return a.mp.MPool.Push(ctx, smsg)
 }
 
+// GasStats returns the gas used per actor method over the recent epochs
+func (a *MessagePoolAPI) GasStats(ctx context.Context) (*types.GasStats, error) {
+	if a.mp.gasStats == nil {
+		return nil, messagepool.ErrGasStatsDisabled
+	}
+	return a.mp.gasStats.Stats(), nil
+}
+
 // MpoolGetConfig returns (a copy of) the current mpool config
 func (a *MessagePoolAPI) MpoolGetConfig(context.Context) (*types.MpoolConfig, error) {
 	cfg := a.mp.MPool.GetConfig()
@@ -51,6 +60,7 @@ func (a *MessagePoolAPI) MpoolGetConfig(context.Context) (*types.MpoolConfig, er
 		ReplaceByFeeRatio:      cfg.ReplaceByFeeRatio,
 		PruneCooldown:          cfg.PruneCooldown,
 		GasLimitOverestimation: cfg.GasLimitOverestimation,
+		PendingTTL:             cfg.PendingTTL,
 	}, nil
 }
 
@@ -63,6 +73,7 @@ func (a *MessagePoolAPI) MpoolSetConfig(ctx context.Context, cfg *types.MpoolCon
 		ReplaceByFeeRatio:      cfg.ReplaceByFeeRatio,
 		PruneCooldown:          cfg.PruneCooldown,
 		GasLimitOverestimation: cfg.GasLimitOverestimation,
+		PendingTTL:             cfg.PendingTTL,
 	})
 }
 
@@ -169,10 +180,24 @@ func (a *MessagePoolAPI) MpoolClear(ctx context.Context, local bool) error {
 }
 
 // MpoolPushUntrusted pushes a signed message to mempool from untrusted sources.
+// It only takes the read permission so that the public gateways can serve it, these checks are the safety net:
+// the node must be in sync, see MessagePool.PushUntrusted for the other checks.
 func (a *MessagePoolAPI) MpoolPushUntrusted(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error) {
+	if err := a.checkUntrustedPush(); err != nil {
+		return cid.Undef, err
+	}
 	return a.mp.MPool.PushUntrusted(ctx, smsg)
 }
 
+func (a *MessagePoolAPI) checkUntrustedPush() error {
+	if !a.mp.nearSynced(untrustedPushSyncEpochs) {
+		head := a.mp.chain.ChainReader.GetHead()
+		return fmt.Errorf("the node is not in sync, head %d of %s is too old to accept messages from untrusted sources",
+			head.Height(), time.Unix(int64(head.MinTimestamp()), 0).Format(time.RFC3339))
+	}
+	return nil
+}
+
 // MpoolPushMessage atomically assigns a nonce, signs, and pushes a message
 // to mempool.
 // maxFee is only used when GasFeeCap/GasPremium fields aren't specified
@@ -180,6 +205,19 @@ func (a *MessagePoolAPI) MpoolPushUntrusted(ctx context.Context, smsg *types.Sig
 // When maxFee is set to 0, MpoolPushMessage will guess appropriate fee
 // based on current chain conditions
 func (a *MessagePoolAPI) MpoolPushMessage(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error) {
+	return a.pushMessage(ctx, nil, msg, spec)
+}
+
+// MpoolPushMessageWithID is MpoolPushMessage deduplicated by id, a push retried with the same id returns the
+// message signed by the first push, so that retrying after a network error does not use a second nonce
+func (a *MessagePoolAPI) MpoolPushMessageWithID(ctx context.Context, id types.UUID, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error) {
+	if id.IsEmpty() {
+		return nil, fmt.Errorf("push id must not be empty")
+	}
+	return a.pushMessage(ctx, &id, msg, spec)
+}
+
+func (a *MessagePoolAPI) pushMessage(ctx context.Context, id *types.UUID, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error) {
 	cp := *msg
 	msg = &cp
 	inMsg := *msg
@@ -195,6 +233,17 @@ func (a *MessagePoolAPI) MpoolPushMessage(ctx context.Context, msg *types.Messag
 		defer done()
 	}
 
+	// the pushes of the same id are serialized by the sender lock
+	if id != nil {
+		pushed, err := a.mp.MPool.PushedByID(ctx, *id, &inMsg, fromA)
+		if err != nil {
+			return nil, err
+		}
+		if pushed != nil {
+			return pushed, nil
+		}
+	}
+
 	if msg.Nonce != 0 {
 		return nil, fmt.Errorf("MpoolPushMessage expects message nonce to be 0, was %d", msg.Nonce)
 	}
@@ -232,15 +281,50 @@ func (a *MessagePoolAPI) MpoolPushMessage(ctx context.Context, msg *types.Messag
 		return nil, fmt.Errorf("mpool push: not enough funds: %s < %s", b, requiredFunds)
 	}
 
+	if err := a.previewPush(ctx, msg, spec); err != nil {
+		return nil, err
+	}
+
 	// Sign and push the message
 	return a.mp.msgSigner.SignMessage(ctx, msg, func(smsg *types.SignedMessage) error {
 		if _, err := a.MpoolPush(ctx, smsg); err != nil {
 			return fmt.Errorf("mpool push: failed to push message: %w", err)
 		}
+		if id != nil {
+			// the message is in the pool, failing now would make the caller push it again
+			if err := a.mp.MPool.RecordPushID(ctx, *id, smsg); err != nil {
+				log.Errorf("record push id %s of message %s: %v", id, smsg.Cid(), err)
+			}
+		}
 		return nil
 	})
 }
 
+// previewPush executes msg before it is signed when the spec asks for it, a failed execution rejects the push or is
+// logged depending on the preview mode. The errors of the preview itself do not reject the push, they are not
+// failures of the message.
+func (a *MessagePoolAPI) previewPush(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec) error {
+	if spec == nil || spec.Preview == types.ExecPreviewOff {
+		return nil
+	}
+	if err := spec.Preview.Validate(); err != nil {
+		return err
+	}
+
+	res, err := a.mp.MPool.PreviewMessage(ctx, msg)
+	if err != nil {
+		log.Warnf("mpool push: could not preview the message from %s to %s, method %d: %v", msg.From, msg.To, msg.Method, err)
+		return nil
+	}
+	if err := messagepool.CheckPreview(res); err != nil {
+		if spec.Preview == types.ExecPreviewReject {
+			return fmt.Errorf("mpool push: %w", err)
+		}
+		log.Warnf("mpool push: the message from %s to %s, method %d, is likely to fail: %v", msg.From, msg.To, msg.Method, err)
+	}
+	return nil
+}
+
 // MpoolBatchPush batch pushes a unsigned message to mempool.
 func (a *MessagePoolAPI) MpoolBatchPush(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error) {
 	var messageCids []cid.Cid
@@ -256,6 +340,9 @@ func (a *MessagePoolAPI) MpoolBatchPush(ctx context.Context, smsgs []*types.Sign
 
 // MpoolBatchPushUntrusted batch pushes a signed message to mempool from untrusted sources.
 func (a *MessagePoolAPI) MpoolBatchPushUntrusted(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error) {
+	if err := a.checkUntrustedPush(); err != nil {
+		return nil, err
+	}
 	var messageCids []cid.Cid
 	for _, smsg := range smsgs {
 		smsgCid, err := a.mp.MPool.PushUntrusted(ctx, smsg)
@@ -290,6 +377,11 @@ func (a *MessagePoolAPI) MpoolSub(ctx context.Context) (<-chan types.MpoolUpdate
 	return a.mp.MPool.Updates(ctx)
 }
 
+// MpoolSubFiltered is like MpoolSub but only sends the updates matching the filter
+func (a *MessagePoolAPI) MpoolSubFiltered(ctx context.Context, filter *types.MpoolSubFilter) (<-chan types.MpoolUpdate, error) {
+	return a.mp.MPool.UpdatesFiltered(ctx, filter)
+}
+
 // GasEstimateMessageGas estimates gas values for unset message gas fields
 func (a *MessagePoolAPI) GasEstimateMessageGas(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec, tsk types.TipSetKey) (*types.Message, error) {
 	return a.mp.MPool.GasEstimateMessageGas(ctx, &types.EstimateMessage{Msg: msg, Spec: spec}, tsk)
@@ -325,3 +417,14 @@ func (a *MessagePoolAPI) MpoolCheckPendingMessages(ctx context.Context, addr add
 func (a *MessagePoolAPI) MpoolCheckReplaceMessages(ctx context.Context, msg []*types.Message) ([][]types.MessageCheckStatus, error) {
 	return a.mp.MPool.CheckReplaceMessages(ctx, msg)
 }
+
+// MpoolEstimateInclusion simulates whether the message with its current fee is likely included within
+// nblocksincl epochs given the pending messages and the recent base fees, and suggests a premium
+func (a *MessagePoolAPI) MpoolEstimateInclusion(ctx context.Context, msg *types.Message, nblocksincl uint64) (*types.InclusionEstimate, error) {
+	return a.mp.MPool.EstimateInclusion(ctx, msg, nblocksincl)
+}
+
+// MpoolPreviewMessage executes msg on the head state after the pending messages of its sender
+func (a *MessagePoolAPI) MpoolPreviewMessage(ctx context.Context, msg *types.Message) (*types.InvocResult, error) {
+	return a.mp.MPool.PreviewMessage(ctx, msg)
+}
@@ -32,6 +32,9 @@ import (
 
 var pubsubMsgsSyncEpochs = 10
 
+// untrustedPushSyncEpochs is how far behind the current epoch the head can be for the untrusted pushes to be accepted
+var untrustedPushSyncEpochs = 3
+
 func init() {
 	if s := os.Getenv("VENUS_MSGS_SYNC_EPOCHS"); s != "" {
 		val, err := strconv.Atoi(s)
@@ -56,6 +59,7 @@ type MessagePoolSubmodule struct { //nolint
 
 	MPool        *messagepool.MessagePool
 	msgSigner    *messagepool.MessageSigner
+	gasStats     *messagepool.GasStatsCollector
 	chain        *chain.ChainSubmodule
 	network      *network.NetworkSubmodule
 	walletAPI    v1api.IWallet
@@ -90,8 +94,14 @@ func NewMpoolSubmodule(ctx context.Context,
 		return nil, fmt.Errorf("constructing mpool: %s", err)
 	}
 
+	var gasStats *messagepool.GasStatsCollector
+	if epochs := cfg.Repo().Config().Mpool.GasStatsEpochs; epochs > 0 {
+		gasStats = messagepool.NewGasStatsCollector(epochs, chain.ChainReader, chain.MessageStore)
+	}
+
 	return &MessagePoolSubmodule{
 		MPool:        mp,
+		gasStats:     gasStats,
 		chain:        chain,
 		walletAPI:    wallet.API(),
 		network:      network,
@@ -128,6 +138,12 @@ func (mp *MessagePoolSubmodule) Validate(ctx context.Context, pid peer.ID, msg *
 
 	log.Debugf("validate incoming msg:%s", m.Cid().String())
 
+	if err := mp.chain.NonceIndex.CheckReplay(ctx, m.Message.From, m.Message.Nonce, m.Cid()); err != nil {
+		// not penalized, honest peers may still relay a message that was replaced by fee before being mined
+		log.Debugf("drop incoming message: %s", err)
+		return pubsub.ValidationIgnore
+	}
+
 	if err := mp.MPool.Add(ctx, m); err != nil {
 		log.Debugf("failed to add message from network to message pool (From: %s, To: %s, Nonce: %d, Value: %s): %s", m.Message.From, m.Message.To, m.Message.Nonce, types.FIL(m.Message.Value), err)
 
@@ -151,6 +167,8 @@ func (mp *MessagePoolSubmodule) Validate(ctx context.Context, pid peer.ID, msg *
 
 		case errors.Is(err, messagepool.ErrMessageTooBig):
 			fallthrough
+		case errors.Is(err, messagepool.ErrInvalidForBlockInclusion):
+			fallthrough
 		case errors.Is(err, messagepool.ErrMessageValueTooHigh):
 			fallthrough
 		case errors.Is(err, messagepool.ErrInvalidToAddr):
@@ -204,6 +222,10 @@ func (mp *MessagePoolSubmodule) Start(ctx context.Context) error {
 		return err
 	}
 
+	if mp.gasStats != nil {
+		mp.gasStats.Start(ctx)
+	}
+
 	var once sync.Once
 	subscribe := func() {
 		once.Do(func() {
@@ -228,14 +250,18 @@ func (mp *MessagePoolSubmodule) Start(ctx context.Context) error {
 	return nil
 }
 
+// nearSynced checks whether the head is less than epochs behind the current epoch
+func (mp *MessagePoolSubmodule) nearSynced(epochs int) bool {
+	nearsync := time.Duration(epochs*int(mp.networkCfg.BlockDelay)) * time.Second
+	timestampTime := time.Unix(int64(mp.chain.ChainReader.GetHead().MinTimestamp()), 0)
+	return constants.Clock.Since(timestampTime) < nearsync
+}
+
 func (mp *MessagePoolSubmodule) waitForSync(epochs int, subscribe func()) {
 	nearsync := time.Duration(epochs*int(mp.networkCfg.BlockDelay)) * time.Second
 
 	// early check, are we synced at start up?
-	ts := mp.chain.ChainReader.GetHead()
-	timestamp := ts.MinTimestamp()
-	timestampTime := time.Unix(int64(timestamp), 0)
-	if constants.Clock.Since(timestampTime) < nearsync {
+	if mp.nearSynced(epochs) {
 		subscribe()
 		return
 	}
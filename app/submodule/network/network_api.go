@@ -146,3 +146,26 @@ func (na *networkAPI) NetPubsubScores(context.Context) ([]types.PubsubScore, err
 
 	return out, nil
 }
+
+// NetPubsubTopics returns the peers subscribed to the pubsub topics, their mesh and their message counts
+func (na *networkAPI) NetPubsubTopics(context.Context) ([]types.PubsubTopic, error) {
+	stats := na.network.TopicTracker.Get()
+	out := make([]types.PubsubTopic, 0, len(stats))
+	for _, topic := range na.network.Pubsub.GetTopics() {
+		st := stats[topic]
+		out = append(out, types.PubsubTopic{
+			Topic:     topic,
+			Peers:     na.network.Pubsub.ListPeers(topic),
+			Mesh:      st.Mesh,
+			Delivered: st.Delivered,
+			Rejected:  st.Rejected,
+			Duplicate: st.Duplicate,
+		})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Topic < out[j].Topic
+	})
+
+	return out, nil
+}
@@ -87,6 +87,8 @@ type NetworkSubmodule struct { //nolint
 	DataTransferHost dtnet.DataTransferNetwork
 
 	ScoreKeeper *net.ScoreKeeper
+	// follows the mesh and the messages of the pubsub topics
+	TopicTracker *net.TopicTracker
 
 	cfg networkConfig
 }
@@ -178,7 +180,8 @@ func NewNetworkSubmodule(ctx context.Context,
 	}
 
 	sk := net.NewScoreKeeper()
-	gsub, err := net.NewGossipSub(ctx, peerHost, sk, networkName, cfg.NetworkParams.DrandSchedule, bootNodes, cfg.PubsubConfig.Bootstrapper)
+	tt := net.NewTopicTracker()
+	gsub, err := net.NewGossipSub(ctx, peerHost, sk, networkName, cfg.NetworkParams.DrandSchedule, bootNodes, cfg.PubsubConfig, tt)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to set up network")
 	}
@@ -231,6 +234,7 @@ func NewNetworkSubmodule(ctx context.Context,
 		HelloHandler:     helloHandler,
 		cfg:              config,
 		ScoreKeeper:      sk,
+		TopicTracker:     tt,
 	}, nil
 }
 
@@ -3,15 +3,18 @@ package syncer
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync/atomic"
 	"time"
 
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	syncTypes "github.com/filecoin-project/venus/pkg/chainsync/types"
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/fvm"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log/v2"
 )
 
@@ -30,16 +33,21 @@ func (sa *syncerAPI) SyncerTracker(ctx context.Context) *types.TargetTracker {
 		History: make([]*types.Target, 0),
 		Buckets: make([]*types.Target, 0),
 	}
-	convertTarget := func(src *syncTypes.Target) *types.Target {
+	convertTarget := func(target *syncTypes.Target) *types.Target {
+		// the target is read through a snapshot, the syncer updates it while syncing
+		src := target.Snapshot()
 		return &types.Target{
-			State:   convertSyncStateStage(src.State),
-			Base:    src.Base,
-			Current: src.Current,
-			Start:   src.Start,
-			End:     src.End,
-			Err:     src.Err,
-			Head:    src.Head,
-			Sender:  src.Sender,
+			State:     convertSyncStateStage(src.State),
+			Stage:     src.Stage,
+			Base:      src.Base,
+			Current:   src.Current,
+			Fetched:   src.Fetched,
+			Start:     src.Start,
+			End:       src.End,
+			Err:       src.Err,
+			Head:      src.Head,
+			Sender:    src.Sender,
+			Preempted: src.Preempted,
 		}
 	}
 	for _, target := range tracker.History() {
@@ -96,35 +104,65 @@ func (sa *syncerAPI) ChainSyncHandleNewTipSet(ctx context.Context, ci *types.Cha
 // SyncSubmitBlock can be used to submit a newly created block to the.
 // network through this node
 func (sa *syncerAPI) SyncSubmitBlock(ctx context.Context, blk *types.BlockMsg) error {
+	res, err := sa.submitBlock(ctx, blk, false)
+	if err != nil {
+		return err
+	}
+	if !res.Submitted {
+		reasons := make([]string, 0, len(res.Rejections))
+		for _, r := range res.Rejections {
+			reasons = append(reasons, r.String())
+		}
+		return fmt.Errorf("<!!> refusing to submit block %s: %s", res.Block, strings.Join(reasons, "; "))
+	}
+	return nil
+}
+
+// SyncSubmitBlockChecked validates the block and submits it unless it would get the miner slashed
+func (sa *syncerAPI) SyncSubmitBlockChecked(ctx context.Context, blk *types.BlockMsg) (*types.SubmitBlockResult, error) {
+	return sa.submitBlock(ctx, blk, true)
+}
+
+// submitBlock submits the block unless it would get the miner slashed, validateHeader runs the full validation of
+// the header first, which SyncSubmitBlock leaves to the syncer so as not to delay the block
+func (sa *syncerAPI) submitBlock(ctx context.Context, blk *types.BlockMsg, validateHeader bool) (*types.SubmitBlockResult, error) {
 	// todo many dot. how to get directly
 	chainModule := sa.syncer.ChainModule
-	parent, err := chainModule.ChainReader.GetBlock(ctx, blk.Header.Parents[0])
-	if err != nil {
-		return fmt.Errorf("loading parent block: %v", err)
+	res := &types.SubmitBlockResult{Block: blk.Cid()}
+	refuse := func(r *types.BlockRejection) (*types.SubmitBlockResult, error) {
+		log.Errorf("<!!> REFUSING TO SUBMIT BLOCK %s of miner %s at %d: %s", res.Block, blk.Header.Miner, blk.Header.Height, r)
+		res.Rejections = append(res.Rejections, r)
+		return res, nil
 	}
 
-	if !constants.NoSlashFilter {
-		witness, fault, err := sa.syncer.SlashFilter.MinedBlock(ctx, blk.Header, parent.Height)
-		if err != nil {
-			log.Errorf("<!!> SLASH FILTER ERRORED: %s", err)
-			// Return an error here, because it's _probably_ wiser to not submit this block
-			return fmt.Errorf("<!!> SLASH FILTER ERRORED: %w", err)
-		}
+	// another block of the miner at the epoch, maybe produced by another node of the miner
+	for _, other := range sa.syncer.SeenBlocks.Others(blk.Header) {
+		other := other
+		res.Rejections = append(res.Rejections, &types.BlockRejection{
+			Reason:  types.BlockEquivocation,
+			Fault:   types.DoubleForkMiningFault,
+			Witness: &other,
+		})
+	}
+	if len(res.Rejections) > 0 {
+		log.Errorf("<!!> REFUSING TO SUBMIT BLOCK %s of miner %s at %d: %d other blocks seen at the epoch",
+			res.Block, blk.Header.Miner, blk.Header.Height, len(res.Rejections))
+		return res, nil
+	}
 
-		if fault {
-			log.Errorf("<!!> SLASH FILTER DETECTED FAULT due to witness %s", witness)
-			return fmt.Errorf("<!!> SLASH FILTER DETECTED FAULT due to witness %s", witness)
-		}
+	parent, err := chainModule.ChainReader.GetBlock(ctx, blk.Header.Parents[0])
+	if err != nil {
+		return nil, fmt.Errorf("loading parent block: %v", err)
 	}
 
 	// TODO: should we have some sort of fast path to adding a local block?
 	bmsgs, err := chainModule.MessageStore.LoadUnsignedMessagesFromCids(ctx, blk.BlsMessages)
 	if err != nil {
-		return fmt.Errorf("failed to load bls messages: %v", err)
+		return nil, fmt.Errorf("failed to load bls messages: %v", err)
 	}
 	smsgs, err := chainModule.MessageStore.LoadSignedMessagesFromCids(ctx, blk.SecpkMessages)
 	if err != nil {
-		return fmt.Errorf("failed to load secpk message: %v", err)
+		return nil, fmt.Errorf("failed to load secpk message: %v", err)
 	}
 
 	fb := &types.FullBlock{
@@ -134,21 +172,47 @@ func (sa *syncerAPI) SyncSubmitBlock(ctx context.Context, blk *types.BlockMsg) e
 	}
 
 	if err := sa.syncer.BlockValidator.ValidateMsgMeta(ctx, fb); err != nil {
-		return fmt.Errorf("provided messages did not match block: %v", err)
+		return refuse(&types.BlockRejection{
+			Reason: types.BlockInvalid,
+			Detail: fmt.Sprintf("provided messages did not match block: %v", err),
+		})
+	}
+	if validateHeader {
+		if err := sa.syncer.BlockValidator.ValidateBlockHeader(ctx, blk.Header); err != nil {
+			return refuse(&types.BlockRejection{Reason: types.BlockInvalid, Detail: err.Error()})
+		}
+	}
+
+	// the slash filter records the block, so it is checked last
+	if !constants.NoSlashFilter {
+		witness, fault, err := sa.syncer.SlashFilter.MinedBlock(ctx, blk.Header, parent.Height)
+		if err != nil {
+			log.Errorf("<!!> SLASH FILTER ERRORED: %s", err)
+			// Return an error here, because it's _probably_ wiser to not submit this block
+			return nil, fmt.Errorf("<!!> SLASH FILTER ERRORED: %w", err)
+		}
+
+		if fault {
+			return refuse(&types.BlockRejection{
+				Reason:  types.BlockSlashable,
+				Fault:   sa.faultType(ctx, blk.Header, witness),
+				Witness: &witness,
+			})
+		}
 	}
 
 	if _, err := chainModule.ChainReader.PutObject(ctx, blk.Header); err != nil {
-		return err
+		return nil, err
 	}
 	localPeer := sa.syncer.NetworkModule.Network.GetPeerID()
 	ci := types.NewChainInfo(localPeer, localPeer, &types.FullTipSet{Blocks: []*types.FullBlock{fb}})
 	if err := sa.syncer.SyncProvider.HandleNewTipSet(ci); err != nil {
-		return fmt.Errorf("sync to submitted block failed: %v", err)
+		return nil, fmt.Errorf("sync to submitted block failed: %v", err)
 	}
 
 	b, err := blk.Serialize()
 	if err != nil {
-		return fmt.Errorf("serializing block for pubsub publishing failed: %v", err)
+		return nil, fmt.Errorf("serializing block for pubsub publishing failed: %v", err)
 	}
 	go func() {
 		tCtx, tCancel := context.WithTimeout(context.TODO(), time.Minute)
@@ -158,7 +222,27 @@ func (sa *syncerAPI) SyncSubmitBlock(ctx context.Context, blk *types.BlockMsg) e
 			syncAPILog.Warnf("publish block failed: %s, %v", blk.Cid(), err)
 		}
 	}()
-	return nil
+	sa.syncer.SeenBlocks.Add(blk.Header)
+
+	res.Submitted = true
+	return res, nil
+}
+
+// faultType tells the consensus fault found by the slash filter from the other block of the miner
+func (sa *syncerAPI) faultType(ctx context.Context, bh *types.BlockHeader, witness cid.Cid) types.ConsensusFaultType {
+	other, err := sa.syncer.ChainModule.ChainReader.GetBlock(ctx, witness)
+	if err != nil {
+		syncAPILog.Warnf("load block %s found by the slash filter: %v", witness, err)
+		return ""
+	}
+	switch {
+	case other.Height == bh.Height:
+		return types.DoubleForkMiningFault
+	case types.CidArrsEqual(other.Parents, bh.Parents):
+		return types.TimeOffsetMiningFault
+	default:
+		return types.ParentGrindingFault
+	}
 }
 
 // SyncState just compatible code lotus
@@ -183,11 +267,16 @@ func (sa *syncerAPI) SyncState(ctx context.Context) (*types.SyncState, error) {
 		}
 		count++
 
+		stage := convertSyncStateStage(t.State)
+		if t.State == syncTypes.StateInSyncing && t.Stage != types.StageIdle {
+			stage = t.Stage
+		}
+
 		activeSync := types.ActiveSync{
 			WorkerID: uint64(count),
 			Base:     t.Base,
 			Target:   t.Head,
-			Stage:    convertSyncStateStage(t.State),
+			Stage:    stage,
 			Height:   currentHeight,
 			Start:    t.Start,
 			End:      t.End,
@@ -196,14 +285,14 @@ func (sa *syncerAPI) SyncState(ctx context.Context) (*types.SyncState, error) {
 		return activeSync
 	}
 	// current
-	for _, t := range tracker.Buckets() {
-		if t.State != syncTypes.StageSyncErrored {
+	for _, target := range tracker.Buckets() {
+		if t := target.Snapshot(); t.State != syncTypes.StageSyncErrored {
 			syncState.ActiveSyncs = append(syncState.ActiveSyncs, toActiveSync(t))
 		}
 	}
 	// history
-	for _, t := range tracker.History() {
-		if t.State != syncTypes.StageSyncErrored {
+	for _, target := range tracker.History() {
+		if t := target.Snapshot(); t.State != syncTypes.StageSyncErrored {
 			syncState.ActiveSyncs = append(syncState.ActiveSyncs, toActiveSync(t))
 		}
 	}
@@ -214,3 +303,59 @@ func (sa *syncerAPI) SyncState(ctx context.Context) (*types.SyncState, error) {
 func (sa *syncerAPI) SyncIncomingBlocks(ctx context.Context) (<-chan *types.BlockHeader, error) {
 	return sa.syncer.ChainSyncManager.BlockProposer().IncomingBlocks(ctx)
 }
+
+// SyncConsensusFaults returns the latest consensus faults found by the consensus fault reporter
+func (sa *syncerAPI) SyncConsensusFaults(ctx context.Context) ([]*types.ConsensusFault, error) {
+	if sa.syncer.FaultReporter == nil {
+		return nil, fmt.Errorf("the consensus fault reporter is not enabled")
+	}
+	return sa.syncer.FaultReporter.Faults(), nil
+}
+
+// SyncForkAlerts returns the latest heads received competing with the local chain from a fork deeper than the alarm
+// depth
+func (sa *syncerAPI) SyncForkAlerts(ctx context.Context) ([]*types.ForkAlert, error) {
+	if sa.syncer.ForkTracker == nil {
+		return nil, fmt.Errorf("the fork alarm is not enabled")
+	}
+	return sa.syncer.ForkTracker.Alerts(), nil
+}
+
+// SyncMarkBad marks the tipset as bad until it is unmarked
+func (sa *syncerAPI) SyncMarkBad(ctx context.Context, tsk types.TipSetKey, reason string) error {
+	if tsk.IsEmpty() {
+		return fmt.Errorf("empty tipset key")
+	}
+	var height abi.ChainEpoch
+	// the tipset may not be known to the node yet
+	if ts, err := sa.syncer.ChainModule.ChainReader.GetTipSet(ctx, tsk); err == nil {
+		height = ts.Height()
+	}
+	sa.syncer.ChainSyncManager.BadTipSets().Add(tsk, height, reason, true)
+	return nil
+}
+
+// SyncUnmarkBad unmarks the tipset
+func (sa *syncerAPI) SyncUnmarkBad(ctx context.Context, tsk types.TipSetKey) error {
+	if !sa.syncer.ChainSyncManager.BadTipSets().Remove(tsk) {
+		return fmt.Errorf("tipset %s is not marked as bad", tsk)
+	}
+	return nil
+}
+
+// SyncUnmarkAllBad unmarks all the bad tipsets
+func (sa *syncerAPI) SyncUnmarkAllBad(ctx context.Context) error {
+	sa.syncer.ChainSyncManager.BadTipSets().Clear()
+	return nil
+}
+
+// SyncCheckBad returns why the tipset is bad, nil when it is not
+func (sa *syncerAPI) SyncCheckBad(ctx context.Context, tsk types.TipSetKey) (*types.BadTipSet, error) {
+	bad, _ := sa.syncer.ChainSyncManager.BadTipSets().Check(tsk)
+	return bad, nil
+}
+
+// SyncListBad returns the bad tipsets which did not expire
+func (sa *syncerAPI) SyncListBad(ctx context.Context) ([]*types.BadTipSet, error) {
+	return sa.syncer.ChainSyncManager.BadTipSets().List(), nil
+}
@@ -26,7 +26,9 @@ import (
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/chainsync"
 	"github.com/filecoin-project/venus/pkg/chainsync/slashfilter"
+	syncTypes "github.com/filecoin-project/venus/pkg/chainsync/types"
 	"github.com/filecoin-project/venus/pkg/consensus"
+	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/net/blocksub"
 	"github.com/filecoin-project/venus/pkg/net/pubsub"
 	"github.com/filecoin-project/venus/pkg/repo"
@@ -54,7 +56,13 @@ type SyncerSubmodule struct { //nolint
 	Drand            beacon.Schedule
 	SyncProvider     ChainSyncProvider
 	SlashFilter      slashfilter.ISlashFilter
-	BlockValidator   *consensus.BlockValidator
+	// SeenBlocks are the recent blocks received from the network and submitted
+	SeenBlocks *slashfilter.SeenBlocks
+	// FaultReporter is nil unless the consensus fault reporter is enabled
+	FaultReporter  *slashfilter.ConsensusFaultReporter
+	BlockValidator *consensus.BlockValidator
+	// ForkTracker is nil unless the fork alarm is enabled
+	ForkTracker *syncTypes.ForkTracker
 
 	// cancelChainSync cancels the context for chain sync subscriptions and handlers.
 	CancelChainSync context.CancelFunc
@@ -92,8 +100,10 @@ func NewSyncerSubmodule(ctx context.Context,
 		&stateViewer,
 		chn.ChainReader,
 		chn.Fork,
+		config.ChainClock(),
 		config.Repo().Config().NetworkParams,
 		gasPriceSchedule)
+	blkValid.SetSigVerifyWorkers(config.Repo().Config().Validation.SigVerifyWorkers)
 
 	// register block validation on pubsub
 	btv := blocksub.NewBlockTopicValidator(blkValid)
@@ -123,10 +133,17 @@ func NewSyncerSubmodule(ctx context.Context,
 
 	blkValid.Stmgr = stmgr
 	chn.Stmgr = stmgr
+	chn.StateReaders = statemanger.NewReadOnlyStmgrPool(stmgr, config.Repo().Config().API.StateReadPoolSize)
 	chn.Waiter.Stmgr = stmgr
 
+	badTipSets, err := syncTypes.LoadBadTipSetCache(ctx, config.Repo().MetaDatastore(),
+		time.Duration(config.Repo().Config().Validation.BadTipSetTTL))
+	if err != nil {
+		return nil, err
+	}
+
 	chainSyncManager, err := chainsync.NewManager(stmgr, blkValid, chn,
-		blockstore.Blockstore, network.ExchangeClient, config.ChainClock(), chn.Fork)
+		blockstore.Blockstore, network.ExchangeClient, config.ChainClock(), chn.Fork, badTipSets)
 	if err != nil {
 		return nil, err
 	}
@@ -151,16 +168,24 @@ func NewSyncerSubmodule(ctx context.Context,
 		return nil, err
 	}
 
+	var forkTracker *syncTypes.ForkTracker
+	if depth := config.Repo().Config().ForkAlarm.Depth; depth > 0 {
+		forkTracker = syncTypes.NewForkTracker(chn.ChainReader.GetTipSet, constants.Finality, depth)
+		chainSyncManager.SetForkTracker(forkTracker)
+	}
+
 	return &SyncerSubmodule{
 		Stmgr:            stmgr,
 		BlockstoreModule: blockstore,
 		ChainModule:      chn,
 		NetworkModule:    network,
 		SlashFilter:      slashFilter,
+		SeenBlocks:       slashfilter.NewSeenBlocks(),
 		ChainSyncManager: &chainSyncManager,
 		Drand:            chn.Drand,
 		SyncProvider:     *NewChainSyncProvider(&chainSyncManager),
 		BlockValidator:   blkValid,
+		ForkTracker:      forkTracker,
 	}, nil
 }
 
@@ -181,6 +206,7 @@ func (syncer *SyncerSubmodule) handleIncomingBlocks(ctx context.Context, msg pub
 	}
 
 	header := bm.Header
+	syncer.SeenBlocks.Add(header)
 	span.AddAttributes(trace.StringAttribute("block", header.Cid().String()))
 
 	log.Infof("received new block %s height %d from peer %s age %v", header.Cid(), header.Height, sender, time.Since(time.Unix(int64(header.Timestamp), 0)))
@@ -269,6 +295,10 @@ func (syncer *SyncerSubmodule) Start(ctx context.Context) error {
 		}
 	}()
 
+	if syncer.ForkTracker != nil {
+		go syncer.checkForks(ctx)
+	}
+
 	err = syncer.ChainModule.Start(ctx)
 	if err != nil {
 		return err
@@ -277,6 +307,27 @@ func (syncer *SyncerSubmodule) Start(ctx context.Context) error {
 	return syncer.ChainSyncManager.Start(ctx)
 }
 
+// checkForks compares the heads received with every new local head, the checks are skipped while one runs
+func (syncer *SyncerSubmodule) checkForks(ctx context.Context) {
+	notify := make(chan struct{}, 1)
+	syncer.ChainModule.ChainReader.SubscribeHeadChanges(func(_, _ []*types.TipSet) error {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-notify:
+			syncer.ForkTracker.Check(ctx, syncer.ChainModule.ChainReader.GetHead())
+		}
+	}
+}
+
 func (syncer *SyncerSubmodule) Stop(ctx context.Context) {
 	if syncer.CancelChainSync != nil {
 		syncer.CancelChainSync()
@@ -13,6 +13,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/wallet"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
+	swallet "github.com/filecoin-project/venus/venus-shared/types/wallet"
 )
 
 var _ v1api.IWallet = &WalletAPI{}
@@ -167,3 +168,66 @@ func (walletAPI *WalletAPI) HasPassword(ctx context.Context) bool {
 func (walletAPI *WalletAPI) WalletState(ctx context.Context) int {
 	return walletAPI.walletModule.Wallet.WalletState(ctx)
 }
+
+// WalletSignRules returns the rules the messages signed by the wallet are checked against
+func (walletAPI *WalletAPI) WalletSignRules(ctx context.Context) ([]*swallet.SignRule, error) {
+	return walletAPI.walletModule.adapter.SignRules(), nil
+}
+
+// WalletAddSignRule parses and adds a rule restricting the messages signed by signer
+func (walletAPI *WalletAPI) WalletAddSignRule(ctx context.Context, signer address.Address, expr string) (*swallet.SignRule, error) {
+	if signer != address.Undef {
+		keyAddr, err := walletAPI.walletModule.Chain.Stmgr.ResolveToDeterministicAddress(ctx, signer, nil)
+		if err != nil {
+			return nil, fmt.Errorf("ResolveTokeyAddress failed:%v", err)
+		}
+		signer = keyAddr
+	}
+
+	rule, err := swallet.ParseSignRule(signer, expr)
+	if err != nil {
+		return nil, err
+	}
+	if err := walletAPI.walletModule.adapter.AddSignRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// WalletRemoveSignRule removes the sign rule of the id
+func (walletAPI *WalletAPI) WalletRemoveSignRule(ctx context.Context, id string) error {
+	return walletAPI.walletModule.adapter.RemoveSignRule(ctx, id)
+}
+
+// WalletSignDenials returns the last sign requests denied by the rules
+func (walletAPI *WalletAPI) WalletSignDenials(ctx context.Context, limit int) ([]swallet.SignDenial, error) {
+	return walletAPI.walletModule.adapter.SignDenials(limit), nil
+}
+
+// WalletTxHistory returns the messages of the wallet address recorded by the tx history
+func (walletAPI *WalletAPI) WalletTxHistory(ctx context.Context, addr address.Address, from, to abi.ChainEpoch) ([]*swallet.Tx, error) {
+	history := walletAPI.walletModule.TxHistory
+	if history == nil {
+		return nil, errors.New("the wallet tx history is disabled, enable walletModule.txHistory")
+	}
+
+	chainReader := walletAPI.walletModule.Chain.ChainReader
+	if addr.Protocol() == address.ID {
+		key, err := chainReader.ResolveToDeterministicAddress(ctx, nil, addr)
+		if err != nil {
+			return nil, fmt.Errorf("resolve %s to its key address: %w", addr, err)
+		}
+		addr = key
+	}
+	if !walletAPI.adapter.HasAddress(ctx, addr) {
+		return nil, fmt.Errorf("%s is not an address of the wallet", addr)
+	}
+	if to <= 0 {
+		to = chainReader.GetHead().Height()
+	}
+	if from > to {
+		return nil, fmt.Errorf("the range from %d to %d is empty", from, to)
+	}
+
+	return history.History(ctx, addr, from, to)
+}
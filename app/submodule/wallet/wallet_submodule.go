@@ -2,20 +2,25 @@ package wallet
 
 import (
 	"context"
+	"path/filepath"
 
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	logging "github.com/ipfs/go-log"
 	"github.com/pkg/errors"
 
 	"github.com/filecoin-project/venus/app/submodule/chain"
 	"github.com/filecoin-project/venus/app/submodule/config"
 	"github.com/filecoin-project/venus/app/submodule/wallet/remotewallet"
+	chain2 "github.com/filecoin-project/venus/pkg/chain"
 	pconfig "github.com/filecoin-project/venus/pkg/config"
 	"github.com/filecoin-project/venus/pkg/repo"
 	"github.com/filecoin-project/venus/pkg/state"
 	"github.com/filecoin-project/venus/pkg/wallet"
+	"github.com/filecoin-project/venus/pkg/wallet/txhistory"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
@@ -25,14 +30,19 @@ var log = logging.Logger("wallet")
 type WalletSubmodule struct { // nolint
 	Chain   *chain.ChainSubmodule
 	Wallet  *wallet.Wallet
-	adapter wallet.WalletIntersection
+	adapter *wallet.PolicyWallet
 	Signer  types.Signer
 	Config  *config.ConfigModule
+	// TxHistory records the messages of the wallet addresses, nil unless enabled
+	TxHistory *txhistory.Indexer
+	lookback  abi.ChainEpoch
 }
 
 type walletRepo interface {
 	Config() *pconfig.Config
 	WalletDatastore() repo.Datastore
+	MetaDatastore() repo.Datastore
+	SqlitePath() (string, error)
 }
 
 // NewWalletSubmodule creates a new storage protocol submodule.
@@ -50,7 +60,16 @@ func NewWalletSubmodule(ctx context.Context,
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to set up walletModule backend")
 	}
-	fcWallet := wallet.New(backend)
+	backends := []wallet.Backend{backend}
+	if kmsKeys := repo.Config().Wallet.KMSKeys; len(kmsKeys) > 0 {
+		kmsBackend, err := wallet.NewKMSBackend(ctx, kmsKeys)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set up kms wallet backend")
+		}
+		backends = append(backends, kmsBackend)
+		log.Infof("%d kms keys set up", len(kmsKeys))
+	}
+	fcWallet := wallet.New(backends...)
 	headSigner := state.NewHeadSignView(chain.ChainReader)
 
 	var adapter wallet.WalletIntersection
@@ -66,13 +85,56 @@ func NewWalletSubmodule(ctx context.Context,
 	} else {
 		adapter = fcWallet
 	}
-	return &WalletSubmodule{
+	policyWallet, err := wallet.NewPolicyWallet(ctx, adapter, repo.MetaDatastore(), func() abi.ChainEpoch {
+		return chain.ChainReader.GetHead().Height()
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set up wallet sign policy")
+	}
+	walletModule := &WalletSubmodule{
 		Config:  cfgModule,
 		Chain:   chain,
 		Wallet:  fcWallet,
-		adapter: adapter,
+		adapter: policyWallet,
 		Signer:  state.NewSigner(headSigner, fcWallet),
-	}, nil
+	}
+
+	if cfg := repo.Config().Wallet.TxHistory; cfg.Enable {
+		sqlitePath, err := repo.SqlitePath()
+		if err != nil {
+			return nil, err
+		}
+		db, err := txhistory.OpenDB(filepath.Join(sqlitePath, "wallet_tx.db"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open the wallet tx history")
+		}
+		walletModule.TxHistory = txhistory.NewIndexer(db, chain.ChainReader, chain.MessageStore,
+			&parentState{store: chain.ChainReader}, policyWallet.Addresses)
+		walletModule.lookback = cfg.Lookback
+	}
+
+	return walletModule, nil
+}
+
+// Start indexes the messages of the wallet addresses since the node last ran, and then those of the tipsets added
+func (wallet *WalletSubmodule) Start(ctx context.Context) error {
+	if wallet.TxHistory == nil {
+		return nil
+	}
+	if err := wallet.TxHistory.CatchUp(ctx, wallet.Chain.ChainReader.GetHead(), wallet.lookback); err != nil {
+		log.Warnf("failed to index the wallet messages below the head: %v", err)
+	}
+	wallet.Chain.ChainReader.SubscribeHeadChanges(wallet.TxHistory.HeadChange(ctx))
+	return nil
+}
+
+func (wallet *WalletSubmodule) Stop(ctx context.Context) {
+	if wallet.TxHistory == nil {
+		return
+	}
+	if err := wallet.TxHistory.Close(); err != nil {
+		log.Warnf("failed to close the wallet tx history: %v", err)
+	}
 }
 
 // API create a new wallet api implement
@@ -94,6 +156,34 @@ func (wallet *WalletSubmodule) WalletIntersection() wallet.WalletIntersection {
 	return wallet.adapter
 }
 
+// parentState reads the actors in the parent state of the tipsets for the tx history
+type parentState struct {
+	store *chain2.Store
+}
+
+func (ps *parentState) LookupID(ctx context.Context, ts *types.TipSet, addr address.Address) (address.Address, error) {
+	view, err := ps.store.ParentStateView(ts)
+	if err != nil {
+		return address.Undef, err
+	}
+	return view.LookupID(ctx, addr)
+}
+
+func (ps *parentState) Balance(ctx context.Context, ts *types.TipSet, addr address.Address) (abi.TokenAmount, error) {
+	view, err := ps.store.ParentStateView(ts)
+	if err != nil {
+		return abi.TokenAmount{}, err
+	}
+	act, err := view.LoadActor(ctx, addr)
+	if err != nil {
+		if errors.Is(err, types.ErrActorNotFound) {
+			return abi.NewTokenAmount(0), nil
+		}
+		return abi.TokenAmount{}, err
+	}
+	return act.Balance, nil
+}
+
 func getPassphraseConfig(cfg *pconfig.Config) (pconfig.PassphraseConfig, error) {
 	return pconfig.PassphraseConfig{
 		ScryptN: cfg.Wallet.PassphraseConfig.ScryptN,
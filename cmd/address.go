@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -17,6 +18,7 @@ import (
 	files "github.com/ipfs/go-libipfs/files"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 
 	"github.com/filecoin-project/venus/app/node"
@@ -24,6 +26,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/wallet"
 	"github.com/filecoin-project/venus/pkg/wallet/key"
 	"github.com/filecoin-project/venus/venus-shared/types"
+	swallet "github.com/filecoin-project/venus/venus-shared/types/wallet"
 )
 
 var (
@@ -47,6 +50,9 @@ var walletCmd = &cmds.Command{
 		"lock":         lockedCmd,
 		"unlock":       unlockedCmd,
 		"set-password": setWalletPassword,
+		"rules":        walletRulesCmd,
+		"market":       walletMarketCmd,
+		"history":      walletHistoryCmd,
 	},
 }
 
@@ -126,6 +132,19 @@ var addrsDeleteCmd = &cmds.Command{
 	},
 }
 
+// WalletAddressInfo is an address listed by wallet ls, only Address is set with --addr-only
+type WalletAddressInfo struct {
+	Address address.Address
+	// ID is nil when it is not asked for, or not found
+	ID              *address.Address `json:",omitempty"`
+	Balance         *types.FIL       `json:",omitempty"`
+	MarketAvailable *types.FIL       `json:",omitempty"`
+	MarketLocked    *types.FIL       `json:",omitempty"`
+	Nonce           *uint64          `json:",omitempty"`
+	Default         bool
+	Error           string `json:",omitempty"`
+}
+
 var addrsLsCmd = &cmds.Command{
 	Options: []cmds.Option{
 		cmds.BoolOption("addr-only", "Only print addresses"),
@@ -141,76 +160,104 @@ var addrsLsCmd = &cmds.Command{
 		// Assume an error means no default key is set
 		def, _ := api.WalletAPI.WalletDefaultAddress(req.Context)
 
-		buf := new(bytes.Buffer)
-		tw := tablewriter.New(
-			tablewriter.Col("Address"),
-			tablewriter.Col("ID"),
-			tablewriter.Col("Balance"),
-			tablewriter.Col("Market(Avail)"),
-			tablewriter.Col("Market(Locked)"),
-			tablewriter.Col("Nonce"),
-			tablewriter.Col("Default"),
-			tablewriter.NewLineCol("Error"))
-
 		addrOnly := false
 		if _, ok := req.Options["addr-only"]; ok {
 			addrOnly = true
 		}
+		out := make([]*WalletAddressInfo, 0, len(addrs))
 		for _, addr := range addrs {
+			info := &WalletAddressInfo{Address: addr, Default: addr == def}
+			out = append(out, info)
 			if addrOnly {
-				writer := NewSilentWriter(buf)
-				writer.WriteStringln(addr.String())
-			} else {
-				a, err := api.ChainAPI.StateGetActor(ctx, addr, types.EmptyTSK)
-				if err != nil {
-					if !strings.Contains(err.Error(), "actor not found") {
-						tw.Write(map[string]interface{}{
-							"Address": addr,
-							"Error":   err,
-						})
-						continue
-					}
+				continue
+			}
 
-					a = &types.Actor{
-						Balance: big.Zero(),
-					}
+			a, err := api.ChainAPI.StateGetActor(ctx, addr, types.EmptyTSK)
+			if err != nil {
+				if !strings.Contains(err.Error(), "actor not found") {
+					info.Error = err.Error()
+					continue
+				}
+
+				a = &types.Actor{
+					Balance: big.Zero(),
+				}
+			}
+			info.Balance = (*types.FIL)(&a.Balance)
+			info.Nonce = &a.Nonce
+
+			if _, ok := req.Options["id"]; ok {
+				id, err := api.ChainAPI.StateLookupID(ctx, addr, types.EmptyTSK)
+				if err == nil {
+					info.ID = &id
+				}
+			}
+
+			if _, ok := req.Options["market"]; ok {
+				mbal, err := api.ChainAPI.StateMarketBalance(ctx, addr, types.EmptyTSK)
+				if err == nil {
+					avail := types.FIL(types.BigSub(mbal.Escrow, mbal.Locked))
+					locked := types.FIL(mbal.Locked)
+					info.MarketAvailable, info.MarketLocked = &avail, &locked
+				}
+			}
+		}
+
+		return re.Emit(out)
+	},
+	Type: []*WalletAddressInfo{},
+	Encoders: cmds.EncoderMap{
+		OutputTable: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, addrs []*WalletAddressInfo) error {
+			if _, ok := req.Options["addr-only"]; ok {
+				writer := NewSilentWriter(w)
+				for _, info := range addrs {
+					writer.WriteStringln(info.Address.String())
+				}
+				return writer.Error()
+			}
+
+			tw := tablewriter.New(
+				tablewriter.Col("Address"),
+				tablewriter.Col("ID"),
+				tablewriter.Col("Balance"),
+				tablewriter.Col("Market(Avail)"),
+				tablewriter.Col("Market(Locked)"),
+				tablewriter.Col("Nonce"),
+				tablewriter.Col("Default"),
+				tablewriter.NewLineCol("Error"))
+			for _, info := range addrs {
+				if info.Error != "" {
+					tw.Write(map[string]interface{}{
+						"Address": info.Address,
+						"Error":   info.Error,
+					})
+					continue
 				}
 
 				row := map[string]interface{}{
-					"Address": addr,
-					"Balance": types.FIL(a.Balance),
-					"Nonce":   a.Nonce,
+					"Address": info.Address,
+					"Balance": *info.Balance,
+					"Nonce":   *info.Nonce,
 				}
-				if addr == def {
+				if info.Default {
 					row["Default"] = "X"
 				}
-
 				if _, ok := req.Options["id"]; ok {
-					id, err := api.ChainAPI.StateLookupID(ctx, addr, types.EmptyTSK)
-					if err != nil {
-						row["ID"] = "n/a"
+					if info.ID != nil {
+						row["ID"] = *info.ID
 					} else {
-						row["ID"] = id
+						row["ID"] = "n/a"
 					}
 				}
-
-				if _, ok := req.Options["market"]; ok {
-					mbal, err := api.ChainAPI.StateMarketBalance(ctx, addr, types.EmptyTSK)
-					if err == nil {
-						row["Market(Avail)"] = types.FIL(types.BigSub(mbal.Escrow, mbal.Locked))
-						row["Market(Locked)"] = types.FIL(mbal.Locked)
-					}
+				if info.MarketAvailable != nil {
+					row["Market(Avail)"] = *info.MarketAvailable
+					row["Market(Locked)"] = *info.MarketLocked
 				}
 
 				tw.Write(row)
 			}
-		}
-
-		if !addrOnly {
-			_ = tw.Flush(buf)
-		}
-
-		return re.Emit(buf)
+			return tw.Flush(w)
+		}),
 	},
 }
 
@@ -247,6 +294,14 @@ var setDefaultAddressCmd = &cmds.Command{
 	},
 }
 
+// WalletBalanceResult is the balance of an address
+type WalletBalanceResult struct {
+	Address address.Address
+	Balance types.FIL
+	// Synced is false while the chain is syncing, the balance may not be up to date
+	Synced bool
+}
+
 var balanceCmd = &cmds.Command{
 	Arguments: []cmds.Argument{
 		cmds.StringArg("address", true, false, "APIAddress to get balance for"),
@@ -267,14 +322,20 @@ var balanceCmd = &cmds.Command{
 			return err
 		}
 
-		var balanceStr string
-		if balance.Equals(big.NewInt(0)) && !isDone {
-			balanceStr = fmt.Sprintf("%s (warning: may display 0 if chain sync in progress)", types.FIL(balance))
-		} else {
-			balanceStr = (types.FIL)(balance).String()
-		}
-
-		return printOneString(re, balanceStr)
+		return re.Emit(&WalletBalanceResult{Address: addr, Balance: types.FIL(balance), Synced: isDone})
+	},
+	Type: WalletBalanceResult{},
+	Encoders: cmds.EncoderMap{
+		OutputTable: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, res *WalletBalanceResult) error {
+			var balanceStr string
+			if big.Int(res.Balance).Equals(big.NewInt(0)) && !res.Synced {
+				balanceStr = fmt.Sprintf("%s (warning: may display 0 if chain sync in progress)", res.Balance)
+			} else {
+				balanceStr = res.Balance.String()
+			}
+			_, err := fmt.Fprintln(w, balanceStr)
+			return err
+		}),
 	},
 }
 
@@ -458,3 +519,180 @@ var unlockedCmd = &cmds.Command{
 		return re.Emit("unlocked success")
 	},
 }
+
+var walletHistoryCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List the messages sent by and to a wallet address, with its balance once they are executed",
+		ShortDescription: `The messages are recorded by the node when walletModule.txHistory is enabled, from the epoch
+it is enabled at minus its lookback.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("address", true, false, "The wallet address"),
+	},
+	Options: []cmds.Option{
+		cmds.Int64Option("from", "the first epoch listed").WithDefault(int64(0)),
+		cmds.Int64Option("to", "the last epoch listed, the head when 0").WithDefault(int64(0)),
+		cmds.BoolOption("csv", "write the messages as csv, with the amounts in attoFIL"),
+		cmds.StringOption("output", "file the csv is written to, printed when not set"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		addr, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		from, _ := req.Options["from"].(int64)
+		to, _ := req.Options["to"].(int64)
+		txs, err := env.(*node.Env).WalletAPI.WalletTxHistory(req.Context, addr, abi.ChainEpoch(from), abi.ChainEpoch(to))
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		if asCSV, _ := req.Options["csv"].(bool); asCSV {
+			if err := swallet.WriteTxsCSV(buf, txs); err != nil {
+				return err
+			}
+			output, _ := req.Options["output"].(string)
+			if output == "" {
+				return re.Emit(buf)
+			}
+			if err := os.WriteFile(output, buf.Bytes(), 0o600); err != nil {
+				return err
+			}
+			return re.Emit(fmt.Sprintf("exported %d messages of %s to %s", len(txs), addr, output))
+		}
+
+		tw := tablewriter.New(tablewriter.Col("Height"), tablewriter.Col("Message"), tablewriter.Col("Direction"),
+			tablewriter.Col("Counterparty"), tablewriter.Col("Method"), tablewriter.Col("Value"),
+			tablewriter.Col("ExitCode"), tablewriter.Col("Balance"))
+		for _, tx := range txs {
+			tw.Write(map[string]interface{}{
+				"Height":       tx.Height,
+				"Message":      tx.Message,
+				"Direction":    tx.Direction,
+				"Counterparty": tx.Counterparty,
+				"Method":       tx.Method,
+				"Value":        types.FIL(tx.Value),
+				"ExitCode":     tx.ExitCode,
+				"Balance":      types.FIL(tx.Balance),
+			})
+		}
+		if err := tw.Flush(buf); err != nil {
+			return err
+		}
+		return re.Emit(buf)
+	},
+}
+
+var walletRulesCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Manage the rules checked before signing messages",
+	},
+	Subcommands: map[string]*cmds.Command{
+		"ls":      walletRulesLsCmd,
+		"add":     walletRulesAddCmd,
+		"remove":  walletRulesRemoveCmd,
+		"denials": walletRulesDenialsCmd,
+	},
+}
+
+var walletRulesLsCmd = &cmds.Command{
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		rules, err := env.(*node.Env).WalletAPI.WalletSignRules(req.Context)
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		tw := tablewriter.New(tablewriter.Col("ID"), tablewriter.Col("Signer"), tablewriter.Col("Rule"))
+		for _, rule := range rules {
+			signer := "*"
+			if rule.Signer != address.Undef {
+				signer = rule.Signer.String()
+			}
+			tw.Write(map[string]interface{}{"ID": rule.ID, "Signer": signer, "Rule": rule.Expr})
+		}
+		if err := tw.Flush(buf); err != nil {
+			return err
+		}
+		return re.Emit(buf)
+	},
+}
+
+var walletRulesAddCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Add a rule restricting the messages signed by an address",
+		ShortDescription: `A rule is one of:
+  value-per-epoch <= 10FIL    the value of the messages signed in an epoch
+  to in [f01000, f01001]      the actors messages may be sent to
+  method in [0, 2]            the methods messages may call
+A signer with rules may only sign payloads of a known type.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("rule", true, false, "the rule expression"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("signer", "the address the rule applies to, every address when not set"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		signer := address.Undef
+		if s, ok := req.Options["signer"].(string); ok && len(s) > 0 {
+			var err error
+			if signer, err = address.NewFromString(s); err != nil {
+				return err
+			}
+		}
+
+		rule, err := env.(*node.Env).WalletAPI.WalletAddSignRule(req.Context, signer, req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		return printOneString(re, rule.ID)
+	},
+}
+
+var walletRulesRemoveCmd = &cmds.Command{
+	Arguments: []cmds.Argument{
+		cmds.StringArg("id", true, false, "the id of the rule"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		if err := env.(*node.Env).WalletAPI.WalletRemoveSignRule(req.Context, req.Arguments[0]); err != nil {
+			return err
+		}
+		return printOneString(re, "Remove successfully!")
+	},
+}
+
+var walletRulesDenialsCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List the last sign requests denied by the rules",
+	},
+	Options: []cmds.Option{
+		cmds.IntOption("limit", "the number of denials to list").WithDefault(20),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		denials, err := env.(*node.Env).WalletAPI.WalletSignDenials(req.Context, req.Options["limit"].(int))
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		tw := tablewriter.New(tablewriter.Col("Time"), tablewriter.Col("Signer"), tablewriter.Col("Rule"), tablewriter.Col("Message"), tablewriter.NewLineCol("Reason"))
+		for _, denial := range denials {
+			row := map[string]interface{}{
+				"Time":   denial.Time.Format(time.RFC3339),
+				"Signer": denial.Signer,
+				"Rule":   denial.RuleID,
+				"Reason": denial.Reason,
+			}
+			if denial.Msg != nil {
+				row["Message"] = denial.Msg.Cid()
+			}
+			tw.Write(row)
+		}
+		if err := tw.Flush(buf); err != nil {
+			return err
+		}
+		return re.Emit(buf)
+	},
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ipfs-force-community/sophon-auth/core"
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/venus-shared/api"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+)
+
+// apiCallMethod describes a method of the api, the table of the methods is generated from the api interfaces
+type apiCallMethod struct {
+	Group  string
+	Perm   string
+	Params []string
+	Result string
+	// Stream methods return a channel, they can't be invoked in a single request
+	Stream bool
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// apiMethodPerms are the permissions the rpc server requires to call the methods of the v1 api
+var apiMethodPerms = func() map[string]string {
+	perms := make(map[string]string)
+	for _, internal := range api.GetInternalStructs(&v1api.FullNodeStruct{}) {
+		rt := reflect.TypeOf(internal).Elem()
+		for i := 0; i < rt.NumField(); i++ {
+			perms[rt.Field(i).Name] = rt.Field(i).Tag.Get("perm")
+		}
+	}
+	return perms
+}()
+
+var apiCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Invoke the methods of the api",
+		ShortDescription: `
+'venus api' calls any method of the node api with JSON parameters, including the
+methods without a dedicated command.
+`,
+	},
+	Subcommands: map[string]*cmds.Command{
+		"call": apiCallCmd,
+		"list": apiListCmd,
+	},
+}
+
+var apiCallCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Call a method of the api",
+		ShortDescription: `
+Each parameter is the JSON value of the parameter of the method, a parameter which is
+not valid JSON is taken as a string.
+
+   eg) venus api call StateLookupID f01000 '[]'
+       venus api call ChainGetTipSetByHeight 100 '[{"/":"bafy2bz..."}]'
+`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("method", true, false, "name of the method"),
+		cmds.StringArg("params", false, true, "JSON parameters of the method"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		name := req.Arguments[0]
+		meta, ok := apiCallMethods[name]
+		if !ok {
+			return fmt.Errorf("unknown method %s", name)
+		}
+		if meta.Stream {
+			return fmt.Errorf("%s returns a stream of %s, it can't be called from the command line", name, meta.Result)
+		}
+
+		// the methods of the environment are not permissioned, the caller is checked as by the rpc server
+		if err := checkAPICallPerm(req.Context, name); err != nil {
+			return err
+		}
+		method, ok := findAPIMethod(getEnv(env), name)
+		if !ok {
+			return fmt.Errorf("method %s of %s is not served to the commands", name, meta.Group)
+		}
+		args, err := decodeAPIParams(method.Type(), req.Arguments[1:])
+		if err != nil {
+			return fmt.Errorf("%s(%s): %w", name, strings.Join(meta.Params, ", "), err)
+		}
+
+		out := method.Call(append([]reflect.Value{reflect.ValueOf(requestContext(req))}, args...))
+		if last := out[len(out)-1]; last.Type() == errorType {
+			if !last.IsNil() {
+				return last.Interface().(error)
+			}
+			out = out[:len(out)-1]
+		}
+		if len(out) == 0 {
+			return nil
+		}
+
+		res, err := json.MarshalIndent(out[0].Interface(), "", "  ")
+		if err != nil {
+			return err
+		}
+		return printOneString(re, string(res))
+	},
+}
+
+var apiListCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List the methods of the api",
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("group", "only list the methods of the group, eg. Chain, Wallet"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		group, _ := req.Options["group"].(string)
+
+		names := make([]string, 0, len(apiCallMethods))
+		for name, m := range apiCallMethods {
+			if len(group) == 0 || strings.EqualFold(m.Group, group) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		buf := new(bytes.Buffer)
+		tw := tabwriter.NewWriter(buf, 2, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "Method\tGroup\tPerm\tParams\tResult")
+		for _, name := range names {
+			m := apiCallMethods[name]
+			perm, result := m.Perm, m.Result
+			if len(perm) == 0 {
+				perm = "-"
+			}
+			if m.Stream {
+				result += " (stream)"
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t(%s)\t%s\n", name, m.Group, perm, strings.Join(m.Params, ", "), result)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		return re.Emit(buf)
+	},
+}
+
+// checkAPICallPerm checks that the caller has the permission the rpc server requires to call the method, the
+// methods without one require admin
+func checkAPICallPerm(ctx context.Context, name string) error {
+	perm := apiMethodPerms[name]
+	if len(perm) == 0 {
+		perm = core.PermAdmin
+	}
+	if !core.HasPerm(ctx, []core.Permission{core.PermRead}, perm) {
+		return fmt.Errorf("missing permission to invoke '%s' (need '%s')", name, perm)
+	}
+	return nil
+}
+
+// findAPIMethod looks for the method among the apis of the environment
+func findAPIMethod(env *node.Env, name string) (reflect.Value, bool) {
+	ev := reflect.ValueOf(env).Elem()
+	for i := 0; i < ev.NumField(); i++ {
+		f := ev.Field(i)
+		if !ev.Type().Field(i).IsExported() || f.Kind() != reflect.Interface || f.IsNil() {
+			continue
+		}
+		if m := f.MethodByName(name); m.IsValid() {
+			return m, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// decodeAPIParams decodes the parameters of a method whose first parameter is the context
+func decodeAPIParams(mt reflect.Type, params []string) ([]reflect.Value, error) {
+	if len(params) != mt.NumIn()-1 {
+		return nil, fmt.Errorf("expect %d parameters, got %d", mt.NumIn()-1, len(params))
+	}
+
+	args := make([]reflect.Value, len(params))
+	for i, p := range params {
+		v := reflect.New(mt.In(i + 1))
+		if err := json.Unmarshal([]byte(p), v.Interface()); err != nil {
+			quoted, _ := json.Marshal(p)
+			if json.Unmarshal(quoted, v.Interface()) != nil {
+				return nil, fmt.Errorf("decode parameter %d: %w", i+1, err)
+			}
+		}
+		args[i] = v.Elem()
+	}
+	return args, nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs-force-community/sophon-auth/core"
+	"github.com/stretchr/testify/assert"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestDecodeAPIParams(t *testing.T) {
+	tf.UnitTest(t)
+
+	var stateLookupID func(context.Context, address.Address, types.TipSetKey) (address.Address, error)
+	mt := reflect.TypeOf(stateLookupID)
+	id, err := address.NewIDAddress(1000)
+	assert.NoError(t, err)
+
+	args, err := decodeAPIParams(mt, []string{"f01000", "[]"})
+	assert.NoError(t, err)
+	assert.Len(t, args, 2)
+	assert.Equal(t, id, args[0].Interface())
+	assert.Equal(t, types.EmptyTSK, args[1].Interface())
+
+	// quoted strings are accepted too
+	args, err = decodeAPIParams(mt, []string{`"f01000"`, "[]"})
+	assert.NoError(t, err)
+	assert.Equal(t, id, args[0].Interface())
+
+	_, err = decodeAPIParams(mt, []string{"f01000"})
+	assert.Error(t, err)
+	_, err = decodeAPIParams(mt, []string{"f01000", "not a key"})
+	assert.Error(t, err)
+
+	var getTipSetByHeight func(context.Context, abi.ChainEpoch, types.TipSetKey) (*types.TipSet, error)
+	args, err = decodeAPIParams(reflect.TypeOf(getTipSetByHeight), []string{"100", "[]"})
+	assert.NoError(t, err)
+	assert.Equal(t, abi.ChainEpoch(100), args[0].Interface())
+}
+
+func TestAPICallMethods(t *testing.T) {
+	tf.UnitTest(t)
+
+	m, ok := apiCallMethods["StateLookupID"]
+	assert.True(t, ok)
+	assert.Equal(t, []string{"address.Address", "types.TipSetKey"}, m.Params)
+	assert.Equal(t, "read", m.Perm)
+	assert.True(t, apiCallMethods["ChainNotify"].Stream)
+}
+
+func TestCheckAPICallPerm(t *testing.T) {
+	tf.UnitTest(t)
+
+	read := core.CtxWithPerm(context.Background(), core.PermRead)
+	admin := core.CtxWithPerm(context.Background(), core.PermAdmin)
+	for _, name := range []string{"ChainHead", "Version"} {
+		assert.NoError(t, checkAPICallPerm(read, name), name)
+	}
+	for _, name := range []string{"AuthNew", "WalletSign", "MpoolPush", "Unknown"} {
+		assert.Error(t, checkAPICallPerm(read, name), name)
+	}
+	for _, name := range []string{"AuthNew", "WalletSign", "MpoolPush"} {
+		assert.NoError(t, checkAPICallPerm(admin, name), name)
+	}
+	// a context without permissions only reads, as for the rpc server
+	assert.NoError(t, checkAPICallPerm(context.Background(), "ChainHead"))
+	assert.Error(t, checkAPICallPerm(context.Background(), "WalletSign"))
+}
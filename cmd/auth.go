@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	cmds "github.com/ipfs/go-ipfs-cmds"
+)
+
+var authCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Manage the api tokens of the node",
+		ShortDescription: `
+'venus auth' issues named api tokens with a set of permissions and an optional
+expiration, the issued tokens can be listed and revoked individually.
+`,
+	},
+	Subcommands: map[string]*cmds.Command{
+		"create-token": authCreateTokenCmd,
+		"verify":       authVerifyCmd,
+		"list":         authListCmd,
+		"revoke":       authRevokeCmd,
+	},
+}
+
+var authCreateTokenCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Create a new named token",
+		ShortDescription: `
+Available permissions: read, write, sign, admin.
+
+   eg) venus auth create-token --perm read --perm write --expiry 720h my-token
+`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("name", true, false, "name of the token"),
+	},
+	Options: []cmds.Option{
+		cmds.StringsOption("perm", "permissions carried by the token"),
+		cmds.StringOption("expiry", "duration after which the token expires, eg. 24h, never expires if not set"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		permStrs, _ := req.Options["perm"].([]string)
+		if len(permStrs) == 0 {
+			return fmt.Errorf("at least one permission is required")
+		}
+		perms := make([]auth.Permission, 0, len(permStrs))
+		for _, p := range permStrs {
+			perms = append(perms, auth.Permission(strings.ToLower(p)))
+		}
+
+		var expiry time.Duration
+		if v, ok := req.Options["expiry"].(string); ok && len(v) > 0 {
+			var err error
+			if expiry, err = time.ParseDuration(v); err != nil {
+				return fmt.Errorf("failed to parse expiry %s: %w", v, err)
+			}
+		}
+
+		token, err := getEnv(env).AuthAPI.AuthNewNamed(req.Context, req.Arguments[0], perms, expiry)
+		if err != nil {
+			return err
+		}
+
+		return printOneString(re, string(token))
+	},
+}
+
+var authVerifyCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Show the permissions carried by a token",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("token", true, false, "token to verify"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		perms, err := getEnv(env).AuthAPI.AuthVerify(req.Context, req.Arguments[0])
+		if err != nil {
+			return err
+		}
+
+		return re.Emit(perms)
+	},
+	Type: []auth.Permission{},
+}
+
+var authListCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List the tokens issued by the node",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		infos, err := getEnv(env).AuthAPI.AuthList(req.Context)
+		if err != nil {
+			return err
+		}
+
+		buf := &bytes.Buffer{}
+		tw := tabwriter.NewWriter(buf, 4, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintf(tw, "Name\tPerms\tCreated\tExpires\tStatus\n")
+
+		now := time.Now()
+		for _, info := range infos {
+			perms := make([]string, 0, len(info.Perms))
+			for _, p := range info.Perms {
+				perms = append(perms, string(p))
+			}
+
+			expires := "never"
+			if !info.ExpiresAt.IsZero() {
+				expires = info.ExpiresAt.Format(time.RFC3339)
+			}
+
+			status := "active"
+			if info.Revoked {
+				status = "revoked"
+			} else if info.Expired(now) {
+				status = "expired"
+			}
+
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", info.Name, strings.Join(perms, ","),
+				info.CreatedAt.Format(time.RFC3339), expires, status)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		return re.Emit(buf)
+	},
+}
+
+var authRevokeCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Revoke a token by name",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("name", true, false, "name of the token"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		name := req.Arguments[0]
+		if err := getEnv(env).AuthAPI.AuthRevoke(req.Context, name); err != nil {
+			return err
+		}
+
+		return printOneString(re, fmt.Sprintf("token %s revoked", name))
+	},
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/howeyc/gopass"
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/pkg/repo"
+)
+
+const backupPasswordOption = "password"
+
+var backupCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Write an encrypted backup of the node",
+		ShortDescription: `
+The backup holds the keystore, the wallet, the api tokens, the local messages of the
+message pool and the config, the chain data is left out. The daemon must not be running.
+
+   eg) venus backup /mnt/usb/venus.bak
+`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("file", true, false, "path of the backup to write"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption(backupPasswordOption, "password protecting the backup, prompted if not set"),
+	},
+	PreRun: func(req *cmds.Request, env cmds.Environment) error {
+		return promptBackupPassword(req, true)
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		path := req.Arguments[0]
+		password, _ := req.Options[backupPasswordOption].(string)
+
+		repoDir, _ := req.Options[OptionRepoDir].(string)
+		rep, err := getRepo(repoDir)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = rep.Close()
+		}()
+
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err != nil {
+			return err
+		}
+		if err := repo.Backup(req.Context, rep, f, []byte(password)); err != nil {
+			_ = f.Close()
+			_ = os.Remove(path)
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+
+		return printOneString(re, fmt.Sprintf("backup written to %s", path))
+	},
+}
+
+var restoreCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Restore the node from a backup",
+		ShortDescription: `
+Writes the content of a backup made by 'venus backup' to the repo, the repo must be
+initialized and the daemon must not be running. The chain data is synced or imported
+as usual.
+
+   eg) venus restore /mnt/usb/venus.bak
+`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("file", true, false, "path of the backup"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption(backupPasswordOption, "password protecting the backup, prompted if not set"),
+		cmds.BoolOption("keep-config", "keep the config of the repo instead of the one of the backup"),
+	},
+	PreRun: func(req *cmds.Request, env cmds.Environment) error {
+		return promptBackupPassword(req, false)
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		path := req.Arguments[0]
+		password, _ := req.Options[backupPasswordOption].(string)
+		keepConfig, _ := req.Options["keep-config"].(bool)
+
+		repoDir, _ := req.Options[OptionRepoDir].(string)
+		rep, err := getRepo(repoDir)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = rep.Close()
+		}()
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close() // nolint:errcheck
+
+		if err := repo.Restore(req.Context, rep, f, []byte(password), keepConfig); err != nil {
+			return err
+		}
+
+		return printOneString(re, fmt.Sprintf("restored from %s", path))
+	},
+}
+
+func promptBackupPassword(req *cmds.Request, confirm bool) error {
+	if pw, _ := req.Options[backupPasswordOption].(string); len(pw) != 0 {
+		return nil
+	}
+
+	pw, err := gopass.GetPasswdPrompt("Password:", true, os.Stdin, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if confirm {
+		pw2, err := gopass.GetPasswdPrompt("Enter Password again:", true, os.Stdin, os.Stdout)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(pw, pw2) {
+			return errors.New("the input passwords are inconsistent")
+		}
+	}
+	if len(pw) == 0 {
+		return errors.New("the backup needs a password")
+	}
+
+	req.Options[backupPasswordOption] = string(pw)
+	return nil
+}
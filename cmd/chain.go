@@ -4,13 +4,16 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/docker/go-units"
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
@@ -38,6 +41,12 @@ var chainCmd = &cmds.Command{
 		"disputer":           chainDisputeSetCmd,
 		"export":             chainExportCmd,
 		"read-obj":           chainReadObjCmd,
+		"put-obj":            chainPutObjCmd,
+		"delete-obj":         chainDeleteObjCmd,
+		"staged-objs":        chainStagedObjsCmd,
+		"stat-obj":           chainStatObjCmd,
+		"scrub":              chainScrubCmd,
+		"decode":             chainDecodeCmd,
 	},
 }
 
@@ -74,6 +83,267 @@ var chainReadObjCmd = &cmds.Command{
 	},
 }
 
+var chainPutObjCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Stage an object in the blockstore",
+		ShortDescription: `Put the raw bytes of an object, given in hex or read from a file, in the blockstore. The cid is computed
+with blake2b-256, unless it is given with --cid and the data matches it. The objects the blockstore did not have
+count against the stagedObjQuota of the datastore config until they are deleted with 'chain delete-obj'.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("data", false, false, "the bytes of the object in hex"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("file", "read the bytes of the object from the file"),
+		cmds.StringOption("codec", "the codec of the object: dag-cbor or raw").WithDefault("dag-cbor"),
+		cmds.StringOption("cid", "the cid of the object, computed from the bytes when not set"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := ReqContext(req.Context)
+
+		var data []byte
+		file, _ := req.Options["file"].(string)
+		switch {
+		case file != "" && len(req.Arguments) > 0:
+			return fmt.Errorf("pass the bytes of the object or --file, not both")
+		case file != "":
+			b, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			data = b
+		case len(req.Arguments) > 0:
+			b, err := hex.DecodeString(req.Arguments[0])
+			if err != nil {
+				return fmt.Errorf("decode the bytes of the object: %w", err)
+			}
+			data = b
+		default:
+			return fmt.Errorf("must pass the bytes of the object or --file")
+		}
+
+		var obj cid.Cid
+		if c, _ := req.Options["cid"].(string); c != "" {
+			parsed, err := cid.Parse(c)
+			if err != nil {
+				return err
+			}
+			obj = parsed
+		} else {
+			builder := cid.V1Builder{MhType: constants.DefaultHashFunction}
+			switch codec, _ := req.Options["codec"].(string); codec {
+			case "dag-cbor":
+				builder.Codec = cid.DagCBOR
+			case "raw":
+				builder.Codec = cid.Raw
+			default:
+				return fmt.Errorf("unknown codec %s, expected dag-cbor or raw", codec)
+			}
+			computed, err := builder.Sum(data)
+			if err != nil {
+				return err
+			}
+			obj = computed
+		}
+
+		if err := env.(*node.Env).BlockStoreAPI.ChainStageObj(ctx, obj, data); err != nil {
+			return err
+		}
+		return printOneString(re, obj.String())
+	},
+}
+
+var chainDeleteObjCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Delete an object from the blockstore",
+		ShortDescription: `Delete an object from the blockstore, freeing its quota when it was staged with 'chain put-obj'.
+Deleting the objects of the chain breaks the node, --really-do-it must be passed.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("objectCid", true, false, "object cid"),
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("really-do-it", "really delete the object"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		obj, err := cid.Parse(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		if really, _ := req.Options["really-do-it"].(bool); !really {
+			return fmt.Errorf("pass --really-do-it to delete %s", obj)
+		}
+
+		if err := env.(*node.Env).BlockStoreAPI.ChainDeleteObj(ReqContext(req.Context), obj); err != nil {
+			return err
+		}
+		return printOneString(re, fmt.Sprintf("deleted %s", obj))
+	},
+}
+
+var chainStagedObjsCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Count the objects staged in the blockstore",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		stat, err := env.(*node.Env).BlockStoreAPI.ChainStagedObjs(ReqContext(req.Context))
+		if err != nil {
+			return err
+		}
+		return re.Emit(stat)
+	},
+	Type: types.StagedObjStat{},
+	Encoders: cmds.EncoderMap{
+		OutputTable: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, stat *types.StagedObjStat) error {
+			quota := "unlimited"
+			if stat.Quota > 0 {
+				quota = units.BytesSize(float64(stat.Quota))
+			}
+			_, err := fmt.Fprintf(w, "%d objects, %s of %s\n", stat.Count, units.BytesSize(float64(stat.Bytes)), quota)
+			return err
+		}),
+	},
+}
+
+var chainScrubCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Check the blocks of the blockstore against their cids",
+		ShortDescription: `Hash every block of the blockstore again and report the ones whose data does not match their cid.
+With --repair, the corrupt blocks are fetched from the peers to replace them.`,
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("rate", "the bytes read per second at most, eg. 64MiB, unlimited when empty"),
+		cmds.BoolOption("repair", "fetch the corrupt blocks from the peers").WithDefault(false),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := ReqContext(req.Context)
+
+		opts := &types.DatastoreScrubOptions{}
+		opts.Repair, _ = req.Options["repair"].(bool)
+		if rate, _ := req.Options["rate"].(string); rate != "" {
+			r, err := units.RAMInBytes(rate)
+			if err != nil {
+				return fmt.Errorf("parse rate %s: %w", rate, err)
+			}
+			opts.BytesPerSecond = r
+		}
+
+		progress, err := env.(*node.Env).BlockStoreAPI.DatastoreScrub(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		var last *types.DatastoreScrubProgress
+		reported := 0
+		for p := range progress {
+			last = p
+			for _, c := range p.CorruptCids[reported:] {
+				if err := printOneString(re, fmt.Sprintf("corrupt block: %s", c)); err != nil {
+					return err
+				}
+			}
+			reported = len(p.CorruptCids)
+			if !p.Done {
+				_ = printOneString(re, fmt.Sprintf("checked %d blocks, %s, %d corrupt, %d repaired, took %s", p.Checked,
+					types.SizeStr(types.NewInt(uint64(p.Bytes))), p.Corrupt, p.Repaired, p.Duration.Truncate(time.Second)))
+			}
+		}
+		if last == nil {
+			return fmt.Errorf("the scrub ended without result")
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		writer.Printf("Checked: %d blocks, %s (largest %s)\n", last.Checked, types.SizeStr(types.NewInt(uint64(last.Bytes))),
+			types.SizeStr(types.NewInt(uint64(last.Largest))))
+		if last.Skipped > 0 {
+			writer.Printf("Skipped: %d blocks with an unsupported hash function\n", last.Skipped)
+		}
+		writer.Printf("Corrupt: %d\n", last.Corrupt)
+		if opts.Repair {
+			writer.Printf("Repaired: %d\n", last.Repaired)
+		}
+		writer.Printf("Took: %s\n", last.Duration.Truncate(time.Millisecond))
+		if err := re.Emit(buf); err != nil {
+			return err
+		}
+		if last.Error != "" {
+			return errors.New(last.Error)
+		}
+		return nil
+	},
+}
+
+var chainStatObjCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Collect size and links data of an object",
+		ShortDescription: `Count the blocks and bytes reachable from an object, an actor head or, by default, the state root
+of the chain head. When a base object is given, the blocks reachable from it are not counted.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("objectCid", false, false, "object cid"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("base", "ignore the blocks reachable from this object"),
+		cmds.StringOption("actor", "collect the data reachable from the head of this actor"),
+		cmds.Uint64Option("max-depth", "do not follow the links deeper than this, 0 is unlimited").WithDefault(uint64(0)),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := ReqContext(req.Context)
+		maxDepth, _ := req.Options["max-depth"].(uint64)
+
+		var stats types.ObjStatWithDepth
+		if actor, _ := req.Options["actor"].(string); actor != "" {
+			addr, err := address.NewFromString(actor)
+			if err != nil {
+				return err
+			}
+			if stats, err = env.(*node.Env).ChainAPI.StateActorStatObj(ctx, addr, maxDepth, types.EmptyTSK); err != nil {
+				return err
+			}
+		} else {
+			var obj cid.Cid
+			if len(req.Arguments) > 0 {
+				c, err := cid.Parse(req.Arguments[0])
+				if err != nil {
+					return err
+				}
+				obj = c
+			} else {
+				head, err := env.(*node.Env).ChainAPI.ChainHead(ctx)
+				if err != nil {
+					return err
+				}
+				obj = head.ParentState()
+			}
+
+			base := cid.Undef
+			if b, _ := req.Options["base"].(string); b != "" {
+				c, err := cid.Parse(b)
+				if err != nil {
+					return err
+				}
+				base = c
+			}
+
+			var err error
+			if stats, err = env.(*node.Env).BlockStoreAPI.ChainStatObjWithDepth(ctx, obj, base, maxDepth); err != nil {
+				return err
+			}
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		writer.Printf("Links: %d\n", stats.Links)
+		writer.Printf("Size: %s (%d)\n", types.SizeStr(types.NewInt(stats.Size)), stats.Size)
+		if stats.Truncated {
+			writer.Printf("Links deeper than %d were not followed\n", maxDepth)
+		}
+
+		return re.Emit(buf)
+	},
+}
+
 var chainHeadCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Get heaviest tipset info",
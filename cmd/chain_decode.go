@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/app/node"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/utils"
+)
+
+var chainDecodeCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Decode the cbor of actor method calls into json",
+	},
+	Subcommands: map[string]*cmds.Command{
+		"params": chainDecodeParamsCmd,
+		"return": chainDecodeReturnCmd,
+	},
+}
+
+var chainDecodeParamsCmd = newChainDecodeCmd("Decode the parameters of a call to an actor method", false)
+
+var chainDecodeReturnCmd = newChainDecodeCmd("Decode the return value of a call to an actor method", true)
+
+func newChainDecodeCmd(tagline string, ret bool) *cmds.Command {
+	return &cmds.Command{
+		Helptext: cmds.HelpText{
+			Tagline: tagline,
+			ShortDescription: `--to is the address of the actor called, whose code is loaded at --tipset, its code cid or
+the name of a builtin actor such as 'miner' for the actors of the network version at --tipset.`,
+		},
+		Arguments: []cmds.Argument{
+			cmds.StringArg("data", true, false, "the encoded cbor"),
+		},
+		Options: []cmds.Option{
+			cmds.StringOption("to", "the actor called"),
+			cmds.Uint64Option("method", "the number of the method called"),
+			cmds.StringOption("encoding", "the encoding of the data, hex or base64").WithDefault("hex"),
+			cmds.StringOption("tipset", "the tipset the actor is loaded from, the head when empty").WithDefault(""),
+		},
+		Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+			to, ok := req.Options["to"].(string)
+			if !ok || to == "" {
+				return fmt.Errorf("must pass the actor called with --to")
+			}
+			method, ok := req.Options["method"].(uint64)
+			if !ok {
+				return fmt.Errorf("must pass the method called with --method")
+			}
+			data, err := decodeData(req.Arguments[0], req.Options["encoding"].(string))
+			if err != nil {
+				return err
+			}
+
+			ctx := req.Context
+			chainAPI := env.(*node.Env).ChainAPI
+			ts, err := LoadTipSet(ctx, req, chainAPI)
+			if err != nil {
+				return err
+			}
+			code, err := resolveActorCode(ctx, chainAPI, to, ts)
+			if err != nil {
+				return err
+			}
+
+			decoded, err := decodeMethodData(code, abi.MethodNum(method), data, ret)
+			if err != nil {
+				return err
+			}
+			out, err := json.MarshalIndent(decoded, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			buf := new(bytes.Buffer)
+			_ = NewSilentWriter(buf).Write(out)
+			return re.Emit(buf)
+		},
+	}
+}
+
+func decodeData(data, encoding string) ([]byte, error) {
+	switch encoding {
+	case "hex":
+		return hex.DecodeString(data)
+	case "base64":
+		return base64.StdEncoding.DecodeString(data)
+	default:
+		return nil, fmt.Errorf("unknown encoding %q, expected hex or base64", encoding)
+	}
+}
+
+// resolveActorCode returns the code of the actor `to`, an address, a code cid or the name of a builtin actor
+func resolveActorCode(ctx context.Context, chainAPI v1api.IChain, to string, ts *types.TipSet) (cid.Cid, error) {
+	if addr, err := address.NewFromString(to); err == nil {
+		act, err := chainAPI.StateGetActor(ctx, addr, ts.Key())
+		if err != nil {
+			return cid.Undef, fmt.Errorf("load actor %s: %w", addr, err)
+		}
+		return act.Code, nil
+	}
+	if code, err := cid.Decode(to); err == nil {
+		return code, nil
+	}
+
+	nv, err := chainAPI.StateNetworkVersion(ctx, ts.Key())
+	if err != nil {
+		return cid.Undef, err
+	}
+	codes, err := chainAPI.StateActorCodeCIDs(ctx, nv)
+	if err != nil {
+		return cid.Undef, err
+	}
+	code, ok := codes[to]
+	if !ok {
+		return cid.Undef, fmt.Errorf("%s is neither an address, a code cid nor a builtin actor of network version %d", to, nv)
+	}
+	return code, nil
+}
+
+// decodeMethodData decodes the parameters, or the return value when ret is set, of a call to the method of the
+// actor of the code
+func decodeMethodData(code cid.Cid, method abi.MethodNum, data []byte, ret bool) (interface{}, error) {
+	methodMeta, found := utils.MethodsMap[code][method]
+	if !found {
+		return nil, fmt.Errorf("method %d not found on actor %s", method, code)
+	}
+
+	am := types.ActorMethod{Name: methodMeta.Name, Params: methodMeta.Params, Ret: methodMeta.Ret}
+	if ret {
+		return am.DecodeReturn(data)
+	}
+	return am.DecodeParams(data)
+}
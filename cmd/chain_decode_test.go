@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	miner12 "github.com/filecoin-project/go-state-types/builtin/v12/miner"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+)
+
+func TestDecodeMethodData(t *testing.T) {
+	tf.UnitTest(t)
+
+	code, ok := actors.GetActorCodeID(actorstypes.Version12, manifest.MinerKey)
+	require.True(t, ok)
+	worker, _ := address.NewIDAddress(1000)
+
+	params := &miner12.ChangeWorkerAddressParams{NewWorker: worker, NewControlAddrs: []address.Address{worker}}
+	buf := new(bytes.Buffer)
+	require.NoError(t, params.MarshalCBOR(buf))
+	data, err := decodeData(hex.EncodeToString(buf.Bytes()), "hex")
+	require.NoError(t, err)
+
+	decoded, err := decodeMethodData(code, 3, data, false)
+	require.NoError(t, err)
+	assert.Equal(t, params, decoded)
+
+	ret := &miner12.GetControlAddressesReturn{Owner: worker, Worker: worker}
+	buf.Reset()
+	require.NoError(t, ret.MarshalCBOR(buf))
+	decoded, err = decodeMethodData(code, 2, buf.Bytes(), true)
+	require.NoError(t, err)
+	assert.Equal(t, ret, decoded)
+
+	// the parameters of a method do not decode as the return value of another
+	_, err = decodeMethodData(code, 2, data, true)
+	assert.Error(t, err)
+	_, err = decodeMethodData(code, abi.MethodNum(1<<40), data, false)
+	assert.Error(t, err)
+	_, err = decodeData("00", "base58")
+	assert.Error(t, err)
+}
@@ -11,7 +11,6 @@ import (
 	types2 "github.com/filecoin-project/venus/venus-shared/actors/types"
 	"github.com/filecoin-project/venus/venus-shared/utils"
 
-	"github.com/filecoin-project/venus/pkg/chainsync/slashfilter"
 	"github.com/filecoin-project/venus/pkg/util/ulimit"
 
 	paramfetch "github.com/filecoin-project/go-paramfetch"
@@ -54,6 +53,7 @@ var daemonCmd = &cmds.Command{
 		cmds.StringsOption(BootstrapPeers, "set the bootstrap peers"),
 		cmds.BoolOption(IsRelay, "advertise and allow venus network traffic to be relayed through this node"),
 		cmds.StringOption(ImportSnapshot, "import chain state from a given chain export file or url"),
+		cmds.StringsOption(CheckpointEndpoints, "bootstrap the chain from the latest checkpoint the trusted endpoints serve, the signers are set in the checkpoint config"),
 		cmds.StringOption(GenesisFile, "path of file or HTTP(S) URL containing archive of genesis block DAG data"),
 		cmds.StringOption(Network, "when set, populates config with network specific parameters, eg. mainnet,2k,calibrationnet,interopnet,butterflynet").WithDefault("mainnet"),
 		cmds.StringOption(Password, "set wallet password"),
@@ -169,6 +169,10 @@ func initRun(req *cmds.Request, repoDir string) error {
 		}
 	}
 
+	if endpoints, ok := req.Options[CheckpointEndpoints].([]string); ok && len(endpoints) > 0 {
+		cfg.Checkpoint.Endpoints = endpoints
+	}
+
 	if err := rep.ReplaceConfig(cfg); err != nil {
 		log.Errorf("Error replacing config %s", err)
 		return err
@@ -187,6 +191,11 @@ func initRun(req *cmds.Request, repoDir string) error {
 			log.Errorf("failed to import snapshot, import path: %s, error: %s", importPath, err.Error())
 			return err
 		}
+	} else if len(cfg.Checkpoint.Endpoints) != 0 {
+		if err := ImportCheckpoint(req.Context, rep); err != nil {
+			log.Errorf("failed to bootstrap from a checkpoint, endpoints: %v, error: %s", cfg.Checkpoint.Endpoints, err.Error())
+			return err
+		}
 	}
 
 	return nil
@@ -198,6 +207,9 @@ func daemonRun(req *cmds.Request, re cmds.ResponseEmitter) error {
 	if err != nil {
 		return err
 	}
+	if err := migration.MigrateMetaDatastore(req.Context, rep.MetaDatastore(), migration.LatestMetaVersion()); err != nil {
+		return fmt.Errorf("migrate metadata datastore: %w", err)
+	}
 
 	config := rep.Config()
 	if err := networks.SetConfigFromNetworkType(config, config.NetworkParams.NetworkType); err != nil {
@@ -297,13 +309,6 @@ func daemonRun(req *cmds.Request, re cmds.ResponseEmitter) error {
 		_ = re.Emit("--" + ELStdout + " option is deprecated\n")
 	}
 
-	if config.FaultReporter.EnableConsensusFaultReporter {
-		if err := slashfilter.SlashConsensus(req.Context, config.FaultReporter, fcn.Wallet().API(),
-			fcn.Chain().API(), fcn.Mpool().API(), fcn.Sync().API()); err != nil {
-			return fmt.Errorf("run consensus fault reporter failed: %v", err)
-		}
-	}
-
 	// Start the node.
 	if err := fcn.Start(req.Context); err != nil {
 		return err
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/cmd/tablewriter"
+	"github.com/filecoin-project/venus/pkg/migration"
+)
+
+var dbCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Manage the local datastores",
+	},
+	Subcommands: map[string]*cmds.Command{
+		"migrate": dbMigrateCmd,
+	},
+}
+
+var dbMigrateCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Migrate the metadata datastore to a schema version",
+		ShortDescription: `The daemon migrates the metadata datastore to the latest version on startup.
+Use this command to roll the datastore back before running an older venus, the daemon must not be running.`,
+	},
+	Options: []cmds.Option{
+		cmds.Uint64Option("to", "the version to migrate to, defaults to the latest version"),
+		cmds.BoolOption("list", "list the versions without migrating"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+
+		repoDir, _ := req.Options[OptionRepoDir].(string)
+		rep, err := getRepo(repoDir)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = rep.Close()
+		}()
+		ds := rep.MetaDatastore()
+
+		if list, _ := req.Options["list"].(bool); list {
+			current, err := migration.ReadMetaVersion(ctx, ds)
+			if err != nil {
+				return err
+			}
+
+			buf := &bytes.Buffer{}
+			tw := tablewriter.New(tablewriter.Col("Version"), tablewriter.Col("Current"), tablewriter.Col("Name"))
+			for _, m := range migration.MetaMigrations() {
+				mark := ""
+				if m.Version == current {
+					mark = "*"
+				}
+				tw.Write(map[string]interface{}{
+					"Version": m.Version,
+					"Current": mark,
+					"Name":    m.Name,
+				})
+			}
+			if err := tw.Flush(buf); err != nil {
+				return err
+			}
+			return re.Emit(buf)
+		}
+
+		target := migration.LatestMetaVersion()
+		if to, ok := req.Options["to"].(uint64); ok {
+			target = uint(to)
+		}
+
+		from, err := migration.ReadMetaVersion(ctx, ds)
+		if err != nil {
+			return err
+		}
+		if err := migration.MigrateMetaDatastore(ctx, ds, target); err != nil {
+			return err
+		}
+
+		return re.Emit(fmt.Sprintf("metadata datastore migrated from version %d to version %d", from, target))
+	},
+}
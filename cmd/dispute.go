@@ -8,18 +8,14 @@ import (
 	"strconv"
 	"time"
 
-	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
-	miner3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
-
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
-	"github.com/filecoin-project/go-state-types/big"
 	cmds "github.com/ipfs/go-ipfs-cmds"
 
 	logging "github.com/ipfs/go-log/v2"
 
 	"github.com/filecoin-project/venus/app/node"
-	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/msgbuilder"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
@@ -84,21 +80,13 @@ var disputerMsgCmd = &cmds.Command{
 			return err
 		}
 
-		dpp, aerr := actors.SerializeParams(&miner3.DisputeWindowedPoStParams{
-			Deadline:  deadline,
-			PoStIndex: postIndex,
-		})
-
-		if aerr != nil {
-			return fmt.Errorf("failed to serailize params: %w", aerr)
+		builder, err := newMsgBuilder(req.Context, env, fromAddr)
+		if err != nil {
+			return err
 		}
-
-		dmsg := &types.Message{
-			To:     toa,
-			From:   fromAddr,
-			Value:  big.Zero(),
-			Method: builtin3.MethodsMiner.DisputeWindowedPoSt,
-			Params: dpp,
+		dmsg, err := builder.Miner(toa).DisputeWindowedPoSt(deadline, postIndex)
+		if err != nil {
+			return err
 		}
 
 		rslt, err := env.(*node.Env).ChainAPI.StateCall(req.Context, dmsg, types.EmptyTSK)
@@ -352,23 +340,16 @@ var disputerStartCmd = &cmds.Command{
 func makeDisputeWindowedPosts(ctx context.Context, api v1api.IChain, dl minerDeadline, postsSnapshotted uint64, sender address.Address) ([]*types.Message, error) {
 	disputes := make([]*types.Message, 0)
 
-	for i := uint64(0); i < postsSnapshotted; i++ {
-
-		dpp, aerr := actors.SerializeParams(&miner3.DisputeWindowedPoStParams{
-			Deadline:  dl.index,
-			PoStIndex: i,
-		})
-
-		if aerr != nil {
-			return nil, fmt.Errorf("failed to serailize params: %w", aerr)
-		}
+	nv, err := api.StateNetworkVersion(ctx, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("getting network version: %w", err)
+	}
+	builder := msgbuilder.New(nv, sender)
 
-		dispute := &types.Message{
-			To:     dl.miner,
-			From:   sender,
-			Value:  big.Zero(),
-			Method: builtin3.MethodsMiner.DisputeWindowedPoSt,
-			Params: dpp,
+	for i := uint64(0); i < postsSnapshotted; i++ {
+		dispute, err := builder.Miner(dl.miner).DisputeWindowedPoSt(dl.index, i)
+		if err != nil {
+			return nil, err
 		}
 
 		rslt, err := api.StateCall(ctx, dispute, types.EmptyTSK)
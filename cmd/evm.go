@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/ipfs/go-cid"
 	cmds "github.com/ipfs/go-ipfs-cmds"
@@ -19,6 +20,7 @@ import (
 	builtintypes "github.com/filecoin-project/go-state-types/builtin"
 	"github.com/filecoin-project/go-state-types/builtin/v10/eam"
 	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/crypto/sha3"
 
 	"github.com/filecoin-project/venus/app/node"
 	"github.com/filecoin-project/venus/pkg/constants"
@@ -44,7 +46,7 @@ var evmCmd = &cmds.Command{
 
 var evmGetInfoCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
-		Tagline: "Print eth/filecoin addrs and code cid",
+		Tagline: "Print eth/filecoin addrs, code cid and the contract stats",
 	},
 	Arguments: []cmds.Argument{
 		cmds.StringArg("address", true, false, "Filecoin address or Ethereum address"),
@@ -93,6 +95,19 @@ var evmGetInfoCmd = &cmds.Command{
 				writer.Println("Code cid:         ", actor.Code.String())
 				writer.Println("Actor Type:       ", builtin.ActorNameByCode(actor.Code))
 			}
+			writer.Println("Balance:          ", types.FIL(actor.Balance))
+			writer.Println("Nonce:            ", actor.Nonce)
+
+			if builtin.IsEvmActor(actor.Code) {
+				code, err := env.(*node.Env).EthAPI.EthGetCode(ctx, eaddr, types.NewEthBlockNumberOrHashFromPredefined("latest"))
+				if err != nil {
+					return fmt.Errorf("failed to get bytecode: %w", err)
+				}
+				hasher := sha3.NewLegacyKeccak256()
+				hasher.Write(code)
+				writer.Println("Bytecode size:    ", len(code))
+				writer.Printf("Bytecode hash:     0x%x\n", hasher.Sum(nil))
+			}
 		}
 
 		return re.Emit(buf)
@@ -199,16 +214,21 @@ var evmGetContractAddressCmd = &cmds.Command{
 var evmDeployCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Deploy an EVM smart contract and return its address",
+		ShortDescription: `The constructor arguments follow the contract file and are encoded with the types of --ctor-types:
+    venus evm deploy --hex --ctor-types "uint256,address" contract.hex 1000 0xff00...0064
+`,
 	},
 	Arguments: []cmds.Argument{
 		cmds.StringArg("contract", true, false, "contract init code"),
+		cmds.StringArg("args", false, true, "constructor arguments"),
 	},
 	Options: []cmds.Option{
 		cmds.StringOption("from", "optionally specify the account to use for sending the exec message"),
 		cmds.BoolOption("hex", "use when input contract is in hex"),
+		cmds.StringOption("ctor-types", "comma separated abi types of the constructor arguments, eg. uint256,address"),
 	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
-		if len(req.Arguments) != 1 {
+		if len(req.Arguments) < 1 {
 			return errors.New("must pass contract init code")
 		}
 
@@ -224,6 +244,18 @@ var evmDeployCmd = &cmds.Command{
 			}
 		}
 
+		ctorTypeList, _ := req.Options["ctor-types"].(string)
+		ctorTypes, err := types.EthAbiParseTypes(ctorTypeList)
+		if err != nil {
+			return fmt.Errorf("invalid constructor types: %w", err)
+		}
+		ctorArgs, err := types.EthAbiEncode(ctorTypes, req.Arguments[1:])
+		if err != nil {
+			return fmt.Errorf("failed to encode constructor arguments: %w", err)
+		}
+		// the constructor reads its arguments after the init code
+		contract = append(contract, ctorArgs...)
+
 		var fromAddr address.Address
 		from, _ := req.Options["from"].(string)
 		if len(from) == 0 {
@@ -315,19 +347,24 @@ var evmDeployCmd = &cmds.Command{
 var evmInvokeCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Invoke an EVM smart contract using the specified CALLDATA",
+		ShortDescription: `The calldata is given in hex, or built from the function signature and its arguments with --sig:
+    venus evm invoke --sig "transfer(address,uint256)" --returns bool <contract> 0xff00...0064 1000
+`,
 	},
 	Arguments: []cmds.Argument{
 		cmds.StringArg("address", true, false, "address"),
-		cmds.StringArg("call-data", false, false, "calldata"),
+		cmds.StringArg("call-data", false, true, "calldata, or the function arguments when --sig is set"),
 	},
 	Options: []cmds.Option{
 		cmds.StringOption("from", "optionally specify the account to use for sending the exec message"),
 		cmds.Int64Option("value", "optionally specify the value to be sent with the invokation message"),
+		cmds.StringOption("sig", "function signature used to encode the arguments, eg. transfer(address,uint256)"),
+		cmds.StringOption("returns", "comma separated abi types used to decode the result, eg. uint256,bool"),
 	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
 		ctx := req.Context
 
-		if len(req.Arguments) != 2 {
+		if len(req.Arguments) < 1 {
 			return fmt.Errorf("must pass the address and calldata")
 		}
 
@@ -336,9 +373,15 @@ var evmInvokeCmd = &cmds.Command{
 			return fmt.Errorf("failed to decode address: %w", err)
 		}
 
-		callData, err := types.DecodeHexStringTrimSpace(req.Arguments[1])
+		sig, _ := req.Options["sig"].(string)
+		callData, err := evmCallData(sig, req.Arguments[1:])
+		if err != nil {
+			return err
+		}
+		returnTypes, _ := req.Options["returns"].(string)
+		returns, err := types.EthAbiParseTypes(returnTypes)
 		if err != nil {
-			return fmt.Errorf("decoding hex input data: %w", err)
+			return fmt.Errorf("invalid return types: %w", err)
 		}
 
 		var buffer bytes.Buffer
@@ -401,6 +444,13 @@ var evmInvokeCmd = &cmds.Command{
 
 		if len(result) > 0 {
 			afmt.Println("Result: ", hex.EncodeToString(result))
+			if len(returns) > 0 {
+				values, err := types.EthAbiDecode(returns, result)
+				if err != nil {
+					return fmt.Errorf("failed to decode result: %w", err)
+				}
+				afmt.Println("Decoded: ", strings.Join(values, ", "))
+			}
 		} else {
 			afmt.Println("OK")
 		}
@@ -485,6 +535,26 @@ var evmGetBytecode = &cmds.Command{
 	},
 }
 
+// evmCallData returns the hex calldata of args, or encodes args as the arguments of the function when sig is set
+func evmCallData(sig string, args []string) ([]byte, error) {
+	if len(sig) > 0 {
+		callData, err := types.EthAbiEncodeCall(sig, args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode call: %w", err)
+		}
+		return callData, nil
+	}
+
+	if len(args) != 1 {
+		return nil, fmt.Errorf("must pass the calldata in hex, or the function signature with --sig")
+	}
+	callData, err := types.DecodeHexStringTrimSpace(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("decoding hex input data: %w", err)
+	}
+	return callData, nil
+}
+
 func ethAddrFromFilecoinAddress(ctx context.Context, addr address.Address, chainAPI v1api.IChain) (types.EthAddress, address.Address, error) {
 	var faddr address.Address
 	var err error
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/docker/go-units"
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	verifreg13 "github.com/filecoin-project/go-state-types/builtin/v13/verifreg"
+	"github.com/ipfs/go-cid"
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var filplusCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Interact with the verified registry and the datacap of the clients",
+	},
+	Subcommands: map[string]*cmds.Command{
+		"list-notaries":        filplusListNotariesCmd,
+		"list-clients":         filplusListClientsCmd,
+		"check-client-datacap": filplusCheckClientCmd,
+		"check-notary-datacap": filplusCheckNotaryCmd,
+		"removal-proposal-id":  filplusRemovalProposalIDCmd,
+		"transfer-datacap":     filplusTransferCmd,
+		"allocate":             filplusAllocateCmd,
+	},
+}
+
+func dataCapTableEncoder() cmds.EncoderFunc {
+	return cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, entries []types.DataCapEntry) error {
+		tw := NewSilentWriter(w)
+		for _, e := range entries {
+			tw.Printf("%s: %s\n", e.Address, types.SizeStr(e.DataCap))
+		}
+		return tw.Error()
+	})
+}
+
+var filplusListNotariesCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List the notaries with their remaining allowance",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		entries, err := env.(*node.Env).ChainAPI.StateListVerifiers(req.Context, types.EmptyTSK)
+		if err != nil {
+			return err
+		}
+		return re.Emit(entries)
+	},
+	Type: []types.DataCapEntry{},
+	Encoders: cmds.EncoderMap{
+		OutputTable: dataCapTableEncoder(),
+	},
+}
+
+var filplusListClientsCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List the verified clients with their datacap",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		entries, err := env.(*node.Env).ChainAPI.StateListVerifiedClients(req.Context, types.EmptyTSK)
+		if err != nil {
+			return err
+		}
+		return re.Emit(entries)
+	},
+	Type: []types.DataCapEntry{},
+	Encoders: cmds.EncoderMap{
+		OutputTable: dataCapTableEncoder(),
+	},
+}
+
+var filplusCheckClientCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Print the datacap of a client",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("address", true, false, "address of the client"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		addr, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		dcap, err := env.(*node.Env).ChainAPI.StateVerifiedClientStatus(req.Context, addr, types.EmptyTSK)
+		if err != nil {
+			return err
+		}
+		if dcap == nil {
+			return fmt.Errorf("%s is not a verified client", addr)
+		}
+		return re.Emit(fmt.Sprintf("%s: %s", addr, types.SizeStr(*dcap)))
+	},
+}
+
+var filplusCheckNotaryCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Print the remaining allowance of a notary",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("address", true, false, "address of the notary"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		addr, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		dcap, err := env.(*node.Env).ChainAPI.StateVerifierStatus(req.Context, addr, types.EmptyTSK)
+		if err != nil {
+			return err
+		}
+		if dcap == nil {
+			return fmt.Errorf("%s is not a notary", addr)
+		}
+		return re.Emit(fmt.Sprintf("%s: %s", addr, types.SizeStr(*dcap)))
+	},
+}
+
+var filplusRemovalProposalIDCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Print the id the next proposal of a notary to remove datacap of a client must sign",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("notary", true, false, "address of the notary"),
+		cmds.StringArg("client", true, false, "address of the client"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		verifier, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		client, err := address.NewFromString(req.Arguments[1])
+		if err != nil {
+			return err
+		}
+		id, err := env.(*node.Env).ChainAPI.StateRemoveDataCapProposalID(req.Context, verifier, client, types.EmptyTSK)
+		if err != nil {
+			return err
+		}
+		return re.Emit(strconv.FormatUint(id, 10))
+	},
+}
+
+func parseDataCap(s string) (abi.StoragePower, error) {
+	size, err := units.RAMInBytes(s)
+	if err != nil {
+		return big.Zero(), fmt.Errorf("invalid datacap %s: %w", s, err)
+	}
+	return abi.NewStoragePower(size), nil
+}
+
+var filplusTransferCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Transfer datacap of a client to another client",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("to", true, false, "address of the client receiving the datacap"),
+		cmds.StringArg("datacap", true, false, "datacap to transfer, such as 32GiB"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("from", "address of the client sending the datacap, the default wallet address when empty"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		to, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		dcap, err := parseDataCap(req.Arguments[1])
+		if err != nil {
+			return err
+		}
+		from, err := fromAddrOrDefault(req, env)
+		if err != nil {
+			return err
+		}
+
+		builder, err := newMsgBuilder(ctx, env, from)
+		if err != nil {
+			return err
+		}
+		msg, err := builder.Datacap().Transfer(to, dcap)
+		if err != nil {
+			return err
+		}
+		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, nil)
+		if err != nil {
+			return err
+		}
+		return re.Emit(fmt.Sprintf("Transferring %s of datacap to %s in message %s", types.SizeStr(dcap), to, smsg.Cid()))
+	},
+}
+
+var filplusAllocateCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Allocate datacap of a client to a piece stored by a provider",
+		ShortDescription: `The datacap of the piece is transferred to the verified registry, which makes the allocation the
+provider claims when it seals the piece. The terms are in epochs, the expiration is the last epoch the provider may
+claim the allocation.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("provider", true, false, "address of the miner storing the piece"),
+		cmds.StringArg("piece-cid", true, false, "cid of the piece"),
+		cmds.StringArg("piece-size", true, false, "padded size of the piece, such as 32GiB"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("from", "address of the client, the default wallet address when empty"),
+		cmds.Int64Option("term-min", "minimum term the provider must store the piece").WithDefault(int64(verifreg13.MinimumVerifiedAllocationTerm)),
+		cmds.Int64Option("term-max", "maximum term the provider earns power for the piece").WithDefault(int64(verifreg13.MaximumVerifiedAllocationTerm)),
+		cmds.Int64Option("expiration", "epochs from the head the provider may claim the allocation in").WithDefault(int64(verifreg13.MaximumVerifiedAllocationExpiration)),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		provider, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		pieceCid, err := cid.Decode(req.Arguments[1])
+		if err != nil {
+			return fmt.Errorf("invalid piece cid: %w", err)
+		}
+		size, err := units.RAMInBytes(req.Arguments[2])
+		if err != nil {
+			return fmt.Errorf("invalid piece size: %w", err)
+		}
+		pieceSize := abi.PaddedPieceSize(size)
+		if err := pieceSize.Validate(); err != nil {
+			return err
+		}
+		from, err := fromAddrOrDefault(req, env)
+		if err != nil {
+			return err
+		}
+
+		chainAPI := env.(*node.Env).ChainAPI
+		providerID, err := chainAPI.StateLookupID(ctx, provider, types.EmptyTSK)
+		if err != nil {
+			return err
+		}
+		pid, err := address.IDFromAddress(providerID)
+		if err != nil {
+			return err
+		}
+		head, err := chainAPI.ChainHead(ctx)
+		if err != nil {
+			return err
+		}
+		termMin, _ := req.Options["term-min"].(int64)
+		termMax, _ := req.Options["term-max"].(int64)
+		expiration, _ := req.Options["expiration"].(int64)
+
+		builder, err := newMsgBuilder(ctx, env, from)
+		if err != nil {
+			return err
+		}
+		msg, err := builder.Datacap().Allocate(&verifreg13.AllocationRequests{
+			Allocations: []verifreg13.AllocationRequest{{
+				Provider:   abi.ActorID(pid),
+				Data:       pieceCid,
+				Size:       pieceSize,
+				TermMin:    abi.ChainEpoch(termMin),
+				TermMax:    abi.ChainEpoch(termMax),
+				Expiration: head.Height() + abi.ChainEpoch(expiration),
+			}},
+		}, big.Zero())
+		if err != nil {
+			return err
+		}
+		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, nil)
+		if err != nil {
+			return err
+		}
+		return re.Emit(fmt.Sprintf("Allocating %s of datacap to %s in message %s", types.SizeStr(big.NewInt(size)), provider, smsg.Cid()))
+	},
+}
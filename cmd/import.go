@@ -8,6 +8,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/filecoin-project/venus/pkg/chainsync/checkpoint"
 	"github.com/filecoin-project/venus/pkg/consensus/chainselector"
 	"github.com/filecoin-project/venus/pkg/httpreader"
 
@@ -25,16 +26,33 @@ var logImport = logging.Logger("commands/import")
 // Import cache tipset cids to store.
 // The value of the cached tipset CIDS is used as the check-point when running `venus daemon`
 func Import(ctx context.Context, r repo.Repo, fileName string) error {
-	return importChain(ctx, r, fileName)
+	_, err := importChain(ctx, r, fileName)
+	return err
 }
 
-func importChain(ctx context.Context, r repo.Repo, fname string) error {
+// ImportCheckpoint imports the snapshot of the latest checkpoint the trusted endpoints of the config serve, and
+// starts the chain from the checkpoint tipset. The chain before it is not validated.
+func ImportCheckpoint(ctx context.Context, r repo.Repo) error {
+	cp, err := checkpoint.Fetch(ctx, r.Config().Checkpoint)
+	if err != nil {
+		return err
+	}
+	logImport.Infof("importing checkpoint %s at %d signed by %s from %s", cp.TipSetKey, cp.Height, cp.Signer, cp.Snapshot)
+
+	chainStore, err := importChain(ctx, r, cp.Snapshot)
+	if err != nil {
+		return err
+	}
+	return checkpoint.Apply(ctx, chainStore, &cp.Checkpoint)
+}
+
+func importChain(ctx context.Context, r repo.Repo, fname string) (*chain.Store, error) {
 	var rd io.Reader
 	var l int64
 	if strings.HasPrefix(fname, "http://") || strings.HasPrefix(fname, "https://") {
 		rrd, err := httpreader.NewResumableReader(ctx, fname)
 		if err != nil {
-			return fmt.Errorf("fetching chain CAR failed: setting up resumable reader: %w", err)
+			return nil, fmt.Errorf("fetching chain CAR failed: setting up resumable reader: %w", err)
 		}
 
 		rd = rrd
@@ -42,18 +60,18 @@ func importChain(ctx context.Context, r repo.Repo, fname string) error {
 	} else {
 		fname, err := homedir.Expand(fname)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		fi, err := os.Open(fname)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		defer fi.Close() //nolint:errcheck
 
 		st, err := os.Stat(fname)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		rd = fi
@@ -68,7 +86,7 @@ func importChain(ctx context.Context, r repo.Repo, fname string) error {
 
 	header, err := bufr.Peek(4)
 	if err != nil {
-		return fmt.Errorf("peek header: %w", err)
+		return nil, fmt.Errorf("peek header: %w", err)
 	}
 
 	bar := pb.New64(l)
@@ -92,19 +110,19 @@ func importChain(ctx context.Context, r repo.Repo, fname string) error {
 	bar.Start()
 	tip, genesisBlk, err := chainStore.Import(ctx, ir)
 	if err != nil {
-		return fmt.Errorf("importing chain failed: %s", err)
+		return nil, fmt.Errorf("importing chain failed: %s", err)
 	}
 	bar.Finish()
 
 	err = chainStore.SetHead(context.TODO(), tip)
 	if err != nil {
-		return fmt.Errorf("importing chain failed: %s", err)
+		return nil, fmt.Errorf("importing chain failed: %s", err)
 	}
 	logImport.Infof("accepting %s as new head", tip.Key().String())
 
 	if err := chainStore.PersistGenesisCID(ctx, genesisBlk); err != nil {
-		return fmt.Errorf("persist genesis failed: %v", err)
+		return nil, fmt.Errorf("persist genesis failed: %v", err)
 	}
 
-	return err
+	return chainStore, nil
 }
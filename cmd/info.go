@@ -1,21 +1,58 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/dustin/go-humanize"
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/venus/app/node"
 	v1 "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	cmds "github.com/ipfs/go-ipfs-cmds"
+	"github.com/libp2p/go-libp2p/core/metrics"
 )
 
+// The sync states of the node
+const (
+	SyncOK     = "ok"
+	SyncSlow   = "slow"
+	SyncBehind = "behind"
+)
+
+// NodeInfo is the result of the info command
+type NodeInfo struct {
+	Network   types.NetworkName
+	StartTime time.Time
+	Head      abi.ChainEpoch
+	// Sync is SyncOK when the head is less than 1.5 epochs old, SyncSlow when it is less than 5 epochs old
+	Sync           string
+	SyncBehindSecs int64
+	BaseFee        types.FIL
+
+	PeersToPublishMsgs   int
+	PeersToPublishBlocks int
+	// ChainHealth is the percentage of the blocks of the last finality over 5 blocks per tipset
+	ChainHealth float64
+
+	// DefaultAddress is nil when the default address is not set
+	DefaultAddress  *address.Address `json:",omitempty"`
+	DefaultBalance  *types.FIL       `json:",omitempty"`
+	Addresses       int
+	TotalBalance    types.FIL
+	MarketLocked    types.FIL
+	MarketAvailable types.FIL
+
+	PaymentChannels int
+	Bandwidth       metrics.Stats
+}
+
 var infoCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Print node info",
@@ -24,53 +61,40 @@ var infoCmd = &cmds.Command{
 		ctx := req.Context
 		chainAPI := env.(*node.Env).ChainAPI
 		commonAPI := env.(*node.Env).CommonAPI
-		buf := new(bytes.Buffer)
-		writer := NewSilentWriter(buf)
+		info := &NodeInfo{}
 
 		netParams, err := chainAPI.StateGetNetworkParams(ctx)
 		if err != nil {
 			return err
 		}
-		writer.Printf("Network: %s\n", netParams.NetworkName)
+		info.Network = netParams.NetworkName
 
-		start, err := commonAPI.StartTime(ctx)
-		if err != nil {
+		if info.StartTime, err = commonAPI.StartTime(ctx); err != nil {
 			return err
 		}
-		writer.Printf("StartTime: %s (started at %s)\n", time.Since(start).Truncate(time.Second), start.Truncate(time.Second))
 
-		if err := SyncBasefeeCheck(ctx, chainAPI, int64(netParams.BlockDelaySecs), writer); err != nil {
+		if err := SyncBasefeeCheck(ctx, chainAPI, int64(netParams.BlockDelaySecs), info); err != nil {
 			return err
 		}
 		status, err := commonAPI.NodeStatus(ctx, true)
 		if err != nil {
 			return err
 		}
-		writer.Printf("Peers to: [publish messages %d] [publish blocks %d]\n", status.PeerStatus.PeersToPublishMsgs,
-			status.PeerStatus.PeersToPublishBlocks)
+		info.PeersToPublishMsgs = status.PeerStatus.PeersToPublishMsgs
+		info.PeersToPublishBlocks = status.PeerStatus.PeersToPublishBlocks
 
 		//Chain health calculated as percentage: amount of blocks in last finality / very healthy amount of blocks in a finality (900 epochs * 5 blocks per tipset)
-		health := (100 * (900 * status.ChainStatus.BlocksPerTipsetLastFinality) / (900 * 5))
-		switch {
-		case health > 85:
-			writer.Printf("Chain health: %.f%% [healthy]\n", health)
-		case health < 85:
-			writer.Printf("Chain health: %.f%% [unhealthy]\n", health)
-		}
-		writer.Println()
+		info.ChainHealth = (100 * (900 * status.ChainStatus.BlocksPerTipsetLastFinality) / (900 * 5))
 
 		addr, err := env.(*node.Env).WalletAPI.WalletDefaultAddress(ctx)
 		if err == nil && !addr.Empty() {
-			fmt.Printf("Default address: \n")
 			balance, err := env.(*node.Env).WalletAPI.WalletBalance(ctx, addr)
 			if err != nil {
 				return err
 			}
-			writer.Printf("      %s [%s]\n", addr.String(), types.FIL(balance).Short())
-		} else {
-			writer.Printf("Default address: address not set\n")
+			info.DefaultAddress = &addr
+			info.DefaultBalance = (*types.FIL)(&balance)
 		}
-		writer.Println()
 
 		addrs := env.(*node.Env).WalletAPI.WalletAddresses(ctx)
 		totalBalance := big.Zero()
@@ -81,8 +105,8 @@ var infoCmd = &cmds.Command{
 			}
 			totalBalance = big.Add(totalBalance, totbal)
 		}
-		writer.Printf("Wallet: %v address\n", len(addrs))
-		writer.Printf("      Total balance: %s\n", types.FIL(totalBalance).Short())
+		info.Addresses = len(addrs)
+		info.TotalBalance = types.FIL(totalBalance)
 
 		mbLockedSum := big.Zero()
 		mbAvailableSum := big.Zero()
@@ -97,51 +121,96 @@ var infoCmd = &cmds.Command{
 			mbLockedSum = big.Add(mbLockedSum, mbal.Locked)
 			mbAvailableSum = big.Add(mbAvailableSum, mbal.Escrow)
 		}
-		writer.Printf("      Market locked: %s\n", types.FIL(mbLockedSum).Short())
-		writer.Printf("      Market available: %s\n", types.FIL(mbAvailableSum).Short())
-		writer.Println()
+		info.MarketLocked = types.FIL(mbLockedSum)
+		info.MarketAvailable = types.FIL(mbAvailableSum)
 
 		chs, err := env.(*node.Env).PaychAPI.PaychList(ctx)
 		if err != nil {
 			return err
 		}
-		writer.Printf("Payment Channels: %v channels\n", len(chs))
-		writer.Println()
+		info.PaymentChannels = len(chs)
 
-		s, err := env.(*node.Env).NetworkAPI.NetBandwidthStats(ctx)
-		if err != nil {
-			return err
-		}
-		tw := tabwriter.NewWriter(writer.w, 6, 6, 2, ' ', 0)
-		writer.Printf("Bandwidth:\n")
-		fmt.Fprintf(tw, "\tTotalIn\tTotalOut\tRateIn\tRateOut\n")
-		fmt.Fprintf(tw, "\t%s\t%s\t%s/s\t%s/s\n", humanize.Bytes(uint64(s.TotalIn)), humanize.Bytes(uint64(s.TotalOut)), humanize.Bytes(uint64(s.RateIn)), humanize.Bytes(uint64(s.RateOut)))
-		if err := tw.Flush(); err != nil {
+		if info.Bandwidth, err = env.(*node.Env).NetworkAPI.NetBandwidthStats(ctx); err != nil {
 			return err
 		}
 
-		return re.Emit(buf)
+		return re.Emit(info)
+	},
+	Type: NodeInfo{},
+	Encoders: cmds.EncoderMap{
+		OutputTable: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, info *NodeInfo) error {
+			writer := NewSilentWriter(w)
+			writer.Printf("Network: %s\n", info.Network)
+			writer.Printf("StartTime: %s (started at %s)\n", time.Since(info.StartTime).Truncate(time.Second), info.StartTime.Truncate(time.Second))
+
+			behind := (time.Duration(info.SyncBehindSecs) * time.Second).String()
+			syncStatus := "[sync ok]"
+			switch info.Sync {
+			case SyncSlow:
+				syncStatus = fmt.Sprintf("[sync slow (%s behind)]", behind)
+			case SyncBehind:
+				syncStatus = fmt.Sprintf("[sync behind! (%s behind)]", behind)
+			}
+			writer.Printf("Chain: %s [basefee %s] [epoch %v]\n", syncStatus, info.BaseFee.Short(), info.Head)
+			writer.Printf("Peers to: [publish messages %d] [publish blocks %d]\n", info.PeersToPublishMsgs, info.PeersToPublishBlocks)
+
+			switch {
+			case info.ChainHealth > 85:
+				writer.Printf("Chain health: %.f%% [healthy]\n", info.ChainHealth)
+			case info.ChainHealth < 85:
+				writer.Printf("Chain health: %.f%% [unhealthy]\n", info.ChainHealth)
+			}
+			writer.Println()
+
+			if info.DefaultAddress != nil {
+				writer.Printf("Default address: \n")
+				writer.Printf("      %s [%s]\n", info.DefaultAddress.String(), info.DefaultBalance.Short())
+			} else {
+				writer.Printf("Default address: address not set\n")
+			}
+			writer.Println()
+
+			writer.Printf("Wallet: %v address\n", info.Addresses)
+			writer.Printf("      Total balance: %s\n", info.TotalBalance.Short())
+			writer.Printf("      Market locked: %s\n", info.MarketLocked.Short())
+			writer.Printf("      Market available: %s\n", info.MarketAvailable.Short())
+			writer.Println()
+
+			writer.Printf("Payment Channels: %v channels\n", info.PaymentChannels)
+			writer.Println()
+
+			s := info.Bandwidth
+			tw := tabwriter.NewWriter(w, 6, 6, 2, ' ', 0)
+			writer.Printf("Bandwidth:\n")
+			fmt.Fprintf(tw, "\tTotalIn\tTotalOut\tRateIn\tRateOut\n")
+			fmt.Fprintf(tw, "\t%s\t%s\t%s/s\t%s/s\n", humanize.Bytes(uint64(s.TotalIn)), humanize.Bytes(uint64(s.TotalOut)), humanize.Bytes(uint64(s.RateIn)), humanize.Bytes(uint64(s.RateOut)))
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+			return writer.Error()
+		}),
 	},
 }
 
-func SyncBasefeeCheck(ctx context.Context, chainAPI v1.IChain, blockDelaySecs int64, writer *SilentWriter) error {
+// SyncBasefeeCheck sets the head, the sync state and the base fee of info
+func SyncBasefeeCheck(ctx context.Context, chainAPI v1.IChain, blockDelaySecs int64, info *NodeInfo) error {
 	head, err := chainAPI.ChainHead(ctx)
 	if err != nil {
 		return err
 	}
 
-	var syncStatus string
+	behind := time.Now().Unix() - int64(head.MinTimestamp())
 	switch {
-	case time.Now().Unix()-int64(head.MinTimestamp()) < blockDelaySecs*3/2: // within 1.5 epochs
-		syncStatus = "[sync ok]"
-	case time.Now().Unix()-int64(head.MinTimestamp()) < blockDelaySecs*5: // within 5 epochs
-		syncStatus = fmt.Sprintf("[sync slow (%s behind)]", time.Since(time.Unix(int64(head.MinTimestamp()), 0)).Truncate(time.Second))
+	case behind < blockDelaySecs*3/2: // within 1.5 epochs
+		info.Sync = SyncOK
+	case behind < blockDelaySecs*5: // within 5 epochs
+		info.Sync = SyncSlow
 	default:
-		syncStatus = fmt.Sprintf("[sync behind! (%s behind)]", time.Since(time.Unix(int64(head.MinTimestamp()), 0)).Truncate(time.Second))
+		info.Sync = SyncBehind
 	}
-	basefee := head.MinTicketBlock().ParentBaseFee
-
-	writer.Printf("Chain: %s [basefee %s] [epoch %v]\n", syncStatus, types.FIL(basefee).Short(), head.Height())
+	info.SyncBehindSecs = behind
+	info.BaseFee = types.FIL(head.MinTicketBlock().ParentBaseFee)
+	info.Head = head.Height()
 
 	return nil
 }
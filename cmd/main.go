@@ -73,6 +73,9 @@ const (
 
 	ImportSnapshot = "import-snapshot"
 
+	// CheckpointEndpoints are the trusted services the chain of a new repo is bootstrapped from
+	CheckpointEndpoints = "checkpoint-endpoints"
+
 	// wallet password
 	Password = "password"
 
@@ -84,9 +87,12 @@ const (
 	Profile = "profile"
 )
 
+// prettyJSON is the default encoding, the json encoding indented with tabs
+const prettyJSON = "pretty-json"
+
 func init() {
 	// add pretty json as an encoding type
-	cmds.Encoders["pretty-json"] = func(req *cmds.Request) func(io.Writer) cmds.Encoder {
+	cmds.Encoders[prettyJSON] = func(req *cmds.Request) func(io.Writer) cmds.Encoder {
 		return func(w io.Writer) cmds.Encoder {
 			enc := json.NewEncoder(w)
 			enc.SetIndent("", "\t")
@@ -132,19 +138,28 @@ Cid COMMANDS
 Evm COMMANDS
   evm                    - Commands related to the Filecoin EVM runtime
 
+AUTH COMMANDS
+  auth                   - Manage the api tokens of the node
+
+API COMMANDS
+  api                    - Invoke the methods of the api
+
 TOOL COMMANDS
   inspect                - Show info about the venus node
   log                    - Interact with the daemon event log output
   version                - Show venus version information
   seed                   - Seal sectors for genesis miner
   fetch                  - Fetch proving parameters
+  db                     - Manage the local datastores
+  backup                 - Write an encrypted backup of the node
+  restore                - Restore the node from a backup
 `,
 	},
 	Options: []cmds.Option{
 		cmds.StringsOption(OptionToken, "set the auth token to use"),
 		cmds.StringOption(OptionAPI, "set the api port to use"),
 		cmds.StringOption(OptionRepoDir, OptionLegacyRepoDir, "set the repo directory, defaults to ~/.venus"),
-		cmds.StringOption(cmds.EncLong, cmds.EncShort, "The encoding type the output should be encoded with (pretty-json or json)").WithDefault("pretty-json"),
+		cmds.StringOption(cmds.EncLong, cmds.EncShort, "The encoding type the output should be encoded with (pretty-json, json, table or yaml). pretty-json prints a table for the commands having a table rendering, the commands printing text only support pretty-json and table").WithDefault(prettyJSON),
 		cmds.BoolOption("help", "Show the full command help text."),
 		cmds.BoolOption("h", "Show a short version of the command help text."),
 	},
@@ -163,6 +178,9 @@ var rootSubcmdsLocal = map[string]*cmds.Command{
 	"version": versionCmd,
 	"seed":    seedCmd,
 	"cid":     cidCmd,
+	"db":      dbCmd,
+	"backup":  backupCmd,
+	"restore": restoreCmd,
 }
 
 // all top level commands, available on daemon. set during init() to avoid configuration loops.
@@ -182,6 +200,9 @@ var rootSubcmdsDaemon = map[string]*cmds.Command{
 	"paych":   paychCmd,
 	"info":    infoCmd,
 	"evm":     evmCmd,
+	"filplus": filplusCmd,
+	"auth":    authCmd,
+	"api":     apiCmd,
 }
 
 func init() {
@@ -218,6 +239,10 @@ type executor struct {
 }
 
 func (e *executor) Execute(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+	re, err := newOutputEmitter(req, re)
+	if err != nil {
+		return err
+	}
 	if e.api == "" {
 		return e.exec.Execute(req, re, env)
 	}
@@ -18,6 +18,7 @@ import (
 	cbg "github.com/whyrusleeping/cbor-gen"
 
 	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/pkg/messagepool"
 	"github.com/filecoin-project/venus/pkg/vm"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
 	"github.com/filecoin-project/venus/venus-shared/types"
@@ -93,6 +94,7 @@ var msgSendCmd = &cmds.Command{
 		cmds.StringOption("params-json", "specify invocation parameters in json"),
 		cmds.StringOption("params-hex", "specify invocation parameters in hex"),
 		cmds.Uint64Option("method", "The method to invoke on the target actor"),
+		cmds.StringOption("preview", "execute the message on the head state before pushing it, and warn or reject when it fails: warn or reject"),
 	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
 		ctx := req.Context
@@ -205,6 +207,11 @@ var msgSendCmd = &cmds.Command{
 			Params:     params,
 		}
 
+		warning, err := previewSend(ctx, env.(*node.Env), msg, req)
+		if err != nil {
+			return err
+		}
+
 		nonceOption := req.Options["nonce"]
 		var c cid.Cid
 		if nonceOption != nil {
@@ -232,10 +239,38 @@ var msgSendCmd = &cmds.Command{
 			c = sm.Cid()
 		}
 
+		if warning != "" {
+			return re.Emit(fmt.Sprintf("WARNING: %s\n%s", warning, c))
+		}
 		return re.Emit(c.String())
 	},
 }
 
+// previewSend executes msg on the head state when the preview option is set, a failed execution is returned as
+// an error in the reject mode, and as a warning in the warn mode
+func previewSend(ctx context.Context, env *node.Env, msg *types.Message, req *cmds.Request) (string, error) {
+	preview, _ := req.Options["preview"].(string)
+	mode := types.ExecPreview(preview)
+	if err := mode.Validate(); err != nil {
+		return "", err
+	}
+	if mode == types.ExecPreviewOff {
+		return "", nil
+	}
+
+	res, err := env.MessagePoolAPI.MpoolPreviewMessage(ctx, msg)
+	if err != nil {
+		return "", fmt.Errorf("preview the message: %w", err)
+	}
+	if err := messagepool.CheckPreview(res); err != nil {
+		if mode == types.ExecPreviewReject {
+			return "", err
+		}
+		return err.Error(), nil
+	}
+	return "", nil
+}
+
 func decodeTypedParams(ctx context.Context, fapi *node.Env, to address.Address, method abi.MethodNum, paramstr string) ([]byte, error) {
 	act, err := fapi.ChainAPI.StateGetActor(ctx, to, types.EmptyTSK)
 	if err != nil {
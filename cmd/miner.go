@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/docker/go-units"
@@ -17,10 +18,8 @@ import (
 	"github.com/filecoin-project/venus/app/node"
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/wallet"
-	"github.com/filecoin-project/venus/venus-shared/actors"
 	"github.com/filecoin-project/venus/venus-shared/actors/adt"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
-	"github.com/filecoin-project/venus/venus-shared/actors/builtin/power"
 	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/filecoin-project/venus/venus-shared/types/params"
@@ -37,6 +36,48 @@ var minerCmd = &cmds.Command{
 		"info":    minerInfoCmd,
 		"actor":   minerActorCmd,
 		"proving": minerProvingCmd,
+		"mine":    minerMineCmd,
+	},
+}
+
+var minerMineCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Produce the next blocks of a miner right away on a devnet",
+		ShortDescription: `The rounds the miner loses are left null. The node must enable the devnet mining,
+it is only available on the 2k and force networks, with INSECURE_POST_VALIDATION=1.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("address", true, false, "Address of the miner"),
+	},
+	Options: []cmds.Option{
+		cmds.IntOption("count", "number of blocks to produce").WithDefault(1),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		maddr, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		count, _ := req.Options["count"].(int)
+		if count < 1 {
+			return fmt.Errorf("invalid count %d", count)
+		}
+
+		ctx := ReqContext(req.Context)
+		blks := make([]*types.MinedBlock, 0, count)
+		for i := 0; i < count; i++ {
+			blk, err := env.(*node.Env).MingingAPI.MineOne(ctx, maddr)
+			if err != nil {
+				return err
+			}
+			blks = append(blks, blk)
+		}
+		return re.Emit(blks)
+	},
+	Type: []*types.MinedBlock{},
+	Encoders: cmds.EncoderMap{
+		OutputTable: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, blks []*types.MinedBlock) error {
+			return writeTable(w, blks)
+		}),
 	},
 }
 
@@ -129,29 +170,10 @@ var newMinerCmd = &cmds.Command{
 			}
 		}
 
-		nv, err := env.(*node.Env).ChainAPI.StateNetworkVersion(ctx, types.EmptyTSK)
-		if err != nil {
-			return fmt.Errorf("getting network version: %v", err)
-		}
-
-		spt, err := miner.WindowPoStProofTypeFromSectorSize(ssize, nv)
-		if err != nil {
-			return fmt.Errorf("getting post proof type: %v", err)
-		}
-
 		peerID, err := env.(*node.Env).NetworkAPI.ID(ctx)
 		if err != nil {
 			return err
 		}
-		params, err := actors.SerializeParams(&power6.CreateMinerParams{
-			Owner:               owner,
-			Worker:              worker,
-			WindowPoStProofType: spt,
-			Peer:                abi.PeerID(peerID),
-		})
-		if err != nil {
-			return err
-		}
 
 		minerCmdLog.Info("peer id: ", peerID.String())
 
@@ -165,17 +187,15 @@ var newMinerCmd = &cmds.Command{
 			sender = faddr
 		}
 
-		createStorageMinerMsg := &types.Message{
-			To:    power.Address,
-			From:  sender,
-			Value: big.Zero(),
-
-			Method: power.Methods.CreateMiner,
-			Params: params,
-
-			GasLimit:   0,
-			GasPremium: abi.TokenAmount{Int: gasPrice.Int},
+		builder, err := newMsgBuilder(ctx, env, sender)
+		if err != nil {
+			return err
+		}
+		createStorageMinerMsg, err := builder.Power().CreateMiner(owner, worker, ssize, peerID, nil)
+		if err != nil {
+			return fmt.Errorf("creating createMiner message: %w", err)
 		}
+		createStorageMinerMsg.GasPremium = abi.TokenAmount{Int: gasPrice.Int}
 
 		signed, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, createStorageMinerMsg, nil)
 		if err != nil {
@@ -13,17 +13,13 @@ import (
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
-	"github.com/filecoin-project/go-state-types/big"
-	miner2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/miner"
 	cmds "github.com/ipfs/go-ipfs-cmds"
 	cbor "github.com/ipfs/go-ipld-cbor"
 	"github.com/libp2p/go-libp2p/core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 
-	builtintypes "github.com/filecoin-project/go-state-types/builtin"
 	"github.com/filecoin-project/venus/app/node"
 	"github.com/filecoin-project/venus/cmd/tablewriter"
-	"github.com/filecoin-project/venus/venus-shared/actors"
 	"github.com/filecoin-project/venus/venus-shared/actors/adt"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
@@ -87,21 +83,19 @@ var actorSetAddrsCmd = &cmds.Command{
 			return err
 		}
 
-		params, err := actors.SerializeParams(&miner2.ChangeMultiaddrsParams{NewMultiaddrs: addrs})
+		builder, err := newMsgBuilder(ctx, env, mi.Worker)
 		if err != nil {
 			return err
 		}
 
 		gasLimit, _ := req.Options["gas-limit"].(int64)
 
-		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, &types.Message{
-			To:       maddr,
-			From:     mi.Worker,
-			Value:    big.NewInt(0),
-			GasLimit: gasLimit,
-			Method:   builtintypes.MethodsMiner.ChangeMultiaddrs,
-			Params:   params,
-		}, nil)
+		msg, err := builder.WithGasLimit(gasLimit).Miner(maddr).ChangeMultiaddrs(addrs)
+		if err != nil {
+			return err
+		}
+
+		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, nil)
 		if err != nil {
 			return err
 		}
@@ -139,21 +133,19 @@ var actorSetPeeridCmd = &cmds.Command{
 			return err
 		}
 
-		params, err := actors.SerializeParams(&miner2.ChangePeerIDParams{NewID: abi.PeerID(pid)})
+		builder, err := newMsgBuilder(ctx, env, mi.Worker)
 		if err != nil {
 			return err
 		}
 
 		gasLimit, _ := req.Options["gas-limit"].(int64)
 
-		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, &types.Message{
-			To:       maddr,
-			From:     mi.Worker,
-			Value:    big.NewInt(0),
-			GasLimit: gasLimit,
-			Method:   builtintypes.MethodsMiner.ChangePeerID,
-			Params:   params,
-		}, nil)
+		msg, err := builder.WithGasLimit(gasLimit).Miner(maddr).ChangePeerID(pid)
+		if err != nil {
+			return err
+		}
+
+		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, nil)
 		if err != nil {
 			return err
 		}
@@ -203,25 +195,21 @@ var actorWithdrawCmd = &cmds.Command{
 			return fmt.Errorf("can't withdraw more funds than available; requested: %s; available: %s", amount, available)
 		}
 
-		params, err := actors.SerializeParams(&miner2.WithdrawBalanceParams{
-			AmountRequested: amount, // Default to attempting to withdraw all the extra funds in the miner actor
-		})
-		if err != nil {
-			return err
-		}
-
 		sender := mi.Owner
 		if beneficiary, _ := req.Options["beneficiary"].(bool); beneficiary {
 			sender = mi.Beneficiary
 		}
 
-		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, &types.Message{
-			To:     maddr,
-			From:   sender,
-			Value:  big.NewInt(0),
-			Method: builtintypes.MethodsMiner.WithdrawBalance,
-			Params: params,
-		}, nil)
+		builder, err := newMsgBuilder(ctx, env, sender)
+		if err != nil {
+			return err
+		}
+		msg, err := builder.Miner(maddr).WithdrawBalance(amount)
+		if err != nil {
+			return err
+		}
+
+		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, nil)
 		if err != nil {
 			return err
 		}
@@ -334,13 +322,16 @@ var actorRepayDebtCmd = &cmds.Command{
 			return fmt.Errorf("sender isn't a controller of miner: %s", fromID)
 		}
 
-		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, &types.Message{
-			To:     maddr,
-			From:   fromID,
-			Value:  amount,
-			Method: builtintypes.MethodsMiner.RepayDebt,
-			Params: nil,
-		}, nil)
+		builder, err := newMsgBuilder(ctx, env, fromID)
+		if err != nil {
+			return err
+		}
+		msg, err := builder.Miner(maddr).RepayDebt(amount)
+		if err != nil {
+			return err
+		}
+
+		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, nil)
 		if err != nil {
 			return err
 		}
@@ -389,18 +380,16 @@ var actorSetOwnerCmd = &cmds.Command{
 			return err
 		}
 
-		sp, err := actors.SerializeParams(&newAddr)
+		builder, err := newMsgBuilder(ctx, env, mi.Owner)
+		if err != nil {
+			return err
+		}
+		msg, err := builder.Miner(maddr).ChangeOwnerAddress(newAddr)
 		if err != nil {
-			return fmt.Errorf("serializing params: %w", err)
+			return err
 		}
 
-		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, &types.Message{
-			From:   mi.Owner,
-			To:     maddr,
-			Method: builtintypes.MethodsMiner.ChangeOwnerAddress,
-			Value:  big.Zero(),
-			Params: sp,
-		}, nil)
+		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, nil)
 		if err != nil {
 			return fmt.Errorf("mpool push: %w", err)
 		}
@@ -420,13 +409,11 @@ var actorSetOwnerCmd = &cmds.Command{
 			return err
 		}
 
-		smsg, err = env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, &types.Message{
-			From:   newAddr,
-			To:     maddr,
-			Method: builtintypes.MethodsMiner.ChangeOwnerAddress,
-			Value:  big.Zero(),
-			Params: sp,
-		}, nil)
+		if msg, err = builder.From(newAddr).Miner(maddr).ChangeOwnerAddress(newAddr); err != nil {
+			return err
+		}
+
+		smsg, err = env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, nil)
 		if err != nil {
 			return fmt.Errorf("mpool push: %w", err)
 		}
@@ -638,23 +625,13 @@ var actorControlSet = &cmds.Command{
 			}
 		}
 
-		cwp := &miner2.ChangeWorkerAddressParams{
-			NewWorker:       mi.Worker,
-			NewControlAddrs: toSet,
-		}
-
-		sp, err := actors.SerializeParams(cwp)
+		builder, err := newMsgBuilder(ctx, env, mi.Owner)
 		if err != nil {
-			return fmt.Errorf("serializing params: %w", err)
+			return err
 		}
-
-		msg := &types.Message{
-			From:   mi.Owner,
-			To:     maddr,
-			Method: builtintypes.MethodsMiner.ChangeWorkerAddress,
-
-			Value:  big.Zero(),
-			Params: sp,
+		msg, err := builder.Miner(maddr).ChangeWorkerAddress(mi.Worker, toSet)
+		if err != nil {
+			return err
 		}
 
 		if ok, _ := req.Options["dump-bytes"].(bool); ok {
@@ -736,23 +713,16 @@ var actorProposeChangeWorker = &cmds.Command{
 			return re.Emit("Pass --really-do-it to actually execute this action")
 		}
 
-		cwp := &miner2.ChangeWorkerAddressParams{
-			NewWorker:       newAddr,
-			NewControlAddrs: mi.ControlAddresses,
+		builder, err := newMsgBuilder(ctx, env, mi.Owner)
+		if err != nil {
+			return err
 		}
-
-		sp, err := actors.SerializeParams(cwp)
+		msg, err := builder.Miner(maddr).ChangeWorkerAddress(newAddr, mi.ControlAddresses)
 		if err != nil {
-			return fmt.Errorf("serializing params: %w", err)
+			return err
 		}
 
-		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, &types.Message{
-			From:   mi.Owner,
-			To:     maddr,
-			Method: builtintypes.MethodsMiner.ChangeWorkerAddress,
-			Value:  big.Zero(),
-			Params: sp,
-		}, nil)
+		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, nil)
 		if err != nil {
 			return fmt.Errorf("mpool push: %w", err)
 		}
@@ -845,12 +815,16 @@ var actorConfirmChangeWorker = &cmds.Command{
 			return re.Emit("Pass --really-do-it to actually execute this action")
 		}
 
-		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, &types.Message{
-			From:   mi.Owner,
-			To:     maddr,
-			Method: builtintypes.MethodsMiner.ConfirmChangeWorkerAddress,
-			Value:  big.Zero(),
-		}, nil)
+		builder, err := newMsgBuilder(ctx, env, mi.Owner)
+		if err != nil {
+			return err
+		}
+		msg, err := builder.Miner(maddr).ConfirmChangeWorkerAddress()
+		if err != nil {
+			return err
+		}
+
+		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, nil)
 		if err != nil {
 			return fmt.Errorf("mpool push: %w", err)
 		}
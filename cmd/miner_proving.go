@@ -7,6 +7,7 @@ import (
 	"text/tabwriter"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/dline"
 	cmds "github.com/ipfs/go-ipfs-cmds"
 	cbor "github.com/ipfs/go-ipld-cbor"
 
@@ -26,6 +27,7 @@ var minerProvingCmd = &cmds.Command{
 		"deadlines": provingDeadlinesCmd,
 		"deadline":  provingDeadlineInfoCmd,
 		"faults":    provingFaultsCmd,
+		"check":     provingCheckCmd,
 	},
 }
 
@@ -374,3 +376,170 @@ var provingFaultsCmd = &cmds.Command{
 		return re.Emit(buf)
 	},
 }
+
+var provingCheckCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Check the proving status of the partitions of a miner from the chain state.",
+		ShortDescription: `
+Show the faulty and recovering sectors of the partitions with live sectors, and
+whether the partitions of the current deadline were proven yet. Only the chain
+state is read, so any node may check any miner.
+`,
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("only-bad", "Only show the faulty, recovering and not yet proven partitions"),
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("address", true, false, "Address of miner to check"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		maddr, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		ctx := req.Context
+		api := env.(*node.Env).ChainAPI
+
+		blockDelay, err := getBlockDelay(ctx, env)
+		if err != nil {
+			return err
+		}
+
+		head, err := api.ChainHead(ctx)
+		if err != nil {
+			return fmt.Errorf("getting chain head: %w", err)
+		}
+
+		deadlines, err := api.StateMinerDeadlines(ctx, maddr, head.Key())
+		if err != nil {
+			return fmt.Errorf("getting deadlines: %w", err)
+		}
+
+		di, err := api.StateMinerProvingDeadline(ctx, maddr, head.Key())
+		if err != nil {
+			return fmt.Errorf("getting proving deadline: %w", err)
+		}
+
+		var checks []*partitionCheck
+		for dlIdx := range deadlines {
+			partitions, err := api.StateMinerPartitions(ctx, maddr, uint64(dlIdx), head.Key())
+			if err != nil {
+				return fmt.Errorf("getting partitions for deadline %d: %w", dlIdx, err)
+			}
+
+			for partIdx := range partitions {
+				pc, err := checkPartition(uint64(dlIdx), uint64(partIdx), &partitions[partIdx], &deadlines[dlIdx], di)
+				if err != nil {
+					return fmt.Errorf("checking partition %d of deadline %d: %w", partIdx, dlIdx, err)
+				}
+				if pc != nil {
+					checks = append(checks, pc)
+				}
+			}
+		}
+
+		onlyBad, _ := req.Options["only-bad"].(bool)
+		buf := new(bytes.Buffer)
+		writeProvingCheck(buf, maddr, di, checks, onlyBad, blockDelay)
+
+		return re.Emit(buf)
+	},
+}
+
+const (
+	partitionOK         = "ok"
+	partitionProven     = "proven"
+	partitionNotProven  = "not proven"
+	partitionRecovering = "recovering"
+	partitionFaulty     = "faulty"
+)
+
+// partitionCheck is the proving status of a partition in the chain state
+type partitionCheck struct {
+	Deadline   uint64
+	Partition  uint64
+	Live       uint64
+	Active     uint64
+	Faulty     uint64
+	Recovering uint64
+	Status     string
+}
+
+func (pc *partitionCheck) bad() bool {
+	return pc.Status != partitionOK && pc.Status != partitionProven
+}
+
+// checkPartition returns the status of the partition of the deadline, nil when it has no live sectors to prove.
+// The partitions of the current deadline are proven or not, the faults of a partition hide the rest.
+func checkPartition(dlIdx, partIdx uint64, part *types.Partition, dl *types.Deadline, di *dline.Info) (*partitionCheck, error) {
+	pc := &partitionCheck{Deadline: dlIdx, Partition: partIdx, Status: partitionOK}
+
+	var err error
+	if pc.Live, err = part.LiveSectors.Count(); err != nil {
+		return nil, err
+	}
+	if pc.Live == 0 {
+		return nil, nil
+	}
+	if pc.Active, err = part.ActiveSectors.Count(); err != nil {
+		return nil, err
+	}
+	if pc.Faulty, err = part.FaultySectors.Count(); err != nil {
+		return nil, err
+	}
+	if pc.Recovering, err = part.RecoveringSectors.Count(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case pc.Faulty > pc.Recovering:
+		pc.Status = partitionFaulty
+	case pc.Recovering > 0:
+		pc.Status = partitionRecovering
+	case dlIdx == di.Index:
+		proven, err := dl.PostSubmissions.IsSet(partIdx)
+		if err != nil {
+			return nil, err
+		}
+		pc.Status = partitionNotProven
+		if proven {
+			pc.Status = partitionProven
+		}
+	}
+	return pc, nil
+}
+
+func writeProvingCheck(buf *bytes.Buffer, maddr address.Address, di *dline.Info, checks []*partitionCheck, onlyBad bool, blockDelay uint64) {
+	writer := NewSilentWriter(buf)
+	writer.Printf("Miner: %s\n", maddr)
+
+	tw := tabwriter.NewWriter(buf, 2, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "deadline\tpartition\tlive\tactive\tfaulty\trecovering\tstatus")
+
+	var faulty, recovering, current, proven int
+	for _, pc := range checks {
+		switch pc.Status {
+		case partitionFaulty:
+			faulty++
+		case partitionRecovering:
+			recovering++
+		}
+		if pc.Deadline == di.Index {
+			current++
+			if pc.Status == partitionProven {
+				proven++
+			}
+		}
+
+		if onlyBad && !pc.bad() {
+			continue
+		}
+		_, _ = fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\t%d\t%s\n", pc.Deadline, pc.Partition, pc.Live, pc.Active, pc.Faulty, pc.Recovering, pc.Status)
+	}
+	_ = tw.Flush()
+
+	writer.Println()
+	writer.Printf("Partitions:       %d (%d faulty, %d recovering)\n", len(checks), faulty, recovering)
+	writer.Printf("Current Deadline: %d, %d of %d partitions proven, closing at %s\n", di.Index, proven, current,
+		EpochTime(di.CurrentEpoch, di.Close, blockDelay))
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/dline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestCheckPartition(t *testing.T) {
+	tf.UnitTest(t)
+
+	bf := func(nums ...uint64) bitfield.BitField {
+		return bitfield.NewFromSet(nums)
+	}
+	partition := func(live []uint64, faulty []uint64, recovering []uint64) *types.Partition {
+		return &types.Partition{
+			AllSectors:        bf(live...),
+			LiveSectors:       bf(live...),
+			ActiveSectors:     bf(live...),
+			FaultySectors:     bf(faulty...),
+			RecoveringSectors: bf(recovering...),
+		}
+	}
+	di := &dline.Info{Index: 3, CurrentEpoch: 100, Close: 160}
+	current := &types.Deadline{PostSubmissions: bf(1)}
+	other := &types.Deadline{PostSubmissions: bf()}
+
+	pc, err := checkPartition(0, 0, partition([]uint64{1, 2, 3}, nil, nil), other, di)
+	require.NoError(t, err)
+	assert.Equal(t, partitionOK, pc.Status)
+	assert.Equal(t, uint64(3), pc.Live)
+
+	pc, err = checkPartition(0, 1, partition([]uint64{1, 2, 3}, []uint64{1, 2}, []uint64{2}), other, di)
+	require.NoError(t, err)
+	assert.Equal(t, partitionFaulty, pc.Status)
+	assert.True(t, pc.bad())
+
+	pc, err = checkPartition(0, 1, partition([]uint64{1, 2, 3}, []uint64{2}, []uint64{2}), other, di)
+	require.NoError(t, err)
+	assert.Equal(t, partitionRecovering, pc.Status)
+
+	// the partitions of the current deadline
+	pc, err = checkPartition(3, 0, partition([]uint64{4}, nil, nil), current, di)
+	require.NoError(t, err)
+	assert.Equal(t, partitionNotProven, pc.Status)
+	assert.True(t, pc.bad())
+	pc, err = checkPartition(3, 1, partition([]uint64{5}, nil, nil), current, di)
+	require.NoError(t, err)
+	assert.Equal(t, partitionProven, pc.Status)
+	assert.False(t, pc.bad())
+
+	// nothing to prove
+	pc, err = checkPartition(3, 2, partition(nil, nil, nil), current, di)
+	require.NoError(t, err)
+	assert.Nil(t, pc)
+
+	maddr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	checks := []*partitionCheck{
+		{Deadline: 0, Partition: 0, Live: 3, Active: 3, Status: partitionOK},
+		{Deadline: 0, Partition: 1, Live: 3, Active: 1, Faulty: 2, Status: partitionFaulty},
+		{Deadline: 3, Partition: 0, Live: 1, Active: 1, Status: partitionNotProven},
+		{Deadline: 3, Partition: 1, Live: 1, Active: 1, Status: partitionProven},
+	}
+	buf := new(bytes.Buffer)
+	writeProvingCheck(buf, maddr, di, checks, true, 30)
+	out := buf.String()
+	assert.Contains(t, out, "Partitions:       4 (1 faulty, 0 recovering)")
+	assert.Contains(t, out, "Current Deadline: 3, 1 of 2 partitions proven")
+	assert.Contains(t, out, "not proven")
+	assert.NotContains(t, out, partitionOK)
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"sort"
 	"strconv"
 
 	stdbig "math/big"
@@ -364,156 +363,6 @@ var mpoolReplaceCmd = &cmds.Command{
 	},
 }
 
-var mpoolStat = &cmds.Command{
-	Helptext: cmds.HelpText{
-		Tagline: "print mpool state messages",
-		ShortDescription: `
-Get pending messages.
-`,
-	},
-	Options: []cmds.Option{
-		cmds.BoolOption("local", "print stats for addresses in local wallet only"),
-		cmds.Int64Option("basefee-lookback", "number of blocks to look back for minimum basefee"),
-	},
-	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
-		local, _ := req.Options["local"].(bool)
-		basefee, _ := req.Options["basefee-lookback"].(int)
-
-		ctx := context.TODO()
-		ts, err := env.(*node.Env).ChainAPI.ChainHead(ctx)
-		if err != nil {
-			return fmt.Errorf("getting chain head: %w", err)
-		}
-		currBF := ts.Blocks()[0].ParentBaseFee
-		minBF := currBF
-		{
-			currTS := ts
-			for i := 0; i < basefee; i++ {
-				key := currTS.Parents()
-				currTS, err = env.(*node.Env).ChainAPI.ChainGetTipSet(req.Context, key)
-				if err != nil {
-					return fmt.Errorf("walking chain: %w", err)
-				}
-				if newBF := currTS.Blocks()[0].ParentBaseFee; newBF.LessThan(minBF) {
-					minBF = newBF
-				}
-			}
-		}
-
-		var filter map[address.Address]struct{}
-		if local {
-			filter = map[address.Address]struct{}{}
-
-			addrss := env.(*node.Env).WalletAPI.WalletAddresses(req.Context)
-
-			for _, a := range addrss {
-				filter[a] = struct{}{}
-			}
-		}
-
-		msgs, err := env.(*node.Env).MessagePoolAPI.MpoolPending(ctx, types.TipSetKey{})
-		if err != nil {
-			return err
-		}
-
-		type statBucket struct {
-			msgs map[uint64]*types.SignedMessage
-		}
-		type mpStat struct {
-			addr                 string
-			past, cur, future    uint64
-			belowCurr, belowPast uint64
-			gasLimit             big.Int
-		}
-
-		buckets := map[address.Address]*statBucket{}
-		for _, v := range msgs {
-			if filter != nil {
-				if _, has := filter[v.Message.From]; !has {
-					continue
-				}
-			}
-
-			bkt, ok := buckets[v.Message.From]
-			if !ok {
-				bkt = &statBucket{
-					msgs: map[uint64]*types.SignedMessage{},
-				}
-				buckets[v.Message.From] = bkt
-			}
-
-			bkt.msgs[v.Message.Nonce] = v
-		}
-
-		var out []mpStat
-
-		for a, bkt := range buckets {
-			act, err := env.(*node.Env).ChainAPI.StateGetActor(ctx, a, ts.Key())
-			if err != nil {
-				fmt.Printf("%s, err: %s\n", a, err)
-				continue
-			}
-
-			cur := act.Nonce
-			for {
-				_, ok := bkt.msgs[cur]
-				if !ok {
-					break
-				}
-				cur++
-			}
-
-			var s mpStat
-			s.addr = a.String()
-			s.gasLimit = big.Zero()
-
-			for _, m := range bkt.msgs {
-				if m.Message.Nonce < act.Nonce {
-					s.past++
-				} else if m.Message.Nonce > cur {
-					s.future++
-				} else {
-					s.cur++
-				}
-
-				if m.Message.GasFeeCap.LessThan(currBF) {
-					s.belowCurr++
-				}
-				if m.Message.GasFeeCap.LessThan(minBF) {
-					s.belowPast++
-				}
-
-				s.gasLimit = big.Add(s.gasLimit, big.NewInt(m.Message.GasLimit))
-			}
-
-			out = append(out, s)
-		}
-
-		sort.Slice(out, func(i, j int) bool {
-			return out[i].addr < out[j].addr
-		})
-
-		var total mpStat
-		total.gasLimit = big.Zero()
-
-		for _, stat := range out {
-			total.past += stat.past
-			total.cur += stat.cur
-			total.future += stat.future
-			total.belowCurr += stat.belowCurr
-			total.belowPast += stat.belowPast
-			total.gasLimit = big.Add(total.gasLimit, stat.gasLimit)
-
-			_ = re.Emit(fmt.Sprintf("%s: Nonce past: %d, cur: %d, future: %d; FeeCap cur: %d, min-%d: %d, gasLimit: %s", stat.addr, stat.past, stat.cur, stat.future, stat.belowCurr, basefee, stat.belowPast, stat.gasLimit))
-		}
-
-		_ = re.Emit("-----")
-		_ = re.Emit(fmt.Sprintf("total: Nonce past: %d, cur: %d, future: %d; FeeCap cur: %d, min-%d: %d, gasLimit: %s", total.past, total.cur, total.future, total.belowCurr, basefee, total.belowPast, total.gasLimit))
-
-		return nil
-	},
-}
-
 var mpoolPending = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Get pending messages",
@@ -618,12 +467,43 @@ var mpoolSub = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "sub",
 		ShortDescription: `
-Subscribe to mpool changes
+Subscribe to mpool changes, the filters only send the changes of the matching messages
 `,
 	},
+	Options: []cmds.Option{
+		cmds.StringsOption("from", "only the messages sent by the addresses"),
+		cmds.StringsOption("to", "only the messages sent to the addresses"),
+		cmds.StringsOption("method", "only the messages calling the method numbers"),
+		cmds.BoolOption("local", "only the messages of the local addresses"),
+	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
 		ctx := context.TODO()
-		sub, err := env.(*node.Env).MessagePoolAPI.MpoolSub(ctx)
+
+		filter := &types.MpoolSubFilter{}
+		filter.LocalOnly, _ = req.Options["local"].(bool)
+		for _, opt := range []struct {
+			name  string
+			addrs *[]address.Address
+		}{{"from", &filter.From}, {"to", &filter.To}} {
+			strs, _ := req.Options[opt.name].([]string)
+			for _, str := range strs {
+				addr, err := address.NewFromString(str)
+				if err != nil {
+					return fmt.Errorf("parse %s address %s: %w", opt.name, str, err)
+				}
+				*opt.addrs = append(*opt.addrs, addr)
+			}
+		}
+		methods, _ := req.Options["method"].([]string)
+		for _, str := range methods {
+			method, err := strconv.ParseUint(str, 10, 64)
+			if err != nil {
+				return fmt.Errorf("parse method %s: %w", str, err)
+			}
+			filter.Methods = append(filter.Methods, abi.MethodNum(method))
+		}
+
+		sub, err := env.(*node.Env).MessagePoolAPI.MpoolSubFiltered(ctx, filter)
 		if err != nil {
 			return err
 		}
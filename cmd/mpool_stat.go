@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// premiumFactors are the upper bounds of the premium buckets, as multiples of the base fee
+var premiumFactors = []int64{1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024}
+
+var mpoolStat = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "print mpool state messages",
+		ShortDescription: `
+Get the pending messages per actor, with their nonce gaps, and bucketed by gas premium relative to the base fee.
+The epochs to inclusion are estimated by filling tipsets at the gas target with the messages of the highest
+premium first, the messages stuck behind a nonce gap or below the base fee are not counted.
+`,
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("local", "print stats for addresses in local wallet only"),
+		cmds.Int64Option("basefee-lookback", "number of blocks to look back for minimum basefee"),
+		cmds.BoolOption("watch", "refresh the stats at every new head"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		api := env.(*node.Env)
+
+		if watch, _ := req.Options["watch"].(bool); !watch {
+			ts, err := api.ChainAPI.ChainHead(ctx)
+			if err != nil {
+				return fmt.Errorf("getting chain head: %w", err)
+			}
+			return emitMpoolStat(ctx, req, re, api, ts)
+		}
+
+		notifs, err := api.ChainAPI.ChainNotify(ctx)
+		if err != nil {
+			return err
+		}
+		for changes := range notifs {
+			var head *types.TipSet
+			for _, change := range changes {
+				if change.Type == types.HCApply || change.Type == types.HCCurrent {
+					if head == nil || change.Val.Height() > head.Height() {
+						head = change.Val
+					}
+				}
+			}
+			if head == nil {
+				continue
+			}
+
+			_ = re.Emit(fmt.Sprintf("===== epoch %d =====", head.Height()))
+			if err := emitMpoolStat(ctx, req, re, api, head); err != nil {
+				return err
+			}
+		}
+		return ctx.Err()
+	},
+}
+
+type mpStat struct {
+	addr                 string
+	past, cur, future    uint64
+	belowCurr, belowPast uint64
+	gasLimit             big.Int
+	gaps                 []string
+}
+
+func emitMpoolStat(ctx context.Context, req *cmds.Request, re cmds.ResponseEmitter, api *node.Env, ts *types.TipSet) error {
+	local, _ := req.Options["local"].(bool)
+	basefee, _ := req.Options["basefee-lookback"].(int64)
+
+	currBF := ts.Blocks()[0].ParentBaseFee
+	minBF := currBF
+	{
+		currTS := ts
+		for i := int64(0); i < basefee; i++ {
+			key := currTS.Parents()
+			var err error
+			currTS, err = api.ChainAPI.ChainGetTipSet(ctx, key)
+			if err != nil {
+				return fmt.Errorf("walking chain: %w", err)
+			}
+			if newBF := currTS.Blocks()[0].ParentBaseFee; newBF.LessThan(minBF) {
+				minBF = newBF
+			}
+		}
+	}
+
+	var filter map[address.Address]struct{}
+	if local {
+		filter = map[address.Address]struct{}{}
+
+		addrss := api.WalletAPI.WalletAddresses(ctx)
+
+		for _, a := range addrss {
+			filter[a] = struct{}{}
+		}
+	}
+
+	msgs, err := api.MessagePoolAPI.MpoolPending(ctx, types.TipSetKey{})
+	if err != nil {
+		return err
+	}
+
+	buckets := map[address.Address]map[uint64]*types.SignedMessage{}
+	for _, v := range msgs {
+		if filter != nil {
+			if _, has := filter[v.Message.From]; !has {
+				continue
+			}
+		}
+
+		bkt, ok := buckets[v.Message.From]
+		if !ok {
+			bkt = map[uint64]*types.SignedMessage{}
+			buckets[v.Message.From] = bkt
+		}
+
+		bkt[v.Message.Nonce] = v
+	}
+
+	var out []mpStat
+	// the messages which may be included in the next tipsets
+	var ready []*types.SignedMessage
+	var blocked int
+
+	for a, bkt := range buckets {
+		act, err := api.ChainAPI.StateGetActor(ctx, a, ts.Key())
+		if err != nil {
+			_ = re.Emit(fmt.Sprintf("%s, err: %s", a, err))
+			continue
+		}
+
+		s := nonceStat(act.Nonce, bkt)
+		s.addr = a.String()
+		for _, m := range bkt {
+			if m.Message.GasFeeCap.LessThan(currBF) {
+				s.belowCurr++
+			}
+			if m.Message.GasFeeCap.LessThan(minBF) {
+				s.belowPast++
+			}
+
+			s.gasLimit = big.Add(s.gasLimit, big.NewInt(m.Message.GasLimit))
+		}
+		for nonce := act.Nonce; ; nonce++ {
+			m, ok := bkt[nonce]
+			if !ok {
+				break
+			}
+			ready = append(ready, m)
+		}
+		blocked += int(s.future)
+
+		out = append(out, s)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].addr < out[j].addr
+	})
+
+	var total mpStat
+	total.gasLimit = big.Zero()
+
+	for _, stat := range out {
+		total.past += stat.past
+		total.cur += stat.cur
+		total.future += stat.future
+		total.belowCurr += stat.belowCurr
+		total.belowPast += stat.belowPast
+		total.gasLimit = big.Add(total.gasLimit, stat.gasLimit)
+
+		line := fmt.Sprintf("%s: Nonce past: %d, cur: %d, future: %d; FeeCap cur: %d, min-%d: %d, gasLimit: %s", stat.addr, stat.past, stat.cur, stat.future, stat.belowCurr, basefee, stat.belowPast, stat.gasLimit)
+		if len(stat.gaps) > 0 {
+			line += fmt.Sprintf("; gaps: %s", strings.Join(stat.gaps, ","))
+		}
+		_ = re.Emit(line)
+	}
+
+	_ = re.Emit("-----")
+	_ = re.Emit(fmt.Sprintf("total: Nonce past: %d, cur: %d, future: %d; FeeCap cur: %d, min-%d: %d, gasLimit: %s", total.past, total.cur, total.future, total.belowCurr, basefee, total.belowPast, total.gasLimit))
+
+	_ = re.Emit("-----")
+	_ = re.Emit(fmt.Sprintf("premium over base fee %s, %d messages blocked by nonce gaps:", types.FIL(currBF), blocked))
+	for _, pb := range premiumBuckets(ready, currBF, constants.BlockGasTarget*constants.ExpectedLeadersPerEpoch) {
+		epochs := "never"
+		if pb.minEpochs > 0 {
+			epochs = fmt.Sprintf("%d-%d", pb.minEpochs, pb.maxEpochs)
+		}
+		_ = re.Emit(fmt.Sprintf("%12s: %d msgs, gasLimit: %d, epochs to inclusion: %s", pb.label, pb.count, pb.gasLimit, epochs))
+	}
+	return nil
+}
+
+// nonceStat counts the messages of an actor of nonce actNonce before, in and after the first nonce gap, and
+// lists the gaps
+func nonceStat(actNonce uint64, msgs map[uint64]*types.SignedMessage) mpStat {
+	s := mpStat{gasLimit: big.Zero()}
+
+	cur := actNonce
+	for {
+		if _, ok := msgs[cur]; !ok {
+			break
+		}
+		cur++
+	}
+
+	var maxNonce uint64
+	for nonce := range msgs {
+		switch {
+		case nonce < actNonce:
+			s.past++
+		case nonce > cur:
+			s.future++
+		default:
+			s.cur++
+		}
+		if nonce > maxNonce {
+			maxNonce = nonce
+		}
+	}
+
+	for nonce := cur; nonce < maxNonce; {
+		if _, ok := msgs[nonce]; ok {
+			nonce++
+			continue
+		}
+		end := nonce
+		for end+1 < maxNonce {
+			if _, ok := msgs[end+1]; ok {
+				break
+			}
+			end++
+		}
+		if end == nonce {
+			s.gaps = append(s.gaps, fmt.Sprintf("%d", nonce))
+		} else {
+			s.gaps = append(s.gaps, fmt.Sprintf("%d-%d", nonce, end))
+		}
+		nonce = end + 1
+	}
+	return s
+}
+
+type premiumBucket struct {
+	label                string
+	count                int
+	gasLimit             int64
+	minEpochs, maxEpochs int64
+}
+
+// premiumBuckets groups the messages by effective gas premium as multiples of the base fee, and estimates the
+// epochs they take to be included when every tipset takes gasPerEpoch of the highest premium messages
+func premiumBuckets(msgs []*types.SignedMessage, baseFee abi.TokenAmount, gasPerEpoch int64) []premiumBucket {
+	premium := func(m *types.SignedMessage) big.Int {
+		return big.Min(m.Message.GasPremium, big.Sub(m.Message.GasFeeCap, baseFee))
+	}
+	sorted := append([]*types.SignedMessage(nil), msgs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return premium(sorted[i]).GreaterThan(premium(sorted[j]))
+	})
+
+	buckets := make([]premiumBucket, len(premiumFactors)+2)
+	buckets[0].label = "under fee"
+	for i, factor := range premiumFactors {
+		buckets[i+1].label = fmt.Sprintf("< %dx", factor)
+	}
+	buckets[len(buckets)-1].label = fmt.Sprintf(">= %dx", premiumFactors[len(premiumFactors)-1])
+
+	var cumGas int64
+	for _, m := range sorted {
+		p := premium(m)
+		idx := 0
+		var epochs int64
+		if p.Sign() >= 0 {
+			idx = len(buckets) - 1
+			for i, factor := range premiumFactors {
+				if p.LessThan(big.Mul(baseFee, big.NewInt(factor))) {
+					idx = i + 1
+					break
+				}
+			}
+			cumGas += m.Message.GasLimit
+			epochs = (cumGas + gasPerEpoch - 1) / gasPerEpoch
+		}
+
+		bkt := &buckets[idx]
+		bkt.count++
+		bkt.gasLimit += m.Message.GasLimit
+		if epochs > 0 && (bkt.minEpochs == 0 || epochs < bkt.minEpochs) {
+			bkt.minEpochs = epochs
+		}
+		if epochs > bkt.maxEpochs {
+			bkt.maxEpochs = epochs
+		}
+	}
+
+	out := buckets[:0]
+	for _, bkt := range buckets {
+		if bkt.count > 0 {
+			out = append(out, bkt)
+		}
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestNonceStat(t *testing.T) {
+	tf.UnitTest(t)
+
+	msgs := map[uint64]*types.SignedMessage{}
+	for _, nonce := range []uint64{3, 5, 6, 7, 9, 12} {
+		msgs[nonce] = &types.SignedMessage{Message: types.Message{Nonce: nonce}}
+	}
+
+	s := nonceStat(5, msgs)
+	assert.Equal(t, uint64(1), s.past)
+	assert.Equal(t, uint64(3), s.cur)
+	assert.Equal(t, uint64(2), s.future)
+	assert.Equal(t, []string{"8", "10-11"}, s.gaps)
+
+	s = nonceStat(3, msgs)
+	assert.Equal(t, uint64(0), s.past)
+	assert.Equal(t, uint64(1), s.cur)
+	assert.Equal(t, []string{"4", "8", "10-11"}, s.gaps)
+}
+
+func TestPremiumBuckets(t *testing.T) {
+	tf.UnitTest(t)
+
+	baseFee := abi.NewTokenAmount(100)
+	msg := func(feeCap, premium, gasLimit int64) *types.SignedMessage {
+		return &types.SignedMessage{Message: types.Message{
+			GasFeeCap:  big.NewInt(feeCap),
+			GasPremium: big.NewInt(premium),
+			GasLimit:   gasLimit,
+		}}
+	}
+
+	buckets := premiumBuckets([]*types.SignedMessage{
+		msg(50, 10, 10),
+		msg(1000, 50, 10),
+		msg(1000, 150, 10),
+		msg(1000, 160, 10),
+		msg(200000, 200000, 10),
+	}, baseFee, 20)
+
+	assert.Equal(t, []premiumBucket{
+		{label: "under fee", count: 1, gasLimit: 10},
+		{label: "< 1x", count: 1, gasLimit: 10, minEpochs: 2, maxEpochs: 2},
+		{label: "< 2x", count: 2, gasLimit: 20, minEpochs: 1, maxEpochs: 2},
+		{label: ">= 1024x", count: 1, gasLimit: 10, minEpochs: 1, maxEpochs: 1},
+	}, buckets)
+}
@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	cmds "github.com/ipfs/go-ipfs-cmds"
+	"gopkg.in/yaml.v3"
+
+	"github.com/filecoin-project/venus/cmd/tablewriter"
+)
+
+// The output formats selected by the encoding option, besides the json ones of go-ipfs-cmds. OutputTable is also the
+// encoding type a command registers its human readable rendering of its result under, it is used by the default
+// pretty-json encoding of the commands having one.
+const (
+	OutputTable = "table"
+	OutputYAML  = "yaml"
+)
+
+func init() {
+	// the results are rendered by outputEmitter, these are only registered so that cli.Run accepts the encodings
+	cmds.Encoders[OutputTable] = func(req *cmds.Request) func(io.Writer) cmds.Encoder {
+		return func(w io.Writer) cmds.Encoder { return outputEncoder{w: w, write: writeTable} }
+	}
+	cmds.Encoders[OutputYAML] = func(req *cmds.Request) func(io.Writer) cmds.Encoder {
+		return func(w io.Writer) cmds.Encoder { return outputEncoder{w: w, write: writeYAML} }
+	}
+}
+
+type outputEncoder struct {
+	w     io.Writer
+	write func(io.Writer, interface{}) error
+}
+
+func (oe outputEncoder) Encode(v interface{}) error {
+	return oe.write(oe.w, v)
+}
+
+// outputEmitter renders the results emitted by a command in the table or yaml format. The results are rendered in
+// the cli process, so that they go through the daemon api as json like the other results. The formats only apply to
+// the commands emitting typed results: the commands which emit text print it as is in the table format, and fail in
+// the json and yaml ones rather than printing text where a script expects structured output.
+type outputEmitter struct {
+	cmds.ResponseEmitter
+
+	req    *cmds.Request
+	format string
+	count  int
+}
+
+// newOutputEmitter wraps re with the renderer of the encoding of req. The json results are encoded by re, the
+// default pretty-json encoding of the commands rendering their results as a table excepted.
+func newOutputEmitter(req *cmds.Request, re cmds.ResponseEmitter) (cmds.ResponseEmitter, error) {
+	format, _ := req.Options[cmds.EncLong].(string)
+	switch format {
+	case OutputTable, OutputYAML, cmds.JSON:
+	case "", prettyJSON:
+		if tableEncoder(req) == nil {
+			return re, nil
+		}
+		format = OutputTable
+	default:
+		return re, nil
+	}
+	return &outputEmitter{ResponseEmitter: re, req: req, format: format}, nil
+}
+
+func tableEncoder(req *cmds.Request) cmds.EncoderFunc {
+	if req.Command == nil {
+		return nil
+	}
+	return req.Command.Encoders[OutputTable]
+}
+
+func (oe *outputEmitter) Emit(v interface{}) error {
+	if single, ok := v.(cmds.Single); ok {
+		v = single.Value
+	}
+	if ch, ok := v.(chan interface{}); ok {
+		v = (<-chan interface{})(ch)
+	}
+	if ch, ok := v.(<-chan interface{}); ok {
+		return cmds.EmitChan(oe, ch)
+	}
+
+	if r, ok := v.(io.Reader); ok {
+		if oe.format != OutputTable {
+			return fmt.Errorf("'%s' only prints text, it does not support the %s encoding", strings.Join(oe.req.Path, " "), oe.format)
+		}
+		return oe.ResponseEmitter.Emit(r)
+	}
+	if oe.format == cmds.JSON {
+		return oe.ResponseEmitter.Emit(v)
+	}
+	if err, ok := v.(error); ok {
+		v = err.Error()
+	}
+
+	buf := new(bytes.Buffer)
+	var err error
+	switch oe.format {
+	case OutputYAML:
+		if oe.count > 0 {
+			buf.WriteString("---\n")
+		}
+		err = writeYAML(buf, v)
+	default:
+		if enc := tableEncoder(oe.req); enc != nil {
+			err = enc(oe.req)(buf).Encode(v)
+		} else {
+			err = writeTable(buf, v)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	oe.count++
+	return oe.ResponseEmitter.Emit(buf)
+}
+
+// jsonField is a field of a json object, the fields keep the order of the json encoding, that is of the struct
+type jsonField struct {
+	Key   string
+	Value interface{}
+}
+
+// toOrderedJSON encodes v as json, and decodes it to []jsonField objects, []interface{} arrays, json.Number numbers,
+// strings, bools and nils, so that every format renders the same field names as the json one
+func toOrderedJSON(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeOrderedJSON(dec)
+}
+
+func decodeOrderedJSON(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := []jsonField{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			val, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, jsonField{Key: key.(string), Value: val})
+		}
+		_, err = dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []interface{}{}
+		for dec.More() {
+			val, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, val)
+		}
+		_, err = dec.Token()
+		return arr, err
+	default:
+		return tok, nil
+	}
+}
+
+func writeYAML(w io.Writer, v interface{}) error {
+	doc, err := toOrderedJSON(v)
+	if err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(yamlNode(doc)); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func yamlNode(v interface{}) *yaml.Node {
+	switch v := v.(type) {
+	case []jsonField:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, field := range v {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: field.Key}, yamlNode(field.Value))
+		}
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, elem := range v {
+			node.Content = append(node.Content, yamlNode(elem))
+		}
+		return node
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(v)}
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fmt.Sprint(v)}
+	}
+}
+
+// writeTable renders v without a rendering of its command: the arrays of objects as tables, the objects as their
+// fields, one per line, and the values nested deeper as compact json
+func writeTable(w io.Writer, v interface{}) error {
+	doc, err := toOrderedJSON(v)
+	if err != nil {
+		return err
+	}
+	sw := NewSilentWriter(w)
+	writeTableValue(sw, doc, "")
+	return sw.Error()
+}
+
+func writeTableValue(sw *SilentWriter, v interface{}, indent string) {
+	switch v := v.(type) {
+	case []jsonField:
+		for _, field := range v {
+			switch val := field.Value.(type) {
+			case []jsonField:
+				sw.Printf("%s%s:\n", indent, field.Key)
+				writeTableValue(sw, val, indent+"  ")
+			case []interface{}:
+				if len(val) == 0 {
+					sw.Printf("%s%s: []\n", indent, field.Key)
+					continue
+				}
+				sw.Printf("%s%s:\n", indent, field.Key)
+				writeTableValue(sw, val, indent+"  ")
+			default:
+				sw.Printf("%s%s: %s\n", indent, field.Key, tableCell(val))
+			}
+		}
+	case []interface{}:
+		if !allObjects(v) {
+			for _, elem := range v {
+				sw.Printf("%s%s\n", indent, tableCell(elem))
+			}
+			return
+		}
+		// the columns are in the order of the fields, the first rows first
+		var cols []tablewriter.Column
+		seen := map[string]bool{}
+		for _, elem := range v {
+			for _, field := range elem.([]jsonField) {
+				if !seen[field.Key] {
+					seen[field.Key] = true
+					cols = append(cols, tablewriter.Col(field.Key))
+				}
+			}
+		}
+		tw := tablewriter.New(cols...)
+		for _, elem := range v {
+			row := map[string]interface{}{}
+			for _, field := range elem.([]jsonField) {
+				row[field.Key] = tableCell(field.Value)
+			}
+			tw.Write(row)
+		}
+		buf := new(bytes.Buffer)
+		if err := tw.Flush(buf); err != nil {
+			sw.err = err
+			return
+		}
+		for _, line := range strings.SplitAfter(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+			sw.Printf("%s%s", indent, line)
+		}
+		sw.Println()
+	default:
+		sw.Printf("%s%s\n", indent, tableCell(v))
+	}
+}
+
+func allObjects(arr []interface{}) bool {
+	for _, elem := range arr {
+		if _, ok := elem.([]jsonField); !ok {
+			return false
+		}
+	}
+	return len(arr) > 0
+}
+
+func tableCell(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []jsonField, []interface{}:
+		return compactJSON(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func compactJSON(v interface{}) string {
+	switch v := v.(type) {
+	case []jsonField:
+		parts := make([]string, len(v))
+		for i, field := range v {
+			key, _ := json.Marshal(field.Key)
+			parts[i] = string(key) + ":" + compactJSON(field.Value)
+		}
+		return "{" + strings.Join(parts, ",") + "}"
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, elem := range v {
+			parts[i] = compactJSON(elem)
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
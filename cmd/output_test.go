@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	cmds "github.com/ipfs/go-ipfs-cmds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+type bufferEmitter struct {
+	out bytes.Buffer
+}
+
+func (be *bufferEmitter) Close() error                 { return nil }
+func (be *bufferEmitter) CloseWithError(_ error) error { return nil }
+func (be *bufferEmitter) SetLength(_ uint64)           {}
+
+func (be *bufferEmitter) Emit(v interface{}) error {
+	if r, ok := v.(io.Reader); ok {
+		_, err := io.Copy(&be.out, r)
+		return err
+	}
+	return json.NewEncoder(&be.out).Encode(v)
+}
+
+type outputRow struct {
+	Name   string
+	Count  int
+	Labels []string `json:",omitempty"`
+}
+
+func TestWriteTable(t *testing.T) {
+	tf.UnitTest(t)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeTable(buf, []outputRow{{Name: "a", Count: 1}, {Name: "b", Count: 2, Labels: []string{"x"}}}))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"Name", "Count", "Labels"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"a", "1"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"b", "2", `["x"]`}, strings.Fields(lines[2]))
+
+	buf.Reset()
+	require.NoError(t, writeTable(buf, struct {
+		Name  string
+		Inner outputRow
+		Rows  []outputRow
+	}{Name: "n", Inner: outputRow{Name: "i"}, Rows: []outputRow{}}))
+	assert.Equal(t, "Name: n\nInner:\n  Name: i\n  Count: 0\nRows: []\n", buf.String())
+}
+
+func TestWriteYAML(t *testing.T) {
+	tf.UnitTest(t)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeYAML(buf, struct {
+		Zeta  string
+		Alpha int
+		Ok    bool
+		Num   string
+		Ratio float64
+	}{Zeta: "z", Alpha: 3, Ok: true, Num: "10", Ratio: 0.5}))
+	// the fields keep their order, and the strings looking like numbers stay strings
+	assert.Equal(t, "Zeta: z\nAlpha: 3\nOk: true\nNum: \"10\"\nRatio: 0.5\n", buf.String())
+}
+
+func TestOutputEmitter(t *testing.T) {
+	tf.UnitTest(t)
+
+	newReq := func(format string, cmd *cmds.Command) *cmds.Request {
+		return &cmds.Request{Path: []string{"test"}, Command: cmd, Options: cmds.OptMap{cmds.EncLong: format}}
+	}
+
+	// the typed results are encoded as json by go-ipfs-cmds
+	be := &bufferEmitter{}
+	re, err := newOutputEmitter(newReq(cmds.JSON, &cmds.Command{}), be)
+	require.NoError(t, err)
+	require.NoError(t, re.Emit(outputRow{Name: "a"}))
+	assert.Equal(t, "{\"Name\":\"a\",\"Count\":0}\n", be.out.String())
+
+	// the commands printing text do not support json and yaml
+	for _, format := range []string{cmds.JSON, OutputYAML} {
+		re, err = newOutputEmitter(newReq(format, &cmds.Command{}), be)
+		require.NoError(t, err)
+		err = re.Emit(strings.NewReader("text"))
+		assert.EqualError(t, err, "'test' only prints text, it does not support the "+format+" encoding")
+	}
+
+	// the text is printed as is in the table format
+	be = &bufferEmitter{}
+	re, err = newOutputEmitter(newReq(OutputTable, &cmds.Command{}), be)
+	require.NoError(t, err)
+	require.NoError(t, re.Emit(strings.NewReader("text")))
+	assert.Equal(t, "text", be.out.String())
+
+	// the yaml documents are separated
+	be = &bufferEmitter{}
+	re, err = newOutputEmitter(newReq(OutputYAML, &cmds.Command{}), be)
+	require.NoError(t, err)
+	ch := make(chan interface{}, 2)
+	ch <- outputRow{Name: "a"}
+	ch <- outputRow{Name: "b"}
+	close(ch)
+	require.NoError(t, re.Emit(ch))
+	assert.Equal(t, "Name: a\nCount: 0\n---\nName: b\nCount: 0\n", be.out.String())
+
+	// the rendering of the command is used by the default encoding, not by the explicit json one
+	cmd := &cmds.Command{Encoders: cmds.EncoderMap{
+		OutputTable: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, row *outputRow) error {
+			_, err := io.WriteString(w, row.Name+"\n")
+			return err
+		}),
+	}}
+	be = &bufferEmitter{}
+	re, err = newOutputEmitter(newReq(prettyJSON, cmd), be)
+	require.NoError(t, err)
+	require.NoError(t, re.Emit(&outputRow{Name: "a"}))
+	assert.Equal(t, "a\n", be.out.String())
+
+	be = &bufferEmitter{}
+	re, err = newOutputEmitter(newReq(cmds.JSON, cmd), be)
+	require.NoError(t, err)
+	require.NoError(t, re.Emit(&outputRow{Name: "a"}))
+	assert.Equal(t, "{\"Name\":\"a\",\"Count\":0}\n", be.out.String())
+
+	// the commands without a rendering are left alone by the default encoding
+	re, err = newOutputEmitter(newReq(prettyJSON, &cmds.Command{}), be)
+	require.NoError(t, err)
+	assert.Equal(t, be, re)
+}
+
+func TestOutputEncodings(t *testing.T) {
+	tf.UnitTest(t)
+
+	// cli.Run looks the encoding up before the request is executed
+	req := &cmds.Request{Command: &cmds.Command{}}
+	for _, enc := range []string{prettyJSON, cmds.JSON, OutputTable, OutputYAML} {
+		req.Options = cmds.OptMap{cmds.EncLong: enc}
+		_, _, err := cmds.GetEncoder(req, new(bytes.Buffer), cmds.JSON)
+		require.NoError(t, err, enc)
+	}
+}
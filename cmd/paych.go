@@ -3,8 +3,10 @@ package cmd
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
@@ -105,6 +107,8 @@ var voucherCmd = &cmds.Command{
 		"list":           voucherListCmd,
 		"best-spendable": voucherBestSpendableCmd,
 		"submit":         voucherSubmitCmd,
+		"export":         voucherExportCmd,
+		"import":         voucherImportCmd,
 	},
 }
 
@@ -400,6 +404,122 @@ var voucherSubmitCmd = &cmds.Command{
 	},
 }
 
+// voucherExport carries the vouchers of a channel out of band to the node of the recipient
+type voucherExport struct {
+	Channel  address.Address
+	Vouchers []string
+}
+
+var voucherExportCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Export the vouchers of a payment channel to a file, to be imported by the node of the recipient",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("channel_addr", true, false, "The given payment channel address"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("output", "file the vouchers are written to, printed when not set"),
+		cmds.Int64Option("lane", "only export the vouchers of the lane").WithDefault(int64(-1)),
+		cmds.BoolOption("best-spendable", "only export the voucher with the highest value currently spendable of each lane"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		chanAddr, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		api := env.(*node.Env).PaychAPI
+
+		var vouchers []*paych.SignedVoucher
+		if best, _ := req.Options["best-spendable"].(bool); best {
+			byLane, err := paychmgr.BestSpendableByLane(req.Context, api, chanAddr)
+			if err != nil {
+				return err
+			}
+			for _, v := range byLane {
+				vouchers = append(vouchers, v)
+			}
+		} else if vouchers, err = api.PaychVoucherList(req.Context, chanAddr); err != nil {
+			return err
+		}
+
+		lane, _ := req.Options["lane"].(int64)
+		export := voucherExport{Channel: chanAddr}
+		for _, v := range sortVouchers(vouchers) {
+			if lane >= 0 && v.Lane != uint64(lane) {
+				continue
+			}
+			enc, err := encodedString(v)
+			if err != nil {
+				return err
+			}
+			export.Vouchers = append(export.Vouchers, enc)
+		}
+		if len(export.Vouchers) == 0 {
+			return fmt.Errorf("no voucher to export for channel %s", chanAddr)
+		}
+
+		data, err := json.MarshalIndent(export, "", "\t")
+		if err != nil {
+			return err
+		}
+		output, _ := req.Options["output"].(string)
+		if output == "" {
+			return re.Emit(string(data))
+		}
+		if err := os.WriteFile(output, data, 0o600); err != nil {
+			return err
+		}
+		return re.Emit(fmt.Sprintf("exported %d vouchers of %s to %s", len(export.Vouchers), chanAddr, output))
+	},
+}
+
+var voucherImportCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Import the vouchers exported by the node of the sender to the local datastore",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("file", true, false, "The file written by voucher export"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		data, err := os.ReadFile(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		chanAddr, vouchers, err := decodeVoucherExport(data)
+		if err != nil {
+			return err
+		}
+
+		buff := bytes.NewBuffer(nil)
+		for _, v := range vouchers {
+			if _, err := env.(*node.Env).PaychAPI.PaychVoucherAdd(req.Context, chanAddr, v, nil, big.NewInt(0)); err != nil {
+				return fmt.Errorf("adding voucher of lane %d nonce %d: %w", v.Lane, v.Nonce, err)
+			}
+			fmt.Fprintf(buff, "Lane %d, Nonce %d: %s added\n", v.Lane, v.Nonce, v.Amount.String())
+		}
+		return re.Emit(buff)
+	},
+}
+
+func decodeVoucherExport(data []byte) (address.Address, []*paych.SignedVoucher, error) {
+	var export voucherExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return address.Undef, nil, fmt.Errorf("invalid voucher export: %w", err)
+	}
+	vouchers := make([]*paych.SignedVoucher, 0, len(export.Vouchers))
+	for i, enc := range export.Vouchers {
+		v, err := lpaych.DecodeSignedVoucher(enc)
+		if err != nil {
+			return address.Undef, nil, fmt.Errorf("voucher %d: %w", i, err)
+		}
+		if v.ChannelAddr != export.Channel {
+			return address.Undef, nil, fmt.Errorf("voucher %d is for channel %s, not %s", i, v.ChannelAddr, export.Channel)
+		}
+		vouchers = append(vouchers, v)
+	}
+	return export.Channel, vouchers, nil
+}
+
 func encodedString(sv *paych.SignedVoucher) (string, error) {
 	buf := new(bytes.Buffer)
 	if err := sv.MarshalCBOR(buf); err != nil {
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"testing"
 
 	addr "github.com/filecoin-project/go-address"
@@ -40,3 +41,30 @@ func TestEncodedString(t *testing.T) {
 	}
 	assert.Equal(t, str, "i1UB6g8OoDmykaDwj9F54FVqjDJ3wNMBGGRYIFByb2Zlc3JYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYg0IAaAJEAQIDBAEBQgAKGQPogPY")
 }
+
+func TestDecodeVoucherExport(t *testing.T) {
+	chanAddr := tutil.NewIDAddr(t, 1000)
+	other := tutil.NewIDAddr(t, 1001)
+	mkVoucher := func(ch addr.Address, lane uint64) string {
+		str, err := encodedString(&paych.SignedVoucher{ChannelAddr: ch, Lane: lane, Nonce: 1, Amount: big.NewInt(10)})
+		assert.NoError(t, err)
+		return str
+	}
+
+	data, err := json.Marshal(voucherExport{Channel: chanAddr, Vouchers: []string{mkVoucher(chanAddr, 0), mkVoucher(chanAddr, 1)}})
+	assert.NoError(t, err)
+	ch, vouchers, err := decodeVoucherExport(data)
+	assert.NoError(t, err)
+	assert.Equal(t, chanAddr, ch)
+	assert.Len(t, vouchers, 2)
+	assert.Equal(t, uint64(1), vouchers[1].Lane)
+
+	// a voucher of another channel is not imported
+	data, err = json.Marshal(voucherExport{Channel: chanAddr, Vouchers: []string{mkVoucher(other, 0)}})
+	assert.NoError(t, err)
+	_, _, err = decodeVoucherExport(data)
+	assert.Error(t, err)
+
+	_, _, err = decodeVoucherExport([]byte(`{"Channel":"f01000","Vouchers":["not base64!"]}`))
+	assert.Error(t, err)
+}
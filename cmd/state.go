@@ -2,28 +2,37 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/docker/go-units"
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	actorstypes "github.com/filecoin-project/go-state-types/actors"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/filecoin-project/go-state-types/network"
 	"github.com/ipfs/go-cid"
 	cmds "github.com/ipfs/go-ipfs-cmds"
 	"github.com/multiformats/go-multiaddr"
+	cbg "github.com/whyrusleeping/cbor-gen"
 
 	"github.com/filecoin-project/venus/app/node"
 	"github.com/filecoin-project/venus/cmd/tablewriter"
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/utils"
 )
 
 // ActorView represents a generic way to represent details about any actor to the user.
@@ -54,7 +63,10 @@ var stateCmd = &cmds.Command{
 		"network-info":   stateNtwkInfoCmd,
 		"list-actor":     stateListActorCmd,
 		"actor-cids":     stateSysActorCIDsCmd,
+		"upgrades":       stateUpgradeScheduleCmd,
+		"actor-names":    stateActorNamesCmd,
 		"replay":         stateReplayCmd,
+		"batch-estimate": stateBatchEstimateCmd,
 		"compute-state":  StateComputeStateCmd,
 	},
 }
@@ -66,36 +78,130 @@ var stateWaitMsgCmd = &cmds.Command{
 	Arguments: []cmds.Argument{
 		cmds.StringArg("cid", true, false, "CID of message to show"),
 	},
+	Options: []cmds.Option{
+		// the timeout is also applied to the request context by go-ipfs-cmds
+		cmds.StringOption(cmds.TimeoutOpt, "How long to wait for the message, e.g. 10m, waits forever when not set"),
+		cmds.Uint64Option("confidence", "Number of epochs to wait after the message is executed").WithDefault(uint64(constants.MessageConfidence)),
+		cmds.BoolOption("json", "generate json output"),
+	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
-		cid, err := cid.Decode(req.Arguments[0])
+		msgCid, err := cid.Decode(req.Arguments[0])
 		if err != nil {
 			return err
 		}
+		var timeout time.Duration
+		if s, ok := req.Options[cmds.TimeoutOpt].(string); ok && s != "" {
+			if timeout, err = time.ParseDuration(s); err != nil {
+				return fmt.Errorf("parse timeout: %w", err)
+			}
+		}
+		confidence := req.Options["confidence"].(uint64)
+
+		ctx := req.Context
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
 
-		mw, err := env.(*node.Env).ChainAPI.StateWaitMsg(req.Context, cid, constants.MessageConfidence, constants.LookbackNoLimit, true)
+		chainAPI := getEnv(env).ChainAPI
+		mw, err := chainAPI.StateWaitMsg(ctx, msgCid, confidence, constants.LookbackNoLimit, true)
 		if err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timed out after %s waiting for message %s", timeout, msgCid)
+			}
 			return err
 		}
+		if mw == nil {
+			return fmt.Errorf("unable to find message receipt of %s", msgCid)
+		}
+
+		out := waitMsgOutput{
+			Message:    mw.Message,
+			TipSet:     mw.TipSet,
+			Height:     mw.Height,
+			ExitCode:   mw.Receipt.ExitCode,
+			GasUsed:    mw.Receipt.GasUsed,
+			Return:     mw.Receipt.Return,
+			EventsRoot: mw.Receipt.EventsRoot,
+		}
+		if len(mw.Receipt.Return) > 0 && mw.Receipt.ExitCode.IsSuccess() {
+			if out.DecodedReturn, err = decodeMsgReturn(req.Context, chainAPI, mw); err != nil {
+				out.DecodeError = err.Error()
+			}
+		}
 
 		buf := new(bytes.Buffer)
 		writer := NewSilentWriter(buf)
 
-		if mw != nil {
-			writer.Printf("message was executed in tipset: %s\n", mw.TipSet.Cids())
-			writer.Printf("Exit Code: %d\n", mw.Receipt.ExitCode)
-			writer.Printf("Gas Used: %d\n", mw.Receipt.GasUsed)
-			writer.Printf("Return: %x\n", mw.Receipt.Return)
-			if mw.Receipt.EventsRoot != nil {
-				writer.Printf("\nEvents Root: %s", mw.Receipt.EventsRoot)
+		if ok, _ := req.Options["json"].(bool); ok {
+			data, err := json.Marshal(out)
+			if err != nil {
+				return err
 			}
-		} else {
-			writer.Printf("Unable to find message receipt of %s\n", cid)
+			writer.Println(string(data))
+			return re.Emit(buf)
+		}
+
+		writer.Printf("message was executed in tipset: %s\n", mw.TipSet.Cids())
+		writer.Printf("Exit Code: %d\n", mw.Receipt.ExitCode)
+		writer.Printf("Gas Used: %d\n", mw.Receipt.GasUsed)
+		writer.Printf("Return: %x\n", mw.Receipt.Return)
+		if out.DecodedReturn != nil {
+			data, err := json.MarshalIndent(out.DecodedReturn, "", "  ")
+			if err != nil {
+				return err
+			}
+			writer.Printf("Decoded Return: %s\n", data)
+		} else if out.DecodeError != "" {
+			writer.Printf("Decoded Return: %s\n", out.DecodeError)
+		}
+		if mw.Receipt.EventsRoot != nil {
+			writer.Printf("\nEvents Root: %s", mw.Receipt.EventsRoot)
 		}
 
 		return re.Emit(buf)
 	},
 }
 
+// waitMsgOutput is the machine readable result of `state wait-msg`
+type waitMsgOutput struct {
+	Message       cid.Cid
+	TipSet        types.TipSetKey
+	Height        abi.ChainEpoch
+	ExitCode      exitcode.ExitCode
+	GasUsed       int64
+	Return        []byte
+	DecodedReturn interface{} `json:",omitempty"`
+	DecodeError   string      `json:",omitempty"`
+	EventsRoot    *cid.Cid    `json:",omitempty"`
+}
+
+// decodeMsgReturn decodes the return value of the executed message with the return type of the method it called
+func decodeMsgReturn(ctx context.Context, chainAPI v1api.IChain, mw *types.MsgLookup) (interface{}, error) {
+	msg, err := chainAPI.ChainGetMessage(ctx, mw.Message)
+	if err != nil {
+		return nil, fmt.Errorf("load message: %w", err)
+	}
+	act, err := chainAPI.StateGetActor(ctx, msg.To, mw.TipSet)
+	if err != nil {
+		return nil, fmt.Errorf("load actor %s: %w", msg.To, err)
+	}
+
+	methodMeta, found := utils.MethodsMap[act.Code][msg.Method]
+	if !found {
+		return nil, fmt.Errorf("method %d not found on actor %s", msg.Method, act.Code)
+	}
+	ret, ok := reflect.New(methodMeta.Ret.Elem()).Interface().(cbg.CBORUnmarshaler)
+	if !ok {
+		return nil, fmt.Errorf("return type of %s can not be decoded", methodMeta.Name)
+	}
+	if err := ret.UnmarshalCBOR(bytes.NewReader(mw.Receipt.Return)); err != nil {
+		return nil, fmt.Errorf("decode return of %s: %w", methodMeta.Name, err)
+	}
+	return ret, nil
+}
+
 var stateSearchMsgCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Search to see whether a message has appeared on chain",
@@ -537,6 +643,12 @@ var stateMinerInfo = &cmds.Command{
 	},
 }
 
+// NetworkInfo is the result of state network-info
+type NetworkInfo struct {
+	NetworkVersion network.Version
+	*types.NetworkParams
+}
+
 var stateNtwkInfoCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Returns the network info",
@@ -549,9 +661,6 @@ var stateNtwkInfoCmd = &cmds.Command{
 			return err
 		}
 
-		buf := new(bytes.Buffer)
-		writer := NewSilentWriter(buf)
-
 		nv, err := env.(*node.Env).ChainAPI.StateNetworkVersion(ctx, ts.Key())
 		if err != nil {
 			return err
@@ -562,30 +671,100 @@ var stateNtwkInfoCmd = &cmds.Command{
 			return err
 		}
 
-		partUpgradeHeight := func() []string {
-			var out []string
-			rv := reflect.ValueOf(params.ForkUpgradeParams)
-			rt := rv.Type()
-			numField := rt.NumField()
-			for i := numField - 3; i < numField; i++ {
-				out = append(out, fmt.Sprintf("%s: %v", rt.Field(i).Name, rv.Field(i).Interface()))
+		return re.Emit(&NetworkInfo{NetworkVersion: nv, NetworkParams: params})
+	},
+	Type: NetworkInfo{},
+	Encoders: cmds.EncoderMap{
+		OutputTable: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, info *NetworkInfo) error {
+			writer := NewSilentWriter(w)
+			params := info.NetworkParams
+
+			partUpgradeHeight := func() []string {
+				var out []string
+				rv := reflect.ValueOf(params.ForkUpgradeParams)
+				rt := rv.Type()
+				numField := rt.NumField()
+				for i := numField - 3; i < numField; i++ {
+					out = append(out, fmt.Sprintf("%s: %v", rt.Field(i).Name, rv.Field(i).Interface()))
+				}
+				return out
+			}
+
+			writer.Println("Network Name:", params.NetworkName)
+			writer.Println("Network Version:", info.NetworkVersion)
+			for _, one := range partUpgradeHeight() {
+				writer.Println(one)
+			}
+			writer.Println("BlockDelaySecs:", params.BlockDelaySecs)
+			writer.Println("PreCommitChallengeDelay:", params.PreCommitChallengeDelay)
+			writer.Println("Chain ID:", params.Eip155ChainID)
+			sizes := make([]string, 0, len(params.SectorSizes))
+			for _, size := range params.SectorSizes {
+				sizes = append(sizes, units.BytesSize(float64(size)))
 			}
-			return out
+			writer.Println("Sector Sizes:", strings.Join(sizes, ", "))
+
+			return writer.Error()
+		}),
+	},
+}
+
+var stateBatchEstimateCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Estimate the network fee of a batch of sector messages and check its size",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("kind", true, false, "Kind of the batch, precommit or provecommit"),
+		cmds.StringArg("size", true, false, "Number of sectors of the batch"),
+	},
+	Options: []cmds.Option{
+		cmds.IntOption("proof-size", "Size in bytes of the aggregate proof to check"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		size, err := strconv.Atoi(req.Arguments[1])
+		if err != nil {
+			return fmt.Errorf("parse batch size: %w", err)
+		}
+		proofSize, _ := req.Options["proof-size"].(int)
+
+		est, err := getEnv(env).ChainAPI.StateSectorBatchEstimate(req.Context, types.SectorBatchKind(req.Arguments[0]), size, types.EmptyTSK)
+		if err != nil {
+			return err
 		}
 
-		writer.Println("Network Name:", params.NetworkName)
-		writer.Println("Network Version:", nv)
-		for _, one := range partUpgradeHeight() {
-			writer.Println(one)
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		writer.Println("Network Version:", est.NetworkVersion)
+		writer.Printf("Batch Size:\t%d (%d - %d)\n", est.Size, est.MinSize, est.MaxSize)
+		if est.MaxProofSize > 0 {
+			writer.Printf("Max Proof Size:\t%d bytes\n", est.MaxProofSize)
+		}
+		writer.Println("Base Fee:", types.FIL(est.BaseFee).Short())
+		writer.Println("Network Fee:", types.FIL(est.NetworkFee).Short())
+		writer.Println("Network Fee Per Sector:", types.FIL(est.NetworkFeePerSector).Short())
+		for _, problem := range sectorBatchProblems(est, proofSize) {
+			writer.Println("WARNING:", problem)
 		}
-		writer.Println("BlockDelaySecs:", params.BlockDelaySecs)
-		writer.Println("PreCommitChallengeDelay:", params.PreCommitChallengeDelay)
-		writer.Println("Chain ID:", params.Eip155ChainID)
 
 		return re.Emit(buf)
 	},
 }
 
+// sectorBatchProblems returns why the batch would be refused by the miner actor
+func sectorBatchProblems(est *types.SectorBatchEstimate, proofSize int) []string {
+	var problems []string
+	if est.Size < est.MinSize {
+		problems = append(problems, fmt.Sprintf("a %s batch needs at least %d sectors", est.Kind, est.MinSize))
+	}
+	if est.Size > est.MaxSize {
+		problems = append(problems, fmt.Sprintf("a %s batch holds at most %d sectors", est.Kind, est.MaxSize))
+	}
+	if proofSize > 0 && est.MaxProofSize > 0 && proofSize > est.MaxProofSize {
+		problems = append(problems, fmt.Sprintf("the aggregate proof exceeds %d bytes", est.MaxProofSize))
+	}
+	return problems
+}
+
 var stateListActorCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "list all actors",
@@ -621,6 +800,20 @@ var stateListActorCmd = &cmds.Command{
 	},
 }
 
+// ActorCIDs is the bundle of the builtin actors of a network version
+type ActorCIDs struct {
+	NetworkVersion network.Version
+	ActorsVersion  actorstypes.Version
+	Manifest       cid.Cid
+	// Actors are sorted by name
+	Actors []ActorCodeCID
+}
+
+type ActorCodeCID struct {
+	Name string
+	Code cid.Cid
+}
+
 var stateSysActorCIDsCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Returns the built-in actor bundle manifest ID & system actor cids",
@@ -643,51 +836,142 @@ var stateSysActorCIDsCmd = &cmds.Command{
 			}
 		}
 
-		buf := new(bytes.Buffer)
-		buf.WriteString(fmt.Sprintf("Network Version: %d\n", nv))
-
 		actorVersion, err := actorstypes.VersionForNetwork(nv)
 		if err != nil {
 			return err
 		}
-		buf.WriteString(fmt.Sprintf("Actor Version: %d\n", actorVersion))
 
 		manifestCid, err := env.(*node.Env).ChainAPI.StateActorManifestCID(ctx, nv)
 		if err != nil {
 			return err
 		}
-		buf.WriteString(fmt.Sprintf("Manifest CID: %v\n", manifestCid))
-
-		tw := tablewriter.New(tablewriter.Col("Actor"), tablewriter.Col("CID"))
 
 		actorsCids, err := env.(*node.Env).ChainAPI.StateActorCodeCIDs(ctx, nv)
 		if err != nil {
 			return err
 		}
 
-		type actorCid struct {
-			actorName string
-			actorCID  string
-		}
-
-		var actorsCid []actorCid
+		res := &ActorCIDs{NetworkVersion: nv, ActorsVersion: actorVersion, Manifest: manifestCid}
 		for name, c := range actorsCids {
-			actorsCid = append(actorsCid, actorCid{
-				actorName: name,
-				actorCID:  c.String(),
-			})
+			res.Actors = append(res.Actors, ActorCodeCID{Name: name, Code: c})
 		}
-		sort.Slice(actorsCid, func(i, j int) bool {
-			return actorsCid[i].actorName < actorsCid[j].actorName
+		sort.Slice(res.Actors, func(i, j int) bool {
+			return res.Actors[i].Name < res.Actors[j].Name
 		})
 
-		for _, ac := range actorsCid {
-			tw.Write(map[string]interface{}{
-				"Actor": ac.actorName,
-				"CID":   ac.actorCID,
-			})
+		return re.Emit(res)
+	},
+	Type: ActorCIDs{},
+	Encoders: cmds.EncoderMap{
+		OutputTable: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, res *ActorCIDs) error {
+			writer := NewSilentWriter(w)
+			writer.Printf("Network Version: %d\n", res.NetworkVersion)
+			writer.Printf("Actor Version: %d\n", res.ActorsVersion)
+			writer.Printf("Manifest CID: %v\n", res.Manifest)
+			if err := writer.Error(); err != nil {
+				return err
+			}
+
+			tw := tablewriter.New(tablewriter.Col("Actor"), tablewriter.Col("CID"))
+			for _, ac := range res.Actors {
+				tw.Write(map[string]interface{}{
+					"Actor": ac.Name,
+					"CID":   ac.Code.String(),
+				})
+			}
+			return tw.Flush(w)
+		}),
+	},
+}
+
+var stateUpgradeScheduleCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Returns the network upgrade schedule, with the actors bundle of each network version",
+	},
+	Options: []cmds.Option{
+		cmds.Int64Option("epoch", "only print the network version of the epoch"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+
+		schedule, err := getEnv(env).ChainAPI.StateNetworkUpgradeSchedule(ctx)
+		if err != nil {
+			return err
 		}
+		if epoch, ok := req.Options["epoch"].(int64); ok {
+			if epoch < 0 {
+				return fmt.Errorf("invalid epoch %d", epoch)
+			}
+			// the network version of the epoch is the last one of the schedule applying from it or before
+			var at []*types.NetworkUpgrade
+			for _, upgrade := range schedule {
+				if upgrade.Epoch <= abi.ChainEpoch(epoch) {
+					at = []*types.NetworkUpgrade{upgrade}
+				}
+			}
+			schedule = at
+		}
+
+		return re.Emit(schedule)
+	},
+	Type: []*types.NetworkUpgrade{},
+	Encoders: cmds.EncoderMap{
+		OutputTable: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, schedule []*types.NetworkUpgrade) error {
+			if _, ok := req.Options["epoch"].(int64); ok && len(schedule) == 1 {
+				_, err := fmt.Fprintf(w, "Network Version: %d\n", schedule[0].Version)
+				return err
+			}
+
+			tw := tablewriter.New(tablewriter.Col("Epoch"), tablewriter.Col("Network"), tablewriter.Col("Actors"), tablewriter.Col("Manifest"))
+			for _, upgrade := range schedule {
+				manifest := "-"
+				if upgrade.Manifest.Defined() {
+					manifest = upgrade.Manifest.String()
+				}
+				tw.Write(map[string]interface{}{
+					"Epoch":    upgrade.Epoch,
+					"Network":  upgrade.Version,
+					"Actors":   upgrade.ActorsVersion,
+					"Manifest": manifest,
+				})
+			}
+			return tw.Flush(w)
+		}),
+	},
+}
 
+var stateActorNamesCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Returns the built-in actors of the code cids",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("code", true, true, "actor code cid"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		codes := make([]cid.Cid, 0, len(req.Arguments))
+		for _, arg := range req.Arguments {
+			c, err := cid.Decode(arg)
+			if err != nil {
+				return fmt.Errorf("invalid code cid %s: %w", arg, err)
+			}
+			codes = append(codes, c)
+		}
+
+		names, err := env.(*node.Env).ChainAPI.StateActorNames(req.Context, codes)
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		tw := tablewriter.New(tablewriter.Col("Code"), tablewriter.Col("Actor"), tablewriter.Col("Networks"))
+		for i, name := range names {
+			row := map[string]interface{}{"Code": codes[i].String(), "Actor": "unknown"}
+			if name != nil {
+				row["Actor"] = name.String()
+				row["Networks"] = strings.Join(name.Networks, ",")
+			}
+			tw.Write(row)
+		}
 		if err := tw.Flush(buf); err != nil {
 			return err
 		}
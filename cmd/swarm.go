@@ -56,6 +56,7 @@ libp2p peers on the internet.
 		"unprotect":      protectRemoveCmd,
 		"list-protected": protectListCmd,
 		"scores":         swarmScoresCmd,
+		"topics":         swarmTopicsCmd,
 	},
 }
 
@@ -683,6 +684,36 @@ var swarmScoresCmd = &cmds.Command{
 	},
 }
 
+var swarmTopicsCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Print the pubsub topics with their mesh and message counts",
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("mesh", "m", "print the peers of the mesh"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		topics, err := env.(*node.Env).NetworkAPI.NetPubsubTopics(req.Context)
+		if err != nil {
+			return err
+		}
+		mesh, _ := req.Options["mesh"].(bool)
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		for _, topic := range topics {
+			writer.Printf("%s: peers %d, mesh %d, delivered %d, rejected %d, duplicate %d\n",
+				topic.Topic, len(topic.Peers), len(topic.Mesh), topic.Delivered, topic.Rejected, topic.Duplicate)
+			if mesh {
+				for _, p := range topic.Mesh {
+					writer.Printf("\t%s\n", p)
+				}
+			}
+		}
+
+		return re.Emit(buf)
+	},
+}
+
 // IDDetails is a collection of information about a node.
 type IDDetails struct {
 	Addresses       []ma.Multiaddr
@@ -3,7 +3,11 @@ package cmd
 
 import (
 	"bytes"
+	"fmt"
 	"strconv"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
 
 	"github.com/filecoin-project/venus/venus-shared/types"
 
@@ -57,73 +61,179 @@ var setConcurrent = &cmds.Command{
 var storeStatusCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Show status of chain sync operation.",
+		ShortDescription: `
+Show the targets of the sync workers. A target in syncing reports its stage: fetching the
+headers, fetching the messages, or validating and executing the tipsets, with the number of
+epochs executed out of the target and the epochs executed per minute since it was received.
+`,
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("watch", "refresh the status until interrupted"),
+		cmds.StringOption("interval", "time between two refreshes of the watch").WithDefault("5s"),
 	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
-		tracker := env.(*node.Env).SyncerAPI.SyncerTracker(req.Context)
-		targets := tracker.Buckets
+		api := env.(*node.Env).SyncerAPI
+		if watch, _ := req.Options["watch"].(bool); !watch {
+			return re.Emit(syncStatus(api.SyncerTracker(req.Context), time.Now()))
+		}
 
-		var inSyncing []*types.Target
-		var waitTarget []*types.Target
-		for _, t := range targets {
-			if t.State == types.StageMessages {
-				inSyncing = append(inSyncing, t)
-			} else {
-				waitTarget = append(waitTarget, t)
-			}
+		interval, err := time.ParseDuration(req.Options["interval"].(string))
+		if err != nil {
+			return fmt.Errorf("invalid interval: %w", err)
+		}
+		if interval <= 0 {
+			return cmds.ClientError("the interval must be positive")
 		}
 
-		w := bytes.NewBufferString("")
-		writer := NewSilentWriter(w)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			now := time.Now()
+			// the refresh is emitted as one buffer, as the status without the watch
+			w := bytes.NewBufferString(fmt.Sprintf("===== %s =====\n", now.Format(time.RFC3339)))
+			if _, err := syncStatus(api.SyncerTracker(req.Context), now).WriteTo(w); err != nil {
+				return err
+			}
+			if err := re.Emit(w); err != nil {
+				return err
+			}
 
-		if len(inSyncing) == 0 && len(waitTarget) == 0 {
-			lenTH := len(tracker.History)
-			if lenTH > 0 {
-				writer.Println(tracker.History[lenTH-1].String())
+			select {
+			case <-req.Context.Done():
+				return req.Context.Err()
+			case <-ticker.C:
 			}
+		}
+	},
+}
 
-			writer.Println("Done!")
-			return re.Emit(w)
+func syncStatus(tracker *types.TargetTracker, now time.Time) *bytes.Buffer {
+	var inSyncing []*types.Target
+	var waitTarget []*types.Target
+	for _, t := range tracker.Buckets {
+		if t.State == types.StageMessages {
+			inSyncing = append(inSyncing, t)
+		} else {
+			waitTarget = append(waitTarget, t)
 		}
+	}
 
-		count := 1
-		if len(inSyncing) > 0 {
-			writer.Println("Syncing:")
-			for _, t := range inSyncing {
-				writer.Println("SyncTarget:", strconv.Itoa(count))
-				writer.Println("\tBase:", t.Base.Height(), t.Base.Key().String())
-				writer.Println("\tTarget:", t.Head.Height(), t.Head.Key().String())
-				writer.Println("\tCurrent:", t.Current.Height(), t.Current.Key().String())
+	w := bytes.NewBufferString("")
+	writer := NewSilentWriter(w)
+
+	if len(inSyncing) == 0 && len(waitTarget) == 0 {
+		lenTH := len(tracker.History)
+		if lenTH > 0 {
+			writer.Println(tracker.History[lenTH-1].String())
+		}
 
-				HeightDiff := t.Head.Height() - t.Current.Height()
-				writer.Println("\tHeightDiff:", HeightDiff)
+		writer.Println("Done!")
+		return w
+	}
 
-				writer.Println("\tStatus:", t.State.String())
-				writer.Println("\tErr:", t.Err)
-				writer.Println()
-				count++
+	count := 1
+	if len(inSyncing) > 0 {
+		writer.Println("Syncing:")
+		for _, t := range inSyncing {
+			writer.Println("SyncTarget:", strconv.Itoa(count))
+			writer.Println("\tBase:", t.Base.Height(), t.Base.Key().String())
+			writer.Println("\tTarget:", t.Head.Height(), t.Head.Key().String())
+			writer.Println("\tCurrent:", t.Current.Height(), t.Current.Key().String())
+
+			HeightDiff := t.Head.Height() - t.Current.Height()
+			writer.Println("\tHeightDiff:", HeightDiff)
+			writer.Println("\tWeight:", t.Head.ParentWeight())
+
+			writer.Println("\tStatus:", t.State.String())
+			writer.Println("\tStage:", syncStageString(t.Stage))
+			if t.Fetched != nil {
+				writer.Println("\tFetched:", t.Fetched.Height())
 			}
+			done, total, rate := syncTargetProgress(t, now)
+			if total > 0 {
+				writer.Printf("\tProgress: %d/%d epochs (%.1f%%)\n", done, total, float64(done)*100/float64(total))
+			}
+			writer.Printf("\tThroughput: %.1f epochs/min\n", rate)
+			writer.Println("\tErr:", t.Err)
+			writer.Println()
+			count++
 		}
+	}
 
-		if len(waitTarget) > 0 {
-			writer.Println("Waiting:")
-			for _, t := range waitTarget {
-				writer.Println("SyncTarget:", strconv.Itoa(count))
-				writer.Println("\tBase:", t.Base.Height(), t.Base.Key().String())
-				writer.Println("\tTarget:", t.Head.Height(), t.Head.Key().String())
-				writer.Println("\tCurrent:", t.Current.Height(), t.Current.Key().String())
+	if len(waitTarget) > 0 {
+		writer.Println("Waiting:")
+		for _, t := range waitTarget {
+			writer.Println("SyncTarget:", strconv.Itoa(count))
+			writer.Println("\tBase:", t.Base.Height(), t.Base.Key().String())
+			writer.Println("\tTarget:", t.Head.Height(), t.Head.Key().String())
+			writer.Println("\tCurrent:", t.Current.Height(), t.Current.Key().String())
 
-				HeightDiff := t.Head.Height() - t.Current.Height()
-				writer.Println("\tHeightDiff:", HeightDiff)
+			HeightDiff := t.Head.Height() - t.Current.Height()
+			writer.Println("\tHeightDiff:", HeightDiff)
+			writer.Println("\tWeight:", t.Head.ParentWeight())
 
-				writer.Println("\tStatus:", t.State.String())
-				writer.Println("\tErr:", t.Err)
-				writer.Println()
-				count++
-			}
+			writer.Println("\tStatus:", t.State.String())
+			writer.Println("\tErr:", t.Err)
+			writer.Println()
+			count++
 		}
+	}
 
-		return re.Emit(w)
-	},
+	writePreemptedTargets(writer, tracker.History)
+
+	return w
+}
+
+// writePreemptedTargets lists the targets of the history whose sync was canceled for a heavier target
+func writePreemptedTargets(writer *SilentWriter, history []*types.Target) {
+	var preempted []*types.Target
+	for _, t := range history {
+		if t.Preempted {
+			preempted = append(preempted, t)
+		}
+	}
+	if len(preempted) == 0 {
+		return
+	}
+
+	writer.Println("Preempted by heavier targets:")
+	for _, t := range preempted {
+		writer.Println("\tTarget:", t.Head.Height(), t.Head.Key().String(), "Weight:", t.Head.ParentWeight())
+	}
+}
+
+func syncStageString(stage types.SyncStateStage) string {
+	switch stage {
+	case types.StageHeaders:
+		return "fetching headers"
+	case types.StageFetchingMessages:
+		return "fetching messages"
+	case types.StageMessages:
+		return "validating and executing"
+	default:
+		return stage.String()
+	}
+}
+
+// syncTargetProgress returns the epochs executed out of the epochs from the base to the head of the target, and the
+// epochs executed per minute since the target was received
+func syncTargetProgress(t *types.Target, now time.Time) (done, total abi.ChainEpoch, epochsPerMinute float64) {
+	total = t.Head.Height() - t.Base.Height()
+	if t.Current != nil {
+		done = t.Current.Height() - t.Base.Height()
+	}
+	if done < 0 {
+		// the sync of a fork starts below the base
+		done = 0
+	}
+	if total > 0 && done > total {
+		done = total
+	}
+
+	if elapsed := now.Sub(t.Start); !t.Start.IsZero() && elapsed > 0 {
+		epochsPerMinute = float64(done) / elapsed.Minutes()
+	}
+	return done, total, epochsPerMinute
 }
 
 var historyCmd = &cmds.Command{
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestSyncTargetProgress(t *testing.T) {
+	tf.UnitTest(t)
+
+	dummyCid := testhelpers.EmptyMessagesCID
+	newAddr := testhelpers.NewForTestGetter()
+	tipset := func(h abi.ChainEpoch) *types.TipSet {
+		ts, err := types.NewTipSet([]*types.BlockHeader{{
+			Miner:                 newAddr(),
+			Height:                h,
+			ParentStateRoot:       dummyCid,
+			Messages:              dummyCid,
+			ParentMessageReceipts: dummyCid,
+			BlockSig:              &crypto.Signature{Type: crypto.SigTypeBLS},
+			BLSAggregate:          &crypto.Signature{Type: crypto.SigTypeBLS},
+		}})
+		require.NoError(t, err)
+		return ts
+	}
+
+	now := time.Now()
+	target := &types.Target{
+		Stage:   types.StageMessages,
+		Base:    tipset(100),
+		Current: tipset(160),
+		Head:    tipset(300),
+		Start:   now.Add(-2 * time.Minute),
+	}
+	done, total, rate := syncTargetProgress(target, now)
+	assert.Equal(t, abi.ChainEpoch(60), done)
+	assert.Equal(t, abi.ChainEpoch(200), total)
+	assert.InDelta(t, 30, rate, 0.001)
+	assert.Equal(t, "validating and executing", syncStageString(target.Stage))
+
+	// the sync of a fork executes the tipsets below the base first
+	target.Current = tipset(90)
+	done, _, rate = syncTargetProgress(target, now)
+	assert.Equal(t, abi.ChainEpoch(0), done)
+	assert.Zero(t, rate)
+
+	target.Current = nil
+	target.Start = time.Time{}
+	done, _, rate = syncTargetProgress(target, now)
+	assert.Equal(t, abi.ChainEpoch(0), done)
+	assert.Zero(t, rate)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/venus-shared/actors/msgbuilder"
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
@@ -150,6 +151,16 @@ func getBlockDelay(ctx context.Context, env cmds.Environment) (uint64, error) {
 	return params.BlockDelaySecs, nil
 }
 
+// newMsgBuilder returns a builder of the messages sent by from at the network version of the head
+func newMsgBuilder(ctx context.Context, env cmds.Environment, from address.Address) (msgbuilder.Builder, error) {
+	nv, err := env.(*node.Env).ChainAPI.StateNetworkVersion(ctx, types.EmptyTSK)
+	if err != nil {
+		return msgbuilder.Builder{}, fmt.Errorf("getting network version: %w", err)
+	}
+
+	return msgbuilder.New(nv, from), nil
+}
+
 func EpochTime(curr, e abi.ChainEpoch, blockDelay uint64) string {
 	switch {
 	case curr > e:
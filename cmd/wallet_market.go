@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var walletMarketCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Manage the market escrow of a client or provider address",
+	},
+	Subcommands: map[string]*cmds.Command{
+		"add":      walletMarketAddCmd,
+		"withdraw": walletMarketWithdrawCmd,
+	},
+}
+
+var walletMarketOptions = []cmds.Option{
+	cmds.StringOption("from", "the address sending the message, the default wallet address when empty"),
+	cmds.StringOption("address", "the client or provider address owning the escrow, the sender when empty"),
+	cmds.Uint64Option("confidence", "number of block confirmations to wait for").WithDefault(constants.MessageConfidence),
+}
+
+var walletMarketAddCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Add funds to the market escrow of an address",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("amount", true, false, "[amount (FIL)]"),
+	},
+	Options: walletMarketOptions,
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		from, addr, err := walletMarketAddresses(ctx, req, env)
+		if err != nil {
+			return err
+		}
+		f, err := types.ParseFIL(req.Arguments[0])
+		if err != nil {
+			return fmt.Errorf("parsing 'amount' argument: %v", err)
+		}
+		amount := abi.TokenAmount(f)
+		if amount.Sign() <= 0 {
+			return fmt.Errorf("amount must be positive")
+		}
+
+		builder, err := newMsgBuilder(ctx, env, from)
+		if err != nil {
+			return err
+		}
+		msg, err := builder.Market().AddBalance(addr, amount)
+		if err != nil {
+			return err
+		}
+		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, nil)
+		if err != nil {
+			return fmt.Errorf("mpool push: %w", err)
+		}
+		_ = re.Emit(fmt.Sprintf("Adding %s to the market escrow of %s in message %s", types.FIL(amount), addr, smsg.Cid()))
+
+		confidence, _ := req.Options["confidence"].(uint64)
+		wait, err := env.(*node.Env).ChainAPI.StateWaitMsg(ctx, smsg.Cid(), confidence, constants.LookbackNoLimit, true)
+		if err != nil {
+			return err
+		}
+		if wait.Receipt.ExitCode.IsError() {
+			return fmt.Errorf("add balance failed, exitcode: %d", wait.Receipt.ExitCode)
+		}
+
+		return emitMarketBalance(ctx, re, env, addr, wait.TipSet)
+	},
+}
+
+var walletMarketWithdrawCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Withdraw funds from the market escrow of an address",
+		ShortDescription: `The funds of a client go to the client, the ones of a provider to its owner, which or whose
+worker must send the message. Every available fund is withdrawn when the amount is omitted.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("amount", false, false, "[amount (FIL)]"),
+	},
+	Options: walletMarketOptions,
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		from, addr, err := walletMarketAddresses(ctx, req, env)
+		if err != nil {
+			return err
+		}
+
+		bal, err := env.(*node.Env).ChainAPI.StateMarketBalance(ctx, addr, types.EmptyTSK)
+		if err != nil {
+			return fmt.Errorf("getting market balance of %s: %w", addr, err)
+		}
+		var requested string
+		if len(req.Arguments) > 0 {
+			requested = req.Arguments[0]
+		}
+		amount, err := marketWithdrawAmount(bal, requested)
+		if err != nil {
+			return err
+		}
+
+		builder, err := newMsgBuilder(ctx, env, from)
+		if err != nil {
+			return err
+		}
+		msg, err := builder.Market().WithdrawBalance(addr, amount)
+		if err != nil {
+			return err
+		}
+		smsg, err := env.(*node.Env).MessagePoolAPI.MpoolPushMessage(ctx, msg, nil)
+		if err != nil {
+			return fmt.Errorf("mpool push: %w", err)
+		}
+		_ = re.Emit(fmt.Sprintf("Withdrawing %s from the market escrow of %s in message %s", types.FIL(amount), addr, smsg.Cid()))
+
+		confidence, _ := req.Options["confidence"].(uint64)
+		wait, err := env.(*node.Env).ChainAPI.StateWaitMsg(ctx, smsg.Cid(), confidence, constants.LookbackNoLimit, true)
+		if err != nil {
+			return err
+		}
+		if wait.Receipt.ExitCode.IsError() {
+			return fmt.Errorf("withdraw balance failed, exitcode: %d", wait.Receipt.ExitCode)
+		}
+
+		nv, err := env.(*node.Env).ChainAPI.StateNetworkVersion(ctx, wait.TipSet)
+		if err != nil {
+			return err
+		}
+		if nv >= network.Version14 {
+			var withdrawn abi.TokenAmount
+			if err := withdrawn.UnmarshalCBOR(bytes.NewReader(wait.Receipt.Return)); err != nil {
+				return err
+			}
+			_ = re.Emit(fmt.Sprintf("Successfully withdrew %s", types.FIL(withdrawn)))
+		}
+
+		return emitMarketBalance(ctx, re, env, addr, wait.TipSet)
+	},
+}
+
+// walletMarketAddresses returns the sender of the message and the address owning the escrow
+func walletMarketAddresses(ctx context.Context, req *cmds.Request, env cmds.Environment) (address.Address, address.Address, error) {
+	var from address.Address
+	var err error
+	if s, _ := req.Options["from"].(string); s != "" {
+		if from, err = address.NewFromString(s); err != nil {
+			return address.Undef, address.Undef, fmt.Errorf("parsing from address: %w", err)
+		}
+	} else if from, err = env.(*node.Env).WalletAPI.WalletDefaultAddress(ctx); err != nil {
+		return address.Undef, address.Undef, fmt.Errorf("getting default wallet address: %w", err)
+	}
+
+	addr := from
+	if s, _ := req.Options["address"].(string); s != "" {
+		if addr, err = address.NewFromString(s); err != nil {
+			return address.Undef, address.Undef, fmt.Errorf("parsing escrow address: %w", err)
+		}
+	}
+	return from, addr, nil
+}
+
+// marketWithdrawAmount returns the amount requested, every available fund when it is empty
+func marketWithdrawAmount(bal types.MarketBalance, requested string) (abi.TokenAmount, error) {
+	available := big.Sub(bal.Escrow, bal.Locked)
+	if available.Sign() <= 0 {
+		return big.Zero(), fmt.Errorf("no funds available to withdraw, escrow: %s, locked: %s", types.FIL(bal.Escrow), types.FIL(bal.Locked))
+	}
+	if requested == "" {
+		return available, nil
+	}
+
+	f, err := types.ParseFIL(requested)
+	if err != nil {
+		return big.Zero(), fmt.Errorf("parsing 'amount' argument: %v", err)
+	}
+	amount := abi.TokenAmount(f)
+	if amount.Sign() <= 0 {
+		return big.Zero(), fmt.Errorf("amount must be positive")
+	}
+	if amount.GreaterThan(available) {
+		return big.Zero(), fmt.Errorf("can't withdraw more funds than available; requested: %s; available: %s", types.FIL(amount), types.FIL(available))
+	}
+	return amount, nil
+}
+
+func emitMarketBalance(ctx context.Context, re cmds.ResponseEmitter, env cmds.Environment, addr address.Address, tsk types.TipSetKey) error {
+	bal, err := env.(*node.Env).ChainAPI.StateMarketBalance(ctx, addr, tsk)
+	if err != nil {
+		return fmt.Errorf("getting market balance of %s: %w", addr, err)
+	}
+
+	return re.Emit(fmt.Sprintf("Market balance of %s: escrow %s, locked %s, available %s", addr,
+		types.FIL(bal.Escrow), types.FIL(bal.Locked), types.FIL(big.Sub(bal.Escrow, bal.Locked))))
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestMarketWithdrawAmount(t *testing.T) {
+	tf.UnitTest(t)
+
+	fil := func(s string) abi.TokenAmount { return abi.TokenAmount(types.MustParseFIL(s)) }
+	bal := types.MarketBalance{Escrow: fil("10"), Locked: fil("4")}
+
+	amount, err := marketWithdrawAmount(bal, "")
+	require.NoError(t, err)
+	assert.Equal(t, fil("6"), amount)
+
+	amount, err = marketWithdrawAmount(bal, "2.5")
+	require.NoError(t, err)
+	assert.Equal(t, fil("2.5"), amount)
+
+	_, err = marketWithdrawAmount(bal, "7")
+	assert.Error(t, err)
+	_, err = marketWithdrawAmount(bal, "0")
+	assert.Error(t, err)
+	_, err = marketWithdrawAmount(bal, "ten")
+	assert.Error(t, err)
+	_, err = marketWithdrawAmount(types.MarketBalance{Escrow: fil("4"), Locked: fil("4")}, "")
+	assert.Error(t, err)
+	_, err = marketWithdrawAmount(types.MarketBalance{Escrow: big.Zero(), Locked: big.Zero()}, "1")
+	assert.Error(t, err)
+}
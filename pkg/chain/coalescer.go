@@ -134,17 +134,21 @@ func (c *HeadChangeCoalescer) background(minDelay, maxDelay, mergeInterval time.
 }
 
 func (c *HeadChangeCoalescer) coalesce(revert, apply []*types.TipSet) {
-	// newly reverted tipsets cancel out with pending applys.
-	// similarly, newly applied tipsets cancel out with pending reverts.
+	c.revert, c.apply = coalesceHeadChange(c.revert, c.apply, revert, apply)
+}
 
+// coalesceHeadChange merges an incoming head change into the pending one.
+// newly reverted tipsets cancel out with pending applys.
+// similarly, newly applied tipsets cancel out with pending reverts.
+func coalesceHeadChange(pendingRevert, pendingApply, revert, apply []*types.TipSet) ([]*types.TipSet, []*types.TipSet) {
 	// pending tipsets
-	pendRevert := make(map[string]struct{}, len(c.revert))
-	for _, ts := range c.revert {
+	pendRevert := make(map[string]struct{}, len(pendingRevert))
+	for _, ts := range pendingRevert {
 		pendRevert[ts.Key().String()] = struct{}{}
 	}
 
-	pendApply := make(map[string]struct{}, len(c.apply))
-	for _, ts := range c.apply {
+	pendApply := make(map[string]struct{}, len(pendingApply))
+	for _, ts := range pendingApply {
 		pendApply[ts.Key().String()] = struct{}{}
 	}
 
@@ -162,8 +166,8 @@ func (c *HeadChangeCoalescer) coalesce(revert, apply []*types.TipSet) {
 	// coalesced revert set
 	// - pending reverts are cancelled by incoming applys
 	// - incoming reverts are cancelled by pending applys
-	newRevert := make([]*types.TipSet, 0, len(c.revert)+len(revert))
-	for _, ts := range c.revert {
+	newRevert := make([]*types.TipSet, 0, len(pendingRevert)+len(revert))
+	for _, ts := range pendingRevert {
 		_, cancel := applying[ts.Key().String()]
 		if cancel {
 			continue
@@ -184,8 +188,8 @@ func (c *HeadChangeCoalescer) coalesce(revert, apply []*types.TipSet) {
 	// coalesced apply set
 	// - pending applys are cancelled by incoming reverts
 	// - incoming applys are cancelled by pending reverts
-	newApply := make([]*types.TipSet, 0, len(c.apply)+len(apply))
-	for _, ts := range c.apply {
+	newApply := make([]*types.TipSet, 0, len(pendingApply)+len(apply))
+	for _, ts := range pendingApply {
 		_, cancel := reverting[ts.Key().String()]
 		if cancel {
 			continue
@@ -203,9 +207,7 @@ func (c *HeadChangeCoalescer) coalesce(revert, apply []*types.TipSet) {
 		newApply = append(newApply, ts)
 	}
 
-	// commit the coalesced sets
-	c.revert = newRevert
-	c.apply = newApply
+	return newRevert, newApply
 }
 
 func (c *HeadChangeCoalescer) dispatch() {
@@ -217,3 +219,66 @@ func (c *HeadChangeCoalescer) dispatch() {
 	c.revert = nil
 	c.apply = nil
 }
+
+// headChangeBacklog buffers the head changes a slow subscriber has not received yet.
+// Incoming changes are coalesced with the pending ones, so the backlog is bounded by the
+// depth of the reorgs rather than by the number of events.
+type headChangeBacklog struct {
+	// current is the head of the last HCCurrent event, the changes pending before it are dropped
+	current *types.TipSet
+	revert  []*types.TipSet
+	apply   []*types.TipSet
+}
+
+// add merges the changes into the backlog and returns the number of events cancelled out.
+func (b *headChangeBacklog) add(changes []*types.HeadChange) int {
+	before := b.len() + len(changes)
+	var revert, apply []*types.TipSet
+	for _, hc := range changes {
+		switch hc.Type {
+		case types.HCCurrent:
+			// the head replaces the changes leading to it
+			b.current, b.revert, b.apply = hc.Val, nil, nil
+			revert, apply = nil, nil
+		case types.HCRevert:
+			revert = append(revert, hc.Val)
+		case types.HCApply:
+			apply = append(apply, hc.Val)
+		}
+	}
+
+	b.revert, b.apply = coalesceHeadChange(b.revert, b.apply, revert, apply)
+
+	return before - b.len()
+}
+
+func (b *headChangeBacklog) len() int {
+	n := len(b.revert) + len(b.apply)
+	if b.current != nil {
+		n++
+	}
+	return n
+}
+
+// changes returns the summarized change set, the current head comes first and reverts are
+// always delivered before applys.
+func (b *headChangeBacklog) changes() []*types.HeadChange {
+	out := make([]*types.HeadChange, 0, b.len())
+	if b.current != nil {
+		out = append(out, &types.HeadChange{Type: types.HCCurrent, Val: b.current})
+	}
+	for _, ts := range b.revert {
+		out = append(out, &types.HeadChange{Type: types.HCRevert, Val: ts})
+	}
+	for _, ts := range b.apply {
+		out = append(out, &types.HeadChange{Type: types.HCApply, Val: ts})
+	}
+
+	return out
+}
+
+func (b *headChangeBacklog) reset() {
+	b.current = nil
+	b.revert = nil
+	b.apply = nil
+}
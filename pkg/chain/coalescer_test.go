@@ -141,3 +141,68 @@ func TestHeadChangeCoalescer(t *testing.T) {
 		t.Fatalf("expected to revert tABC")
 	}
 }
+
+func TestHeadChangeBacklog(t *testing.T) {
+	tf.UnitTest(t)
+
+	b0 := mkBlock(nil, 0, 0)
+	root := mkTipSet(b0)
+	bA := mkBlock(root, 1, 1)
+	tA := mkTipSet(bA)
+	bB := mkBlock(root, 1, 2)
+	tB := mkTipSet(bB)
+	tAB := mkTipSet(bA, bB)
+
+	var backlog headChangeBacklog
+
+	coalesced := backlog.add([]*types.HeadChange{{Type: types.HCApply, Val: tA}})
+	if coalesced != 0 || backlog.len() != 1 {
+		t.Fatalf("expected single pending apply, got %d pending and %d coalesced", backlog.len(), coalesced)
+	}
+
+	// reverting tA cancels out the pending apply
+	coalesced = backlog.add([]*types.HeadChange{
+		{Type: types.HCRevert, Val: tA},
+		{Type: types.HCApply, Val: tB},
+	})
+	if coalesced != 2 {
+		t.Fatalf("expected 2 coalesced events but got %d", coalesced)
+	}
+
+	backlog.add([]*types.HeadChange{
+		{Type: types.HCRevert, Val: tB},
+		{Type: types.HCApply, Val: tAB},
+	})
+
+	changes := backlog.changes()
+	if len(changes) != 1 {
+		t.Fatalf("expected single change but got %d", len(changes))
+	}
+	if changes[0].Type != types.HCApply || changes[0].Val != tAB {
+		t.Fatalf("expected to apply tAB")
+	}
+
+	// the current head replaces the pending changes, the following ones are delivered after it
+	coalesced = backlog.add([]*types.HeadChange{{Type: types.HCCurrent, Val: tA}})
+	if coalesced != 1 || backlog.len() != 1 {
+		t.Fatalf("expected the current head only, got %d pending and %d coalesced", backlog.len(), coalesced)
+	}
+	backlog.add([]*types.HeadChange{
+		{Type: types.HCRevert, Val: tA},
+		{Type: types.HCApply, Val: tB},
+	})
+	changes = backlog.changes()
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes but got %d", len(changes))
+	}
+	for i, want := range []*types.HeadChange{{Type: types.HCCurrent, Val: tA}, {Type: types.HCRevert, Val: tA}, {Type: types.HCApply, Val: tB}} {
+		if changes[i].Type != want.Type || changes[i].Val != want.Val {
+			t.Fatalf("expected change %d to be %s %s, got %s %s", i, want.Type, want.Val.Key(), changes[i].Type, changes[i].Val.Key())
+		}
+	}
+
+	backlog.reset()
+	if backlog.len() != 0 {
+		t.Fatalf("expected empty backlog after reset")
+	}
+}
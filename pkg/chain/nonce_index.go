@@ -0,0 +1,206 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs-force-community/metrics"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// DefaultNonceIndexEpochs is the number of epochs below the head kept in the nonce index
+const DefaultNonceIndexEpochs = abi.ChainEpoch(20)
+
+// ErrReplayedNonce is returned for a message using a nonce already applied by another message of the same sender
+var ErrReplayedNonce = errors.New("nonce already applied by another message")
+
+var replayedMsgRejectedCnt = metrics.NewCounter("chain/replayed_message_rejected", "The number of messages rejected for replaying an applied nonce")
+
+type nonceKey struct {
+	from  address.Address
+	nonce uint64
+}
+
+type nonceEntry struct {
+	msg   cid.Cid
+	epoch abi.ChainEpoch
+}
+
+// NonceIndex indexes the (from, nonce) of the messages applied in the recent tipsets of the canonical chain, so
+// that the message pool drops the messages replaying an applied nonce before their signature and state checks.
+// A message is applied when its receipt shows it went past the sender checks, the messages included but skipped
+// for their nonce or rejected for their sender are not indexed. The index is only a filter of the message pool,
+// the consensus checks of the nonces are done by the state transition.
+// The sender is indexed as written in the message, a sender using both its id and key address is not matched.
+type NonceIndex struct {
+	lk      sync.RWMutex
+	keep    abi.ChainEpoch
+	head    abi.ChainEpoch
+	entries map[nonceKey]nonceEntry
+	// keys applied by each indexed tipset, used to revert and prune them
+	tipsets map[types.TipSetKey][]nonceKey
+	heights map[types.TipSetKey]abi.ChainEpoch
+}
+
+// NewNonceIndex creates an index keeping the messages of the last keep epochs
+func NewNonceIndex(keep abi.ChainEpoch) *NonceIndex {
+	if keep <= 0 {
+		keep = DefaultNonceIndexEpochs
+	}
+	return &NonceIndex{
+		keep:    keep,
+		entries: make(map[nonceKey]nonceEntry),
+		tipsets: make(map[types.TipSetKey][]nonceKey),
+		heights: make(map[types.TipSetKey]abi.ChainEpoch),
+	}
+}
+
+// applied tells whether the message of the receipt was applied, the messages failing the sender checks are not
+// and do not use their nonce
+func applied(receipt *types.MessageReceipt) bool {
+	return receipt.ExitCode != exitcode.SysErrSenderInvalid && receipt.ExitCode != exitcode.SysErrSenderStateInvalid
+}
+
+// Apply indexes the messages executed by a tipset added to the canonical chain, that is the messages of its parent
+// as returned by MessageStore.MessagesForTipset, with their receipts from its ParentMessageReceipts
+func (ni *NonceIndex) Apply(ts *types.TipSet, msgs []types.ChainMsg, receipts []types.MessageReceipt) error {
+	if len(msgs) != len(receipts) {
+		return fmt.Errorf("tipset %s has %d receipts for %d messages", ts.Key(), len(receipts), len(msgs))
+	}
+
+	ni.lk.Lock()
+	defer ni.lk.Unlock()
+
+	if _, ok := ni.tipsets[ts.Key()]; ok {
+		return nil
+	}
+
+	keys := make([]nonceKey, 0, len(msgs))
+	for i, msg := range msgs {
+		if !applied(&receipts[i]) {
+			continue
+		}
+		m := msg.VMMessage()
+		key := nonceKey{from: m.From, nonce: m.Nonce}
+		if _, ok := ni.entries[key]; ok {
+			continue
+		}
+		ni.entries[key] = nonceEntry{msg: msg.Cid(), epoch: ts.Height()}
+		keys = append(keys, key)
+	}
+	ni.tipsets[ts.Key()] = keys
+	ni.heights[ts.Key()] = ts.Height()
+
+	if ts.Height() > ni.head {
+		ni.head = ts.Height()
+	}
+	ni.prune()
+	return nil
+}
+
+// Revert removes the messages of a tipset reverted from the canonical chain
+func (ni *NonceIndex) Revert(ts *types.TipSet) {
+	ni.lk.Lock()
+	defer ni.lk.Unlock()
+
+	ni.remove(ts.Key())
+
+	ni.head = 0
+	for _, h := range ni.heights {
+		if h > ni.head {
+			ni.head = h
+		}
+	}
+}
+
+// CheckReplay returns ErrReplayedNonce when another message than msg of the same sender and nonce was applied
+func (ni *NonceIndex) CheckReplay(ctx context.Context, from address.Address, nonce uint64, msg cid.Cid) error {
+	ni.lk.RLock()
+	defer ni.lk.RUnlock()
+
+	entry, ok := ni.entries[nonceKey{from: from, nonce: nonce}]
+	if !ok || entry.msg.Equals(msg) {
+		return nil
+	}
+
+	replayedMsgRejectedCnt.Tick(ctx)
+	return fmt.Errorf("message %s from %s nonce %d: %w by %s at %d", msg, from, nonce, ErrReplayedNonce, entry.msg, entry.epoch)
+}
+
+// HeadChange returns the notifee keeping the index in sync with the head of the chain
+func (ni *NonceIndex) HeadChange(store *Store, ms *MessageStore) ReorgNotifee {
+	return func(rev, app []*types.TipSet) error {
+		for _, ts := range rev {
+			ni.Revert(ts)
+		}
+		// app is ordered from the new head down
+		for i := len(app) - 1; i >= 0; i-- {
+			if err := ni.load(context.TODO(), store, ms, app[i]); err != nil {
+				log.Warnf("failed to index the messages of %s: %v", app[i].Key(), err)
+			}
+		}
+		return nil
+	}
+}
+
+// Load indexes the tipsets of the last epochs below head, used to fill the index at start
+func (ni *NonceIndex) Load(ctx context.Context, store *Store, ms *MessageStore, head *types.TipSet) error {
+	var tss []*types.TipSet
+	for ts := head; ts != nil && ts.Height() > 0 && head.Height()-ts.Height() < ni.keep; {
+		tss = append(tss, ts)
+		parent, err := store.GetTipSet(ctx, ts.Parents())
+		if err != nil {
+			return err
+		}
+		ts = parent
+	}
+	for i := len(tss) - 1; i >= 0; i-- {
+		if err := ni.load(ctx, store, ms, tss[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load indexes the messages executed by ts
+func (ni *NonceIndex) load(ctx context.Context, store *Store, ms *MessageStore, ts *types.TipSet) error {
+	if ts.Height() == 0 {
+		return nil
+	}
+	parent, err := store.GetTipSet(ctx, ts.Parents())
+	if err != nil {
+		return err
+	}
+	msgs, err := ms.MessagesForTipset(parent)
+	if err != nil {
+		return err
+	}
+	receipts, err := ms.LoadReceipts(ctx, ts.Blocks()[0].ParentMessageReceipts)
+	if err != nil {
+		return err
+	}
+	return ni.Apply(ts, msgs, receipts)
+}
+
+func (ni *NonceIndex) remove(tsk types.TipSetKey) {
+	for _, key := range ni.tipsets[tsk] {
+		delete(ni.entries, key)
+	}
+	delete(ni.tipsets, tsk)
+	delete(ni.heights, tsk)
+}
+
+func (ni *NonceIndex) prune() {
+	for tsk, h := range ni.heights {
+		if ni.head-h >= ni.keep {
+			ni.remove(tsk)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package chain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestNonceIndex(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	addrs := testhelpers.NewForTestGetter()
+	from, to := addrs(), addrs()
+	mkMsg := func(nonce uint64, value int64) *types.Message {
+		return &types.Message{From: from, To: to, Nonce: nonce, Value: big.NewInt(value)}
+	}
+
+	ok := types.MessageReceipt{ExitCode: exitcode.Ok}
+	failed := types.MessageReceipt{ExitCode: exitcode.ErrInsufficientFunds}
+	skipped := types.MessageReceipt{ExitCode: exitcode.SysErrSenderStateInvalid}
+
+	index := chain.NewNonceIndex(3)
+	ts1 := testhelpers.RequireTipsetWithHeight(t, 1)
+	ts2 := testhelpers.RequireTipsetWithHeight(t, 2)
+	applied := mkMsg(0, 1)
+	require.NoError(t, index.Apply(ts1, []types.ChainMsg{applied}, []types.MessageReceipt{ok}))
+	// a message failing in its actor still uses its nonce, one failing the sender checks does not
+	unapplied := mkMsg(2, 1)
+	require.NoError(t, index.Apply(ts2, []types.ChainMsg{mkMsg(1, 1), unapplied}, []types.MessageReceipt{failed, skipped}))
+	require.Error(t, index.Apply(testhelpers.RequireTipsetWithHeight(t, 3), []types.ChainMsg{mkMsg(3, 1)}, nil))
+
+	replay := mkMsg(0, 2)
+	require.NoError(t, index.CheckReplay(ctx, from, 0, applied.Cid()))
+	err := index.CheckReplay(ctx, from, 0, replay.Cid())
+	require.True(t, errors.Is(err, chain.ErrReplayedNonce))
+	require.Error(t, index.CheckReplay(ctx, from, 1, mkMsg(1, 2).Cid()))
+	// the nonce of the message not applied is free for another one
+	require.NoError(t, index.CheckReplay(ctx, from, 2, mkMsg(2, 2).Cid()))
+
+	// a reverted nonce can be used again
+	index.Revert(ts2)
+	require.NoError(t, index.CheckReplay(ctx, from, 1, mkMsg(1, 2).Cid()))
+
+	// the old epochs are pruned
+	require.NoError(t, index.Apply(testhelpers.RequireTipsetWithHeight(t, abi.ChainEpoch(4)), nil, nil))
+	require.NoError(t, index.CheckReplay(ctx, from, 0, replay.Cid()))
+}
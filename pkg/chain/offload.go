@@ -0,0 +1,362 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/multiformats/go-multihash"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const (
+	// DefaultOffloadInterval is the default number of epochs between two offloads
+	DefaultOffloadInterval = constants.Finality
+	// offloadWindow is the number of epochs offloaded at once, the offloaded height is saved after each window
+	offloadWindow = abi.ChainEpoch(2880)
+	// minOffloadSize is the size below which the objects stay local, they cost little to keep and are often shared
+	// with the recent tipsets, as the empty message and receipt collections are
+	minOffloadSize = 128
+)
+
+// OffloadHeightKey is the key of the offloaded height in the metadata datastore
+var OffloadHeightKey = datastore.NewKey("/chain/offload/height")
+
+// ChainOffloader moves the chain data of the canonical tipsets deeper than depth below the head, that is their block
+// headers, messages and receipts, to the object storage of the offload blockstore, which reads them back
+// transparently. The state trees stay in the local blockstore, as their nodes are shared with the recent states.
+//
+// The tipsets are offloaded in increasing heights, the height reached is saved so that a restart resumes from it.
+// The data restored below that height stays local.
+type ChainOffloader struct {
+	store    *Store
+	bs       *blockstoreutil.OffloadBlockstore
+	ds       datastore.Datastore
+	depth    abi.ChainEpoch
+	interval abi.ChainEpoch
+
+	trigger chan struct{}
+
+	lk     sync.Mutex
+	status types.ChainOffloadStatus
+}
+
+// NewChainOffloader creates an offloader keeping the depth epochs below the head local, depth can not be less than
+// the finality. The offloaded height is saved in ds.
+func NewChainOffloader(ctx context.Context, store *Store, bs *blockstoreutil.OffloadBlockstore, ds datastore.Datastore,
+	depth, interval abi.ChainEpoch,
+) (*ChainOffloader, error) {
+	if depth < constants.Finality {
+		log.Warnf("chain offload depth %d is less than the finality, using %d", depth, constants.Finality)
+		depth = constants.Finality
+	}
+	return newChainOffloader(ctx, store, bs, ds, depth, interval)
+}
+
+func newChainOffloader(ctx context.Context, store *Store, bs *blockstoreutil.OffloadBlockstore, ds datastore.Datastore,
+	depth, interval abi.ChainEpoch,
+) (*ChainOffloader, error) {
+	if interval <= 0 {
+		interval = DefaultOffloadInterval
+	}
+	o := &ChainOffloader{
+		store:    store,
+		bs:       bs,
+		ds:       ds,
+		depth:    depth,
+		interval: interval,
+		trigger:  make(chan struct{}, 1),
+		status:   types.ChainOffloadStatus{Depth: depth},
+	}
+
+	data, err := ds.Get(ctx, OffloadHeightKey)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &o.status.OffloadedHeight); err != nil {
+			return nil, fmt.Errorf("decode the offloaded height: %w", err)
+		}
+	case err != datastore.ErrNotFound:
+		return nil, fmt.Errorf("load the offloaded height: %w", err)
+	}
+	return o, nil
+}
+
+// Start offloads the chain data in the background every interval epochs
+func (o *ChainOffloader) Start(ctx context.Context) {
+	o.lk.Lock()
+	o.status.Enabled = true
+	o.lk.Unlock()
+
+	o.store.SubscribeHeadChanges(func(_, app []*types.TipSet) error {
+		if len(app) == 0 {
+			return nil
+		}
+		o.lk.Lock()
+		due := app[0].Height()-o.depth >= o.status.OffloadedHeight+o.interval
+		o.lk.Unlock()
+		if due {
+			select {
+			case o.trigger <- struct{}{}:
+			default:
+			}
+		}
+		return nil
+	})
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-o.trigger:
+				if _, err := o.Offload(ctx); err != nil && ctx.Err() == nil {
+					log.Errorf("failed to offload the chain data: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Status returns the state of the offloader and the result of its last offload
+func (o *ChainOffloader) Status() types.ChainOffloadStatus {
+	o.lk.Lock()
+	defer o.lk.Unlock()
+	status := o.status
+	status.ColdReads = o.bs.ColdReads()
+	return status
+}
+
+// begin marks the offloader running, it fails when it already is
+func (o *ChainOffloader) begin() error {
+	o.lk.Lock()
+	defer o.lk.Unlock()
+	if o.status.Running {
+		return fmt.Errorf("chain offload is already running")
+	}
+	o.status.Running = true
+	return nil
+}
+
+// Offload moves the chain data of the tipsets above the offloaded height and deeper than depth below the head
+func (o *ChainOffloader) Offload(ctx context.Context) (types.ChainOffloadStats, error) {
+	if err := o.begin(); err != nil {
+		return types.ChainOffloadStats{}, err
+	}
+
+	stats, err := o.offload(ctx, o.store.GetHead())
+
+	o.lk.Lock()
+	defer o.lk.Unlock()
+	o.status.Running = false
+	o.status.LastRun = time.Now()
+	o.status.LastError = ""
+	if err != nil {
+		o.status.LastError = err.Error()
+	}
+	o.status.Last = stats
+	o.status.Total.Tipsets += stats.Tipsets
+	o.status.Total.Objects += stats.Objects
+	o.status.Total.Bytes += stats.Bytes
+	return stats, err
+}
+
+func (o *ChainOffloader) offload(ctx context.Context, head *types.TipSet) (types.ChainOffloadStats, error) {
+	var stats types.ChainOffloadStats
+	bound := head.Height() - o.depth
+	o.lk.Lock()
+	from := o.status.OffloadedHeight + 1
+	o.lk.Unlock()
+	// the genesis is read at every start
+	if from < 1 {
+		from = 1
+	}
+
+	for lo := from; lo <= bound; lo += offloadWindow {
+		hi := lo + offloadWindow - 1
+		if hi > bound {
+			hi = bound
+		}
+		tipsets, cids, err := o.collect(ctx, head, lo, hi, o.bs.Hot().Get)
+		if err != nil {
+			return stats, err
+		}
+		moved, err := o.bs.Offload(ctx, cids)
+		stats.Objects += moved.Blocks
+		stats.Bytes += moved.Bytes
+		if err != nil {
+			return stats, err
+		}
+		stats.Tipsets += tipsets
+
+		if err := o.saveHeight(ctx, hi); err != nil {
+			return stats, err
+		}
+	}
+	if stats.Tipsets > 0 {
+		log.Infof("chain offload up to %d: %d tipsets, %d objects, %d bytes", bound, stats.Tipsets, stats.Objects, stats.Bytes)
+	}
+	return stats, nil
+}
+
+func (o *ChainOffloader) saveHeight(ctx context.Context, height abi.ChainEpoch) error {
+	data, err := json.Marshal(height)
+	if err != nil {
+		return err
+	}
+	if err := o.ds.Put(ctx, OffloadHeightKey, data); err != nil {
+		return fmt.Errorf("save the offloaded height: %w", err)
+	}
+	o.lk.Lock()
+	o.status.OffloadedHeight = height
+	o.lk.Unlock()
+	return nil
+}
+
+// Restore copies the chain data of the canonical tipsets between the heights from and to, included, back to the
+// local blockstore
+func (o *ChainOffloader) Restore(ctx context.Context, from, to abi.ChainEpoch) (types.ChainOffloadStats, error) {
+	var stats types.ChainOffloadStats
+	if from > to {
+		return stats, fmt.Errorf("invalid range [%d, %d]", from, to)
+	}
+	if err := o.begin(); err != nil {
+		return stats, err
+	}
+	defer func() {
+		o.lk.Lock()
+		o.status.Running = false
+		o.lk.Unlock()
+	}()
+
+	hot := o.bs.Hot()
+	head := o.store.GetHead()
+	if to > head.Height() {
+		to = head.Height()
+	}
+	for lo := from; lo <= to; lo += offloadWindow {
+		hi := lo + offloadWindow - 1
+		if hi > to {
+			hi = to
+		}
+		var restored []blocks.Block
+		// read the objects missing locally from the object storage, and keep them
+		get := func(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+			blk, err := hot.Get(ctx, c)
+			if !ipld.IsNotFound(err) {
+				return blk, err
+			}
+			if blk, err = o.bs.GetCold(ctx, c); err == nil {
+				restored = append(restored, blk)
+			}
+			return blk, err
+		}
+		tipsets, cids, err := o.collect(ctx, head, lo, hi, get)
+		if err != nil {
+			return stats, err
+		}
+		if err := hot.PutMany(ctx, restored); err != nil {
+			return stats, fmt.Errorf("write the restored objects: %w", err)
+		}
+		stats.Tipsets += tipsets
+		stats.Objects += len(restored)
+		for _, blk := range restored {
+			stats.Bytes += int64(len(blk.RawData()))
+		}
+		// the block headers are read through by the store rather than by get
+		moved, err := o.bs.Restore(ctx, cids)
+		stats.Objects += moved.Blocks
+		stats.Bytes += moved.Bytes
+		if err != nil {
+			return stats, err
+		}
+	}
+	log.Infof("chain offload restored [%d, %d]: %d tipsets, %d objects, %d bytes", from, to, stats.Tipsets, stats.Objects, stats.Bytes)
+	return stats, nil
+}
+
+// collect returns the number of the canonical tipsets of the chain of head between lo and hi, included, and the
+// cids of their chain data large enough to be offloaded, loading the objects with get
+func (o *ChainOffloader) collect(ctx context.Context, head *types.TipSet, lo, hi abi.ChainEpoch,
+	get func(context.Context, cid.Cid) (blocks.Block, error),
+) (int, []cid.Cid, error) {
+	ts, err := o.store.GetTipSetByHeight(ctx, head, hi, true)
+	if err != nil {
+		return 0, nil, fmt.Errorf("load the tipset at %d: %w", hi, err)
+	}
+
+	var tipsets int
+	var out []cid.Cid
+	seen := cid.NewSet()
+	for ts.Height() >= lo {
+		if err := ctx.Err(); err != nil {
+			return 0, nil, err
+		}
+		tipsets++
+		for _, blk := range ts.Blocks() {
+			if seen.Visit(blk.Cid()) {
+				out = append(out, blk.Cid())
+			}
+			if err := collectLinks(ctx, blk.Messages, get, seen, &out); err != nil {
+				return 0, nil, fmt.Errorf("collect the messages of block %s: %w", blk.Cid(), err)
+			}
+			if err := collectLinks(ctx, blk.ParentMessageReceipts, get, seen, &out); err != nil {
+				return 0, nil, fmt.Errorf("collect the receipts of block %s: %w", blk.Cid(), err)
+			}
+		}
+		if ts.Height() == 0 {
+			break
+		}
+		parent, err := o.store.GetTipSet(ctx, ts.Parents())
+		if err != nil {
+			return 0, nil, fmt.Errorf("load the parent of the tipset at %d: %w", ts.Height(), err)
+		}
+		ts = parent
+	}
+	return tipsets, out, nil
+}
+
+// collectLinks appends the cids of the objects reachable from c which get finds, the objects missing are skipped
+// with their links
+func collectLinks(ctx context.Context, c cid.Cid, get func(context.Context, cid.Cid) (blocks.Block, error), seen *cid.Set, out *[]cid.Cid) error {
+	if !seen.Visit(c) || c.Prefix().MhType == multihash.IDENTITY {
+		return nil
+	}
+	blk, err := get(ctx, c)
+	if ipld.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(blk.RawData()) >= minOffloadSize {
+		*out = append(*out, c)
+	}
+	if c.Prefix().Codec != cid.DagCBOR {
+		return nil
+	}
+
+	var links []cid.Cid
+	if err := cbg.ScanForLinks(bytes.NewReader(blk.RawData()), func(link cid.Cid) {
+		links = append(links, link)
+	}); err != nil {
+		return err
+	}
+	for _, link := range links {
+		if err := collectLinks(ctx, link, get, seen, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
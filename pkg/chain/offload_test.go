@@ -0,0 +1,166 @@
+package chain
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/consensus/chainselector"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type memObjectStore struct {
+	lk      sync.Mutex
+	objects map[string][]byte
+}
+
+func (m *memObjectStore) PutObject(_ context.Context, key string, data []byte) error {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+	m.objects[key] = data
+	return nil
+}
+
+func (m *memObjectStore) GetObject(_ context.Context, key string) ([]byte, error) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, blockstoreutil.ErrObjectNotFound
+	}
+	return data, nil
+}
+
+func (m *memObjectStore) HasObject(_ context.Context, key string) (bool, error) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+	_, ok := m.objects[key]
+	return ok, nil
+}
+
+func (m *memObjectStore) DeleteObject(_ context.Context, key string) error {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+	delete(m.objects, key)
+	return nil
+}
+
+func (m *memObjectStore) ListObjects(_ context.Context, prefix string, fn func(key string) error) error {
+	m.lk.Lock()
+	var keys []string
+	for k := range m.objects {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	m.lk.Unlock()
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := fn(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestChainOffloader(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := NewBuilder(t, address.Undef)
+	hot := builder.BlockStore()
+
+	var main []*types.TipSet
+	head := builder.Genesis()
+	for i := 0; i < 12; i++ {
+		head = builder.AppendOn(ctx, head, 1)
+		main = append(main, head)
+	}
+
+	cold := &memObjectStore{objects: map[string][]byte{}}
+	bs, err := blockstoreutil.NewOffloadBlockstore(ctx, hot, cold, datastore.NewMapDatastore(), false)
+	require.NoError(t, err)
+	store := NewStore(builder.Repo().ChainDatastore(), bs, builder.Genesis().At(0).Cid(), NewMockCirculatingSupplyCalculator(), chainselector.Weight)
+	require.NoError(t, store.SetHead(ctx, head))
+
+	requireLocal := func(ts *types.TipSet, local bool) {
+		for _, c := range ts.Cids() {
+			found, err := hot.Has(ctx, c)
+			require.NoError(t, err)
+			require.Equal(t, local, found, ts.Height())
+			// always found through the offload blockstore
+			found, err = bs.Has(ctx, c)
+			require.NoError(t, err)
+			require.True(t, found, ts.Height())
+		}
+	}
+
+	ds := datastore.NewMapDatastore()
+	offloader, err := newChainOffloader(ctx, store, bs, ds, 4, 1)
+	require.NoError(t, err)
+	stats, err := offloader.Offload(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 8, stats.Tipsets)
+	assert.Equal(t, len(cold.objects), stats.Objects)
+	assert.Equal(t, abi.ChainEpoch(8), offloader.Status().OffloadedHeight)
+
+	requireLocal(builder.Genesis(), true)
+	for _, ts := range main[:8] {
+		requireLocal(ts, false)
+	}
+	for _, ts := range main[8:] {
+		requireLocal(ts, true)
+	}
+
+	// the offloaded height is kept across restarts
+	offloader, err = newChainOffloader(ctx, store, bs, ds, 4, 1)
+	require.NoError(t, err)
+	stats, err = offloader.Offload(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Tipsets)
+
+	stats, err = offloader.Restore(ctx, 1, 8)
+	require.NoError(t, err)
+	assert.Equal(t, 8, stats.Tipsets)
+	for _, ts := range main[:8] {
+		requireLocal(ts, true)
+	}
+
+	_, err = offloader.Restore(ctx, 8, 1)
+	assert.Error(t, err)
+}
+
+func TestCollectLinks(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := NewBuilder(t, address.Undef)
+	bs := builder.BlockStore()
+
+	addrs := testhelpers.NewForTestGetter()
+	large := &types.Message{From: addrs(), To: addrs(), Method: 2, Params: make([]byte, 256)}
+	small := &types.Message{From: addrs(), To: addrs()}
+	meta, err := builder.Mstore().StoreMessages(ctx, nil, []*types.Message{large, small})
+	require.NoError(t, err)
+
+	var out []cid.Cid
+	require.NoError(t, collectLinks(ctx, meta, bs.Get, cid.NewSet(), &out))
+	// the small objects, such as the empty collection of the secp messages, stay local
+	assert.Contains(t, out, large.Cid())
+	assert.NotContains(t, out, small.Cid())
+	for _, c := range out {
+		blk, err := bs.Get(ctx, c)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, len(blk.RawData()), minOffloadSize)
+	}
+}
@@ -0,0 +1,303 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// DefaultOrphanGCInterval is the default number of epochs between two collections of the orphaned tipsets
+const DefaultOrphanGCInterval = abi.ChainEpoch(120)
+
+// OrphanGC deletes the side-chain tipsets left by the reorgs, once they are deeper than depth below the head.
+//
+// The orphaned tipsets are found from the states the node computed, so side-chain blocks which were never
+// executed are not collected. For each of them the GC deletes its state entry in the chain datastore, the
+// headers of its blocks and the root of its state when they are not the ones of the canonical tipset at the
+// same height. The state tree nodes shared with the canonical states are kept, as are the messages which may
+// be included by the canonical chain.
+type OrphanGC struct {
+	store    *Store
+	depth    abi.ChainEpoch
+	interval abi.ChainEpoch
+	dryRun   bool
+
+	trigger chan struct{}
+	// collected is the height the last collection deleted the orphans up to, the next one only looks at the tipsets
+	// above it. It is only accessed by the running collection.
+	collected abi.ChainEpoch
+
+	lk     sync.Mutex
+	status types.ChainGCStatus
+}
+
+// NewOrphanGC creates an orphan GC keeping the depth epochs below the head, depth can not be less than the
+// fork length threshold as deeper reorgs are refused
+func NewOrphanGC(store *Store, depth, interval abi.ChainEpoch, dryRun bool) *OrphanGC {
+	if depth < constants.ForkLengthThreshold {
+		log.Warnf("chain gc depth %d is less than the fork length threshold, using %d", depth, constants.ForkLengthThreshold)
+		depth = constants.ForkLengthThreshold
+	}
+	return newOrphanGC(store, depth, interval, dryRun)
+}
+
+func newOrphanGC(store *Store, depth, interval abi.ChainEpoch, dryRun bool) *OrphanGC {
+	if interval <= 0 {
+		interval = DefaultOrphanGCInterval
+	}
+	return &OrphanGC{
+		store:    store,
+		depth:    depth,
+		interval: interval,
+		dryRun:   dryRun,
+		trigger:  make(chan struct{}, 1),
+		status: types.ChainGCStatus{
+			DryRun: dryRun,
+			Depth:  depth,
+		},
+	}
+}
+
+// Start collects the orphaned tipsets in the background every interval epochs
+func (gc *OrphanGC) Start(ctx context.Context) {
+	gc.lk.Lock()
+	gc.status.Enabled = true
+	gc.lk.Unlock()
+
+	gc.store.SubscribeHeadChanges(func(_, app []*types.TipSet) error {
+		if len(app) == 0 {
+			return nil
+		}
+		gc.lk.Lock()
+		due := app[0].Height() >= gc.status.LastHead+gc.interval
+		gc.lk.Unlock()
+		if due {
+			select {
+			case gc.trigger <- struct{}{}:
+			default:
+			}
+		}
+		return nil
+	})
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-gc.trigger:
+				if _, err := gc.Collect(ctx); err != nil {
+					log.Errorf("failed to collect the orphaned tipsets: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Status returns the state of the GC and the result of its last collection
+func (gc *OrphanGC) Status() types.ChainGCStatus {
+	gc.lk.Lock()
+	defer gc.lk.Unlock()
+	return gc.status
+}
+
+// Collect finds the orphaned tipsets deeper than depth below the head and deletes them, unless in dry-run
+func (gc *OrphanGC) Collect(ctx context.Context) (types.ChainGCStats, error) {
+	gc.lk.Lock()
+	if gc.status.Running {
+		gc.lk.Unlock()
+		return types.ChainGCStats{}, fmt.Errorf("chain gc is already running")
+	}
+	gc.status.Running = true
+	gc.lk.Unlock()
+
+	head := gc.store.GetHead()
+	stats, err := gc.collect(ctx, head)
+
+	gc.lk.Lock()
+	defer gc.lk.Unlock()
+	gc.status.Running = false
+	gc.status.LastRun = time.Now()
+	gc.status.LastHead = head.Height()
+	gc.status.LastError = ""
+	if err != nil {
+		gc.status.LastError = err.Error()
+	}
+	gc.status.Last = stats
+	gc.status.Total.Tipsets += stats.Tipsets
+	gc.status.Total.Blocks += stats.Blocks
+	gc.status.Total.StateRoots += stats.StateRoots
+	gc.status.Total.Bytes += stats.Bytes
+	return stats, err
+}
+
+func (gc *OrphanGC) collect(ctx context.Context, head *types.TipSet) (types.ChainGCStats, error) {
+	var stats types.ChainGCStats
+	bound := head.Height() - gc.depth
+	if bound <= 0 {
+		return stats, nil
+	}
+
+	res, err := gc.store.ds.Query(ctx, query.Query{KeysOnly: true})
+	if err != nil {
+		return stats, fmt.Errorf("query tipset states: %w", err)
+	}
+	type orphan struct {
+		tsk    types.TipSetKey
+		height abi.ChainEpoch
+	}
+	var orphans []orphan
+	canonicals := make(map[abi.ChainEpoch]*types.TipSet)
+	for entry := range res.Next() {
+		if entry.Error != nil {
+			_ = res.Close()
+			return stats, fmt.Errorf("query tipset states: %w", entry.Error)
+		}
+		tsk, height, ok := parseTipSetMetadataKey(entry.Key)
+		if !ok || height <= gc.collected || height > bound {
+			continue
+		}
+		canonical, err := gc.canonical(ctx, canonicals, head, height)
+		if err != nil {
+			_ = res.Close()
+			return stats, err
+		}
+		if canonical.Key() != tsk {
+			orphans = append(orphans, orphan{tsk: tsk, height: height})
+		}
+	}
+	if err := res.Close(); err != nil {
+		return stats, err
+	}
+
+	var toDelete []cid.Cid
+	seen := cid.NewSet()
+	collect := func(c cid.Cid) bool {
+		if !seen.Visit(c) {
+			return false
+		}
+		size, err := gc.store.bsstore.GetSize(ctx, c)
+		if err != nil {
+			// already deleted
+			return false
+		}
+		stats.Bytes += int64(size)
+		toDelete = append(toDelete, c)
+		return true
+	}
+
+	for _, o := range orphans {
+		canonical := canonicals[o.height]
+
+		ts, err := gc.store.GetTipSet(ctx, o.tsk)
+		if err != nil {
+			// the blocks are gone, only the state entry is left
+			log.Debugf("orphaned tipset %s at %d not found: %v", o.tsk, o.height, err)
+			if !gc.dryRun {
+				if err := gc.store.ds.Delete(ctx, datastore.NewKey(makeKey(o.tsk.String(), o.height))); err != nil {
+					return stats, err
+				}
+			}
+			stats.Tipsets++
+			continue
+		}
+
+		var canonicalRoot cid.Cid
+		canonicalBlocks := cid.NewSet()
+		if canonical.Height() == o.height {
+			if canonicalRoot, err = gc.store.GetTipSetStateRoot(ctx, canonical); err != nil {
+				return stats, fmt.Errorf("load state of canonical tipset %s: %w", canonical.Key(), err)
+			}
+			for _, c := range canonical.Cids() {
+				canonicalBlocks.Add(c)
+			}
+		}
+
+		stats.Tipsets++
+		for _, c := range ts.Cids() {
+			if !canonicalBlocks.Has(c) && collect(c) {
+				stats.Blocks++
+			}
+		}
+		meta, err := gc.store.LoadTipsetMetadata(ctx, ts)
+		if err == nil && meta.TipSetStateRoot != canonicalRoot && collect(meta.TipSetStateRoot) {
+			stats.StateRoots++
+		}
+
+		if gc.dryRun {
+			continue
+		}
+		// drop the state entry first, so that no entry points to deleted objects
+		if err := gc.store.DeleteTipSetMetadata(ctx, ts); err != nil {
+			return stats, fmt.Errorf("delete state of tipset %s: %w", o.tsk, err)
+		}
+		gc.store.tsCache.Remove(o.tsk)
+	}
+
+	if !gc.dryRun {
+		if len(toDelete) > 0 {
+			if err := gc.store.bsstore.DeleteMany(ctx, toDelete); err != nil {
+				return stats, fmt.Errorf("delete orphaned objects: %w", err)
+			}
+		}
+		// the reorgs deeper than the depth are refused, no orphan is added below the bound afterwards
+		gc.collected = bound
+	}
+	if stats.Tipsets > 0 {
+		log.Infof("chain gc below %d, dry-run %v: %d orphaned tipsets, %d blocks, %d state roots, %d bytes",
+			bound, gc.dryRun, stats.Tipsets, stats.Blocks, stats.StateRoots, stats.Bytes)
+	}
+	return stats, nil
+}
+
+// canonical returns the tipset of the chain of head at height, or the one after it when the height is a null round
+func (gc *OrphanGC) canonical(ctx context.Context, cache map[abi.ChainEpoch]*types.TipSet, head *types.TipSet, height abi.ChainEpoch) (*types.TipSet, error) {
+	if ts, ok := cache[height]; ok {
+		return ts, nil
+	}
+	ts, err := gc.store.GetTipSetByHeight(ctx, head, height, false)
+	if err != nil {
+		return nil, fmt.Errorf("load canonical tipset at %d: %w", height, err)
+	}
+	cache[height] = ts
+	return ts, nil
+}
+
+// parseTipSetMetadataKey parses the key a tipset state is written at by writeTipSetMetadata
+func parseTipSetMetadataKey(key string) (types.TipSetKey, abi.ChainEpoch, bool) {
+	rest, ok := strings.CutPrefix(key, "/p-{")
+	if !ok {
+		return types.EmptyTSK, 0, false
+	}
+	idx := strings.LastIndex(rest, "} h-")
+	if idx < 0 {
+		return types.EmptyTSK, 0, false
+	}
+	height, err := strconv.ParseInt(rest[idx+len("} h-"):], 10, 64)
+	if err != nil {
+		return types.EmptyTSK, 0, false
+	}
+	var cids []cid.Cid
+	for _, s := range strings.Fields(rest[:idx]) {
+		c, err := cid.Decode(s)
+		if err != nil {
+			return types.EmptyTSK, 0, false
+		}
+		cids = append(cids, c)
+	}
+	if len(cids) == 0 {
+		return types.EmptyTSK, 0, false
+	}
+	return types.NewTipSetKey(cids...), abi.ChainEpoch(height), true
+}
@@ -0,0 +1,127 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestParseTipSetMetadataKey(t *testing.T) {
+	tf.UnitTest(t)
+
+	tsk := types.NewTipSetKey(testhelpers.CidFromString(t, "a"), testhelpers.CidFromString(t, "b"))
+	key := datastore.NewKey(makeKey(tsk.String(), 12)).String()
+
+	parsed, height, ok := parseTipSetMetadataKey(key)
+	require.True(t, ok)
+	require.Equal(t, tsk, parsed)
+	require.Equal(t, abi.ChainEpoch(12), height)
+
+	for _, key := range []string{HeadKey.String(), "/p-{ } h-1", "/p-{ x } h-1", "/p-{ " + tsk.Cids()[0].String() + " } h-x"} {
+		_, _, ok := parseTipSetMetadataKey(key)
+		require.False(t, ok, key)
+	}
+}
+
+func TestOrphanGC(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := NewBuilder(t, address.Undef)
+	store := builder.Store()
+	bs := builder.BlockStore()
+
+	putState := func(ts *types.TipSet) {
+		root, err := builder.Cstore().Put(ctx, fmt.Sprintf("state of %s", ts.Key()))
+		require.NoError(t, err)
+		require.NoError(t, store.PutTipSetMetadata(ctx, &TipSetMetadata{
+			TipSet:          ts,
+			TipSetStateRoot: root,
+			TipSetReceipts:  testhelpers.EmptyReceiptsCID,
+		}))
+	}
+
+	var main []*types.TipSet
+	head := builder.Genesis()
+	for i := 0; i < 10; i++ {
+		head = builder.AppendOn(ctx, head, 1)
+		putState(head)
+		main = append(main, head)
+	}
+	// an old fork of two tipsets, and a recent one
+	oldFork := builder.AppendOn(ctx, main[1], 2)
+	putState(oldFork)
+	oldForkChild := builder.AppendOn(ctx, oldFork, 1)
+	putState(oldForkChild)
+	recentFork := builder.AppendOn(ctx, main[7], 1)
+	putState(recentFork)
+	require.NoError(t, store.SetHead(ctx, head))
+
+	requireHas := func(ts *types.TipSet, has bool) {
+		for _, c := range ts.Cids() {
+			found, err := bs.Has(ctx, c)
+			require.NoError(t, err)
+			require.Equal(t, has, found, ts.Key())
+		}
+		_, err := store.LoadTipsetMetadata(ctx, ts)
+		require.Equal(t, has, err == nil, ts.Key())
+	}
+
+	// heads of height 10, the tipsets up to 5 are collected
+	dryRun := newOrphanGC(store, 5, 1, true)
+	stats, err := dryRun.Collect(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.ChainGCStats{Tipsets: 2, Blocks: 3, StateRoots: 2, Bytes: stats.Bytes}, stats)
+	require.Greater(t, stats.Bytes, int64(0))
+	requireHas(oldFork, true)
+	requireHas(oldForkChild, true)
+
+	gc := newOrphanGC(store, 5, 1, false)
+	stats2, err := gc.Collect(ctx)
+	require.NoError(t, err)
+	require.Equal(t, stats, stats2)
+	requireHas(oldFork, false)
+	requireHas(oldForkChild, false)
+	requireHas(recentFork, true)
+	for _, ts := range main {
+		requireHas(ts, true)
+		root, err := store.GetTipSetStateRoot(ctx, ts)
+		require.NoError(t, err)
+		found, err := bs.Has(ctx, root)
+		require.NoError(t, err)
+		require.True(t, found)
+	}
+
+	stats, err = gc.Collect(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.ChainGCStats{}, stats)
+
+	status := gc.Status()
+	require.False(t, status.Running)
+	require.Equal(t, abi.ChainEpoch(10), status.LastHead)
+	require.Equal(t, stats2, status.Total)
+	require.Empty(t, status.LastError)
+
+	// the next collections only look at the heights above the last one
+	for i := 0; i < 4; i++ {
+		head = builder.AppendOn(ctx, head, 1)
+		putState(head)
+	}
+	require.NoError(t, store.SetHead(ctx, head))
+	// a state computed below the collected height afterwards is not looked at
+	belowCollected := builder.AppendOn(ctx, main[2], 2)
+	putState(belowCollected)
+	stats, err = gc.Collect(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.Tipsets)
+	requireHas(recentFork, false)
+	requireHas(belowCollected, true)
+}
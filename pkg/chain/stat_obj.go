@@ -0,0 +1,72 @@
+package chain
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ipfs/boxo/blockservice"
+	offline "github.com/ipfs/boxo/exchange/offline"
+	"github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// StatObj walks the dag reachable from obj and counts its blocks and bytes, the blocks reachable from base are
+// not counted. Links deeper than maxDepth, obj being at depth 0, are not followed, maxDepth 0 is unlimited.
+func StatObj(ctx context.Context, bs blockstoreutil.Blockstore, obj, base cid.Cid, maxDepth uint64) (types.ObjStatWithDepth, error) {
+	dag := merkledag.NewDAGService(blockservice.New(bs, offline.Exchange(bs)))
+
+	seen := cid.NewSet()
+
+	var statslk sync.Mutex
+	var stats types.ObjStatWithDepth
+	collect := true
+
+	walker := func(ctx context.Context, c cid.Cid) ([]*ipld.Link, error) {
+		if c.Prefix().Codec == cid.FilCommitmentSealed || c.Prefix().Codec == cid.FilCommitmentUnsealed {
+			return []*ipld.Link{}, nil
+		}
+
+		nd, err := dag.Get(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+
+		if collect {
+			s := uint64(len(nd.RawData()))
+			statslk.Lock()
+			stats.Size = stats.Size + s
+			stats.Links = stats.Links + 1
+			statslk.Unlock()
+		}
+
+		return nd.Links(), nil
+	}
+
+	visit := func(c cid.Cid, depth int) bool {
+		if maxDepth > 0 && uint64(depth) > maxDepth {
+			statslk.Lock()
+			stats.Truncated = true
+			statslk.Unlock()
+			return false
+		}
+		return seen.Visit(c)
+	}
+
+	if base != cid.Undef {
+		collect = false
+		if err := merkledag.Walk(ctx, walker, base, seen.Visit, merkledag.Concurrent()); err != nil {
+			return types.ObjStatWithDepth{}, err
+		}
+		collect = true
+	}
+
+	if err := merkledag.WalkDepth(ctx, walker, obj, visit, merkledag.Concurrent()); err != nil {
+		return types.ObjStatWithDepth{}, err
+	}
+
+	return stats, nil
+}
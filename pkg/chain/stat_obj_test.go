@@ -0,0 +1,50 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+)
+
+func TestStatObj(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	bs := blockstoreutil.NewMemory()
+
+	put := func(obj interface{}) (cid.Cid, uint64) {
+		nd, err := cbor.WrapObject(obj, mh.SHA2_256, -1)
+		require.NoError(t, err)
+		require.NoError(t, bs.Put(ctx, nd))
+		return nd.Cid(), uint64(len(nd.RawData()))
+	}
+
+	leaf1, leaf1Size := put("leaf1")
+	leaf2, leaf2Size := put("leaf2")
+	mid, midSize := put(map[string]interface{}{"leaf": leaf1})
+	root, rootSize := put(map[string]interface{}{"mid": mid, "leaf": leaf2})
+
+	stats, err := StatObj(ctx, bs, root, cid.Undef, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), stats.Links)
+	require.Equal(t, rootSize+midSize+leaf1Size+leaf2Size, stats.Size)
+	require.False(t, stats.Truncated)
+
+	stats, err = StatObj(ctx, bs, root, cid.Undef, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), stats.Links)
+	require.Equal(t, rootSize+midSize+leaf2Size, stats.Size)
+	require.True(t, stats.Truncated)
+
+	// the blocks reachable from the base are not counted
+	stats, err = StatObj(ctx, bs, root, mid, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), stats.Links)
+	require.Equal(t, rootSize+leaf2Size, stats.Size)
+}
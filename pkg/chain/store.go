@@ -33,6 +33,7 @@ import (
 	"github.com/filecoin-project/go-state-types/network"
 	"github.com/filecoin-project/pubsub"
 	blockadt "github.com/filecoin-project/specs-actors/actors/util/adt"
+	"github.com/ipfs-force-community/metrics"
 
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/metrics/tracing"
@@ -65,6 +66,15 @@ var HeadKey = datastore.NewKey("/chain/heaviestTipSet")
 
 var ErrNotifeeDone = errors.New("notifee is done and should be removed")
 
+// maxHeadChangeBacklog is the number of coalesced head changes kept for a slow subscriber,
+// the subscription is closed once its backlog grows beyond it.
+var maxHeadChangeBacklog = 2 * int(constants.Finality)
+
+var (
+	headChangeCoalescedCnt  = metrics.NewCounter("chain/head_change_coalesced", "The number of head changes coalesced into the backlog of slow subscribers")
+	headChangeDroppedSubCnt = metrics.NewCounter("chain/head_change_dropped_subscription", "The number of head change subscriptions closed due to slow readers")
+)
+
 type loadTipSetFunc func(context.Context, types.TipSetKey) (*types.TipSet, error)
 
 // ReorgNotifee represents a callback that gets called upon reorgs.
@@ -356,6 +366,35 @@ func (store *Store) GetTipSet(ctx context.Context, key types.TipSetKey) (*types.
 	return ts, nil
 }
 
+// SelectTipSet resolves the selector to the tipset it selects, the result is not cached as it moves with the head
+func (store *Store) SelectTipSet(ctx context.Context, tss types.TipSetSelector) (*types.TipSet, error) {
+	if err := tss.Validate(); err != nil {
+		return nil, err
+	}
+	switch {
+	case tss.Key != nil:
+		return store.GetTipSet(ctx, *tss.Key)
+	case tss.Height != nil:
+		anchor := store.GetHead()
+		if tss.Height.Anchor != nil {
+			var err error
+			if anchor, err = store.GetTipSet(ctx, *tss.Height.Anchor); err != nil {
+				return nil, fmt.Errorf("loading anchor %s: %w", tss.Height.Anchor, err)
+			}
+		}
+		return store.GetTipSetByHeight(ctx, anchor, tss.Height.At, tss.Height.Previous)
+	case *tss.Tag == types.TipSetTagFinalized:
+		head := store.GetHead()
+		h := head.Height() - policy.ChainFinality
+		if h < 0 {
+			h = 0
+		}
+		return store.GetTipSetByHeight(ctx, head, h, true)
+	default:
+		return store.GetHead(), nil
+	}
+}
+
 // GetTipSetByHeight looks back for a tipset at the specified epoch.
 // In the case that the given height is a null round, the 'prev' flag
 // selects the tipset before the null round if true, and the tipset following
@@ -731,7 +770,16 @@ func (store *Store) SubHeadChanges(ctx context.Context) chan []*types.HeadChange
 			}
 		}()
 
+		var backlog headChangeBacklog
 		for {
+			// only try to deliver the backlog when there is something pending
+			var pendingCh chan<- []*types.HeadChange
+			var pending []*types.HeadChange
+			if backlog.len() > 0 {
+				pendingCh = out
+				pending = backlog.changes()
+			}
+
 			select {
 			case val, ok := <-subCh:
 				if !ok {
@@ -739,15 +787,30 @@ func (store *Store) SubHeadChanges(ctx context.Context) chan []*types.HeadChange
 					return
 				}
 
-				select {
-				case out <- val.([]*types.HeadChange):
-				default:
-					log.Errorf("closing head change subscription due to slow reader")
-					return
+				changes := val.([]*types.HeadChange)
+				if backlog.len() == 0 {
+					select {
+					case out <- changes:
+						if len(out) > 5 {
+							log.Warnf("head change sub is slow, has %d buffered entries", len(out))
+						}
+						continue
+					default:
+					}
+				}
+
+				// the reader falls behind, keep a summarized change set instead of disconnecting it
+				// the counter view aggregates by count, record every change cancelled out
+				for coalesced := backlog.add(changes); coalesced > 0; coalesced-- {
+					headChangeCoalescedCnt.Tick(ctx)
 				}
-				if len(out) > 5 {
-					log.Warnf("head change sub is slow, has %d buffered entries", len(out))
+				if backlog.len() > maxHeadChangeBacklog {
+					log.Errorf("closing head change subscription due to slow reader, %d changes pending", backlog.len())
+					headChangeDroppedSubCnt.Tick(ctx)
+					return
 				}
+			case pendingCh <- pending:
+				backlog.reset()
 			case <-ctx.Done():
 				log.Infof("exit sub head change: %v", ctx.Err())
 				return
@@ -1525,6 +1588,11 @@ func (store *Store) Weight(ctx context.Context, ts *types.TipSet) (big.Int, erro
 	return store.weight(ctx, store.stateAndBlockSource, ts)
 }
 
+// SetWeightFunc replaces the function the fork choice weighs the tipsets with, it is set before the chain is synced
+func (store *Store) SetWeightFunc(weight WeightFunc) {
+	store.weight = weight
+}
+
 func (store *Store) AddToTipSetTracker(ctx context.Context, b *types.BlockHeader) error {
 	store.tstLk.Lock()
 	defer store.tstLk.Unlock()
@@ -97,6 +97,49 @@ func TestPutTipSet(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSelectTipSet(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	genTS := builder.Genesis()
+	r := repo.NewInMemoryRepo()
+	cs := newChainStore(r, genTS)
+
+	link1 := builder.AppendOn(ctx, genTS, 2)
+	link2 := builder.AppendOn(ctx, link1, 3)
+	link3 := builder.AppendOn(ctx, link2, 1)
+	link4 := builder.BuildOn(ctx, link3, 2, func(bb *chain.BlockBuilder, i int) { bb.IncHeight(2) })
+	requirePutTestChain(ctx, t, cs, link4.Key(), builder, 5)
+	require.NoError(t, cs.SetHead(ctx, link4))
+
+	link2Key := link2.Key()
+	for _, tc := range []struct {
+		tss    types.TipSetSelector
+		expect *types.TipSet
+	}{
+		{types.SelectTipSetByKey(link1.Key()), link1},
+		{types.SelectTipSetByTag(types.TipSetTagLatest), link4},
+		// the head is less than a finality above the genesis
+		{types.SelectTipSetByTag(types.TipSetTagFinalized), genTS},
+		{types.SelectTipSetByHeight(3, false, nil), link3},
+		// null rounds
+		{types.SelectTipSetByHeight(4, true, nil), link3},
+		{types.SelectTipSetByHeight(4, false, nil), link4},
+		{types.SelectTipSetByHeight(1, false, &link2Key), link1},
+	} {
+		ts, err := cs.SelectTipSet(ctx, tc.tss)
+		require.NoError(t, err)
+		require.Equal(t, tc.expect.Key(), ts.Key())
+	}
+
+	// above the anchor
+	_, err := cs.SelectTipSet(ctx, types.SelectTipSetByHeight(3, false, &link2Key))
+	require.Error(t, err)
+	_, err = cs.SelectTipSet(ctx, types.TipSetSelector{})
+	require.Error(t, err)
+}
+
 // Tipsets can be retrieved by key (all block cids).
 func TestGetByKey(t *testing.T) {
 	tf.UnitTest(t)
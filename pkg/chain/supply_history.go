@@ -0,0 +1,132 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const (
+	// DefaultSupplyCacheSize is the number of tipsets whose supply is kept in memory
+	DefaultSupplyCacheSize = 2048
+	// MaxSupplyHistoryPoints bounds the points returned by a single history query
+	MaxSupplyHistoryPoints = 2000
+)
+
+// SupplyHistoryPrefix is the prefix of the supplies persisted in the metadata datastore
+var SupplyHistoryPrefix = datastore.NewKey("/supply/history")
+
+// SupplyFunc computes the supply at a tipset
+type SupplyFunc func(ctx context.Context, ts *types.TipSet) (types.CirculatingSupply, error)
+
+// SupplyHistory keeps the circulating supply and the burnt funds of the tipsets already computed, so that a series
+// of epochs is not computed again for each query. The supplies are also written to the datastore when one is given,
+// which keeps them across restarts.
+type SupplyHistory struct {
+	compute SupplyFunc
+	cache   *lru.Cache[types.TipSetKey, types.CirculatingSupply]
+	ds      datastore.Batching
+}
+
+// NewSupplyHistory creates a history computing the supplies with compute, ds may be nil to keep them in memory only
+func NewSupplyHistory(compute SupplyFunc, ds datastore.Batching) *SupplyHistory {
+	cache, _ := lru.New[types.TipSetKey, types.CirculatingSupply](DefaultSupplyCacheSize)
+	sh := &SupplyHistory{compute: compute, cache: cache}
+	if ds != nil {
+		sh.ds = namespace.Wrap(ds, SupplyHistoryPrefix)
+	}
+	return sh
+}
+
+func supplyKey(tsk types.TipSetKey) (datastore.Key, error) {
+	c, err := tsk.Cid()
+	if err != nil {
+		return datastore.Key{}, err
+	}
+	return datastore.NewKey(c.String()), nil
+}
+
+// Supply returns the supply at the tipset
+func (sh *SupplyHistory) Supply(ctx context.Context, ts *types.TipSet) (types.CirculatingSupply, error) {
+	if cs, ok := sh.cache.Get(ts.Key()); ok {
+		return cs, nil
+	}
+
+	var key datastore.Key
+	if sh.ds != nil {
+		var err error
+		if key, err = supplyKey(ts.Key()); err != nil {
+			return types.CirculatingSupply{}, err
+		}
+		data, err := sh.ds.Get(ctx, key)
+		if err == nil {
+			var cs types.CirculatingSupply
+			if err := json.Unmarshal(data, &cs); err == nil {
+				sh.cache.Add(ts.Key(), cs)
+				return cs, nil
+			}
+			log.Warnf("discard the invalid supply persisted for %s", ts.Key())
+		} else if err != datastore.ErrNotFound {
+			return types.CirculatingSupply{}, err
+		}
+	}
+
+	cs, err := sh.compute(ctx, ts)
+	if err != nil {
+		return types.CirculatingSupply{}, err
+	}
+	sh.cache.Add(ts.Key(), cs)
+
+	if sh.ds != nil {
+		data, err := json.Marshal(cs)
+		if err != nil {
+			return types.CirculatingSupply{}, err
+		}
+		if err := sh.ds.Put(ctx, key, data); err != nil {
+			log.Warnf("persist the supply of %s: %v", ts.Key(), err)
+		}
+	}
+	return cs, nil
+}
+
+// History returns the supply every step epochs from from to to, on the chain of head. A null round is reported at
+// the tipset before it, which is only reported once.
+func (sh *SupplyHistory) History(ctx context.Context, store *Store, head *types.TipSet, from, to, step abi.ChainEpoch) ([]*types.SupplyPoint, error) {
+	if step <= 0 {
+		step = 1
+	}
+	if to > head.Height() {
+		to = head.Height()
+	}
+	if from < 0 || from > to {
+		return nil, fmt.Errorf("invalid epoch range [%d, %d] for the head at %d", from, to, head.Height())
+	}
+	if n := (to-from)/step + 1; n > MaxSupplyHistoryPoints {
+		return nil, fmt.Errorf("%d points requested, at most %d are returned at once", n, MaxSupplyHistoryPoints)
+	}
+
+	var points []*types.SupplyPoint
+	for h := from; h <= to; h += step {
+		ts, err := store.GetTipSetByHeight(ctx, head, h, true)
+		if err != nil {
+			return nil, fmt.Errorf("load tipset at %d: %w", h, err)
+		}
+		if len(points) > 0 && points[len(points)-1].TipSet == ts.Key() {
+			continue
+		}
+
+		cs, err := sh.Supply(ctx, ts)
+		if err != nil {
+			return nil, fmt.Errorf("supply at %d: %w", ts.Height(), err)
+		}
+		points = append(points, &types.SupplyPoint{Epoch: ts.Height(), TipSet: ts.Key(), CirculatingSupply: cs})
+	}
+	return points, nil
+}
@@ -0,0 +1,78 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestSupplyHistory(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := NewBuilder(t, address.Undef)
+	store := builder.Store()
+
+	head := builder.Genesis()
+	for i := 0; i < 6; i++ {
+		head = builder.AppendOn(ctx, head, 1)
+	}
+	// a null round at 7
+	head = builder.BuildOneOn(ctx, head, func(b *BlockBuilder) {
+		b.IncHeight(1)
+	})
+	head = builder.AppendOn(ctx, head, 1)
+	require.Equal(t, abi.ChainEpoch(9), head.Height())
+
+	computed := map[abi.ChainEpoch]int{}
+	compute := func(ctx context.Context, ts *types.TipSet) (types.CirculatingSupply, error) {
+		computed[ts.Height()]++
+		return types.CirculatingSupply{
+			FilVested:           big.NewInt(int64(ts.Height())),
+			FilMined:            big.Zero(),
+			FilBurnt:            big.NewInt(int64(ts.Height()) * 10),
+			FilLocked:           big.Zero(),
+			FilCirculating:      big.Zero(),
+			FilReserveDisbursed: big.Zero(),
+		}, nil
+	}
+
+	ds := datastore.NewMapDatastore()
+	history := NewSupplyHistory(compute, ds)
+	points, err := history.History(ctx, store, head, 2, 20, 2)
+	require.NoError(t, err)
+
+	var epochs []abi.ChainEpoch
+	for _, p := range points {
+		epochs = append(epochs, p.Epoch)
+		require.Equal(t, big.NewInt(int64(p.Epoch)*10), p.FilBurnt)
+	}
+	require.Equal(t, []abi.ChainEpoch{2, 4, 6, 8}, epochs)
+
+	// the null round at 7 is reported at 6, once
+	points, err = history.History(ctx, store, head, 6, 7, 1)
+	require.NoError(t, err)
+	require.Len(t, points, 1)
+	require.Equal(t, abi.ChainEpoch(6), points[0].Epoch)
+	require.Equal(t, 1, computed[6])
+
+	// the persisted supplies are not computed again
+	history = NewSupplyHistory(compute, ds)
+	_, err = history.History(ctx, store, head, 2, 9, 2)
+	require.NoError(t, err)
+	for _, h := range []abi.ChainEpoch{2, 4, 6, 8} {
+		require.Equal(t, 1, computed[h], h)
+	}
+
+	_, err = history.History(ctx, store, head, 5, 3, 1)
+	require.Error(t, err)
+	_, err = history.History(ctx, store, head, 0, 9, 0)
+	require.NoError(t, err)
+}
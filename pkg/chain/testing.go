@@ -726,7 +726,8 @@ func (e *FakeStateEvaluator) RunStateTransition(ctx context.Context, ts *types.T
 	return rootCid, receiptCid, nil
 }
 
-func (e *FakeStateEvaluator) ValidateFullBlock(ctx context.Context, blk *types.BlockHeader) error {
+// ValidateBlockHeader runs the state transition of the parent of the block
+func (e *FakeStateEvaluator) ValidateBlockHeader(ctx context.Context, blk *types.BlockHeader) error {
 	parent, err := e.ChainStore.GetTipSet(ctx, types.NewTipSetKey(blk.Parents...))
 	if err != nil {
 		return err
@@ -744,6 +745,21 @@ func (e *FakeStateEvaluator) ValidateFullBlock(ctx context.Context, blk *types.B
 	})
 }
 
+// ValidateMsgMeta accepts all messages
+func (e *FakeStateEvaluator) ValidateMsgMeta(_ context.Context, _ *types.FullBlock) error {
+	return nil
+}
+
+// IsEpochBeyondCurrMax accepts all epochs
+func (e *FakeStateEvaluator) IsEpochBeyondCurrMax(_ context.Context, _ abi.ChainEpoch) bool {
+	return false
+}
+
+// Weight returns the expected consensus weight of the tipset
+func (e *FakeStateEvaluator) Weight(ctx context.Context, ts *types.TipSet) (big.Int, error) {
+	return chainselector.Weight(ctx, e.ChainStore.stateAndBlockSource, ts)
+}
+
 // /// Chain selector /////
 
 // FakeChainSelector is a syncChainSelector that delegates to the FakeStateBuilder
@@ -33,30 +33,37 @@ var _ = (BlockProposer)((*dispatcher.Dispatcher)(nil))
 // Manager sync the chain.
 type Manager struct {
 	dispatcher *dispatcher.Dispatcher
+	badTipSets *types.BadTipSetCache
 }
 
-// NewManager creates a new chain sync manager.
+// NewManager creates a new chain sync manager, which syncs the chain by the rules of the consensus. badTipSets may
+// be nil to keep the bad tipsets in memory.
 func NewManager(
 	stmgr *statemanger.Stmgr,
-	hv *consensus.BlockValidator,
+	cons consensus.Consensus,
 	submodule *chain2.ChainSubmodule,
 	bsstore blockstoreutil.Blockstore,
 	exchangeClient exchange.Client,
 	c clock.Clock,
 	fork fork.IFork,
+	badTipSets *types.BadTipSetCache,
 ) (Manager, error) {
-	chainSyncer, err := syncer.NewSyncer(stmgr, hv, submodule.ChainReader,
+	chainSyncer, err := syncer.NewSyncer(stmgr, cons, submodule.ChainReader,
 		submodule.MessageStore, bsstore,
 		exchangeClient, c, fork)
 	if err != nil {
 		return Manager{}, err
 	}
+	if badTipSets != nil {
+		chainSyncer.SetBadTipSetCache(badTipSets)
+	}
 
 	return Manager{
+		badTipSets: chainSyncer.BadTipSets(),
 		dispatcher: dispatcher.NewDispatcher(struct {
 			*syncer.Syncer
-			*consensus.BlockValidator
-		}{Syncer: chainSyncer, BlockValidator: hv}, submodule.ChainReader),
+			consensus.Consensus
+		}{Syncer: chainSyncer, Consensus: cons}, submodule.ChainReader),
 	}, nil
 }
 
@@ -66,6 +73,16 @@ func (m *Manager) Start(ctx context.Context) error {
 	return nil
 }
 
+// SetForkTracker sets the tracker of the heads received competing with the local chain.
+func (m *Manager) SetForkTracker(forks *types.ForkTracker) {
+	m.dispatcher.SetForkTracker(forks)
+}
+
+// BadTipSets returns the cache of the tipsets the syncer refuses.
+func (m *Manager) BadTipSets() *types.BadTipSetCache {
+	return m.badTipSets
+}
+
 // BlockProposer returns the block proposer.
 func (m *Manager) BlockProposer() BlockProposer {
 	return m.dispatcher
@@ -0,0 +1,164 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var log = logging.Logger("chainsync.checkpoint")
+
+// maxResponseSize bounds the body read from an endpoint, a checkpoint is a few hundred bytes
+const maxResponseSize = 1 << 20
+
+// ErrNoCheckpoint is returned when the endpoints do not agree on a checkpoint signed by a trusted signer
+var ErrNoCheckpoint = errors.New("no trusted checkpoint")
+
+// Fetch requests the checkpoint of every endpoint of cfg, and returns the highest one served by Threshold endpoints
+// and signed by a trusted signer. The endpoints failing or serving an untrusted checkpoint are skipped.
+func Fetch(ctx context.Context, cfg *config.CheckpointConfig) (*types.SignedCheckpoint, error) {
+	if cfg == nil || len(cfg.Endpoints) == 0 {
+		return nil, fmt.Errorf("%w: no endpoints are configured", ErrNoCheckpoint)
+	}
+	if len(cfg.TrustedSigners) == 0 {
+		return nil, fmt.Errorf("%w: no trusted signers are configured", ErrNoCheckpoint)
+	}
+	trusted := make(map[address.Address]struct{}, len(cfg.TrustedSigners))
+	for _, signer := range cfg.TrustedSigners {
+		trusted[signer] = struct{}{}
+	}
+	threshold := cfg.Threshold
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	client := &http.Client{Timeout: time.Duration(cfg.Timeout)}
+	type vote struct {
+		cp        *types.SignedCheckpoint
+		endpoints map[string]struct{}
+	}
+	votes := map[string]*vote{}
+	for _, endpoint := range cfg.Endpoints {
+		cp, err := fetchOne(ctx, client, endpoint)
+		if err == nil {
+			err = verify(cp, trusted)
+		}
+		if err != nil {
+			log.Warnf("skip the checkpoint of %s: %v", endpoint, err)
+			continue
+		}
+		// the endpoints agree on the tipset and its state, the signers and urls of the snapshots may differ
+		key := fmt.Sprintf("%s/%s", cp.TipSetKey, cp.StateRoot)
+		v, ok := votes[key]
+		if !ok {
+			v = &vote{cp: cp, endpoints: map[string]struct{}{}}
+			votes[key] = v
+		}
+		v.endpoints[endpoint] = struct{}{}
+	}
+
+	var best *types.SignedCheckpoint
+	for _, v := range votes {
+		if len(v.endpoints) < threshold {
+			continue
+		}
+		if best == nil || v.cp.Height > best.Height {
+			best = v.cp
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("%w: %d endpoints must serve the same checkpoint", ErrNoCheckpoint, threshold)
+	}
+	return best, nil
+}
+
+func fetchOne(ctx context.Context, client *http.Client, endpoint string) (*types.SignedCheckpoint, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	var cp types.SignedCheckpoint
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&cp); err != nil {
+		return nil, fmt.Errorf("decode the checkpoint: %w", err)
+	}
+	return &cp, nil
+}
+
+func verify(cp *types.SignedCheckpoint, trusted map[address.Address]struct{}) error {
+	if _, ok := trusted[cp.Signer]; !ok {
+		return fmt.Errorf("signer %s is not trusted", cp.Signer)
+	}
+	if cp.TipSetKey.IsEmpty() || !cp.StateRoot.Defined() {
+		return fmt.Errorf("the checkpoint misses its tipset or state root")
+	}
+	if cp.Snapshot == "" {
+		return fmt.Errorf("the checkpoint has no snapshot")
+	}
+	data, err := cp.Checkpoint.SigningBytes()
+	if err != nil {
+		return err
+	}
+	if err := crypto.Verify(&cp.Signature, cp.Signer, data); err != nil {
+		return fmt.Errorf("invalid signature of %s: %w", cp.Signer, err)
+	}
+	return nil
+}
+
+// Apply sets the tipset of cp as the checkpoint of store, once the snapshot of cp is imported. The tipset must be on
+// the imported chain with the state root of cp. The head is left at the head of the snapshot, the syncer then syncs
+// from it and refuses the chains forking before the checkpoint.
+func Apply(ctx context.Context, store *chain.Store, cp *types.Checkpoint) error {
+	ts, err := store.GetTipSet(ctx, cp.TipSetKey)
+	if err != nil {
+		return fmt.Errorf("the snapshot misses the checkpoint tipset %s: %w", cp.TipSetKey, err)
+	}
+	if ts.Height() != cp.Height {
+		return fmt.Errorf("the checkpoint tipset %s is at height %d, not %d", cp.TipSetKey, ts.Height(), cp.Height)
+	}
+	head := store.GetHead()
+	if head.Height() < ts.Height() {
+		return fmt.Errorf("the snapshot head %s at %d is below the checkpoint tipset %s", head.Key(), head.Height(), cp.TipSetKey)
+	}
+	at, err := store.GetTipSetByHeight(ctx, head, ts.Height(), false)
+	if err != nil {
+		return fmt.Errorf("look up the tipset at the checkpoint height: %w", err)
+	}
+	if !at.Key().Equals(ts.Key()) {
+		return fmt.Errorf("the checkpoint tipset %s is not on the chain of the snapshot, which has %s at %d", cp.TipSetKey, at.Key(), ts.Height())
+	}
+	root, err := store.GetTipSetStateRoot(ctx, ts)
+	if err != nil {
+		return fmt.Errorf("the snapshot misses the state of the checkpoint tipset %s: %w", cp.TipSetKey, err)
+	}
+	if !root.Equals(cp.StateRoot) {
+		return fmt.Errorf("the state root of the checkpoint tipset %s is %s, not %s", cp.TipSetKey, root, cp.StateRoot)
+	}
+
+	if err := store.WriteCheckPoint(ctx, ts.Key()); err != nil {
+		return fmt.Errorf("write the checkpoint: %w", err)
+	}
+	store.SetCheckPoint(ts.Key())
+	log.Infof("bootstrapped the chain from the checkpoint %s at %d, the head is %s at %d", ts.Key(), ts.Height(), head.Key(), head.Height())
+	return nil
+}
@@ -0,0 +1,163 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/crypto"
+	_ "github.com/filecoin-project/venus/pkg/crypto/secp"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/testutil"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type signer struct {
+	addr address.Address
+	key  []byte
+}
+
+func newSigner(t *testing.T) signer {
+	key, err := crypto.Generate(crypto.SigTypeSecp256k1)
+	require.NoError(t, err)
+	pub, err := crypto.ToPublic(crypto.SigTypeSecp256k1, key)
+	require.NoError(t, err)
+	addr, err := address.NewSecp256k1Address(pub)
+	require.NoError(t, err)
+	return signer{addr: addr, key: key}
+}
+
+func (s signer) sign(t *testing.T, cp types.Checkpoint) *types.SignedCheckpoint {
+	data, err := cp.SigningBytes()
+	require.NoError(t, err)
+	sig, err := crypto.Sign(data, s.key, crypto.SigTypeSecp256k1)
+	require.NoError(t, err)
+	return &types.SignedCheckpoint{Checkpoint: cp, Signer: s.addr, Signature: *sig}
+}
+
+func newCheckpoint(t *testing.T, height int64) types.Checkpoint {
+	var c types.Checkpoint
+	var blocks []cid.Cid
+	testutil.Provide(t, &blocks, testutil.WithSliceLen(2))
+	testutil.Provide(t, &c.StateRoot)
+	c.TipSetKey = types.NewTipSetKey(blocks...)
+	c.Height = abi.ChainEpoch(height)
+	c.Snapshot = "https://snapshots.example/latest.car"
+	return c
+}
+
+func serve(t *testing.T, cp *types.SignedCheckpoint) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewEncoder(w).Encode(cp))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestFetch(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	trusted, untrusted := newSigner(t), newSigner(t)
+	older, newer := newCheckpoint(t, 100), newCheckpoint(t, 200)
+
+	cfg := func(threshold int, endpoints ...string) *config.CheckpointConfig {
+		cfg := config.NewDefaultConfig().Checkpoint
+		cfg.Endpoints = endpoints
+		cfg.TrustedSigners = []address.Address{trusted.addr}
+		cfg.Threshold = threshold
+		return cfg
+	}
+
+	t.Run("highest trusted checkpoint", func(t *testing.T) {
+		cp, err := Fetch(ctx, cfg(1, serve(t, trusted.sign(t, older)), serve(t, trusted.sign(t, newer))))
+		require.NoError(t, err)
+		assert.Equal(t, newer, cp.Checkpoint)
+		assert.Equal(t, trusted.addr, cp.Signer)
+	})
+
+	t.Run("untrusted and tampered checkpoints are skipped", func(t *testing.T) {
+		tampered := trusted.sign(t, older)
+		tampered.Height = newer.Height
+		cp, err := Fetch(ctx, cfg(1,
+			serve(t, untrusted.sign(t, newer)),
+			serve(t, tampered),
+			"http://127.0.0.1:0",
+			serve(t, trusted.sign(t, older)),
+		))
+		require.NoError(t, err)
+		assert.Equal(t, older, cp.Checkpoint)
+	})
+
+	t.Run("threshold", func(t *testing.T) {
+		endpoints := []string{
+			serve(t, trusted.sign(t, older)),
+			serve(t, trusted.sign(t, older)),
+			serve(t, trusted.sign(t, newer)),
+		}
+		cp, err := Fetch(ctx, cfg(2, endpoints...))
+		require.NoError(t, err)
+		assert.Equal(t, older, cp.Checkpoint)
+
+		_, err = Fetch(ctx, cfg(3, endpoints...))
+		assert.ErrorIs(t, err, ErrNoCheckpoint)
+	})
+
+	t.Run("no trusted signers", func(t *testing.T) {
+		c := cfg(1, serve(t, trusted.sign(t, older)))
+		c.TrustedSigners = nil
+		_, err := Fetch(ctx, c)
+		assert.ErrorIs(t, err, ErrNoCheckpoint)
+	})
+}
+
+func TestApply(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	store := builder.Store()
+	genesis := store.GetHead()
+
+	t1 := builder.AppendOn(ctx, genesis, 1)
+	t2 := builder.AppendOn(ctx, t1, 1)
+	fork := builder.AppendOn(ctx, genesis, 2)
+	require.NoError(t, store.SetHead(ctx, t2))
+
+	// the state of t1 is the parent state of t2, as the snapshot import records it
+	root := t2.At(0).ParentStateRoot
+	before := store.GetCheckPoint()
+	require.NoError(t, store.PutTipSetMetadata(ctx, &chain.TipSetMetadata{
+		TipSet:          t1,
+		TipSetStateRoot: root,
+		TipSetReceipts:  t2.At(0).ParentMessageReceipts,
+	}))
+
+	t.Run("off the imported chain", func(t *testing.T) {
+		cp := &types.Checkpoint{TipSetKey: fork.Key(), Height: fork.Height(), StateRoot: root}
+		assert.Error(t, Apply(ctx, store, cp))
+		assert.Equal(t, before, store.GetCheckPoint())
+	})
+
+	t.Run("wrong state root", func(t *testing.T) {
+		cp := &types.Checkpoint{TipSetKey: t1.Key(), Height: t1.Height(), StateRoot: t1.Key().Cids()[0]}
+		assert.Error(t, Apply(ctx, store, cp))
+		assert.Equal(t, before, store.GetCheckPoint())
+	})
+
+	t.Run("on the imported chain", func(t *testing.T) {
+		cp := &types.Checkpoint{TipSetKey: t1.Key(), Height: t1.Height(), StateRoot: root}
+		require.NoError(t, Apply(ctx, store, cp))
+		assert.Equal(t, t1.Key(), store.GetCheckPoint())
+		// the head stays at the head of the snapshot
+		assert.Equal(t, t2.Key(), store.GetHead().Key())
+	})
+}
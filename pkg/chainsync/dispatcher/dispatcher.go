@@ -52,6 +52,7 @@ func NewDispatcherWithSizes(syncer dispatchSyncer, chainStore *chain.Store, work
 		maxCount:        1,
 		incomingPubsub:  pubsub.New(50),
 		chainStore:      chainStore,
+		running:         make(map[*types.Target]context.CancelFunc),
 	}
 }
 
@@ -92,9 +93,18 @@ type Dispatcher struct {
 	lk              sync.Mutex
 	conCurrent      atomic.Int
 	maxCount        int64
+	// running are the targets in syncing with the cancel of their sync, guarded by lk
+	running map[*types.Target]context.CancelFunc
 
 	incomingPubsub *pubsub.PubSub
 	chainStore     *chain.Store
+	// forks tracks the heads received, nil unless the fork alarm is enabled
+	forks *types.ForkTracker
+}
+
+// SetForkTracker sets the tracker of the heads received competing with the local chain
+func (d *Dispatcher) SetForkTracker(forks *types.ForkTracker) {
+	d.forks = forks
 }
 
 // SyncTracker returnss the target tracker of syncing
@@ -124,6 +134,9 @@ func (d *Dispatcher) sendHead(ci *types2.ChainInfo) error {
 	}
 
 	d.incomingPubsub.Pub(fts.TipSet().Blocks(), LocalIncoming)
+	if d.forks != nil {
+		d.forks.AddHead(fts.TipSet(), ci.Sender)
+	}
 
 	return d.addTracker(ci)
 }
@@ -282,15 +295,22 @@ func (d *Dispatcher) syncWorker(ctx context.Context) {
 				lastTarget = syncTarget
 				if d.conCurrent.Get() < d.maxCount {
 					atmoic2.StoreInt64(&unsolvedNotify, 0)
-					syncTarget.State = types.StateInSyncing
+					syncTarget.Update(func(t *types.Target) { t.State = types.StateInSyncing })
 					ctx, cancel := context.WithCancel(ctx)
+					d.lk.Lock()
 					d.cancelControler.PushBack(cancel)
+					d.running[syncTarget] = cancel
+					d.lk.Unlock()
 					d.conCurrent.Add(1)
 					go func() {
 						err := d.syncer.HandleNewTipSet(ctx, syncTarget)
 						if err != nil {
 							log.Infof("failed sync of %v at %d  %s", syncTarget.Head.Key(), syncTarget.Head.Height(), err)
 						}
+						d.lk.Lock()
+						delete(d.running, syncTarget)
+						d.lk.Unlock()
+						cancel()
 						d.workTracker.Remove(syncTarget)
 						d.registeredCb(syncTarget, err)
 						d.conCurrent.Add(-1)
@@ -303,6 +323,7 @@ func (d *Dispatcher) syncWorker(ctx context.Context) {
 					}()
 				} else {
 					atmoic2.StoreInt64(&unsolvedNotify, 1)
+					d.preempt(syncTarget)
 				}
 			}
 		case <-ctx.Done():
@@ -315,6 +336,55 @@ func (d *Dispatcher) syncWorker(ctx context.Context) {
 	}
 }
 
+// preempt cancels the sync of the lightest target in syncing when the waiting target is heavier and on a fork of
+// it, so that the workers follow the heaviest candidate chain during a fork. A target the waiting one descends from
+// keeps syncing as its work is that of the waiting target.
+func (d *Dispatcher) preempt(waiting *types.Target) {
+	d.lk.Lock()
+	var lightest *types.Target
+	for t := range d.running {
+		if t.Preempted {
+			continue
+		}
+		if lightest == nil || t.Head.ParentWeight().LessThan(lightest.Head.ParentWeight()) {
+			lightest = t
+		}
+	}
+	d.lk.Unlock()
+	if lightest == nil || !waiting.Head.ParentWeight().GreaterThan(lightest.Head.ParentWeight()) {
+		return
+	}
+	// the ancestry is read from the store out of the lock
+	if !d.isFork(lightest.Head, waiting.Head) {
+		return
+	}
+
+	d.lk.Lock()
+	defer d.lk.Unlock()
+	cancel, ok := d.running[lightest]
+	if !ok || lightest.Preempted {
+		return
+	}
+	log.Infow("preempting the sync of a lighter target", "height", lightest.Head.Height(), "weight",
+		lightest.Head.ParentWeight(), "by height", waiting.Head.Height(), "by weight", waiting.Head.ParentWeight())
+	lightest.Update(func(t *types.Target) { t.Preempted = true })
+	cancel()
+}
+
+// isFork reports whether head is on a fork of target, walking the ancestors of head down to the height of target. A
+// head whose ancestry is not in the store may extend target, it is not a fork then.
+func (d *Dispatcher) isFork(target, head *types2.TipSet) bool {
+	ctx := context.TODO()
+	for head.Height() > target.Height() {
+		parent, err := d.chainStore.GetTipSet(ctx, head.Parents())
+		if err != nil {
+			return false
+		}
+		head = parent
+	}
+	return !head.Key().Equals(target.Key())
+}
+
 // RegisterCallback registers a callback on the dispatcher that
 // will fire after every successful target sync.
 func (d *Dispatcher) RegisterCallback(cb func(*types.Target, error)) {
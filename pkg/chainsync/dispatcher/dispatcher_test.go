@@ -96,6 +96,103 @@ func TestDispatchStartHappy(t *testing.T) {
 	}
 }
 
+// blockingSyncer syncs the targets lighter than heavy until their sync is canceled
+type blockingSyncer struct {
+	mockSyncer
+	heavy   fbig.Int
+	started chan struct{}
+}
+
+func (fs *blockingSyncer) HandleNewTipSet(ctx context.Context, ci *syncTypes.Target) error {
+	if ci.Head.ParentWeight().LessThan(fs.heavy) {
+		fs.started <- struct{}{}
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func TestDispatchPreemptsLighterTarget(t *testing.T) {
+	tf.UnitTest(t)
+	s := &blockingSyncer{heavy: fbig.NewInt(20), started: make(chan struct{}, 1)}
+	builder := chain.NewBuilder(t, address.Undef)
+
+	testDispatch := dispatcher.NewDispatcher(s, builder.Store())
+	testDispatch.SetConcurrent(1)
+	testDispatch.Start(context.Background())
+	time.Sleep(time.Millisecond * 100)
+
+	// the heavy head is at the height of the light one, on a fork of it
+	light := chainInfoWithHeightAndWeight(t, 10, 10)
+	heavy := chainInfoWithHeightAndWeight(t, 10, 20)
+
+	done := make(chan *syncTypes.Target, 2)
+	testDispatch.RegisterCallback(func(target *syncTypes.Target, _ error) {
+		done <- target
+	})
+
+	require.NoError(t, testDispatch.SendHello(light))
+	select {
+	case <-s.started:
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "the light target is not synced")
+	}
+	require.NoError(t, testDispatch.SendHello(heavy))
+
+	var synced []*syncTypes.Target
+	for len(synced) < 2 {
+		select {
+		case target := <-done:
+			synced = append(synced, target)
+		case <-time.After(time.Second * 5):
+			require.Fail(t, "the heavy target did not preempt the light one")
+		}
+	}
+	assert.True(t, synced[0].Head.Key().Equals(light.FullTipSet.TipSet().Key()))
+	assert.True(t, synced[0].Preempted)
+	assert.True(t, synced[1].Head.Key().Equals(heavy.FullTipSet.TipSet().Key()))
+	assert.False(t, synced[1].Preempted)
+}
+
+func TestDispatchKeepsExtendedTarget(t *testing.T) {
+	tf.UnitTest(t)
+	s := &blockingSyncer{heavy: fbig.NewInt(20), started: make(chan struct{}, 1)}
+	builder := chain.NewBuilder(t, address.Undef)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	testDispatch := dispatcher.NewDispatcher(s, builder.Store())
+	testDispatch.SetConcurrent(1)
+	testDispatch.Start(ctx)
+	time.Sleep(time.Millisecond * 100)
+
+	// the heavy head descends from the light one through a tipset in the store
+	light := chainInfoWithHeightAndWeight(t, 10, 10)
+	mid := chainInfoOn(t, light.FullTipSet.TipSet(), 15)
+	heavy := chainInfoOn(t, mid.FullTipSet.TipSet(), 20)
+	_, err := builder.Store().PutObject(ctx, mid.FullTipSet.Blocks[0].Header)
+	require.NoError(t, err)
+
+	done := make(chan *syncTypes.Target, 2)
+	testDispatch.RegisterCallback(func(target *syncTypes.Target, _ error) {
+		done <- target
+	})
+
+	require.NoError(t, testDispatch.SendHello(light))
+	select {
+	case <-s.started:
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "the light target is not synced")
+	}
+	require.NoError(t, testDispatch.SendHello(heavy))
+
+	select {
+	case target := <-done:
+		require.Failf(t, "the light target is preempted", "target %s", target.Head.Key())
+	case <-time.After(time.Millisecond * 500):
+	}
+}
+
 func TestQueueHappy(t *testing.T) {
 	tf.UnitTest(t)
 	testQ := syncTypes.NewTargetTracker(20)
@@ -201,3 +298,10 @@ func chainInfoWithHeightAndWeight(t *testing.T, h int, weight int64) *types.Chai
 		},
 	}
 }
+
+// chainInfoOn returns the chain info of a child of parent
+func chainInfoOn(t *testing.T, parent *types.TipSet, weight int64) *types.ChainInfo {
+	ci := chainInfoWithHeightAndWeight(t, int(parent.Height())+1, weight)
+	ci.FullTipSet.Blocks[0].Header.Parents = parent.Cids()
+	return ci
+}
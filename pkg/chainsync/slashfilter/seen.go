@@ -0,0 +1,72 @@
+package slashfilter
+
+import (
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// SeenBlocksEpochs is how many epochs below the highest block seen the blocks are kept by SeenBlocks
+const SeenBlocksEpochs = 20
+
+// SeenBlocks indexes the recent blocks seen by the node by miner and epoch, so that a block of a miner which already
+// has another block at the epoch is not submitted, whichever node produced the other block
+type SeenBlocks struct {
+	lk       sync.Mutex
+	byEpoch  map[abi.ChainEpoch]map[address.Address][]cid.Cid
+	maxEpoch abi.ChainEpoch
+}
+
+func NewSeenBlocks() *SeenBlocks {
+	return &SeenBlocks{byEpoch: make(map[abi.ChainEpoch]map[address.Address][]cid.Cid)}
+}
+
+// Add records the block, the blocks too far below the highest block seen are dropped
+func (s *SeenBlocks) Add(bh *types.BlockHeader) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if bh.Height > s.maxEpoch {
+		s.maxEpoch = bh.Height
+		for epoch := range s.byEpoch {
+			if epoch < s.maxEpoch-SeenBlocksEpochs {
+				delete(s.byEpoch, epoch)
+			}
+		}
+	}
+	if bh.Height < s.maxEpoch-SeenBlocksEpochs {
+		return
+	}
+
+	miners, ok := s.byEpoch[bh.Height]
+	if !ok {
+		miners = make(map[address.Address][]cid.Cid)
+		s.byEpoch[bh.Height] = miners
+	}
+	c := bh.Cid()
+	for _, seen := range miners[bh.Miner] {
+		if seen == c {
+			return
+		}
+	}
+	miners[bh.Miner] = append(miners[bh.Miner], c)
+}
+
+// Others returns the blocks seen of the miner of the block at its epoch, the block excluded
+func (s *SeenBlocks) Others(bh *types.BlockHeader) []cid.Cid {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	c := bh.Cid()
+	var out []cid.Cid
+	for _, seen := range s.byEpoch[bh.Height][bh.Miner] {
+		if seen != c {
+			out = append(out, seen)
+		}
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package slashfilter
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestSeenBlocks(t *testing.T) {
+	tf.UnitTest(t)
+
+	addrs := testhelpers.NewForTestGetter()
+	miner, otherMiner := addrs(), addrs()
+	newCid := testhelpers.NewCidForTestGetter()
+	block := func(miner address.Address, height abi.ChainEpoch) *types.BlockHeader {
+		return &types.BlockHeader{
+			Miner:                 miner,
+			Height:                height,
+			Messages:              newCid(),
+			ParentStateRoot:       newCid(),
+			ParentMessageReceipts: newCid(),
+		}
+	}
+
+	seen := NewSeenBlocks()
+	first := block(miner, 100)
+	seen.Add(first)
+	seen.Add(first)
+	assert.Empty(t, seen.Others(first))
+
+	// the other blocks of the miner at the epoch are returned, not those of other miners or epochs
+	second := block(miner, 100)
+	assert.Equal(t, []cid.Cid{first.Cid()}, seen.Others(second))
+	seen.Add(second)
+	seen.Add(block(otherMiner, 100))
+	seen.Add(block(miner, 101))
+	assert.Equal(t, []cid.Cid{second.Cid()}, seen.Others(first))
+	assert.Len(t, seen.Others(block(miner, 100)), 2)
+	assert.Empty(t, seen.Others(block(otherMiner, 101)))
+
+	// the blocks too far below the highest block seen are dropped, and not recorded
+	seen.Add(block(otherMiner, 100+SeenBlocksEpochs+1))
+	assert.Empty(t, seen.Others(second))
+	old := block(miner, 100)
+	seen.Add(old)
+	assert.Empty(t, seen.Others(block(miner, 100)))
+	assert.Len(t, seen.Others(block(miner, 101)), 1)
+}
@@ -3,153 +3,261 @@ package slashfilter
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/filecoin-project/go-address"
 	cborutil "github.com/filecoin-project/go-cbor-util"
-	"github.com/filecoin-project/go-state-types/builtin"
-	"github.com/filecoin-project/go-state-types/builtin/v8/miner"
 	"github.com/filecoin-project/venus/pkg/config"
-	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/msgbuilder"
 	v1 "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/ipfs-force-community/metrics"
+	"github.com/ipfs/go-cid"
 	levelds "github.com/ipfs/go-ds-leveldb"
 	ldbopts "github.com/syndtr/goleveldb/leveldb/opt"
 )
 
-func SlashConsensus(ctx context.Context,
+var (
+	faultsFound    = metrics.NewCounterWithCategory("slasher/faults_found", "Number of consensus faults found in the incoming blocks by fault type")
+	faultsReported = metrics.NewCounter("slasher/faults_reported", "Number of ReportConsensusFault messages pushed to the message pool")
+	faultsFailed   = metrics.NewCounter("slasher/faults_failed", "Number of consensus faults found which could not be reported")
+)
+
+// MaxConsensusFaults bounds the faults kept by the reporter
+const MaxConsensusFaults = 100
+
+// ConsensusFaultReporter watches the incoming blocks for the consensus faults of the miners and reports them with
+// ReportConsensusFault messages sent by the configured address
+type ConsensusFaultReporter struct {
+	sf       ISlashFilter
+	ds       *levelds.Datastore
+	from     address.Address
+	chainAPI v1.IChain
+	mpoolAPI v1.IMessagePool
+	syncAPI  v1.ISyncer
+
+	lk     sync.Mutex
+	faults []*types.ConsensusFault
+}
+
+// NewConsensusFaultReporter opens the state of the reporter and resolves the address of the reports, the default
+// wallet address when none is configured
+func NewConsensusFaultReporter(ctx context.Context,
 	cfg *config.FaultReporterConfig,
 	walletAPI v1.IWallet,
 	chainAPI v1.IChain,
 	mpoolAPI v1.IMessagePool,
 	syncAPI v1.ISyncer,
-) error {
-	ds, err := levelds.NewDatastore(cfg.ConsensusFaultReporterDataDir, &levelds.Options{
-		Compression: ldbopts.NoCompression,
-		NoSync:      false,
-		Strict:      ldbopts.StrictAll,
-		ReadOnly:    false,
-	})
-	if err != nil {
-		return fmt.Errorf("open leveldb: %w", err)
-	}
-	sf := NewLocalSlashFilter(ds)
-
+) (*ConsensusFaultReporter, error) {
 	var fromAddr address.Address
 	if cfg.ConsensusFaultReporterAddress == "" {
 		defaddr, err := walletAPI.WalletDefaultAddress(ctx)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		fromAddr = defaddr
 	} else {
 		addr, err := address.NewFromString(cfg.ConsensusFaultReporterAddress)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		fromAddr = addr
 	}
 
-	blocks, err := syncAPI.SyncIncomingBlocks(ctx)
+	ds, err := levelds.NewDatastore(cfg.ConsensusFaultReporterDataDir, &levelds.Options{
+		Compression: ldbopts.NoCompression,
+		NoSync:      false,
+		Strict:      ldbopts.StrictAll,
+		ReadOnly:    false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open leveldb: %w", err)
+	}
+
+	return &ConsensusFaultReporter{
+		sf:       NewLocalSlashFilter(ds),
+		ds:       ds,
+		from:     fromAddr,
+		chainAPI: chainAPI,
+		mpoolAPI: mpoolAPI,
+		syncAPI:  syncAPI,
+	}, nil
+}
+
+// Start checks the incoming blocks until ctx is done, then closes the state of the reporter
+func (r *ConsensusFaultReporter) Start(ctx context.Context) error {
+	blocks, err := r.syncAPI.SyncIncomingBlocks(ctx)
 	if err != nil {
 		return fmt.Errorf("sync incoming blocks failed: %w", err)
 	}
 
-	log.Infow("consensus fault reporter", "from", fromAddr)
+	log.Infow("consensus fault reporter", "from", r.from)
 	go func() {
-		for block := range blocks {
-			otherBlock, extraBlock, fault, err := slashFilterMinedBlock(ctx, sf, chainAPI, block)
-			if err != nil {
-				log.Errorf("slash detector errored: %s", err)
-				continue
-			}
-			if fault {
-				log.Errorf("<!!> SLASH FILTER DETECTED FAULT DUE TO BLOCKS %s and %s", otherBlock.Cid(), block.Cid())
-				bh1, err := cborutil.Dump(otherBlock)
-				if err != nil {
-					log.Errorf("could not dump otherblock:%s, err:%s", otherBlock.Cid(), err)
-					continue
-				}
-
-				bh2, err := cborutil.Dump(block)
-				if err != nil {
-					log.Errorf("could not dump block:%s, err:%s", block.Cid(), err)
-					continue
-				}
-
-				params := miner.ReportConsensusFaultParams{
-					BlockHeader1: bh1,
-					BlockHeader2: bh2,
-				}
-				if extraBlock != nil {
-					be, err := cborutil.Dump(extraBlock)
-					if err != nil {
-						log.Errorf("could not dump block:%s, err:%s", block.Cid(), err)
-						continue
-					}
-					params.BlockHeaderExtra = be
-				}
-
-				enc, err := actors.SerializeParams(&params)
-				if err != nil {
-					log.Errorf("could not serialize declare faults parameters: %s", err)
-					continue
-				}
-				for {
-					head, err := chainAPI.ChainHead(ctx)
-					if err != nil || head.Height() > block.Height {
-						break
-					}
-					time.Sleep(time.Second * 10)
-				}
-				message, err := mpoolAPI.MpoolPushMessage(ctx, &types.Message{
-					To:     block.Miner,
-					From:   fromAddr,
-					Value:  types.NewInt(0),
-					Method: builtin.MethodsMiner.ReportConsensusFault,
-					Params: enc,
-				}, nil)
-				if err != nil {
-					log.Errorf("ReportConsensusFault to messagepool error:%s", err)
-					continue
-				}
-				log.Infof("ReportConsensusFault message CID:%s", message.Cid())
+		defer func() {
+			if err := r.ds.Close(); err != nil {
+				log.Errorf("close consensus fault reporter state: %s", err)
 			}
+		}()
+
+		for block := range blocks {
+			r.checkBlock(ctx, block)
 		}
 	}()
 
-	return err
+	return nil
+}
+
+// Faults returns copies of the latest faults, the oldest first
+func (r *ConsensusFaultReporter) Faults() []*types.ConsensusFault {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	out := make([]*types.ConsensusFault, 0, len(r.faults))
+	for _, fault := range r.faults {
+		cp := *fault
+		out = append(out, &cp)
+	}
+	return out
+}
+
+func (r *ConsensusFaultReporter) addFault(fault *types.ConsensusFault) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	r.faults = append(r.faults, fault)
+	if len(r.faults) > MaxConsensusFaults {
+		r.faults = r.faults[len(r.faults)-MaxConsensusFaults:]
+	}
+}
+
+func (r *ConsensusFaultReporter) updateFault(fault *types.ConsensusFault, update func(*types.ConsensusFault)) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	update(fault)
+}
+
+func (r *ConsensusFaultReporter) checkBlock(ctx context.Context, block *types.BlockHeader) {
+	otherBlock, extraBlock, faultType, err := slashFilterMinedBlock(ctx, r.sf, r.chainAPI, block)
+	if err != nil {
+		log.Errorf("slash detector errored: %s", err)
+		return
+	}
+	if faultType == "" {
+		return
+	}
+
+	log.Errorf("<!!> SLASH FILTER DETECTED %s FAULT DUE TO BLOCKS %s and %s", faultType, otherBlock.Cid(), block.Cid())
+	faultsFound.Tick(ctx, string(faultType))
+
+	fault := &types.ConsensusFault{
+		Type:   faultType,
+		Miner:  block.Miner,
+		Epoch:  block.Height,
+		Block1: otherBlock.Cid(),
+		Block2: block.Cid(),
+		Found:  time.Now(),
+	}
+	if extraBlock != nil {
+		extra := extraBlock.Cid()
+		fault.Extra = &extra
+	}
+	r.addFault(fault)
+
+	msgCid, err := r.report(ctx, otherBlock, block, extraBlock)
+	if err != nil {
+		log.Errorf("report consensus fault of miner %s: %s", block.Miner, err)
+		faultsFailed.Tick(ctx)
+		r.updateFault(fault, func(f *types.ConsensusFault) { f.Err = err.Error() })
+		return
+	}
+
+	log.Infof("ReportConsensusFault message CID:%s", msgCid)
+	faultsReported.Tick(ctx)
+	r.updateFault(fault, func(f *types.ConsensusFault) { f.Message = &msgCid })
+}
+
+func (r *ConsensusFaultReporter) report(ctx context.Context, otherBlock, block, extraBlock *types.BlockHeader) (cid.Cid, error) {
+	bh1, err := cborutil.Dump(otherBlock)
+	if err != nil {
+		return cid.Undef, fmt.Errorf("could not dump otherblock:%s, err:%w", otherBlock.Cid(), err)
+	}
+
+	bh2, err := cborutil.Dump(block)
+	if err != nil {
+		return cid.Undef, fmt.Errorf("could not dump block:%s, err:%w", block.Cid(), err)
+	}
+
+	var be []byte
+	if extraBlock != nil {
+		if be, err = cborutil.Dump(extraBlock); err != nil {
+			return cid.Undef, fmt.Errorf("could not dump block:%s, err:%w", extraBlock.Cid(), err)
+		}
+	}
+
+	// the fault can only be reported once the block is below the head
+	var head *types.TipSet
+	for {
+		head, err = r.chainAPI.ChainHead(ctx)
+		if err != nil {
+			return cid.Undef, fmt.Errorf("get chain head: %w", err)
+		}
+		if head.Height() > block.Height {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return cid.Undef, ctx.Err()
+		case <-time.After(time.Second * 10):
+		}
+	}
+
+	nv, err := r.chainAPI.StateNetworkVersion(ctx, head.Key())
+	if err != nil {
+		return cid.Undef, fmt.Errorf("get network version: %w", err)
+	}
+	msg, err := msgbuilder.New(nv, r.from).Miner(block.Miner).ReportConsensusFault(bh1, bh2, be)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	message, err := r.mpoolAPI.MpoolPushMessage(ctx, msg, nil)
+	if err != nil {
+		return cid.Undef, fmt.Errorf("ReportConsensusFault to messagepool error:%w", err)
+	}
+	return message.Cid(), nil
 }
 
-func slashFilterMinedBlock(ctx context.Context, sf ISlashFilter, chainAPI v1.IChain, blockB *types.BlockHeader) (*types.BlockHeader, *types.BlockHeader, bool, error) {
+func slashFilterMinedBlock(ctx context.Context, sf ISlashFilter, chainAPI v1.IChain, blockB *types.BlockHeader) (*types.BlockHeader, *types.BlockHeader, types.ConsensusFaultType, error) {
 	blockC, err := chainAPI.ChainGetBlock(ctx, blockB.Parents[0])
 	if err != nil {
-		return nil, nil, false, fmt.Errorf("chain get block error:%s", err)
+		return nil, nil, "", fmt.Errorf("chain get block error:%s", err)
 	}
 
 	blockACid, fault, err := sf.MinedBlock(ctx, blockB, blockC.Height)
 	if err != nil {
-		return nil, nil, false, fmt.Errorf("slash filter check block error:%s", err)
+		return nil, nil, "", fmt.Errorf("slash filter check block error:%s", err)
 	}
 
 	if !fault {
-		return nil, nil, false, nil
+		return nil, nil, "", nil
 	}
 
 	blockA, err := chainAPI.ChainGetBlock(ctx, blockACid)
 	if err != nil {
-		return nil, nil, false, fmt.Errorf("failed to get blockA: %w", err)
+		return nil, nil, "", fmt.Errorf("failed to get blockA: %w", err)
 	}
 
 	// (a) double-fork mining (2 blocks at one epoch)
 	if blockA.Height == blockB.Height {
-		return blockA, nil, true, nil
+		return blockA, nil, types.DoubleForkMiningFault, nil
 	}
 
 	// (b) time-offset mining faults (2 blocks with the same parents)
 	if types.CidArrsEqual(blockB.Parents, blockA.Parents) {
-		return blockA, nil, true, nil
+		return blockA, nil, types.TimeOffsetMiningFault, nil
 	}
 
 	// (c) parent-grinding fault
@@ -162,9 +270,9 @@ func slashFilterMinedBlock(ctx context.Context, sf ISlashFilter, chainAPI v1.ICh
 	//  [A, C]
 	if types.CidArrsEqual(blockA.Parents, blockC.Parents) && blockA.Height == blockC.Height &&
 		types.CidArrsContains(blockB.Parents, blockC.Cid()) && !types.CidArrsContains(blockB.Parents, blockA.Cid()) {
-		return blockA, blockC, true, nil
+		return blockA, blockC, types.ParentGrindingFault, nil
 	}
 
 	log.Error("unexpectedly reached end of slashFilterMinedBlock despite fault being reported!")
-	return nil, nil, false, nil
+	return nil, nil, "", nil
 }
@@ -0,0 +1,125 @@
+package slashfilter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/golang/mock/gomock"
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/api/chain/v1/mock"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestConsensusFaultReporter(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	addrs := testhelpers.NewForTestGetter()
+	miner, from := addrs(), addrs()
+	newCid := testhelpers.NewCidForTestGetter()
+	blocks := make(map[cid.Cid]*types.BlockHeader)
+	block := func(miner address.Address, height abi.ChainEpoch, parents ...cid.Cid) *types.BlockHeader {
+		bh := &types.BlockHeader{
+			Miner:                 miner,
+			Height:                height,
+			Parents:               parents,
+			Messages:              newCid(),
+			ParentStateRoot:       newCid(),
+			ParentMessageReceipts: newCid(),
+		}
+		blocks[bh.Cid()] = bh
+		return bh
+	}
+	parent := block(addrs(), 9)
+	head, err := types.NewTipSet([]*types.BlockHeader{block(addrs(), 20, parent.Cid())})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	node := mock.NewMockFullNode(ctrl)
+	node.EXPECT().ChainGetBlock(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, c cid.Cid) (*types.BlockHeader, error) {
+		return blocks[c], nil
+	}).AnyTimes()
+	node.EXPECT().ChainHead(gomock.Any()).Return(head, nil).AnyTimes()
+	node.EXPECT().StateNetworkVersion(gomock.Any(), head.Key()).Return(network.Version21, nil).AnyTimes()
+
+	r := &ConsensusFaultReporter{
+		sf:       NewLocalSlashFilter(dssync.MutexWrap(ds.NewMapDatastore())),
+		from:     from,
+		chainAPI: node,
+		mpoolAPI: node,
+	}
+
+	// the first block of the miner is not a fault
+	first := block(miner, 10, parent.Cid())
+	r.checkBlock(ctx, first)
+	assert.Empty(t, r.Faults())
+
+	// a second block at the epoch is reported
+	var pushed *types.Message
+	node.EXPECT().MpoolPushMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, msg *types.Message, _ *types.MessageSendSpec) (*types.SignedMessage, error) {
+			pushed = msg
+			return &types.SignedMessage{Message: *msg}, nil
+		})
+	doubleFork := block(miner, 10, parent.Cid())
+	r.checkBlock(ctx, doubleFork)
+	require.NotNil(t, pushed)
+	assert.Equal(t, miner, pushed.To)
+	assert.Equal(t, from, pushed.From)
+	assert.Equal(t, builtin.MethodsMiner.ReportConsensusFault, pushed.Method)
+
+	faults := r.Faults()
+	require.Len(t, faults, 1)
+	msgCid := (&types.SignedMessage{Message: *pushed}).Cid()
+	assert.Equal(t, &types.ConsensusFault{
+		Type:    types.DoubleForkMiningFault,
+		Miner:   miner,
+		Epoch:   10,
+		Block1:  first.Cid(),
+		Block2:  doubleFork.Cid(),
+		Found:   faults[0].Found,
+		Message: &msgCid,
+	}, faults[0])
+
+	// a fault which can not be reported keeps the error
+	node.EXPECT().MpoolPushMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("not enough funds"))
+	timeOffset := block(miner, 11, parent.Cid())
+	r.checkBlock(ctx, timeOffset)
+	faults = r.Faults()
+	require.Len(t, faults, 2)
+	assert.Equal(t, types.TimeOffsetMiningFault, faults[1].Type)
+	assert.Equal(t, timeOffset.Cid(), faults[1].Block2)
+	assert.Nil(t, faults[1].Message)
+	assert.Contains(t, faults[1].Err, "not enough funds")
+
+	// the faults returned are copies
+	faults[0].Err = "changed"
+	assert.Empty(t, r.Faults()[0].Err)
+}
+
+func TestConsensusFaultReporterBound(t *testing.T) {
+	tf.UnitTest(t)
+
+	r := &ConsensusFaultReporter{}
+	for i := 0; i < MaxConsensusFaults+5; i++ {
+		r.addFault(&types.ConsensusFault{Epoch: abi.ChainEpoch(i)})
+	}
+
+	// the oldest faults are dropped
+	faults := r.Faults()
+	require.Len(t, faults, MaxConsensusFaults)
+	assert.Equal(t, abi.ChainEpoch(5), faults[0].Epoch)
+	assert.Equal(t, abi.ChainEpoch(MaxConsensusFaults+4), faults[MaxConsensusFaults-1].Epoch)
+}
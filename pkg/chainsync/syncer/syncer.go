@@ -16,6 +16,7 @@ import (
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/filecoin-project/go-state-types/abi"
 	actorsTypes "github.com/filecoin-project/go-state-types/actors"
 	syncTypes "github.com/filecoin-project/venus/pkg/chainsync/types"
 	cbor "github.com/ipfs/go-ipld-cbor"
@@ -32,6 +33,7 @@ import (
 	"github.com/filecoin-project/venus/venus-shared/types"
 	blockstore "github.com/ipfs/boxo/blockstore"
 	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
@@ -56,6 +58,11 @@ var (
 	ErrForkTooLong = fmt.Errorf("fork longer than threshold")
 	// ErrChainHasBadTipSet is returned when the syncer traverses a chain with a cached bad tipset.
 	ErrChainHasBadTipSet = errors.New("input chain contains a cached bad tipset")
+	// ErrForkBeforeCheckpoint is returned when the target forks from the chain before the checkpoint, the chain up to
+	// the checkpoint is trusted.
+	ErrForkBeforeCheckpoint = errors.New("the target forks from the chain before the checkpoint")
+	// ErrEpochBeyondCurrMax is returned when the target is too far in the future by the consensus.
+	ErrEpochBeyondCurrMax = errors.New("target epoch is beyond the current max epoch")
 	// ErrNewChainTooLong is returned when processing a fork that split off from the main chain too many blocks ago.
 	ErrNewChainTooLong = errors.New("input chain forked from best chain past finality limit")
 	// ErrUnexpectedStoreState indicates that the syncer's chain bsstore is violating expected invariants.
@@ -73,6 +80,22 @@ var (
 	epochGauge        = metrics.NewInt64("chain/epoch", "The current epoch.", "")
 	netVersionGuage   = metrics.NewInt64("chain/net_version", "The current network version.", "")
 	actorVersionGuage = metrics.NewInt64("chain/actor_version", "The current actor version.", "")
+
+	epochLagGauge   = metrics.NewInt64("sync/epoch_lag", "The number of epochs the last synced tipset is behind the current time.", "")
+	headersTimer    = metrics.NewTimerMs("sync/stage_headers", "Duration of fetching and checking the headers of a sync target in milliseconds")
+	messagesTimer   = metrics.NewTimerMs("sync/stage_messages", "Duration of fetching the messages of a tipset segment in milliseconds")
+	validationTimer = metrics.NewTimerMs("sync/stage_validation", "Duration of validating the blocks of a tipset, the execution of its parent included, in milliseconds")
+	syncFailedCnt   = metrics.NewCounterWithCategory("sync/failed", "The number of sync targets which failed, by reason.")
+)
+
+// the reasons a sync target fails for
+const (
+	failedHeaders       = "headers"
+	failedMessages      = "messages"
+	failedValidation    = "validation"
+	failedStateMismatch = "state_mismatch"
+	failedBadTipSet     = "bad_tipset"
+	failedForkTooLong   = "fork_too_long"
 )
 
 // StateProcessor does semantic validation on fullblocks.
@@ -82,11 +105,6 @@ type StateProcessor interface {
 	RunStateTransition(ctx context.Context, ts *types.TipSet) (root cid.Cid, receipt cid.Cid, err error)
 }
 
-// BlockValidator used to validate full block
-type BlockValidator interface {
-	ValidateFullBlock(ctx context.Context, blk *types.BlockHeader) error
-}
-
 // ChainReaderWriter reads and writes the chain bsstore.
 type ChainReaderWriter interface {
 	GetHead() *types.TipSet
@@ -128,8 +146,8 @@ type Syncer struct {
 
 	// Evaluates tipset messages and stores the resulting states.
 	stmgr *statemanger.Stmgr
-	// Validates headers and message structure
-	blockValidator BlockValidator
+	// Validates headers and message structure by the rules of the consensus
+	consensus consensus.Consensus
 	// Provides and stores validated tipsets and their state roots.
 	chainStore *chain.Store
 	// Provides message collections given cids
@@ -148,7 +166,7 @@ type Syncer struct {
 // NewSyncer constructs a Syncer ready for use.  The chain reader must have a
 // head tipset to initialize the staging field.
 func NewSyncer(stmgr *statemanger.Stmgr,
-	hv BlockValidator,
+	cons consensus.Consensus,
 	s *chain.Store,
 	m messageStore,
 	bsstore blockstoreutil.Blockstore,
@@ -162,10 +180,15 @@ func NewSyncer(stmgr *statemanger.Stmgr,
 		logSyncer.Warn("*********************************************************************************************")
 	}
 
+	// the fork choice of the chain store weighs the tipsets by the rules of the consensus
+	s.SetWeightFunc(func(ctx context.Context, _ cbor.IpldStore, ts *types.TipSet) (big.Int, error) {
+		return cons.Weight(ctx, ts)
+	})
+
 	syncer := &Syncer{
 		exchangeClient:  exchangeClient,
 		badTipSets:      syncTypes.NewBadTipSetCache(),
-		blockValidator:  hv,
+		consensus:       cons,
 		bsstore:         bsstore,
 		chainStore:      s,
 		messageProvider: m,
@@ -182,6 +205,16 @@ func NewSyncer(stmgr *statemanger.Stmgr,
 	return syncer, nil
 }
 
+// SetBadTipSetCache replaces the cache of the tipsets the syncer refuses, before it starts
+func (syncer *Syncer) SetBadTipSetCache(cache *syncTypes.BadTipSetCache) {
+	syncer.badTipSets = cache
+}
+
+// BadTipSets returns the cache of the tipsets the syncer refuses
+func (syncer *Syncer) BadTipSets() *syncTypes.BadTipSetCache {
+	return syncer.badTipSets
+}
+
 // syncOne syncs a single tipset with the chain bsstore. syncOne calculates the
 // parent state of the tipset and calls into consensus to run a state transition
 // in order to validate the tipset.  In the case the input tipset is valid,
@@ -202,21 +235,23 @@ func (syncer *Syncer) syncOne(ctx context.Context, parent, next *types.TipSet) e
 	var err error
 
 	if !parent.Key().Equals(syncer.checkPoint) {
+		validationStopwatch := validationTimer.Start()
 		var wg errgroup.Group
 		for i := 0; i < next.Len(); i++ {
 			blk := next.At(i)
 			wg.Go(func() error {
 				// Fetch the URL.
-				err := syncer.blockValidator.ValidateFullBlock(ctx, blk)
+				err := syncer.consensus.ValidateBlockHeader(ctx, blk)
 				if err == nil {
 					if err := syncer.chainStore.AddToTipSetTracker(ctx, blk); err != nil {
-						return fmt.Errorf("failed to add validated header to tipset tracker: %w", err)
+						return &localError{fmt.Errorf("failed to add validated header to tipset tracker: %w", err)}
 					}
 				}
 				return err
 			})
 		}
 		err = wg.Wait()
+		validationStopwatch(ctx)
 		if err != nil {
 			var rootNotMatch bool // nolint
 
@@ -234,6 +269,9 @@ func (syncer *Syncer) syncOne(ctx context.Context, parent, next *types.TipSet) e
 			if rootNotMatch { // nolint
 				// todo: should here rollback, and re-compute?
 				_ = syncer.stmgr.Rollback(ctx, parent, next)
+				syncFailedCnt.Tick(ctx, failedStateMismatch)
+			} else {
+				syncFailedCnt.Tick(ctx, failedValidation)
 			}
 
 			return fmt.Errorf("validate mining failed %w", err)
@@ -247,6 +285,13 @@ func (syncer *Syncer) syncOne(ctx context.Context, parent, next *types.TipSet) e
 	epochGauge.Set(ctx, int64(height))
 
 	timeStamp := next.MinTimestamp()
+	if blockDelay := repo.Config.NetworkParams.BlockDelay; blockDelay > 0 {
+		if now := uint64(syncer.clock.Now().Unix()); now > timeStamp {
+			epochLagGauge.Set(ctx, int64((now-timeStamp)/blockDelay))
+		} else {
+			epochLagGauge.Set(ctx, 0)
+		}
+	}
 	if timeStamp+repo.Config.NetworkParams.BlockDelay*uint64(time.Second) >= uint64(syncer.clock.Now().Unix()) {
 		// update to latest
 		syncStatus.Set(ctx, 1)
@@ -261,6 +306,29 @@ func (syncer *Syncer) syncOne(ctx context.Context, parent, next *types.TipSet) e
 	return nil
 }
 
+// localError is a failure of the node rather than of the tipset, which does not make the tipset bad
+type localError struct {
+	err error
+}
+
+func (e *localError) Error() string { return e.err.Error() }
+
+func (e *localError) Unwrap() error { return e.err }
+
+// isConsensusFailure reports whether err rejects the tipset by the rules of the consensus. The syncs cancelled, for
+// a heavier target or the shutdown, the blocks from the future and the failures of the node, such as a missing
+// object, may succeed later and do not make the tipset bad.
+func isConsensusFailure(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var local *localError
+	if errors.As(err, &local) || ipld.IsNotFound(err) {
+		return false
+	}
+	return !errors.Is(err, consensus.ErrTemporal)
+}
+
 func isRootNotMatch(err error) bool {
 	return errors.Is(err, consensus.ErrStateRootMismatch) || errors.Is(err, consensus.ErrReceiptRootMismatch)
 }
@@ -275,12 +343,14 @@ func (syncer *Syncer) HandleNewTipSet(ctx context.Context, target *syncTypes.Tar
 	now := time.Now()
 
 	defer func() {
-		if err != nil {
-			target.Err = err
-			target.State = syncTypes.StageSyncErrored
-		} else {
-			target.State = syncTypes.StageSyncComplete
-		}
+		target.Update(func(t *syncTypes.Target) {
+			if err != nil {
+				t.Err = err
+				t.State = syncTypes.StageSyncErrored
+			} else {
+				t.State = syncTypes.StageSyncComplete
+			}
+		})
 		tracing.AddErrorEndSpan(ctx, span, &err)
 		span.End()
 		logSyncer.Infof("handle tipset height %d, count %d, took %.4f(s)", target.Head.Height(), target.Head.Len(), time.Since(now).Seconds())
@@ -293,17 +363,40 @@ func (syncer *Syncer) HandleNewTipSet(ctx context.Context, target *syncTypes.Tar
 		return errors.New("do not sync to a target with less weight")
 	}
 
+	if syncer.consensus.IsEpochBeyondCurrMax(ctx, target.Head.Height()) {
+		return fmt.Errorf("%w: %d", ErrEpochBeyondCurrMax, target.Head.Height())
+	}
+
 	if syncer.chainStore.HasTipSetAndState(ctx, target.Head) || target.Head.Key().Equals(head.Key()) {
 		return errors.New("do not sync to a target has synced before")
 	}
 
+	if bad, ok := syncer.badTipSets.Check(target.Head.Key()); ok {
+		return fmt.Errorf("%w: %s: %s", ErrChainHasBadTipSet, target.Head.Key(), bad.Reason)
+	}
+
 	syncer.exchangeClient.AddPeer(target.Sender)
+	target.Update(func(t *syncTypes.Target) { t.Stage = types.StageHeaders })
+	headersStopwatch := headersTimer.Start()
 	tipsets, err := syncer.fetchChainBlocks(ctx, head, target.Head)
+	headersStopwatch(ctx)
 	if err != nil {
+		switch {
+		case errors.Is(err, ErrChainHasBadTipSet):
+			syncFailedCnt.Tick(ctx, failedBadTipSet)
+		case errors.Is(err, ErrForkTooLong):
+			syncFailedCnt.Tick(ctx, failedForkTooLong)
+		default:
+			syncFailedCnt.Tick(ctx, failedHeaders)
+		}
 		return errors.Wrapf(err, "failure fetching or validating headers")
 	}
 	logSyncer.Debugf("fetch header success at %v %s ...", tipsets[0].Height(), tipsets[0].Key())
 
+	if err := syncer.checkCheckpoint(ctx, tipsets[0]); err != nil {
+		return err
+	}
+
 	if err = syncer.syncSegement(ctx, target, tipsets); err == nil {
 		syncer.delayRunTx.update(tipsets[len(tipsets)-1])
 	}
@@ -311,6 +404,34 @@ func (syncer *Syncer) HandleNewTipSet(ctx context.Context, target *syncTypes.Tar
 	return err
 }
 
+// checkCheckpoint fails when the chain of the first tipset fetched does not go through the checkpoint of the chain
+// store, the sync starts from the checkpoint and the chain before it is not validated again
+func (syncer *Syncer) checkCheckpoint(ctx context.Context, first *types.TipSet) error {
+	cpKey := syncer.chainStore.GetCheckPoint()
+	if cpKey.IsEmpty() {
+		return nil
+	}
+	cp, err := syncer.chainStore.GetTipSet(ctx, cpKey)
+	if err != nil {
+		return fmt.Errorf("load the checkpoint %s: %w", cpKey, err)
+	}
+	base, err := syncer.chainStore.GetTipSet(ctx, first.Parents())
+	if err != nil {
+		return err
+	}
+	if base.Height() < cp.Height() {
+		return fmt.Errorf("%w: at height %d, the checkpoint is at %d", ErrForkBeforeCheckpoint, base.Height(), cp.Height())
+	}
+	at, err := syncer.chainStore.GetTipSetByHeight(ctx, base, cp.Height(), false)
+	if err != nil {
+		return err
+	}
+	if !at.Key().Equals(cpKey) {
+		return fmt.Errorf("%w: %s is at the height %d of the checkpoint %s", ErrForkBeforeCheckpoint, at.Key(), cp.Height(), cpKey)
+	}
+	return nil
+}
+
 func (syncer *Syncer) syncSegement(ctx context.Context, target *syncTypes.Target, tipsets []*types.TipSet) error {
 	parent, err := syncer.chainStore.GetTipSet(ctx, tipsets[0].Parents())
 	if err != nil {
@@ -326,11 +447,19 @@ func (syncer *Syncer) syncSegement(ctx context.Context, target *syncTypes.Target
 		startTip := segTipset[0].Height()
 		emdTipset := segTipset[len(segTipset)-1].Height()
 		logSyncer.Debugf("start to fetch message segement %d-%d", startTip, emdTipset)
+		target.Update(func(t *syncTypes.Target) { t.Stage = types.StageFetchingMessages })
+		messagesStopwatch := messagesTimer.Start()
 		_, err := syncer.fetchSegMessage(ctx, segTipset)
+		messagesStopwatch(ctx)
 		if err != nil {
+			syncFailedCnt.Tick(ctx, failedMessages)
 			return err
 		}
 		logSyncer.Debugf("finish to fetch message segement %d-%d", startTip, emdTipset)
+		target.Update(func(t *syncTypes.Target) {
+			t.Fetched = segTipset[len(segTipset)-1]
+			t.Stage = types.StageMessages
+		})
 		err = <-errProcessChan
 		if err != nil {
 			return fmt.Errorf("process message failed %v", err)
@@ -390,46 +519,30 @@ func (syncer *Syncer) fetchChainBlocks(ctx context.Context, knownTip *types.TipS
 		return blockstoreutil.CopyBlockstore(ctx, bs, syncer.bsstore)
 	}
 
+	// headers are fetched window by window while the previous windows are validated and saved
 	untilHeight := knownTip.Height()
-	count := 0
-loop:
-	for chainTipsets[len(chainTipsets)-1].Height() > untilHeight {
-		tipSet, err := syncer.chainStore.GetTipSet(ctx, targetTip.Parents())
-		if err == nil {
-			chainTipsets = append(chainTipsets, tipSet)
-			targetTip = tipSet
-			count++
-			if count%500 == 0 {
-				logSyncer.Info("load from local db ", "Height: ", tipSet.Height())
+	batches := make(chan headerBatch, headerBatchQueue)
+	wg, wctx := errgroup.WithContext(ctx)
+	wg.Go(func() error {
+		defer close(batches)
+		return syncer.fetchHeaderBatches(wctx, targetTip, untilHeight, batches)
+	})
+	wg.Go(func() error {
+		for batch := range batches {
+			if err := syncer.validateHeaderBatch(chainTipsets[len(chainTipsets)-1], batch.tipsets); err != nil {
+				return err
 			}
-			continue
-		}
-
-		windows := targetTip.Height() - untilHeight
-		if windows > 500 {
-			windows = 500
-		}
-
-		fetchHeaders, err := syncer.exchangeClient.GetBlocks(ctx, targetTip.Parents(), int(windows))
-		if err != nil {
-			return nil, err
-		}
-
-		if len(fetchHeaders) == 0 {
-			break loop
-		}
-
-		logSyncer.Infof("fetch blocks %d height from %d-%d", len(fetchHeaders), fetchHeaders[0].Height(), fetchHeaders[len(fetchHeaders)-1].Height())
-		if err = flushDB(fetchHeaders); err != nil {
-			return nil, err
-		}
-		for _, b := range fetchHeaders {
-			if b.Height() < untilHeight {
-				break loop
+			if len(batch.fetched) > 0 {
+				if err := flushDB(batch.fetched); err != nil {
+					return err
+				}
 			}
-			chainTipsets = append(chainTipsets, b)
-			targetTip = b
+			chainTipsets = append(chainTipsets, batch.tipsets...)
 		}
+		return nil
+	})
+	if err := wg.Wait(); err != nil {
+		return nil, err
 	}
 
 	base := chainTipsets[len(chainTipsets)-1]
@@ -475,6 +588,107 @@ loop:
 	return chainTipsets, nil
 }
 
+const (
+	// headerFetchWindow is the number of tipsets requested at once while fetching headers
+	headerFetchWindow = 500
+	// headerBatchQueue is the number of fetched header windows waiting to be validated
+	headerBatchQueue = 4
+)
+
+// headerBatch is a window of tipsets, from the highest to the lowest, loaded from the local db
+// or fetched from the network
+type headerBatch struct {
+	tipsets []*types.TipSet
+	// the tipsets fetched from the network, which may go below the requested height
+	fetched []*types.TipSet
+}
+
+// fetchHeaderBatches walks the chain backwards from the parents of from down to untilHeight, the tipsets
+// of the local db are used while they are available, the others are requested from the network
+func (syncer *Syncer) fetchHeaderBatches(ctx context.Context, from *types.TipSet, untilHeight abi.ChainEpoch, out chan<- headerBatch) error {
+	send := func(batch headerBatch) error {
+		select {
+		case out <- batch:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	var local []*types.TipSet
+	count := 0
+	for from.Height() > untilHeight {
+		tipSet, err := syncer.chainStore.GetTipSet(ctx, from.Parents())
+		if err == nil {
+			local = append(local, tipSet)
+			from = tipSet
+			count++
+			if count%500 == 0 {
+				logSyncer.Info("load from local db ", "Height: ", tipSet.Height())
+			}
+			continue
+		}
+		if len(local) > 0 {
+			if err := send(headerBatch{tipsets: local}); err != nil {
+				return err
+			}
+			local = nil
+		}
+
+		windows := from.Height() - untilHeight
+		if windows > headerFetchWindow {
+			windows = headerFetchWindow
+		}
+
+		fetchHeaders, err := syncer.exchangeClient.GetBlocks(ctx, from.Parents(), int(windows))
+		if err != nil {
+			return err
+		}
+		if len(fetchHeaders) == 0 {
+			break
+		}
+		logSyncer.Infof("fetch blocks %d height from %d-%d", len(fetchHeaders), fetchHeaders[0].Height(), fetchHeaders[len(fetchHeaders)-1].Height())
+
+		keep := fetchHeaders
+		for i, b := range fetchHeaders {
+			if b.Height() < untilHeight {
+				keep = fetchHeaders[:i]
+				break
+			}
+		}
+		if err := send(headerBatch{tipsets: keep, fetched: fetchHeaders}); err != nil {
+			return err
+		}
+		if len(keep) == 0 || len(keep) < len(fetchHeaders) {
+			return nil
+		}
+		from = keep[len(keep)-1]
+	}
+
+	if len(local) > 0 {
+		return send(headerBatch{tipsets: local})
+	}
+	return nil
+}
+
+// validateHeaderBatch checks that the tipsets of the batch link to each other and to child, and that none
+// of them is known to be bad
+func (syncer *Syncer) validateHeaderBatch(child *types.TipSet, tipsets []*types.TipSet) error {
+	for _, ts := range tipsets {
+		if !child.Parents().Equals(ts.Key()) {
+			return fmt.Errorf("tipset %s at %d is not the parent of %s at %d", ts.Key(), ts.Height(), child.Key(), child.Height())
+		}
+		if ts.Height() >= child.Height() {
+			return fmt.Errorf("parent tipset %s height %d is not below %d", ts.Key(), ts.Height(), child.Height())
+		}
+		if bad, ok := syncer.badTipSets.Check(ts.Key()); ok {
+			return fmt.Errorf("%w: %s: %s", ErrChainHasBadTipSet, ts.Key(), bad.Reason)
+		}
+		child = ts
+	}
+	return nil
+}
+
 // syncFork tries to obtain the chain fragment that links a fork into a common
 // ancestor in our view of the chain.
 //
@@ -648,16 +862,14 @@ func (syncer *Syncer) processTipSetSegment(ctx context.Context, target *syncType
 	for i, ts := range segTipset {
 		err := syncer.syncOne(ctx, parent, ts)
 		if err != nil {
-			// While `syncOne` can indeed fail for reasons other than consensus,
-			// adding to the badTipSets at this point is the simplest, since we
-			// have access to the chain. If syncOne fails for non-consensus reasons,
-			// there is no assumption that the running node's data is valid at all,
-			// so we don't really lose anything with this simplification.
-			syncer.badTipSets.AddChain(segTipset[i:])
+			// the bad tipsets are persisted, a chain failing for another reason would be refused after a restart
+			if isConsensusFailure(ctx, err) {
+				syncer.badTipSets.AddChain(segTipset[i:], err.Error())
+			}
 			return nil, errors.Wrapf(err, "failed to sync tipset %s, number %d of %d in chain", ts.Key().String(), i, len(segTipset))
 		}
 		parent = ts
-		target.Current = ts
+		target.Update(func(t *syncTypes.Target) { t.Current = ts })
 	}
 	return parent, nil
 }
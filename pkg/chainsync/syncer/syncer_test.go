@@ -11,10 +11,13 @@ import (
 	"github.com/filecoin-project/venus/pkg/statemanger"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/chainsync/syncer"
 	syncTypes "github.com/filecoin-project/venus/pkg/chainsync/types"
 	"github.com/filecoin-project/venus/pkg/clock"
+	"github.com/filecoin-project/venus/pkg/consensus"
 	_ "github.com/filecoin-project/venus/pkg/crypto/bls"
 	_ "github.com/filecoin-project/venus/pkg/crypto/secp"
 	"github.com/filecoin-project/venus/pkg/fork"
@@ -23,6 +26,8 @@ import (
 	"github.com/filecoin-project/venus/venus-shared/actors/policy"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -213,6 +218,35 @@ func TestIgnoreLightFork(t *testing.T) {
 	verifyHead(t, builder.Store(), t4)
 }
 
+func TestRefuseForkBeforeCheckpoint(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder, s := setup(ctx, t)
+	genesis := builder.Store().GetHead()
+
+	forkbase := builder.AppendOn(ctx, genesis, 1)
+	main1 := builder.AppendOn(ctx, forkbase, 1)
+	main2 := builder.AppendOn(ctx, main1, 1)
+
+	assert.NoError(t, s.HandleNewTipSet(ctx, &syncTypes.Target{Head: main1}))
+	assert.NoError(t, builder.FlushHead(ctx))
+	verifyHead(t, builder.Store(), main1)
+	builder.Store().SetCheckPoint(main1.Key())
+
+	// the chain extending the checkpoint syncs
+	assert.NoError(t, s.HandleNewTipSet(ctx, &syncTypes.Target{Head: main2}))
+	assert.NoError(t, builder.FlushHead(ctx))
+	verifyHead(t, builder.Store(), main2)
+
+	// a heavier fork leaving the chain below the checkpoint is refused
+	builder.ResetMiners()
+	fork1 := builder.AppendOn(ctx, forkbase, 3)
+	fork2 := builder.AppendOn(ctx, fork1, 3)
+	err := s.HandleNewTipSet(ctx, &syncTypes.Target{Head: fork2})
+	assert.ErrorIs(t, err, syncer.ErrForkBeforeCheckpoint)
+	verifyHead(t, builder.Store(), main2)
+}
+
 func TestAcceptHeavierFork(t *testing.T) {
 	tf.UnitTest(t)
 	ctx := context.Background()
@@ -459,6 +493,49 @@ func TestBlockNotLinkedRejected(t *testing.T) {
 	assert.NoError(t, syncer.HandleNewTipSet(ctx, target2))
 }
 
+// skippingExchange drops a tipset from the middle of the fetched header windows
+type skippingExchange struct {
+	*chain.Builder
+}
+
+func (e *skippingExchange) GetBlocks(ctx context.Context, tsk types.TipSetKey, count int) ([]*types.TipSet, error) {
+	tipsets, err := e.Builder.GetBlocks(ctx, tsk, count)
+	if err != nil || len(tipsets) < 3 {
+		return tipsets, err
+	}
+	return append(tipsets[:1], tipsets[2:]...), nil
+}
+
+func TestUnlinkedHeaderWindowRejected(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	eval := builder.FakeStateEvaluator()
+	stmgr, err := statemanger.NewStateManager(builder.Store(), builder.MessageStore(), eval, nil, nil, nil, nil, false)
+	require.NoError(t, err)
+
+	// the headers are only available from a parallel builder, which skips one of them
+	shadowBuilder := chain.NewBuilder(t, address.Undef)
+	s, err := syncer.NewSyncer(stmgr,
+		eval,
+		builder.Store(),
+		builder.Mstore(),
+		builder.BlockStore(),
+		&skippingExchange{Builder: shadowBuilder},
+		clock.NewFake(time.Unix(1234567890, 0)),
+		fork.NewMockFork())
+	require.NoError(t, err)
+
+	genesis := builder.Store().GetHead()
+	require.True(t, genesis.Equals(shadowBuilder.Genesis()))
+	head := shadowBuilder.AppendManyOn(ctx, 5, genesis)
+	target := &syncTypes.Target{Head: head}
+	err = s.HandleNewTipSet(ctx, target)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not the parent of")
+	verifyHead(t, builder.Store(), genesis)
+}
+
 type poisonValidator struct {
 	headerFailureTS uint64
 	fullFailureTS   uint64
@@ -476,13 +553,25 @@ func (pv *poisonValidator) RunStateTransition(ctx context.Context, ts *types.Tip
 	return testhelpers.EmptyTxMetaCID, testhelpers.EmptyTxMetaCID, nil
 }
 
-func (pv *poisonValidator) ValidateFullBlock(ctx context.Context, blk *types.BlockHeader) error {
+func (pv *poisonValidator) ValidateBlockHeader(ctx context.Context, blk *types.BlockHeader) error {
 	if pv.headerFailureTS == blk.Timestamp {
 		return errors.New("val semantic fails on poison timestamp")
 	}
 	return nil
 }
 
+func (pv *poisonValidator) ValidateMsgMeta(_ context.Context, _ *types.FullBlock) error {
+	return nil
+}
+
+func (pv *poisonValidator) IsEpochBeyondCurrMax(_ context.Context, _ abi.ChainEpoch) bool {
+	return false
+}
+
+func (pv *poisonValidator) Weight(_ context.Context, ts *types.TipSet) (big.Int, error) {
+	return ts.ParentWeight(), nil
+}
+
 func (pv *poisonValidator) ValidateHeaderSemantic(_ context.Context, header *types.BlockHeader, _ *types.TipSet) error {
 	if pv.headerFailureTS == header.Timestamp {
 		return errors.New("val semantic fails on poison timestamp")
@@ -538,6 +627,108 @@ func TestSemanticallyBadTipSetFails(t *testing.T) {
 	assert.Contains(t, err.Error(), "val semantic fails")
 }
 
+// cancelValidator is a consensus whose header validation is interrupted by the cancellation of the sync
+type cancelValidator struct {
+	*chain.FakeStateEvaluator
+	cancel context.CancelFunc
+}
+
+func (c *cancelValidator) ValidateBlockHeader(ctx context.Context, _ *types.BlockHeader) error {
+	c.cancel()
+	return ctx.Err()
+}
+
+func persistedBadTipSets(ctx context.Context, t *testing.T, store ds.Datastore) int {
+	res, err := store.Query(ctx, query.Query{KeysOnly: true})
+	require.NoError(t, err)
+	entries, err := res.Rest()
+	require.NoError(t, err)
+	return len(entries)
+}
+
+func TestOnlyConsensusFailuresAreBad(t *testing.T) {
+	tf.UnitTest(t)
+
+	t.Run("poisoned tipset is persisted", func(t *testing.T) {
+		ctx := context.Background()
+		eval := newPoisonValidator(t, 98, 99)
+		builder := chain.NewBuilder(t, address.Undef)
+		stmgr, err := statemanger.NewStateManager(builder.Store(), builder.MessageStore(), eval, nil, nil, nil, nil, false)
+		require.NoError(t, err)
+		builder, s := setupWithValidator(ctx, t, builder, stmgr, eval)
+
+		store := ds.NewMapDatastore()
+		cache, err := syncTypes.LoadBadTipSetCache(ctx, store, time.Hour)
+		require.NoError(t, err)
+		s.SetBadTipSetCache(cache)
+
+		genesis := builder.Store().GetHead()
+		link := builder.BuildOneOn(ctx, genesis, func(bb *chain.BlockBuilder) {
+			bb.SetTimestamp(98)
+		})
+		require.Error(t, s.HandleNewTipSet(ctx, &syncTypes.Target{Head: link}))
+
+		_, bad := cache.Check(link.Key())
+		assert.True(t, bad)
+		assert.Equal(t, 1, persistedBadTipSets(ctx, t, store))
+	})
+
+	t.Run("cancelled sync is not", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		builder := chain.NewBuilder(t, address.Undef)
+		eval := &cancelValidator{FakeStateEvaluator: builder.FakeStateEvaluator(), cancel: cancel}
+		stmgr, err := statemanger.NewStateManager(builder.Store(), builder.MessageStore(), eval, nil, nil, nil, nil, false)
+		require.NoError(t, err)
+		builder, s := setupWithValidator(ctx, t, builder, stmgr, eval)
+
+		store := ds.NewMapDatastore()
+		cache, err := syncTypes.LoadBadTipSetCache(ctx, store, time.Hour)
+		require.NoError(t, err)
+		s.SetBadTipSetCache(cache)
+
+		head := builder.AppendManyOn(ctx, 3, builder.Store().GetHead())
+		require.Error(t, s.HandleNewTipSet(ctx, &syncTypes.Target{Head: head}))
+
+		assert.Empty(t, cache.List())
+		assert.Equal(t, 0, persistedBadTipSets(context.Background(), t, store))
+	})
+}
+
+// maxEpochConsensus is a consensus not syncing the epochs after maxEpoch
+type maxEpochConsensus struct {
+	*chain.FakeStateEvaluator
+	maxEpoch abi.ChainEpoch
+}
+
+func (c *maxEpochConsensus) IsEpochBeyondCurrMax(_ context.Context, epoch abi.ChainEpoch) bool {
+	return epoch > c.maxEpoch
+}
+
+func TestEpochBeyondCurrMaxFails(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	eval := &maxEpochConsensus{FakeStateEvaluator: builder.FakeStateEvaluator(), maxEpoch: 3}
+
+	stmgr, err := statemanger.NewStateManager(builder.Store(), builder.MessageStore(), eval, nil, nil, nil, nil, false)
+	require.NoError(t, err)
+
+	builder, s := setupWithValidator(ctx, t, builder, stmgr, eval)
+	genesis := builder.Store().GetHead()
+
+	head := builder.AppendManyOn(ctx, 3, genesis)
+	require.NoError(t, s.HandleNewTipSet(ctx, &syncTypes.Target{Head: head}))
+	require.NoError(t, builder.FlushHead(ctx))
+	verifyHead(t, builder.Store(), head)
+
+	// the consensus does not sync a chain too far in the future
+	next := builder.AppendOn(ctx, head, 1)
+	err = s.HandleNewTipSet(ctx, &syncTypes.Target{Head: next})
+	require.ErrorIs(t, err, syncer.ErrEpochBeyondCurrMax)
+	verifyHead(t, builder.Store(), head)
+}
+
 // TODO: fix test
 func TestStoresMessageReceipts(t *testing.T) {
 	t.SkipNow()
@@ -589,7 +780,7 @@ func setup(ctx context.Context, t *testing.T) (*chain.Builder, *syncer.Syncer) {
 }
 
 func setupWithValidator(ctx context.Context, t *testing.T, builder *chain.Builder,
-	stmgr *statemanger.Stmgr, headerVal syncer.BlockValidator,
+	stmgr *statemanger.Stmgr, headerVal consensus.Consensus,
 ) (*chain.Builder, *syncer.Syncer) {
 	// Note: the chain builder is passed as the fetcher, from which blocks may be requested, but
 	// *not* as the bsstore, to which the syncer must ensure to put blocks.
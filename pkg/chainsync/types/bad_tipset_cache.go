@@ -1,48 +1,259 @@
 package types
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
 
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
+// MaxBadTipSets bounds the bad tipsets kept, the oldest are dropped first
+const MaxBadTipSets = 16384
+
+// BadTipSetPrefix is the prefix of the bad tipsets persisted in the metadata datastore
+var BadTipSetPrefix = datastore.NewKey("/chainsync/badtipsets")
+
 // BadTipSetCache keeps track of bad tipsets that the syncer should not try to
-// download. Readers and writers grab a lock. The purpose of this cache is to
-// prevent a node from having to repeatedly invalidate a block (and its children)
-// in the event that the tipset does not conform to the rules of consensus. Note
-// that the cache is only in-memory, so it is reset whenever the node is restarted.
-// TODO: this needs to be limited.
+// download, with the reason they were rejected. Readers and writers grab a lock.
+// The purpose of this cache is to prevent a node from having to repeatedly
+// invalidate a block (and its children) in the event that the tipset does not
+// conform to the rules of consensus. The tipsets rejected by the syncer expire
+// after the ttl, so that a node recovers from a transient validation failure,
+// and the cache is persisted when it is given a datastore.
 type BadTipSetCache struct {
 	mu  sync.Mutex
-	bad map[string]struct{}
+	bad map[types.TipSetKey]*types.BadTipSet
+	ttl time.Duration
+	now func() time.Time
+
+	// dsMu orders the writes to ds, it is taken before mu is released so that the readers do not wait for them
+	dsMu sync.Mutex
+	ds   datastore.Batching
 }
 
+// NewBadTipSetCache creates a cache in memory whose tipsets do not expire
 func NewBadTipSetCache() *BadTipSetCache {
 	return &BadTipSetCache{
-		bad: make(map[string]struct{}),
+		bad: make(map[types.TipSetKey]*types.BadTipSet),
+		now: time.Now,
+	}
+}
+
+// LoadBadTipSetCache creates a cache persisted in ds, loading the tipsets which did not expire, whose tipsets
+// rejected by the syncer expire after ttl, 0 for never
+func LoadBadTipSetCache(ctx context.Context, ds datastore.Batching, ttl time.Duration) (*BadTipSetCache, error) {
+	cache := NewBadTipSetCache()
+	cache.ttl = ttl
+	cache.ds = namespace.Wrap(ds, BadTipSetPrefix)
+
+	res, err := cache.ds.Query(ctx, query.Query{})
+	if err != nil {
+		return nil, fmt.Errorf("query the bad tipsets: %w", err)
+	}
+	defer res.Close() //nolint:errcheck
+
+	now := cache.now()
+	for r := range res.Next() {
+		if r.Error != nil {
+			return nil, fmt.Errorf("load the bad tipsets: %w", r.Error)
+		}
+		var bad types.BadTipSet
+		if err := json.Unmarshal(r.Value, &bad); err != nil || bad.Key.IsEmpty() {
+			log.Warnf("discard the invalid bad tipset persisted at %s", r.Key)
+			_ = cache.ds.Delete(ctx, datastore.NewKey(r.Key))
+			continue
+		}
+		if expired(&bad, now) {
+			_ = cache.ds.Delete(ctx, datastore.NewKey(r.Key))
+			continue
+		}
+		cache.bad[bad.Key] = &bad
+	}
+	return cache, nil
+}
+
+func expired(bad *types.BadTipSet, now time.Time) bool {
+	return !bad.Expires.IsZero() && !now.Before(bad.Expires)
+}
+
+func badTipSetKey(tsk types.TipSetKey) (datastore.Key, error) {
+	c, err := tsk.Cid()
+	if err != nil {
+		return datastore.Key{}, err
+	}
+	return datastore.NewKey(c.String()), nil
+}
+
+// dsOps are the writes to the datastore of a change of the cache, the tipsets put and the ones deleted
+type dsOps struct {
+	put    []*types.BadTipSet
+	delete []types.TipSetKey
+}
+
+// unlockAndWrite releases mu, which the caller holds, and writes ops to the datastore
+func (cache *BadTipSetCache) unlockAndWrite(ops *dsOps) {
+	if cache.ds == nil || (len(ops.put) == 0 && len(ops.delete) == 0) {
+		cache.mu.Unlock()
+		return
+	}
+	cache.dsMu.Lock()
+	cache.mu.Unlock()
+	defer cache.dsMu.Unlock()
+
+	ctx := context.TODO()
+	batch, err := cache.ds.Batch(ctx)
+	if err != nil {
+		log.Warnf("persist the bad tipsets: %v", err)
+		return
+	}
+	for _, bad := range ops.put {
+		key, err := badTipSetKey(bad.Key)
+		if err == nil {
+			var data []byte
+			if data, err = json.Marshal(bad); err == nil {
+				err = batch.Put(ctx, key, data)
+			}
+		}
+		if err != nil {
+			log.Warnf("persist the bad tipset %s: %v", bad.Key, err)
+		}
+	}
+	for _, tsk := range ops.delete {
+		key, err := badTipSetKey(tsk)
+		if err == nil {
+			err = batch.Delete(ctx, key)
+		}
+		if err != nil {
+			log.Warnf("remove the persisted bad tipset %s: %v", tsk, err)
+		}
+	}
+	if err := batch.Commit(ctx); err != nil {
+		log.Warnf("persist the bad tipsets: %v", err)
 	}
 }
 
-// AddChain adds the chain of tipsets to the BadTipSetCache.  For now it just
-// does the simplest thing and adds all blocks of the chain to the cache.
-// TODO: might want to cache a random subset once cache size is limited.
-func (cache *BadTipSetCache) AddChain(chain []*types.TipSet) {
-	for _, ts := range chain {
-		cache.Add(ts.String())
+// AddChain adds the chain of tipsets to the BadTipSetCache, the first one rejected for reason and the others as
+// its descendants.
+func (cache *BadTipSetCache) AddChain(chain []*types.TipSet, reason string) {
+	cache.mu.Lock()
+	ops := &dsOps{}
+	for i, ts := range chain {
+		why := reason
+		if i > 0 {
+			why = fmt.Sprintf("descends from the bad tipset %s: %s", chain[0].Key(), reason)
+		}
+		cache.add(ops, ts.Key(), ts.Height(), why, false)
 	}
+	cache.unlockAndWrite(ops)
 }
 
-// Add adds a single tipset key to the BadTipSetCache.
-func (cache *BadTipSetCache) Add(tsKey string) {
+// Add adds a single tipset key to the BadTipSetCache, height is 0 when unknown. An operator marking a tipset adds
+// it manually, and it does not expire then.
+func (cache *BadTipSetCache) Add(tsk types.TipSetKey, height abi.ChainEpoch, reason string, manual bool) {
 	cache.mu.Lock()
-	defer cache.mu.Unlock()
-	cache.bad[tsKey] = struct{}{}
+	ops := &dsOps{}
+	cache.add(ops, tsk, height, reason, manual)
+	cache.unlockAndWrite(ops)
+}
+
+func (cache *BadTipSetCache) add(ops *dsOps, tsk types.TipSetKey, height abi.ChainEpoch, reason string, manual bool) {
+	now := cache.now()
+	bad := &types.BadTipSet{Key: tsk, Height: height, Reason: reason, Manual: manual, Added: now}
+	if prev, ok := cache.bad[tsk]; ok && prev.Manual && !manual {
+		// the syncer does not override the mark of an operator
+		return
+	}
+	if !manual && cache.ttl > 0 {
+		bad.Expires = now.Add(cache.ttl)
+	}
+	cache.bad[tsk] = bad
+	ops.put = append(ops.put, bad)
+
+	if len(cache.bad) > MaxBadTipSets {
+		cache.evict(ops)
+	}
+}
+
+// evict drops the oldest tipsets down to 90% of the limit, so that it does not run for every tipset added once full
+func (cache *BadTipSetCache) evict(ops *dsOps) {
+	all := make([]*types.BadTipSet, 0, len(cache.bad))
+	for _, bad := range cache.bad {
+		all = append(all, bad)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Added.Before(all[j].Added) })
+	for _, bad := range all[:len(all)-MaxBadTipSets*9/10] {
+		delete(cache.bad, bad.Key)
+		ops.delete = append(ops.delete, bad.Key)
+	}
 }
 
 // Has checks for membership in the BadTipSetCache.
-func (cache *BadTipSetCache) Has(tsKey string) bool {
+func (cache *BadTipSetCache) Has(tsk types.TipSetKey) bool {
+	_, ok := cache.Check(tsk)
+	return ok
+}
+
+// Check returns why the tipset is bad, false when it is not in the cache or expired
+func (cache *BadTipSetCache) Check(tsk types.TipSetKey) (*types.BadTipSet, bool) {
 	cache.mu.Lock()
-	defer cache.mu.Unlock()
-	_, ok := cache.bad[tsKey]
+	bad, ok := cache.bad[tsk]
+	if !ok {
+		cache.mu.Unlock()
+		return nil, false
+	}
+	if expired(bad, cache.now()) {
+		delete(cache.bad, tsk)
+		cache.unlockAndWrite(&dsOps{delete: []types.TipSetKey{tsk}})
+		return nil, false
+	}
+	out := *bad
+	cache.mu.Unlock()
+	return &out, true
+}
+
+// Remove drops the tipset from the cache, so that the syncer tries it again, and reports whether it was in it
+func (cache *BadTipSetCache) Remove(tsk types.TipSetKey) bool {
+	cache.mu.Lock()
+	_, ok := cache.bad[tsk]
+	delete(cache.bad, tsk)
+	cache.unlockAndWrite(&dsOps{delete: []types.TipSetKey{tsk}})
 	return ok
 }
+
+// Clear drops every tipset of the cache
+func (cache *BadTipSetCache) Clear() {
+	cache.mu.Lock()
+	ops := &dsOps{}
+	for tsk := range cache.bad {
+		ops.delete = append(ops.delete, tsk)
+	}
+	cache.bad = make(map[types.TipSetKey]*types.BadTipSet)
+	cache.unlockAndWrite(ops)
+}
+
+// List returns the tipsets of the cache which did not expire, the latest added first
+func (cache *BadTipSetCache) List() []*types.BadTipSet {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := cache.now()
+	out := make([]*types.BadTipSet, 0, len(cache.bad))
+	for _, bad := range cache.bad {
+		if expired(bad, now) {
+			continue
+		}
+		cp := *bad
+		out = append(out, &cp)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Added.After(out[j].Added) })
+	return out
+}
@@ -2,7 +2,14 @@
 package types
 
 import (
+	"context"
 	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
 
 	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
 	"github.com/filecoin-project/venus/venus-shared/testutil"
@@ -18,15 +25,71 @@ func TestBadTipsetCache(t *testing.T) {
 	testutil.Provide(t, &ts)
 
 	// stm: @CHAINSYNC_TYPES_ADD_CHAIN_001
-	badTSCache.AddChain([]*types.TipSet{&ts})
+	badTSCache.AddChain([]*types.TipSet{&ts}, "invalid")
 
 	var tsKey types.TipSetKey
 	testutil.Provide(t, &tsKey, testutil.WithSliceLen(3))
 
 	// stm: @CHAINSYNC_TYPES_ADD_001
-	badTSCache.Add(tsKey.String())
+	badTSCache.Add(tsKey, 0, "marked", true)
 
 	// stm: @CHAINSYNC_TYPES_HAS_001
-	assert.True(t, badTSCache.Has(ts.Key().String()))
-	assert.True(t, badTSCache.Has(tsKey.String()))
+	assert.True(t, badTSCache.Has(ts.Key()))
+	assert.True(t, badTSCache.Has(tsKey))
+}
+
+func TestBadTipsetCacheReasonsAndExpiry(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	ds := datastore.NewMapDatastore()
+
+	cache, err := LoadBadTipSetCache(ctx, ds, time.Hour)
+	require.NoError(t, err)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	var cids []cid.Cid
+	testutil.Provide(t, &cids, testutil.WithSliceLen(3))
+	parent, child, marked := types.NewTipSetKey(cids[0]), types.NewTipSetKey(cids[1]), types.NewTipSetKey(cids[2])
+
+	cache.Add(parent, 10, "invalid state root", false)
+	cache.Add(marked, 11, "operator", true)
+	// the syncer does not override the mark of an operator
+	cache.Add(marked, 11, "invalid", false)
+
+	bad, ok := cache.Check(parent)
+	require.True(t, ok)
+	assert.Equal(t, "invalid state root", bad.Reason)
+	assert.Equal(t, abi.ChainEpoch(10), bad.Height)
+	assert.Equal(t, now.Add(time.Hour), bad.Expires)
+	bad, ok = cache.Check(marked)
+	require.True(t, ok)
+	assert.True(t, bad.Manual)
+	assert.Equal(t, "operator", bad.Reason)
+	assert.True(t, bad.Expires.IsZero())
+
+	now = now.Add(time.Minute)
+	cache.Add(child, 12, "invalid", false)
+	list := cache.List()
+	require.Len(t, list, 3)
+	assert.Equal(t, child, list[0].Key)
+
+	// persisted, the expired tipsets are dropped when loaded
+	now = now.Add(time.Hour - 30*time.Second)
+	assert.False(t, cache.Has(parent))
+	loaded, err := LoadBadTipSetCache(ctx, ds, time.Hour)
+	require.NoError(t, err)
+	loaded.now = cache.now
+	assert.False(t, loaded.Has(parent))
+	assert.True(t, loaded.Has(child))
+	assert.True(t, loaded.Has(marked))
+
+	assert.True(t, loaded.Remove(marked))
+	assert.False(t, loaded.Remove(marked))
+	loaded.Clear()
+	assert.Empty(t, loaded.List())
+
+	loaded, err = LoadBadTipSetCache(ctx, ds, time.Hour)
+	require.NoError(t, err)
+	assert.Empty(t, loaded.List())
 }
@@ -0,0 +1,280 @@
+package types
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs-force-community/metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var (
+	forkAlerts   = metrics.NewCounter("chainsync/fork_alerts", "Number of heads found competing with the local chain from a fork deeper than the alarm depth")
+	forkHeads    = metrics.NewInt64("chainsync/fork_heads", "Number of heads competing with the local chain within the finality window", "")
+	forkMaxDepth = metrics.NewInt64("chainsync/fork_max_depth", "Depth of the deepest fork of the heads competing with the local chain", "")
+	forkSkipped  = metrics.NewInt64("chainsync/fork_skipped_heads", "Number of heads not compared with the local chain as their ancestors are not stored yet", "")
+)
+
+const (
+	// MaxForkHeads bounds the competing heads tracked, the lowest are dropped first
+	MaxForkHeads = 64
+	// MaxForkAlerts bounds the fork alerts kept
+	MaxForkAlerts = 100
+)
+
+// ForkTracker tracks the heads received from the peers which are not on the local chain, within the finality
+// window below the local head, and raises an alert when one of them forks from the local chain at least depth
+// epochs deep.
+type ForkTracker struct {
+	loadTipSet func(context.Context, types.TipSetKey) (*types.TipSet, error)
+	window     abi.ChainEpoch
+	depth      abi.ChainEpoch
+
+	lk     sync.Mutex
+	heads  map[types.TipSetKey]*forkHead
+	alerts []*types.ForkAlert
+	// local is the local head of the last check, the common ancestors of the heads are those with it
+	local *types.TipSet
+	// skipped are the heads of the last check whose ancestors are not stored yet
+	skipped []types.TipSetKey
+}
+
+type forkHead struct {
+	ts      *types.TipSet
+	peers   map[peer.ID]struct{}
+	alerted bool
+
+	// checked tells that base is the common ancestor with the local head of the last check, nil when it is below
+	// the finality window
+	checked bool
+	base    *types.TipSet
+}
+
+// NewForkTracker creates a fork tracker loading the tipsets with loadTipSet, the heads further than window below the
+// local head are dropped
+func NewForkTracker(loadTipSet func(context.Context, types.TipSetKey) (*types.TipSet, error), window, depth abi.ChainEpoch) *ForkTracker {
+	return &ForkTracker{
+		loadTipSet: loadTipSet,
+		window:     window,
+		depth:      depth,
+		heads:      make(map[types.TipSetKey]*forkHead),
+	}
+}
+
+// AddHead records a head sent by a peer
+func (f *ForkTracker) AddHead(ts *types.TipSet, sender peer.ID) {
+	f.lk.Lock()
+	defer f.lk.Unlock()
+
+	head, ok := f.heads[ts.Key()]
+	if !ok {
+		head = &forkHead{ts: ts, peers: make(map[peer.ID]struct{})}
+		f.heads[ts.Key()] = head
+	}
+	if sender != "" {
+		head.peers[sender] = struct{}{}
+	}
+
+	if len(f.heads) > MaxForkHeads {
+		var lowest *forkHead
+		for _, h := range f.heads {
+			if lowest == nil || h.ts.Height() < lowest.ts.Height() {
+				lowest = h
+			}
+		}
+		delete(f.heads, lowest.ts.Key())
+	}
+}
+
+// Check compares the heads tracked with the local head. The heads on the local chain or below the finality window
+// are dropped, the ones whose parents are not yet stored are reported and checked again with the next local head. An
+// alert is raised once for every head with a fork at least as deep as the alarm depth.
+//
+// The common ancestors are kept while the local head extends the one of the previous check, so that only the heads
+// added since are walked down to the local chain.
+func (f *ForkTracker) Check(ctx context.Context, local *types.TipSet) {
+	minHeight := local.Height() - f.window
+
+	f.lk.Lock()
+	prev := f.local
+	f.local = local
+	heads := make([]*forkHead, 0, len(f.heads))
+	for key, h := range f.heads {
+		if h.ts.Height() < minHeight {
+			delete(f.heads, key)
+			continue
+		}
+		heads = append(heads, h)
+	}
+	f.lk.Unlock()
+
+	extends := prev != nil && f.extends(ctx, local, prev, minHeight)
+	var competing int64
+	var maxDepth abi.ChainEpoch
+	var skipped []types.TipSetKey
+	for _, h := range heads {
+		base, ok, err := f.cachedAncestor(ctx, h, prev, local, extends, minHeight)
+		if err != nil {
+			log.Debugf("find the common ancestor of %s and the local head: %v", h.ts.Key(), err)
+			skipped = append(skipped, h.ts.Key())
+			continue
+		}
+		if ok && base.Equals(h.ts) {
+			// the head is on the local chain
+			f.lk.Lock()
+			delete(f.heads, h.ts.Key())
+			f.lk.Unlock()
+			continue
+		}
+
+		depth := f.window
+		var baseKey types.TipSetKey
+		if ok {
+			depth = forkDepth(base, h.ts, local)
+			baseKey = base.Key()
+		}
+		if depth <= 0 {
+			// the local head is an ancestor of the head
+			continue
+		}
+		competing++
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+		if f.depth <= 0 || depth < f.depth {
+			continue
+		}
+
+		f.lk.Lock()
+		if h.alerted {
+			f.lk.Unlock()
+			continue
+		}
+		h.alerted = true
+		alert := &types.ForkAlert{
+			Head:        h.ts.Key(),
+			Height:      h.ts.Height(),
+			Weight:      h.ts.ParentWeight(),
+			LocalHead:   local.Key(),
+			LocalHeight: local.Height(),
+			LocalWeight: local.ParentWeight(),
+			Base:        baseKey,
+			Depth:       depth,
+			Peers:       sortedPeers(h.peers),
+			Found:       time.Now(),
+		}
+		f.alerts = append(f.alerts, alert)
+		if len(f.alerts) > MaxForkAlerts {
+			f.alerts = f.alerts[len(f.alerts)-MaxForkAlerts:]
+		}
+		f.lk.Unlock()
+
+		forkAlerts.Tick(ctx)
+		log.Warnw("fork deeper than the alarm depth", "head", alert.Head, "height", alert.Height, "weight", alert.Weight,
+			"localHead", alert.LocalHead, "localHeight", alert.LocalHeight, "localWeight", alert.LocalWeight,
+			"base", alert.Base, "depth", alert.Depth, "peers", alert.Peers)
+	}
+
+	f.lk.Lock()
+	f.skipped = skipped
+	f.lk.Unlock()
+	if len(skipped) > 0 {
+		log.Infow("heads not compared with the local chain, their ancestors are not stored yet", "heads", skipped,
+			"localHead", local.Key(), "localHeight", local.Height())
+	}
+
+	forkHeads.Set(ctx, competing)
+	forkMaxDepth.Set(ctx, int64(maxDepth))
+	forkSkipped.Set(ctx, int64(len(skipped)))
+}
+
+// Skipped returns the heads of the last check whose ancestors are not stored yet
+func (f *ForkTracker) Skipped() []types.TipSetKey {
+	f.lk.Lock()
+	defer f.lk.Unlock()
+	return append([]types.TipSetKey(nil), f.skipped...)
+}
+
+// Alerts returns the fork alerts raised, the oldest first
+func (f *ForkTracker) Alerts() []*types.ForkAlert {
+	f.lk.Lock()
+	defer f.lk.Unlock()
+
+	out := make([]*types.ForkAlert, 0, len(f.alerts))
+	for _, alert := range f.alerts {
+		cpy := *alert
+		cpy.Peers = append([]peer.ID(nil), alert.Peers...)
+		out = append(out, &cpy)
+	}
+	return out
+}
+
+// cachedAncestor returns the common ancestor of the head and the local head, the one found with the previous local
+// head is kept if local extends it. It is found again if the head descends from the previous local head, its branch
+// may then fork from the blocks added to the local chain since.
+func (f *ForkTracker) cachedAncestor(ctx context.Context, h *forkHead, prev, local *types.TipSet, extends bool, minHeight abi.ChainEpoch) (*types.TipSet, bool, error) {
+	f.lk.Lock()
+	checked, base := h.checked, h.base
+	f.lk.Unlock()
+	if checked && extends && (base == nil || !base.Equals(prev)) {
+		return base, base != nil, nil
+	}
+
+	base, ok, err := f.commonAncestor(ctx, h.ts, local, minHeight)
+	f.lk.Lock()
+	h.checked, h.base = err == nil, base
+	f.lk.Unlock()
+	return base, ok, err
+}
+
+// extends tells whether ts descends from ancestor, or is ancestor
+func (f *ForkTracker) extends(ctx context.Context, ts, ancestor *types.TipSet, minHeight abi.ChainEpoch) bool {
+	var err error
+	for ts.Height() > ancestor.Height() && ts.Height() >= minHeight {
+		if ts, err = f.loadTipSet(ctx, ts.Parents()); err != nil {
+			return false
+		}
+	}
+	return ts.Equals(ancestor)
+}
+
+// commonAncestor walks both chains down to their common ancestor, ok is false when it is below minHeight
+func (f *ForkTracker) commonAncestor(ctx context.Context, a, b *types.TipSet, minHeight abi.ChainEpoch) (*types.TipSet, bool, error) {
+	var err error
+	for !a.Equals(b) {
+		if a.Height() < minHeight || b.Height() < minHeight {
+			return nil, false, nil
+		}
+		if a.Height() >= b.Height() {
+			if a, err = f.loadTipSet(ctx, a.Parents()); err != nil {
+				return nil, false, err
+			}
+		} else if b, err = f.loadTipSet(ctx, b.Parents()); err != nil {
+			return nil, false, err
+		}
+	}
+	return a, true, nil
+}
+
+// forkDepth is the number of epochs both branches have above the base
+func forkDepth(base, head, local *types.TipSet) abi.ChainEpoch {
+	top := head.Height()
+	if local.Height() < top {
+		top = local.Height()
+	}
+	return top - base.Height()
+}
+
+func sortedPeers(peers map[peer.ID]struct{}) []peer.ID {
+	out := make([]peer.ID, 0, len(peers))
+	for p := range peers {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
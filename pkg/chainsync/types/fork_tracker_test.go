@@ -0,0 +1,107 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestForkTracker(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	builder := chain.NewBuilder(t, address.Undef)
+	base := builder.AppendManyOn(ctx, 5, builder.Genesis())
+	local := builder.AppendManyOn(ctx, 10, base)
+	deep := builder.AppendManyOn(ctx, 8, base)
+	parent, err := builder.GetTipSet(ctx, local.Parents())
+	require.NoError(t, err)
+	shallow := builder.AppendOn(ctx, parent, 1)
+	ahead := builder.AppendOn(ctx, local, 1)
+	// the parent of the head is not stored yet
+	unknown := builder.AppendOn(ctx, local, 1)
+	missing := builder.AppendOn(ctx, unknown, 1)
+	var loads int
+	loadTipSet := func(ctx context.Context, key types.TipSetKey) (*types.TipSet, error) {
+		loads++
+		if key == unknown.Key() {
+			return nil, fmt.Errorf("no tipset %s", key)
+		}
+		return builder.GetTipSet(ctx, key)
+	}
+
+	tracker := NewForkTracker(loadTipSet, 900, 5)
+	tracker.AddHead(deep, "peer1")
+	tracker.AddHead(deep, "peer2")
+	tracker.AddHead(deep, "peer1")
+	tracker.AddHead(shallow, "peer3")
+	tracker.AddHead(ahead, "peer3")
+	tracker.AddHead(parent, "peer4")
+	tracker.AddHead(missing, "peer4")
+
+	tracker.Check(ctx, local)
+	alerts := tracker.Alerts()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, deep.Key(), alerts[0].Head)
+	assert.Equal(t, deep.Height(), alerts[0].Height)
+	assert.Equal(t, local.Key(), alerts[0].LocalHead)
+	assert.Equal(t, base.Key(), alerts[0].Base)
+	assert.Equal(t, abi.ChainEpoch(8), alerts[0].Depth)
+	assert.Equal(t, deep.ParentWeight(), alerts[0].Weight)
+	assert.Len(t, alerts[0].Peers, 2)
+
+	// the heads on the local chain are dropped, the others kept
+	tracker.lk.Lock()
+	assert.Len(t, tracker.heads, 4)
+	assert.NotContains(t, tracker.heads, parent.Key())
+	tracker.lk.Unlock()
+	// the head whose parent is missing is reported
+	assert.Equal(t, []types.TipSetKey{missing.Key()}, tracker.Skipped())
+
+	// an alert is raised once for a head
+	tracker.Check(ctx, local)
+	assert.Len(t, tracker.Alerts(), 1)
+
+	// the common ancestors are kept while the local chain grows, only the heads descending from the previous local
+	// head and the ones skipped are walked again
+	next := builder.AppendOn(ctx, local, 1)
+	loads = 0
+	tracker.Check(ctx, next)
+	// one load checks that next extends local, two walk ahead and next down to local, one fails on the missing parent
+	assert.Equal(t, 4, loads)
+	tracker.lk.Lock()
+	assert.Equal(t, base.Key(), tracker.heads[deep.Key()].base.Key())
+	assert.True(t, tracker.heads[ahead.Key()].checked)
+	assert.False(t, tracker.heads[missing.Key()].checked)
+	tracker.lk.Unlock()
+
+	// they are all found again after a reorg of the local chain
+	loads = 0
+	tracker.Check(ctx, shallow)
+	assert.Greater(t, loads, 10)
+	assert.Len(t, tracker.Alerts(), 1)
+
+	// the fork is deeper than the window
+	narrow := NewForkTracker(loadTipSet, 3, 3)
+	narrow.AddHead(deep, "peer1")
+	narrow.Check(ctx, local)
+	alerts = narrow.Alerts()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, abi.ChainEpoch(3), alerts[0].Depth)
+	assert.Equal(t, types.EmptyTSK, alerts[0].Base)
+
+	// the heads below the window are dropped
+	narrow.Check(ctx, builder.AppendManyOn(ctx, 10, local))
+	narrow.lk.Lock()
+	assert.Empty(t, narrow.heads)
+	narrow.lk.Unlock()
+}
@@ -25,14 +25,56 @@ var log = logging.Logger("chainsync.target")
 // Target tracks a logical request of the syncing subsystem to run a
 // syncing job against given inputs.
 type Target struct {
-	State   SyncStateStage
+	State SyncStateStage
+	// Stage is the step of the sync the target waits on once State is StateInSyncing: the headers, the messages or
+	// the validation and the execution of the tipsets
+	Stage   types.SyncStateStage
 	Base    *types.TipSet
 	Current *types.TipSet
+	// Fetched is the highest tipset whose messages are fetched, the tipsets up to it are validated after Current
+	Fetched *types.TipSet
 	Start   time.Time
 	End     time.Time
 	Err     error
 	Head    *types.TipSet
 	Sender  peer.ID
+	// Preempted is set when the sync is canceled for a heavier target
+	Preempted bool
+
+	// lk guards the fields written while the target is synced, see Update and Snapshot
+	lk sync.Mutex
+}
+
+// Update applies f to the target under its lock, the fields written while the target is synced are set through it
+func (target *Target) Update(f func(t *Target)) {
+	target.lk.Lock()
+	defer target.lk.Unlock()
+	f(target)
+}
+
+// Snapshot returns a copy of the target taken under its lock, the copy can be read while the target is synced
+func (target *Target) Snapshot() *Target {
+	target.lk.Lock()
+	defer target.lk.Unlock()
+	return &Target{
+		State:     target.State,
+		Stage:     target.Stage,
+		Base:      target.Base,
+		Current:   target.Current,
+		Fetched:   target.Fetched,
+		Start:     target.Start,
+		End:       target.End,
+		Err:       target.Err,
+		Head:      target.Head,
+		Sender:    target.Sender,
+		Preempted: target.Preempted,
+	}
+}
+
+func (target *Target) isIdle() bool {
+	target.lk.Lock()
+	defer target.lk.Unlock()
+	return target.State == StageIdle
 }
 
 // IsNeighbor the target t is neighbor or not
@@ -214,7 +256,7 @@ func (tq *TargetTracker) Add(t *Target) bool {
 	var replaceTarget *Target
 	// try to replace a idea child target
 	for i := len(tq.q) - 1; i > -1; i-- {
-		if t.HasChild(tq.q[i]) && tq.q[i].State == StageIdle {
+		if t.HasChild(tq.q[i]) && tq.q[i].isIdle() {
 			replaceTarget = tq.q[i]
 			replaceIndex = i
 			log.Infof("%s replace a child target at %d", t.Head.String(), i)
@@ -225,7 +267,7 @@ func (tq *TargetTracker) Add(t *Target) bool {
 	if replaceTarget == nil {
 		// replace a least weight idle
 		for i := len(tq.q) - 1; i > -1; i-- {
-			if tq.q[i].State == StageIdle {
+			if tq.q[i].isIdle() {
 				replaceTarget = tq.q[i]
 				replaceIndex = i
 				log.Infof("%s replace a idle target at %d", t.Head.String(), i)
@@ -363,7 +405,7 @@ func (tq *TargetTracker) Select() (*Target, bool) {
 	}
 	var toSyncTarget *Target
 	for _, target := range tq.q {
-		if target.State == StageIdle {
+		if target.isIdle() {
 			toSyncTarget = target
 			break
 		}
@@ -386,7 +428,7 @@ func (tq *TargetTracker) Remove(t *Target) {
 			break
 		}
 	}
-	t.End = time.Now()
+	t.Update(func(t *Target) { t.End = time.Now() })
 	if tq.history.Len() > tq.historySize {
 		tq.history.Remove(tq.history.Front()) // remove olddest
 		popKey := tq.history.Front().Value.(*Target).Head.String()
@@ -413,9 +455,11 @@ func (tq *TargetTracker) Len() int {
 	return tq.q.Len()
 }
 
-// Buckets returns the number of targets in the queue.
+// Buckets returns a copy of the targets in the queue.
 func (tq *TargetTracker) Buckets() TargetBuckets {
-	return tq.q
+	tq.lk.Lock()
+	defer tq.lk.Unlock()
+	return append(make(TargetBuckets, 0, len(tq.q)), tq.q...)
 }
 
 // TargetBuckets orders targets by a policy.
@@ -0,0 +1,40 @@
+// stm: #unit
+package types
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestTargetSnapshot(t *testing.T) {
+	tf.UnitTest(t)
+
+	target := &Target{State: StateInSyncing}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for _, stage := range []types.SyncStateStage{types.StageHeaders, types.StageFetchingMessages, types.StageMessages} {
+			target.Update(func(t *Target) { t.Stage = stage })
+		}
+		target.Update(func(t *Target) { t.State = StageSyncComplete })
+	}()
+	for i := 0; i < 10; i++ {
+		_ = target.Snapshot().Stage
+	}
+	wg.Wait()
+
+	snapshot := target.Snapshot()
+	require.Equal(t, types.StageMessages, snapshot.Stage)
+	require.Equal(t, StageSyncComplete, snapshot.State)
+
+	// the snapshot is a copy, later updates do not change it
+	target.Update(func(t *Target) { t.Stage = types.StageIdle })
+	require.Equal(t, types.StageMessages, snapshot.Stage)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"os"
 	"reflect"
 	"regexp"
@@ -43,6 +44,14 @@ type Config struct {
 	EventsConfig  *EventsConfig        `json:"events"`
 	PubsubConfig  *PubsubConfig        `json:"pubsub"`
 	FaultReporter *FaultReporterConfig `json:"faultReporter"`
+	ChainGC       *ChainGCConfig       `json:"chainGC"`
+	ChainOffload  *ChainOffloadConfig  `json:"chainOffload"`
+	Validation    *ValidationConfig    `json:"validation"`
+	ForkAlarm     *ForkAlarmConfig     `json:"forkAlarm"`
+	SupplyHistory *SupplyHistoryConfig `json:"supplyHistory"`
+	EventBus      *EventBusConfig      `json:"eventBus"`
+	DevnetMining  *DevnetMiningConfig  `json:"devnetMining"`
+	Checkpoint    *CheckpointConfig    `json:"checkpoint"`
 }
 
 // APIConfig holds all configuration options related to the api.
@@ -54,6 +63,8 @@ type APIConfig struct {
 	AccessControlAllowOrigin      []string `json:"accessControlAllowOrigin"`
 	AccessControlAllowCredentials bool     `json:"accessControlAllowCredentials"`
 	AccessControlAllowMethods     []string `json:"accessControlAllowMethods"`
+	// StateReadPoolSize is the number of heavy rpc state reads allowed to run at the same time
+	StateReadPoolSize int `json:"stateReadPoolSize"`
 }
 
 type RateLimitCfg struct {
@@ -73,6 +84,7 @@ func newDefaultAPIConfig() *APIConfig {
 			"https://127.0.0.1:8080",
 		},
 		AccessControlAllowMethods: []string{"GET", "POST", "PUT"},
+		StateReadPoolSize:         8,
 	}
 }
 
@@ -81,6 +93,25 @@ func newDefaultAPIConfig() *APIConfig {
 type DatastoreConfig struct {
 	Type string `json:"type"`
 	Path string `json:"path"`
+	// Profile tunes the badger options of the blockstore: ssd-large, ssd-small or archival, the badger defaults
+	// are used when empty
+	Profile string `json:"profile"`
+	// ValueLogFileSize, NumCompactors and BlockCacheSize override the ones of the profile when set
+	ValueLogFileSize int64 `json:"valueLogFileSize,omitempty"`
+	NumCompactors    int   `json:"numCompactors,omitempty"`
+	BlockCacheSize   int64 `json:"blockCacheSize,omitempty"`
+	// GCInterval runs the value log garbage collection of the blockstore periodically, 0 disables it
+	GCInterval Duration `json:"gcInterval"`
+	// GCDiscardRatio is the part of a value log file which must be garbage for the collection to rewrite it
+	GCDiscardRatio float64 `json:"gcDiscardRatio"`
+	// ScrubInterval hashes every block of the blockstore again periodically to find the corrupt ones, 0 disables it
+	ScrubInterval Duration `json:"scrubInterval"`
+	// ScrubRate limits the bytes read per second by the periodic scrub, 0 is unlimited
+	ScrubRate int64 `json:"scrubRate"`
+	// ScrubRepair fetches the corrupt blocks found by the periodic scrub from the peers to replace them
+	ScrubRepair bool `json:"scrubRepair"`
+	// StagedObjQuota bounds the bytes of the objects put in the blockstore through the api, 0 is unlimited
+	StagedObjQuota int64 `json:"stagedObjQuota"`
 }
 
 // Validators hold the list of validation functions for each configuration
@@ -94,8 +125,12 @@ var Validators = map[string]func(string, string) error{
 
 func newDefaultDatastoreConfig() *DatastoreConfig {
 	return &DatastoreConfig{
-		Type: "badgerds",
-		Path: "badger",
+		Type:           "badgerds",
+		Path:           "badger",
+		GCDiscardRatio: 0.5,
+		ScrubRate:      32 << 20,
+		ScrubRepair:    true,
+		StagedObjQuota: 1 << 30,
 	}
 }
 
@@ -166,6 +201,33 @@ type WalletConfig struct {
 	PassphraseConfig PassphraseConfig `json:"passphraseConfig,omitempty"`
 	RemoteEnable     bool             `json:"remoteEnable"`
 	RemoteBackend    string           `json:"remoteBackend"`
+	// KMSKeys are the secp256k1 addresses whose keys are kept by a key management service, which signs for them
+	KMSKeys []KMSKeyConfig `json:"kmsKeys,omitempty"`
+	// TxHistory records the messages sent by and to the wallet addresses, served by WalletTxHistory
+	TxHistory TxHistoryConfig `json:"txHistory"`
+}
+
+type TxHistoryConfig struct {
+	Enable bool `json:"enable"`
+	// Lookback is the number of epochs below the head indexed when the node starts, the index resumes from the last
+	// tipset indexed when it is closer
+	Lookback abi.ChainEpoch `json:"lookback"`
+}
+
+// KMSKeyConfig binds a secp256k1 address to the key of a key management service. The credentials are taken from the
+// environment, VAULT_TOKEN for vault and AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN for AWS KMS.
+type KMSKeyConfig struct {
+	Address address.Address `json:"address"`
+	// Backend is vault for the transit engine of HashiCorp Vault, or awskms for AWS KMS
+	Backend string `json:"backend"`
+	// KeyID is the name of the transit key, or the id, arn or alias of the KMS key
+	KeyID string `json:"keyID"`
+	// Endpoint is the url of vault, or of KMS when not the endpoint of the region
+	Endpoint string `json:"endpoint,omitempty"`
+	// Mount is the path of the transit engine, transit by default
+	Mount string `json:"mount,omitempty"`
+	// Region is the region of the KMS key, AWS_REGION by default
+	Region string `json:"region,omitempty"`
 }
 
 type PassphraseConfig struct {
@@ -177,6 +239,9 @@ func newDefaultWalletConfig() *WalletConfig {
 	return &WalletConfig{
 		DefaultAddress:   address.Undef,
 		PassphraseConfig: DefaultPassphraseConfig(),
+		TxHistory: TxHistoryConfig{
+			Lookback: 2880,
+		},
 	}
 }
 
@@ -272,6 +337,8 @@ type MessagePoolConfig struct {
 	MaxNonceGap uint64 `json:"maxNonceGap"`
 	// MaxFee
 	MaxFee types.FIL `json:"maxFee"`
+	// GasStatsEpochs is the number of recent epochs the gas used per actor method is collected over, 0 disables it
+	GasStatsEpochs uint64 `json:"gasStatsEpochs"`
 }
 
 var DefaultMessagePoolParam = &MessagePoolConfig{
@@ -282,7 +349,8 @@ var DefaultMessagePoolParam = &MessagePoolConfig{
 func newDefaultMessagePoolConfig() *MessagePoolConfig {
 	return &MessagePoolConfig{
 		MaxNonceGap: 100,
-		MaxFee:      DefaultDefaultMaxFee,
+		// a copy, decoding a config into the default would change it
+		MaxFee: types.FIL{Int: new(big.Int).Set(DefaultDefaultMaxFee.Int)},
 	}
 }
 
@@ -445,6 +513,10 @@ type EventConfig struct {
 	// MaxFilters specifies the maximum number of filters that may exist at any one time.
 	MaxFilters int `json:"maxFilters"`
 
+	// MaxFiltersPerToken specifies the maximum number of filters that may be installed by a single api token
+	// at any one time, 0 means filters are only limited by MaxFilters.
+	MaxFiltersPerToken int `json:"maxFiltersPerToken"`
+
 	// MaxFilterResults specifies the maximum number of results that can be accumulated by an actor event filter.
 	MaxFilterResults int `json:"maxFilterResults"`
 
@@ -491,6 +563,7 @@ func newFevmConfig() *FevmConfig {
 			DisableHistoricFilterAPI: false,
 			FilterTTL:                Duration(time.Hour * 24),
 			MaxFilters:               100,
+			MaxFiltersPerToken:       20,
 			MaxFilterResults:         10000,
 			MaxFilterHeightRange:     2880, // conservative limit of one day
 		},
@@ -506,10 +579,23 @@ func newEventsConfig() *EventsConfig {
 type PubsubConfig struct {
 	// Run the node in bootstrap-node mode
 	Bootstrapper bool `json:"bootstrapper"`
+
+	// IPColocationThreshold is the number of peers sharing an ip before they are penalized
+	IPColocationThreshold int `json:"ipColocationThreshold"`
+	// IPColocationWeight weights the square of the number of peers above the threshold, 0 disables the penalty
+	IPColocationWeight float64 `json:"ipColocationWeight"`
+	// IPColocationWhitelist lists the subnets, in CIDR notation, which peers are never penalized for colocation
+	IPColocationWhitelist []string `json:"ipColocationWhitelist"`
+	// TopicScoreCap caps the positive score a peer gets from all the topics, 0 leaves it uncapped
+	TopicScoreCap float64 `json:"topicScoreCap"`
 }
 
 func newPubsubConfig() *PubsubConfig {
-	return &PubsubConfig{Bootstrapper: false}
+	return &PubsubConfig{
+		Bootstrapper:          false,
+		IPColocationThreshold: 5,
+		IPColocationWeight:    -100,
+	}
 }
 
 type FaultReporterConfig struct {
@@ -536,6 +622,182 @@ func newFaultReporterConfig() *FaultReporterConfig {
 	return &FaultReporterConfig{}
 }
 
+type ChainGCConfig struct {
+	// Enable deletes the blocks and state roots of the orphaned chain branches in the background
+	Enable bool `json:"enable"`
+	// DryRun only counts the orphaned objects, without deleting them
+	DryRun bool `json:"dryRun"`
+	// Depth is the number of epochs below the head the orphaned tipsets are kept, at least the fork length threshold
+	Depth abi.ChainEpoch `json:"depth"`
+	// Interval is the number of epochs between two collections
+	Interval abi.ChainEpoch `json:"interval"`
+}
+
+func newChainGCConfig() *ChainGCConfig {
+	return &ChainGCConfig{
+		Enable:   false,
+		DryRun:   false,
+		Depth:    constants.Finality,
+		Interval: 120,
+	}
+}
+
+type ChainOffloadConfig struct {
+	// Enable moves the headers, messages and receipts of the tipsets deeper than Depth below the head to the S3
+	// bucket, they are read back from it transparently. The state trees are kept in the local blockstore.
+	Enable bool `json:"enable"`
+	// Depth is the number of epochs below the head the chain data is kept locally, at least the finality
+	Depth abi.ChainEpoch `json:"depth"`
+	// Interval is the number of epochs between two offloads
+	Interval abi.ChainEpoch `json:"interval"`
+	// PromoteOnRead copies the offloaded blocks read back to the local blockstore
+	PromoteOnRead bool      `json:"promoteOnRead"`
+	S3            *S3Config `json:"s3"`
+}
+
+type S3Config struct {
+	// Endpoint is the url of the S3-compatible storage, such as https://s3.us-east-1.amazonaws.com
+	Endpoint string `json:"endpoint"`
+	Region   string `json:"region"`
+	Bucket   string `json:"bucket"`
+	// Prefix is prepended to the keys of the objects
+	Prefix string `json:"prefix"`
+	// AccessKeyID and SecretAccessKey are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY when empty
+	AccessKeyID     string `json:"accessKeyID"`
+	SecretAccessKey string `json:"secretAccessKey"`
+	// StorageClass is the storage class of the objects written, such as STANDARD_IA, the default of the bucket when
+	// empty. The transitions and expiration of the objects are left to the lifecycle rules of the bucket.
+	StorageClass string `json:"storageClass"`
+	// PathStyle addresses the bucket in the path of the urls, which most self-hosted storages need
+	PathStyle bool `json:"pathStyle"`
+}
+
+func newChainOffloadConfig() *ChainOffloadConfig {
+	return &ChainOffloadConfig{
+		Enable:        false,
+		Depth:         7 * 2880, // a week
+		Interval:      constants.Finality,
+		PromoteOnRead: true,
+		S3: &S3Config{
+			Region: "us-east-1",
+			Prefix: "venus/",
+		},
+	}
+}
+
+type ValidationConfig struct {
+	// SigVerifyWorkers is the number of goroutines verifying the message signatures of the blocks, 0 uses all the CPUs
+	SigVerifyWorkers int `json:"sigVerifyWorkers"`
+	// BadTipSetTTL is how long a tipset which failed to sync is refused before the syncer tries it again, 0 refuses
+	// it until the operator unmarks it. The bad tipsets are kept in the metadata datastore across restarts.
+	BadTipSetTTL Duration `json:"badTipSetTTL"`
+}
+
+func newValidationConfig() *ValidationConfig {
+	return &ValidationConfig{
+		SigVerifyWorkers: 0,
+		BadTipSetTTL:     Duration(24 * time.Hour),
+	}
+}
+
+type ForkAlarmConfig struct {
+	// Depth is the number of epochs a head received must fork from the local chain below both heads to raise an
+	// alert, 0 disables the tracking of the competing heads
+	Depth abi.ChainEpoch `json:"depth"`
+}
+
+func newForkAlarmConfig() *ForkAlarmConfig {
+	return &ForkAlarmConfig{
+		Depth: 5,
+	}
+}
+
+type SupplyHistoryConfig struct {
+	// Persist writes the computed supplies to the metadata datastore, so that they survive restarts
+	Persist bool `json:"persist"`
+}
+
+func newSupplyHistoryConfig() *SupplyHistoryConfig {
+	return &SupplyHistoryConfig{
+		Persist: false,
+	}
+}
+
+const (
+	EventSinkWebhook = "webhook"
+	EventSinkNats    = "nats"
+	EventSinkKafka   = "kafka"
+)
+
+type EventSinkConfig struct {
+	// Type is one of webhook, nats or kafka
+	Type string `json:"type"`
+	// URL is the endpoint the webhook posts to, the url of the nats server, or the kafka rest proxy. The nats urls
+	// are nats://[user:password@]host:port or nats://token@host:port, tls:// connects with tls.
+	URL string `json:"url"`
+	// Topic is the kafka topic, or the prefix of the nats subjects followed by the event type
+	Topic string `json:"topic"`
+	// Events are the event types pushed to the sink, all of them if empty: head_apply, head_revert, message_executed
+	// and actor_event
+	Events []string `json:"events"`
+}
+
+type EventBusConfig struct {
+	// Sinks receive the head changes, the executed messages and the actor events as they are applied
+	Sinks []*EventSinkConfig `json:"sinks"`
+	// QueueSize is the number of tipsets buffered for a sink, it must be positive. The events of the newer tipsets are
+	// dropped when it is full
+	QueueSize int `json:"queueSize"`
+}
+
+func newEventBusConfig() *EventBusConfig {
+	return &EventBusConfig{
+		Sinks:     []*EventSinkConfig{},
+		QueueSize: 64,
+	}
+}
+
+type DevnetMiningConfig struct {
+	// Enable lets the node produce the blocks of its miners itself, through the MineOne api or automatically. It is
+	// only allowed on the 2k and force networks, with INSECURE_POST_VALIDATION=1 as venus does not compute the
+	// winning PoSts.
+	Enable bool `json:"enable"`
+	// Miner produces a block automatically every Interval when set
+	Miner address.Address `json:"miner,omitempty"`
+	// Interval is the time between two blocks produced automatically, the block delay when 0
+	Interval Duration `json:"interval"`
+	// MaxNullRounds is the number of rounds in a row the miner may lose before producing a block fails
+	MaxNullRounds abi.ChainEpoch `json:"maxNullRounds"`
+}
+
+func newDevnetMiningConfig() *DevnetMiningConfig {
+	return &DevnetMiningConfig{
+		Enable:        false,
+		MaxNullRounds: 100,
+	}
+}
+
+type CheckpointConfig struct {
+	// Endpoints are the urls of the trusted services serving a signed recent checkpoint, a new repo bootstraps its
+	// chain from the checkpoint when set, instead of syncing from the genesis
+	Endpoints []string `json:"endpoints"`
+	// TrustedSigners are the key addresses the checkpoints must be signed by
+	TrustedSigners []address.Address `json:"trustedSigners"`
+	// Threshold is the number of endpoints which must serve the same checkpoint
+	Threshold int `json:"threshold"`
+	// Timeout bounds the request of a checkpoint to an endpoint
+	Timeout Duration `json:"timeout"`
+}
+
+func newCheckpointConfig() *CheckpointConfig {
+	return &CheckpointConfig{
+		Endpoints:      []string{},
+		TrustedSigners: []address.Address{},
+		Threshold:      1,
+		Timeout:        Duration(30 * time.Second),
+	}
+}
+
 // NewDefaultConfig returns a config object with all the fields filled out to
 // their default values
 func NewDefaultConfig() *Config {
@@ -554,6 +816,14 @@ func NewDefaultConfig() *Config {
 		EventsConfig:  newEventsConfig(),
 		PubsubConfig:  newPubsubConfig(),
 		FaultReporter: newFaultReporterConfig(),
+		ChainGC:       newChainGCConfig(),
+		ChainOffload:  newChainOffloadConfig(),
+		Validation:    newValidationConfig(),
+		ForkAlarm:     newForkAlarmConfig(),
+		SupplyHistory: newSupplyHistoryConfig(),
+		EventBus:      newEventBusConfig(),
+		DevnetMining:  newDevnetMiningConfig(),
+		Checkpoint:    newCheckpointConfig(),
 	}
 }
 
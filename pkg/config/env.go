@@ -0,0 +1,234 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EnvPrefix is the prefix of the environment variables overriding the config fields
+const EnvPrefix = "VENUS"
+
+// EnvVar is the environment variable of a config field. Its name is made of the json tags of the field and of its
+// sections in upper snake case, e.g. VENUS_API_API_ADDRESS for api.apiAddress, an `env:"-"` tag leaves a field out.
+//
+// The variables take precedence over the config file, which takes precedence over the defaults. A value is the json
+// of the field, the strings and the durations may be left unquoted, a bool may be 1 or 0 and a list of strings may be
+// comma separated.
+type EnvVar struct {
+	Name string
+	// Key is the dotted key of the field, as taken by Config.Get and Config.Set
+	Key  string
+	Type reflect.Type
+	// Deprecated are the former names of the variable, read when Name is not set
+	Deprecated []string
+}
+
+// EnvOverride is a config field set from its environment variable
+type EnvOverride struct {
+	EnvVar
+	// File is the json of the field before the override, Applied the one after
+	File    json.RawMessage
+	Applied json.RawMessage
+}
+
+// deprecatedEnvNames are the former names of the variables of the fields, by their keys
+var deprecatedEnvNames = map[string][]string{
+	// read by constants.FevmEnableEthRPC before the fields had their variables
+	"fevm.enableEthRPC": {"VENUS_FEVM_ENABLEETHRPC"},
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// EnvVars lists the environment variables of the config fields, in the order of the fields
+func EnvVars() []EnvVar {
+	var vars []EnvVar
+	collectEnvVars(reflect.TypeOf(Config{}), EnvPrefix, "", &vars)
+	return vars
+}
+
+func collectEnvVars(t reflect.Type, name, key string, vars *[]EnvVar) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("env") == "-" {
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+		if tag == "" {
+			tag = field.Name
+		}
+
+		fieldName, fieldKey := name+"_"+envSegment(tag), tag
+		if key != "" {
+			fieldKey = key + "." + tag
+		}
+
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && !isJSONLeaf(field.Type) {
+			collectEnvVars(ft, fieldName, fieldKey, vars)
+			continue
+		}
+		*vars = append(*vars, EnvVar{Name: fieldName, Key: fieldKey, Type: field.Type, Deprecated: deprecatedEnvNames[fieldKey]})
+	}
+}
+
+// isJSONLeaf tells whether the type decodes itself instead of being decoded field by field
+func isJSONLeaf(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	if t.Kind() == reflect.Ptr {
+		pt = t
+	}
+	return pt.Implements(jsonUnmarshalerType) || pt.Implements(textUnmarshalerType)
+}
+
+// envSegment turns a json tag into upper snake case, apiAddress into API_ADDRESS and venusAuthURL into VENUS_AUTH_URL
+func envSegment(tag string) string {
+	var b strings.Builder
+	rs := []rune(tag)
+	for i, r := range rs {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			b.WriteByte('_')
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) {
+			prev := rs[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(rs) && unicode.IsLower(rs[i+1])) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// ApplyEnv sets the config fields whose environment variable is found by lookup, usually os.LookupEnv, and
+// returns the overrides
+func (cfg *Config) ApplyEnv(lookup func(string) (string, bool)) ([]EnvOverride, error) {
+	var overrides []EnvOverride
+	for _, ev := range EnvVars() {
+		value, ok := lookup(ev.Name)
+		for _, name := range ev.Deprecated {
+			if ok {
+				break
+			}
+			value, ok = lookup(name)
+		}
+		if !ok {
+			continue
+		}
+
+		file, err := cfg.fieldJSON(ev.Key)
+		if err != nil {
+			return nil, err
+		}
+		if err := cfg.Set(ev.Key, envValueJSON(ev.Type, value)); err != nil {
+			return nil, fmt.Errorf("set %s from %s: %w", ev.Key, ev.Name, err)
+		}
+		applied, err := cfg.fieldJSON(ev.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		overrides = append(overrides, EnvOverride{EnvVar: ev, File: file, Applied: applied})
+	}
+	return overrides, nil
+}
+
+// envValueJSON quotes the values of the string fields, turns a comma separated list into a json array and 1 or 0 into
+// a bool, the other values are left to Config.Set
+func envValueJSON(t reflect.Type, value string) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if isJSONLeaf(t) {
+		return value
+	}
+
+	switch {
+	case t.Kind() == reflect.String:
+		quoted, _ := json.Marshal(value)
+		return string(quoted)
+	case t.Kind() == reflect.Bool:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return strconv.FormatBool(b)
+		}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String && !json.Valid([]byte(value)):
+		items := []string{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		list, _ := json.Marshal(items)
+		return string(list)
+	}
+	return value
+}
+
+// WithoutEnv returns a copy of the config with the overridden fields back to their values before the overrides, so
+// that the environment is not written to the config file. A field changed since its override keeps its value.
+func (cfg *Config) WithoutEnv(overrides []EnvOverride) (*Config, error) {
+	if len(overrides) == 0 {
+		return cfg, nil
+	}
+
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cp := &Config{}
+	if err := json.Unmarshal(raw, cp); err != nil {
+		return nil, err
+	}
+
+	for _, o := range overrides {
+		current, err := cp.fieldJSON(o.Key)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(current, o.Applied) {
+			continue
+		}
+		if err := cp.Set(o.Key, string(o.File)); err != nil {
+			return nil, fmt.Errorf("restore %s: %w", o.Key, err)
+		}
+	}
+	return cp, nil
+}
+
+// fieldJSON returns the json of the field at the dotted key, null when one of its sections is not set
+func (cfg *Config) fieldJSON(key string) (json.RawMessage, error) {
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range strings.Split(key, ".") {
+		var obj map[string]json.RawMessage
+		if bytes.Equal(raw, []byte("null")) {
+			return raw, nil
+		}
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return nil, fmt.Errorf("key: %s invalid for config: %w", key, err)
+		}
+		if raw = obj[k]; raw == nil {
+			return nil, fmt.Errorf("key: %s invalid for config", key)
+		}
+	}
+	return raw, nil
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestEnvVarNames(t *testing.T) {
+	tf.UnitTest(t)
+
+	names := map[string]string{}
+	for _, ev := range EnvVars() {
+		_, dup := names[ev.Name]
+		assert.False(t, dup, ev.Name)
+		names[ev.Name] = ev.Key
+	}
+
+	assert.Equal(t, "api.apiAddress", names["VENUS_API_API_ADDRESS"])
+	assert.Equal(t, "api.venusAuthURL", names["VENUS_API_VENUS_AUTH_URL"])
+	assert.Equal(t, "rateLimit.RedisEndpoint", names["VENUS_RATE_LIMIT_REDIS_ENDPOINT"])
+	assert.Equal(t, "observability.metrics.prometheusEnabled", names["VENUS_OBSERVABILITY_METRICS_PROMETHEUS_ENABLED"])
+	// the fields decoding themselves are not split
+	assert.Equal(t, "mpool.maxFee", names["VENUS_MPOOL_MAX_FEE"])
+	assert.Equal(t, "fevm.enableEthRPC", names["VENUS_FEVM_ENABLE_ETH_RPC"])
+}
+
+func TestApplyDeprecatedEnv(t *testing.T) {
+	tf.UnitTest(t)
+
+	apply := func(env map[string]string) *Config {
+		cfg := NewDefaultConfig()
+		_, err := cfg.ApplyEnv(func(name string) (string, bool) {
+			v, ok := env[name]
+			return v, ok
+		})
+		require.NoError(t, err)
+		return cfg
+	}
+
+	// the former name is still read, with the values it took
+	assert.True(t, apply(map[string]string{"VENUS_FEVM_ENABLEETHRPC": "1"}).FevmConfig.EnableEthRPC)
+	assert.False(t, apply(map[string]string{"VENUS_FEVM_ENABLEETHRPC": "0"}).FevmConfig.EnableEthRPC)
+	// the current name takes precedence
+	assert.False(t, apply(map[string]string{
+		"VENUS_FEVM_ENABLEETHRPC":   "1",
+		"VENUS_FEVM_ENABLE_ETH_RPC": "false",
+	}).FevmConfig.EnableEthRPC)
+}
+
+func TestApplyEnv(t *testing.T) {
+	tf.UnitTest(t)
+
+	env := map[string]string{
+		"VENUS_API_API_ADDRESS":                  "/ip4/0.0.0.0/tcp/3453",
+		"VENUS_BOOTSTRAP_ADDRESSES":              "/ip4/1.2.3.4/tcp/1, /ip4/1.2.3.5/tcp/1",
+		"VENUS_API_ACCESS_CONTROL_ALLOW_METHODS": `["GET"]`,
+		"VENUS_FEVM_ENABLE_ETH_RPC":              "true",
+		"VENUS_SWARM_CONN_MGR_GRACE":             "30s",
+		"VENUS_CHAIN_GC_DEPTH":                   "2000",
+		"VENUS_MPOOL_MAX_FEE":                    "1 FIL",
+		"VENUS_DATASTORE_PATH":                   "123",
+	}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	cfg := NewDefaultConfig()
+	overrides, err := cfg.ApplyEnv(lookup)
+	require.NoError(t, err)
+	assert.Len(t, overrides, len(env))
+
+	assert.Equal(t, "/ip4/0.0.0.0/tcp/3453", cfg.API.APIAddress)
+	assert.Equal(t, []string{"/ip4/1.2.3.4/tcp/1", "/ip4/1.2.3.5/tcp/1"}, cfg.Bootstrap.Addresses)
+	assert.Equal(t, []string{"GET"}, cfg.API.AccessControlAllowMethods)
+	assert.True(t, cfg.FevmConfig.EnableEthRPC)
+	assert.Equal(t, Duration(30*time.Second), cfg.Swarm.ConnMgrGrace)
+	assert.Equal(t, abi.ChainEpoch(2000), cfg.ChainGC.Depth)
+	assert.Equal(t, types.MustParseFIL("1"), cfg.Mpool.MaxFee)
+	assert.Equal(t, "123", cfg.Datastore.Path)
+
+	// the overrides are left out of the config file, unless changed since
+	cfg.Datastore.Path = "other"
+	fileCfg, err := cfg.WithoutEnv(overrides)
+	require.NoError(t, err)
+	defaults := NewDefaultConfig()
+	assert.Equal(t, defaults.API.APIAddress, fileCfg.API.APIAddress)
+	assert.Equal(t, defaults.Bootstrap.Addresses, fileCfg.Bootstrap.Addresses)
+	assert.Equal(t, defaults.FevmConfig.EnableEthRPC, fileCfg.FevmConfig.EnableEthRPC)
+	assert.Equal(t, defaults.Mpool.MaxFee, fileCfg.Mpool.MaxFee)
+	assert.Equal(t, "other", fileCfg.Datastore.Path)
+	assert.Equal(t, "/ip4/0.0.0.0/tcp/3453", cfg.API.APIAddress)
+
+	_, err = NewDefaultConfig().ApplyEnv(func(name string) (string, bool) {
+		return "not a number", name == "VENUS_CHAIN_GC_DEPTH"
+	})
+	assert.Error(t, err)
+}
@@ -30,7 +30,9 @@ import (
 
 	"github.com/filecoin-project/venus/pkg/beacon"
 	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/clock"
 	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/consensus/chainselector"
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/crypto"
 	"github.com/filecoin-project/venus/pkg/fork"
@@ -74,12 +76,16 @@ type BlockValidator struct {
 	chainState chainReader
 	// fork used to process fork code
 	fork fork.IFork
+	// clock tells how far the chain may be ahead of the wall clock
+	clock clock.Clock
 	// network params
 	config *config.NetworkParamsConfig
 	// gasprice for vm
 	gasPirceSchedule *gas.PricesSchedule
 	// cache for validate block
 	validateBlockCache *arc.ARCCache[cid.Cid, struct{}]
+	// verifies the message signatures
+	sigVerifyPool *sigVerifyPool
 
 	Stmgr StateTransformer
 }
@@ -94,6 +100,7 @@ func NewBlockValidator(tv TicketValidator,
 	state StateViewer,
 	chainState chainReader,
 	fork fork.IFork,
+	clk clock.Clock,
 	config *config.NetworkParamsConfig,
 	gasPirceSchedule *gas.PricesSchedule,
 ) *BlockValidator {
@@ -108,12 +115,20 @@ func NewBlockValidator(tv TicketValidator,
 		state:              state,
 		chainState:         chainState,
 		fork:               fork,
+		clock:              clk,
 		config:             config,
 		gasPirceSchedule:   gasPirceSchedule,
 		validateBlockCache: validateBlockCache,
+		sigVerifyPool:      newSigVerifyPool(0),
 	}
 }
 
+// SetSigVerifyWorkers sets the number of goroutines verifying the message signatures of the blocks, the number
+// of CPUs when workers is not positive
+func (bv *BlockValidator) SetSigVerifyWorkers(workers int) {
+	bv.sigVerifyPool = newSigVerifyPool(workers)
+}
+
 // ValidateBlockMsg used to validate block from incoming. check message, signature , wincount.
 // if give a reject error. local node reject this block. if give a ignore error. recheck this block in latest notify
 func (bv *BlockValidator) ValidateBlockMsg(ctx context.Context, blk *types.BlockMsg) pubsub.ValidationResult {
@@ -125,8 +140,10 @@ func (bv *BlockValidator) ValidateBlockMsg(ctx context.Context, blk *types.Block
 	return bv.validateBlockMsg(ctx, blk)
 }
 
-// ValidateFullBlock should match up with 'Semantical Validation' in validation.md in the spec
-func (bv *BlockValidator) ValidateFullBlock(ctx context.Context, blk *types.BlockHeader) error {
+var _ Consensus = (*BlockValidator)(nil)
+
+// ValidateBlockHeader should match up with 'Semantical Validation' in validation.md in the spec
+func (bv *BlockValidator) ValidateBlockHeader(ctx context.Context, blk *types.BlockHeader) error {
 	validationStart := time.Now()
 
 	if _, ok := bv.validateBlockCache.Get(blk.Cid()); ok {
@@ -143,6 +160,38 @@ func (bv *BlockValidator) ValidateFullBlock(ctx context.Context, blk *types.Bloc
 	return nil
 }
 
+// IsEpochBeyondCurrMax returns true when the epoch is more than MaxHeightDrift epochs ahead of the wall clock
+func (bv *BlockValidator) IsEpochBeyondCurrMax(ctx context.Context, epoch abi.ChainEpoch) bool {
+	if bv.config.BlockDelay == 0 || bv.aheadOfClock() {
+		return false
+	}
+	genesis, err := bv.chainState.GetGenesisBlock(ctx)
+	if err != nil {
+		log.Warnf("failed to load genesis block: %v", err)
+		return false
+	}
+
+	now := uint64(bv.clock.Now().Unix())
+	if now < genesis.Timestamp {
+		return epoch > MaxHeightDrift
+	}
+	return epoch > abi.ChainEpoch((now-genesis.Timestamp)/bv.config.BlockDelay)+MaxHeightDrift
+}
+
+// Weight returns the expected consensus weight of the tipset, computed from the power in its parent state
+func (bv *BlockValidator) Weight(ctx context.Context, ts *types.TipSet) (big.Int, error) {
+	return chainselector.Weight(ctx, bv.cstore, ts)
+}
+
+// aheadOfClock returns true when the chain may run ahead of the wall clock: on the local networks with the winning
+// PoSts not verified, the devnet miner produces the blocks of the epochs as fast as they are validated
+func (bv *BlockValidator) aheadOfClock() bool {
+	if !constants.InsecurePoStValidation {
+		return false
+	}
+	return bv.config.NetworkType == types.Network2k || bv.config.NetworkType == types.NetworkForce
+}
+
 func (bv *BlockValidator) validateBlock(ctx context.Context, blk *types.BlockHeader) error {
 	parent, err := bv.chainState.GetTipSet(ctx, types.NewTipSetKey(blk.Parents...))
 	if err != nil {
@@ -164,7 +213,7 @@ func (bv *BlockValidator) validateBlock(ctx context.Context, blk *types.BlockHea
 	}
 
 	now := uint64(time.Now().Unix())
-	if blk.Timestamp > now+bv.config.AllowableClockDriftSecs {
+	if blk.Timestamp > now+bv.config.AllowableClockDriftSecs && !bv.aheadOfClock() {
 		return fmt.Errorf("block was from the future (now=%d, blk=%d): %v", now, blk.Timestamp, ErrTemporal)
 	}
 	if blk.Timestamp > now {
@@ -268,7 +317,7 @@ func (bv *BlockValidator) validateBlock(ctx context.Context, blk *types.BlockHea
 			return err
 		}
 		keyStateView := bv.state.PowerStateView(stateRoot)
-		sigValidator := appstate.NewSignatureValidator(keyStateView)
+		sigValidator := appstate.NewParallelSignatureValidator(keyStateView, bv.sigVerifyPool.forEach)
 		if err := bv.checkBlockMessages(ctx, sigValidator, blk, parent, keyStateView); err != nil {
 			return fmt.Errorf("block had invalid messages: %w", err)
 		}
@@ -378,7 +427,7 @@ func (bv *BlockValidator) isChainNearSynced() bool {
 	ts := bv.chainState.GetHead()
 	timestamp := ts.MinTimestamp()
 	timestampTime := time.Unix(int64(timestamp), 0)
-	return constants.Clock.Since(timestampTime) < 6*time.Hour
+	return bv.clock.Since(timestampTime) < 6*time.Hour
 }
 
 func (bv *BlockValidator) validateMsgMeta(ctx context.Context, msg *types.BlockMsg) error {
@@ -794,20 +843,30 @@ func (bv *BlockValidator) checkBlockMessages(ctx context.Context,
 
 	{
 		// Verify that the BLS signature aggregate is correct
-		if err := sigValidator.ValidateBLSMessageAggregate(ctx, blkblsMsgs, blk.BLSAggregate); err != nil {
+		blsStopwatch := blsVerifyTimer.Start()
+		err := sigValidator.ValidateBLSMessageAggregate(ctx, blkblsMsgs, blk.BLSAggregate)
+		blsStopwatch(ctx)
+		if err != nil {
 			return fmt.Errorf("bls message verification failed for block %s %v", blk.Cid(), err)
 		}
 
 		// Verify that all secp message signatures are correct
-		for i, msg := range blksecpMsgs {
+		secpStopwatch := secpVerifyTimer.Start()
+		err = bv.sigVerifyPool.forEach(ctx, len(blksecpMsgs), func(i int) error {
+			msg := blksecpMsgs[i]
 			signer, err := stateView.ResolveToDeterministicAddress(ctx, msg.Message.From)
 			if err != nil {
-				return errors.Wrapf(err, "failed to load signer address for %v", signer)
+				return errors.Wrapf(err, "failed to load signer address for %v", msg.Message.From)
 			}
 
 			if err := chain.AuthenticateMessage(msg, signer); err != nil {
 				return fmt.Errorf("invalid signature for secp message %d in block %s %v", i, blk.Cid(), err)
 			}
+			return nil
+		})
+		secpStopwatch(ctx)
+		if err != nil {
+			return err
 		}
 	}
 
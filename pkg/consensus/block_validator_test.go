@@ -0,0 +1,72 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/clock"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/constants"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestBlockValidatorAheadOfClock(t *testing.T) {
+	tf.UnitTest(t)
+
+	insecure := constants.InsecurePoStValidation
+	t.Cleanup(func() { constants.InsecurePoStValidation = insecure })
+
+	for _, tc := range []struct {
+		netType  types.NetworkType
+		insecure bool
+		ahead    bool
+	}{
+		{types.Network2k, true, true},
+		{types.NetworkForce, true, true},
+		{types.Network2k, false, false},
+		{types.NetworkMainnet, true, false},
+		{types.NetworkCalibnet, true, false},
+	} {
+		constants.InsecurePoStValidation = tc.insecure
+		bv := &BlockValidator{config: &config.NetworkParamsConfig{NetworkType: tc.netType, BlockDelay: 4}}
+		require.Equal(t, tc.ahead, bv.aheadOfClock(), "network %d, insecure %v", tc.netType, tc.insecure)
+		if tc.ahead {
+			// the devnet miner fast-forwards the epochs, they are not refused as too far in the future
+			require.False(t, bv.IsEpochBeyondCurrMax(context.Background(), 1<<40))
+		}
+	}
+}
+
+// genesisReader serves the genesis block of the chain only
+type genesisReader struct {
+	chainReader
+	genesis *types.BlockHeader
+}
+
+func (r *genesisReader) GetGenesisBlock(_ context.Context) (*types.BlockHeader, error) {
+	return r.genesis, nil
+}
+
+func TestBlockValidatorEpochBeyondCurrMax(t *testing.T) {
+	tf.UnitTest(t)
+
+	genesis := &types.BlockHeader{Timestamp: 1000}
+	fake := clock.NewFake(time.Unix(int64(genesis.Timestamp)+100*4, 0))
+	bv := &BlockValidator{
+		config:     &config.NetworkParamsConfig{NetworkType: types.NetworkMainnet, BlockDelay: 4},
+		chainState: &genesisReader{genesis: genesis},
+		clock:      fake,
+	}
+
+	ctx := context.Background()
+	require.False(t, bv.IsEpochBeyondCurrMax(ctx, 100+MaxHeightDrift))
+	require.True(t, bv.IsEpochBeyondCurrMax(ctx, 100+MaxHeightDrift+1))
+
+	// the epoch is synced once the clock reaches it
+	fake.Advance(4 * time.Second)
+	require.False(t, bv.IsEpochBeyondCurrMax(ctx, 100+MaxHeightDrift+1))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/fvm"
 	"github.com/filecoin-project/venus/pkg/vm/vmcontext"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/reward"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
 
 	"github.com/filecoin-project/go-address"
 	amt4 "github.com/filecoin-project/go-amt-ipld/v4"
@@ -21,6 +22,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/vm"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/cron"
 	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/ipfs-force-community/metrics"
 	"github.com/ipfs/go-cid"
 	cbor "github.com/ipfs/go-ipld-cbor"
 	logging "github.com/ipfs/go-log/v2"
@@ -29,6 +31,13 @@ import (
 
 var processLog = logging.Logger("process block")
 
+var (
+	applyBlocksTimer = metrics.NewTimerMs("consensus/apply_blocks", "Duration of executing the messages of a tipset, state flush included, in milliseconds")
+	stateFlushTimer  = metrics.NewTimerMs("consensus/state_flush", "Duration of writing the state of a tipset execution to the blockstore in milliseconds")
+	stateFlushBlocks = metrics.NewInt64("consensus/state_flush_blocks", "Number of state blocks written by the last flush", "")
+	stateFlushBytes  = metrics.NewInt64("consensus/state_flush_bytes", "Number of state bytes written by the last flush", "By")
+)
+
 // ApplicationResult contains the result of successfully applying one message.
 // ExecutionError might be set and the message can still be applied successfully.
 // See ApplyMessage() for details.
@@ -87,6 +96,9 @@ func (p *DefaultProcessor) ApplyBlocks(ctx context.Context,
 	cb vm.ExecCallBack,
 ) (cid.Cid, []types.MessageReceipt, error) {
 	toProcessTipset := time.Now()
+	applyStopwatch := applyBlocksTimer.Start()
+	defer applyStopwatch(ctx)
+
 	var (
 		receipts      []types.MessageReceipt
 		err           error
@@ -94,6 +106,26 @@ func (p *DefaultProcessor) ApplyBlocks(ctx context.Context,
 		events        [][]types.Event
 	)
 
+	// the vms write to a buffer which is committed once the state of the tipset is computed, so that only the
+	// blocks reachable from the final state are written to the blockstore, in batches
+	baseBs := vmOpts.Bsstore
+	writeBuf := blockstoreutil.NewBufferedBstore(baseBs)
+	commit := func(root cid.Cid) error {
+		stopwatch := stateFlushTimer.Start()
+		defer stopwatch(ctx)
+
+		stats, err := blockstoreutil.CopyParticialWithStats(ctx, writeBuf.Write(), baseBs, root)
+		if err != nil {
+			return fmt.Errorf("commit state %s: %w", root, err)
+		}
+		stateFlushBlocks.Set(ctx, int64(stats.Blocks))
+		stateFlushBytes.Set(ctx, int64(stats.Bytes))
+		processLog.Debugf("commit state %s: %d blocks %d bytes", root, stats.Blocks, stats.Bytes)
+
+		writeBuf = blockstoreutil.NewBufferedBstore(baseBs)
+		return nil
+	}
+
 	makeVM := func(base cid.Cid, e abi.ChainEpoch, timestamp uint64) (vm.Interface, error) {
 		vmOpt := vm.VmOption{
 			CircSupplyCalculator: vmOpts.CircSupplyCalculator,
@@ -107,7 +139,7 @@ func (p *DefaultProcessor) ApplyBlocks(ctx context.Context,
 			Timestamp:            timestamp,
 			GasPriceSchedule:     vmOpts.GasPriceSchedule,
 			PRoot:                base,
-			Bsstore:              vmOpts.Bsstore,
+			Bsstore:              writeBuf,
 			SysCallsImpl:         vmOpts.SysCallsImpl,
 			TipSetGetter:         vmOpts.TipSetGetter,
 			Tracing:              vmOpts.Tracing,
@@ -167,6 +199,10 @@ func (p *DefaultProcessor) ApplyBlocks(ctx context.Context,
 			if err != nil {
 				return cid.Undef, nil, fmt.Errorf("can not Flush vm State To db %vs", err)
 			}
+			// the migrations read the state from the blockstore
+			if err := commit(pstate); err != nil {
+				return cid.Undef, nil, err
+			}
 		}
 		// handle State forks
 		// XXX: The State tree
@@ -278,6 +314,9 @@ func (p *DefaultProcessor) ApplyBlocks(ctx context.Context,
 	if err != nil {
 		return cid.Undef, nil, err
 	}
+	if err := commit(root); err != nil {
+		return cid.Undef, nil, err
+	}
 
 	// copy to db
 	return root, receipts, nil
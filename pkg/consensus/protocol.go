@@ -12,6 +12,9 @@ package consensus
 import (
 	"context"
 
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+
 	"github.com/filecoin-project/venus/pkg/vm"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/ipfs/go-cid"
@@ -38,3 +41,20 @@ type StateTransformer interface {
 	// prior `stateID`.  It returns an error if the transition is invalid.
 	RunStateTransition(ctx context.Context, ts *types.TipSet, cb vm.ExecCallBack, vmTracing bool) (root cid.Cid, receipt cid.Cid, err error)
 }
+
+// MaxHeightDrift is how many epochs a block may be ahead of the epoch of the wall clock
+const MaxHeightDrift = 5
+
+// Consensus is the set of rules the chain is validated with by the syncer. The expected consensus is implemented by
+// BlockValidator, other rules, for the tests or the interop networks, are plugged in by passing another implementation
+// to the syncer.
+type Consensus interface {
+	// ValidateBlockHeader validates the block header against its parent tipset, the messages of the block included
+	ValidateBlockHeader(ctx context.Context, blk *types.BlockHeader) error
+	// ValidateMsgMeta checks the message cids of the block header match its messages
+	ValidateMsgMeta(ctx context.Context, fblk *types.FullBlock) error
+	// IsEpochBeyondCurrMax returns true when the epoch is too far in the future to be synced yet
+	IsEpochBeyondCurrMax(ctx context.Context, epoch abi.ChainEpoch) bool
+	// Weight returns the weight of the tipset, the fork choice of the chain store picks the heaviest tipset by it
+	Weight(ctx context.Context, ts *types.TipSet) (big.Int, error)
+}
@@ -0,0 +1,79 @@
+package consensus
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ipfs-force-community/metrics"
+)
+
+var (
+	blsVerifyTimer  = metrics.NewTimerMs("consensus/bls_verify", "Duration of verifying the BLS aggregate of the messages of a block in milliseconds")
+	secpVerifyTimer = metrics.NewTimerMs("consensus/secp_verify", "Duration of verifying the secp signatures of the messages of a block in milliseconds")
+)
+
+// sigVerifyPool bounds the goroutines verifying the message signatures, the workers are shared by the blocks
+// validated at the same time
+type sigVerifyPool struct {
+	sem chan struct{}
+}
+
+// newSigVerifyPool creates a pool of workers, the number of CPUs when workers is not positive
+func newSigVerifyPool(workers int) *sigVerifyPool {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return &sigVerifyPool{sem: make(chan struct{}, workers)}
+}
+
+// forEach splits the indexes from 0 to n-1 into contiguous chunks, one per worker, and calls fn for them on the
+// workers of the pool. The first failure stops the other chunks, the error of the lowest failing chunk is returned.
+func (p *sigVerifyPool) forEach(ctx context.Context, n int, fn func(i int) error) error {
+	if n == 0 {
+		return nil
+	}
+	chunks := cap(p.sem)
+	if chunks > n {
+		chunks = n
+	}
+	size := (n + chunks - 1) / chunks
+
+	var failed atomic.Bool
+	errs := make([]error, chunks)
+	var wg sync.WaitGroup
+	for c := 0; c < chunks; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			select {
+			case p.sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[c] = ctx.Err()
+				return
+			}
+			defer func() { <-p.sem }()
+
+			end := (c + 1) * size
+			if end > n {
+				end = n
+			}
+			for i := c * size; i < end && !failed.Load(); i++ {
+				if err := fn(i); err != nil {
+					errs[c] = err
+					failed.Store(true)
+					return
+				}
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestSigVerifyPool(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	pool := newSigVerifyPool(3)
+	for _, n := range []int{0, 1, 2, 5, 100} {
+		seen := make([]int32, n)
+		var running, maxRunning atomic.Int32
+		err := pool.forEach(ctx, n, func(i int) error {
+			cur := running.Add(1)
+			defer running.Add(-1)
+			for {
+				prev := maxRunning.Load()
+				if cur <= prev || maxRunning.CompareAndSwap(prev, cur) {
+					break
+				}
+			}
+			atomic.AddInt32(&seen[i], 1)
+			return nil
+		})
+		require.NoError(t, err)
+		for i := range seen {
+			require.Equal(t, int32(1), seen[i], "index %d of %d", i, n)
+		}
+		require.LessOrEqual(t, maxRunning.Load(), int32(3))
+	}
+
+	err := pool.forEach(ctx, 100, func(i int) error {
+		if i == 10 {
+			return fmt.Errorf("bad signature %d", i)
+		}
+		return nil
+	})
+	require.EqualError(t, err, "bad signature 10")
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	full := newSigVerifyPool(1)
+	full.sem <- struct{}{}
+	require.ErrorIs(t, full.forEach(canceled, 2, func(int) error { return nil }), context.Canceled)
+}
@@ -3,6 +3,9 @@ package constants
 import "os"
 
 // FevmEnableEthRPC enables eth rpc, and enables storing a mapping of eth transaction hashes to filecoin message Cids.
+//
+// Deprecated: VENUS_FEVM_ENABLEETHRPC is a former name of VENUS_FEVM_ENABLE_ETH_RPC, the variable of
+// fevm.enableEthRPC in the config, which still reads it when VENUS_FEVM_ENABLE_ETH_RPC is not set.
 var FevmEnableEthRPC = os.Getenv("VENUS_FEVM_ENABLEETHRPC") == "1"
 
 // InsecurePoStValidation use to attach debug
@@ -0,0 +1,323 @@
+package bus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	amt4 "github.com/filecoin-project/go-amt-ipld/v4"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	logging "github.com/ipfs/go-log/v2"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var log = logging.Logger("eventbus")
+
+type EventType string
+
+const (
+	// HeadApply is a tipset added to the head
+	HeadApply EventType = "head_apply"
+	// HeadRevert is a tipset removed from the head by a reorg
+	HeadRevert EventType = "head_revert"
+	// MessageExecuted is a message of the parent tipset with its receipt
+	MessageExecuted EventType = "message_executed"
+	// ActorEvent is an event emitted by an actor while executing a message
+	ActorEvent EventType = "actor_event"
+)
+
+var eventTypes = []EventType{HeadApply, HeadRevert, MessageExecuted, ActorEvent}
+
+type ExecutedMessage struct {
+	Cid     cid.Cid              `json:"cid"`
+	From    address.Address      `json:"from"`
+	To      address.Address      `json:"to"`
+	Method  abi.MethodNum        `json:"method"`
+	Receipt types.MessageReceipt `json:"receipt"`
+}
+
+type EmittedEvent struct {
+	MsgCid  cid.Cid            `json:"msgCid"`
+	Index   int                `json:"index"`
+	Emitter abi.ActorID        `json:"emitter"`
+	Entries []types.EventEntry `json:"entries"`
+}
+
+// Event is pushed to the sinks as json. The messages and their events are those of the parent of the tipset, the
+// receipts of which are in the tipset.
+type Event struct {
+	Type    EventType        `json:"type"`
+	Height  abi.ChainEpoch   `json:"height"`
+	TipSet  types.TipSetKey  `json:"tipset"`
+	Message *ExecutedMessage `json:"message,omitempty"`
+	Actor   *EmittedEvent    `json:"actorEvent,omitempty"`
+}
+
+// Sink receives the events of a tipset at once
+type Sink interface {
+	Publish(ctx context.Context, events []*Event) error
+	Close() error
+}
+
+type sinkWorker struct {
+	name  string
+	sink  Sink
+	types map[EventType]bool
+	queue chan []*Event
+}
+
+type headChange struct {
+	rev, app []*types.TipSet
+}
+
+// Bus follows the head of the chain and pushes its events to the sinks of the config. The messages and receipts of
+// the head changes are loaded by a goroutine of the bus and each sink is fed by its own goroutine, so that neither
+// the loading nor a slow sink blocks the chain or the other sinks.
+type Bus struct {
+	cs  *chain.Store
+	cms *chain.MessageStore
+
+	workers []*sinkWorker
+	// whether the messages of the applied tipsets have to be loaded
+	loadMsgs bool
+	changes  chan headChange
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewBus(cfg *config.EventBusConfig, cs *chain.Store, cms *chain.MessageStore) (*Bus, error) {
+	if cfg.QueueSize <= 0 {
+		return nil, fmt.Errorf("the queue size of the event bus must be positive, got %d", cfg.QueueSize)
+	}
+	b := &Bus{cs: cs, cms: cms, changes: make(chan headChange, cfg.QueueSize)}
+	for i, sc := range cfg.Sinks {
+		selected, err := parseEventTypes(sc.Events)
+		if err != nil {
+			return nil, fmt.Errorf("sink %d: %w", i, err)
+		}
+		sink, err := NewSink(sc)
+		if err != nil {
+			return nil, fmt.Errorf("sink %d: %w", i, err)
+		}
+		b.addSink(fmt.Sprintf("%s(%s)", sc.Type, sc.URL), sink, selected, cfg.QueueSize)
+	}
+	return b, nil
+}
+
+// parseEventTypes returns the event types of a sink, all of them if events is empty
+func parseEventTypes(events []string) (map[EventType]bool, error) {
+	selected := make(map[EventType]bool)
+	if len(events) == 0 {
+		for _, t := range eventTypes {
+			selected[t] = true
+		}
+		return selected, nil
+	}
+	for _, e := range events {
+		known := false
+		for _, t := range eventTypes {
+			if EventType(e) == t {
+				selected[t], known = true, true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown event type %q, expected one of %v", e, eventTypes)
+		}
+	}
+	return selected, nil
+}
+
+func (b *Bus) addSink(name string, sink Sink, selected map[EventType]bool, queueSize int) {
+	w := &sinkWorker{name: name, sink: sink, types: selected, queue: make(chan []*Event, queueSize)}
+	b.loadMsgs = b.loadMsgs || w.types[MessageExecuted] || w.types[ActorEvent]
+	b.workers = append(b.workers, w)
+}
+
+// Start runs the sinks and follows the head changes
+func (b *Bus) Start(ctx context.Context) {
+	ctx, b.cancel = context.WithCancel(ctx)
+	for _, w := range b.workers {
+		b.wg.Add(1)
+		go func(w *sinkWorker) {
+			defer b.wg.Done()
+			w.run(ctx)
+		}(w)
+	}
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.run(ctx)
+	}()
+	b.cs.SubscribeHeadChanges(b.notify)
+}
+
+// notify queues a head change of the chain store, the callback returns at once and the head changes are dropped
+// when the bus falls behind
+func (b *Bus) notify(rev, app []*types.TipSet) error {
+	select {
+	case b.changes <- headChange{rev: rev, app: app}:
+	default:
+		log.Warnf("the event bus is behind the chain, dropping a head change of %d reverted and %d applied tipsets", len(rev), len(app))
+	}
+	return nil
+}
+
+func (b *Bus) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case change := <-b.changes:
+			b.headChange(ctx, change.rev, change.app)
+		}
+	}
+}
+
+// Close stops the sinks, the queued events are dropped
+func (b *Bus) Close() error {
+	if b.cancel != nil {
+		b.cancel()
+	}
+	b.wg.Wait()
+	for _, w := range b.workers {
+		if err := w.sink.Close(); err != nil {
+			log.Warnf("closing sink %s: %v", w.name, err)
+		}
+	}
+	return nil
+}
+
+func (b *Bus) headChange(ctx context.Context, rev, app []*types.TipSet) {
+	for _, ts := range rev {
+		b.publish([]*Event{{Type: HeadRevert, Height: ts.Height(), TipSet: ts.Key()}})
+	}
+	for _, ts := range app {
+		events := []*Event{{Type: HeadApply, Height: ts.Height(), TipSet: ts.Key()}}
+		if b.loadMsgs && ts.Height() > 0 {
+			executed, err := b.loadExecuted(ctx, ts)
+			if err != nil {
+				log.Warnf("loading the messages executed before %d: %v", ts.Height(), err)
+			}
+			events = append(events, executed...)
+		}
+		b.publish(events)
+	}
+}
+
+func (b *Bus) publish(events []*Event) {
+	for _, w := range b.workers {
+		filtered := make([]*Event, 0, len(events))
+		for _, e := range events {
+			if w.types[e.Type] {
+				filtered = append(filtered, e)
+			}
+		}
+		if len(filtered) == 0 {
+			continue
+		}
+		select {
+		case w.queue <- filtered:
+		default:
+			log.Warnf("queue of sink %s is full, dropping %d events of %s", w.name, len(filtered), events[0].TipSet)
+		}
+	}
+}
+
+// loadExecuted makes the events of the messages of the parent of ts, with the receipts in ts
+func (b *Bus) loadExecuted(ctx context.Context, ts *types.TipSet) ([]*Event, error) {
+	pts, err := b.cs.GetTipSet(ctx, ts.Parents())
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := b.cms.MessagesForTipset(pts)
+	if err != nil {
+		return nil, fmt.Errorf("loading messages: %w", err)
+	}
+	receipts, err := b.cms.LoadReceipts(ctx, ts.At(0).ParentMessageReceipts)
+	if err != nil {
+		return nil, fmt.Errorf("loading receipts: %w", err)
+	}
+	if len(receipts) != len(msgs) {
+		return nil, fmt.Errorf("%d receipts for %d messages", len(receipts), len(msgs))
+	}
+
+	store := cbor.NewCborStore(b.cs.Blockstore())
+	var events []*Event
+	for i, m := range msgs {
+		msg := m.VMMessage()
+		msgCid := m.Cid()
+		events = append(events, &Event{
+			Type:   MessageExecuted,
+			Height: ts.Height(),
+			TipSet: ts.Key(),
+			Message: &ExecutedMessage{
+				Cid:     msgCid,
+				From:    msg.From,
+				To:      msg.To,
+				Method:  msg.Method,
+				Receipt: receipts[i],
+			},
+		})
+		if receipts[i].EventsRoot == nil {
+			continue
+		}
+		evts, err := loadEvents(ctx, store, *receipts[i].EventsRoot)
+		if err != nil {
+			return nil, fmt.Errorf("loading events of %s: %w", msgCid, err)
+		}
+		for j, evt := range evts {
+			events = append(events, &Event{
+				Type:   ActorEvent,
+				Height: ts.Height(),
+				TipSet: ts.Key(),
+				Actor:  &EmittedEvent{MsgCid: msgCid, Index: j, Emitter: evt.Emitter, Entries: evt.Entries},
+			})
+		}
+	}
+	return events, nil
+}
+
+func loadEvents(ctx context.Context, store cbor.IpldStore, root cid.Cid) ([]types.Event, error) {
+	evtArr, err := amt4.LoadAMT(ctx, store, root, amt4.UseTreeBitWidth(types.EventAMTBitwidth))
+	if err != nil {
+		return nil, fmt.Errorf("load events amt: %w", err)
+	}
+
+	ret := make([]types.Event, 0, evtArr.Len())
+	err = evtArr.ForEach(ctx, func(u uint64, deferred *cbg.Deferred) error {
+		if u > math.MaxInt {
+			return fmt.Errorf("too many events")
+		}
+		var evt types.Event
+		if err := evt.UnmarshalCBOR(bytes.NewReader(deferred.Raw)); err != nil {
+			return err
+		}
+		ret = append(ret, evt)
+		return nil
+	})
+	return ret, err
+}
+
+func (w *sinkWorker) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case events := <-w.queue:
+			if err := w.sink.Publish(ctx, events); err != nil {
+				log.Warnf("pushing %d events of %s to sink %s: %v", len(events), events[0].TipSet, w.name, err)
+			}
+		}
+	}
+}
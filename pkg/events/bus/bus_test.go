@@ -0,0 +1,285 @@
+package bus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestBusHeadChange(t *testing.T) {
+	tf.UnitTest(t)
+
+	received := make(chan []*Event, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var events []*Event
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&events))
+		received <- events
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b, err := NewBus(&config.EventBusConfig{
+		Sinks:     []*config.EventSinkConfig{{Type: config.EventSinkWebhook, URL: srv.URL, Events: []string{string(HeadApply)}}},
+		QueueSize: 4,
+	}, nil, nil)
+	require.NoError(t, err)
+	assert.False(t, b.loadMsgs)
+	for _, w := range b.workers {
+		go w.run(ctx)
+	}
+	go b.run(ctx)
+
+	newKey := testhelpers.NewCidForTestGetter()
+	ts1 := &types.TipSet{}
+	ts2, err := types.NewTipSet([]*types.BlockHeader{{Height: 2, Miner: testhelpers.NewForTestGetter()(), Messages: newKey(), ParentStateRoot: newKey(), ParentMessageReceipts: newKey()}})
+	require.NoError(t, err)
+	require.NoError(t, b.notify([]*types.TipSet{ts1}, []*types.TipSet{ts2}))
+
+	// only the applied tipset is pushed to the sink
+	select {
+	case events := <-received:
+		require.Len(t, events, 1)
+		assert.Equal(t, HeadApply, events[0].Type)
+		assert.Equal(t, ts2.Key(), events[0].TipSet)
+		assert.EqualValues(t, 2, events[0].Height)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no events received")
+	}
+	require.NoError(t, b.Close())
+
+	_, err = NewBus(&config.EventBusConfig{Sinks: []*config.EventSinkConfig{{Type: "zmq", URL: srv.URL}}, QueueSize: 4}, nil, nil)
+	assert.Error(t, err)
+	_, err = NewBus(&config.EventBusConfig{Sinks: []*config.EventSinkConfig{{Type: config.EventSinkWebhook, URL: srv.URL, Events: []string{"head_aply"}}}, QueueSize: 4}, nil, nil)
+	assert.ErrorContains(t, err, "unknown event type")
+	for _, size := range []int{0, -1} {
+		_, err = NewBus(&config.EventBusConfig{Sinks: []*config.EventSinkConfig{{Type: config.EventSinkWebhook, URL: srv.URL}}, QueueSize: size}, nil, nil)
+		assert.ErrorContains(t, err, "queue size")
+	}
+	_, err = NewSink(&config.EventSinkConfig{Type: config.EventSinkKafka, URL: srv.URL})
+	assert.Error(t, err)
+}
+
+func TestKafkaSink(t *testing.T) {
+	tf.UnitTest(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/topics/chain", r.URL.Path)
+		assert.Equal(t, "application/vnd.kafka.json.v2+json", r.Header.Get("Content-Type"))
+		var body struct {
+			Records []kafkaRecord `json:"records"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if assert.Len(t, body.Records, 1) {
+			assert.Equal(t, HeadRevert, body.Records[0].Key)
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte("unknown topic"))
+	}))
+	defer srv.Close()
+
+	sink, err := NewSink(&config.EventSinkConfig{Type: config.EventSinkKafka, URL: srv.URL + "/", Topic: "chain"})
+	require.NoError(t, err)
+	err = sink.Publish(context.Background(), []*Event{{Type: HeadRevert, Height: 10}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown topic")
+}
+
+func TestBusNotifyDoesNotBlock(t *testing.T) {
+	tf.UnitTest(t)
+
+	b, err := NewBus(&config.EventBusConfig{QueueSize: 1}, nil, nil)
+	require.NoError(t, err)
+
+	// the head changes beyond the queue of the bus are dropped instead of blocking the chain store
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, b.notify(nil, []*types.TipSet{{}}))
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("notify blocked")
+	}
+	assert.Len(t, b.changes, 1)
+}
+
+// natsServer accepts a connection, greets it with info and sends the lines read to the returned channel. It answers
+// PING with PONG, or with reply if it is set.
+func natsServer(t *testing.T, info, reply string) (string, <-chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	lines := make(chan string, 8)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close() // nolint: errcheck
+		_, _ = io.WriteString(conn, "INFO "+info+"\r\n")
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimSpace(line)
+			if line == "PING" {
+				if reply == "" {
+					reply = "PONG"
+				}
+				_, _ = io.WriteString(conn, reply+"\r\n")
+				continue
+			}
+			lines <- line
+		}
+	}()
+	return l.Addr().String(), lines
+}
+
+func nextLine(t *testing.T, lines <-chan string) string {
+	select {
+	case line := <-lines:
+		return line
+	case <-time.After(5 * time.Second):
+		t.Fatal("nothing received")
+		return ""
+	}
+}
+
+func TestNatsSink(t *testing.T) {
+	tf.UnitTest(t)
+
+	addr, lines := natsServer(t, `{"server_id":"test"}`, "")
+	sink, err := NewSink(&config.EventSinkConfig{Type: config.EventSinkNats, URL: "nats://" + addr, Topic: "venus"})
+	require.NoError(t, err)
+	defer sink.Close() // nolint: errcheck
+	require.NoError(t, sink.Publish(context.Background(), []*Event{{Type: HeadApply, Height: 3}}))
+
+	var connect natsConnect
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(nextLine(t, lines), "CONNECT ")), &connect))
+	assert.Equal(t, natsConnect{Name: "venus"}, connect)
+	payload, _ := json.Marshal(&Event{Type: HeadApply, Height: 3})
+	assert.Equal(t, "PUB venus.head_apply "+strconv.Itoa(len(payload)), nextLine(t, lines))
+	assert.Equal(t, string(payload), nextLine(t, lines))
+}
+
+func TestNatsSinkAuth(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	events := []*Event{{Type: HeadApply, Height: 3}}
+
+	t.Run("user and password", func(t *testing.T) {
+		addr, lines := natsServer(t, `{"auth_required":true}`, "")
+		sink, err := NewSink(&config.EventSinkConfig{Type: config.EventSinkNats, URL: "nats://venus:secret@" + addr, Topic: "venus"})
+		require.NoError(t, err)
+		defer sink.Close() // nolint: errcheck
+		require.NoError(t, sink.Publish(ctx, events))
+
+		var connect natsConnect
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(nextLine(t, lines), "CONNECT ")), &connect))
+		assert.Equal(t, "venus", connect.User)
+		assert.Equal(t, "secret", connect.Pass)
+		assert.Empty(t, connect.AuthToken)
+	})
+
+	t.Run("token", func(t *testing.T) {
+		addr, lines := natsServer(t, `{"auth_required":true}`, "")
+		sink, err := NewSink(&config.EventSinkConfig{Type: config.EventSinkNats, URL: "nats://s3cr3t@" + addr, Topic: "venus"})
+		require.NoError(t, err)
+		defer sink.Close() // nolint: errcheck
+		require.NoError(t, sink.Publish(ctx, events))
+
+		var connect natsConnect
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(nextLine(t, lines), "CONNECT ")), &connect))
+		assert.Equal(t, "s3cr3t", connect.AuthToken)
+		assert.Empty(t, connect.User)
+	})
+
+	t.Run("rejected credentials", func(t *testing.T) {
+		addr, _ := natsServer(t, `{"auth_required":true}`, "-ERR 'Authorization Violation'")
+		sink, err := NewSink(&config.EventSinkConfig{Type: config.EventSinkNats, URL: "nats://venus:wrong@" + addr, Topic: "venus"})
+		require.NoError(t, err)
+		defer sink.Close() // nolint: errcheck
+		assert.ErrorContains(t, sink.Publish(ctx, events), "Authorization Violation")
+	})
+
+	t.Run("missing credentials", func(t *testing.T) {
+		addr, _ := natsServer(t, `{"auth_required":true}`, "")
+		sink, err := NewSink(&config.EventSinkConfig{Type: config.EventSinkNats, URL: addr, Topic: "venus"})
+		require.NoError(t, err)
+		defer sink.Close() // nolint: errcheck
+		assert.ErrorContains(t, sink.Publish(ctx, events), "requires authentication")
+	})
+
+	_, err := NewSink(&config.EventSinkConfig{Type: config.EventSinkNats, URL: "ws://127.0.0.1:4222", Topic: "venus"})
+	assert.Error(t, err)
+}
+
+func TestNatsSinkTLS(t *testing.T) {
+	tf.UnitTest(t)
+
+	for name, tc := range map[string]struct {
+		scheme, info string
+	}{
+		"tls url":             {scheme: "tls://", info: `{}`},
+		"server requires tls": {scheme: "nats://", info: `{"tls_required":true}`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer l.Close() // nolint: errcheck
+
+			// the sink starts the tls handshake right after the info of the server
+			record := make(chan byte, 1)
+			go func() {
+				conn, err := l.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close() // nolint: errcheck
+				_, _ = io.WriteString(conn, "INFO "+tc.info+"\r\n")
+				b := make([]byte, 1)
+				if _, err := io.ReadFull(conn, b); err == nil {
+					record <- b[0]
+				}
+			}()
+
+			sink, err := NewSink(&config.EventSinkConfig{Type: config.EventSinkNats, URL: tc.scheme + l.Addr().String(), Topic: "venus"})
+			require.NoError(t, err)
+			defer sink.Close() // nolint: errcheck
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			assert.Error(t, sink.Publish(ctx, []*Event{{Type: HeadApply}}))
+
+			select {
+			case b := <-record:
+				// 0x16 is the content type of the tls handshake records
+				assert.Equal(t, byte(0x16), b)
+			case <-time.After(5 * time.Second):
+				t.Fatal("nothing received")
+			}
+		})
+	}
+}
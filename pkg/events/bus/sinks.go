@@ -0,0 +1,329 @@
+package bus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/venus/pkg/config"
+)
+
+const sinkTimeout = 30 * time.Second
+
+// NewSink makes the sink of the config
+func NewSink(cfg *config.EventSinkConfig) (Sink, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("no url for the %s sink", cfg.Type)
+	}
+	switch cfg.Type {
+	case config.EventSinkWebhook:
+		return newWebhookSink(cfg.URL), nil
+	case config.EventSinkNats:
+		if cfg.Topic == "" {
+			return nil, fmt.Errorf("no subject prefix for the nats sink")
+		}
+		return newNatsSink(cfg.URL, cfg.Topic)
+	case config.EventSinkKafka:
+		if cfg.Topic == "" {
+			return nil, fmt.Errorf("no topic for the kafka sink")
+		}
+		return newKafkaSink(cfg.URL, cfg.Topic), nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", cfg.Type)
+	}
+}
+
+// webhookSink posts the events of a tipset as a json array
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookSink(url string) *webhookSink {
+	return &webhookSink{url: url, client: &http.Client{Timeout: sinkTimeout}}
+}
+
+func (s *webhookSink) Publish(ctx context.Context, events []*Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.client, s.url, "application/json", body)
+}
+
+func (s *webhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// kafkaSink produces the events to a topic through the kafka rest proxy, keyed by their type
+type kafkaSink struct {
+	url    string
+	client *http.Client
+}
+
+type kafkaRecord struct {
+	Key   EventType `json:"key"`
+	Value *Event    `json:"value"`
+}
+
+func newKafkaSink(url, topic string) *kafkaSink {
+	return &kafkaSink{
+		url:    fmt.Sprintf("%s/topics/%s", strings.TrimSuffix(url, "/"), topic),
+		client: &http.Client{Timeout: sinkTimeout},
+	}
+}
+
+func (s *kafkaSink) Publish(ctx context.Context, events []*Event) error {
+	records := make([]kafkaRecord, len(events))
+	for i, e := range events {
+		records[i] = kafkaRecord{Key: e.Type, Value: e}
+	}
+	body, err := json.Marshal(map[string]interface{}{"records": records})
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.client, s.url, "application/vnd.kafka.json.v2+json", body)
+}
+
+func (s *kafkaSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+func post(ctx context.Context, client *http.Client, url, contentType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// natsSink publishes the events to the subjects <prefix>.<type> with the core nats protocol, it connects again on
+// the next events after losing the connection. The connection is upgraded to tls for the tls:// urls or when the
+// server requires it, the user and password or the token of the url authenticate the sink.
+type natsSink struct {
+	addr   string
+	host   string
+	tls    bool
+	user   string
+	pass   string
+	token  string
+	prefix string
+
+	lk   sync.Mutex
+	conn net.Conn
+}
+
+type natsInfo struct {
+	TLSRequired  bool `json:"tls_required"`
+	AuthRequired bool `json:"auth_required"`
+}
+
+type natsConnect struct {
+	Verbose     bool   `json:"verbose"`
+	Pedantic    bool   `json:"pedantic"`
+	TLSRequired bool   `json:"tls_required"`
+	Name        string `json:"name"`
+	User        string `json:"user,omitempty"`
+	Pass        string `json:"pass,omitempty"`
+	AuthToken   string `json:"auth_token,omitempty"`
+}
+
+func newNatsSink(rawURL, prefix string) (*natsSink, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "nats://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the nats url: %w", err)
+	}
+	s := &natsSink{addr: u.Host, host: u.Hostname(), prefix: prefix}
+	switch u.Scheme {
+	case "nats":
+	case "tls":
+		s.tls = true
+	default:
+		return nil, fmt.Errorf("unknown nats url scheme %q, expected nats or tls", u.Scheme)
+	}
+	if u.Port() == "" {
+		s.addr = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			s.user, s.pass = u.User.Username(), pass
+		} else {
+			s.token = u.User.Username()
+		}
+	}
+	return s, nil
+}
+
+func (s *natsSink) connect(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: sinkTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return nil, err
+	}
+	conn, r, err := s.handshake(ctx, conn)
+	if err != nil {
+		conn.Close() // nolint: errcheck
+		return nil, err
+	}
+	s.lk.Lock()
+	s.conn = conn
+	s.lk.Unlock()
+	go s.serve(conn, r)
+	return conn, nil
+}
+
+// handshake reads the info of the server, upgrades conn to tls if needed and authenticates, the PONG answering the
+// PING following CONNECT tells that the server accepted the connection.
+func (s *natsSink) handshake(ctx context.Context, conn net.Conn) (net.Conn, *bufio.Reader, error) {
+	_ = conn.SetDeadline(time.Now().Add(sinkTimeout))
+	r := bufio.NewReader(conn)
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return conn, nil, fmt.Errorf("reading server info: %w", err)
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return conn, nil, fmt.Errorf("unexpected greeting %q", strings.TrimSpace(line))
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info); err != nil {
+		return conn, nil, fmt.Errorf("parsing server info: %w", err)
+	}
+	if info.TLSRequired || s.tls {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return conn, nil, fmt.Errorf("tls handshake: %w", err)
+		}
+		conn, r = tlsConn, bufio.NewReader(tlsConn)
+	}
+	if info.AuthRequired && s.user == "" && s.token == "" {
+		return conn, nil, fmt.Errorf("the nats server requires authentication, the url has no credentials")
+	}
+
+	connect, err := json.Marshal(&natsConnect{
+		TLSRequired: info.TLSRequired || s.tls,
+		Name:        "venus",
+		User:        s.user,
+		Pass:        s.pass,
+		AuthToken:   s.token,
+	})
+	if err != nil {
+		return conn, nil, err
+	}
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		return conn, nil, err
+	}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return conn, nil, fmt.Errorf("waiting for the server to accept the connection: %w", err)
+		}
+		switch {
+		case strings.HasPrefix(line, "PONG"):
+			_ = conn.SetDeadline(time.Time{})
+			return conn, r, nil
+		case strings.HasPrefix(line, "-ERR"):
+			return conn, nil, fmt.Errorf("nats server %s: %s", s.addr, strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+// serve answers the pings of the server, which drops the clients that don't
+func (s *natsSink) serve(conn net.Conn, r *bufio.Reader) {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			s.drop(conn)
+			return
+		}
+		switch {
+		case strings.HasPrefix(line, "PING"):
+			s.lk.Lock()
+			_, err = conn.Write([]byte("PONG\r\n"))
+			s.lk.Unlock()
+			if err != nil {
+				s.drop(conn)
+				return
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			log.Warnf("nats server %s: %s", s.addr, strings.TrimSpace(line))
+		}
+	}
+}
+
+func (s *natsSink) drop(conn net.Conn) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	if s.conn == conn {
+		s.conn = nil
+	}
+	conn.Close() // nolint: errcheck
+}
+
+func (s *natsSink) Publish(ctx context.Context, events []*Event) error {
+	buf := &bytes.Buffer{}
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "PUB %s.%s %d\r\n", s.prefix, e.Type, len(payload))
+		buf.Write(payload)
+		buf.WriteString("\r\n")
+	}
+
+	s.lk.Lock()
+	conn := s.conn
+	s.lk.Unlock()
+	if conn == nil {
+		var err error
+		if conn, err = s.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	s.lk.Lock()
+	_ = conn.SetWriteDeadline(time.Now().Add(sinkTimeout))
+	_, err := conn.Write(buf.Bytes())
+	s.lk.Unlock()
+	if err != nil {
+		s.drop(conn)
+	}
+	return err
+}
+
+func (s *natsSink) Close() error {
+	s.lk.Lock()
+	conn := s.conn
+	s.conn = nil
+	s.lk.Unlock()
+	if conn != nil {
+		return conn.Close()
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/google/uuid"
+	"github.com/ipfs-force-community/sophon-auth/core"
 )
 
 type Filter interface {
@@ -29,6 +30,8 @@ var (
 	ErrFilterAlreadyRegistered = errors.New("filter already registered")
 	ErrFilterNotFound          = errors.New("filter not found")
 	ErrMaximumNumberOfFilters  = errors.New("maximum number of filters registered")
+
+	ErrMaximumNumberOfFiltersPerToken = errors.New("maximum number of filters registered for the token")
 )
 
 func newFilterID() (types.FilterID, error) {
@@ -42,21 +45,29 @@ func newFilterID() (types.FilterID, error) {
 }
 
 type memFilterStore struct {
-	max     int
-	mu      sync.Mutex
-	filters map[types.FilterID]Filter
+	max         int
+	maxPerToken int // 0 is unlimited
+	mu          sync.Mutex
+	filters     map[types.FilterID]Filter
+	owners      map[types.FilterID]string
+	perToken    map[string]int
 }
 
 var _ FilterStore = (*memFilterStore)(nil)
 
-func NewMemFilterStore(maxFilters int) FilterStore {
+// NewMemFilterStore creates a filter store holding at most maxFilters filters, and at most maxFiltersPerToken
+// filters installed with the same api token, the token is identified by the account name carried in the context
+func NewMemFilterStore(maxFilters, maxFiltersPerToken int) FilterStore {
 	return &memFilterStore{
-		max:     maxFilters,
-		filters: make(map[types.FilterID]Filter),
+		max:         maxFilters,
+		maxPerToken: maxFiltersPerToken,
+		filters:     make(map[types.FilterID]Filter),
+		owners:      make(map[types.FilterID]string),
+		perToken:    make(map[string]int),
 	}
 }
 
-func (m *memFilterStore) Add(_ context.Context, f Filter) error {
+func (m *memFilterStore) Add(ctx context.Context, f Filter) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -67,7 +78,17 @@ func (m *memFilterStore) Add(_ context.Context, f Filter) error {
 	if _, exists := m.filters[f.ID()]; exists {
 		return ErrFilterAlreadyRegistered
 	}
+
+	owner, _ := core.CtxGetName(ctx)
+	if len(owner) > 0 && m.maxPerToken > 0 && m.perToken[owner] >= m.maxPerToken {
+		return ErrMaximumNumberOfFiltersPerToken
+	}
+
 	m.filters[f.ID()] = f
+	if len(owner) > 0 {
+		m.owners[f.ID()] = owner
+		m.perToken[owner]++
+	}
 	return nil
 }
 
@@ -89,6 +110,13 @@ func (m *memFilterStore) Remove(_ context.Context, id types.FilterID) error {
 		return ErrFilterNotFound
 	}
 	delete(m.filters, id)
+
+	if owner, ok := m.owners[id]; ok {
+		delete(m.owners, id)
+		if m.perToken[owner]--; m.perToken[owner] <= 0 {
+			delete(m.perToken, owner)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,43 @@
+package filter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs-force-community/sophon-auth/core"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func newTestTipSetFilter(t *testing.T) *TipSetFilter {
+	id, err := newFilterID()
+	require.NoError(t, err)
+	return &TipSetFilter{id: id}
+}
+
+func TestMemFilterStorePerTokenLimit(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	aliceCtx := core.CtxWithName(ctx, "alice")
+	bobCtx := core.CtxWithName(ctx, "bob")
+
+	store := NewMemFilterStore(4, 2)
+
+	alice1, alice2 := newTestTipSetFilter(t), newTestTipSetFilter(t)
+	require.NoError(t, store.Add(aliceCtx, alice1))
+	require.NoError(t, store.Add(aliceCtx, alice2))
+	require.ErrorIs(t, store.Add(aliceCtx, newTestTipSetFilter(t)), ErrMaximumNumberOfFiltersPerToken)
+
+	// other tokens are not affected
+	require.NoError(t, store.Add(bobCtx, newTestTipSetFilter(t)))
+
+	// removing a filter frees a slot of its token
+	require.NoError(t, store.Remove(ctx, alice1.ID()))
+	require.NoError(t, store.Add(aliceCtx, newTestTipSetFilter(t)))
+	require.ErrorIs(t, store.Add(aliceCtx, newTestTipSetFilter(t)), ErrMaximumNumberOfFiltersPerToken)
+
+	// callers without a token are only bound by the global limit
+	require.NoError(t, store.Add(ctx, newTestTipSetFilter(t)))
+	require.ErrorIs(t, store.Add(ctx, newTestTipSetFilter(t)), ErrMaximumNumberOfFilters)
+}
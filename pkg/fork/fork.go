@@ -24,6 +24,7 @@ import (
 	"github.com/filecoin-project/go-state-types/network"
 	"github.com/filecoin-project/go-state-types/rt"
 	gstStore "github.com/filecoin-project/go-state-types/store"
+	"github.com/ipfs-force-community/metrics"
 	blockstore "github.com/ipfs/boxo/blockstore"
 	ipfsblock "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
@@ -85,6 +86,20 @@ var ErrExpensiveFork = errors.New("refusing explicit call due to state fork at e
 var (
 	MigrationMaxWorkerCount    int
 	EnvMigrationMaxWorkerCount = "VENUS_MIGRATION_MAX_WORKER_COUNT"
+
+	// PreMigrationLeadEpochs, when set, replaces the StartWithin of every pre-migration, so that the
+	// migration cache is warmed earlier on nodes slow to pre-compute it.
+	PreMigrationLeadEpochs    abi.ChainEpoch
+	EnvPreMigrationLeadEpochs = "VENUS_PRE_MIGRATION_LEAD_EPOCHS"
+)
+
+var (
+	preMigrationStarted   = metrics.NewCounter("fork/pre_migration_started", "Number of pre-migrations started")
+	preMigrationCompleted = metrics.NewCounter("fork/pre_migration_completed", "Number of pre-migrations which warmed the migration cache")
+	preMigrationFailed    = metrics.NewCounter("fork/pre_migration_failed", "Number of pre-migrations which failed or were cancelled")
+	preMigrationRunning   = metrics.NewInt64("fork/pre_migration_running", "Number of pre-migrations running", "")
+	preMigrationTimer     = metrics.NewTimerMs("fork/pre_migration_duration", "Duration of a pre-migration in milliseconds")
+	upgradeEpochsLeft     = metrics.NewInt64("fork/upgrade_epochs_left", "Number of epochs until the next upgrade with a pre-migration", "")
 )
 
 func init() {
@@ -105,6 +120,18 @@ func init() {
 	log.Infof("migration worker count: %d", MigrationMaxWorkerCount)
 }
 
+func init() {
+	if leads := os.Getenv(EnvPreMigrationLeadEpochs); leads != "" {
+		lead, err := strconv.ParseInt(leads, 10, 64)
+		if err != nil || lead <= 0 {
+			log.Errorf("invalid value for %s (%s), using the start epochs of the upgrades", EnvPreMigrationLeadEpochs, leads)
+			return
+		}
+		log.Infof("pre-migration lead epochs set from %s (%d)", EnvPreMigrationLeadEpochs, lead)
+		PreMigrationLeadEpochs = abi.ChainEpoch(lead)
+	}
+}
+
 // MigrationCache can be used to cache information used by a migration. This is primarily useful to
 // "pre-compute" some migration state ahead of time, and make it accessible in the migration itself.
 type MigrationCache interface {
@@ -516,6 +543,7 @@ type IFork interface {
 	HasExpensiveFork(ctx context.Context, height abi.ChainEpoch) bool
 	HasExpensiveForkBetween(parent, height abi.ChainEpoch) bool
 	GetForkUpgrade() *config.ForkUpgradeConfig
+	NetworkVersions() []types.NetworkVersionEpoch
 	Start(ctx context.Context) error
 }
 
@@ -740,9 +768,12 @@ func runPreMigration(ctx context.Context, fn PreMigrationFunc, cache *nv16.MemMi
 	height := ts.Height()
 	parent := ts.Blocks()[0].ParentStateRoot
 
-	startTime := time.Now()
+	stopwatch := preMigrationTimer.Start()
+	preMigrationStarted.Tick(ctx)
+	preMigrationRunning.Inc(ctx, 1)
+	defer preMigrationRunning.Inc(ctx, -1)
 
-	log.Warn("STARTING pre-migration")
+	log.Warnw("STARTING pre-migration", "height", height)
 	// Clone the cache so we don't actually _update_ it
 	// till we're done. Otherwise, if we fail, the next
 	// migration to use the cache may assume that
@@ -750,12 +781,37 @@ func runPreMigration(ctx context.Context, fn PreMigrationFunc, cache *nv16.MemMi
 	tmpCache := cache.Clone()
 	err := fn(ctx, tmpCache, parent, height, ts)
 	if err != nil {
-		log.Errorw("FAILED pre-migration", "error", err)
+		preMigrationFailed.Tick(ctx)
+		log.Errorw("FAILED pre-migration", "height", height, "error", err)
 		return
 	}
 	// Finally, if everything worked, update the cache.
 	cache.Update(tmpCache)
-	log.Warnw("COMPLETED pre-migration", "duration", time.Since(startTime))
+	preMigrationCompleted.Tick(ctx)
+	log.Warnw("COMPLETED pre-migration", "height", height, "duration", stopwatch(ctx))
+}
+
+// preMigrationWindow returns the epochs after which the pre-migration starts, after which it doesn't start any
+// more and at which it is cancelled, the lead epochs replacing the StartWithin of the pre-migration when set.
+func preMigrationWindow(upgradeEpoch abi.ChainEpoch, prem PreMigration, lead abi.ChainEpoch) (after, notAfter, stop abi.ChainEpoch) {
+	startWithin := prem.StartWithin
+	if lead > 0 {
+		if lead > prem.DontStartWithin {
+			startWithin = lead
+		} else {
+			log.Warnf("ignoring %d pre-migration lead epochs, the pre-migration of upgrade %d doesn't start within %d epochs",
+				lead, upgradeEpoch, prem.DontStartWithin)
+		}
+	}
+
+	after = upgradeEpoch - startWithin
+	notAfter = upgradeEpoch - prem.DontStartWithin
+	stop = upgradeEpoch - prem.StopWithin
+	// We can't start after we stop.
+	if notAfter > stop {
+		notAfter = stop - 1
+	}
+	return after, notAfter, stop
 }
 
 func (c *ChainFork) preMigrationWorker(ctx context.Context) {
@@ -773,19 +829,18 @@ func (c *ChainFork) preMigrationWorker(ctx context.Context) {
 
 	// Turn each pre-migration into an operation in a schedule.
 	var schedule []op
+	var upgrades []abi.ChainEpoch
 	for upgradeEpoch, migration := range c.stateMigrations {
 		cache := migration.cache
+		if len(migration.preMigrations) > 0 {
+			upgrades = append(upgrades, upgradeEpoch)
+		}
 		for _, prem := range migration.preMigrations {
 			preCtx, preCancel := context.WithCancel(ctx)
 			migrationFunc := prem.PreMigration
 
-			afterEpoch := upgradeEpoch - prem.StartWithin
-			notAfterEpoch := upgradeEpoch - prem.DontStartWithin
-			stopEpoch := upgradeEpoch - prem.StopWithin
-			// We can't start after we stop.
-			if notAfterEpoch > stopEpoch {
-				notAfterEpoch = stopEpoch - 1
-			}
+			afterEpoch, notAfterEpoch, stopEpoch := preMigrationWindow(upgradeEpoch, prem, PreMigrationLeadEpochs)
+			log.Debugw("scheduled pre-migration", "upgrade", upgradeEpoch, "start", afterEpoch, "stop", stopEpoch)
 
 			// Add an op to start a pre-migration.
 			schedule = append(schedule, op{
@@ -815,12 +870,20 @@ func (c *ChainFork) preMigrationWorker(ctx context.Context) {
 	sort.Slice(schedule, func(i, j int) bool {
 		return schedule[i].after < schedule[j].after
 	})
+	sort.Slice(upgrades, func(i, j int) bool { return upgrades[i] < upgrades[j] })
 
 	// Finally, when the head changes, see if there's anything we need to do.
 	//
 	// We're intentionally ignoring reorgs as they don't matter for our purposes.
 	for change := range c.cr.SubHeadChanges(ctx) {
 		for _, head := range change {
+			for len(upgrades) > 0 && upgrades[0] <= head.Val.Height() {
+				upgrades = upgrades[1:]
+			}
+			if len(upgrades) > 0 {
+				upgradeEpochsLeft.Set(ctx, int64(upgrades[0]-head.Val.Height()))
+			}
+
 			for len(schedule) > 0 {
 				op := &schedule[0]
 				if head.Val.Height() < op.after {
@@ -3334,6 +3397,21 @@ func (c *ChainFork) GetForkUpgrade() *config.ForkUpgradeConfig {
 	return c.forkUpgrade
 }
 
+// NetworkVersions returns the network versions GetNetworkVersion returns in order, with the first epoch of each
+func (c *ChainFork) NetworkVersions() []types.NetworkVersionEpoch {
+	var out []types.NetworkVersionEpoch
+	first := abi.ChainEpoch(0)
+	for _, spec := range c.networkVersions {
+		// a version whose upgrade is disabled, or which is upgraded from at the epoch of its own upgrade, never applies
+		if spec.atOrBelow < first {
+			continue
+		}
+		out = append(out, types.NetworkVersionEpoch{Version: spec.networkVersion, Epoch: first})
+		first = spec.atOrBelow + 1
+	}
+	return append(out, types.NetworkVersionEpoch{Version: c.latestVersion, Epoch: first})
+}
+
 // Example upgrade function if upgrade requires only code changes
 // func (c *ChainFork) upgradeActorsV9Common(
 // 	ctx context.Context, cache MigrationCache,
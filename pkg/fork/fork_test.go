@@ -0,0 +1,77 @@
+package fork
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/stretchr/testify/assert"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestPreMigrationWindow(t *testing.T) {
+	tf.UnitTest(t)
+
+	prem := PreMigration{StartWithin: 120, DontStartWithin: 15, StopWithin: 10}
+	for name, tc := range map[string]struct {
+		prem                  PreMigration
+		lead                  abi.ChainEpoch
+		after, notAfter, stop abi.ChainEpoch
+	}{
+		"start epochs of the upgrade": {prem: prem, after: 880, notAfter: 985, stop: 990},
+		"lead replaces StartWithin":   {prem: prem, lead: 500, after: 500, notAfter: 985, stop: 990},
+		"lead within DontStartWithin": {prem: prem, lead: 15, after: 880, notAfter: 985, stop: 990},
+		"start before the stop": {
+			prem:  PreMigration{StartWithin: 60, DontStartWithin: 5, StopWithin: 10},
+			after: 940, notAfter: 989, stop: 990,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			after, notAfter, stop := preMigrationWindow(1000, tc.prem, tc.lead)
+			assert.Equal(t, tc.after, after)
+			assert.Equal(t, tc.notAfter, notAfter)
+			assert.Equal(t, tc.stop, stop)
+		})
+	}
+}
+
+func TestNetworkVersions(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	// the upgrades to v1 and v2 are disabled, v4 is upgraded to at the epoch of the upgrade to v3
+	fork := &ChainFork{
+		networkVersions: []versionSpec{
+			{networkVersion: network.Version0, atOrBelow: -1},
+			{networkVersion: network.Version1, atOrBelow: -1},
+			{networkVersion: network.Version2, atOrBelow: 10},
+			{networkVersion: network.Version3, atOrBelow: 10},
+			{networkVersion: network.Version4, atOrBelow: 20},
+		},
+		latestVersion: network.Version5,
+	}
+	versions := fork.NetworkVersions()
+	assert.Equal(t, []types.NetworkVersionEpoch{
+		{Version: network.Version2, Epoch: 0},
+		{Version: network.Version4, Epoch: 11},
+		{Version: network.Version5, Epoch: 21},
+	}, versions)
+
+	// the schedule agrees with GetNetworkVersion at every epoch
+	for height := abi.ChainEpoch(0); height < 30; height++ {
+		expected := versions[0].Version
+		for _, v := range versions {
+			if v.Epoch <= height {
+				expected = v.Version
+			}
+		}
+		assert.Equal(t, expected, fork.GetNetworkVersion(ctx, height), "epoch %d", height)
+	}
+
+	// a network without upgrades stays at its genesis version
+	fork = &ChainFork{latestVersion: network.Version21}
+	assert.Equal(t, []types.NetworkVersionEpoch{{Version: network.Version21, Epoch: 0}}, fork.NetworkVersions())
+}
@@ -55,6 +55,10 @@ func (mockFork *MockFork) GetForkUpgrade() *config.ForkUpgradeConfig {
 	}
 }
 
+func (mockFork *MockFork) NetworkVersions() []types.NetworkVersionEpoch {
+	return []types.NetworkVersionEpoch{{Version: network.Version0}}
+}
+
 func (mockFork *MockFork) Start(ctx context.Context) error {
 	return nil
 }
@@ -269,12 +269,13 @@ type FVM struct {
 }
 
 func defaultFVMOpts(ctx context.Context, opts *vm.VmOption) (*ffi.FVMOpts, error) {
-	state, err := tree.LoadState(ctx, cbor.NewCborStore(opts.Bsstore), opts.PRoot)
-	if err != nil {
-		return nil, fmt.Errorf("loading state tree: %w", err)
-	}
-
-	circToReport, err := opts.CircSupplyCalculator(ctx, opts.Epoch, state)
+	circToReport, err := machines.baseCircSupply(ctx, opts.PRoot, opts.Epoch, func() (abi.TokenAmount, error) {
+		state, err := tree.LoadState(ctx, cbor.NewCborStore(opts.Bsstore), opts.PRoot)
+		if err != nil {
+			return abi.TokenAmount{}, fmt.Errorf("loading state tree: %w", err)
+		}
+		return opts.CircSupplyCalculator(ctx, opts.Epoch, state)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("calculating circ supply: %w", err)
 	}
@@ -311,7 +312,7 @@ func NewFVM(ctx context.Context, opts *vm.VmOption) (*FVM, error) {
 		return nil, fmt.Errorf("creating fvm opts: %w", err)
 	}
 
-	fvm, err := ffi.CreateFVM(fvmOpts)
+	fvm, err := machines.create(ctx, opts.ExecutionLane, fvmOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -414,7 +415,7 @@ func NewDebugFVM(ctx context.Context, opts *vm.VmOption) (*FVM, error) {
 		}
 	}
 
-	fvm, err := ffi.CreateFVM(fvmOpts)
+	fvm, err := machines.create(ctx, opts.ExecutionLane, fvmOpts)
 	if err != nil {
 		return nil, err
 	}
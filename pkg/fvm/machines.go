@@ -0,0 +1,79 @@
+package fvm
+
+import (
+	"context"
+
+	ffi "github.com/filecoin-project/filecoin-ffi"
+	"github.com/filecoin-project/go-state-types/abi"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/ipfs-force-community/metrics"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/pkg/vm/vmcontext"
+)
+
+// circSupplyCacheSize bounds the instantiation inputs kept, callers mostly instantiate machines on a few recent
+// state roots
+const circSupplyCacheSize = 64
+
+var (
+	machinesCreating   = metrics.NewInt64("fvm/machines_creating", "Number of fvm machines waiting for or being instantiated", "")
+	machineCreated     = metrics.NewCounter("fvm/machine_created", "Number of fvm machines instantiated")
+	machineInputReused = metrics.NewCounter("fvm/machine_input_reused", "Number of fvm machines instantiated with cached inputs")
+	machineCreateTimer = metrics.NewTimerMs("fvm/machine_create_duration", "Duration of an fvm machine instantiation in milliseconds")
+)
+
+type circSupplyKey struct {
+	root  cid.Cid
+	epoch abi.ChainEpoch
+}
+
+// machineFactory instantiates the fvm machines. The instantiations take an execution lane like the executions of
+// messages, so that they are bounded together. The machines are not reused, filecoin-ffi has no way to reset one,
+// but the inputs loaded from the state are cached across the machines on the same state.
+type machineFactory struct {
+	// circSupply caches the circulating supply of a state at an epoch, which loads the whole state tree
+	circSupply *lru.Cache[circSupplyKey, abi.TokenAmount]
+	newMachine func(*ffi.FVMOpts) (*ffi.FVM, error)
+}
+
+var machines = newMachineFactory()
+
+func newMachineFactory() *machineFactory {
+	cache, _ := lru.New[circSupplyKey, abi.TokenAmount](circSupplyCacheSize)
+	return &machineFactory{
+		circSupply: cache,
+		newMachine: ffi.CreateFVM,
+	}
+}
+
+// baseCircSupply returns the circulating supply of the state root at epoch, computed by calc the first time
+func (f *machineFactory) baseCircSupply(ctx context.Context, root cid.Cid, epoch abi.ChainEpoch, calc func() (abi.TokenAmount, error)) (abi.TokenAmount, error) {
+	key := circSupplyKey{root: root, epoch: epoch}
+	if v, ok := f.circSupply.Get(key); ok {
+		machineInputReused.Tick(ctx)
+		return v, nil
+	}
+	v, err := calc()
+	if err != nil {
+		return abi.TokenAmount{}, err
+	}
+	f.circSupply.Add(key, v)
+	return v, nil
+}
+
+// create instantiates a machine once an execution lane of lane is free
+func (f *machineFactory) create(ctx context.Context, lane vmcontext.ExecutionLane, opts *ffi.FVMOpts) (*ffi.FVM, error) {
+	machinesCreating.Inc(ctx, 1)
+	release := vmcontext.ReserveExecution(lane)
+	stopwatch := machineCreateTimer.Start()
+	machine, err := f.newMachine(opts)
+	release()
+	machinesCreating.Inc(ctx, -1)
+	if err != nil {
+		return nil, err
+	}
+	stopwatch(ctx)
+	machineCreated.Tick(ctx)
+	return machine, nil
+}
@@ -0,0 +1,102 @@
+package fvm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ffi "github.com/filecoin-project/filecoin-ffi"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/pkg/vm/vmcontext"
+	"github.com/filecoin-project/venus/venus-shared/testutil"
+)
+
+func TestMachineFactoryBaseCircSupply(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	f := newMachineFactory()
+
+	var root, other cid.Cid
+	testutil.Provide(t, &root)
+	testutil.Provide(t, &other)
+
+	calls := 0
+	calc := func(v int64) func() (abi.TokenAmount, error) {
+		return func() (abi.TokenAmount, error) {
+			calls++
+			return abi.NewTokenAmount(v), nil
+		}
+	}
+
+	v, err := f.baseCircSupply(ctx, root, 10, calc(1))
+	require.NoError(t, err)
+	assert.Equal(t, abi.NewTokenAmount(1), v)
+	v, err = f.baseCircSupply(ctx, root, 10, calc(2))
+	require.NoError(t, err)
+	assert.Equal(t, abi.NewTokenAmount(1), v)
+	assert.Equal(t, 1, calls)
+
+	// another state or epoch is computed again
+	v, err = f.baseCircSupply(ctx, root, 11, calc(3))
+	require.NoError(t, err)
+	assert.Equal(t, abi.NewTokenAmount(3), v)
+	v, err = f.baseCircSupply(ctx, other, 10, calc(4))
+	require.NoError(t, err)
+	assert.Equal(t, abi.NewTokenAmount(4), v)
+	assert.Equal(t, 3, calls)
+
+	// the failures are not cached
+	_, err = f.baseCircSupply(ctx, other, 12, func() (abi.TokenAmount, error) { return abi.TokenAmount{}, errors.New("boom") })
+	assert.EqualError(t, err, "boom")
+	v, err = f.baseCircSupply(ctx, other, 12, calc(5))
+	require.NoError(t, err)
+	assert.Equal(t, abi.NewTokenAmount(5), v)
+}
+
+func TestMachineFactoryUsesExecutionLanes(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	var running, peak atomic.Int64
+	f := newMachineFactory()
+	f.newMachine = func(*ffi.FVMOpts) (*ffi.FVM, error) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		return &ffi.FVM{}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3*vmcontext.DefaultAvailableExecutionLanes; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := f.create(ctx, vmcontext.ExecutionLaneDefault, &ffi.FVMOpts{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	// the default lane leaves the reserved lanes to the priority executions
+	assert.Equal(t, int64(vmcontext.DefaultAvailableExecutionLanes-vmcontext.DefaultPriorityExecutionLanes), peak.Load())
+
+	// the lane is freed when the instantiation fails
+	f.newMachine = func(*ffi.FVMOpts) (*ffi.FVM, error) { return nil, errors.New("boom") }
+	for i := 0; i < vmcontext.DefaultAvailableExecutionLanes+1; i++ {
+		_, err := f.create(ctx, vmcontext.ExecutionLanePriority, &ffi.FVMOpts{})
+		assert.EqualError(t, err, "boom")
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
 	"github.com/ipfs/go-cid"
 )
 
@@ -17,6 +18,7 @@ type fundManager interface {
 	MpoolPushMessage(context.Context, *types.Message, *types.MessageSendSpec) (*types.SignedMessage, error)
 	StateMarketBalance(context.Context, address.Address, types.TipSetKey) (types.MarketBalance, error)
 	StateWaitMsg(ctx context.Context, c cid.Cid, confidence uint64, limit abi.ChainEpoch, allowReplaced bool) (*types.MsgLookup, error)
+	StateNetworkVersion(ctx context.Context, tsk types.TipSetKey) (network.Version, error)
 }
 
 type fmgr struct {
@@ -46,3 +48,7 @@ func (o *fmgr) StateMarketBalance(ctx context.Context, address address.Address,
 func (o *fmgr) StateWaitMsg(ctx context.Context, c cid.Cid, confidence uint64, limit abi.ChainEpoch, allowReplaced bool) (*types.MsgLookup, error) {
 	return o.ChainInfoAPI.StateWaitMsg(ctx, c, confidence, limit, allowReplaced)
 }
+
+func (o *fmgr) StateNetworkVersion(ctx context.Context, tsk types.TipSetKey) (network.Version, error) {
+	return o.ChainInfoAPI.StateNetworkVersion(ctx, tsk)
+}
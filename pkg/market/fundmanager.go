@@ -6,10 +6,9 @@ import (
 	"sync"
 
 	"github.com/filecoin-project/go-state-types/big"
-	"github.com/filecoin-project/go-state-types/builtin"
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/repo"
-	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/msgbuilder"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
 
@@ -645,6 +644,14 @@ type fundManagerEnvironment struct {
 	api fundManager
 }
 
+func (env *fundManagerEnvironment) msgBuilder(ctx context.Context, wallet address.Address) (msgbuilder.Builder, error) {
+	nv, err := env.api.StateNetworkVersion(ctx, types.EmptyTSK)
+	if err != nil {
+		return msgbuilder.Builder{}, fmt.Errorf("getting network version: %w", err)
+	}
+	return msgbuilder.New(nv, wallet), nil
+}
+
 func (env *fundManagerEnvironment) AvailableFunds(ctx context.Context, addr address.Address) (abi.TokenAmount, error) {
 	bal, err := env.api.StateMarketBalance(ctx, addr, types.EmptyTSK)
 	if err != nil {
@@ -660,18 +667,16 @@ func (env *fundManagerEnvironment) AddFunds(
 	addr address.Address,
 	amt abi.TokenAmount,
 ) (cid.Cid, error) {
-	params, err := actors.SerializeParams(&addr)
+	builder, err := env.msgBuilder(ctx, wallet)
+	if err != nil {
+		return cid.Undef, err
+	}
+	msg, err := builder.Market().AddBalance(addr, amt)
 	if err != nil {
 		return cid.Undef, err
 	}
 
-	smsg, aerr := env.api.MpoolPushMessage(ctx, &types.Message{
-		To:     builtin.StorageMarketActorAddr,
-		From:   wallet,
-		Value:  amt,
-		Method: builtin.MethodsMarket.AddBalance,
-		Params: params,
-	}, nil)
+	smsg, aerr := env.api.MpoolPushMessage(ctx, msg, nil)
 
 	if aerr != nil {
 		return cid.Undef, aerr
@@ -685,21 +690,16 @@ func (env *fundManagerEnvironment) WithdrawFunds(
 	addr address.Address,
 	amt abi.TokenAmount,
 ) (cid.Cid, error) {
-	params, err := actors.SerializeParams(&types.MarketWithdrawBalanceParams{
-		ProviderOrClientAddress: addr,
-		Amount:                  amt,
-	})
+	builder, err := env.msgBuilder(ctx, wallet)
+	if err != nil {
+		return cid.Undef, err
+	}
+	msg, err := builder.Market().WithdrawBalance(addr, amt)
 	if err != nil {
-		return cid.Undef, fmt.Errorf("serializing params: %w", err)
+		return cid.Undef, err
 	}
 
-	smsg, aerr := env.api.MpoolPushMessage(ctx, &types.Message{
-		To:     builtin.StorageMarketActorAddr,
-		From:   wallet,
-		Value:  big.NewInt(0),
-		Method: builtin.MethodsMarket.WithdrawBalance,
-		Params: params,
-	}, nil)
+	smsg, aerr := env.api.MpoolPushMessage(ctx, msg, nil)
 
 	if aerr != nil {
 		return cid.Undef, aerr
@@ -34,6 +34,8 @@ type MpoolConfig struct {
 	ReplaceByFeeRatio      types.Percent
 	PruneCooldown          time.Duration
 	GasLimitOverestimation float64
+	// PendingTTL is how long a message may stay pending before being dropped, 0 keeps it until it is included
+	PendingTTL time.Duration
 }
 
 func (mc *MpoolConfig) Clone() *MpoolConfig {
@@ -83,6 +85,9 @@ func validateConfg(cfg *MpoolConfig) error {
 	if cfg.GasLimitOverestimation < 1 {
 		return fmt.Errorf("'GasLimitOverestimation' cannot be less than 1")
 	}
+	if cfg.PendingTTL < 0 {
+		return fmt.Errorf("'PendingTTL' cannot be negative")
+	}
 	return nil
 }
 
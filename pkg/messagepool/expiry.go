@@ -0,0 +1,88 @@
+package messagepool
+
+import (
+	"context"
+	"sort"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs-force-community/metrics"
+	"github.com/ipfs/go-datastore"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var expiredMsgs = metrics.NewCounter("mpool/expired_messages", "The number of pending messages dropped after staying in the message pool longer than the pending ttl")
+
+type expiredMsg struct {
+	from  address.Address
+	nonce uint64
+}
+
+// expirePendingMessages drops the messages pending for longer than the PendingTTL of the config, with the messages of
+// the same actor at higher nonces, which can't be included once the nonce gap is opened. The subscribers are notified
+// with MpoolExpire updates, and the local messages are removed from the datastore, so they don't come back on
+// restart. The messages loaded from the datastore on start are pending since then.
+func (mp *MessagePool) expirePendingMessages(ctx context.Context) {
+	mp.cfgLk.RLock()
+	ttl := mp.cfg.PendingTTL
+	mp.cfgLk.RUnlock()
+	if ttl <= 0 {
+		return
+	}
+
+	mp.lk.Lock()
+	defer mp.lk.Unlock()
+
+	now := constants.Clock.Now()
+	var expired []expiredMsg
+	mp.forEachPending(func(from address.Address, ms *msgSet) {
+		lowest, found := uint64(0), false
+		for nonce, added := range ms.added {
+			if now.Sub(added) >= ttl && (!found || nonce < lowest) {
+				lowest, found = nonce, true
+			}
+		}
+		if !found {
+			return
+		}
+		for nonce := range ms.msgs {
+			if nonce >= lowest {
+				expired = append(expired, expiredMsg{from: from, nonce: nonce})
+			}
+		}
+	})
+	if len(expired) == 0 {
+		return
+	}
+
+	// from the highest nonce of each actor down, like the pruning
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].nonce > expired[j].nonce
+	})
+
+	log.Infof("Expiring %d messages pending for more than %s or nonced after one", len(expired), ttl)
+	for _, e := range expired {
+		mset, ok, err := mp.getPendingMset(ctx, e.from)
+		if err != nil || !ok {
+			continue
+		}
+		m, ok := mset.msgs[e.nonce]
+		if !ok {
+			continue
+		}
+
+		local, err := mp.isLocal(ctx, e.from)
+		if err != nil {
+			log.Warnf("errored while determining isLocal: %s", err)
+		}
+		if local {
+			if err := mp.localMsgs.Delete(ctx, datastore.NewKey(string(m.Cid().Bytes()))); err != nil {
+				log.Warnf("error deleting local message: %s", err)
+			}
+		}
+
+		mp.removeWithChange(ctx, e.from, e.nonce, false, types.MpoolExpire)
+		expiredMsgs.Tick(ctx)
+	}
+}
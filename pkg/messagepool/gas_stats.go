@@ -0,0 +1,218 @@
+package messagepool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var ErrGasStatsDisabled = errors.New("gas stats collection is disabled, set gasStatsEpochs in the mpool config")
+
+type gasStatKey struct {
+	code   cid.Cid
+	method abi.MethodNum
+}
+
+// gasStatsEpoch is the gas used by the messages executed in an epoch
+type gasStatsEpoch struct {
+	height  abi.ChainEpoch
+	samples map[gasStatKey][]int64
+}
+
+// GasStatsCollector records the gas used by the messages per actor code and method over the recent epochs, so the
+// gas estimation can be compared to what the messages actually use.
+type GasStatsCollector struct {
+	epochs abi.ChainEpoch
+	cs     *chain.Store
+	cms    *chain.MessageStore
+
+	lk sync.Mutex
+	// keyed by the tipset holding the receipts of the epoch, so a reverted tipset drops its epoch
+	byTipSet map[types.TipSetKey]*gasStatsEpoch
+}
+
+func NewGasStatsCollector(epochs uint64, cs *chain.Store, cms *chain.MessageStore) *GasStatsCollector {
+	return &GasStatsCollector{
+		epochs:   abi.ChainEpoch(epochs),
+		cs:       cs,
+		cms:      cms,
+		byTipSet: make(map[types.TipSetKey]*gasStatsEpoch),
+	}
+}
+
+// Start loads the recent epochs of the chain and follows its head
+func (c *GasStatsCollector) Start(ctx context.Context) {
+	head := c.cs.GetHead()
+	go func() {
+		ts := head
+		for i := abi.ChainEpoch(0); i < c.epochs && ts.Height() > 0; i++ {
+			if err := c.apply(ctx, ts); err != nil {
+				log.Warnf("collecting the gas used before %d: %v", ts.Height(), err)
+				return
+			}
+			parent, err := c.cs.GetTipSet(ctx, ts.Parents())
+			if err != nil {
+				log.Warnf("loading parent of %s: %v", ts.Key(), err)
+				return
+			}
+			ts = parent
+		}
+	}()
+
+	c.cs.SubscribeHeadChanges(func(rev, app []*types.TipSet) error {
+		for _, ts := range rev {
+			c.revert(ts.Key())
+		}
+		for _, ts := range app {
+			if err := c.apply(ctx, ts); err != nil {
+				log.Warnf("collecting the gas used before %d: %v", ts.Height(), err)
+			}
+		}
+		return nil
+	})
+}
+
+// apply records the gas used by the messages of the parent of ts, the receipts of which are in ts
+func (c *GasStatsCollector) apply(ctx context.Context, ts *types.TipSet) error {
+	if ts.Height() == 0 {
+		return nil
+	}
+	pts, err := c.cs.GetTipSet(ctx, ts.Parents())
+	if err != nil {
+		return err
+	}
+	msgs, err := c.cms.MessagesForTipset(pts)
+	if err != nil {
+		return fmt.Errorf("loading messages: %w", err)
+	}
+	receipts, err := c.cms.LoadReceipts(ctx, ts.At(0).ParentMessageReceipts)
+	if err != nil {
+		return fmt.Errorf("loading receipts: %w", err)
+	}
+	if len(receipts) != len(msgs) {
+		return fmt.Errorf("%d receipts for %d messages", len(receipts), len(msgs))
+	}
+	view, err := c.cs.ParentStateView(ts)
+	if err != nil {
+		return err
+	}
+
+	codes := make(map[address.Address]cid.Cid)
+	samples := make(map[gasStatKey][]int64)
+	for i, m := range msgs {
+		msg := m.VMMessage()
+		code, ok := codes[msg.To]
+		if !ok {
+			// the receiver of a failed message may not exist
+			if act, err := view.LoadActor(ctx, msg.To); err == nil {
+				code = act.Code
+			}
+			codes[msg.To] = code
+		}
+		if !code.Defined() {
+			continue
+		}
+		key := gasStatKey{code: code, method: msg.Method}
+		samples[key] = append(samples[key], receipts[i].GasUsed)
+	}
+
+	c.record(ts.Key(), pts.Height(), samples)
+	return nil
+}
+
+func (c *GasStatsCollector) record(key types.TipSetKey, height abi.ChainEpoch, samples map[gasStatKey][]int64) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	c.byTipSet[key] = &gasStatsEpoch{height: height, samples: samples}
+
+	maxHeight := height
+	for _, e := range c.byTipSet {
+		if e.height > maxHeight {
+			maxHeight = e.height
+		}
+	}
+	for k, e := range c.byTipSet {
+		if e.height <= maxHeight-c.epochs {
+			delete(c.byTipSet, k)
+		}
+	}
+}
+
+func (c *GasStatsCollector) revert(key types.TipSetKey) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	delete(c.byTipSet, key)
+}
+
+// Stats sums up the gas used per actor code and method, the most called first
+func (c *GasStatsCollector) Stats() *types.GasStats {
+	c.lk.Lock()
+	out := &types.GasStats{}
+	all := make(map[gasStatKey][]int64)
+	for _, e := range c.byTipSet {
+		if out.From == 0 || e.height < out.From {
+			out.From = e.height
+		}
+		if e.height > out.To {
+			out.To = e.height
+		}
+		for k, s := range e.samples {
+			all[k] = append(all[k], s...)
+		}
+	}
+	c.lk.Unlock()
+
+	out.Stats = make([]types.GasStat, 0, len(all))
+	for k, s := range all {
+		out.Stats = append(out.Stats, summarizeGas(k, s))
+	}
+	sort.Slice(out.Stats, func(i, j int) bool {
+		if out.Stats[i].Count != out.Stats[j].Count {
+			return out.Stats[i].Count > out.Stats[j].Count
+		}
+		if out.Stats[i].Actor != out.Stats[j].Actor {
+			return out.Stats[i].Actor < out.Stats[j].Actor
+		}
+		return out.Stats[i].Method < out.Stats[j].Method
+	})
+	return out
+}
+
+func summarizeGas(key gasStatKey, samples []int64) types.GasStat {
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	var sum float64
+	for _, s := range samples {
+		sum += float64(s)
+	}
+	// the nearest rank
+	percentile := func(p float64) int64 {
+		return samples[int(math.Ceil(p*float64(len(samples))))-1]
+	}
+
+	return types.GasStat{
+		Code:   key.code,
+		Actor:  builtin.ActorNameByCode(key.code),
+		Method: key.method,
+		Count:  len(samples),
+		Min:    samples[0],
+		Mean:   int64(sum / float64(len(samples))),
+		P50:    percentile(0.5),
+		P90:    percentile(0.9),
+		P99:    percentile(0.99),
+		Max:    samples[len(samples)-1],
+	}
+}
@@ -0,0 +1,56 @@
+package messagepool
+
+import (
+	"testing"
+
+	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestGasStatsCollector(t *testing.T) {
+	tf.UnitTest(t)
+
+	c := NewGasStatsCollector(3, nil, nil)
+	newKey := testhelpers.NewCidForTestGetter()
+	send := gasStatKey{code: builtin2.AccountActorCodeID, method: 0}
+	publish := gasStatKey{code: builtin2.StorageMarketActorCodeID, method: 4}
+
+	key10, key11, key12 := types.NewTipSetKey(newKey()), types.NewTipSetKey(newKey()), types.NewTipSetKey(newKey())
+	c.record(key10, 10, map[gasStatKey][]int64{send: {100, 300}})
+	c.record(key11, 11, map[gasStatKey][]int64{send: {200}, publish: {5000}})
+	c.record(key12, 12, map[gasStatKey][]int64{send: {400}})
+
+	stats := c.Stats()
+	assert.Equal(t, 10, int(stats.From))
+	assert.Equal(t, 12, int(stats.To))
+	require.Len(t, stats.Stats, 2)
+	// the most called first
+	s := stats.Stats[0]
+	assert.Equal(t, builtin2.AccountActorCodeID, s.Code)
+	assert.Equal(t, 4, s.Count)
+	assert.Equal(t, int64(100), s.Min)
+	assert.Equal(t, int64(250), s.Mean)
+	assert.Equal(t, int64(200), s.P50)
+	assert.Equal(t, int64(400), s.P90)
+	assert.Equal(t, int64(400), s.Max)
+	assert.Equal(t, int64(5000), stats.Stats[1].P99)
+
+	// a reverted tipset drops its epoch
+	c.revert(key11)
+	stats = c.Stats()
+	require.Len(t, stats.Stats, 1)
+	assert.Equal(t, 3, stats.Stats[0].Count)
+
+	// only the last epochs are kept
+	c.record(types.NewTipSetKey(newKey()), 13, map[gasStatKey][]int64{send: {500}})
+	stats = c.Stats()
+	assert.Equal(t, 12, int(stats.From))
+	assert.Equal(t, 2, stats.Stats[0].Count)
+
+	assert.Empty(t, NewGasStatsCollector(3, nil, nil).Stats().Stats)
+}
@@ -0,0 +1,210 @@
+package messagepool
+
+import (
+	"context"
+	"fmt"
+	"math"
+	stdbig "math/big"
+	"sort"
+
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const (
+	// MaxInclusionEpochs bounds the number of epochs MpoolEstimateInclusion simulates
+	MaxInclusionEpochs = 2880
+
+	// the suggested premium aims at this probability of inclusion
+	inclusionConfidence = 0.95
+
+	minBaseFeeLookback = 10
+	maxBaseFeeLookback = 120
+)
+
+// EstimateInclusion simulates whether msg, with its current fee, is likely to be included within nblocksincl
+// epochs. Pending messages paying a better premium and the pending messages of the sender with a lower nonce
+// are selected first, each non empty epoch is assumed to include a block worth of unique gas, and the base fee
+// is projected following its trend over the recent epochs. Messages arriving after the simulation are ignored.
+func (mp *MessagePool) EstimateInclusion(ctx context.Context, msg *types.Message, nblocksincl uint64) (*types.InclusionEstimate, error) {
+	if msg.GasLimit <= 0 || msg.GasFeeCap == types.EmptyInt || msg.GasPremium == types.EmptyInt {
+		return nil, fmt.Errorf("the gas of the message must be estimated first")
+	}
+	if nblocksincl == 0 {
+		nblocksincl = 1
+	}
+	if nblocksincl > MaxInclusionEpochs {
+		return nil, fmt.Errorf("can not simulate more than %d epochs, got %d", MaxInclusionEpochs, nblocksincl)
+	}
+
+	ts, err := mp.api.ChainHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	baseFee, err := mp.api.ChainComputeBaseFee(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("computing base fee: %w", err)
+	}
+
+	lookback := 2 * nblocksincl
+	if lookback < minBaseFeeLookback {
+		lookback = minBaseFeeLookback
+	}
+	if lookback > maxBaseFeeLookback {
+		lookback = maxBaseFeeLookback
+	}
+
+	baseFees := []big.Int{baseFee}
+	for i := uint64(0); i < lookback && ts.Height() > 0; i++ {
+		baseFees = append(baseFees, ts.Blocks()[0].ParentBaseFee)
+
+		if ts, err = mp.api.LoadTipSet(ctx, ts.Parents()); err != nil {
+			return nil, err
+		}
+	}
+
+	pending, _ := mp.Pending(ctx)
+
+	return simulateInclusion(msg, pending, baseFees, int(nblocksincl)), nil
+}
+
+// simulateInclusion runs the simulation of EstimateInclusion, baseFees holds the base fee of the next epoch
+// followed by the base fees of the previous epochs
+func simulateInclusion(msg *types.Message, pending []*types.SignedMessage, baseFees []big.Int, epochs int) *types.InclusionEstimate {
+	baseFee := baseFees[0]
+	projected := projectBaseFees(baseFees, epochs)
+
+	maxBaseFee := baseFee
+	feasible := 0
+	for _, bf := range projected {
+		if bf.GreaterThan(maxBaseFee) {
+			maxBaseFee = bf
+		}
+		if msg.GasFeeCap.GreaterThanEqual(bf) {
+			feasible++
+		}
+	}
+
+	premium := effectivePremium(msg, baseFee)
+	senderAhead := int64(0)
+	var others []*types.SignedMessage
+	for _, m := range pending {
+		if m.Message.From == msg.From {
+			// the message of the sender with a lower nonce must be included first, the pending message with
+			// the same nonce would be replaced
+			if m.Message.Nonce < msg.Nonce {
+				senderAhead += m.Message.GasLimit
+			}
+			continue
+		}
+		others = append(others, m)
+	}
+
+	premiums := make([]big.Int, len(others))
+	for i, m := range others {
+		premiums[i] = effectivePremium(&m.Message, baseFee)
+	}
+	sort.Sort(byPremium{msgs: others, premiums: premiums})
+
+	gasAhead := senderAhead
+	for i, m := range others {
+		if premiums[i].LessThan(premium) {
+			break
+		}
+		gasAhead += m.Message.GasLimit
+	}
+
+	// a non empty epoch includes about a block worth of unique gas, as the blocks of an epoch mostly select
+	// the same messages
+	pBlock := 1 - noWinnersProb()[0]
+	probability := 0.0
+	if needed := int((gasAhead + msg.GasLimit + constants.BlockGasLimit - 1) / constants.BlockGasLimit); needed <= feasible &&
+		msg.GasLimit <= constants.BlockGasLimit {
+		probability = binomialTails(feasible, pBlock)[needed]
+	}
+
+	// the number of epochs worth of gas which are included with the aimed confidence
+	tails := binomialTails(epochs, pBlock)
+	confident := 0
+	for confident < epochs && tails[confident+1] >= inclusionConfidence {
+		confident++
+	}
+	budget := int64(confident) * constants.BlockGasLimit
+
+	suggested := big.NewInt(MinGasPremium)
+	used := senderAhead + msg.GasLimit
+	for i, m := range others {
+		used += m.Message.GasLimit
+		if used > budget {
+			if p := big.Add(premiums[i], big.NewInt(1)); p.GreaterThan(suggested) {
+				suggested = p
+			}
+			break
+		}
+	}
+
+	return &types.InclusionEstimate{
+		Probability:         probability,
+		GasAhead:            gasAhead,
+		ProjectedBaseFee:    projected[len(projected)-1],
+		SuggestedGasPremium: suggested,
+		SuggestedGasFeeCap:  big.Add(maxBaseFee, suggested),
+	}
+}
+
+// projectBaseFees projects the base fee of the next epochs from its average change over the previous ones,
+// the change per epoch is bounded by the maximum change allowed by the protocol
+func projectBaseFees(baseFees []big.Int, epochs int) []big.Int {
+	ratio := 1.0
+	if oldest := baseFees[len(baseFees)-1]; len(baseFees) > 1 && oldest.Sign() > 0 {
+		total, _ := new(stdbig.Float).Quo(new(stdbig.Float).SetInt(baseFees[0].Int), new(stdbig.Float).SetInt(oldest.Int)).Float64()
+		ratio = math.Pow(total, 1/float64(len(baseFees)-1))
+	}
+	maxRatio := 1 + 1/float64(constants.BaseFeeMaxChangeDenom)
+	ratio = math.Max(math.Min(ratio, maxRatio), 1/maxRatio)
+
+	out := make([]big.Int, epochs)
+	for i := range out {
+		factor := math.Pow(ratio, float64(i))
+		bf, _ := new(stdbig.Float).Mul(new(stdbig.Float).SetInt(baseFees[0].Int), stdbig.NewFloat(factor)).Int(nil)
+		out[i] = big.NewFromGo(bf)
+		if out[i].LessThan(big.NewInt(constants.MinimumBaseFee)) {
+			out[i] = big.NewInt(constants.MinimumBaseFee)
+		}
+	}
+	return out
+}
+
+func effectivePremium(msg *types.Message, baseFee big.Int) big.Int {
+	maxPremium := big.Sub(msg.GasFeeCap, baseFee)
+	if big.Cmp(maxPremium, msg.GasPremium) < 0 {
+		return maxPremium
+	}
+	return msg.GasPremium
+}
+
+// binomialTails returns the probabilities of at least k successes out of n trials, for k from 0 to n+1
+func binomialTails(n int, p float64) []float64 {
+	tails := make([]float64, n+2)
+	lgN, _ := math.Lgamma(float64(n + 1))
+	for i := n; i >= 0; i-- {
+		lgI, _ := math.Lgamma(float64(i + 1))
+		lgNI, _ := math.Lgamma(float64(n - i + 1))
+		tails[i] = math.Min(tails[i+1]+math.Exp(lgN-lgI-lgNI+float64(i)*math.Log(p)+float64(n-i)*math.Log1p(-p)), 1)
+	}
+	return tails
+}
+
+type byPremium struct {
+	msgs     []*types.SignedMessage
+	premiums []big.Int
+}
+
+func (b byPremium) Len() int           { return len(b.msgs) }
+func (b byPremium) Less(i, j int) bool { return b.premiums[i].GreaterThan(b.premiums[j]) }
+func (b byPremium) Swap(i, j int) {
+	b.msgs[i], b.msgs[j] = b.msgs[j], b.msgs[i]
+	b.premiums[i], b.premiums[j] = b.premiums[j], b.premiums[i]
+}
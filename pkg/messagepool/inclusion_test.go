@@ -0,0 +1,71 @@
+package messagepool
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestSimulateInclusion(t *testing.T) {
+	tf.UnitTest(t)
+
+	mkMsg := func(from address.Address, nonce uint64, gasLimit int64, feeCap, premium int64) *types.Message {
+		return &types.Message{
+			From:       from,
+			Nonce:      nonce,
+			GasLimit:   gasLimit,
+			GasFeeCap:  big.NewInt(feeCap),
+			GasPremium: big.NewInt(premium),
+		}
+	}
+	sender, other := mkAddress(100), mkAddress(101)
+	baseFees := []big.Int{big.NewInt(1000), big.NewInt(1000), big.NewInt(1000)}
+
+	// an empty pool includes the message in the next non empty epoch
+	msg := mkMsg(sender, 0, 1_000_000, 10_000_000, 200_000)
+	est := simulateInclusion(msg, nil, baseFees, 1)
+	assert.InDelta(t, 1-noWinnersProb()[0], est.Probability, 1e-9)
+	assert.Equal(t, int64(0), est.GasAhead)
+	assert.Equal(t, big.NewInt(1000), est.ProjectedBaseFee)
+	assert.Equal(t, big.NewInt(MinGasPremium), est.SuggestedGasPremium)
+
+	// two blocks worth of better paying messages push the message back by two epochs
+	var pending []*types.SignedMessage
+	for i := uint64(0); i < 2; i++ {
+		pending = append(pending, &types.SignedMessage{Message: *mkMsg(other, i, constants.BlockGasLimit, 10_000_000, 500_000)})
+	}
+	pending = append(pending, &types.SignedMessage{Message: *mkMsg(other, 2, 1_000_000, 10_000_000, 100_000)})
+	est = simulateInclusion(msg, pending, baseFees, 1)
+	assert.Equal(t, float64(0), est.Probability)
+	assert.Equal(t, int64(2*constants.BlockGasLimit), est.GasAhead)
+	assert.Equal(t, big.NewInt(500_001), est.SuggestedGasPremium)
+
+	est = simulateInclusion(msg, pending, baseFees, 10)
+	assert.Greater(t, est.Probability, 0.99)
+
+	// the pending messages of the sender with a lower nonce go first whatever their premium
+	pending = append(pending, &types.SignedMessage{Message: *mkMsg(sender, 0, 1_000_000, 10_000_000, 1)})
+	msg = mkMsg(sender, 1, 1_000_000, 10_000_000, 1_000_000)
+	est = simulateInclusion(msg, pending, baseFees, 1)
+	assert.Equal(t, int64(1_000_000), est.GasAhead)
+
+	// a fee cap below the rising base fee is never included
+	rising := []big.Int{big.NewInt(10_000), big.NewInt(8_000), big.NewInt(6_400)}
+	est = simulateInclusion(mkMsg(sender, 0, 1_000_000, 9_999, 200), nil, rising, 3)
+	assert.Equal(t, float64(0), est.Probability)
+	assert.True(t, est.ProjectedBaseFee.GreaterThan(big.NewInt(10_000)))
+	assert.True(t, est.SuggestedGasFeeCap.GreaterThan(est.ProjectedBaseFee))
+}
+
+func TestBinomialTails(t *testing.T) {
+	tf.UnitTest(t)
+
+	tails := binomialTails(2, 0.5)
+	assert.InDeltaSlice(t, []float64{1, 0.75, 0.25, 0}, tails, 1e-9)
+}
@@ -79,6 +79,8 @@ const MaxMessageSize = 64 << 10 // 64KiB
 var (
 	ErrMessageTooBig = errors.New("message too big")
 
+	ErrInvalidForBlockInclusion = errors.New("message not valid for block inclusion")
+
 	ErrMessageValueTooHigh = errors.New("cannot send more filecoin than will ever exist")
 
 	ErrNonceTooLow = errors.New("message nonce too low")
@@ -169,6 +171,8 @@ type MessagePool struct {
 
 	localMsgs datastore.Datastore
 
+	pushIDs datastore.Datastore
+
 	netName string
 
 	sigValCache *lru.TwoQueueCache[string, struct{}]
@@ -198,7 +202,9 @@ func newDefaultMaxFeeFunc(maxFee types.FIL) DefaultMaxFeeFunc {
 }
 
 type msgSet struct {
-	msgs          map[uint64]*types.SignedMessage
+	msgs map[uint64]*types.SignedMessage
+	// added is when the messages were added to the pool, or replaced by fee
+	added         map[uint64]time.Time
 	nextNonce     uint64
 	requiredFunds *stdbig.Int
 }
@@ -206,6 +212,7 @@ type msgSet struct {
 func newMsgSet(nonce uint64) *msgSet {
 	return &msgSet{
 		msgs:          make(map[uint64]*types.SignedMessage),
+		added:         make(map[uint64]time.Time),
 		nextNonce:     nonce,
 		requiredFunds: stdbig.NewInt(0),
 	}
@@ -320,6 +327,7 @@ func (ms *msgSet) add(m *types.SignedMessage, mp *MessagePool, strict, untrusted
 
 	ms.nextNonce = nextNonce
 	ms.msgs[m.Message.Nonce] = m
+	ms.added[m.Message.Nonce] = constants.Clock.Now()
 	ms.requiredFunds.Add(ms.requiredFunds, m.Message.RequiredFunds().Int)
 	// ms.requiredFunds.Add(ms.requiredFunds, m.Message.Value.Int)
 
@@ -343,6 +351,7 @@ func (ms *msgSet) rm(nonce uint64, applied bool) {
 	ms.requiredFunds.Sub(ms.requiredFunds, m.Message.RequiredFunds().Int)
 	// ms.requiredFunds.Sub(ms.requiredFunds, m.Message.Value.Int)
 	delete(ms.msgs, nonce)
+	delete(ms.added, nonce)
 
 	// adjust next nonce
 	if applied {
@@ -429,6 +438,7 @@ func New(ctx context.Context,
 		stateNonceCache: stateNonceCache,
 		changes:         lps.New(50),
 		localMsgs:       namespace.Wrap(ds, datastore.NewKey(localMsgsDs)),
+		pushIDs:         namespace.Wrap(ds, datastore.NewKey(pushIDsDs)),
 		api:             api,
 		sm:              sm,
 		netName:         netName,
@@ -473,6 +483,10 @@ func New(ctx context.Context,
 			log.Errorf("loading local messages: %+v", err)
 		}
 
+		if err := mp.prunePushIDs(ctx); err != nil {
+			log.Errorf("pruning push ids: %+v", err)
+		}
+
 		log.Info("mpool ready")
 
 		mp.runLoop(ctx)
@@ -668,6 +682,7 @@ func (mp *MessagePool) runLoop(ctx context.Context) {
 	for {
 		select {
 		case <-mp.repubTk.C:
+			mp.expirePendingMessages(ctx)
 			if err := mp.republishPendingMessages(ctx); err != nil {
 				log.Errorf("error while republishing messages: %s", err)
 			}
@@ -689,10 +704,6 @@ func (mp *MessagePool) runLoop(ctx context.Context) {
 }
 
 func (mp *MessagePool) addLocal(ctx context.Context, m *types.SignedMessage) error {
-	if err := mp.setLocal(ctx, m.Message.From); err != nil {
-		return err
-	}
-
 	buf := new(bytes.Buffer)
 	err := m.MarshalCBOR(buf)
 	if err != nil {
@@ -805,7 +816,7 @@ func (mp *MessagePool) checkMessage(ctx context.Context, m *types.SignedMessage)
 
 	// Perform syntactic validation, minGas=0 as we check the actual mingas before we add it
 	if err := m.Message.ValidForBlockInclusion(0, mp.api.StateNetworkVersion(ctx, mp.curTS.Height())); err != nil {
-		return fmt.Errorf("message not valid for block inclusion: %v", err)
+		return fmt.Errorf("%w: %v", ErrInvalidForBlockInclusion, err)
 	}
 
 	if m.Message.To == address.Undef {
@@ -974,12 +985,15 @@ func (mp *MessagePool) addTS(ctx context.Context, m *types.SignedMessage, curTS
 		return false, fmt.Errorf("failed to check balance: %w", err)
 	}
 
-	err = mp.addLocked(ctx, m, !local, untrusted)
+	err = mp.addLocked(ctx, m, !local, untrusted, local)
 	if err != nil {
 		return false, fmt.Errorf("failed to add locked: %w", err)
 	}
 
 	if local {
+		if err := mp.setLocal(ctx, m.Message.From); err != nil {
+			return false, err
+		}
 		err = mp.addLocal(ctx, m)
 		if err != nil {
 			return false, fmt.Errorf("error persisting local message: %v", err)
@@ -1019,17 +1033,19 @@ func (mp *MessagePool) addLoaded(ctx context.Context, m *types.SignedMessage) er
 		return err
 	}
 
-	return mp.addLocked(ctx, m, false, false)
+	return mp.addLocked(ctx, m, false, false, true)
 }
 
 func (mp *MessagePool) addSkipChecks(ctx context.Context, m *types.SignedMessage) error {
 	mp.lk.Lock()
 	defer mp.lk.Unlock()
 
-	return mp.addLocked(ctx, m, false, false)
+	return mp.addLocked(ctx, m, false, false, false)
 }
 
-func (mp *MessagePool) addLocked(ctx context.Context, m *types.SignedMessage, strict, untrusted bool) error {
+// addLocked adds m to the pending messages, local tells the subscribers that m is local before its sender is marked
+// as local, which is done by the callers once m is added
+func (mp *MessagePool) addLocked(ctx context.Context, m *types.SignedMessage, strict, untrusted, local bool) error {
 	log.Debugf("mpooladd: %s %d", m.Message.From, m.Message.Nonce)
 	if m.Signature.Type == crypto.SigTypeBLS {
 		mp.blsSigCache.Add(m.Cid(), m.Signature)
@@ -1081,10 +1097,7 @@ func (mp *MessagePool) addLocked(ctx context.Context, m *types.SignedMessage, st
 		}
 	}
 
-	mp.changes.Pub(types.MpoolUpdate{
-		Type:    types.MpoolAdd,
-		Message: m,
-	}, localUpdates)
+	mp.publishUpdate(ctx, types.MpoolAdd, m, local)
 
 	mp.journal.RecordEvent(mp.evtTypes[evtTypeMpoolAdd], func() interface{} {
 		mc := m.Cid()
@@ -1202,6 +1215,9 @@ func (mp *MessagePool) getStateBalance(ctx context.Context, addr address.Address
 //   - strict checks are enabled
 //   - extra strict add checks are used when adding the messages to the msgSet
 //     that means: no nonce gaps, at most 10 pending messages for the actor
+//   - the GasFeeCap must cover the base fee lower bound, the message is rejected instead of being kept for a
+//     later republish
+//   - the message is not local, the sender gains no priority in the selection and the message is not persisted
 func (mp *MessagePool) PushUntrusted(ctx context.Context, m *types.SignedMessage) (cid.Cid, error) {
 	err := mp.checkMessage(ctx, m)
 	if err != nil {
@@ -1215,24 +1231,21 @@ func (mp *MessagePool) PushUntrusted(ctx context.Context, m *types.SignedMessage
 	}()
 
 	mp.curTSLk.Lock()
-	publish, err := mp.addTS(ctx, m, mp.curTS, true, true)
+	_, err = mp.addTS(ctx, m, mp.curTS, false, true)
 	if err != nil {
 		mp.curTSLk.Unlock()
 		return cid.Undef, err
 	}
 	mp.curTSLk.Unlock()
 
-	if publish {
-		buf := new(bytes.Buffer)
-		err := m.MarshalCBOR(buf)
-		if err != nil {
-			return cid.Undef, fmt.Errorf("error serializing message: %v", err)
-		}
+	// the non local checks passed, the message can be included in the next blocks
+	buf := new(bytes.Buffer)
+	if err := m.MarshalCBOR(buf); err != nil {
+		return cid.Undef, fmt.Errorf("error serializing message: %v", err)
+	}
 
-		err = mp.api.PubSubPublish(ctx, types.MessageTopic(mp.netName), buf.Bytes())
-		if err != nil {
-			return cid.Undef, fmt.Errorf("error publishing message: %v", err)
-		}
+	if err := mp.api.PubSubPublish(ctx, types.MessageTopic(mp.netName), buf.Bytes()); err != nil {
+		return cid.Undef, fmt.Errorf("error publishing message: %v", err)
 	}
 
 	return m.Cid(), nil
@@ -1246,6 +1259,11 @@ func (mp *MessagePool) Remove(ctx context.Context, from address.Address, nonce u
 }
 
 func (mp *MessagePool) remove(ctx context.Context, from address.Address, nonce uint64, applied bool) {
+	mp.removeWithChange(ctx, from, nonce, applied, types.MpoolRemove)
+}
+
+// removeWithChange removes the message like remove, the subscribers are notified with change
+func (mp *MessagePool) removeWithChange(ctx context.Context, from address.Address, nonce uint64, applied bool, change types.MpoolChange) {
 	mset, ok, err := mp.getPendingMset(ctx, from)
 	if err != nil {
 		log.Debugf("mpoolremove failed to get mset: %s", err)
@@ -1257,14 +1275,15 @@ func (mp *MessagePool) remove(ctx context.Context, from address.Address, nonce u
 	}
 
 	if m, ok := mset.msgs[nonce]; ok {
-		mp.changes.Pub(types.MpoolUpdate{
-			Type:    types.MpoolRemove,
-			Message: m,
-		}, localUpdates)
+		mp.publishUpdate(ctx, change, m, false)
 
 		mp.journal.RecordEvent(mp.evtTypes[evtTypeMpoolRemove], func() interface{} {
+			action := "remove"
+			if change == types.MpoolExpire {
+				action = "expire"
+			}
 			return MessagePoolEvt{
-				Action:   "remove",
+				Action:   action,
 				Messages: []MessagePoolEvtMessage{{Message: m.Message, CID: m.Cid()}},
 			}
 		})
@@ -1605,7 +1624,39 @@ func (mp *MessagePool) RecoverSig(msg *types.Message) *types.SignedMessage {
 	}
 }
 
+// mpoolUpdate is the update published to the subscribers, local is decided when it is published since the
+// subscribers can't take the lock of the pool
+type mpoolUpdate struct {
+	update types.MpoolUpdate
+	local  bool
+}
+
+// publishUpdate notifies the subscribers of the change of the message, it is called with the lock held. The message
+// is local when local is set or its sender is local.
+func (mp *MessagePool) publishUpdate(ctx context.Context, change types.MpoolChange, m *types.SignedMessage, local bool) {
+	if !local {
+		var err error
+		if local, err = mp.isLocal(ctx, m.Message.From); err != nil {
+			log.Debugf("check whether %s is local: %v", m.Message.From, err)
+		}
+	}
+
+	mp.changes.Pub(mpoolUpdate{
+		update: types.MpoolUpdate{
+			Type:    change,
+			Message: m,
+		},
+		local: local,
+	}, localUpdates)
+}
+
 func (mp *MessagePool) Updates(ctx context.Context) (<-chan types.MpoolUpdate, error) {
+	return mp.UpdatesFiltered(ctx, nil)
+}
+
+// UpdatesFiltered is like Updates but only sends the updates matching the filter, all of them when it is nil
+func (mp *MessagePool) UpdatesFiltered(ctx context.Context, filter *types.MpoolSubFilter) (<-chan types.MpoolUpdate, error) {
+	match := mp.updateMatcher(ctx, filter)
 	out := make(chan types.MpoolUpdate, 20)
 	sub := mp.changes.Sub(localUpdates)
 
@@ -1616,8 +1667,12 @@ func (mp *MessagePool) Updates(ctx context.Context) (<-chan types.MpoolUpdate, e
 		for {
 			select {
 			case u := <-sub:
+				up := u.(mpoolUpdate)
+				if !match(ctx, up) {
+					continue
+				}
 				select {
-				case out <- u.(types.MpoolUpdate):
+				case out <- up.update:
 				case <-ctx.Done():
 					return
 				case <-mp.closer:
@@ -1659,8 +1714,17 @@ func (mp *MessagePool) loadLocal(ctx context.Context) error {
 			if errors.Is(err, ErrNonceTooLow) {
 				continue // todo: drop the message from local cache (if above certain confidence threshold)
 			}
+			if errors.Is(err, ErrMessageTooBig) || errors.Is(err, ErrInvalidForBlockInclusion) {
+				// it can never be included, don't load it again on the next start
+				log.Warnf("dropping local message %s: %v", sm.Cid(), err)
+				if err := mp.localMsgs.Delete(ctx, datastore.NewKey(r.Key)); err != nil {
+					log.Errorf("deleting local message %s: %v", sm.Cid(), err)
+				}
+				continue
+			}
 
 			log.Errorf("adding local message: %+v", err)
+			continue
 		}
 
 		if err = mp.setLocal(ctx, sm.Message.From); err != nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/fork"
@@ -21,6 +22,7 @@ import (
 	"github.com/ipfs/go-datastore"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
 
@@ -488,6 +490,45 @@ func TestMessagePool(t *testing.T) {
 
 }
 
+func TestPushUntrusted(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	tma := newTestMpoolAPI()
+	w, mp := newWalletAndMpool(t, tma)
+	defer mp.Close() // nolint
+
+	sender, err := w.NewAddress(ctx, address.SECP256K1)
+	assert.NoError(t, err)
+	tma.setBalance(sender, 1000)
+	tma.setStateNonce(sender, 0)
+	target := mkAddress(1001)
+
+	// no nonce gap
+	_, err = mp.PushUntrusted(ctx, mkMessage(sender, target, 1, w))
+	assert.ErrorIs(t, err, ErrNonceGap)
+
+	// the balance must cover the gas
+	tma.setBalanceRaw(sender, tbig.NewInt(1))
+	_, err = mp.PushUntrusted(ctx, mkMessage(sender, target, 0, w))
+	assert.ErrorIs(t, err, ErrNotEnoughFunds)
+	tma.setBalance(sender, 1000)
+
+	smsg := mkMessage(sender, target, 0, w)
+	c, err := mp.PushUntrusted(ctx, smsg)
+	assert.NoError(t, err)
+	assert.Equal(t, smsg.Cid(), c)
+	assertNonce(t, mp, sender, 1)
+
+	// the sender is not made local
+	local, err := mp.isLocal(ctx, sender)
+	assert.NoError(t, err)
+	assert.False(t, local)
+	has, err := mp.localMsgs.Has(ctx, datastore.NewKey(string(c.Bytes())))
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
+
 func TestCheckMessageBig(t *testing.T) {
 	tma := newTestMpoolAPI()
 
@@ -551,6 +592,61 @@ func TestCheckMessageBig(t *testing.T) {
 	}
 }
 
+func TestCheckMessageUnincludable(t *testing.T) {
+	tf.UnitTest(t)
+
+	tma := newTestMpoolAPI()
+
+	w, mp := newWalletAndMpool(t, tma)
+	from, err := w.NewAddress(context.Background(), address.SECP256K1)
+	assert.NoError(t, err)
+
+	tma.setBalance(from, 1000e9)
+
+	to := mkAddress(1001)
+
+	sign := func(msg *types.Message) *types.SignedMessage {
+		sb, err := msg.SigningBytes(types.AddressProtocol2SignType(from.Protocol()))
+		require.NoError(t, err)
+		sig, err := w.WalletSign(context.Background(), from, sb, types.MsgMeta{})
+		require.NoError(t, err)
+		return &types.SignedMessage{Message: *msg, Signature: *sig}
+	}
+
+	sm := sign(&types.Message{
+		To:         to,
+		From:       from,
+		Value:      types.NewInt(1),
+		GasLimit:   constants.BlockGasLimit + 1,
+		GasFeeCap:  types.NewInt(100),
+		GasPremium: types.NewInt(1),
+	})
+	_, err = mp.Push(context.TODO(), sm)
+	assert.ErrorIs(t, err, ErrInvalidForBlockInclusion)
+
+	sm = sign(&types.Message{
+		To:         to,
+		From:       from,
+		Value:      types.NewInt(1),
+		GasLimit:   50000000,
+		GasFeeCap:  types.NewInt(100),
+		GasPremium: types.NewInt(1),
+		Params:     make([]byte, MaxMessageSize+1),
+	})
+	err = mp.Add(context.TODO(), sm)
+	assert.ErrorIs(t, err, ErrMessageTooBig)
+
+	// a local message stored before the checks is dropped when loaded
+	data, err := sm.Serialize()
+	require.NoError(t, err)
+	key := datastore.NewKey(string(sm.Cid().Bytes()))
+	require.NoError(t, mp.localMsgs.Put(context.TODO(), key, data))
+	require.NoError(t, mp.loadLocal(context.TODO()))
+	has, err := mp.localMsgs.Has(context.TODO(), key)
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
 func TestMessagePoolMessagesInEachBlock(t *testing.T) {
 	tf.UnitTest(t)
 
@@ -690,6 +786,61 @@ func TestPruningSimple(t *testing.T) {
 	}
 }
 
+func TestPruningKeepsNonceChains(t *testing.T) {
+	tf.UnitTest(t)
+
+	tma := newTestMpoolAPI()
+	w, mp := newWalletAndMpool(t, tma)
+
+	a := tma.nextBlock()
+	tma.applyBlock(t, a)
+
+	target := mkAddress(1001)
+	premiums := map[address.Address]func(nonce uint64) uint64{}
+	var senders []address.Address
+	for i := 0; i < 3; i++ {
+		sender, err := w.NewAddress(context.Background(), address.SECP256K1)
+		assert.NoError(t, err)
+		tma.setBalance(sender, 1) // in FIL
+		senders = append(senders, sender)
+	}
+	// the first sender pays little for its first nonces and a lot for the next ones
+	premiums[senders[0]] = func(nonce uint64) uint64 {
+		if nonce < 10 {
+			return 1
+		}
+		return 300
+	}
+	premiums[senders[1]] = func(nonce uint64) uint64 { return 200 - nonce*10 }
+	premiums[senders[2]] = func(nonce uint64) uint64 { return 50 }
+
+	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
+	for _, sender := range senders {
+		for nonce := uint64(0); nonce < 20; nonce++ {
+			m := makeTestMessage(w, sender, target, nonce, gasLimit, premiums[sender](nonce))
+			assert.NoError(t, mp.Add(context.TODO(), m))
+		}
+	}
+
+	mp.cfg.SizeLimitHigh = 50
+	mp.cfg.SizeLimitLow = 25
+	mp.Prune()
+
+	msgs, _ := mp.Pending(context.TODO())
+	assert.Len(t, msgs, 25)
+
+	nonces := map[address.Address][]uint64{}
+	for _, m := range msgs {
+		nonces[m.Message.From] = append(nonces[m.Message.From], m.Message.Nonce)
+	}
+	for sender, ns := range nonces {
+		sort.Slice(ns, func(i, j int) bool { return ns[i] < ns[j] })
+		for i, n := range ns {
+			assert.Equal(t, uint64(i), n, "nonce gap left for %s: %v", sender, ns)
+		}
+	}
+}
+
 func TestLoadLocal(t *testing.T) {
 	tf.UnitTest(t)
 
@@ -757,6 +908,128 @@ func TestLoadLocal(t *testing.T) {
 	}
 }
 
+func TestExpirePendingMessages(t *testing.T) {
+	tf.UnitTest(t)
+
+	tma := newTestMpoolAPI()
+	ds := datastore.NewMapDatastore()
+	ctx := context.Background()
+
+	mp, err := New(ctx, tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
+	require.NoError(t, err)
+
+	// the local actor pushes its messages, the others come from the network
+	w1 := newWallet(t)
+	a1, err := w1.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	w2 := newWallet(t)
+	a2, err := w2.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+
+	tma.setBalance(a1, 1) // in FIL
+	tma.setBalance(a2, 1) // in FIL
+	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
+	for i := 0; i < 5; i++ {
+		_, err := mp.Push(ctx, makeTestMessage(w1, a1, a2, uint64(i), gasLimit, uint64(i+1)))
+		require.NoError(t, err)
+	}
+	for i := 0; i < 3; i++ {
+		mustAdd(t, mp, makeTestMessage(w2, a2, a1, uint64(i), gasLimit, uint64(i+1)))
+	}
+
+	// nothing expires without a ttl
+	mp.expirePendingMessages(ctx)
+	pending, _ := mp.Pending(ctx)
+	require.Len(t, pending, 8)
+
+	cfg := mp.GetConfig()
+	cfg.PendingTTL = time.Hour
+	require.NoError(t, mp.SetConfig(ctx, cfg))
+
+	// the last two messages of a1 and the messages of a2 are too old
+	old := time.Now().Add(-2 * time.Hour)
+	mp.lk.Lock()
+	mset, _, err := mp.getPendingMset(ctx, a1)
+	require.NoError(t, err)
+	mset.added[3], mset.added[4] = old, old
+	mset, _, err = mp.getPendingMset(ctx, a2)
+	require.NoError(t, err)
+	for nonce := range mset.added {
+		mset.added[nonce] = old
+	}
+	mp.lk.Unlock()
+
+	updates, err := mp.Updates(ctx)
+	require.NoError(t, err)
+	mp.expirePendingMessages(ctx)
+
+	for i := 0; i < 5; i++ {
+		u := <-updates
+		assert.Equal(t, types.MpoolExpire, u.Type)
+	}
+	pending, _ = mp.Pending(ctx)
+	require.Len(t, pending, 3)
+	for _, m := range pending {
+		assert.Equal(t, a1, m.Message.From)
+		assert.Less(t, m.Message.Nonce, uint64(3))
+	}
+
+	// the nonce doesn't count the expired messages
+	nonce, err := mp.GetNonce(ctx, a1, types.EmptyTSK)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), nonce)
+
+	// and the expired local messages are not loaded again
+	require.NoError(t, mp.Close())
+	mp, err = New(ctx, tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
+	require.NoError(t, err)
+	pending, _ = mp.Pending(ctx)
+	assert.Len(t, pending, 3)
+}
+
+func TestExpirePendingMessagesNonceGap(t *testing.T) {
+	tf.UnitTest(t)
+
+	tma := newTestMpoolAPI()
+	ds := datastore.NewMapDatastore()
+	ctx := context.Background()
+
+	mp, err := New(ctx, tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
+	require.NoError(t, err)
+	cfg := mp.GetConfig()
+	cfg.PendingTTL = time.Hour
+	require.NoError(t, mp.SetConfig(ctx, cfg))
+
+	w1 := newWallet(t)
+	a1, err := w1.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	w2 := newWallet(t)
+	a2, err := w2.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+
+	tma.setBalance(a1, 1) // in FIL
+	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
+	for i := 0; i < 5; i++ {
+		mustAdd(t, mp, makeTestMessage(w1, a1, a2, uint64(i), gasLimit, uint64(i+1)))
+	}
+
+	// only the message at nonce 1 is too old, the later ones can't be included without it
+	mp.lk.Lock()
+	mset, _, err := mp.getPendingMset(ctx, a1)
+	require.NoError(t, err)
+	mset.added[1] = time.Now().Add(-2 * time.Hour)
+	mp.lk.Unlock()
+
+	mp.expirePendingMessages(ctx)
+	pending, _ := mp.Pending(ctx)
+	require.Len(t, pending, 1)
+	assert.Equal(t, uint64(0), pending[0].Message.Nonce)
+
+	nonce, err := mp.GetNonce(ctx, a1, types.EmptyTSK)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), nonce)
+}
+
 func TestClearAll(t *testing.T) {
 	tf.UnitTest(t)
 
@@ -922,6 +1195,138 @@ func TestUpdates(t *testing.T) {
 	}
 }
 
+func TestUpdatesFiltered(t *testing.T) {
+	tf.UnitTest(t)
+
+	tma := newTestMpoolAPI()
+	ds := datastore.NewMapDatastore()
+
+	mp, err := New(context.Background(), tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w1 := newWallet(t)
+	a1, err := w1.NewAddress(context.Background(), address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w2 := newWallet(t)
+	a2, err := w2.NewAddress(context.Background(), address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	local, err := mp.UpdatesFiltered(ctx, &types.MpoolSubFilter{LocalOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inbound, err := mp.UpdatesFiltered(ctx, &types.MpoolSubFilter{To: []address.Address{a1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
+
+	tma.setBalance(a1, 1) // in FIL
+	tma.setBalance(a2, 1) // in FIL
+
+	for i := 0; i < 3; i++ {
+		// the messages of a2 are received from the network
+		m := makeTestMessage(w2, a2, a1, uint64(i), gasLimit, uint64(i+1))
+		if err := mp.Add(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+		u := <-inbound
+		assert.Equal(t, m.Cid(), u.Message.Cid())
+
+		m = makeTestMessage(w1, a1, a2, uint64(i), gasLimit, uint64(i+1))
+		if _, err := mp.Push(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+		u = <-local
+		assert.Equal(t, m.Cid(), u.Message.Cid())
+		assert.Equal(t, types.MpoolAdd, u.Type)
+	}
+
+	msg := makeTestMessage(w1, a1, a2, 0, gasLimit, 1)
+	update := func(change types.MpoolChange, local bool) mpoolUpdate {
+		return mpoolUpdate{update: types.MpoolUpdate{Type: change, Message: msg}, local: local}
+	}
+	for _, c := range []struct {
+		filter *types.MpoolSubFilter
+		update mpoolUpdate
+		match  bool
+	}{
+		{nil, update(types.MpoolAdd, false), true},
+		{&types.MpoolSubFilter{}, update(types.MpoolRemove, false), true},
+		{&types.MpoolSubFilter{From: []address.Address{a1}, To: []address.Address{a2}}, update(types.MpoolAdd, false), true},
+		{&types.MpoolSubFilter{From: []address.Address{a2}}, update(types.MpoolAdd, false), false},
+		{&types.MpoolSubFilter{To: []address.Address{a1, a2}}, update(types.MpoolAdd, false), true},
+		{&types.MpoolSubFilter{Methods: []abi.MethodNum{2}}, update(types.MpoolAdd, false), true},
+		{&types.MpoolSubFilter{Methods: []abi.MethodNum{3}}, update(types.MpoolAdd, false), false},
+		{&types.MpoolSubFilter{Types: []types.MpoolChange{types.MpoolRemove, types.MpoolExpire}}, update(types.MpoolAdd, false), false},
+		{&types.MpoolSubFilter{Types: []types.MpoolChange{types.MpoolRemove, types.MpoolExpire}}, update(types.MpoolExpire, false), true},
+		{&types.MpoolSubFilter{LocalOnly: true}, update(types.MpoolAdd, false), false},
+		{&types.MpoolSubFilter{LocalOnly: true, Methods: []abi.MethodNum{2}}, update(types.MpoolAdd, true), true},
+	} {
+		assert.Equal(t, c.match, mp.updateMatcher(ctx, c.filter)(ctx, c.update), "filter %+v", c.filter)
+	}
+
+	if err := mp.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLocalSenderMarkedOnceAdded(t *testing.T) {
+	tf.UnitTest(t)
+
+	tma := newTestMpoolAPI()
+	ds := datastore.NewMapDatastore()
+
+	mp, err := New(context.Background(), tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := newWallet(t)
+	a1, err := w.NewAddress(context.Background(), address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a2, err := w.NewAddress(context.Background(), address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
+	tma.setBalance(a1, 1) // in FIL
+
+	if err := mp.Add(ctx, makeTestMessage(w, a1, a2, 0, gasLimit, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the replacement is rejected when it is added, its sender is not local
+	m := makeTestMessage(w, a1, a1, 0, gasLimit, 2)
+	_, err = mp.Push(ctx, m)
+	assert.ErrorIs(t, err, ErrRBFTooLowPremium)
+	local, err := mp.isLocal(ctx, a1)
+	require.NoError(t, err)
+	assert.False(t, local)
+
+	m = makeTestMessage(w, a1, a1, 0, gasLimit, 10)
+	if _, err := mp.Push(ctx, m); err != nil {
+		t.Fatal(err)
+	}
+	local, err = mp.isLocal(ctx, a1)
+	require.NoError(t, err)
+	assert.True(t, local)
+}
 func TestCapGasFee(t *testing.T) {
 	t.Run("use default maxfee", func(t *testing.T) {
 		msg := &types.Message{
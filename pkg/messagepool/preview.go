@@ -0,0 +1,59 @@
+package messagepool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// ErrExecPreviewFailed is returned when the preview of the execution of a message fails
+var ErrExecPreviewFailed = errors.New("message execution preview failed")
+
+// PreviewMessage executes msg on the state of the head, after the pending messages of its sender, so that the
+// failures not depending on the messages included in the meantime, such as bad params or a missing escrow, are
+// caught before paying for gas. The gas limit and the fee cap left unset do not limit the execution.
+func (mp *MessagePool) PreviewMessage(ctx context.Context, msg *types.Message) (*types.InvocResult, error) {
+	head, err := mp.api.ChainHead(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting head: %w", err)
+	}
+
+	cp := *msg
+	if cp.GasLimit == 0 {
+		cp.GasLimit = constants.BlockGasLimit
+	}
+	if cp.GasFeeCap == types.EmptyInt || cp.GasFeeCap.IsZero() {
+		// the gas is free with a zero fee cap
+		cp.GasFeeCap = big.Zero()
+		cp.GasPremium = big.Zero()
+	}
+	if cp.GasPremium == types.EmptyInt {
+		cp.GasPremium = big.Zero()
+	}
+	if cp.Value == types.EmptyInt {
+		cp.Value = big.Zero()
+	}
+
+	res, _, _, err := mp.GasEstimateCallWithGas(ctx, &cp, head)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CheckPreview returns an ErrExecPreviewFailed error when the previewed execution exited with an error
+func CheckPreview(res *types.InvocResult) error {
+	if res.MsgRct == nil {
+		return fmt.Errorf("%w: %s", ErrExecPreviewFailed, res.Error)
+	}
+	if res.MsgRct.ExitCode != exitcode.Ok {
+		return fmt.Errorf("%w: exit %s, reason: %s", ErrExecPreviewFailed, res.MsgRct.ExitCode, res.Error)
+	}
+	return nil
+}
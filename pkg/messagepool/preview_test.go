@@ -0,0 +1,30 @@
+package messagepool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestCheckPreview(t *testing.T) {
+	tf.UnitTest(t)
+
+	assert.NoError(t, CheckPreview(&types.InvocResult{MsgRct: &types.MessageReceipt{ExitCode: exitcode.Ok}}))
+
+	err := CheckPreview(&types.InvocResult{
+		MsgRct: &types.MessageReceipt{ExitCode: exitcode.ErrInsufficientFunds},
+		Error:  "not enough escrow",
+	})
+	assert.True(t, errors.Is(err, ErrExecPreviewFailed))
+	assert.Contains(t, err.Error(), "not enough escrow")
+
+	assert.True(t, errors.Is(CheckPreview(&types.InvocResult{Error: "no receipt"}), ErrExecPreviewFailed))
+
+	assert.NoError(t, types.ExecPreviewWarn.Validate())
+	assert.Error(t, types.ExecPreview("always").Validate())
+}
@@ -1,6 +1,7 @@
 package messagepool
 
 import (
+	"container/heap"
 	"context"
 	"errors"
 	"fmt"
@@ -9,9 +10,16 @@ import (
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/ipfs-force-community/metrics"
 	"github.com/ipfs/go-cid"
 )
 
+var (
+	prunedChainsCnt = metrics.NewCounter("mpool/pruned_chains", "The number of message chains evicted, entirely or from their tail, by the message pool pruning")
+	prunedMsgs      = metrics.NewInt64("mpool/pruned_messages", "The number of messages evicted by the last message pool pruning", "")
+	prunedSenders   = metrics.NewInt64("mpool/pruned_senders", "The number of senders losing messages in the last message pool pruning", "")
+)
+
 func (mp *MessagePool) pruneExcessMessages() error {
 	mp.curTSLk.Lock()
 	ts := mp.curTS
@@ -73,7 +81,8 @@ func (mp *MessagePool) pruneMessages(ctx context.Context, ts *types.TipSet) erro
 	pruneMsgs := make(map[cid.Cid]*types.SignedMessage, mp.currentSize)
 	keepCount := 0
 
-	var chains []*msgChain
+	// the chains of each actor, in nonce order
+	var queues []*chainQueue
 	for actor, mset := range pending {
 		// we never prune protected actors
 		_, keep := protected[actor]
@@ -87,33 +96,84 @@ func (mp *MessagePool) pruneMessages(ctx context.Context, ts *types.TipSet) erro
 			pruneMsgs[m.Message.Cid()] = m
 		}
 		actorChains := mp.createMessageChains(ctx, actor, mset, baseFeeLowerBound, ts)
-		chains = append(chains, actorChains...)
+		if len(actorChains) > 0 {
+			queues = append(queues, &chainQueue{chains: actorChains})
+		}
 	}
 
-	// Sort the chains
-	sort.Slice(chains, func(i, j int) bool {
-		return chains[i].Before(chains[j])
-	})
-
-	// Keep messages (remove them from pruneMsgs) from chains while we are under the low water mark
+	// Keep messages (remove them from pruneMsgs) from the best chains while we are under the low water mark. A chain
+	// is only kept after the chains of lower nonces of its actor, so that the messages left in the pool are never
+	// stranded behind a nonce gap: the messages are evicted from the tail of the nonce chains of their actor.
 	loWaterMark := mp.cfg.SizeLimitLow
+	cq := chainQueues(queues)
+	heap.Init(&cq)
 keepLoop:
-	for _, chain := range chains {
-		for _, m := range chain.msgs {
-			if keepCount < loWaterMark {
-				delete(pruneMsgs, m.Message.Cid())
-				keepCount++
-			} else {
+	for cq.Len() > 0 {
+		q := cq[0]
+		for _, m := range q.chains[0].msgs {
+			if keepCount >= loWaterMark {
 				break keepLoop
 			}
+			delete(pruneMsgs, m.Message.Cid())
+			keepCount++
+		}
+
+		q.chains = q.chains[1:]
+		if len(q.chains) == 0 {
+			heap.Pop(&cq)
+		} else {
+			heap.Fix(&cq, 0)
 		}
 	}
 
-	// and remove all messages that are still in pruneMsgs after processing the chains
-	log.Infof("Pruning %d messages", len(pruneMsgs))
+	// and remove all messages that are still in pruneMsgs after processing the chains, from the highest nonce of each
+	// actor down
+	evicted := make([]*types.SignedMessage, 0, len(pruneMsgs))
+	senders := make(map[address.Address]struct{})
 	for _, m := range pruneMsgs {
+		evicted = append(evicted, m)
+		senders[m.Message.From] = struct{}{}
+	}
+	sort.Slice(evicted, func(i, j int) bool {
+		return evicted[i].Message.Nonce > evicted[j].Message.Nonce
+	})
+
+	log.Infof("Pruning %d messages of %d senders", len(evicted), len(senders))
+	for _, m := range evicted {
 		mp.remove(ctx, m.Message.From, m.Message.Nonce, false)
 	}
+	for _, q := range cq {
+		for range q.chains {
+			prunedChainsCnt.Tick(ctx)
+		}
+	}
+	prunedMsgs.Set(ctx, int64(len(evicted)))
+	prunedSenders.Set(ctx, int64(len(senders)))
 
 	return nil
 }
+
+// chainQueue holds the chains of an actor not kept yet, the next one to keep first
+type chainQueue struct {
+	chains []*msgChain
+}
+
+// chainQueues is a heap of the actors by their next chain to keep
+type chainQueues []*chainQueue
+
+func (cq chainQueues) Len() int { return len(cq) }
+func (cq chainQueues) Less(i, j int) bool {
+	return cq[i].chains[0].Before(cq[j].chains[0])
+}
+func (cq chainQueues) Swap(i, j int) { cq[i], cq[j] = cq[j], cq[i] }
+func (cq *chainQueues) Push(x interface{}) {
+	*cq = append(*cq, x.(*chainQueue))
+}
+
+func (cq *chainQueues) Pop() interface{} {
+	old := *cq
+	n := len(old)
+	q := old[n-1]
+	*cq = old[:n-1]
+	return q
+}
@@ -0,0 +1,110 @@
+package messagepool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const pushIDsDs = "/mpool/pushid"
+
+// PushIDTTL is how long the message pushed with an id is returned to the pushes retried with the same id
+var PushIDTTL = 24 * time.Hour
+
+type pushIDRecord struct {
+	Message *types.SignedMessage
+	Time    time.Time
+}
+
+// GetPushedByID returns the message pushed with the id, or nil when the id is unknown or expired
+func (mp *MessagePool) GetPushedByID(ctx context.Context, id types.UUID) (*types.SignedMessage, error) {
+	data, err := mp.pushIDs.Get(ctx, datastore.NewKey(id.String()))
+	if err != nil {
+		if errors.Is(err, datastore.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get push id %s: %w", id, err)
+	}
+
+	var record pushIDRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("unmarshal push id %s: %w", id, err)
+	}
+	if time.Since(record.Time) > PushIDTTL {
+		return nil, nil
+	}
+	return record.Message, nil
+}
+
+// PushedByID returns the message pushed with the id when msg, sent from the key address from, is a retry of
+// its push, nil when the id is unknown or expired, and an error when the id was used for another message
+func (mp *MessagePool) PushedByID(ctx context.Context, id types.UUID, msg *types.Message, from address.Address) (*types.SignedMessage, error) {
+	pushed, err := mp.GetPushedByID(ctx, id)
+	if err != nil || pushed == nil {
+		return nil, err
+	}
+	if err := samePushRequest(&pushed.Message, msg, from); err != nil {
+		return nil, fmt.Errorf("push id %s was used for another message %s: %w", id, pushed.Cid(), err)
+	}
+	return pushed, nil
+}
+
+// RecordPushID remembers that smsg was pushed with the id
+func (mp *MessagePool) RecordPushID(ctx context.Context, id types.UUID, smsg *types.SignedMessage) error {
+	data, err := json.Marshal(&pushIDRecord{Message: smsg, Time: time.Now()})
+	if err != nil {
+		return err
+	}
+	return mp.pushIDs.Put(ctx, datastore.NewKey(id.String()), data)
+}
+
+// prunePushIDs removes the expired push ids
+func (mp *MessagePool) prunePushIDs(ctx context.Context) error {
+	res, err := mp.pushIDs.Query(ctx, query.Query{})
+	if err != nil {
+		return fmt.Errorf("query push ids: %w", err)
+	}
+	defer res.Close() //nolint:errcheck
+
+	for r := range res.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+
+		var record pushIDRecord
+		if err := json.Unmarshal(r.Value, &record); err == nil && time.Since(record.Time) <= PushIDTTL {
+			continue
+		}
+		if err := mp.pushIDs.Delete(ctx, datastore.NewKey(r.Key)); err != nil {
+			return fmt.Errorf("delete push id %s: %w", r.Key, err)
+		}
+	}
+	return nil
+}
+
+// samePushRequest checks that a retried push asks for the message pushed the first time, from is the key
+// address the pushed message was sent from
+func samePushRequest(pushed, msg *types.Message, from address.Address) error {
+	switch {
+	case pushed.From != from:
+		return fmt.Errorf("from %s instead of %s", from, pushed.From)
+	case pushed.To != msg.To:
+		return fmt.Errorf("to %s instead of %s", msg.To, pushed.To)
+	case !pushed.Value.Equals(msg.Value):
+		return fmt.Errorf("value %s instead of %s", msg.Value, pushed.Value)
+	case pushed.Method != msg.Method:
+		return fmt.Errorf("method %d instead of %d", msg.Method, pushed.Method)
+	case !bytes.Equal(pushed.Params, msg.Params):
+		return fmt.Errorf("different params")
+	}
+	return nil
+}
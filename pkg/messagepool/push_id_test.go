@@ -0,0 +1,99 @@
+package messagepool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestPushID(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	mp, _ := makeTestMpool()
+
+	smsg := &types.SignedMessage{Message: types.Message{
+		From:       mkAddress(100),
+		To:         mkAddress(101),
+		Value:      big.NewInt(1),
+		GasFeeCap:  big.NewInt(1),
+		GasPremium: big.NewInt(1),
+	}}
+
+	id := types.NewUUID()
+	pushed, err := mp.GetPushedByID(ctx, id)
+	require.NoError(t, err)
+	assert.Nil(t, pushed)
+
+	require.NoError(t, mp.RecordPushID(ctx, id, smsg))
+	pushed, err = mp.GetPushedByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, smsg.Cid(), pushed.Cid())
+
+	// expired ids are ignored and pruned
+	oldTTL := PushIDTTL
+	PushIDTTL = -time.Second
+	defer func() { PushIDTTL = oldTTL }()
+
+	pushed, err = mp.GetPushedByID(ctx, id)
+	require.NoError(t, err)
+	assert.Nil(t, pushed)
+
+	require.NoError(t, mp.prunePushIDs(ctx))
+	_, err = mp.pushIDs.Get(ctx, datastore.NewKey(id.String()))
+	assert.ErrorIs(t, err, datastore.ErrNotFound)
+}
+
+func TestPushedByID(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	mp, _ := makeTestMpool()
+
+	from := mkAddress(100)
+	smsg := &types.SignedMessage{Message: types.Message{
+		From:       from,
+		To:         mkAddress(101),
+		Value:      big.NewInt(1),
+		Method:     2,
+		Params:     []byte{1, 2},
+		Nonce:      7,
+		GasLimit:   1000,
+		GasFeeCap:  big.NewInt(10),
+		GasPremium: big.NewInt(1),
+	}}
+	id := types.NewUUID()
+	require.NoError(t, mp.RecordPushID(ctx, id, smsg))
+
+	// a retry sent before the estimation, by the id address of the sender, gets the message pushed first
+	retry := types.Message{From: mkAddress(1), To: smsg.Message.To, Value: big.NewInt(1), Method: 2, Params: []byte{1, 2}}
+	pushed, err := mp.PushedByID(ctx, id, &retry, from)
+	require.NoError(t, err)
+	assert.Equal(t, smsg.Cid(), pushed.Cid())
+
+	pushed, err = mp.PushedByID(ctx, types.NewUUID(), &retry, from)
+	require.NoError(t, err)
+	assert.Nil(t, pushed)
+
+	// the id of a message can not push another one
+	for name, change := range map[string]func(msg *types.Message, from *address.Address){
+		"from":   func(_ *types.Message, from *address.Address) { *from = mkAddress(102) },
+		"to":     func(msg *types.Message, _ *address.Address) { msg.To = mkAddress(102) },
+		"value":  func(msg *types.Message, _ *address.Address) { msg.Value = big.NewInt(2) },
+		"method": func(msg *types.Message, _ *address.Address) { msg.Method = 3 },
+		"params": func(msg *types.Message, _ *address.Address) { msg.Params = []byte{1} },
+	} {
+		other, otherFrom := retry, from
+		change(&other, &otherFrom)
+		pushed, err := mp.PushedByID(ctx, id, &other, otherFrom)
+		assert.Error(t, err, name)
+		assert.Nil(t, pushed, name)
+	}
+}
@@ -0,0 +1,88 @@
+package messagepool
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// updateMatcher returns the function matching the updates against the filter. The addresses of the filter are
+// resolved to their key addresses once, so that a message matches whether it uses the id or the key address.
+func (mp *MessagePool) updateMatcher(ctx context.Context, filter *types.MpoolSubFilter) func(context.Context, mpoolUpdate) bool {
+	if filter == nil {
+		return func(context.Context, mpoolUpdate) bool { return true }
+	}
+
+	from := mp.resolveAddressSet(ctx, filter.From)
+	to := mp.resolveAddressSet(ctx, filter.To)
+	methods := make(map[abi.MethodNum]struct{}, len(filter.Methods))
+	for _, m := range filter.Methods {
+		methods[m] = struct{}{}
+	}
+	changes := make(map[types.MpoolChange]struct{}, len(filter.Types))
+	for _, c := range filter.Types {
+		changes[c] = struct{}{}
+	}
+
+	return func(ctx context.Context, u mpoolUpdate) bool {
+		if filter.LocalOnly && !u.local {
+			return false
+		}
+		if len(changes) > 0 {
+			if _, ok := changes[u.update.Type]; !ok {
+				return false
+			}
+		}
+		msg := &u.update.Message.Message
+		if len(methods) > 0 {
+			if _, ok := methods[msg.Method]; !ok {
+				return false
+			}
+		}
+		return mp.addressIn(ctx, msg.From, from) && mp.addressIn(ctx, msg.To, to)
+	}
+}
+
+// addressSet is a set of addresses along with their key addresses, the ones which are not accounts are kept as is
+type addressSet struct {
+	addrs map[address.Address]struct{}
+	// keys is set when some of the addresses are key addresses, only then the id addresses matched are resolved
+	keys bool
+}
+
+func (mp *MessagePool) resolveAddressSet(ctx context.Context, addrs []address.Address) addressSet {
+	set := addressSet{addrs: make(map[address.Address]struct{}, len(addrs)*2)}
+	for _, addr := range addrs {
+		set.addrs[addr] = struct{}{}
+		ka, err := mp.resolveToKey(ctx, addr)
+		if err != nil {
+			log.Debugf("resolve %s to its key address: %v", addr, err)
+			continue
+		}
+		set.addrs[ka] = struct{}{}
+		set.keys = true
+	}
+	return set
+}
+
+// addressIn reports whether the address is in the set, any address is when the set is empty
+func (mp *MessagePool) addressIn(ctx context.Context, addr address.Address, set addressSet) bool {
+	if len(set.addrs) == 0 {
+		return true
+	}
+	if _, ok := set.addrs[addr]; ok {
+		return true
+	}
+	if !set.keys || addr.Protocol() != address.ID {
+		return false
+	}
+	ka, err := mp.resolveToKey(ctx, addr)
+	if err != nil {
+		return false
+	}
+	_, ok := set.addrs[ka]
+	return ok
+}
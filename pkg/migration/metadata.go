@@ -0,0 +1,125 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ipfs/go-datastore"
+
+	"github.com/filecoin-project/venus/pkg/repo"
+)
+
+// MetaVersionKey is the key under which the schema version of the metadata datastore is stored
+var MetaVersionKey = datastore.NewKey("/meta/version")
+
+// MetaMigration changes how data is persisted in the metadata datastore (mpool, local messages, tokens, etc.).
+// Down must undo Up, so that the datastore can be rolled back before running an older venus. The version is
+// recorded after each migration, a migration interrupted halfway is run again, so both funcs must be idempotent.
+type MetaMigration struct {
+	Version uint
+	Name    string
+	Up      func(ctx context.Context, ds repo.Datastore) error
+	Down    func(ctx context.Context, ds repo.Datastore) error
+}
+
+func noopMetaMigration(context.Context, repo.Datastore) error { return nil }
+
+var metaMigrations = []MetaMigration{
+	{Version: 1, Name: "record the schema version of the metadata datastore", Up: noopMetaMigration, Down: noopMetaMigration},
+}
+
+// MetaMigrations returns the known migrations of the metadata datastore, ordered by version
+func MetaMigrations() []MetaMigration {
+	return append([]MetaMigration(nil), metaMigrations...)
+}
+
+// LatestMetaVersion returns the schema version of the metadata datastore used by this venus
+func LatestMetaVersion() uint {
+	return latestMetaVersion(metaMigrations)
+}
+
+func latestMetaVersion(migrations []MetaMigration) uint {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// ReadMetaVersion returns the schema version of the metadata datastore, 0 if it has never been migrated
+func ReadMetaVersion(ctx context.Context, ds repo.Datastore) (uint, error) {
+	data, err := ds.Get(ctx, MetaVersionKey)
+	if err != nil {
+		if errors.Is(err, datastore.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("read metadata version: %w", err)
+	}
+
+	version, err := strconv.ParseUint(string(data), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid metadata version %q: %w", data, err)
+	}
+	return uint(version), nil
+}
+
+func writeMetaVersion(ctx context.Context, ds repo.Datastore, version uint) error {
+	if err := ds.Put(ctx, MetaVersionKey, []byte(strconv.FormatUint(uint64(version), 10))); err != nil {
+		return fmt.Errorf("write metadata version: %w", err)
+	}
+	return ds.Sync(ctx, MetaVersionKey)
+}
+
+// MigrateMetaDatastore migrates the metadata datastore forward or backward to the target version
+func MigrateMetaDatastore(ctx context.Context, ds repo.Datastore, target uint) error {
+	return migrateMeta(ctx, ds, metaMigrations, target)
+}
+
+func migrateMeta(ctx context.Context, ds repo.Datastore, migrations []MetaMigration, target uint) error {
+	for i, m := range migrations {
+		if m.Version != uint(i+1) {
+			return fmt.Errorf("metadata migrations must be numbered from 1 without gaps, got version %d at %d", m.Version, i)
+		}
+	}
+
+	latest := latestMetaVersion(migrations)
+	if target > latest {
+		return fmt.Errorf("unknown metadata version %d, the latest version is %d", target, latest)
+	}
+
+	current, err := ReadMetaVersion(ctx, ds)
+	if err != nil {
+		return err
+	}
+	if current > latest {
+		return fmt.Errorf("metadata datastore version %d is newer than the latest known version %d, "+
+			"roll it back with `venus db migrate --to %d` of the venus which migrated it", current, latest, latest)
+	}
+
+	for current < target {
+		m := migrations[current]
+		if err := m.Up(ctx, ds); err != nil {
+			return fmt.Errorf("migrate metadata to version %d (%s): %w", m.Version, m.Name, err)
+		}
+		if err := writeMetaVersion(ctx, ds, m.Version); err != nil {
+			return err
+		}
+		migrateLog.Infof("success to migrate metadata from version %d to version %d", current, m.Version)
+		current = m.Version
+	}
+
+	for current > target {
+		m := migrations[current-1]
+		if err := m.Down(ctx, ds); err != nil {
+			return fmt.Errorf("roll back metadata version %d (%s): %w", m.Version, m.Name, err)
+		}
+		if err := writeMetaVersion(ctx, ds, m.Version-1); err != nil {
+			return err
+		}
+		migrateLog.Infof("success to roll back metadata from version %d to version %d", current, m.Version-1)
+		current = m.Version - 1
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/repo"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestMigrateMetaDatastore(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	oldKey, newKey := datastore.NewKey("/old"), datastore.NewKey("/new")
+	move := func(from, to datastore.Key) func(context.Context, repo.Datastore) error {
+		return func(ctx context.Context, ds repo.Datastore) error {
+			val, err := ds.Get(ctx, from)
+			if errors.Is(err, datastore.ErrNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := ds.Put(ctx, to, val); err != nil {
+				return err
+			}
+			return ds.Delete(ctx, from)
+		}
+	}
+	migrations := []MetaMigration{
+		{Version: 1, Up: noopMetaMigration, Down: noopMetaMigration},
+		{Version: 2, Up: move(oldKey, newKey), Down: move(newKey, oldKey)},
+	}
+
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+	require.NoError(t, ds.Put(ctx, oldKey, []byte("val")))
+
+	version, err := ReadMetaVersion(ctx, ds)
+	require.NoError(t, err)
+	assert.Equal(t, uint(0), version)
+
+	require.NoError(t, migrateMeta(ctx, ds, migrations, 2))
+	version, err = ReadMetaVersion(ctx, ds)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), version)
+	val, err := ds.Get(ctx, newKey)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("val"), val)
+
+	// roll back
+	require.NoError(t, migrateMeta(ctx, ds, migrations, 1))
+	version, err = ReadMetaVersion(ctx, ds)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), version)
+	has, err := ds.Has(ctx, oldKey)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	// an older venus refuses the datastore of a newer one
+	require.NoError(t, migrateMeta(ctx, ds, migrations, 2))
+	assert.Error(t, migrateMeta(ctx, ds, migrations[:1], 1))
+	assert.Error(t, migrateMeta(ctx, ds, migrations, 3))
+
+	// a failed migration keeps the last version
+	failing := append(migrations, MetaMigration{
+		Version: 3,
+		Up:      func(context.Context, repo.Datastore) error { return errors.New("failed") },
+		Down:    noopMetaMigration,
+	})
+	assert.Error(t, migrateMeta(ctx, ds, failing, 3))
+	version, err = ReadMetaVersion(ctx, ds)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), version)
+
+	assert.Error(t, migrateMeta(ctx, ds, []MetaMigration{{Version: 2}}, 0))
+}
@@ -54,8 +54,8 @@ func NewClient(host host.Host, pmgr peermgr.IPeerMgr) Client {
 
 // Main logic of the client request service. The provided `Request`
 // is sent to the `singlePeer` if one is indicated or to all available
-// ones otherwise. The response is processed and validated according
-// to the `Request` options. Either a `validatedResponse` is returned
+// ones otherwise, `width` of them at a time. The response is processed
+// and validated according to the `Request` options. Either a `validatedResponse` is returned
 // (which can be safely accessed), or an `error` that may represent
 // either a response error status, a failed validation or an internal
 // error.
@@ -73,6 +73,8 @@ func (c *client) doRequest(
 	ctx context.Context,
 	req *exchange.Request,
 	singlePeer []peer.ID,
+	// The number of peers the request is sent to at once.
+	width int,
 	// In the `GetChainMessages` case, we won't request the headers but we still
 	// need them to check the integrity of the `CompactedMessages` in the response
 	// so the tipset blocks need to be provided by the caller.
@@ -105,40 +107,71 @@ func (c *client) doRequest(
 		return nil, fmt.Errorf("no peers available")
 	}
 
-	// Try the request for each peer in the list,
-	// return on the first successful response.
-	// FIXME: Doing this serially isn't great, but fetching in parallel
-	//  may not be a good idea either. Think about this more.
-	globalTime := time.Now()
+	// Try the request on `width` peers at once, moving to the next peer in
+	// the list when one fails, return on the first successful response.
+	// The requests still in flight are not cancelled, so that the peer
+	// tracker keeps measuring the peers which were just slower.
+	if width < 1 {
+		width = 1
+	}
 	// Global time used to track what is the expected time we will need to get
 	// a response if a client fails us.
-	for _, peer := range selectPeers {
+	globalTime := time.Now()
+
+	type peerResult struct {
+		peer     peer.ID
+		res      *validatedResponse
+		sendErr  error
+		validErr error
+	}
+	results := make(chan peerResult, len(selectPeers))
+	next, inflight := 0, 0
+	sendNext := func() {
+		p := selectPeers[next]
+		next++
+		inflight++
+		go func() {
+			// Send request, read response.
+			res, err := c.sendRequestToPeer(ctx, p, req)
+			if err != nil {
+				results <- peerResult{peer: p, sendErr: err}
+				return
+			}
+
+			// Process and validate response.
+			validRes, err := c.processResponse(req, res, tipsets)
+			results <- peerResult{peer: p, res: validRes, validErr: err}
+		}()
+	}
+	for inflight < width && next < len(selectPeers) {
+		sendNext()
+	}
+
+	for inflight > 0 {
+		var r peerResult
 		select {
 		case <-ctx.Done():
 			return nil, fmt.Errorf("context cancelled: %w", ctx.Err())
-		default:
+		case r = <-results:
 		}
+		inflight--
 
-		// Send request, read response.
-		res, err := c.sendRequestToPeer(ctx, peer, req)
-		if err != nil {
-			if !errors.Is(err, network.ErrNoConn) {
-				exchangeClientLogger.Warnf("could not send request to peer %s: %s",
-					peer.String(), err)
+		switch {
+		case r.sendErr != nil:
+			if !errors.Is(r.sendErr, network.ErrNoConn) {
+				exchangeClientLogger.Warnf("could not send request to peer %s: %s", r.peer.String(), r.sendErr)
 			}
-			continue
+		case r.validErr != nil:
+			exchangeClientLogger.Warnf("processing peer %s response failed: %s", r.peer.String(), r.validErr)
+		default:
+			c.peerTracker.logGlobalSuccess(time.Since(globalTime))
+			c.host.ConnManager().TagPeer(r.peer, "bsync", SuccessPeerTagValue)
+			return r.res, nil
 		}
 
-		// Process and validate response.
-		validRes, err := c.processResponse(req, res, tipsets)
-		if err != nil {
-			exchangeClientLogger.Warnf("processing peer %s response failed: %s", peer.String(), err)
-			continue
+		if next < len(selectPeers) && ctx.Err() == nil {
+			sendNext()
 		}
-
-		c.peerTracker.logGlobalSuccess(time.Since(globalTime))
-		c.host.ConnManager().TagPeer(peer, "bsync", SuccessPeerTagValue)
-		return validRes, nil
 	}
 
 	return nil, fmt.Errorf("doRequest failed for all peers")
@@ -311,7 +344,7 @@ func (c *client) GetBlocks(ctx context.Context, tsk types.TipSetKey, count int)
 		Options: exchange.Headers,
 	}
 
-	validRes, err := c.doRequest(ctx, req, nil, nil)
+	validRes, err := c.doRequest(ctx, req, nil, HeaderRequestPeers, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -329,7 +362,7 @@ func (c *client) GetFullTipSet(ctx context.Context, peers []peer.ID, tsk types.T
 		Options: exchange.Headers | exchange.Messages,
 	}
 
-	validRes, err := c.doRequest(ctx, req, peers, nil)
+	validRes, err := c.doRequest(ctx, req, peers, 1, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -359,7 +392,7 @@ func (c *client) GetChainMessages(ctx context.Context, tipsets []*types.TipSet)
 		Options: exchange.Messages,
 	}
 
-	validRes, err := c.doRequest(ctx, req, nil, tipsets)
+	validRes, err := c.doRequest(ctx, req, nil, 1, tipsets)
 	if err != nil {
 		return nil, err
 	}
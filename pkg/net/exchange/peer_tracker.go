@@ -3,16 +3,23 @@ package exchange
 // FIXME: This needs to be reviewed.
 
 import (
+	"context"
 	"sort"
 	"sync"
 	"time"
 
+	"github.com/ipfs-force-community/metrics"
 	host "github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/filecoin-project/venus/pkg/net/peermgr"
 )
 
+var (
+	exchangePeers     = metrics.NewInt64("sync/exchange_peers", "The number of peers the chain data can be requested from", "")
+	exchangePeersUsed = metrics.NewInt64("sync/exchange_peers_used", "The number of peers which served at least one chain exchange request", "")
+)
+
 type peerStats struct {
 	successes   int
 	failures    int
@@ -63,6 +70,20 @@ func (bpt *bsPeerTracker) addPeer(p peer.ID) {
 	bpt.peers[p] = &peerStats{
 		firstSeen: time.Now(),
 	}
+	bpt.recordPeers()
+}
+
+// recordPeers updates the peer gauges, bpt.lk must be held
+func (bpt *bsPeerTracker) recordPeers() {
+	used := 0
+	for _, pi := range bpt.peers {
+		if pi.successes > 0 {
+			used++
+		}
+	}
+	ctx := context.Background()
+	exchangePeers.Set(ctx, int64(len(bpt.peers)))
+	exchangePeersUsed.Set(ctx, int64(used))
 }
 
 const (
@@ -152,6 +173,9 @@ func (bpt *bsPeerTracker) logSuccess(p peer.ID, dur time.Duration, reqSize uint6
 	}
 
 	pi.successes++
+	if pi.successes == 1 {
+		bpt.recordPeers()
+	}
 	if reqSize == 0 {
 		reqSize = 1
 	}
@@ -180,4 +204,5 @@ func (bpt *bsPeerTracker) removePeer(p peer.ID) {
 	bpt.lk.Lock()
 	defer bpt.lk.Unlock()
 	delete(bpt.peers, p)
+	bpt.recordPeers()
 }
@@ -19,6 +19,10 @@ const (
 	ReadResMinSpeed     = 50 << 10
 	ShufflePeersPrefix  = 16
 	WriteResDeadline    = 60 * time.Second
+
+	// HeaderRequestPeers is the number of peers a headers request is sent to at once, the first
+	// valid response is used, so that a slow or failing peer does not stall the header sync.
+	HeaderRequestPeers = 3
 )
 
 // `Request` processed and validated to query the tipsets needed.
@@ -2,6 +2,7 @@ package net
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"time"
 
@@ -47,7 +48,8 @@ func NewGossipSub(ctx context.Context,
 	networkName string,
 	drandSchedule map[abi.ChainEpoch]config.DrandEnum,
 	bootNodes []peer.AddrInfo,
-	bs bool,
+	cfg *config.PubsubConfig,
+	tt *TopicTracker,
 ) (*pubsub.PubSub, error) {
 	bootstrappers := make(map[peer.ID]struct{})
 	for _, info := range bootNodes {
@@ -207,10 +209,21 @@ func NewGossipSub(ctx context.Context,
 	// Index ingestion whitelist
 	topicParams[indexerIngestTopic] = ingestTopicParams
 
-	isBootstrapNode := bs
+	isBootstrapNode := cfg.Bootstrapper
 
 	// IP colocation whitelist
 	var ipcoloWhitelist []*net.IPNet
+	for _, cidr := range cfg.IPColocationWhitelist {
+		_, subnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ip colocation whitelist entry %s: %w", cidr, err)
+		}
+		ipcoloWhitelist = append(ipcoloWhitelist, subnet)
+	}
+	ipcoloThreshold := cfg.IPColocationThreshold
+	if ipcoloThreshold < 1 {
+		ipcoloThreshold = 1
+	}
 
 	options := []pubsub.Option{
 		// Gossipsubv1.1 configuration
@@ -240,9 +253,9 @@ func NewGossipSub(ctx context.Context,
 				},
 				AppSpecificWeight: 1,
 
-				// This sets the IP colocation threshold to 5 peers before we apply penalties
-				IPColocationFactorThreshold: 5,
-				IPColocationFactorWeight:    -100,
+				// This sets the IP colocation threshold to 5 peers before we apply penalties by default
+				IPColocationFactorThreshold: ipcoloThreshold,
+				IPColocationFactorWeight:    cfg.IPColocationWeight,
 				IPColocationFactorWhitelist: ipcoloWhitelist,
 
 				// P7: behavioural penalties, decay after 1hr
@@ -257,7 +270,8 @@ func NewGossipSub(ctx context.Context,
 				RetainScore: 6 * time.Hour,
 
 				// topic parameters
-				Topics: topicParams,
+				Topics:        topicParams,
+				TopicScoreCap: cfg.TopicScoreCap,
 			},
 			&pubsub.PeerScoreThresholds{
 				GossipThreshold:             GossipScoreThreshold,
@@ -268,6 +282,7 @@ func NewGossipSub(ctx context.Context,
 			},
 		),
 		pubsub.WithPeerScoreInspect(sk.Update, 10*time.Second),
+		pubsub.WithRawTracer(tt),
 	}
 
 	// enable Peer eXchange on bootstrappers
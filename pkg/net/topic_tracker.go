@@ -0,0 +1,137 @@
+package net
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/ipfs-force-community/metrics"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+var (
+	topicMeshPeers      = metrics.NewInt64WithCategory("net/pubsub_mesh_peers", "The number of peers in the mesh of a pubsub topic", "")
+	topicDeliveredMsgs  = metrics.NewInt64WithCategory("net/pubsub_delivered", "The number of messages delivered on a pubsub topic", "")
+	topicRejectedMsgs   = metrics.NewInt64WithCategory("net/pubsub_rejected", "The number of messages rejected or ignored on a pubsub topic", "")
+	topicDuplicatedMsgs = metrics.NewInt64WithCategory("net/pubsub_duplicate", "The number of duplicate messages dropped on a pubsub topic", "")
+)
+
+// TopicStats is the mesh and the message counts of a pubsub topic
+type TopicStats struct {
+	Mesh      []peer.ID
+	Delivered uint64
+	Rejected  uint64
+	Duplicate uint64
+}
+
+// TopicTracker follows the mesh of the pubsub topics and counts their messages, it is plugged in pubsub as a
+// raw tracer
+type TopicTracker struct {
+	lk     sync.Mutex
+	topics map[string]*topicState
+}
+
+type topicState struct {
+	mesh map[peer.ID]struct{}
+	TopicStats
+}
+
+var _ pubsub.RawTracer = (*TopicTracker)(nil)
+
+func NewTopicTracker() *TopicTracker {
+	return &TopicTracker{topics: make(map[string]*topicState)}
+}
+
+// Get returns the stats of the topics seen
+func (tt *TopicTracker) Get() map[string]TopicStats {
+	tt.lk.Lock()
+	defer tt.lk.Unlock()
+
+	out := make(map[string]TopicStats, len(tt.topics))
+	for topic, st := range tt.topics {
+		stats := st.TopicStats
+		stats.Mesh = make([]peer.ID, 0, len(st.mesh))
+		for p := range st.mesh {
+			stats.Mesh = append(stats.Mesh, p)
+		}
+		sort.Slice(stats.Mesh, func(i, j int) bool { return stats.Mesh[i] < stats.Mesh[j] })
+		out[topic] = stats
+	}
+	return out
+}
+
+func (tt *TopicTracker) topic(topic string) *topicState {
+	st, ok := tt.topics[topic]
+	if !ok {
+		st = &topicState{mesh: make(map[peer.ID]struct{})}
+		tt.topics[topic] = st
+	}
+	return st
+}
+
+func (tt *TopicTracker) Graft(p peer.ID, topic string) {
+	tt.lk.Lock()
+	defer tt.lk.Unlock()
+	st := tt.topic(topic)
+	st.mesh[p] = struct{}{}
+	topicMeshPeers.Set(context.Background(), topic, int64(len(st.mesh)))
+}
+
+func (tt *TopicTracker) Prune(p peer.ID, topic string) {
+	tt.lk.Lock()
+	defer tt.lk.Unlock()
+	st := tt.topic(topic)
+	delete(st.mesh, p)
+	topicMeshPeers.Set(context.Background(), topic, int64(len(st.mesh)))
+}
+
+func (tt *TopicTracker) RemovePeer(p peer.ID) {
+	tt.lk.Lock()
+	defer tt.lk.Unlock()
+	for topic, st := range tt.topics {
+		if _, ok := st.mesh[p]; ok {
+			delete(st.mesh, p)
+			topicMeshPeers.Set(context.Background(), topic, int64(len(st.mesh)))
+		}
+	}
+}
+
+func (tt *TopicTracker) Leave(topic string) {
+	tt.lk.Lock()
+	defer tt.lk.Unlock()
+	st := tt.topic(topic)
+	st.mesh = make(map[peer.ID]struct{})
+	topicMeshPeers.Set(context.Background(), topic, 0)
+}
+
+func (tt *TopicTracker) DeliverMessage(msg *pubsub.Message) {
+	tt.lk.Lock()
+	defer tt.lk.Unlock()
+	tt.topic(msg.GetTopic()).Delivered++
+	topicDeliveredMsgs.Inc(context.Background(), msg.GetTopic(), 1)
+}
+
+func (tt *TopicTracker) RejectMessage(msg *pubsub.Message, reason string) {
+	tt.lk.Lock()
+	defer tt.lk.Unlock()
+	tt.topic(msg.GetTopic()).Rejected++
+	topicRejectedMsgs.Inc(context.Background(), msg.GetTopic(), 1)
+}
+
+func (tt *TopicTracker) DuplicateMessage(msg *pubsub.Message) {
+	tt.lk.Lock()
+	defer tt.lk.Unlock()
+	tt.topic(msg.GetTopic()).Duplicate++
+	topicDuplicatedMsgs.Inc(context.Background(), msg.GetTopic(), 1)
+}
+
+func (tt *TopicTracker) AddPeer(p peer.ID, proto protocol.ID)     {}
+func (tt *TopicTracker) Join(topic string)                        {}
+func (tt *TopicTracker) ValidateMessage(msg *pubsub.Message)      {}
+func (tt *TopicTracker) ThrottlePeer(p peer.ID)                   {}
+func (tt *TopicTracker) RecvRPC(rpc *pubsub.RPC)                  {}
+func (tt *TopicTracker) SendRPC(rpc *pubsub.RPC, p peer.ID)       {}
+func (tt *TopicTracker) DropRPC(rpc *pubsub.RPC, p peer.ID)       {}
+func (tt *TopicTracker) UndeliverableMessage(msg *pubsub.Message) {}
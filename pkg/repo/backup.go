@@ -0,0 +1,258 @@
+package repo
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/filecoin-project/venus/pkg/config"
+)
+
+const backupVersion = 1
+
+// backupMetaPrefixes are the keys of the metadata datastore carried by a backup: the local messages and the config of
+// the message pool, the nonces assigned by the message signer, the api tokens and the sign rules of the wallet
+var backupMetaPrefixes = []string{"/mpool", "/message-signer", "/auth/tokens", "/wallet/sign-rules"}
+
+// ErrBackupPassword is returned when a backup is restored with another password than the one it was made with
+var ErrBackupPassword = errors.New("could not decrypt the backup with the given password")
+
+type backupEntry struct {
+	Key   string
+	Value []byte
+}
+
+// backupContent is the state of a node which can't be rebuilt from the network, the chain data is left out
+type backupContent struct {
+	Version  int
+	Config   *config.Config
+	APIToken string
+	Keystore map[string][]byte
+	Wallet   []backupEntry
+	Meta     []backupEntry
+}
+
+// backupArchive is the content of a backup encrypted with a key derived from a password
+type backupArchive struct {
+	Version    int
+	ScryptN    int
+	ScryptR    int
+	ScryptP    int
+	Salt       []byte
+	Nonce      []byte
+	CipherText []byte
+}
+
+// Backup writes the keystore, the wallet, the api tokens, the local messages and the config of the repo to w,
+// encrypted with password. The daemon using the repo should not be running.
+func Backup(ctx context.Context, r Repo, w io.Writer, password []byte) error {
+	content := backupContent{
+		Version:  backupVersion,
+		Config:   r.Config(),
+		Keystore: map[string][]byte{},
+	}
+
+	var err error
+	if content.APIToken, err = r.APIToken(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read api token: %w", err)
+	}
+
+	ks := r.Keystore()
+	names, err := ks.List()
+	if err != nil {
+		return fmt.Errorf("list keystore: %w", err)
+	}
+	for _, name := range names {
+		if content.Keystore[name], err = ks.Get(name); err != nil {
+			return fmt.Errorf("read key %s: %w", name, err)
+		}
+	}
+
+	if content.Wallet, err = readBackupEntries(ctx, r.WalletDatastore(), ""); err != nil {
+		return fmt.Errorf("read wallet datastore: %w", err)
+	}
+	for _, prefix := range backupMetaPrefixes {
+		entries, err := readBackupEntries(ctx, r.MetaDatastore(), prefix)
+		if err != nil {
+			return fmt.Errorf("read %s of metadata datastore: %w", prefix, err)
+		}
+		content.Meta = append(content.Meta, entries...)
+	}
+
+	data, err := json.Marshal(&content)
+	if err != nil {
+		return err
+	}
+	archive, err := sealBackup(data, password, r.Config().Wallet.PassphraseConfig)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(archive)
+}
+
+// Restore writes the content of a backup to the repo, replacing the keys and the config it holds unless keepConfig is
+// set. The repo needs to be initialized and the daemon using it should not be running.
+func Restore(ctx context.Context, r Repo, rd io.Reader, password []byte, keepConfig bool) error {
+	var archive backupArchive
+	if err := json.NewDecoder(rd).Decode(&archive); err != nil {
+		return fmt.Errorf("decode backup: %w", err)
+	}
+	if archive.Version != backupVersion {
+		return fmt.Errorf("unsupported backup version %d", archive.Version)
+	}
+	data, err := openBackup(&archive, password)
+	if err != nil {
+		return err
+	}
+
+	var content backupContent
+	if err := json.Unmarshal(data, &content); err != nil {
+		return fmt.Errorf("decode backup content: %w", err)
+	}
+
+	ks := r.Keystore()
+	for name, key := range content.Keystore {
+		if has, err := ks.Has(name); err != nil {
+			return fmt.Errorf("read key %s: %w", name, err)
+		} else if has {
+			if err := ks.Delete(name); err != nil {
+				return fmt.Errorf("replace key %s: %w", name, err)
+			}
+		}
+		if err := ks.Put(name, key); err != nil {
+			return fmt.Errorf("write key %s: %w", name, err)
+		}
+	}
+
+	if err := writeBackupEntries(ctx, r.WalletDatastore(), content.Wallet); err != nil {
+		return fmt.Errorf("write wallet datastore: %w", err)
+	}
+	if err := writeBackupEntries(ctx, r.MetaDatastore(), content.Meta); err != nil {
+		return fmt.Errorf("write metadata datastore: %w", err)
+	}
+
+	if len(content.APIToken) > 0 {
+		if err := r.SetAPIToken([]byte(content.APIToken)); err != nil {
+			return fmt.Errorf("write api token: %w", err)
+		}
+	}
+	if !keepConfig && content.Config != nil {
+		if err := r.ReplaceConfig(content.Config); err != nil {
+			return fmt.Errorf("replace config: %w", err)
+		}
+	}
+	return nil
+}
+
+func readBackupEntries(ctx context.Context, ds Datastore, prefix string) ([]backupEntry, error) {
+	res, err := ds.Query(ctx, query.Query{Prefix: prefix})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close() // nolint:errcheck
+
+	var entries []backupEntry
+	exact := false
+	for r := range res.Next() {
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		// the prefix matches whole path segments only
+		if len(prefix) > 0 && r.Key != prefix && !strings.HasPrefix(r.Key, prefix+"/") {
+			continue
+		}
+		exact = exact || r.Key == prefix
+		entries = append(entries, backupEntry{Key: r.Key, Value: r.Value})
+	}
+	if len(prefix) == 0 || exact {
+		return entries, nil
+	}
+
+	// the queries only return the keys below the prefix, a value may be stored at the prefix itself
+	value, err := ds.Get(ctx, datastore.NewKey(prefix))
+	switch {
+	case err == nil:
+		entries = append(entries, backupEntry{Key: prefix, Value: value})
+	case !errors.Is(err, datastore.ErrNotFound):
+		return nil, err
+	}
+	return entries, nil
+}
+
+func writeBackupEntries(ctx context.Context, ds Datastore, entries []backupEntry) error {
+	batch, err := ds.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := batch.Put(ctx, datastore.NewKey(e.Key), e.Value); err != nil {
+			return err
+		}
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return err
+	}
+	return ds.Sync(ctx, datastore.NewKey("/"))
+}
+
+func sealBackup(data, password []byte, pc config.PassphraseConfig) (*backupArchive, error) {
+	archive := &backupArchive{
+		Version: backupVersion,
+		ScryptN: pc.ScryptN,
+		ScryptR: 8,
+		ScryptP: pc.ScryptP,
+		Salt:    make([]byte, 32),
+	}
+	if _, err := io.ReadFull(rand.Reader, archive.Salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := backupCipher(archive, password)
+	if err != nil {
+		return nil, err
+	}
+	archive.Nonce = make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, archive.Nonce); err != nil {
+		return nil, err
+	}
+	archive.CipherText = aead.Seal(nil, archive.Nonce, data, nil)
+	return archive, nil
+}
+
+func openBackup(archive *backupArchive, password []byte) ([]byte, error) {
+	aead, err := backupCipher(archive, password)
+	if err != nil {
+		return nil, err
+	}
+	if len(archive.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid backup nonce size %d", len(archive.Nonce))
+	}
+	data, err := aead.Open(nil, archive.Nonce, archive.CipherText, nil)
+	if err != nil {
+		return nil, ErrBackupPassword
+	}
+	return data, nil
+}
+
+func backupCipher(archive *backupArchive, password []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(password, archive.Salt, archive.ScryptN, archive.ScryptR, archive.ScryptP, 32)
+	if err != nil {
+		return nil, fmt.Errorf("derive backup key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
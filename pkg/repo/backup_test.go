@@ -0,0 +1,68 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestBackupRestore(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	src := NewInMemoryRepo()
+	require.NoError(t, src.SetAPIToken([]byte("token")))
+	require.NoError(t, src.Keystore().Put("libp2p-host", []byte("host key")))
+	require.NoError(t, src.WalletDatastore().Put(ctx, ds.NewKey("/key/addr"), []byte("wallet key")))
+	require.NoError(t, src.MetaDatastore().Put(ctx, ds.NewKey("/mpool/local/msg"), []byte("msg")))
+	require.NoError(t, src.MetaDatastore().Put(ctx, ds.NewKey("/auth/tokens/admin"), []byte("admin token")))
+	require.NoError(t, src.MetaDatastore().Put(ctx, ds.NewKey("/wallet/sign-rules"), []byte("sign rules")))
+	require.NoError(t, src.MetaDatastore().Put(ctx, ds.NewKey("/mpoolx"), []byte("not local")))
+	require.NoError(t, src.ChainDatastore().Put(ctx, ds.NewKey("/chain/heaviestTipSet"), []byte("head")))
+
+	var buf bytes.Buffer
+	require.NoError(t, Backup(ctx, src, &buf, []byte("password")))
+	assert.NotContains(t, buf.String(), "wallet key")
+
+	dst := NewInMemoryRepo()
+	require.NoError(t, dst.Keystore().Put("libp2p-host", []byte("another host key")))
+
+	err := Restore(ctx, dst, bytes.NewReader(buf.Bytes()), []byte("wrong"), false)
+	assert.ErrorIs(t, err, ErrBackupPassword)
+
+	require.NoError(t, Restore(ctx, dst, bytes.NewReader(buf.Bytes()), []byte("password"), false))
+
+	token, err := dst.APIToken()
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+
+	key, err := dst.Keystore().Get("libp2p-host")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("host key"), key)
+
+	for k, v := range map[string]string{
+		"/mpool/local/msg":   "msg",
+		"/auth/tokens/admin": "admin token",
+		"/wallet/sign-rules": "sign rules",
+	} {
+		data, err := dst.MetaDatastore().Get(ctx, ds.NewKey(k))
+		require.NoError(t, err)
+		assert.Equal(t, v, string(data))
+	}
+	data, err := dst.WalletDatastore().Get(ctx, ds.NewKey("/key/addr"))
+	require.NoError(t, err)
+	assert.Equal(t, "wallet key", string(data))
+
+	has, err := dst.MetaDatastore().Has(ctx, ds.NewKey("/mpoolx"))
+	require.NoError(t, err)
+	assert.False(t, has)
+	has, err = dst.ChainDatastore().Has(ctx, ds.NewKey("/chain/heaviestTipSet"))
+	require.NoError(t, err)
+	assert.False(t, has)
+}
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -14,6 +15,8 @@ import (
 
 	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
 	bstore "github.com/ipfs/boxo/blockstore"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
 
 	badgerds "github.com/ipfs/go-ds-badger2"
 	lockfile "github.com/ipfs/go-fs-lock"
@@ -43,6 +46,9 @@ const (
 	fsSqlite               = "sqlite"
 )
 
+// offloadIndexPrefix is the namespace of the chain datastore the offloaded blocks are indexed in
+var offloadIndexPrefix = datastore.NewKey("/offload-index")
+
 // FSRepo is a repo implementation backed by a filesystem.
 type FSRepo struct {
 	// Path to the repo root directory.
@@ -51,6 +57,8 @@ type FSRepo struct {
 
 	// lk protects the config file
 	lk sync.RWMutex
+	// envOverrides are the config fields set from the environment, they are not written to the config file
+	envOverrides []config.EnvOverride
 
 	ds       *blockstoreutil.BadgerBlockstore
 	keystore fskeystore.Keystore
@@ -59,6 +67,8 @@ type FSRepo struct {
 	metaDs   Datastore
 	// marketDs Datastore
 	paychDs Datastore
+	// offload reads the offloaded chain blocks through from the object storage, when the chain offload is enabled
+	offload *blockstoreutil.OffloadBlockstore
 	// lockfile is the file system lock to prevent others from opening the same repo.
 	lockfile io.Closer
 
@@ -230,6 +240,10 @@ func (r *FSRepo) loadFromDisk() error {
 		return errors.Wrap(err, "failed to open metadata datastore")
 	}
 
+	if err := r.openOffload(); err != nil {
+		return errors.Wrap(err, "failed to open chain offload storage")
+	}
+
 	if err := r.openPaychDataStore(); err != nil {
 		return errors.Wrap(err, "failed to open paych datastore")
 	}
@@ -251,20 +265,27 @@ func (r *FSRepo) ReplaceConfig(cfg *config.Config) error {
 	defer r.lk.Unlock()
 
 	Config = cfg
+	fileCfg, err := cfg.WithoutEnv(r.envOverrides)
+	if err != nil {
+		return err
+	}
 	tmp := filepath.Join(r.path, tempConfigFilename)
-	err := os.RemoveAll(tmp)
+	err = os.RemoveAll(tmp)
 	if err != nil {
 		return err
 	}
-	err = Config.WriteFile(tmp)
+	err = fileCfg.WriteFile(tmp)
 	if err != nil {
 		return err
 	}
 	return os.Rename(tmp, filepath.Join(r.path, configFilename))
 }
 
-// Datastore returns the datastore.
+// Datastore returns the datastore, an *blockstoreutil.OffloadBlockstore when the chain offload is enabled.
 func (r *FSRepo) Datastore() blockstoreutil.Blockstore {
+	if r.offload != nil {
+		return r.offload
+	}
 	return r.ds
 }
 
@@ -366,9 +387,19 @@ func LoadConfig(p string) (*config.Config, error) {
 	return cfg, nil
 }
 
-func (r *FSRepo) loadConfig() (err error) {
-	Config, err = LoadConfig(r.path)
-	return
+// loadConfig reads the config file and applies the environment variables of the config fields over it
+func (r *FSRepo) loadConfig() error {
+	cfg, err := LoadConfig(r.path)
+	if err != nil {
+		return err
+	}
+	overrides, err := cfg.ApplyEnv(os.LookupEnv)
+	if err != nil {
+		return errors.Wrap(err, "failed to apply config environment variables")
+	}
+
+	Config, r.envOverrides = cfg, overrides
+	return nil
 }
 
 // readVersion reads the repo's version file (but does not change r.version).
@@ -385,6 +416,9 @@ func (r *FSRepo) openDatastore() error {
 			return err
 		}
 		opts.Prefix = bstore.BlockPrefix.String()
+		if err := applyDatastoreTuning(&opts, Config.Datastore); err != nil {
+			return err
+		}
 		ds, err := blockstoreutil.Open(opts)
 		if err != nil {
 			return err
@@ -397,6 +431,36 @@ func (r *FSRepo) openDatastore() error {
 	return nil
 }
 
+// openOffload opens the chain offload storage over the datastore, the offloaded blocks are indexed in the chain
+// datastore
+func (r *FSRepo) openOffload() error {
+	if cfg := Config.ChainOffload; cfg != nil && cfg.Enable {
+		if cfg.S3 == nil {
+			return fmt.Errorf("chain offload is enabled without a s3 bucket")
+		}
+		cold, err := blockstoreutil.NewS3Store(blockstoreutil.S3Options{
+			Endpoint:        cfg.S3.Endpoint,
+			Region:          cfg.S3.Region,
+			Bucket:          cfg.S3.Bucket,
+			Prefix:          cfg.S3.Prefix,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+			StorageClass:    cfg.S3.StorageClass,
+			PathStyle:       cfg.S3.PathStyle,
+		})
+		if err != nil {
+			return fmt.Errorf("open the chain offload storage: %w", err)
+		}
+		index := namespace.Wrap(r.chainDs, offloadIndexPrefix)
+		r.offload, err = blockstoreutil.NewOffloadBlockstore(context.TODO(), r.ds, cold, index, cfg.PromoteOnRead)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *FSRepo) openKeystore() error {
 	ksp := filepath.Join(r.path, "keystore")
 
@@ -651,6 +715,23 @@ func (r *FSRepo) APIToken() (string, error) {
 	return strings.TrimSpace(string(tkBuff)), nil
 }
 
+// applyDatastoreTuning applies the badger profile of the config, then the options it overrides
+func applyDatastoreTuning(opts *blockstoreutil.Options, cfg *config.DatastoreConfig) error {
+	if err := blockstoreutil.ApplyBadgerProfile(opts, cfg.Profile); err != nil {
+		return err
+	}
+	if cfg.ValueLogFileSize > 0 {
+		opts.ValueLogFileSize = cfg.ValueLogFileSize
+	}
+	if cfg.NumCompactors > 0 {
+		opts.NumCompactors = cfg.NumCompactors
+	}
+	if cfg.BlockCacheSize > 0 {
+		opts.BlockCacheSize = cfg.BlockCacheSize
+	}
+	return nil
+}
+
 func badgerOptions() *badgerds.Options {
 	result := &badgerds.DefaultOptions
 	result.Truncate = true
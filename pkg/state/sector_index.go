@@ -0,0 +1,88 @@
+package state
+
+import (
+	"context"
+	"fmt"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	lminer "github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+)
+
+// DefaultSectorIndexMiners is the default number of miners whose sector locations are indexed
+const DefaultSectorIndexMiners = 64
+
+// sectorIndex maps the sectors of a miner to their deadline and partition, it is valid as long as the deadlines
+// of the miner state it was built from are unchanged
+type sectorIndex struct {
+	state     lminer.State
+	locations map[abi.SectorNumber]lminer.SectorLocation
+}
+
+// SectorIndexCache keeps the location of every sector of the recently queried miners, so that finding the
+// partition of a sector does not scan the deadlines and partitions of the miner.
+// An index is rebuilt once the deadlines of the miner changed, which happens at least at each of its deadline
+// boundaries and with every message moving sectors.
+type SectorIndexCache struct {
+	indexes *lru.Cache[addr.Address, *sectorIndex]
+}
+
+// NewSectorIndexCache creates a cache indexing the sectors of up to miners miners
+func NewSectorIndexCache(miners int) *SectorIndexCache {
+	if miners <= 0 {
+		miners = DefaultSectorIndexMiners
+	}
+	indexes, _ := lru.New[addr.Address, *sectorIndex](miners)
+	return &SectorIndexCache{indexes: indexes}
+}
+
+// FindSector returns the deadline and partition of the sector in mas, the state of the miner maddr
+func (c *SectorIndexCache) FindSector(ctx context.Context, maddr addr.Address, mas lminer.State, sectorNumber abi.SectorNumber) (*lminer.SectorLocation, error) {
+	idx, ok := c.indexes.Get(maddr)
+	if ok {
+		changed, err := idx.state.DeadlinesChanged(mas)
+		if err != nil {
+			return nil, err
+		}
+		ok = !changed
+	}
+	if !ok {
+		var err error
+		if idx, err = newSectorIndex(ctx, mas); err != nil {
+			return nil, fmt.Errorf("indexing the sectors of %s: %w", maddr, err)
+		}
+		c.indexes.Add(maddr, idx)
+	}
+
+	loc, ok := idx.locations[sectorNumber]
+	if !ok {
+		// the actor reports the sectors not found
+		return mas.FindSector(sectorNumber)
+	}
+	return &loc, nil
+}
+
+func newSectorIndex(ctx context.Context, mas lminer.State) (*sectorIndex, error) {
+	idx := &sectorIndex{state: mas, locations: make(map[abi.SectorNumber]lminer.SectorLocation)}
+	err := mas.ForEachDeadline(func(dlIdx uint64, dl lminer.Deadline) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return dl.ForEachPartition(func(partIdx uint64, part lminer.Partition) error {
+			sectors, err := part.AllSectors()
+			if err != nil {
+				return err
+			}
+			return sectors.ForEach(func(s uint64) error {
+				idx.locations[abi.SectorNumber(s)] = lminer.SectorLocation{Deadline: dlIdx, Partition: partIdx}
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
@@ -0,0 +1,107 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	lminer "github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+)
+
+// fakeMinerState lays out its sectors in deadlines of partitions, version tells the deadlines apart
+type fakeMinerState struct {
+	lminer.State
+	version   int
+	deadlines [][][]uint64
+	scans     *int
+}
+
+func (s *fakeMinerState) ForEachDeadline(cb func(idx uint64, dl lminer.Deadline) error) error {
+	*s.scans++
+	for i, dl := range s.deadlines {
+		if err := cb(uint64(i), fakeDeadline{partitions: dl}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeMinerState) DeadlinesChanged(other lminer.State) (bool, error) {
+	return s.version != other.(*fakeMinerState).version, nil
+}
+
+func (s *fakeMinerState) FindSector(num abi.SectorNumber) (*lminer.SectorLocation, error) {
+	return nil, fmt.Errorf("sector %d not found", num)
+}
+
+type fakeDeadline struct {
+	lminer.Deadline
+	partitions [][]uint64
+}
+
+func (dl fakeDeadline) ForEachPartition(cb func(idx uint64, part lminer.Partition) error) error {
+	for i, part := range dl.partitions {
+		if err := cb(uint64(i), fakePartition{sectors: part}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fakePartition struct {
+	lminer.Partition
+	sectors []uint64
+}
+
+func (p fakePartition) AllSectors() (bitfield.BitField, error) {
+	return bitfield.NewFromSet(p.sectors), nil
+}
+
+func TestSectorIndexCache(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	maddr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	var scans int
+	mas := &fakeMinerState{
+		version:   1,
+		deadlines: [][][]uint64{{{1, 2}, {3}}, {}, {{4, 5}}},
+		scans:     &scans,
+	}
+
+	cache := NewSectorIndexCache(0)
+	for s, expect := range map[abi.SectorNumber]lminer.SectorLocation{
+		1: {Deadline: 0, Partition: 0},
+		3: {Deadline: 0, Partition: 1},
+		5: {Deadline: 2, Partition: 0},
+	} {
+		loc, err := cache.FindSector(ctx, maddr, mas, s)
+		require.NoError(t, err)
+		require.Equal(t, expect, *loc)
+	}
+	_, err = cache.FindSector(ctx, maddr, mas, 6)
+	require.Error(t, err)
+	require.Equal(t, 1, scans)
+
+	// the same deadlines in another state reuse the index
+	same := *mas
+	loc, err := cache.FindSector(ctx, maddr, &same, 4)
+	require.NoError(t, err)
+	require.Equal(t, lminer.SectorLocation{Deadline: 2, Partition: 0}, *loc)
+	require.Equal(t, 1, scans)
+
+	// the sectors moved
+	moved := &fakeMinerState{version: 2, deadlines: [][][]uint64{{{1}}, {{2, 3, 4, 5}}}, scans: &scans}
+	loc, err = cache.FindSector(ctx, maddr, moved, 4)
+	require.NoError(t, err)
+	require.Equal(t, lminer.SectorLocation{Deadline: 1, Partition: 0}, *loc)
+	require.Equal(t, 2, scans)
+}
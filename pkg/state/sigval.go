@@ -10,13 +10,31 @@ import (
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
+// ForEachFunc calls fn for the indexes from 0 to n-1, possibly concurrently, and returns one of the errors of fn
+type ForEachFunc func(ctx context.Context, n int, fn func(i int) error) error
+
 // SignatureValidator resolves account actor addresses to their pubkey-style address for signature validation.
 type SignatureValidator struct {
 	signerView AccountView
+	forEach    ForEachFunc
 }
 
 func NewSignatureValidator(signerView AccountView) *SignatureValidator {
-	return &SignatureValidator{signerView: signerView}
+	return NewParallelSignatureValidator(signerView, forEachSequential)
+}
+
+// NewParallelSignatureValidator creates a validator resolving the signers of the messages with forEach
+func NewParallelSignatureValidator(signerView AccountView, forEach ForEachFunc) *SignatureValidator {
+	return &SignatureValidator{signerView: signerView, forEach: forEach}
+}
+
+func forEachSequential(_ context.Context, n int, fn func(i int) error) error {
+	for i := 0; i < n; i++ {
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ValidateSignature check the signature is valid or not
@@ -41,16 +59,19 @@ func (v *SignatureValidator) ValidateBLSMessageAggregate(ctx context.Context, ms
 		return nil
 	}
 
-	var pubKeys [][]byte
-	var encodedMsgCids [][]byte
-	for _, msg := range msgs {
+	pubKeys := make([][]byte, len(msgs))
+	encodedMsgCids := make([][]byte, len(msgs))
+	if err := v.forEach(ctx, len(msgs), func(i int) error {
+		msg := msgs[i]
 		signerAddress, err := v.signerView.ResolveToDeterministicAddress(ctx, msg.From)
 		if err != nil {
 			return errors.Wrapf(err, "failed to load signer address for %v", msg.From)
 		}
-		pubKeys = append(pubKeys, signerAddress.Payload())
-		mCid := msg.Cid()
-		encodedMsgCids = append(encodedMsgCids, mCid.Bytes())
+		pubKeys[i] = signerAddress.Payload()
+		encodedMsgCids[i] = msg.Cid().Bytes()
+		return nil
+	}); err != nil {
+		return err
 	}
 
 	if crypto.VerifyAggregate(pubKeys, encodedMsgCids, sig.Data) != nil {
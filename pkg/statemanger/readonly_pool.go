@@ -0,0 +1,177 @@
+package statemanger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+
+	appstate "github.com/filecoin-project/venus/pkg/state"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/pkg/util"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// readOnlyPoolSize is the number of rpc state reads allowed to run at the same time when it is not configured
+const readOnlyPoolSize = 8
+
+// snapshotter is implemented by blockstores which are able to open a consistent read-only view, eg. badger
+type snapshotter interface {
+	ReadonlyDatastore() *blockstoreutil.TxBlockstore
+}
+
+// ReadOnlyStmgrPool hands out read-only state accessors to rpc callers, so that heavy state queries
+// read from a snapshot of the blockstore and never contend with block validation for the state lock
+type ReadOnlyStmgrPool struct {
+	stmgr  *Stmgr
+	bs     blockstoreutil.Blockstore
+	tokens chan struct{}
+}
+
+// NewReadOnlyStmgrPool creates a pool of read-only accessors over the blockstore of the state manager,
+// the default pool size is used if size is not positive
+func NewReadOnlyStmgrPool(stmgr *Stmgr, size int) *ReadOnlyStmgrPool {
+	if size <= 0 {
+		size = readOnlyPoolSize
+	}
+	return &ReadOnlyStmgrPool{
+		stmgr:  stmgr,
+		bs:     stmgr.cs.Blockstore(),
+		tokens: make(chan struct{}, size),
+	}
+}
+
+// Acquire waits for a free slot of the pool, the returned accessor must be released after use
+func (p *ReadOnlyStmgrPool) Acquire(ctx context.Context) (*ReadOnlyStmgr, error) {
+	select {
+	case p.tokens <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	r := &ReadOnlyStmgr{pool: p}
+	r.snapshot()
+	return r, nil
+}
+
+// ReadOnlyStmgr reads chain state from a snapshot of the blockstore, states not computed yet are
+// computed by the primary state manager
+type ReadOnlyStmgr struct {
+	pool  *ReadOnlyStmgrPool
+	tx    *blockstoreutil.TxBlockstore
+	store cbor.IpldStore
+}
+
+func (r *ReadOnlyStmgr) snapshot() {
+	r.discard()
+
+	bs := r.pool.bs
+	if s, ok := bs.(snapshotter); ok {
+		r.tx = s.ReadonlyDatastore()
+		bs = r.tx
+	}
+	r.store = &util.ReadOnlyIpldStore{IpldStore: cbor.NewCborStore(bs)}
+}
+
+func (r *ReadOnlyStmgr) discard() {
+	if r.tx != nil {
+		r.tx.Discard()
+		r.tx = nil
+	}
+}
+
+// Release discards the snapshot and returns the slot to the pool
+func (r *ReadOnlyStmgr) Release() {
+	if r.pool == nil {
+		return
+	}
+	r.discard()
+	r.store = nil
+	<-r.pool.tokens
+	r.pool = nil
+}
+
+// stateRoot returns the state root of ts, the snapshot is renewed if the state was written after it was taken
+func (r *ReadOnlyStmgr) stateRoot(ctx context.Context, ts *types.TipSet) (cid.Cid, error) {
+	var root cid.Cid
+	if meta, _ := r.pool.stmgr.cs.GetTipsetMetadata(ctx, ts); meta != nil {
+		root = meta.TipSetStateRoot
+	} else {
+		var err error
+		if root, _, err = r.pool.stmgr.RunStateTransition(ctx, ts, nil, false); err != nil {
+			return cid.Undef, err
+		}
+	}
+
+	if r.tx != nil {
+		if has, err := r.tx.HasInTx(ctx, root); err != nil {
+			return cid.Undef, err
+		} else if !has {
+			r.snapshot()
+		}
+	}
+	return root, nil
+}
+
+func (r *ReadOnlyStmgr) TipsetStateTsk(ctx context.Context, tsk types.TipSetKey) (*types.TipSet, *tree.State, error) {
+	ts, err := r.pool.stmgr.cs.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load tipset(%s) failed:%v", tsk.String(), err)
+	}
+	stat, err := r.TipsetState(ctx, ts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load tipset(%s, %d) state failed:%v", ts.String(), ts.Height(), err)
+	}
+	return ts, stat, nil
+}
+
+func (r *ReadOnlyStmgr) TipsetState(ctx context.Context, ts *types.TipSet) (*tree.State, error) {
+	root, err := r.stateRoot(ctx, ts)
+	if err != nil {
+		return nil, err
+	}
+	return tree.LoadState(ctx, r.store, root)
+}
+
+func (r *ReadOnlyStmgr) ParentStateViewTsk(ctx context.Context, tsk types.TipSetKey) (*types.TipSet, *appstate.View, error) {
+	ts, err := r.pool.stmgr.cs.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.ParentStateView(ctx, ts)
+}
+
+func (r *ReadOnlyStmgr) ParentStateView(ctx context.Context, ts *types.TipSet) (*types.TipSet, *appstate.View, error) {
+	if ts == nil {
+		ts = r.pool.stmgr.cs.GetHead()
+	}
+	parent, err := r.pool.stmgr.cs.GetTipSet(ctx, ts.Parents())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, view, err := r.StateView(ctx, parent)
+	if err != nil {
+		return nil, nil, fmt.Errorf("StateView failed:%w", err)
+	}
+	return parent, view, nil
+}
+
+func (r *ReadOnlyStmgr) StateViewTsk(ctx context.Context, tsk types.TipSetKey) (*types.TipSet, cid.Cid, *appstate.View, error) {
+	ts, err := r.pool.stmgr.cs.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, cid.Undef, nil, err
+	}
+	root, view, err := r.StateView(ctx, ts)
+	return ts, root, view, err
+}
+
+func (r *ReadOnlyStmgr) StateView(ctx context.Context, ts *types.TipSet) (cid.Cid, *appstate.View, error) {
+	root, err := r.stateRoot(ctx, ts)
+	if err != nil {
+		return cid.Undef, nil, err
+	}
+	return root, appstate.NewView(r.store, root), nil
+}
@@ -0,0 +1,138 @@
+package statemanger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var testActor = &types.Actor{Code: testhelpers.EmptyMessagesCID, Head: testhelpers.EmptyMessagesCID}
+
+func newTestStmgr(t *testing.T, bs blockstoreutil.Blockstore) *Stmgr {
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+	return &Stmgr{cs: chain.NewStore(ds, bs, testhelpers.CidFromString(t, "genesis"), chain.NewMockCirculatingSupplyCalculator(), nil)}
+}
+
+// putState flushes a state tree holding actors to bs and records it as the state of a new tipset at height
+func putState(ctx context.Context, t *testing.T, stmgr *Stmgr, bs blockstoreutil.Blockstore, height abi.ChainEpoch, actors ...address.Address) (*types.TipSet, cid.Cid) {
+	st, err := tree.NewState(cbor.NewCborStore(bs), tree.StateTreeVersion5)
+	require.NoError(t, err)
+	for _, a := range actors {
+		require.NoError(t, st.SetActor(ctx, a, testActor))
+	}
+	root, err := st.Flush(ctx)
+	require.NoError(t, err)
+
+	newCid := testhelpers.NewCidForTestGetter()
+	ts, err := types.NewTipSet([]*types.BlockHeader{{
+		Height:                height,
+		Miner:                 testhelpers.NewForTestGetter()(),
+		Messages:              newCid(),
+		ParentStateRoot:       newCid(),
+		ParentMessageReceipts: newCid(),
+	}})
+	require.NoError(t, err)
+	require.NoError(t, stmgr.cs.PutTipSetMetadata(ctx, &chain.TipSetMetadata{
+		TipSet:          ts,
+		TipSetStateRoot: root,
+		TipSetReceipts:  testhelpers.EmptyReceiptsCID,
+	}))
+	return ts, root
+}
+
+func TestReadOnlyStmgrPoolAcquire(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	pool := NewReadOnlyStmgrPool(newTestStmgr(t, blockstoreutil.NewBlockstore(datastore.NewMapDatastore())), 1)
+	r, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+	// a blockstore which does not make snapshots is read as is
+	assert.Nil(t, r.tx)
+
+	// the callers wait for a free slot
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = pool.Acquire(timeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// a released accessor frees its slot once
+	r.Release()
+	r.Release()
+	r2, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+	timeout, cancel = context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = pool.Acquire(timeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	r2.Release()
+
+	assert.Equal(t, readOnlyPoolSize, cap(NewReadOnlyStmgrPool(pool.stmgr, 0).tokens))
+}
+
+func TestReadOnlyStmgrSnapshot(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	bs, err := blockstoreutil.Open(blockstoreutil.DefaultOptions(t.TempDir()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = bs.Close() })
+	stmgr := newTestStmgr(t, bs)
+	// the actors are set by id, the state trees have no init actor to resolve the other addresses
+	var id uint64 = 100
+	addrs := func() address.Address {
+		id++
+		a, err := address.NewIDAddress(id)
+		require.NoError(t, err)
+		return a
+	}
+	ts1, root1 := putState(ctx, t, stmgr, bs, 1)
+
+	pool := NewReadOnlyStmgrPool(stmgr, 1)
+	r, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+	defer r.Release()
+	require.NotNil(t, r.tx)
+	first := r.tx
+
+	st, err := r.TipsetState(ctx, ts1)
+	require.NoError(t, err)
+	assert.Equal(t, first, r.tx)
+	// the state read from the snapshot can not be written
+	require.NoError(t, st.SetActor(ctx, addrs(), testActor))
+	_, err = st.Flush(ctx)
+	assert.Error(t, err)
+
+	// a state written after the snapshot renews it
+	actor := addrs()
+	ts2, root2 := putState(ctx, t, stmgr, bs, 2, actor)
+	root, view, err := r.StateView(ctx, ts2)
+	require.NoError(t, err)
+	assert.Equal(t, root2, root)
+	assert.NotEqual(t, first, r.tx)
+	_, err = view.LoadActor(ctx, actor)
+	assert.NoError(t, err)
+
+	// the states already in the snapshot keep it
+	renewed := r.tx
+	root, _, err = r.StateView(ctx, ts1)
+	require.NoError(t, err)
+	assert.Equal(t, root1, root)
+	assert.Equal(t, renewed, r.tx)
+}
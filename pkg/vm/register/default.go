@@ -30,6 +30,13 @@ var (
 
 func GetDefaultActros() *dispatch.CodeLoader {
 	loadOnce.Do(func() {
+		/* inline-gen template
+		{{range .actorVersions}}{{if le . $.lastLegacyActorsVersion}}
+		DefaultActorBuilder.AddMany(actorstypes.Version{{.}}, dispatch.ActorsVersionPredicate(actorstypes.Version{{.}}), builtin.MakeRegistryLegacy(exported{{.}}.BuiltinActors())){{else}}
+		DefaultActorBuilder.AddMany(actorstypes.Version{{.}}, dispatch.ActorsVersionPredicate(actorstypes.Version{{.}}), builtin.MakeRegistry(actorstypes.Version{{.}})){{end}}{{end}}
+
+		/* inline-gen start */
+
 		DefaultActorBuilder.AddMany(actorstypes.Version0, dispatch.ActorsVersionPredicate(actorstypes.Version0), builtin.MakeRegistryLegacy(exported0.BuiltinActors()))
 		DefaultActorBuilder.AddMany(actorstypes.Version2, dispatch.ActorsVersionPredicate(actorstypes.Version2), builtin.MakeRegistryLegacy(exported2.BuiltinActors()))
 		DefaultActorBuilder.AddMany(actorstypes.Version3, dispatch.ActorsVersionPredicate(actorstypes.Version3), builtin.MakeRegistryLegacy(exported3.BuiltinActors()))
@@ -43,6 +50,8 @@ func GetDefaultActros() *dispatch.CodeLoader {
 		DefaultActorBuilder.AddMany(actorstypes.Version11, dispatch.ActorsVersionPredicate(actorstypes.Version11), builtin.MakeRegistry(actorstypes.Version11))
 		DefaultActorBuilder.AddMany(actorstypes.Version12, dispatch.ActorsVersionPredicate(actorstypes.Version12), builtin.MakeRegistry(actorstypes.Version12))
 		DefaultActorBuilder.AddMany(actorstypes.Version13, dispatch.ActorsVersionPredicate(actorstypes.Version13), builtin.MakeRegistry(actorstypes.Version13))
+
+		/* inline-gen end */
 		defaultActors = DefaultActorBuilder.Build()
 	})
 
@@ -55,6 +55,12 @@ func (e *vmExecutor) Flush(ctx context.Context) (cid.Cid, error) {
 	return e.vmi.Flush(ctx)
 }
 
+// ReserveExecution waits for an execution lane of lane, and returns the function freeing it. It bounds the work on
+// the machines besides the executions of messages, such as their instantiation.
+func ReserveExecution(lane ExecutionLane) func() {
+	return execution.getToken(lane).Done
+}
+
 type executionToken struct {
 	lane     ExecutionLane
 	reserved int
@@ -706,7 +706,8 @@ func (vm *LegacyVM) Flush(ctx context.Context) (tree.Root, error) {
 	if root, err := vm.State.Flush(vm.context); err != nil {
 		return cid.Undef, err
 	} else {
-		if err := blockstoreutil.CopyBlockstore(context.TODO(), vm.bsstore.Write(), vm.bsstore.Read()); err != nil {
+		// only the blocks reachable from the new root are written, the intermediate nodes are dropped with the buffer
+		if err := blockstoreutil.CopyParticial(context.TODO(), vm.bsstore.Write(), vm.bsstore.Read(), root); err != nil {
 			return cid.Undef, fmt.Errorf("copying tree: %w", err)
 		}
 		return root, nil
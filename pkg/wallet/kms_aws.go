@@ -0,0 +1,111 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/filecoin-project/venus/venus-shared/utils/sigv4"
+)
+
+// AwsCredentials are the credentials of the requests to AWS KMS, SessionToken is only set for temporary credentials
+type AwsCredentials = sigv4.Credentials
+
+// AwsKMSSigner signs with an asymmetric ECC_SECG_P256K1 key of AWS KMS
+type AwsKMSSigner struct {
+	client   *http.Client
+	endpoint string
+	region   string
+	keyID    string
+	creds    AwsCredentials
+	now      func() time.Time
+}
+
+var _ KMSSigner = (*AwsKMSSigner)(nil)
+
+// NewAwsKMSSigner creates a signer with the key keyID of the region, endpoint is the endpoint of the region when empty
+func NewAwsKMSSigner(client *http.Client, endpoint, region, keyID string, creds AwsCredentials) *AwsKMSSigner {
+	if len(endpoint) == 0 {
+		endpoint = fmt.Sprintf("https://kms.%s.amazonaws.com", region)
+	}
+	return &AwsKMSSigner{
+		client:   client,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		region:   region,
+		keyID:    keyID,
+		creds:    creds,
+		now:      time.Now,
+	}
+}
+
+// PublicKey returns the public key of the key
+func (s *AwsKMSSigner) PublicKey(ctx context.Context) ([]byte, error) {
+	var out struct {
+		KeySpec   string
+		PublicKey []byte
+	}
+	if err := s.call(ctx, "GetPublicKey", map[string]interface{}{"KeyId": s.keyID}, &out); err != nil {
+		return nil, err
+	}
+	if out.KeySpec != "ECC_SECG_P256K1" {
+		return nil, fmt.Errorf("key %s is a %s key, not a ECC_SECG_P256K1 key", s.keyID, out.KeySpec)
+	}
+	return subjectPublicKey(out.PublicKey)
+}
+
+// SignDigest signs the digest as given, without hashing it again
+func (s *AwsKMSSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	in := map[string]interface{}{
+		"KeyId":            s.keyID,
+		"Message":          digest,
+		"MessageType":      "DIGEST",
+		"SigningAlgorithm": "ECDSA_SHA_256",
+	}
+	var out struct {
+		Signature []byte
+	}
+	if err := s.call(ctx, "Sign", in, &out); err != nil {
+		return nil, err
+	}
+	return out.Signature, nil
+}
+
+func (s *AwsKMSSigner) call(ctx context.Context, op string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+op)
+	sigv4.Sign(req, sigv4.PayloadHash(body), s.creds, s.region, "kms", s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("aws kms %s %s: %w", op, s.keyID, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("aws kms %s %s: %w", op, s.keyID, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var res struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(data, &res)
+		return fmt.Errorf("aws kms %s %s: status %d: %s %s", op, s.keyID, resp.StatusCode, res.Type, res.Message)
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,266 @@
+package wallet
+
+import (
+	"context"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	gocrypto "github.com/filecoin-project/go-crypto"
+	"github.com/minio/blake2b-simd"
+	"github.com/pkg/errors"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/wallet/key"
+)
+
+const (
+	// KMSVault is the backend of the keys kept by the transit engine of HashiCorp Vault
+	KMSVault = "vault"
+	// KMSAws is the backend of the keys kept by AWS KMS
+	KMSAws = "awskms"
+)
+
+// KMSBackendType is the reflect type of the KMSBackend.
+var KMSBackendType = reflect.TypeOf(&KMSBackend{})
+
+var ErrKMSKey = errors.New("the private key is kept by a key management service")
+
+// secp256k1N is the order of the secp256k1 curve
+var secp256k1N, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+
+// KMSSigner signs with a secp256k1 key kept by a key management service
+type KMSSigner interface {
+	// PublicKey returns the uncompressed public key of the key
+	PublicKey(ctx context.Context) ([]byte, error)
+	// SignDigest returns the asn1 ecdsa signature of the digest
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+type kmsKey struct {
+	signer KMSSigner
+	pubKey []byte
+}
+
+// KMSBackend is a wallet backend signing for the secp256k1 addresses of the keys of key management services, the
+// keys never leave the services, so they can't be exported nor removed from the wallet.
+type KMSBackend struct {
+	keys map[address.Address]*kmsKey
+}
+
+var _ Backend = (*KMSBackend)(nil)
+
+// NewKMSBackend creates the signers of the configured keys and checks their public keys give the configured addresses
+func NewKMSBackend(ctx context.Context, cfgs []config.KMSKeyConfig) (*KMSBackend, error) {
+	client := &http.Client{Timeout: time.Minute}
+	signers := make(map[address.Address]KMSSigner, len(cfgs))
+	for _, cfg := range cfgs {
+		signer, err := newKMSSigner(client, cfg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "kms key of %s", cfg.Address)
+		}
+		signers[cfg.Address] = signer
+	}
+	return newKMSBackend(ctx, signers)
+}
+
+func newKMSBackend(ctx context.Context, signers map[address.Address]KMSSigner) (*KMSBackend, error) {
+	backend := &KMSBackend{keys: make(map[address.Address]*kmsKey, len(signers))}
+	for addr, signer := range signers {
+		if addr.Protocol() != address.SECP256K1 {
+			return nil, fmt.Errorf("kms key of %s: only secp256k1 addresses are supported", addr)
+		}
+
+		pubKey, err := signer.PublicKey(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get public key of %s", addr)
+		}
+		keyAddr, err := address.NewSecp256k1Address(pubKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key of %s", addr)
+		}
+		if keyAddr != addr {
+			return nil, fmt.Errorf("the kms key of %s is the key of %s", addr, keyAddr)
+		}
+
+		backend.keys[addr] = &kmsKey{signer: signer, pubKey: pubKey}
+	}
+	return backend, nil
+}
+
+func newKMSSigner(client *http.Client, cfg config.KMSKeyConfig) (KMSSigner, error) {
+	if len(cfg.KeyID) == 0 {
+		return nil, fmt.Errorf("no key id")
+	}
+
+	switch cfg.Backend {
+	case KMSVault:
+		endpoint := cfg.Endpoint
+		if len(endpoint) == 0 {
+			endpoint = os.Getenv("VAULT_ADDR")
+		}
+		token := os.Getenv("VAULT_TOKEN")
+		if len(endpoint) == 0 || len(token) == 0 {
+			return nil, fmt.Errorf("vault needs an endpoint and VAULT_TOKEN")
+		}
+		return NewVaultTransitSigner(client, endpoint, cfg.Mount, cfg.KeyID, token), nil
+	case KMSAws:
+		region := cfg.Region
+		if len(region) == 0 {
+			region = os.Getenv("AWS_REGION")
+		}
+		creds := AwsCredentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}
+		if len(region) == 0 || len(creds.AccessKeyID) == 0 || len(creds.SecretAccessKey) == 0 {
+			return nil, fmt.Errorf("aws kms needs a region, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+		}
+		return NewAwsKMSSigner(client, cfg.Endpoint, region, cfg.KeyID, creds), nil
+	default:
+		return nil, fmt.Errorf("unknown kms backend %q", cfg.Backend)
+	}
+}
+
+// Addresses returns the addresses of the configured keys
+func (backend *KMSBackend) Addresses(ctx context.Context) []address.Address {
+	addrs := make([]address.Address, 0, len(backend.keys))
+	for addr := range backend.keys {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].String() < addrs[j].String()
+	})
+	return addrs
+}
+
+// HasAddress checks if an address of the configured keys
+func (backend *KMSBackend) HasAddress(ctx context.Context, addr address.Address) bool {
+	_, ok := backend.keys[addr]
+	return ok
+}
+
+// DeleteAddress fails, the kms keys are removed from the config
+func (backend *KMSBackend) DeleteAddress(ctx context.Context, addr address.Address) error {
+	return errors.Wrapf(ErrKMSKey, "remove the key of %s from the config", addr)
+}
+
+// SignBytes signs the blake2b hash of data with the key of addr, as a recoverable secp256k1 signature
+func (backend *KMSBackend) SignBytes(ctx context.Context, data []byte, addr address.Address) (*crypto.Signature, error) {
+	k, ok := backend.keys[addr]
+	if !ok {
+		return nil, errors.Errorf("%s not found", addr)
+	}
+
+	digest := blake2b.Sum256(data)
+	der, err := k.signer.SignDigest(ctx, digest[:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "kms sign with %s", addr)
+	}
+	sig, err := recoverableSignature(der, digest[:], k.pubKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "kms signature of %s", addr)
+	}
+
+	return &crypto.Signature{
+		Type: crypto.SigTypeSecp256k1,
+		Data: sig,
+	}, nil
+}
+
+// GetKeyInfo fails, the private keys of the kms can't be read
+func (backend *KMSBackend) GetKeyInfo(ctx context.Context, addr address.Address) (*key.KeyInfo, error) {
+	return nil, ErrKMSKey
+}
+
+// GetKeyInfoPassphrase fails, the private keys of the kms can't be exported
+func (backend *KMSBackend) GetKeyInfoPassphrase(ctx context.Context, addr address.Address, password []byte) (*key.KeyInfo, error) {
+	return nil, ErrKMSKey
+}
+
+// LockWallet does nothing, the access to the keys is controlled by the kms
+func (backend *KMSBackend) LockWallet(ctx context.Context) error {
+	return nil
+}
+
+// UnLockWallet does nothing, the access to the keys is controlled by the kms
+func (backend *KMSBackend) UnLockWallet(ctx context.Context, password []byte) error {
+	return nil
+}
+
+// WalletState always returns Unlock
+func (backend *KMSBackend) WalletState(ctx context.Context) int {
+	return Unlock
+}
+
+// recoverableSignature turns the asn1 ecdsa signature of a kms into the [R | S | V] signature of filecoin,
+// with the low S form and the recovery id giving pubKey back
+func recoverableSignature(der, digest, pubKey []byte) ([]byte, error) {
+	var ecSig struct {
+		R, S *big.Int
+	}
+	rest, err := asn1.Unmarshal(der, &ecSig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ecdsa signature: %w", err)
+	}
+	if len(rest) != 0 || ecSig.R.Sign() <= 0 || ecSig.S.Sign() <= 0 ||
+		ecSig.R.Cmp(secp256k1N) >= 0 || ecSig.S.Cmp(secp256k1N) >= 0 {
+		return nil, fmt.Errorf("invalid ecdsa signature")
+	}
+
+	s := ecSig.S
+	if s.Cmp(new(big.Int).Rsh(secp256k1N, 1)) > 0 {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+
+	sig := make([]byte, 65)
+	ecSig.R.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+		recovered, err := gocrypto.EcRecover(digest, sig)
+		if err == nil && string(recovered) == string(pubKey) {
+			return sig, nil
+		}
+	}
+	return nil, fmt.Errorf("signature not made by the key")
+}
+
+// subjectPublicKey decodes the public key of an asn1 SubjectPublicKeyInfo, the format the kms give their keys in
+func subjectPublicKey(der []byte) ([]byte, error) {
+	var spki struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.RawValue `asn1:"optional"`
+		}
+		PublicKey asn1.BitString
+	}
+	rest, err := asn1.Unmarshal(der, &spki)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("invalid public key: trailing data")
+	}
+
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(spki.Algorithm.Parameters.FullBytes, &curve); err != nil || !curve.Equal(oidSecp256k1) {
+		return nil, fmt.Errorf("not a secp256k1 public key")
+	}
+
+	pubKey := spki.PublicKey.RightAlign()
+	if len(pubKey) != gocrypto.PublicKeyBytes || pubKey[0] != 4 {
+		return nil, fmt.Errorf("not an uncompressed public key")
+	}
+	return pubKey, nil
+}
+
+var oidSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
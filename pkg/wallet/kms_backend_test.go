@@ -0,0 +1,196 @@
+package wallet
+
+import (
+	"context"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	gocrypto "github.com/filecoin-project/go-crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/crypto"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+// testKMSKey signs like a kms, with asn1 signatures which have the high S form when highS is set
+type testKMSKey struct {
+	priv  []byte
+	highS bool
+}
+
+func newTestKMSKey(t *testing.T) *testKMSKey {
+	priv, err := gocrypto.GenerateKey()
+	require.NoError(t, err)
+	return &testKMSKey{priv: priv}
+}
+
+func (k *testKMSKey) address(t *testing.T) address.Address {
+	addr, err := address.NewSecp256k1Address(gocrypto.PublicKey(k.priv))
+	require.NoError(t, err)
+	return addr
+}
+
+func (k *testKMSKey) spki(t *testing.T) []byte {
+	params, err := asn1.Marshal(oidSecp256k1)
+	require.NoError(t, err)
+	pub := gocrypto.PublicKey(k.priv)
+
+	der, err := asn1.Marshal(struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.RawValue
+		}
+		PublicKey asn1.BitString
+	}{
+		Algorithm: struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.RawValue
+		}{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}, Parameters: asn1.RawValue{FullBytes: params}},
+		PublicKey: asn1.BitString{Bytes: pub, BitLength: len(pub) * 8},
+	})
+	require.NoError(t, err)
+	return der
+}
+
+func (k *testKMSKey) sign(t *testing.T, digest []byte) []byte {
+	sig, err := gocrypto.Sign(k.priv, digest)
+	require.NoError(t, err)
+
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+	if k.highS {
+		s.Sub(secp256k1N, s)
+	}
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	require.NoError(t, err)
+	return der
+}
+
+func TestKMSBackendAws(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	k := newTestKMSKey(t)
+	addr := k.address(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		assert.Equal(t, "token", r.Header.Get("X-Amz-Security-Token"))
+
+		var in struct {
+			KeyId       string // nolint
+			Message     []byte
+			MessageType string
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		require.Equal(t, "alias/filecoin", in.KeyId)
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"KeySpec": "ECC_SECG_P256K1", "PublicKey": k.spki(t)})
+		case "TrentService.Sign":
+			require.Equal(t, "DIGEST", in.MessageType)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"Signature": k.sign(t, in.Message)})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"UnknownOperationException"}`))
+		}
+	}))
+	defer srv.Close()
+
+	signer := NewAwsKMSSigner(srv.Client(), srv.URL, "us-east-1", "alias/filecoin",
+		AwsCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "token"})
+	backend, err := newKMSBackend(ctx, map[address.Address]KMSSigner{addr: signer})
+	require.NoError(t, err)
+	assert.Equal(t, []address.Address{addr}, backend.Addresses(ctx))
+	assert.True(t, backend.HasAddress(ctx, addr))
+
+	// the low and the high S forms both give a valid signature
+	for _, highS := range []bool{false, true} {
+		k.highS = highS
+		data := []byte("message to sign")
+		sig, err := backend.SignBytes(ctx, data, addr)
+		require.NoError(t, err)
+		assert.Equal(t, crypto.SigTypeSecp256k1, sig.Type)
+		assert.NoError(t, crypto.Verify(sig, addr, data))
+		assert.LessOrEqual(t, new(big.Int).SetBytes(sig.Data[32:64]).Cmp(new(big.Int).Rsh(secp256k1N, 1)), 0)
+	}
+
+	_, err = backend.GetKeyInfo(ctx, addr)
+	assert.ErrorIs(t, err, ErrKMSKey)
+	assert.Error(t, backend.DeleteAddress(ctx, addr))
+
+	// the configured address has to be the one of the key
+	other := newTestKMSKey(t).address(t)
+	_, err = newKMSBackend(ctx, map[address.Address]KMSSigner{other: signer})
+	assert.Error(t, err)
+
+	// the wallet finds the kms keys
+	w := New(backend)
+	sig, err := w.SignBytes(ctx, []byte("data"), addr)
+	require.NoError(t, err)
+	assert.NoError(t, crypto.Verify(sig, addr, []byte("data")))
+}
+
+func TestKMSBackendVault(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	k := newTestKMSKey(t)
+	addr := k.address(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secp/keys/filecoin":
+			pub := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: k.spki(t)})
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"latest_version": 2,
+				"keys":           map[string]interface{}{"2": map[string]string{"public_key": string(pub)}},
+			}})
+		case "/v1/secp/sign/filecoin":
+			var in struct {
+				Input     string `json:"input"`
+				Prehashed bool   `json:"prehashed"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			require.True(t, in.Prehashed)
+			digest, err := base64.StdEncoding.DecodeString(in.Input)
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{
+				"signature": "vault:v2:" + base64.StdEncoding.EncodeToString(k.sign(t, digest)),
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	backend, err := newKMSBackend(ctx, map[address.Address]KMSSigner{
+		addr: NewVaultTransitSigner(srv.Client(), srv.URL, "secp", "filecoin", "token"),
+	})
+	require.NoError(t, err)
+
+	sig, err := backend.SignBytes(ctx, []byte("data"), addr)
+	require.NoError(t, err)
+	assert.NoError(t, crypto.Verify(sig, addr, []byte("data")))
+
+	_, err = newKMSBackend(ctx, map[address.Address]KMSSigner{
+		addr: NewVaultTransitSigner(srv.Client(), srv.URL, "secp", "filecoin", "other"),
+	})
+	assert.ErrorContains(t, err, "permission denied")
+}
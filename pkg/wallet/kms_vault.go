@@ -0,0 +1,124 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// VaultTransitSigner signs with a key of the transit engine of HashiCorp Vault. The key has to be a secp256k1 key,
+// which transit doesn't support out of the box, so the engine is usually provided by a plugin with the same api.
+type VaultTransitSigner struct {
+	client   *http.Client
+	endpoint string
+	mount    string
+	name     string
+	token    string
+}
+
+var _ KMSSigner = (*VaultTransitSigner)(nil)
+
+// NewVaultTransitSigner creates a signer with the key name of the transit engine mounted at mount, transit when empty
+func NewVaultTransitSigner(client *http.Client, endpoint, mount, name, token string) *VaultTransitSigner {
+	if len(mount) == 0 {
+		mount = "transit"
+	}
+	return &VaultTransitSigner{
+		client:   client,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		mount:    strings.Trim(mount, "/"),
+		name:     name,
+		token:    token,
+	}
+}
+
+// PublicKey returns the public key of the latest version of the key
+func (s *VaultTransitSigner) PublicKey(ctx context.Context) ([]byte, error) {
+	var out struct {
+		LatestVersion int `json:"latest_version"`
+		Keys          map[string]struct {
+			PublicKey string `json:"public_key"`
+		} `json:"keys"`
+	}
+	if err := s.call(ctx, http.MethodGet, "keys", nil, &out); err != nil {
+		return nil, err
+	}
+
+	k, ok := out.Keys[strconv.Itoa(out.LatestVersion)]
+	if !ok {
+		return nil, fmt.Errorf("no version %d of key %s", out.LatestVersion, s.name)
+	}
+	block, _ := pem.Decode([]byte(k.PublicKey))
+	if block == nil {
+		return nil, fmt.Errorf("invalid public key of key %s", s.name)
+	}
+	return subjectPublicKey(block.Bytes)
+}
+
+// SignDigest signs the digest with the latest version of the key
+func (s *VaultTransitSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	in := map[string]interface{}{
+		"input":                base64.StdEncoding.EncodeToString(digest),
+		"prehashed":            true,
+		"marshaling_algorithm": "asn1",
+	}
+	var out struct {
+		Signature string `json:"signature"`
+	}
+	if err := s.call(ctx, http.MethodPost, "sign", in, &out); err != nil {
+		return nil, err
+	}
+
+	// the signatures are prefixed with vault and the version of the key, vault:v1:<signature>
+	parts := strings.Split(out.Signature, ":")
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, fmt.Errorf("invalid signature %q", out.Signature)
+	}
+	return base64.StdEncoding.DecodeString(parts[2])
+}
+
+func (s *VaultTransitSigner) call(ctx context.Context, method, op string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/%s/%s", s.endpoint, s.mount, op, s.name)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", s.token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault %s %s: %w", op, s.name, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var res struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []string        `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&res); err != nil {
+		return fmt.Errorf("vault %s %s: status %d: %w", op, s.name, resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vault %s %s: status %d: %s", op, s.name, resp.StatusCode, strings.Join(res.Errors, ", "))
+	}
+	return json.Unmarshal(res.Data, out)
+}
@@ -0,0 +1,105 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/venus-shared/types"
+	swallet "github.com/filecoin-project/venus/venus-shared/types/wallet"
+)
+
+var signRulesKey = ds.NewKey("/wallet/sign-rules")
+
+var _ WalletIntersection = &PolicyWallet{}
+
+// PolicyWallet checks the sign requests against the sign rules before passing them to the wallet
+type PolicyWallet struct {
+	WalletIntersection
+
+	// lk orders the saves of the rules
+	lk     sync.Mutex
+	policy *swallet.SignPolicy
+	// epoch returns the epoch the value signed is accounted in
+	epoch func() abi.ChainEpoch
+	ds    repo.Datastore
+}
+
+// NewPolicyWallet wraps the wallet with the sign rules saved in the datastore
+func NewPolicyWallet(ctx context.Context, w WalletIntersection, store repo.Datastore, epoch func() abi.ChainEpoch) (*PolicyWallet, error) {
+	var rules []*swallet.SignRule
+	data, err := store.Get(ctx, signRulesKey)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &rules); err != nil {
+			return nil, fmt.Errorf("decode sign rules: %w", err)
+		}
+	case errors.Is(err, ds.ErrNotFound):
+	default:
+		return nil, fmt.Errorf("load sign rules: %w", err)
+	}
+
+	return &PolicyWallet{
+		WalletIntersection: w,
+		policy:             swallet.NewSignPolicy(rules),
+		epoch:              epoch,
+		ds:                 store,
+	}, nil
+}
+
+// WalletSign signs the payload when the sign rules of keyAddr allow it
+func (pw *PolicyWallet) WalletSign(ctx context.Context, keyAddr address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) {
+	if err := pw.policy.Check(keyAddr, msg, meta, pw.epoch()); err != nil {
+		walletLog.Warnf("sign request of %s denied: %v", keyAddr, err)
+		return nil, err
+	}
+	return pw.WalletIntersection.WalletSign(ctx, keyAddr, msg, meta)
+}
+
+// SignRules returns the sign rules
+func (pw *PolicyWallet) SignRules() []*swallet.SignRule {
+	return pw.policy.Rules()
+}
+
+// AddSignRule adds and saves a sign rule
+func (pw *PolicyWallet) AddSignRule(ctx context.Context, rule *swallet.SignRule) error {
+	pw.lk.Lock()
+	defer pw.lk.Unlock()
+
+	if err := pw.policy.AddRule(rule); err != nil {
+		return err
+	}
+	return pw.saveRules(ctx)
+}
+
+// RemoveSignRule removes a sign rule and saves the others
+func (pw *PolicyWallet) RemoveSignRule(ctx context.Context, id string) error {
+	pw.lk.Lock()
+	defer pw.lk.Unlock()
+
+	if err := pw.policy.RemoveRule(id); err != nil {
+		return err
+	}
+	return pw.saveRules(ctx)
+}
+
+// SignDenials returns the last sign requests denied by the rules, the most recent first
+func (pw *PolicyWallet) SignDenials(limit int) []swallet.SignDenial {
+	return pw.policy.Denials(limit)
+}
+
+func (pw *PolicyWallet) saveRules(ctx context.Context) error {
+	data, err := json.Marshal(pw.policy.Rules())
+	if err != nil {
+		return err
+	}
+	return pw.ds.Put(ctx, signRulesKey, data)
+}
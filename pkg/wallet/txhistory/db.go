@@ -0,0 +1,216 @@
+package txhistory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs/go-cid"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/wallet"
+)
+
+var pragmas = []string{
+	"PRAGMA synchronous = normal",
+	"PRAGMA temp_store = memory",
+	"PRAGMA journal_mode = WAL",
+}
+
+var ddls = []string{
+	`CREATE TABLE IF NOT EXISTS wallet_txs (
+		address TEXT NOT NULL,
+		direction TEXT NOT NULL,
+		message TEXT NOT NULL,
+		height INTEGER NOT NULL,
+		tipset BLOB NOT NULL,
+		counterparty TEXT NOT NULL,
+		method INTEGER NOT NULL,
+		value TEXT NOT NULL,
+		exit_code INTEGER NOT NULL,
+		gas_used INTEGER NOT NULL,
+		balance TEXT NOT NULL,
+		PRIMARY KEY (address, direction, message)
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS wallet_txs_address_height ON wallet_txs (address, height)`,
+
+	`CREATE INDEX IF NOT EXISTS wallet_txs_tipset ON wallet_txs (tipset)`,
+
+	// the tipsets indexed, with or without messages of the wallet addresses
+	`CREATE TABLE IF NOT EXISTS wallet_tx_tipsets (
+		tipset BLOB PRIMARY KEY NOT NULL,
+		height INTEGER NOT NULL
+	)`,
+
+	`CREATE TABLE IF NOT EXISTS _meta (
+		version UINT64 NOT NULL UNIQUE
+	)`,
+
+	`INSERT OR IGNORE INTO _meta (version) VALUES (1)`,
+}
+
+const schemaVersion = 1
+
+const (
+	insertTx = `INSERT OR REPLACE INTO wallet_txs
+	(address, direction, message, height, tipset, counterparty, method, value, exit_code, gas_used, balance)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectTxs = `SELECT address, direction, message, height, tipset, counterparty, method, value, exit_code, gas_used, balance
+	FROM wallet_txs WHERE address = ? AND height >= ? AND height <= ? ORDER BY height, message, direction`
+)
+
+// DB keeps the messages of the wallet addresses in sqlite
+type DB struct {
+	db *sql.DB
+}
+
+// OpenDB opens the database at path, creating it when it does not exist
+func OpenDB(path string) (*DB, error) {
+	db, err := sql.Open("sqlite3", path+"?mode=rwc")
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite3 database: %w", err)
+	}
+	// a single connection, so that an in memory database is shared
+	db.SetMaxOpenConns(1)
+
+	for _, pragma := range pragmas {
+		if _, err := db.Exec(pragma); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("exec pragma %q: %w", pragma, err)
+		}
+	}
+	for _, ddl := range ddls {
+		if _, err := db.Exec(ddl); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("exec ddl %q: %w", ddl, err)
+		}
+	}
+
+	var version int
+	if err := db.QueryRow("SELECT max(version) FROM _meta").Scan(&version); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("invalid database version: %w", err)
+	}
+	if version != schemaVersion {
+		_ = db.Close()
+		return nil, fmt.Errorf("invalid database version: got %d, expected %d", version, schemaVersion)
+	}
+
+	return &DB{db: db}, nil
+}
+
+// Put records the txs of the tipset executing them, and the tipset as indexed
+func (d *DB) Put(ctx context.Context, ts *types.TipSet, txs []*wallet.Tx) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	stmt, err := tx.PrepareContext(ctx, insertTx)
+	if err != nil {
+		return fmt.Errorf("prepare insert tx: %w", err)
+	}
+	defer stmt.Close() //nolint:errcheck
+
+	for _, t := range txs {
+		_, err := stmt.ExecContext(ctx, t.Address.String(), string(t.Direction), t.Message.String(), int64(t.Height),
+			t.TipSet.Bytes(), t.Counterparty.String(), uint64(t.Method), t.Value.String(), int64(t.ExitCode),
+			t.GasUsed, t.Balance.String())
+		if err != nil {
+			return fmt.Errorf("insert tx %s: %w", t.Message, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT OR REPLACE INTO wallet_tx_tipsets (tipset, height) VALUES (?, ?)",
+		ts.Key().Bytes(), int64(ts.Height())); err != nil {
+		return fmt.Errorf("insert tipset: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// Revert removes the txs of the tipset executing them
+func (d *DB) Revert(ctx context.Context, ts *types.TipSet) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	key := ts.Key().Bytes()
+	if _, err := tx.ExecContext(ctx, "DELETE FROM wallet_txs WHERE tipset = ?", key); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM wallet_tx_tipsets WHERE tipset = ?", key); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Indexed reports whether the tipset is indexed
+func (d *DB) Indexed(ctx context.Context, ts *types.TipSet) (bool, error) {
+	var n int
+	err := d.db.QueryRowContext(ctx, "SELECT count(*) FROM wallet_tx_tipsets WHERE tipset = ?", ts.Key().Bytes()).Scan(&n)
+	return n > 0, err
+}
+
+// List returns the txs of the key address addr included from the epoch from to the epoch to, in the order of the chain
+func (d *DB) List(ctx context.Context, addr address.Address, from, to abi.ChainEpoch) ([]*wallet.Tx, error) {
+	rows, err := d.db.QueryContext(ctx, selectTxs, addr.String(), int64(from), int64(to))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var out []*wallet.Tx
+	for rows.Next() {
+		var (
+			a, direction, msg, counterparty, value, balance string
+			height, exitCode, gasUsed                       int64
+			method                                          uint64
+			tsk                                             []byte
+		)
+		if err := rows.Scan(&a, &direction, &msg, &height, &tsk, &counterparty, &method, &value, &exitCode, &gasUsed, &balance); err != nil {
+			return nil, err
+		}
+
+		t := &wallet.Tx{
+			Direction: wallet.TxDirection(direction),
+			Height:    abi.ChainEpoch(height),
+			Method:    abi.MethodNum(method),
+			ExitCode:  exitcode.ExitCode(exitCode),
+			GasUsed:   gasUsed,
+		}
+		if t.Address, err = address.NewFromString(a); err != nil {
+			return nil, err
+		}
+		if t.Counterparty, err = address.NewFromString(counterparty); err != nil {
+			return nil, err
+		}
+		if t.Message, err = cid.Decode(msg); err != nil {
+			return nil, err
+		}
+		if t.TipSet, err = types.TipSetKeyFromBytes(tsk); err != nil {
+			return nil, err
+		}
+		if t.Value, err = big.FromString(value); err != nil {
+			return nil, err
+		}
+		if t.Balance, err = big.FromString(balance); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+func (d *DB) Close() error {
+	return d.db.Close()
+}
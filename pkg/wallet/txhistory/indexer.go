@@ -0,0 +1,209 @@
+package txhistory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/wallet"
+)
+
+var log = logging.Logger("wallet-txhistory")
+
+// DefaultLookback is the number of epochs below the head indexed when the index starts, a day
+const DefaultLookback = abi.ChainEpoch(2880)
+
+// StateReader reads the actors in the parent state of a tipset, which the messages of its parent are executed to
+type StateReader interface {
+	LookupID(ctx context.Context, ts *types.TipSet, addr address.Address) (address.Address, error)
+	Balance(ctx context.Context, ts *types.TipSet, addr address.Address) (abi.TokenAmount, error)
+}
+
+type chainReader interface {
+	GetTipSet(ctx context.Context, key types.TipSetKey) (*types.TipSet, error)
+}
+
+type messageReader interface {
+	LoadTipSetMessage(ctx context.Context, ts *types.TipSet) ([]types.BlockMessagesInfo, error)
+	LoadReceipts(ctx context.Context, c cid.Cid) ([]types.MessageReceipt, error)
+}
+
+// Indexer records the messages sent by and to the wallet addresses in the tipsets of the canonical chain, along
+// with the balance of the address once they are executed
+type Indexer struct {
+	lk    sync.Mutex
+	db    *DB
+	chain chainReader
+	msgs  messageReader
+	state StateReader
+	// addrs returns the key addresses of the wallet
+	addrs func(context.Context) []address.Address
+}
+
+func NewIndexer(db *DB, chain chainReader, msgs messageReader, state StateReader, addrs func(context.Context) []address.Address) *Indexer {
+	return &Indexer{
+		db:    db,
+		chain: chain,
+		msgs:  msgs,
+		state: state,
+		addrs: addrs,
+	}
+}
+
+// Apply indexes the messages of the parent of ts, which ts executes
+func (ix *Indexer) Apply(ctx context.Context, ts *types.TipSet) error {
+	ix.lk.Lock()
+	defer ix.lk.Unlock()
+
+	if ts.Height() == 0 {
+		return nil
+	}
+	if indexed, err := ix.db.Indexed(ctx, ts); err != nil || indexed {
+		return err
+	}
+
+	// the wallet addresses by the addresses messages may use for them
+	owned := map[address.Address]address.Address{}
+	for _, addr := range ix.addrs(ctx) {
+		owned[addr] = addr
+		if id, err := ix.state.LookupID(ctx, ts, addr); err == nil {
+			owned[id] = addr
+		}
+	}
+
+	var txs []*wallet.Tx
+	if len(owned) > 0 {
+		parent, err := ix.chain.GetTipSet(ctx, ts.Parents())
+		if err != nil {
+			return fmt.Errorf("load parent of %s: %w", ts.Key(), err)
+		}
+		bms, err := ix.msgs.LoadTipSetMessage(ctx, parent)
+		if err != nil {
+			return fmt.Errorf("load messages of %s: %w", parent.Key(), err)
+		}
+		receipts, err := ix.msgs.LoadReceipts(ctx, ts.At(0).ParentMessageReceipts)
+		if err != nil {
+			return fmt.Errorf("load receipts of %s: %w", parent.Key(), err)
+		}
+
+		// the receipts are in the order the messages are executed, the bls messages of a block before its secp ones
+		var msgs []types.ChainMsg
+		for _, bm := range bms {
+			msgs = append(msgs, bm.BlsMessages...)
+			msgs = append(msgs, bm.SecpkMessages...)
+		}
+		if len(msgs) != len(receipts) {
+			return fmt.Errorf("%d receipts for %d messages in %s", len(receipts), len(msgs), parent.Key())
+		}
+
+		for i, msg := range msgs {
+			m := msg.VMMessage()
+			newTx := func(addr address.Address, dir wallet.TxDirection, counterparty address.Address) *wallet.Tx {
+				return &wallet.Tx{
+					Address:      addr,
+					Direction:    dir,
+					Message:      msg.Cid(),
+					Height:       parent.Height(),
+					TipSet:       ts.Key(),
+					Counterparty: counterparty,
+					Method:       m.Method,
+					Value:        m.Value,
+					ExitCode:     receipts[i].ExitCode,
+					GasUsed:      receipts[i].GasUsed,
+				}
+			}
+			if addr, ok := owned[m.From]; ok {
+				txs = append(txs, newTx(addr, wallet.TxOut, m.To))
+			}
+			if addr, ok := owned[m.To]; ok {
+				txs = append(txs, newTx(addr, wallet.TxIn, m.From))
+			}
+		}
+
+		balances := map[address.Address]abi.TokenAmount{}
+		for _, tx := range txs {
+			balance, ok := balances[tx.Address]
+			if !ok {
+				if balance, err = ix.state.Balance(ctx, ts, tx.Address); err != nil {
+					return fmt.Errorf("balance of %s at %s: %w", tx.Address, ts.Key(), err)
+				}
+				balances[tx.Address] = balance
+			}
+			tx.Balance = balance
+		}
+	}
+
+	return ix.db.Put(ctx, ts, txs)
+}
+
+// Revert removes the messages executed by a tipset reverted from the canonical chain
+func (ix *Indexer) Revert(ctx context.Context, ts *types.TipSet) error {
+	ix.lk.Lock()
+	defer ix.lk.Unlock()
+
+	return ix.db.Revert(ctx, ts)
+}
+
+// HeadChange returns the notifee keeping the index in sync with the head of the chain
+func (ix *Indexer) HeadChange(ctx context.Context) chain.ReorgNotifee {
+	return func(rev, app []*types.TipSet) error {
+		for _, ts := range rev {
+			if err := ix.Revert(ctx, ts); err != nil {
+				log.Warnf("failed to revert the wallet messages of %s: %v", ts.Key(), err)
+			}
+		}
+		// app is ordered from the new head down
+		for i := len(app) - 1; i >= 0; i-- {
+			if err := ix.Apply(ctx, app[i]); err != nil {
+				log.Warnf("failed to index the wallet messages of %s: %v", app[i].Key(), err)
+			}
+		}
+		return nil
+	}
+}
+
+// CatchUp indexes the tipsets below head down to the last tipset indexed, at most lookback epochs below head
+func (ix *Indexer) CatchUp(ctx context.Context, head *types.TipSet, lookback abi.ChainEpoch) error {
+	var tss []*types.TipSet
+	for ts := head; ts.Height() > 0 && head.Height()-ts.Height() < lookback; {
+		indexed, err := ix.db.Indexed(ctx, ts)
+		if err != nil {
+			return err
+		}
+		if indexed {
+			break
+		}
+		tss = append(tss, ts)
+		if ts, err = ix.chain.GetTipSet(ctx, ts.Parents()); err != nil {
+			return err
+		}
+	}
+	for i := len(tss) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := ix.Apply(ctx, tss[i]); err != nil {
+			return err
+		}
+	}
+	if len(tss) > 0 {
+		log.Infof("indexed the wallet messages of %d tipsets", len(tss))
+	}
+	return nil
+}
+
+// History returns the messages of the key address addr included from the epoch from to the epoch to
+func (ix *Indexer) History(ctx context.Context, addr address.Address, from, to abi.ChainEpoch) ([]*wallet.Tx, error) {
+	return ix.db.List(ctx, addr, from, to)
+}
+
+func (ix *Indexer) Close() error {
+	return ix.db.Close()
+}
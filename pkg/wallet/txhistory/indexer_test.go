@@ -0,0 +1,146 @@
+package txhistory
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/wallet"
+)
+
+type fakeChain struct {
+	tipsets  map[types.TipSetKey]*types.TipSet
+	msgs     map[types.TipSetKey][]types.BlockMessagesInfo
+	receipts map[cid.Cid][]types.MessageReceipt
+	ids      map[address.Address]address.Address
+	balances map[types.TipSetKey]map[address.Address]abi.TokenAmount
+}
+
+func (fc *fakeChain) GetTipSet(_ context.Context, key types.TipSetKey) (*types.TipSet, error) {
+	return fc.tipsets[key], nil
+}
+
+func (fc *fakeChain) LoadTipSetMessage(_ context.Context, ts *types.TipSet) ([]types.BlockMessagesInfo, error) {
+	return fc.msgs[ts.Key()], nil
+}
+
+func (fc *fakeChain) LoadReceipts(_ context.Context, c cid.Cid) ([]types.MessageReceipt, error) {
+	return fc.receipts[c], nil
+}
+
+func (fc *fakeChain) LookupID(_ context.Context, _ *types.TipSet, addr address.Address) (address.Address, error) {
+	id, ok := fc.ids[addr]
+	if !ok {
+		return address.Undef, types.ErrActorNotFound
+	}
+	return id, nil
+}
+
+func (fc *fakeChain) Balance(_ context.Context, ts *types.TipSet, addr address.Address) (abi.TokenAmount, error) {
+	return fc.balances[ts.Key()][addr], nil
+}
+
+// child appends a tipset executing the messages of parent with receipts
+func (fc *fakeChain) child(t *testing.T, parent *types.TipSet, receipts []types.MessageReceipt, balances map[address.Address]abi.TokenAmount) *types.TipSet {
+	blk := *testhelpers.RequireTipsetWithHeight(t, parent.Height()+1).At(0)
+	blk.Parents = parent.Cids()
+	blk.ParentMessageReceipts = testhelpers.CidFromString(t, parent.Key().String())
+	ts := testhelpers.RequireNewTipSet(t, &blk)
+
+	fc.tipsets[ts.Key()] = ts
+	fc.receipts[blk.ParentMessageReceipts] = receipts
+	fc.balances[ts.Key()] = balances
+	return ts
+}
+
+func TestIndexer(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	addrs := testhelpers.NewForTestGetter()
+	owned, other := addrs(), addrs()
+	ownedID, _ := address.NewIDAddress(1000)
+	fc := &fakeChain{
+		tipsets:  map[types.TipSetKey]*types.TipSet{},
+		msgs:     map[types.TipSetKey][]types.BlockMessagesInfo{},
+		receipts: map[cid.Cid][]types.MessageReceipt{},
+		ids:      map[address.Address]address.Address{owned: ownedID},
+		balances: map[types.TipSetKey]map[address.Address]abi.TokenAmount{},
+	}
+
+	db, err := OpenDB(filepath.Join(t.TempDir(), "wallet_tx.db"))
+	require.NoError(t, err)
+	ix := NewIndexer(db, fc, fc, fc, func(context.Context) []address.Address { return []address.Address{owned} })
+	defer ix.Close() //nolint:errcheck
+
+	genesis := testhelpers.RequireTipsetWithHeight(t, 0)
+	fc.tipsets[genesis.Key()] = genesis
+	ts1 := fc.child(t, genesis, nil, nil)
+
+	// sent by the id address, and a message of others
+	sent := &types.Message{From: ownedID, To: other, Value: big.NewInt(10), Method: 0}
+	unrelated := &types.Message{From: other, To: other, Value: big.NewInt(1)}
+	fc.msgs[ts1.Key()] = []types.BlockMessagesInfo{{BlsMessages: []types.ChainMsg{unrelated, sent}}}
+	ts2 := fc.child(t, ts1, []types.MessageReceipt{{}, {GasUsed: 100}}, map[address.Address]abi.TokenAmount{owned: big.NewInt(90)})
+
+	received := &types.Message{From: other, To: owned, Value: big.NewInt(5), Method: 2}
+	fc.msgs[ts2.Key()] = []types.BlockMessagesInfo{{SecpkMessages: []types.ChainMsg{&types.SignedMessage{Message: *received}}}}
+	ts3 := fc.child(t, ts2, []types.MessageReceipt{{ExitCode: exitcode.ErrForbidden, GasUsed: 7}},
+		map[address.Address]abi.TokenAmount{owned: big.NewInt(88)})
+
+	require.NoError(t, ix.CatchUp(ctx, ts3, DefaultLookback))
+	txs, err := ix.History(ctx, owned, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+
+	assert.Equal(t, wallet.TxOut, txs[0].Direction)
+	assert.Equal(t, owned, txs[0].Address)
+	assert.Equal(t, other, txs[0].Counterparty)
+	assert.Equal(t, sent.Cid(), txs[0].Message)
+	assert.Equal(t, ts1.Height(), txs[0].Height)
+	assert.Equal(t, ts2.Key(), txs[0].TipSet)
+	assert.Equal(t, int64(100), txs[0].GasUsed)
+	assert.Equal(t, big.NewInt(90), txs[0].Balance)
+
+	assert.Equal(t, wallet.TxIn, txs[1].Direction)
+	assert.Equal(t, abi.MethodNum(2), txs[1].Method)
+	assert.Equal(t, exitcode.ErrForbidden, txs[1].ExitCode)
+	assert.Equal(t, big.NewInt(88), txs[1].Balance)
+
+	// the range is inclusive
+	txs, err = ix.History(ctx, owned, ts2.Height(), ts2.Height())
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, wallet.TxIn, txs[0].Direction)
+
+	// the messages of a reverted tipset are removed, and indexed again when it is applied
+	notify := ix.HeadChange(ctx)
+	require.NoError(t, notify([]*types.TipSet{ts3}, nil))
+	txs, err = ix.History(ctx, owned, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.NoError(t, notify(nil, []*types.TipSet{ts3}))
+	txs, err = ix.History(ctx, owned, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, wallet.WriteTxsCSV(&buf, txs))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "height,message,address,direction"))
+	assert.Equal(t, "1,"+sent.Cid().String()+","+owned.String()+",out,"+other.String()+",0,10,0,100,90", lines[1])
+}
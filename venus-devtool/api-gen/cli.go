@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"text/template"
+
+	"github.com/filecoin-project/venus/venus-devtool/api-gen/common"
+	"github.com/filecoin-project/venus/venus-devtool/util"
+	"github.com/urfave/cli/v2"
+)
+
+var errorElem = reflect.TypeOf((*error)(nil)).Elem()
+
+// cliPkgPath is the package of the venus commands, `venus api call` lives there
+const cliPkgPath = "github.com/filecoin-project/venus/cmd"
+
+var cliCmd = &cli.Command{
+	Name:  "cli",
+	Usage: "generate the method table of `venus api call` from the latest chain api",
+	Flags: []cli.Flag{},
+	Action: func(cctx *cli.Context) error {
+		return genCLIForAPI(util.LatestChainAPIPair.Venus)
+	},
+}
+
+const cliGenTemplate = `
+// Code generated by github.com/filecoin-project/venus/venus-devtool/api-gen. DO NOT EDIT.
+package cmd
+
+// apiCallMethods are the methods of the v{{ .Version }} api which can be invoked by ` + "`venus api call`" + `
+var apiCallMethods = map[string]apiCallMethod{
+{{- range .Methods }}
+	"{{ .Name }}": {Group: "{{ .Group }}", Perm: "{{ .Perm }}", Params: []string{ {{- range $i, $p := .Params }}{{ if $i }}, {{ end }}"{{ $p }}"{{ end -}} }, Result: "{{ .Result }}"{{ if .Stream }}, Stream: true{{ end }}},
+{{- end }}
+}
+`
+
+type cliMethod struct {
+	Name   string
+	Group  string
+	Perm   string
+	Params []string
+	Result string
+	Stream bool
+}
+
+func genCLIForAPI(t util.APIMeta) error {
+	opt := t.ParseOpt
+	opt.ResolveImports = true
+	ifaceMetas, _, err := util.ParseInterfaceMetas(opt)
+	if err != nil {
+		return err
+	}
+
+	var methods []cliMethod
+	for _, im := range ifaceMetas {
+		for _, mm := range im.Defined {
+			method, ok := t.Type.MethodByName(mm.Name)
+			if !ok {
+				fmt.Println("not found method: ", mm.Name)
+				continue
+			}
+
+			ft := method.Type
+			m := cliMethod{
+				Name:  mm.Name,
+				Group: simpleGroupName(im.Name),
+				Perm:  util.GetAPIMethodPerm(mm),
+			}
+			for i := 0; i < ft.NumIn(); i++ {
+				if ft.In(i).Implements(ctxElem) {
+					continue
+				}
+				m.Params = append(m.Params, ft.In(i).String())
+			}
+			if ft.NumOut() == 2 || (ft.NumOut() == 1 && ft.Out(0) != errorElem) {
+				m.Result = ft.Out(0).String()
+				m.Stream = ft.Out(0).Kind() == reflect.Chan
+			}
+			methods = append(methods, m)
+		}
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name < methods[j].Name
+	})
+
+	tmpl, err := template.New("cli").Parse(cliGenTemplate)
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]interface{}{
+		"Version": t.RPCMeta.Version,
+		"Methods": methods,
+	})
+	if err != nil {
+		return fmt.Errorf("exec template: %w", err)
+	}
+
+	location, err := util.FindPackageLocation(cliPkgPath)
+	if err != nil {
+		return err
+	}
+	return common.OutputSourceFile(location, "api_call_gen.go", &buf)
+}
@@ -126,6 +126,9 @@ func init() {
 	addExample(network.Connected)
 	addExample(types.NetworkName("mainnet"))
 	addExample(types.SyncStateStage(1))
+	addExample(types.DoubleForkMiningFault)
+	addExample(types.BlockEquivocation)
+	addExample(types.MessageDirectionIn)
 	addExample(chain.FullAPIVersion1)
 	addExample(types.PCHInbound)
 	addExample(time.Minute)
@@ -146,6 +149,8 @@ func init() {
 	addExample(&clientEvent)
 	addExample(retrievalmarket.ClientEventDealAccepted)
 	addExample(retrievalmarket.DealStatusNew)
+	addExample(retrievalmarket.QueryResponseAvailable)
+	addExample(retrievalmarket.QueryItemAvailable)
 	addExample(&textSelExample)
 	addExample(network.ReachabilityPublic)
 	addExample(map[string]int{"name": 42})
@@ -174,6 +179,7 @@ func init() {
 	addExample(map[string]types.MarketBalance{
 		"t026363": ExampleValue("init", reflect.TypeOf(types.MarketBalance{}), nil).(types.MarketBalance),
 	})
+	addExample(types.ExecPreviewReject)
 	addExample([]*types.EstimateMessage{
 		{
 			Msg:  ExampleValue("init", reflect.TypeOf(&types.Message{}), nil).(*types.Message),
@@ -292,6 +298,18 @@ func init() {
 	addExample(subid)
 	addExample(&subid)
 
+	ethvalue := types.EthBigInt(types.NewInt(100))
+	addExample(&types.EthCallFrame{
+		Type:    "CALL",
+		From:    ethaddr,
+		To:      &ethaddr,
+		Value:   &ethvalue,
+		Gas:     ethint,
+		GasUsed: ethint,
+		Input:   types.EthBytes{},
+		Output:  types.EthBytes{},
+	})
+
 	pstring := func(s string) *string { return &s }
 	addExample(&types.EthFilterSpec{
 		FromBlock: pstring("2301220"),
@@ -322,9 +340,22 @@ func init() {
 	addExample(&percent)
 	addExample(gateway.UnsealStateFinished)
 	addExample(types.UnpaddedByteIndex(0))
+	addExample(types.DealActivated)
+	addExample(types.SectorBatchProveCommit)
+	addExample(types.FinalityEC)
+	addExample(wallet.SignRuleMaxValue)
+	addExample(wallet.TxOut)
 
 	addExample(retrievalmarket.CborGenCompatibleNode{})
 	addExample(gateway.HostNode)
+	addExample(gateway.ErrCodeTemporary)
+	addExample(gateway.AccountUpdated)
+	addExample(gateway.AddressProofMandatory)
+	addExample(gateway.QueueDropOldest)
+	addExample(gateway.BreakerClosed)
+	addExample(gateway.SLOSign)
+	addExample(gateway.PriorityWinningPoSt)
+	addExample(types.TipSetTagFinalized)
 }
 
 func ExampleValue(method string, t, parent reflect.Type) interface{} {
@@ -22,6 +22,8 @@ func main() {
 			clientCmd,
 			docGenCmd,
 			mockCmd,
+			cliCmd,
+			pythonCmd,
 		},
 	}
 
@@ -0,0 +1,627 @@
+package main
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/filecoin-project/venus/venus-devtool/api-gen/common"
+	"github.com/filecoin-project/venus/venus-devtool/util"
+	gatewayv2 "github.com/filecoin-project/venus/venus-shared/api/gateway/v2"
+	"github.com/urfave/cli/v2"
+)
+
+// sharedAPIPkgPath is the package of the apis, the python package is generated in its python directory
+const sharedAPIPkgPath = "github.com/filecoin-project/venus/venus-shared/api"
+
+// pythonPkgName is the name of the python package of the clients
+const pythonPkgName = "venus_api"
+
+var pythonCmd = &cli.Command{
+	Name:  "python",
+	Usage: "generate the python clients of the latest full node and gateway apis",
+	Flags: []cli.Flag{},
+	Action: func(cctx *cli.Context) error {
+		if err := util.LoadExtraInterfaceMeta(); err != nil {
+			return err
+		}
+		location, err := util.FindPackageLocation(sharedAPIPkgPath)
+		if err != nil {
+			return err
+		}
+		dir := filepath.Join(location, "python", pythonPkgName)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+
+		gatewayElem := reflect.TypeOf((*gatewayv2.IGateway)(nil)).Elem()
+		targets := []struct {
+			module string
+			meta   util.APIMeta
+		}{
+			{module: "fullnode", meta: util.LatestChainAPIPair.Venus},
+		}
+		for _, t := range common.ApiTargets {
+			if t.Type == gatewayElem {
+				targets = append(targets, struct {
+					module string
+					meta   util.APIMeta
+				}{module: "gateway", meta: t})
+			}
+		}
+
+		var modules []string
+		for _, target := range targets {
+			module := fmt.Sprintf("%s_v%d", target.module, target.meta.RPCMeta.Version)
+			if err := genPythonForAPI(target.meta, filepath.Join(dir, module+".py")); err != nil {
+				return fmt.Errorf("generate python client for %s: %w", target.meta.Type, err)
+			}
+			modules = append(modules, module)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, "_rpc.py"), []byte(pythonRuntime), 0o644); err != nil {
+			return err
+		}
+		return writePythonTemplate(filepath.Join(dir, "__init__.py"), pythonInitTemplate, map[string]interface{}{
+			"Modules": modules,
+		})
+	},
+}
+
+const pythonInitTemplate = `# Code generated by github.com/filecoin-project/venus/venus-devtool/api-gen. DO NOT EDIT.
+"""Typed clients of the venus apis, see the module of every api."""
+
+from ._rpc import Client, RPCError, Subscription, parse_api_info
+
+__all__ = ["Client", "RPCError", "Subscription", "parse_api_info"{{ range .Modules }}, "{{ . }}"{{ end }}]
+`
+
+const pythonModuleTemplate = `# Code generated by github.com/filecoin-project/venus/venus-devtool/api-gen. DO NOT EDIT.
+"""Client of the v{{ .Version }} {{ .APIName }} api of venus."""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, field
+from typing import Any, Dict, List, Optional
+
+from ._rpc import Cid, Client, Subscription, parse_api_info
+
+MAJOR_VERSION = {{ .Version }}
+API_NAMESPACE = "{{ .APINs }}"
+METHOD_NAMESPACE = "{{ .MethNs }}"
+{{ range .Classes }}
+
+@dataclass
+class {{ .Name }}:
+{{- range .Fields }}
+    {{ .Name }}: {{ .Hint }} = {{ .Default }}
+{{- else }}
+    pass
+{{- end }}
+{{ end }}
+
+class {{ .ClientName }}(Client):
+    """Calls the methods of the {{ .APIName }} api over http, the methods returning a channel subscribe over a websocket."""
+
+    def __init__(self, url: str, token: Optional[str] = None, **kwargs: Any) -> None:
+        super().__init__(url, token, namespace=API_NAMESPACE, method_namespace=METHOD_NAMESPACE, **kwargs)
+
+    @classmethod
+    def from_api_info(cls, info: str, **kwargs: Any) -> {{ .ClientName }}:
+        """Creates the client from an api info like token:/ip4/127.0.0.1/tcp/3453."""
+        url, token = parse_api_info(info, MAJOR_VERSION)
+        return cls(url, token, **kwargs)
+{{ range .Methods }}
+    def {{ .Name }}(self{{ range .Params }}, {{ .Name }}: {{ .Hint }}{{ end }}) -> {{ .Result }}:
+        """{{ .Doc }}"""
+        {{ if .Stream }}return self.subscribe{{ else if .ResultHint }}return self.call{{ else }}self.call{{ end }}("{{ .Name }}", [{{ range $i, $p := .Params }}{{ if $i }}, {{ end }}{{ $p.Name }}{{ end }}]{{ if .ResultHint }}, {{ .ResultHint }}{{ end }})
+{{ end -}}
+`
+
+type pyClass struct {
+	Name   string
+	Fields []pyField
+}
+
+type pyField struct {
+	Name    string
+	Hint    string
+	Default string
+}
+
+type pyMethod struct {
+	Name       string
+	Doc        string
+	Params     []pyField
+	Result     string
+	ResultHint string
+	Stream     bool
+}
+
+var (
+	jsonMarshalerElem = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerElem = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// pyReservedNames are the names imported by the python modules, which the classes can not take
+var pyReservedNames = map[string]bool{
+	"Any": true, "Dict": true, "List": true, "Optional": true, "Cid": true, "Client": true, "Subscription": true,
+}
+
+var pyKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true, "async": true, "await": true,
+	"break": true, "class": true, "continue": true, "def": true, "del": true, "elif": true, "else": true,
+	"except": true, "finally": true, "for": true, "from": true, "global": true, "if": true, "import": true,
+	"in": true, "is": true, "lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true,
+	"raise": true, "return": true, "try": true, "while": true, "with": true, "yield": true, "self": true,
+}
+
+// pyTypes maps the go types to the python hints, the structs to dataclasses which are emitted after the classes
+// of their fields
+type pyTypes struct {
+	method  string
+	names   map[reflect.Type]string
+	taken   map[string]bool
+	classes []pyClass
+}
+
+func genPythonForAPI(t util.APIMeta, out string) error {
+	opt := t.ParseOpt
+	opt.ResolveImports = true
+	ifaceMetas, _, err := util.ParseInterfaceMetas(opt)
+	if err != nil {
+		return err
+	}
+
+	apiName := t.Type.Name()
+	ns, methNs := t.RPCMeta.Namespace, t.RPCMeta.MethodNamespace
+	if methNs == "" {
+		methNs = "Filecoin"
+	}
+
+	types := &pyTypes{names: make(map[reflect.Type]string), taken: make(map[string]bool)}
+	var methods []pyMethod
+	for _, im := range ifaceMetas {
+		if im.Name == apiName && ns == "" {
+			ns = fmt.Sprintf("%s.%s", im.Pkg.Name, im.Name)
+		}
+		for _, mm := range im.Defined {
+			method, ok := t.Type.MethodByName(mm.Name)
+			if !ok {
+				fmt.Println("not found method: ", mm.Name)
+				continue
+			}
+			types.method = mm.Name
+			methods = append(methods, types.method2py(mm, method.Type))
+		}
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name < methods[j].Name
+	})
+
+	return writePythonTemplate(out, pythonModuleTemplate, map[string]interface{}{
+		"APIName":    simpleGroupName(apiName),
+		"ClientName": simpleGroupName(apiName) + "Client",
+		"Version":    t.RPCMeta.Version,
+		"APINs":      ns,
+		"MethNs":     methNs,
+		"Classes":    types.classes,
+		"Methods":    methods,
+	})
+}
+
+func (p *pyTypes) method2py(mm util.InterfaceMethodMeta, ft reflect.Type) pyMethod {
+	m := pyMethod{Name: mm.Name, Result: "None"}
+
+	var names []string
+	for _, f := range mm.FuncType.Params.List {
+		if len(f.Names) == 0 {
+			names = append(names, "")
+		}
+		for _, n := range f.Names {
+			names = append(names, n.Name)
+		}
+	}
+	for i := 0; i < ft.NumIn(); i++ {
+		if ft.In(i).Implements(ctxElem) {
+			continue
+		}
+		name := fmt.Sprintf("p%d", i)
+		if i < len(names) && names[i] != "" && names[i] != "_" {
+			name = pyIdent(names[i])
+		}
+		m.Params = append(m.Params, pyField{Name: name, Hint: p.hint(ft.In(i))})
+	}
+
+	if ft.NumOut() == 2 || (ft.NumOut() == 1 && ft.Out(0) != errorElem) {
+		out := ft.Out(0)
+		if out.Kind() == reflect.Chan {
+			m.Stream = true
+			m.ResultHint = p.hint(out.Elem())
+			m.Result = fmt.Sprintf("Subscription[%s]", m.ResultHint)
+		} else {
+			m.ResultHint = p.hint(out)
+			m.Result = m.ResultHint
+		}
+	}
+
+	var doc []string
+	if cmt := strings.TrimSpace(getComment(mm.Comments)); cmt != "" {
+		doc = append(doc, strings.Split(strings.ReplaceAll(cmt, "\\<", "<"), "\n")...)
+	}
+	if perm := util.GetAPIMethodPerm(mm); perm != "" {
+		if len(doc) > 0 {
+			doc = append(doc, "")
+		}
+		doc = append(doc, "Perms: "+perm)
+	}
+	for i := range doc {
+		doc[i] = strings.ReplaceAll(strings.ReplaceAll(doc[i], "\\", "\\\\"), `"""`, `\"\"\"`)
+	}
+	if len(doc) == 0 {
+		doc = append(doc, fmt.Sprintf("Calls the %s method.", mm.Name))
+	}
+	m.Doc = doc[0]
+	if len(doc) > 1 {
+		// the lines of a docstring are indented as its quotes
+		for _, line := range doc[1:] {
+			m.Doc += "\n"
+			if line != "" {
+				m.Doc += "        " + line
+			}
+		}
+		m.Doc += "\n        "
+	}
+	return m
+}
+
+// hint returns the python hint of the go type, the structs are declared as dataclasses
+func (p *pyTypes) hint(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		return fmt.Sprintf("Optional[%s]", p.hint(t.Elem()))
+	}
+	if isMarshaler(t) {
+		// the structs encoded as objects, like the tipsets, are still declared with the fields of the object
+		hint := p.exampleHint(t)
+		if keys, values, ok := p.exampleObject(t); ok && hint != "Cid" && t.Kind() == reflect.Struct && t.Name() != "" {
+			return p.class(t, func() []pyField { return p.objectFields(t, keys, values) })
+		}
+		return hint
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.String:
+		return "str"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes"
+		}
+		return fmt.Sprintf("List[%s]", p.hint(t.Elem()))
+	case reflect.Array:
+		return fmt.Sprintf("List[%s]", p.hint(t.Elem()))
+	case reflect.Map:
+		return fmt.Sprintf("Dict[str, %s]", p.hint(t.Elem()))
+	case reflect.Struct:
+		if t.Name() == "" {
+			return "Dict[str, Any]"
+		}
+		return p.class(t, func() []pyField { return p.fields(t, nil) })
+	default:
+		return "Any"
+	}
+}
+
+func isMarshaler(t reflect.Type) bool {
+	return t.Implements(jsonMarshalerElem) || reflect.PtrTo(t).Implements(jsonMarshalerElem) ||
+		t.Implements(textMarshalerElem) || reflect.PtrTo(t).Implements(textMarshalerElem)
+}
+
+// exampleHint is the hint of a type with its own json encoding, from the json of its example value
+func (p *pyTypes) exampleHint(t reflect.Type) string {
+	b, err := p.exampleJSON(t)
+	if err != nil {
+		return "Any"
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return "Any"
+	}
+	return jsonHint(v)
+}
+
+func (p *pyTypes) exampleJSON(t reflect.Type) (b []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("no example value of %s: %v", t, r)
+		}
+	}()
+	// through a pointer for the json methods of the pointers
+	v := reflect.New(t)
+	v.Elem().Set(reflect.ValueOf(ExampleValue(p.method, t, nil)))
+	return json.Marshal(v.Interface())
+}
+
+// exampleObject returns the keys, in order, and the values of the example value when it is encoded as an object
+func (p *pyTypes) exampleObject(t reflect.Type) ([]string, []json.RawMessage, bool) {
+	b, err := p.exampleJSON(t)
+	if err != nil {
+		return nil, nil, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, nil, false
+	}
+
+	var keys []string
+	var values []json.RawMessage
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, false
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, false
+		}
+		keys = append(keys, tok.(string))
+		values = append(values, value)
+	}
+	return keys, values, true
+}
+
+// objectFields declares the keys of the object encoding a struct, typed as the fields of the same name, exported or
+// not, when the struct has some
+func (p *pyTypes) objectFields(t reflect.Type, keys []string, values []json.RawMessage) []pyField {
+	out := make([]pyField, 0, len(keys))
+	for i, key := range keys {
+		var hint string
+		f, ok := findField(t, key)
+		if ok {
+			hint = p.hint(f.Type)
+		} else {
+			dec := json.NewDecoder(bytes.NewReader(values[i]))
+			dec.UseNumber()
+			var v interface{}
+			_ = dec.Decode(&v)
+			hint = jsonHint(v)
+		}
+		if !ok || isMarshaler(f.Type) {
+			hint = pyNullable(hint)
+		}
+
+		attr := pyIdent(pyClassName(key))
+		var meta []string
+		if attr != key {
+			meta = append(meta, fmt.Sprintf("%q: %q", "json", key))
+		}
+		out = append(out, pyField{Name: attr, Hint: hint, Default: pyDefault(hint, meta)})
+	}
+	return out
+}
+
+func findField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" {
+			name = f.Name
+		}
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && ft.Kind() == reflect.Struct {
+			if found, ok := findField(ft, key); ok {
+				return found, true
+			}
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func jsonHint(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return "str"
+	case bool:
+		return "bool"
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return "float"
+		}
+		return "int"
+	case []interface{}:
+		if len(v) == 0 {
+			return "List[Any]"
+		}
+		return fmt.Sprintf("List[%s]", jsonHint(v[0]))
+	case map[string]interface{}:
+		if _, ok := v["/"]; ok && len(v) == 1 {
+			return "Cid"
+		}
+		return "Dict[str, Any]"
+	default:
+		return "Any"
+	}
+}
+
+// class declares the dataclass of the struct once the classes of its fields are declared
+func (p *pyTypes) class(t reflect.Type, fields func() []pyField) string {
+	if name, ok := p.names[t]; ok {
+		return name
+	}
+
+	name := pyClassName(t.Name())
+	if p.taken[name] || pyReservedNames[name] {
+		name = pyClassName(path.Base(t.PkgPath())) + name
+	}
+	for i := 2; p.taken[name]; i++ {
+		name = fmt.Sprintf("%s%d", pyClassName(t.Name()), i)
+	}
+	p.names[t] = name
+	p.taken[name] = true
+
+	p.classes = append(p.classes, pyClass{Name: name, Fields: fields()})
+	return name
+}
+
+// fields lists the fields of the struct as encoded in json, the embedded structs inlined unless their fields are
+// shadowed
+func (p *pyTypes) fields(t reflect.Type, seen map[string]bool) []pyField {
+	if seen == nil {
+		seen = make(map[string]bool)
+	}
+	var out []pyField
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		jsonName, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && jsonName == "" && ft.Kind() == reflect.Struct && !isMarshaler(ft) {
+			embedded = append(embedded, ft)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = f.Name
+		}
+		if seen[jsonName] {
+			continue
+		}
+		seen[jsonName] = true
+
+		hint := p.hint(f.Type)
+		if isMarshaler(f.Type) {
+			hint = pyNullable(hint)
+		}
+		var meta []string
+		attr := pyIdent(f.Name)
+		if attr != jsonName {
+			meta = append(meta, fmt.Sprintf("%q: %q", "json", jsonName))
+		}
+		if strings.Contains(opts, "omitempty") {
+			meta = append(meta, `"omitempty": True`)
+		}
+		out = append(out, pyField{Name: attr, Hint: hint, Default: pyDefault(hint, meta)})
+	}
+
+	// the fields of the struct shadow the ones of the embedded structs
+	for _, et := range embedded {
+		out = append(out, p.fields(et, seen)...)
+	}
+	return out
+}
+
+// pyNullable makes the field of a type with its own json encoding default to None, the fields which are None are
+// not encoded, so that the node keeps the zero value of the type rather than failing to decode an empty value
+func pyNullable(hint string) string {
+	if strings.HasPrefix(hint, "Optional[") || hint == "Any" {
+		return hint
+	}
+	return "Optional[" + hint + "]"
+}
+
+func pyDefault(hint string, meta []string) string {
+	var def string
+	switch {
+	case strings.HasPrefix(hint, "Optional["), hint == "Any":
+		def = "default=None"
+	case strings.HasPrefix(hint, "List["):
+		def = "default_factory=list"
+	case strings.HasPrefix(hint, "Dict["), hint == "Cid":
+		def = "default_factory=dict"
+	case hint == "int":
+		def = "default=0"
+	case hint == "float":
+		def = "default=0.0"
+	case hint == "str":
+		def = `default=""`
+	case hint == "bool":
+		def = "default=False"
+	case hint == "bytes":
+		def = `default=b""`
+	default:
+		def = "default_factory=" + hint
+	}
+	if len(meta) > 0 {
+		def += ", metadata={" + strings.Join(meta, ", ") + "}"
+	}
+	return "field(" + def + ")"
+}
+
+// pyClassName drops the package paths of the type parameters and the characters python does not allow in names
+func pyClassName(name string) string {
+	if i := strings.Index(name, "["); i >= 0 && strings.HasSuffix(name, "]") {
+		params := strings.Split(name[i+1:len(name)-1], ",")
+		name = name[:i]
+		for _, param := range params {
+			name += param[strings.LastIndex(param, ".")+1:]
+		}
+	}
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, name)
+	if name == "" {
+		return "Struct"
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func pyIdent(name string) string {
+	if pyKeywords[name] {
+		return name + "_"
+	}
+	return name
+}
+
+func writePythonTemplate(out, tmplText string, data interface{}) error {
+	tmpl, err := template.New(filepath.Base(out)).Parse(tmplText)
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("exec template: %w", err)
+	}
+	return os.WriteFile(out, buf.Bytes(), 0o644)
+}
@@ -0,0 +1,243 @@
+package main
+
+// pythonRuntime is the json rpc client of the generated python modules, it only needs the standard library but for
+// the channel methods, which need the websocket-client package
+const pythonRuntime = `# Code generated by github.com/filecoin-project/venus/venus-devtool/api-gen. DO NOT EDIT.
+"""JSON RPC runtime of the generated clients."""
+
+import base64
+import dataclasses
+import itertools
+import json
+import typing
+import urllib.error
+import urllib.parse
+import urllib.request
+from typing import Any, Dict, Generic, Iterator, List, Optional, Tuple, TypeVar
+
+Cid = Dict[str, str]
+
+T = TypeVar("T")
+
+VENUS_API_NAMESPACE_HEADER = "X-VENUS-API-NAMESPACE"
+
+
+class RPCError(Exception):
+    """An error returned by the node."""
+
+    def __init__(self, code: int, message: str, data: Any = None) -> None:
+        super().__init__("%s (code %d)" % (message, code))
+        self.code = code
+        self.message = message
+        self.data = data
+
+
+def parse_api_info(info: str, version: int) -> Tuple[str, Optional[str]]:
+    """Splits an api info like token:/ip4/127.0.0.1/tcp/3453 into the url of the rpc endpoint and the token."""
+    token = None
+    if not info.startswith(("/", "http://", "https://", "ws://", "wss://")):
+        token, info = info.split(":", 1)
+    if info.startswith("/"):
+        parts = info.strip("/").split("/")
+        if len(parts) < 4 or parts[0] not in ("ip4", "ip6", "dns", "dns4", "dns6") or parts[2] != "tcp":
+            raise ValueError("unsupported multiaddr %s" % info)
+        host = "[%s]" % parts[1] if parts[0] == "ip6" else parts[1]
+        scheme = parts[4] if len(parts) > 4 else "http"
+        info = "%s://%s:%s" % (scheme, host, parts[3])
+    return endpoint(info, version), token
+
+
+def endpoint(url: str, version: int) -> str:
+    """Appends the path of the rpc endpoint of the api version to the url when it has none."""
+    u = urllib.parse.urlparse(url)
+    if u.path in ("", "/"):
+        u = u._replace(path="/rpc/v%d" % version)
+    return urllib.parse.urlunparse(u)
+
+
+def encode(value: Any) -> Any:
+    """Converts the value to its json form, the dataclasses with the json names of their fields."""
+    if dataclasses.is_dataclass(value) and not isinstance(value, type):
+        out = {}
+        for f in dataclasses.fields(value):
+            v = getattr(value, f.name)
+            # the node keeps the zero value of the fields left out
+            if v is None or (f.metadata.get("omitempty") and _empty(v)):
+                continue
+            out[f.metadata.get("json", f.name)] = encode(v)
+        return out
+    if isinstance(value, (bytes, bytearray)):
+        return base64.b64encode(value).decode()
+    if isinstance(value, (list, tuple)):
+        return [encode(v) for v in value]
+    if isinstance(value, dict):
+        return {k: encode(v) for k, v in value.items()}
+    return value
+
+
+def _empty(value: Any) -> bool:
+    if value is None or value is False:
+        return True
+    if isinstance(value, (int, float)) and value == 0:
+        return True
+    return isinstance(value, (str, bytes, list, tuple, dict)) and len(value) == 0
+
+
+_hints: Dict[type, Dict[str, Any]] = {}
+
+
+def decode(hint: Any, value: Any) -> Any:
+    """Converts the json value to the hinted type, the objects of the dataclasses to their instances."""
+    if value is None or hint is Any:
+        return value
+    origin = typing.get_origin(hint)
+    if origin is typing.Union:
+        args = [a for a in typing.get_args(hint) if a is not type(None)]
+        return decode(args[0], value) if len(args) == 1 else value
+    if origin is list:
+        args = typing.get_args(hint)
+        return [decode(args[0] if args else Any, v) for v in value]
+    if origin is dict:
+        args = typing.get_args(hint)
+        return {k: decode(args[1] if args else Any, v) for k, v in value.items()}
+    if dataclasses.is_dataclass(hint):
+        hints = _hints.get(hint)
+        if hints is None:
+            hints = _hints[hint] = typing.get_type_hints(hint)
+        kwargs = {}
+        for f in dataclasses.fields(hint):
+            name = f.metadata.get("json", f.name)
+            if name in value:
+                kwargs[f.name] = decode(hints[f.name], value[name])
+        return hint(**kwargs)
+    if hint is bytes:
+        return base64.b64decode(value)
+    if hint is float:
+        return float(value)
+    return value
+
+
+def _result(reply: Dict[str, Any]) -> Any:
+    err = reply.get("error")
+    if err:
+        raise RPCError(err.get("code", 0), err.get("message", ""), err.get("data"))
+    return reply.get("result")
+
+
+class Subscription(Generic[T]):
+    """Iterates the values the node sends on a channel until it closes it, close ends it early."""
+
+    def __init__(self, ws: Any, chan_id: int, item: Any) -> None:
+        self._ws = ws
+        self._chan_id = chan_id
+        self._item = item
+
+    def __iter__(self) -> Iterator[T]:
+        return self
+
+    def __next__(self) -> T:
+        while self._ws is not None:
+            try:
+                frame = self._ws.recv()
+            except Exception:
+                self.close()
+                break
+            if not frame:
+                self.close()
+                break
+            msg = json.loads(frame)
+            params = msg.get("params") or []
+            if not params or params[0] != self._chan_id:
+                continue
+            if msg.get("method") == "xrpc.ch.val":
+                return decode(self._item, params[1])
+            if msg.get("method") == "xrpc.ch.close":
+                self.close()
+        raise StopIteration
+
+    def close(self) -> None:
+        if self._ws is not None:
+            self._ws.close()
+            self._ws = None
+
+    def __enter__(self) -> "Subscription[T]":
+        return self
+
+    def __exit__(self, *exc: Any) -> None:
+        self.close()
+
+
+class Client:
+    """Calls the methods of an api of a venus node, see the generated client of every api."""
+
+    def __init__(self, url: str, token: Optional[str] = None, namespace: str = "", method_namespace: str = "Filecoin",
+                 headers: Optional[Dict[str, str]] = None, timeout: float = 60.0) -> None:
+        self._url = url
+        self._token = token
+        self._namespace = namespace
+        self._method_namespace = method_namespace
+        self._extra_headers = dict(headers or {})
+        self._timeout = timeout
+        self._ids = itertools.count(1)
+
+    def _headers(self) -> Dict[str, str]:
+        headers = {"Content-Type": "application/json"}
+        if self._namespace:
+            headers[VENUS_API_NAMESPACE_HEADER] = self._namespace
+        if self._token:
+            headers["Authorization"] = "Bearer " + self._token
+        headers.update(self._extra_headers)
+        return headers
+
+    def _request(self, method: str, params: List[Any]) -> Dict[str, Any]:
+        return {
+            "jsonrpc": "2.0",
+            "id": next(self._ids),
+            "method": "%s.%s" % (self._method_namespace, method),
+            "params": [encode(p) for p in params],
+        }
+
+    def _endpoint(self, ws: bool) -> str:
+        u = urllib.parse.urlparse(self._url)
+        schemes = {"http": "ws", "https": "wss"} if ws else {"ws": "http", "wss": "https"}
+        return urllib.parse.urlunparse(u._replace(scheme=schemes.get(u.scheme, u.scheme)))
+
+    def call(self, method: str, params: List[Any], result: Any = Any) -> Any:
+        """Calls the method over http and decodes its result to the hinted type."""
+        body = json.dumps(self._request(method, params)).encode()
+        req = urllib.request.Request(self._endpoint(False), data=body, headers=self._headers(), method="POST")
+        try:
+            with urllib.request.urlopen(req, timeout=self._timeout) as resp:
+                reply = json.load(resp)
+        except urllib.error.HTTPError as e:
+            # the errors of the methods come with their json reply
+            try:
+                reply = json.load(e)
+            except ValueError:
+                raise e from None
+        return decode(result, _result(reply))
+
+    def subscribe(self, method: str, params: List[Any], item: Any = Any) -> Subscription[Any]:
+        """Calls a method returning a channel over a websocket and returns the iterator of its values."""
+        try:
+            import websocket
+        except ImportError as e:
+            raise RuntimeError("the channel methods need the websocket-client package") from e
+
+        headers = ["%s: %s" % (k, v) for k, v in self._headers().items()]
+        ws = websocket.create_connection(self._endpoint(True), header=headers, timeout=self._timeout)
+        try:
+            req = self._request(method, params)
+            ws.send(json.dumps(req))
+            while True:
+                reply = json.loads(ws.recv())
+                if reply.get("id") == req["id"]:
+                    break
+            chan_id = _result(reply)
+            # the values come whenever the node has some
+            ws.settimeout(None)
+        except BaseException:
+            ws.close()
+            raise
+        return Subscription(ws, chan_id, item)
+`
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DataDog/zstd"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/ipfs/go-cid"
+	"github.com/urfave/cli/v2"
+
+	"github.com/filecoin-project/venus/venus-shared/actors"
+)
+
+const defaultReleaseURL = "https://github.com/filecoin-project/builtin-actors/releases/download"
+
+var defaultNetworks = []string{
+	"butterflynet",
+	"calibrationnet",
+	"caterpillarnet",
+	"devnet",
+	"mainnet",
+	"testing",
+	"testing-fake-proofs",
+}
+
+var fetchCmd = &cli.Command{
+	Name:  "fetch",
+	Usage: "download a builtin-actors release, pack its bundles and regenerate the embedded metadata",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "release", Usage: "git tag of the release, eg. v13.0.0", Required: true},
+		&cli.StringFlag{Name: "version", Usage: "actors version of the release, eg. v13, the major of the release by default"},
+		&cli.StringSliceFlag{Name: "networks", Usage: "networks of the bundles", Value: cli.NewStringSlice(defaultNetworks...)},
+		&cli.StringSliceFlag{Name: "manifest", Usage: "expected manifest cid of a network, network=cid"},
+		&cli.StringFlag{Name: "url", Usage: "base url of the releases", Value: defaultReleaseURL},
+		&cli.StringFlag{Name: "bundle-dir", Usage: "directory of the embedded bundles", Value: "./../venus-shared/actors/builtin-actors-code"},
+		&cli.StringFlag{Name: "dst", Usage: "file of the embedded metadata", Value: "./../venus-shared/actors/builtin_actors_gen.go"},
+	},
+	Action: func(ctx *cli.Context) error {
+		release := ctx.String("release")
+		ver := ctx.String("version")
+		if ver == "" {
+			ver = strings.SplitN(release, ".", 2)[0]
+		}
+		av, err := strconv.Atoi(strings.TrimPrefix(ver, "v"))
+		if err != nil {
+			return fmt.Errorf("invalid actors version %q: %w", ver, err)
+		}
+		version := actorstypes.Version(av)
+
+		expected := map[string]cid.Cid{}
+		for _, m := range ctx.StringSlice("manifest") {
+			network, c := splitOverride(m)
+			if expected[network], err = cid.Decode(c); err != nil {
+				return fmt.Errorf("invalid manifest cid of %s: %w", network, err)
+			}
+		}
+
+		bundles := map[string][]byte{}
+		for _, network := range ctx.StringSlice("networks") {
+			url := fmt.Sprintf("%s/%s/builtin-actors-%s.car", strings.TrimSuffix(ctx.String("url"), "/"), release, network)
+			fmt.Printf("fetching %s\n", url)
+			if bundles[network], err = download(ctx, url); err != nil {
+				return err
+			}
+		}
+
+		packed, err := packBundles(bundles)
+		if err != nil {
+			return err
+		}
+		// check the bundles are complete before replacing anything
+		fetched, err := actors.ReadBuiltinActorsMetadata(version, bytes.NewReader(packed))
+		if err != nil {
+			return err
+		}
+		for _, m := range fetched {
+			if c, ok := expected[m.Network]; ok && !c.Equals(m.ManifestCid) {
+				return fmt.Errorf("manifest of %s is %s, expected %s", m.Network, m.ManifestCid, c)
+			}
+			delete(expected, m.Network)
+			m.BundleGitTag = release
+			fmt.Printf("%s: %s\n", m.Network, m.ManifestCid)
+		}
+		if len(expected) > 0 {
+			missing := make([]string, 0, len(expected))
+			for network := range expected {
+				missing = append(missing, network)
+			}
+			sort.Strings(missing)
+			return fmt.Errorf("no bundle fetched for the expected manifests of %s", strings.Join(missing, ", "))
+		}
+
+		archive := filepath.Join(ctx.String("bundle-dir"), fmt.Sprintf("v%d.tar.zst", version))
+		if err := os.WriteFile(archive, packed, 0o644); err != nil {
+			return err
+		}
+
+		// the bundles of the other versions keep their metadata
+		metadata := fetched
+		for _, m := range actors.EmbeddedBuiltinActorsMetadata {
+			if m.Version != version {
+				metadata = append(metadata, m)
+			}
+		}
+		sort.Slice(metadata, func(i, j int) bool {
+			if metadata[i].Network == metadata[j].Network {
+				return metadata[i].Version < metadata[j].Version
+			}
+			return metadata[i].Network < metadata[j].Network
+		})
+
+		return writeMetadata(ctx.String("dst"), metadata)
+	},
+}
+
+func download(ctx *cli.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx.Context, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// packBundles makes the zstd compressed tarfile embedded for a release, with one bundle per network
+func packBundles(bundles map[string][]byte) ([]byte, error) {
+	networks := make([]string, 0, len(bundles))
+	for network := range bundles {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+
+	buf := &bytes.Buffer{}
+	compressed := zstd.NewWriterLevel(buf, 19)
+	tw := tar.NewWriter(compressed)
+	for _, network := range networks {
+		hdr := &tar.Header{
+			Name: fmt.Sprintf("builtin-actors-%s.car", network),
+			Mode: 0o644,
+			Size: int64(len(bundles[network])),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(bundles[network]); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := compressed.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
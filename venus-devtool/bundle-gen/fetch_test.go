@@ -0,0 +1,105 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DataDog/zstd"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+)
+
+// embeddedBundles reads the bundles of an embedded release, by network
+func embeddedBundles(t *testing.T, version actorstypes.Version) map[string][]byte {
+	f, err := os.Open(filepath.Join("..", "..", "venus-shared", "actors", "builtin-actors-code", fmt.Sprintf("v%d.tar.zst", version)))
+	require.NoError(t, err)
+	defer f.Close() // nolint: errcheck
+
+	uncompressed := zstd.NewReader(f)
+	defer uncompressed.Close() // nolint: errcheck
+	bundles := map[string][]byte{}
+	tr := tar.NewReader(uncompressed)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return bundles
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		bundles[strings.TrimSuffix(strings.TrimPrefix(hdr.Name, "builtin-actors-"), ".car")] = data
+	}
+}
+
+func embeddedManifest(t *testing.T, network string, version actorstypes.Version) string {
+	for _, m := range actors.EmbeddedBuiltinActorsMetadata {
+		if m.Network == network && m.Version == version {
+			return m.ManifestCid.String()
+		}
+	}
+	t.Fatalf("no embedded bundle of %s v%d", network, version)
+	return ""
+}
+
+func TestFetch(t *testing.T) {
+	tf.UnitTest(t)
+
+	bundles := embeddedBundles(t, actorstypes.Version10)
+	release := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		network := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v10.0.0/builtin-actors-"), ".car")
+		if data, ok := bundles[network]; ok {
+			_, _ = w.Write(data)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer release.Close()
+
+	dir := t.TempDir()
+	dst := filepath.Join(dir, "builtin_actors_gen.go")
+	fetch := func(args ...string) error {
+		app := &cli.App{Commands: []*cli.Command{fetchCmd}}
+		return app.RunContext(context.Background(), append([]string{"bundle-gen", "fetch", "--release", "v10.0.0",
+			"--url", release.URL, "--bundle-dir", dir, "--dst", dst, "--networks", "mainnet"}, args...))
+	}
+
+	// nothing is written when a bundle is missing or does not match its manifest
+	assert.ErrorContains(t, fetch("--networks", "unknownnet"), "404")
+	assert.ErrorContains(t, fetch("--manifest", "mainnet="+embeddedManifest(t, "calibrationnet", actorstypes.Version10)), "manifest of mainnet")
+	assert.ErrorContains(t, fetch("--manifest", "butterflynet="+embeddedManifest(t, "butterflynet", actorstypes.Version10)),
+		"no bundle fetched for the expected manifests of butterflynet")
+	_, err := os.Stat(dst)
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, fetch("--manifest", "mainnet="+embeddedManifest(t, "mainnet", actorstypes.Version10)))
+
+	packed, err := os.ReadFile(filepath.Join(dir, "v10.tar.zst"))
+	require.NoError(t, err)
+	fetched, err := actors.ReadBuiltinActorsMetadata(actorstypes.Version10, bytes.NewReader(packed))
+	require.NoError(t, err)
+	require.Len(t, fetched, 1)
+	assert.Equal(t, "mainnet", fetched[0].Network)
+	assert.Equal(t, embeddedManifest(t, "mainnet", actorstypes.Version10), fetched[0].ManifestCid.String())
+
+	// the release metadata replaces the one of the version, the other versions are kept
+	gen, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(gen), `BundleGitTag: "v10.0.0"`))
+	assert.Len(t, regexp.MustCompile(`Version: +10,`).FindAllString(string(gen), -1), 1)
+	assert.Contains(t, string(gen), embeddedManifest(t, "mainnet", actorstypes.Version13))
+}
@@ -65,18 +65,9 @@ func main() {
 				}
 			}
 
-			buf := &bytes.Buffer{}
-			if err := tmpl.Execute(buf, metadata); err != nil {
-				return err
-			}
-
-			formatted, err := util.FmtFile("", buf.Bytes())
-			if err != nil {
-				return err
-			}
-
-			return os.WriteFile(ctx.String("dst"), formatted, 0o744)
+			return writeMetadata(ctx.String("dst"), metadata)
 		},
+		Commands: []*cli.Command{fetchCmd},
 	}
 
 	app.Setup()
@@ -86,6 +77,21 @@ func main() {
 	}
 }
 
+// writeMetadata generates the file of the embedded metadata
+func writeMetadata(dst string, metadata []*actors.BuiltinActorsMetadata) error {
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, metadata); err != nil {
+		return err
+	}
+
+	formatted, err := util.FmtFile("", buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(dst, formatted, 0o744)
+}
+
 func getOldGitTagFromEmbeddedMetadata(m *actors.BuiltinActorsMetadata) string {
 	for _, v := range actors.EmbeddedBuiltinActorsMetadata {
 		// if we agree on the manifestCid for the previously embedded metadata, use the previously set tag
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+func main() {
+	app := &cli.App{
+		Name:                 "compat-matrix",
+		Usage:                "devtool generating the compatibility matrix of the chain services built on venus-shared",
+		EnableBashCompletion: true,
+		Flags:                []cli.Flag{},
+		Commands: []*cli.Command{
+			genCmd,
+			checkCmd,
+		},
+	}
+
+	app.Setup()
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "ERR: %v\n", err) // nolint: errcheck
+		os.Exit(1)
+	}
+}
+
+var matrixFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "venus",
+		Usage: "root of the venus tree",
+		Value: "..",
+	},
+	&cli.StringSliceFlag{
+		Name:     "component",
+		Usage:    "directory of the go.mod of a component, such as a checkout of sophon-messager or venus-market",
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:  "output",
+		Usage: "file the json matrix is written to, the stdout when empty",
+	},
+}
+
+var genCmd = &cli.Command{
+	Name:  "gen",
+	Usage: "write the compatibility matrix of the components with the venus tree",
+	Flags: matrixFlags,
+	Action: func(cctx *cli.Context) error {
+		_, err := writeMatrix(cctx)
+		return err
+	},
+}
+
+var checkCmd = &cli.Command{
+	Name:  "check",
+	Usage: "write the compatibility matrix and fail when a component breaks, diverges or cannot be checked",
+	Flags: matrixFlags,
+	Action: func(cctx *cli.Context) error {
+		m, err := writeMatrix(cctx)
+		if err != nil {
+			return err
+		}
+		for _, c := range m.Components {
+			for _, b := range c.Breaks {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", c.Module, b) // nolint: errcheck
+			}
+		}
+		for _, d := range m.Divergences {
+			fmt.Fprintln(os.Stderr, d) // nolint: errcheck
+		}
+		if fails := m.failures(); len(fails) > 0 {
+			return fmt.Errorf("compatibility check failed: %s", strings.Join(fails, "; "))
+		}
+		return nil
+	},
+}
+
+func writeMatrix(cctx *cli.Context) (*matrix, error) {
+	m, err := buildMatrix(cctx.String("venus"), cctx.StringSlice("component"))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(m); err != nil {
+		return nil, err
+	}
+
+	if out := cctx.String("output"); out != "" {
+		return m, os.WriteFile(out, buf.Bytes(), 0o644)
+	}
+	_, err = os.Stdout.Write(buf.Bytes())
+	return m, err
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+const (
+	statusCompatible   = "compatible"
+	statusIncompatible = "incompatible"
+	statusUnknown      = "unknown"
+)
+
+// matrix is the compatibility of the components with the venus tree, it is written as json
+type matrix struct {
+	// Venus is the build version of the tree
+	Venus string `json:"venus"`
+	// APIs are the major versions of the api packages of the tree
+	APIs       map[string]int `json:"apis"`
+	Components []*component   `json:"components"`
+	// Divergences are the shared types and methods two components see differently
+	Divergences []string `json:"divergences,omitempty"`
+}
+
+type component struct {
+	Module string `json:"module"`
+	Dir    string `json:"dir"`
+	// VenusRequire is the version of venus required by the go.mod of the component, VenusReplace the replacement
+	VenusRequire string `json:"venusRequire"`
+	VenusReplace string `json:"venusReplace,omitempty"`
+	// VenusVersion is the build version of the venus source the component is built with
+	VenusVersion string `json:"venusVersion,omitempty"`
+	// APIs are the major versions of the api packages the component imports
+	APIs map[string]int `json:"apis"`
+	// Types are the shared type packages the component imports
+	Types  []string `json:"types"`
+	Status string   `json:"status"`
+	// Breaks are the methods and types of the component the tree changed or removed
+	Breaks []string `json:"breaks,omitempty"`
+	Error  string   `json:"error,omitempty"`
+
+	snap    *snapshot
+	imports map[string]struct{}
+}
+
+// buildMatrix compares the components at dirs with the venus tree at root
+func buildMatrix(root string, dirs []string) (*matrix, error) {
+	tree, err := loadSnapshot(root)
+	if err != nil {
+		return nil, fmt.Errorf("load the venus tree: %w", err)
+	}
+	m := &matrix{Venus: tree.version, APIs: map[string]int{}}
+	for _, pkg := range sortedKeys(tree.apis) {
+		m.APIs[pkg] = tree.apis[pkg].major
+	}
+
+	for _, dir := range dirs {
+		c, err := loadComponent(dir)
+		if err != nil {
+			return nil, err
+		}
+		if c.snap != nil {
+			c.Breaks = diffSnapshots(c.snap, tree, c.uses, true)
+			c.Status = statusCompatible
+			if len(c.Breaks) > 0 {
+				c.Status = statusIncompatible
+			}
+		}
+		m.Components = append(m.Components, c)
+	}
+
+	// the components calling each other share the types of the packages both import
+	for i, a := range m.Components {
+		for _, b := range m.Components[i+1:] {
+			if a.snap == nil || b.snap == nil {
+				continue
+			}
+			both := func(pkg string) bool { return a.uses(pkg) && b.uses(pkg) }
+			for _, d := range diffSnapshots(a.snap, b.snap, both, false) {
+				m.Divergences = append(m.Divergences, fmt.Sprintf("%s <> %s: %s", a.Module, b.Module, d))
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m *matrix) failures() []string {
+	var fails []string
+	for _, c := range m.Components {
+		switch c.Status {
+		case statusIncompatible:
+			fails = append(fails, fmt.Sprintf("%s is incompatible: %d breaks", c.Module, len(c.Breaks)))
+		case statusUnknown:
+			fails = append(fails, fmt.Sprintf("%s is unknown: %s", c.Module, c.Error))
+		}
+	}
+	if n := len(m.Divergences); n > 0 {
+		fails = append(fails, fmt.Sprintf("%d shared types or methods diverge between the components", n))
+	}
+	return fails
+}
+
+// loadComponent reads the go.mod of the component at dir, and the venus source it requires from the replacement or
+// the module cache. The component is unknown when the source is missing, `go mod download` in dir fetches it.
+func loadComponent(dir string) (*component, error) {
+	file := filepath.Join(dir, "go.mod")
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	mf, err := modfile.Parse(file, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	if mf.Module == nil {
+		return nil, fmt.Errorf("%s has no module", file)
+	}
+
+	c := &component{Module: mf.Module.Mod.Path, Dir: dir, APIs: map[string]int{}, Types: []string{}, Status: statusUnknown}
+	if c.imports, err = sharedImports(dir); err != nil {
+		return nil, err
+	}
+	for _, pkg := range sortedKeys(c.imports) {
+		if strings.HasPrefix(pkg, "types") {
+			c.Types = append(c.Types, pkg)
+		}
+	}
+
+	var src string
+	for _, req := range mf.Require {
+		if req.Mod.Path == venusModule {
+			c.VenusRequire = req.Mod.Version
+		}
+	}
+	if c.VenusRequire == "" {
+		c.Error = fmt.Sprintf("%s does not require %s", file, venusModule)
+		return c, nil
+	}
+	for _, rep := range mf.Replace {
+		if rep.Old.Path != venusModule || (rep.Old.Version != "" && rep.Old.Version != c.VenusRequire) {
+			continue
+		}
+		if rep.New.Version == "" {
+			c.VenusReplace = rep.New.Path
+			src = rep.New.Path
+			if !filepath.IsAbs(src) {
+				src = filepath.Join(dir, src)
+			}
+		} else {
+			c.VenusReplace = rep.New.Path + "@" + rep.New.Version
+			if src, err = moduleDir(rep.New.Path, rep.New.Version); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if src == "" {
+		if src, err = moduleDir(venusModule, c.VenusRequire); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.snap, err = loadSnapshot(src); err != nil {
+		c.Error = fmt.Sprintf("load the venus source %s: %v", src, err)
+		return c, nil
+	}
+	c.VenusVersion = c.snap.version
+	for pkg := range c.imports {
+		if api, ok := c.snap.apis[pkg]; ok {
+			c.APIs[pkg] = api.major
+		}
+	}
+	return c, nil
+}
+
+// uses tells whether the component depends on the shared package pkg. The api packages carry the shared types, a
+// component importing any of them depends on all the types.
+func (c *component) uses(pkg string) bool {
+	if _, ok := c.imports[pkg]; ok {
+		return true
+	}
+	if strings.HasPrefix(pkg, "types") {
+		for imp := range c.imports {
+			if strings.HasPrefix(imp, "api/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func moduleDir(path, version string) (string, error) {
+	cache := os.Getenv("GOMODCACHE")
+	if cache == "" {
+		out, err := exec.Command("go", "env", "GOMODCACHE").Output()
+		if err != nil {
+			return "", fmt.Errorf("find the module cache: %w", err)
+		}
+		cache = strings.TrimSpace(string(out))
+	}
+	escaped, err := module.EscapePath(path)
+	if err != nil {
+		return "", err
+	}
+	escapedVer, err := module.EscapeVersion(version)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cache, escaped+"@"+escapedVer), nil
+}
+
+// diffSnapshots lists the methods and types of old that cur changed, and the ones it removed with removals, in the
+// packages used
+func diffSnapshots(old, cur *snapshot, used func(pkg string) bool, removals bool) []string {
+	var diffs []string
+	for _, pkg := range sortedKeys(old.apis) {
+		if !used(pkg) {
+			continue
+		}
+		oldAPI := old.apis[pkg]
+		curAPI, ok := cur.apis[pkg]
+		if !ok {
+			if removals {
+				diffs = append(diffs, fmt.Sprintf("%s: the api was removed", pkg))
+			}
+			continue
+		}
+		if oldAPI.major != curAPI.major {
+			diffs = append(diffs, fmt.Sprintf("%s: major version %d became %d", pkg, oldAPI.major, curAPI.major))
+		}
+		for _, meth := range sortedKeys(oldAPI.methods) {
+			sig, ok := curAPI.methods[meth]
+			switch {
+			case !ok && removals:
+				diffs = append(diffs, fmt.Sprintf("%s: %s was removed", pkg, meth))
+			case ok && sig != oldAPI.methods[meth]:
+				diffs = append(diffs, fmt.Sprintf("%s: %s changed from %s to %s", pkg, meth, oldAPI.methods[meth], sig))
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(old.types) {
+		if !used(name[:strings.LastIndex(name, ".")]) {
+			continue
+		}
+		oldType := old.types[name]
+		curType, ok := cur.types[name]
+		if !ok {
+			if removals {
+				diffs = append(diffs, fmt.Sprintf("%s was removed", name))
+			}
+			continue
+		}
+		_, oldPlain := oldType[""]
+		_, curPlain := curType[""]
+		if oldPlain != curPlain {
+			diffs = append(diffs, fmt.Sprintf("%s changed from %s to %s", name, kindOf(oldType), kindOf(curType)))
+			continue
+		}
+		// the fields added are left out of the json of the older components, which is compatible
+		for _, field := range sortedKeys(oldType) {
+			typ, ok := curType[field]
+			label := name
+			if field != "" {
+				label += "." + field
+			}
+			switch {
+			case !ok && removals:
+				diffs = append(diffs, fmt.Sprintf("%s was removed", label))
+			case ok && typ != oldType[field]:
+				diffs = append(diffs, fmt.Sprintf("%s changed from %s to %s", label, oldType[field], typ))
+			}
+		}
+	}
+	return diffs
+}
+
+func kindOf(ts typeSnapshot) string {
+	if expr, ok := ts[""]; ok {
+		return expr
+	}
+	return "a struct"
+}
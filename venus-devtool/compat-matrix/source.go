@@ -0,0 +1,311 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	venusModule = "github.com/filecoin-project/venus"
+	sharedDir   = "venus-shared"
+)
+
+// snapshot is what a venus source tree exposes to the other components: the major versions of the api packages,
+// the methods of their interfaces and the shared types. The packages are keyed by their path below venus-shared,
+// such as api/chain/v1 or types/market.
+type snapshot struct {
+	version string
+	apis    map[string]*apiSnapshot
+	// types are keyed by the package and the type name, such as types.Message
+	types map[string]typeSnapshot
+}
+
+type apiSnapshot struct {
+	major int
+	// methods map the interface and the method names to the signature, without the param names
+	methods map[string]string
+}
+
+// typeSnapshot maps the fields of a struct to their types and tags, the other types have a single entry with an
+// empty key
+type typeSnapshot map[string]string
+
+// loadSnapshot parses the venus source tree at root, it does not need to build
+func loadSnapshot(root string) (*snapshot, error) {
+	snap := &snapshot{
+		apis:  map[string]*apiSnapshot{},
+		types: map[string]typeSnapshot{},
+	}
+
+	// the trees of the old venus versions may keep their version elsewhere
+	version, err := buildVersion(filepath.Join(root, "pkg", "constants", "version.go"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	snap.version = version
+
+	err = walkPackages(filepath.Join(root, sharedDir, "api"), func(rel string, files []*ast.File) error {
+		major, ok := majorVersion(files)
+		if !ok {
+			// not an api package, such as the permissions
+			return nil
+		}
+		api := &apiSnapshot{major: major, methods: map[string]string{}}
+		forEachType(files, func(spec *ast.TypeSpec) {
+			iface, ok := spec.Type.(*ast.InterfaceType)
+			if !ok {
+				return
+			}
+			for _, m := range iface.Methods.List {
+				ft, ok := m.Type.(*ast.FuncType)
+				if !ok {
+					// embedded interfaces are listed with their own methods
+					continue
+				}
+				for _, name := range m.Names {
+					api.methods[spec.Name.Name+"."+name.Name] = exprString(ft)
+				}
+			}
+		})
+		snap.apis["api/"+rel] = api
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = walkPackages(filepath.Join(root, sharedDir, "types"), func(rel string, files []*ast.File) error {
+		pkg := "types"
+		if rel != "." {
+			pkg += "/" + rel
+		}
+		forEachType(files, func(spec *ast.TypeSpec) {
+			snap.types[pkg+"."+spec.Name.Name] = typeOf(spec)
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// walkPackages calls fn with the parsed non test files of every package below root, rel is the path of the package
+// relative to root
+func walkPackages(root string, fn func(rel string, files []*ast.File) error) error {
+	if _, err := os.Stat(root); err != nil {
+		return err
+	}
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if name := d.Name(); path != root && (name == "testdata" || name == "mock" || strings.HasPrefix(name, ".")) {
+			return filepath.SkipDir
+		}
+
+		pkgs, err := parser.ParseDir(token.NewFileSet(), path, func(fi fs.FileInfo) bool {
+			return !strings.HasSuffix(fi.Name(), "_test.go")
+		}, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+		var files []*ast.File
+		for _, pkg := range pkgs {
+			for _, f := range pkg.Files {
+				files = append(files, f)
+			}
+		}
+		if len(files) == 0 {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		return fn(filepath.ToSlash(rel), files)
+	})
+}
+
+func forEachType(files []*ast.File, fn func(spec *ast.TypeSpec)) {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				if ts := spec.(*ast.TypeSpec); ts.Name.IsExported() {
+					fn(ts)
+				}
+			}
+		}
+	}
+}
+
+// majorVersion finds the MajorVersion constant the clients of the api package dial
+func majorVersion(files []*ast.File) (int, bool) {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if name.Name != "MajorVersion" || i >= len(vs.Values) {
+						continue
+					}
+					if lit, ok := vs.Values[i].(*ast.BasicLit); ok && lit.Kind == token.INT {
+						if v, err := strconv.Atoi(lit.Value); err == nil {
+							return v, true
+						}
+					}
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+func buildVersion(file string) (string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return "", err
+	}
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if name.Name != "BuildVersion" || i >= len(vs.Values) {
+					continue
+				}
+				if lit, ok := vs.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					return strconv.Unquote(lit.Value)
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("no BuildVersion in %s", file)
+}
+
+func typeOf(spec *ast.TypeSpec) typeSnapshot {
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		expr := exprString(spec.Type)
+		if spec.Assign.IsValid() {
+			expr = "= " + expr
+		}
+		return typeSnapshot{"": expr}
+	}
+
+	ts := typeSnapshot{}
+	for _, field := range st.Fields.List {
+		typ := exprString(field.Type)
+		if field.Tag != nil {
+			typ += " " + field.Tag.Value
+		}
+		if len(field.Names) == 0 {
+			// the embedded fields are named after their type
+			ts[strings.TrimPrefix(exprString(field.Type), "*")] = typ
+			continue
+		}
+		for _, name := range field.Names {
+			if name.IsExported() {
+				ts[name.Name] = typ
+			}
+		}
+	}
+	return ts
+}
+
+// exprString prints a type expression, the param names of the func types are dropped as they do not change the
+// signature
+func exprString(expr ast.Expr) string {
+	return types.ExprString(unnamed(expr))
+}
+
+func unnamed(expr ast.Expr) ast.Expr {
+	ft, ok := expr.(*ast.FuncType)
+	if !ok {
+		return expr
+	}
+	return &ast.FuncType{Params: unnamedFields(ft.Params), Results: unnamedFields(ft.Results)}
+}
+
+func unnamedFields(fl *ast.FieldList) *ast.FieldList {
+	if fl == nil {
+		return nil
+	}
+	out := &ast.FieldList{}
+	for _, field := range fl.List {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			out.List = append(out.List, &ast.Field{Type: unnamed(field.Type)})
+		}
+	}
+	return out
+}
+
+// sharedImports lists the venus-shared packages imported by the go files of the component at dir, keyed like the
+// snapshots
+func sharedImports(dir string) (map[string]struct{}, error) {
+	prefix := venusModule + "/" + sharedDir + "/"
+	imports := map[string]struct{}{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != dir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+		for _, imp := range f.Imports {
+			p, err := strconv.Unquote(imp.Path.Value)
+			if err == nil && strings.HasPrefix(p, prefix) {
+				imports[strings.TrimPrefix(p, prefix)] = struct{}{}
+			}
+		}
+		return nil
+	})
+	return imports, err
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
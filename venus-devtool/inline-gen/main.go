@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"go/format"
 	"log"
 	"os"
 	"path/filepath"
@@ -39,6 +40,9 @@ func processFile(path string, info os.FileInfo, err error) error {
 		return err
 	}
 	if info.IsDir() {
+		if name := info.Name(); name != "." && name != ".." && strings.HasPrefix(name, ".") {
+			return filepath.SkipDir
+		}
 		return nil
 	}
 	if filepath.Ext(path) != ".go" {
@@ -52,50 +56,37 @@ func processFile(path string, info os.FileInfo, err error) error {
 
 	lines := strings.Split(string(fileBytes), "\n")
 
-	outLines, templateLines, err := processLines(lines)
+	outLines, found, err := processLines(lines)
 	if err != nil {
 		log.Printf("Error processing file %s: %v", path, err)
 		return nil
 	}
+	if !found {
+		return nil
+	}
 
-	if len(templateLines) > 0 {
-		tpl, err := template.New("").Funcs(template.FuncMap{
-			"import": func(v float64) string {
-				if v == 0 {
-					return "/"
-				}
-				return fmt.Sprintf("/v%d/", int(v))
-			},
-			"add": func(a, b float64) float64 {
-				return a + b
-			},
-		}).Parse(strings.Join(templateLines, "\n"))
-		if err != nil {
-			return fmt.Errorf("parsing template: %v", err)
-		}
-
-		var b bytes.Buffer
-		err = tpl.Execute(&b, data)
-		if err != nil {
-			return fmt.Errorf("executing template: %v", err)
-		}
-
-		outLines = append(outLines, strings.Split(b.String(), "\n")...)
+	out, err := format.Source([]byte(strings.Join(outLines, "\n")))
+	if err != nil {
+		return fmt.Errorf("formatting %s: %v", path, err)
 	}
 
-	if len(outLines) != len(lines) {
-		err = os.WriteFile(path, []byte(strings.Join(outLines, "\n")), 0)
+	if !bytes.Equal(out, fileBytes) {
+		err = os.WriteFile(path, out, info.Mode())
 		if err != nil {
 			return fmt.Errorf("writing file: %v", err)
 		}
+		log.Printf("updated %s", path)
 	}
 	return nil
 }
 
-func processLines(lines []string) ([]string, []string, error) {
+// processLines regenerates the content between every `/* inline-gen start */` and `/* inline-gen end */` pair
+// from the template above it, the template itself lives in the comment opened by `/* inline-gen template`
+func processLines(lines []string) ([]string, bool, error) {
 	outLines := make([]string, 0, len(lines))
-	templateLines := make([]string, 0)
+	var templateLines []string
 	state := stateGlobal
+	found := false
 
 	for _, line := range lines {
 		switch state {
@@ -103,6 +94,7 @@ func processLines(lines []string) ([]string, []string, error) {
 			outLines = append(outLines, line)
 			if strings.TrimSpace(line) == `/* inline-gen template` {
 				state = stateTemplate
+				templateLines = templateLines[:0]
 			}
 		case stateTemplate:
 			outLines = append(outLines, line)
@@ -117,11 +109,43 @@ func processLines(lines []string) ([]string, []string, error) {
 			}
 			state = stateGlobal
 			templateLines = append(templateLines, line)
+
+			generated, err := execTemplate(templateLines)
+			if err != nil {
+				return nil, false, err
+			}
+			outLines = append(outLines, generated...)
+			found = true
 		}
 	}
 	if state != stateGlobal {
-		return nil, nil, fmt.Errorf("unexpected end of file while in state %d", state)
+		return nil, false, fmt.Errorf("unexpected end of file while in state %d", state)
+	}
+
+	return outLines, found, nil
+}
+
+func execTemplate(lines []string) ([]string, error) {
+	tpl, err := template.New("").Funcs(template.FuncMap{
+		"import": func(v float64) string {
+			if v == 0 {
+				return "/"
+			}
+			return fmt.Sprintf("/v%d/", int(v))
+		},
+		"add": func(a, b float64) float64 {
+			return a + b
+		},
+	}).Parse(strings.Join(lines, "\n"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %v", err)
+	}
+
+	var b bytes.Buffer
+	err = tpl.Execute(&b, data)
+	if err != nil {
+		return nil, fmt.Errorf("executing template: %v", err)
 	}
 
-	return outLines, templateLines, nil
+	return strings.Split(b.String(), "\n"), nil
 }
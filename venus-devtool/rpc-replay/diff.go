@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type fieldDiff struct {
+	path     string
+	recorded interface{}
+	replayed interface{}
+}
+
+func (d fieldDiff) String() string {
+	return fmt.Sprintf("%s: recorded %s, replayed %s", d.path, formatValue(d.recorded), formatValue(d.replayed))
+}
+
+var indexRe = regexp.MustCompile(`\[\d+\]`)
+
+// ignoreSet matches the paths of the fields to skip, [*] matches any array index
+type ignoreSet map[string]struct{}
+
+func newIgnoreSet(paths []string) ignoreSet {
+	set := make(ignoreSet, len(paths))
+	for _, p := range paths {
+		set[strings.TrimSpace(p)] = struct{}{}
+	}
+	return set
+}
+
+func (s ignoreSet) has(path string) bool {
+	if _, ok := s[path]; ok {
+		return true
+	}
+	_, ok := s[indexRe.ReplaceAllString(path, "[*]")]
+	return ok
+}
+
+// diffValues compares two decoded json values field by field and appends the differences to diffs
+func diffValues(path string, recorded, replayed interface{}, ignore ignoreSet, diffs []fieldDiff) []fieldDiff {
+	if ignore.has(path) {
+		return diffs
+	}
+
+	switch rec := recorded.(type) {
+	case map[string]interface{}:
+		rep, ok := replayed.(map[string]interface{})
+		if !ok {
+			return append(diffs, fieldDiff{path: path, recorded: recorded, replayed: replayed})
+		}
+
+		keys := make([]string, 0, len(rec)+len(rep))
+		for k := range rec {
+			keys = append(keys, k)
+		}
+		for k := range rep {
+			if _, ok := rec[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			sub := k
+			if len(path) > 0 {
+				sub = path + "." + k
+			}
+			rv, inRec := rec[k]
+			pv, inRep := rep[k]
+			switch {
+			case !inRec:
+				if !ignore.has(sub) {
+					diffs = append(diffs, fieldDiff{path: sub, recorded: missing{}, replayed: pv})
+				}
+			case !inRep:
+				if !ignore.has(sub) {
+					diffs = append(diffs, fieldDiff{path: sub, recorded: rv, replayed: missing{}})
+				}
+			default:
+				diffs = diffValues(sub, rv, pv, ignore, diffs)
+			}
+		}
+		return diffs
+
+	case []interface{}:
+		rep, ok := replayed.([]interface{})
+		if !ok || len(rep) != len(rec) {
+			return append(diffs, fieldDiff{path: path, recorded: recorded, replayed: replayed})
+		}
+		for i := range rec {
+			diffs = diffValues(fmt.Sprintf("%s[%d]", path, i), rec[i], rep[i], ignore, diffs)
+		}
+		return diffs
+	}
+
+	if !reflect.DeepEqual(recorded, replayed) {
+		diffs = append(diffs, fieldDiff{path: path, recorded: recorded, replayed: replayed})
+	}
+	return diffs
+}
+
+// missing marks a field present on one side only
+type missing struct{}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case missing:
+		return "<missing>"
+	case map[string]interface{}:
+		return fmt.Sprintf("object of %d fields", len(v))
+	case []interface{}:
+		return fmt.Sprintf("array of %d items", len(v))
+	case string:
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+)
+
+func main() {
+	app := &cli.App{
+		Name:                 "rpc-replay",
+		Usage:                "devtool recording the json-rpc traffic of a node and replaying it against another build to catch api regressions",
+		EnableBashCompletion: true,
+		Flags:                []cli.Flag{},
+		Commands: []*cli.Command{
+			recordCmd,
+			replayCmd,
+		},
+	}
+
+	app.Setup()
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "ERR: %v\n", err) // nolint: errcheck
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/filecoin-project/venus/venus-shared/api"
+	v0 "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
+	v1 "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+)
+
+// methodPerms are the permissions of the methods of the chain apis, by json-rpc name
+var methodPerms = loadMethodPerms(&v0.FullNodeStruct{}, &v1.FullNodeStruct{})
+
+func loadMethodPerms(structs ...interface{}) map[string]string {
+	perms := make(map[string]string)
+	for _, s := range structs {
+		for _, internal := range api.GetInternalStructs(s) {
+			rt := reflect.TypeOf(internal).Elem()
+			for i := 0; i < rt.NumField(); i++ {
+				field := rt.Field(i)
+				name := "Filecoin." + field.Name
+				if alias := field.Tag.Get("rpc_method"); alias != "" {
+					name = alias
+				}
+				perms[name] = field.Tag.Get("perm")
+			}
+		}
+	}
+	return perms
+}
+
+// sideEffects are the methods needing only the read permission which change the state of the node
+var sideEffects = map[string]struct{}{
+	"Filecoin.EthSendRawTransaction":          {},
+	"Filecoin.EthNewFilter":                   {},
+	"Filecoin.EthNewBlockFilter":              {},
+	"Filecoin.EthNewPendingTransactionFilter": {},
+	"Filecoin.EthUninstallFilter":             {},
+	"Filecoin.EthSubscribe":                   {},
+	"Filecoin.EthUnsubscribe":                 {},
+}
+
+// rpcName returns the name of the method an alias like eth_getBalance is served by, eg. Filecoin.EthGetBalance
+func rpcName(method string) string {
+	if _, ok := methodPerms[method]; ok || strings.HasPrefix(method, "Filecoin.") {
+		return method
+	}
+	prefix, name, ok := strings.Cut(method, "_")
+	if !ok || len(prefix) == 0 || len(name) == 0 {
+		return method
+	}
+	return "Filecoin." + strings.ToUpper(prefix[:1]) + prefix[1:] + strings.ToUpper(name[:1]) + name[1:]
+}
+
+// isReadMethod tells whether the method only needs the read permission and leaves the node as is, the unknown
+// methods do not
+func isReadMethod(method string) bool {
+	name := rpcName(method)
+	if _, ok := sideEffects[name]; ok {
+		return false
+	}
+	return methodPerms[name] == "read"
+}
+
+// requestMethods returns the methods of a request, or of the requests of a batch
+func requestMethods(req json.RawMessage) []string {
+	var single struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(req, &single); err == nil {
+		return []string{single.Method}
+	}
+
+	var batch []struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(req, &batch); err != nil {
+		return nil
+	}
+	methods := make([]string, len(batch))
+	for i, r := range batch {
+		methods[i] = r.Method
+	}
+	return methods
+}
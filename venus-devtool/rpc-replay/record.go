@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// record is a json-rpc exchange, stored one per line
+type record struct {
+	Time     time.Time
+	Request  json.RawMessage
+	Response json.RawMessage
+}
+
+var recordCmd = &cli.Command{
+	Name:  "record",
+	Usage: "proxy the http json-rpc requests to a node and record them with their responses",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "listen",
+			Value: "127.0.0.1:3454",
+			Usage: "address the proxy listens on",
+		},
+		&cli.StringFlag{
+			Name:     "target",
+			Usage:    "rpc endpoint of the node, eg. http://127.0.0.1:3453/rpc/v1",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "token",
+			Usage: "token sent to the node instead of the one of the clients",
+		},
+		&cli.StringFlag{
+			Name:  "out",
+			Value: "rpc-traffic.jsonl",
+			Usage: "file the exchanges are appended to",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		out, err := os.OpenFile(cctx.String("out"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		defer out.Close() // nolint: errcheck
+
+		rec := &recorder{
+			target: cctx.String("target"),
+			token:  cctx.String("token"),
+			enc:    json.NewEncoder(out),
+		}
+
+		log.Printf("recording the requests to %s on %s", rec.target, cctx.String("listen"))
+		return http.ListenAndServe(cctx.String("listen"), rec)
+	},
+}
+
+type recorder struct {
+	target string
+	token  string
+
+	lk  sync.Mutex
+	enc *json.Encoder
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only http json-rpc requests are recorded, websockets are not supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	reqBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	token := rec.token
+	if len(token) == 0 {
+		token = r.Header.Get("Authorization")
+	}
+	status, respBody, err := post(r.Context(), rec.target, token, reqBody)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(respBody)
+
+	if !json.Valid(reqBody) || !json.Valid(respBody) {
+		log.Printf("skip recording a non json exchange, status %d", status)
+		return
+	}
+
+	rec.lk.Lock()
+	defer rec.lk.Unlock()
+	if err := rec.enc.Encode(&record{Time: time.Now(), Request: reqBody, Response: respBody}); err != nil {
+		log.Printf("recording exchange: %v", err)
+	}
+}
+
+// post sends a json-rpc request, token is sent as the Authorization header with or without its Bearer prefix
+func post(ctx context.Context, target, token string, body []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(token) > 0 {
+		if !strings.HasPrefix(token, "Bearer ") {
+			token = "Bearer " + token
+		}
+		req.Header.Set("Authorization", token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("post to %s: %w", target, err)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("read response of %s: %w", target, err)
+	}
+	return resp.StatusCode, respBody, nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+var replayCmd = &cli.Command{
+	Name:  "replay",
+	Usage: "send the recorded requests of the read methods to a node and diff its responses with the recorded ones",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "target",
+			Usage:    "rpc endpoint of the node under test, eg. http://127.0.0.1:3453/rpc/v1",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "token",
+			Usage: "token sent to the node",
+		},
+		&cli.StringFlag{
+			Name:  "in",
+			Value: "rpc-traffic.jsonl",
+			Usage: "file of the recorded exchanges",
+		},
+		&cli.StringSliceFlag{
+			Name:  "ignore",
+			Usage: "path of a response field not compared, [*] matches any index, eg. result.Blocks[*].Timestamp",
+		},
+		&cli.StringSliceFlag{
+			Name:  "skip-method",
+			Usage: "method whose requests are not replayed, eg. Filecoin.ChainHead",
+		},
+		&cli.BoolFlag{
+			Name:  "all-methods",
+			Usage: "also replay the methods needing the write, sign or admin permission, they may change the state of the node or spend funds",
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		in, err := os.Open(cctx.String("in"))
+		if err != nil {
+			return err
+		}
+		defer in.Close() // nolint: errcheck
+
+		r := &replayer{
+			target:     cctx.String("target"),
+			token:      cctx.String("token"),
+			ignore:     newIgnoreSet(append(cctx.StringSlice("ignore"), "id", "[*].id")),
+			skip:       make(map[string]struct{}),
+			allMethods: cctx.Bool("all-methods"),
+		}
+		for _, m := range cctx.StringSlice("skip-method") {
+			r.skip[m] = struct{}{}
+		}
+
+		stats, err := r.replay(cctx.Context, in, os.Stdout)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("replayed %d requests, %d skipped, %d with different responses\n", stats.replayed, stats.skipped, stats.failed)
+		if stats.failed > 0 {
+			return fmt.Errorf("%d responses differ", stats.failed)
+		}
+		return nil
+	},
+}
+
+type replayer struct {
+	target string
+	token  string
+	ignore ignoreSet
+	skip   map[string]struct{}
+	// allMethods replays the requests of the methods needing more than the read permission too
+	allMethods bool
+}
+
+type replayStats struct {
+	replayed, skipped, failed int
+}
+
+// replay sends the requests recorded in in to the target and prints the differences of the responses to out
+func (r *replayer) replay(ctx context.Context, in io.Reader, out io.Writer) (replayStats, error) {
+	var stats replayStats
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 1<<20), 1<<30)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return stats, fmt.Errorf("decode record at line %d: %w", line, err)
+		}
+		methods := requestMethods(rec.Request)
+		if !r.replayable(methods) {
+			stats.skipped++
+			continue
+		}
+		method := "unknown"
+		if len(methods) > 0 {
+			method = strings.Join(methods, ", ")
+		}
+
+		_, respBody, err := post(ctx, r.target, r.token, rec.Request)
+		if err != nil {
+			return stats, err
+		}
+		stats.replayed++
+
+		diffs, err := diffResponses(rec.Response, respBody, r.ignore)
+		if err != nil {
+			return stats, fmt.Errorf("line %d %s: %w", line, method, err)
+		}
+		if len(diffs) == 0 {
+			continue
+		}
+
+		stats.failed++
+		fmt.Fprintf(out, "line %d %s:\n", line, method) // nolint: errcheck
+		for _, d := range diffs {
+			fmt.Fprintf(out, "\t%s\n", d) // nolint: errcheck
+		}
+	}
+	return stats, scanner.Err()
+}
+
+// replayable tells whether the requests of methods are replayed, a batch is replayed only if all its requests are
+func (r *replayer) replayable(methods []string) bool {
+	if len(methods) == 0 {
+		return false
+	}
+	for _, m := range methods {
+		if _, ok := r.skip[m]; ok {
+			return false
+		}
+		if !r.allMethods && !isReadMethod(m) {
+			return false
+		}
+	}
+	return true
+}
+
+func diffResponses(recorded, replayed []byte, ignore ignoreSet) ([]fieldDiff, error) {
+	var rec, rep interface{}
+	if err := json.Unmarshal(recorded, &rec); err != nil {
+		return nil, fmt.Errorf("decode recorded response: %w", err)
+	}
+	if err := json.Unmarshal(replayed, &rep); err != nil {
+		return []fieldDiff{{path: "", recorded: rec, replayed: string(replayed)}}, nil
+	}
+	return diffValues("", rec, rep, ignore, nil), nil
+}
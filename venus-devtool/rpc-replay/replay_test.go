@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestReadMethods(t *testing.T) {
+	tf.UnitTest(t)
+
+	for method, read := range map[string]bool{
+		"Filecoin.ChainHead":           true,
+		"Filecoin.StateGetActor":       true,
+		"Filecoin.MpoolPush":           false,
+		"Filecoin.WalletSign":          false,
+		"Filecoin.AuthNew":             false,
+		"Filecoin.Unknown":             false,
+		"eth_blockNumber":              true,
+		"eth_getBalance":               true,
+		"eth_sendRawTransaction":       false,
+		"net_version":                  true,
+		"web3_clientVersion":           true,
+		"eth_newFilter":                false,
+		"eth_subscribe":                false,
+		"eth_unknownMethod":            false,
+		"ChainHead":                    false,
+		"Filecoin.ChainGetTipSetAfter": false,
+	} {
+		assert.Equal(t, read, isReadMethod(method), method)
+	}
+
+	assert.Equal(t, []string{"Filecoin.ChainHead"}, requestMethods(json.RawMessage(`{"method":"Filecoin.ChainHead","id":1}`)))
+	assert.Equal(t, []string{"Filecoin.ChainHead", "Filecoin.MpoolPush"},
+		requestMethods(json.RawMessage(`[{"method":"Filecoin.ChainHead"},{"method":"Filecoin.MpoolPush"}]`)))
+	assert.Empty(t, requestMethods(json.RawMessage(`"invalid"`)))
+
+	r := &replayer{skip: map[string]struct{}{"Filecoin.ChainHead": {}}}
+	assert.True(t, r.replayable([]string{"Filecoin.StateGetActor"}))
+	assert.False(t, r.replayable([]string{"Filecoin.ChainHead"}))
+	// a batch is replayed only if all its methods are
+	assert.False(t, r.replayable([]string{"Filecoin.StateGetActor", "Filecoin.MpoolPush"}))
+	assert.False(t, r.replayable(nil))
+	r.allMethods = true
+	assert.True(t, r.replayable([]string{"Filecoin.StateGetActor", "Filecoin.MpoolPush"}))
+}
+
+func TestDiffResponses(t *testing.T) {
+	tf.UnitTest(t)
+
+	recorded := []byte(`{"id":1,"result":{"Height":10,"Blocks":[{"Timestamp":1,"Miner":"f01000"}],"Old":true}}`)
+	replayed := []byte(`{"id":2,"result":{"Height":11,"Blocks":[{"Timestamp":2,"Miner":"f01000"}],"New":1}}`)
+
+	diffs, err := diffResponses(recorded, replayed, newIgnoreSet([]string{"id", "result.Blocks[*].Timestamp"}))
+	require.NoError(t, err)
+	var paths []string
+	for _, d := range diffs {
+		paths = append(paths, d.path)
+	}
+	assert.Equal(t, []string{"result.Height", "result.New", "result.Old"}, paths)
+	assert.Equal(t, "result.New: recorded <missing>, replayed 1", diffs[1].String())
+
+	diffs, err = diffResponses(recorded, []byte("not json"), newIgnoreSet(nil))
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "", diffs[0].path)
+
+	_, err = diffResponses([]byte("not json"), replayed, newIgnoreSet(nil))
+	assert.Error(t, err)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	height := 10
+	var received []string
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		var req struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		received = append(received, req.Method)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": req.ID, "result": map[string]int{"Height": height}})
+	}))
+	defer node.Close()
+
+	out := &bytes.Buffer{}
+	proxy := httptest.NewServer(&recorder{target: node.URL, token: "secret", enc: json.NewEncoder(out)})
+	defer proxy.Close()
+	for i, method := range []string{"Filecoin.ChainHead", "Filecoin.MpoolPush"} {
+		body := `{"jsonrpc":"2.0","id":` + strconv.Itoa(i+1) + `,"method":"` + method + `","params":[]}`
+		resp, err := http.Post(proxy.URL, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		require.NoError(t, resp.Body.Close())
+	}
+	assert.Equal(t, 2, strings.Count(out.String(), "\n"))
+
+	// the write method is not sent again, the read one differs by its height
+	height = 11
+	received = nil
+	diffs := &bytes.Buffer{}
+	r := &replayer{target: node.URL, token: "secret", ignore: newIgnoreSet([]string{"id"}), skip: map[string]struct{}{}}
+	stats, err := r.replay(ctx, bytes.NewReader(out.Bytes()), diffs)
+	require.NoError(t, err)
+	assert.Equal(t, replayStats{replayed: 1, skipped: 1, failed: 1}, stats)
+	assert.Equal(t, []string{"Filecoin.ChainHead"}, received)
+	assert.Equal(t, "line 1 Filecoin.ChainHead:\n\tresult.Height: recorded 10, replayed 11\n", diffs.String())
+
+	// both are sent once opted in
+	height = 10
+	received = nil
+	r.allMethods = true
+	stats, err = r.replay(ctx, bytes.NewReader(out.Bytes()), io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, replayStats{replayed: 2}, stats)
+	assert.Equal(t, []string{"Filecoin.ChainHead", "Filecoin.MpoolPush"}, received)
+}
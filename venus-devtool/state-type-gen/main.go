@@ -43,6 +43,8 @@ type meta struct {
 	basePath    string
 	includePkgs []string
 	skipFiles   []string
+	// methodsFile is where the method tables of the packages are generated from their methods.go, if set
+	methodsFile string
 
 	output outputOpt
 }
@@ -70,6 +72,7 @@ var metas = []meta{
 		basePath:    "github.com/filecoin-project/go-state-types/builtin",
 		includePkgs: getStateTypesIncludePkgs(),
 		skipFiles:   []string{"invariants.go", "methods.go"},
+		methodsFile: "state_methods_gen.go",
 		output: outputOpt{
 			typ:      0,
 			fileName: "state_types_gen.go",
@@ -179,6 +182,12 @@ func run(cctx *cli.Context) error {
 		if err := outputFile(cctx.String("dst"), m.output, pkgInfos, m.opt); err != nil {
 			return err
 		}
+
+		if len(m.methodsFile) > 0 {
+			if err := writeMethods(filepath.Join(cctx.String("dst"), m.methodsFile), m.basePath, m.includePkgs); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/filecoin-project/venus/venus-devtool/util"
+)
+
+const (
+	abiPath     = "github.com/filecoin-project/go-state-types/abi"
+	reflectPath = "reflect"
+)
+
+// methodInfo is an entry of the `Methods` table of an actor package, the expressions are qualified to be used
+// out of the package
+type methodInfo struct {
+	num    string
+	name   string
+	params string
+	ret    string
+}
+
+type actorMethods struct {
+	pkgName string
+	methods []methodInfo
+}
+
+// importSet is the imports of the generated file, by package name
+type importSet map[string]string
+
+func (is importSet) add(name, path string) error {
+	if prev, ok := is[name]; ok && prev != path {
+		return fmt.Errorf("package name %s is used by both %s and %s", name, prev, path)
+	}
+	is[name] = path
+	return nil
+}
+
+// writeMethods generates the method tables of the packages from the `Methods` var of their methods.go
+func writeMethods(dst string, basePath string, pkgs []string) error {
+	imports := importSet{}
+	if err := imports.add("abi", abiPath); err != nil {
+		return err
+	}
+	if err := imports.add("reflect", reflectPath); err != nil {
+		return err
+	}
+
+	actors := make([]*actorMethods, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		am, err := parseMethods(filepath.Join(basePath, pkg), imports)
+		if err != nil {
+			return fmt.Errorf("parse methods of %s: %w", pkg, err)
+		}
+		actors = append(actors, am)
+	}
+	sort.Slice(actors, func(i, j int) bool {
+		return actors[i].pkgName < actors[j].pkgName
+	})
+
+	var fileBuffer bytes.Buffer
+	fmt.Fprintf(&fileBuffer, "// Code generated by github.com/filecoin-project/venus/venus-devtool/state-type-gen. DO NOT EDIT.\npackage %s\n\n", "types")
+
+	names := make([]string, 0, len(imports))
+	for name := range imports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(&fileBuffer, "import (")
+	for _, name := range names {
+		// only the packages imported under another name than theirs need an alias
+		if pkg := util.FindPackage(imports[name]); pkg.Err == nil && pkg.Name == name {
+			fmt.Fprintf(&fileBuffer, "\"%v\"\n", imports[name])
+			continue
+		}
+		fmt.Fprintf(&fileBuffer, "%s \"%v\"\n", name, imports[name])
+	}
+	fmt.Fprint(&fileBuffer, ")\n\n")
+
+	for _, am := range actors {
+		fmt.Fprintf(&fileBuffer, "////////// %s //////////\n\n", am.pkgName)
+		fmt.Fprintf(&fileBuffer, "var %sMethods = map[abi.MethodNum]ActorMethod{\n", methodsVarPrefix(am.pkgName))
+		for _, m := range am.methods {
+			if len(m.params) == 0 {
+				fmt.Fprintf(&fileBuffer, "\t%s: {Name: %q},\n", m.num, m.name)
+				continue
+			}
+			fmt.Fprintf(&fileBuffer, "\t%s: {Name: %q, Params: reflect.TypeOf(new(%s)), Ret: reflect.TypeOf(new(%s))},\n", m.num, m.name, m.params, m.ret)
+		}
+		fmt.Fprint(&fileBuffer, "}\n\n")
+	}
+
+	formatedBuf, err := util.FmtFile("", fileBuffer.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(dst, formatedBuf, 0o755)
+}
+
+func methodsVarPrefix(pkgName string) string {
+	return strings.ToUpper(pkgName[:1]) + pkgName[1:]
+}
+
+func parseMethods(pkgPath string, imports importSet) (*actorMethods, error) {
+	pkg := util.FindPackage(pkgPath)
+	if pkg.Err != nil {
+		return nil, pkg.Err
+	}
+	if err := imports.add(pkg.Name, pkgPath); err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, "methods.go"), nil, parser.AllErrors)
+	if err != nil {
+		return nil, err
+	}
+
+	// the imports of methods.go, by the name they are referred with
+	fileImports := map[string]string{}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		if spec.Name != nil {
+			fileImports[spec.Name.Name] = path
+			continue
+		}
+		imported := util.FindPackage(path)
+		if imported.Err != nil {
+			return nil, imported.Err
+		}
+		fileImports[imported.Name] = path
+	}
+
+	table, err := findMethodsTable(file)
+	if err != nil {
+		return nil, err
+	}
+
+	am := &actorMethods{pkgName: pkg.Name}
+	qualify := func(expr ast.Expr) (string, error) {
+		var err error
+		ast.Inspect(expr, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.SelectorExpr:
+				x, ok := n.X.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				path, ok := fileImports[x.Name]
+				if !ok {
+					err = fmt.Errorf("unknown package %s", x.Name)
+					return false
+				}
+				if e := imports.add(x.Name, path); e != nil {
+					err = e
+				}
+				return false
+			case *ast.Ident:
+				if n.IsExported() {
+					n.Name = pkg.Name + "." + n.Name
+				}
+			}
+			return true
+		})
+		return types.ExprString(expr), err
+	}
+
+	for _, elt := range table.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return nil, fmt.Errorf("unexpected methods entry %s", types.ExprString(elt))
+		}
+		meta, ok := kv.Value.(*ast.CompositeLit)
+		if !ok || len(meta.Elts) != 2 {
+			return nil, fmt.Errorf("unexpected method meta %s", types.ExprString(kv.Value))
+		}
+
+		var m methodInfo
+		if m.num, err = qualify(kv.Key); err != nil {
+			return nil, err
+		}
+		name, ok := meta.Elts[0].(*ast.BasicLit)
+		if !ok || name.Kind != token.STRING {
+			return nil, fmt.Errorf("unexpected method name %s", types.ExprString(meta.Elts[0]))
+		}
+		if m.name, err = strconv.Unquote(name.Value); err != nil {
+			return nil, err
+		}
+
+		// deprecated methods have no signature
+		if ident, ok := meta.Elts[1].(*ast.Ident); ok && ident.Name == "nil" {
+			am.methods = append(am.methods, m)
+			continue
+		}
+		params, ret, err := methodSignature(meta.Elts[1])
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", m.name, err)
+		}
+		if m.params, err = qualify(params); err != nil {
+			return nil, err
+		}
+		if m.ret, err = qualify(ret); err != nil {
+			return nil, err
+		}
+		am.methods = append(am.methods, m)
+	}
+
+	return am, nil
+}
+
+func findMethodsTable(file *ast.File) (*ast.CompositeLit, error) {
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if name.Name != "Methods" || i >= len(vs.Values) {
+					continue
+				}
+				if table, ok := vs.Values[i].(*ast.CompositeLit); ok {
+					return table, nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("var Methods not found")
+}
+
+// methodSignature returns the types the params and the return value point to, from a signature written as
+// `*new(func(*Params) *Return)`
+func methodSignature(expr ast.Expr) (ast.Expr, ast.Expr, error) {
+	star, ok := expr.(*ast.StarExpr)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected signature %s", types.ExprString(expr))
+	}
+	call, ok := star.X.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil, nil, fmt.Errorf("unexpected signature %s", types.ExprString(expr))
+	}
+	ft, ok := call.Args[0].(*ast.FuncType)
+	if !ok || ft.Params == nil || len(ft.Params.List) != 1 || ft.Results == nil || len(ft.Results.List) != 1 {
+		return nil, nil, fmt.Errorf("unexpected signature %s", types.ExprString(expr))
+	}
+
+	params, ok := ft.Params.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return nil, nil, fmt.Errorf("params of %s are not a pointer", types.ExprString(expr))
+	}
+	ret, ok := ft.Results.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return nil, nil, fmt.Errorf("return of %s is not a pointer", types.ExprString(expr))
+	}
+	return params.X, ret.X, nil
+}
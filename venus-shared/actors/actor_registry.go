@@ -0,0 +1,66 @@
+package actors
+
+import (
+	"sort"
+	"sync"
+
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/ipfs/go-cid"
+)
+
+// ActorCode is a known builtin actor code
+type ActorCode struct {
+	Name    string
+	Version actorstypes.Version
+	// Networks are the ones of the embedded bundles with the code, empty for the actors released before the bundles
+	// or for a bundle loaded from elsewhere
+	Networks []string
+}
+
+var (
+	actorCodesOnce sync.Once
+	actorCodes     map[cid.Cid]*ActorCode
+)
+
+// loadActorCodes indexes the codes of the embedded bundles of every network and of the actors before the bundles
+func loadActorCodes() {
+	actorCodes = make(map[cid.Cid]*ActorCode)
+	for _, m := range EmbeddedBuiltinActorsMetadata {
+		for name, c := range m.Actors {
+			entry, ok := actorCodes[c]
+			if !ok {
+				entry = &ActorCode{Name: name, Version: m.Version}
+				actorCodes[c] = entry
+			}
+			entry.Networks = append(entry.Networks, m.Network)
+		}
+	}
+	for _, entry := range actorCodes {
+		sort.Strings(entry.Networks)
+	}
+
+	for av := actorstypes.Version0; av <= actorstypes.Version7; av++ {
+		for _, name := range manifest.GetBuiltinActorsKeys(av) {
+			if c, ok := GetActorCodeID(av, name); ok {
+				if _, known := actorCodes[c]; !known {
+					actorCodes[c] = &ActorCode{Name: name, Version: av}
+				}
+			}
+		}
+	}
+}
+
+// LookupActorCode returns the name and version of a builtin actor code, whichever the network of its bundle is
+func LookupActorCode(c cid.Cid) (*ActorCode, bool) {
+	actorCodesOnce.Do(loadActorCodes)
+	if entry, ok := actorCodes[c]; ok {
+		return &ActorCode{Name: entry.Name, Version: entry.Version, Networks: append([]string{}, entry.Networks...)}, true
+	}
+
+	// a bundle registered without being embedded
+	if name, av, ok := GetActorMetaByCode(c); ok {
+		return &ActorCode{Name: name, Version: av}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,67 @@
+package miner
+
+import (
+	"fmt"
+
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+
+	minertypes13 "github.com/filecoin-project/go-state-types/builtin/v13/miner"
+)
+
+// MinBatchNetworkVersion is the first network version accepting the precommit batches and the aggregated
+// prove commits, introduced by actors v5
+const MinBatchNetworkVersion = network.Version13
+
+// PreCommitBatchBounds returns the number of sectors a precommit batch message can hold at nv
+func PreCommitBatchBounds(nv network.Version) (min, max int, err error) {
+	if nv < MinBatchNetworkVersion {
+		return 0, 0, fmt.Errorf("precommit batches are not supported at network version %d", nv)
+	}
+	// the bound is unchanged since actors v5
+	return 1, minertypes13.PreCommitSectorBatchMaxSize, nil
+}
+
+// ProveCommitAggregateBounds returns the number of sectors a ProveCommitAggregate message can hold at nv, and
+// the size of the largest aggregate proof accepted
+func ProveCommitAggregateBounds(nv network.Version) (min, max, maxProofSize int, err error) {
+	if nv < MinBatchNetworkVersion {
+		return 0, 0, 0, fmt.Errorf("aggregated prove commits are not supported at network version %d", nv)
+	}
+	// the bounds are unchanged since actors v5
+	return minertypes13.MinAggregatedSectors, minertypes13.MaxAggregatedSectors, minertypes13.MaxAggregateProofSize, nil
+}
+
+// NewProveCommitAggregateParams builds the params of a ProveCommitAggregate message, the number of sectors and
+// the proof size are checked against the bounds of nv
+func NewProveCommitAggregateParams(nv network.Version, sectors []abi.SectorNumber, proof []byte) (*ProveCommitAggregateParams, error) {
+	min, max, maxProofSize, err := ProveCommitAggregateBounds(nv)
+	if err != nil {
+		return nil, err
+	}
+
+	nums := make([]uint64, 0, len(sectors))
+	for _, s := range sectors {
+		nums = append(nums, uint64(s))
+	}
+	bf := bitfield.NewFromSet(nums)
+	count, err := bf.Count()
+	if err != nil {
+		return nil, err
+	}
+	if count != uint64(len(sectors)) {
+		return nil, fmt.Errorf("duplicated sectors in the aggregate")
+	}
+	if len(sectors) < min || len(sectors) > max {
+		return nil, fmt.Errorf("%d sectors can not be aggregated, expected between %d and %d", len(sectors), min, max)
+	}
+	if len(proof) > maxProofSize {
+		return nil, fmt.Errorf("aggregate proof of %d bytes exceeds the limit of %d bytes", len(proof), maxProofSize)
+	}
+
+	return &ProveCommitAggregateParams{
+		SectorNumbers:  bf,
+		AggregateProof: proof,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package miner
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestBatchBounds(t *testing.T) {
+	tf.UnitTest(t)
+
+	_, _, err := PreCommitBatchBounds(network.Version12)
+	require.Error(t, err)
+	min, max, err := PreCommitBatchBounds(network.Version21)
+	require.NoError(t, err)
+	require.Equal(t, 1, min)
+	require.Equal(t, 256, max)
+
+	_, _, _, err = ProveCommitAggregateBounds(network.Version12)
+	require.Error(t, err)
+	min, max, maxProofSize, err := ProveCommitAggregateBounds(network.Version13)
+	require.NoError(t, err)
+	require.Equal(t, 4, min)
+	require.Equal(t, 819, max)
+	require.Equal(t, 81960, maxProofSize)
+}
+
+func TestNewProveCommitAggregateParams(t *testing.T) {
+	tf.UnitTest(t)
+
+	params, err := NewProveCommitAggregateParams(network.Version21, []abi.SectorNumber{5, 1, 3, 2}, []byte("proof"))
+	require.NoError(t, err)
+	sectors, err := params.SectorNumbers.All(10)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 3, 5}, sectors)
+	require.Equal(t, []byte("proof"), params.AggregateProof)
+
+	_, err = NewProveCommitAggregateParams(network.Version21, []abi.SectorNumber{1, 2, 3}, nil)
+	require.Error(t, err)
+	_, err = NewProveCommitAggregateParams(network.Version21, []abi.SectorNumber{1, 2, 3, 3}, nil)
+	require.Error(t, err)
+	_, err = NewProveCommitAggregateParams(network.Version21, []abi.SectorNumber{1, 2, 3, 4}, make([]byte, 81961))
+	require.Error(t, err)
+	_, err = NewProveCommitAggregateParams(network.Version12, []abi.SectorNumber{1, 2, 3, 4}, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package miner
+
+import (
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/dline"
+	"github.com/filecoin-project/go-state-types/network"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/policy"
+)
+
+// The proving period and deadline parameters are the same for all actor versions, the helpers below
+// read the period and window through WPoStProvingPeriod and WPoStChallengeWindow so that devnet
+// overrides are honoured.
+
+// NewDeadlineInfo returns the deadline-related calculations for a deadline in the proving period starting at periodStart
+func NewDeadlineInfo(periodStart abi.ChainEpoch, deadlineIdx uint64, currEpoch abi.ChainEpoch) *dline.Info {
+	return dline.NewInfo(periodStart, deadlineIdx, currEpoch, WPoStPeriodDeadlines, WPoStProvingPeriod(),
+		WPoStChallengeWindow(), WPoStChallengeLookback, FaultDeclarationCutoff)
+}
+
+// CurrentProvingPeriodStart returns the start of the proving period which contains epoch, periodStart is the start
+// of any proving period of the miner, eg. the ProvingPeriodStart recorded in its state
+func CurrentProvingPeriodStart(periodStart, epoch abi.ChainEpoch) abi.ChainEpoch {
+	period := WPoStProvingPeriod()
+	elapsed := ((epoch-periodStart)%period + period) % period
+	return epoch - elapsed
+}
+
+// DeadlineIndexAt returns the index of the deadline open at epoch
+func DeadlineIndexAt(periodStart, epoch abi.ChainEpoch) uint64 {
+	start := CurrentProvingPeriodStart(periodStart, epoch)
+	return uint64((epoch - start) / WPoStChallengeWindow())
+}
+
+// DeadlineInfoAt returns the info of the deadline open at epoch
+func DeadlineInfoAt(periodStart, epoch abi.ChainEpoch) *dline.Info {
+	start := CurrentProvingPeriodStart(periodStart, epoch)
+	return NewDeadlineInfo(start, uint64((epoch-start)/WPoStChallengeWindow()), epoch)
+}
+
+// NextWindowStart returns the first epoch not before epoch at which the challenge window of deadlineIdx opens
+func NextWindowStart(periodStart abi.ChainEpoch, deadlineIdx uint64, epoch abi.ChainEpoch) (abi.ChainEpoch, error) {
+	if deadlineIdx >= WPoStPeriodDeadlines {
+		return 0, fmt.Errorf("invalid deadline index %d, must be less than %d", deadlineIdx, WPoStPeriodDeadlines)
+	}
+
+	di := NewDeadlineInfo(CurrentProvingPeriodStart(periodStart, epoch), deadlineIdx, epoch)
+	if di.Open < epoch {
+		return di.Open + WPoStProvingPeriod(), nil
+	}
+	return di.Open, nil
+}
+
+// PreCommitProveWindow returns the epochs between which the pre-committed sector can be prove-committed,
+// the sector can be proven from start on, and the pre-commit expires at end
+func PreCommitProveWindow(nv network.Version, info *SectorPreCommitOnChainInfo) (start, end abi.ChainEpoch, err error) {
+	av, err := actorstypes.VersionForNetwork(nv)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	maxDuration, err := policy.GetMaxProveCommitDuration(av, info.Info.SealProof)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return info.PreCommitEpoch + policy.GetPreCommitChallengeDelay(), info.PreCommitEpoch + maxDuration, nil
+}
@@ -0,0 +1,49 @@
+package miner
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestDeadlineMath(t *testing.T) {
+	tf.UnitTest(t)
+
+	period := WPoStProvingPeriod()
+	window := WPoStChallengeWindow()
+	periodStart := abi.ChainEpoch(100)
+
+	require.Equal(t, periodStart, CurrentProvingPeriodStart(periodStart, periodStart))
+	require.Equal(t, periodStart+period, CurrentProvingPeriodStart(periodStart, periodStart+period+1))
+	// epochs before the recorded period start still map to their own period
+	require.Equal(t, periodStart-period, CurrentProvingPeriodStart(periodStart, periodStart-1))
+
+	require.Equal(t, uint64(0), DeadlineIndexAt(periodStart, periodStart))
+	require.Equal(t, uint64(1), DeadlineIndexAt(periodStart, periodStart+window))
+	require.Equal(t, WPoStPeriodDeadlines-1, DeadlineIndexAt(periodStart, periodStart-1))
+
+	epoch := periodStart + 3*window + 1
+	di := DeadlineInfoAt(periodStart, epoch)
+	require.Equal(t, uint64(3), di.Index)
+	require.Equal(t, periodStart+3*window, di.Open)
+	require.True(t, di.IsOpen())
+
+	// the window of deadline 3 is already open, the next one is in the next proving period
+	start, err := NextWindowStart(periodStart, 3, epoch)
+	require.NoError(t, err)
+	require.Equal(t, periodStart+period+3*window, start)
+
+	start, err = NextWindowStart(periodStart, 4, epoch)
+	require.NoError(t, err)
+	require.Equal(t, periodStart+4*window, start)
+
+	start, err = NextWindowStart(periodStart, 4, periodStart+4*window)
+	require.NoError(t, err)
+	require.Equal(t, periodStart+4*window, start)
+
+	_, err = NextWindowStart(periodStart, WPoStPeriodDeadlines, epoch)
+	require.Error(t, err)
+}
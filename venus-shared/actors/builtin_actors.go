@@ -25,6 +25,22 @@ import (
 //go:embed builtin-actors-code/*.tar.zst
 var embeddedBuiltinActorReleases embed.FS
 
+// calibnetBuggyManifests existed temporarily on the calibnet testnet,
+// they are included in our builtin bundle, but intentionally omitted from metadata
+var calibnetBuggyManifests = map[cid.Cid]struct{}{
+	/* inline-gen template
+	{{range .calibnetBuggyManifests}}
+	cid.MustParse({{printf "%q" .}}): {},{{end}}
+
+	/* inline-gen start */
+
+	cid.MustParse("bafy2bzacedrunxfqta5skb7q7x32lnp4efz2oq7fn226ffm7fu5iqs62jkmvs"): {},
+	cid.MustParse("bafy2bzacebl4w5ptfvuw6746w7ev562idkbf5ppq72e6zub22435ws2rukzru"): {},
+	cid.MustParse("bafy2bzacea4firkyvt2zzdwqjrws5pyeluaesh6uaid246tommayr4337xpmi"): {},
+
+	/* inline-gen end */
+}
+
 // NOTE: DO NOT change this unless you REALLY know what you're doing. This is consensus critical.
 var BundleOverrides map[actorstypes.Version]string
 
@@ -127,9 +143,17 @@ func loadManifests(netw string) error {
 	// The following code cid existed temporarily on the calibnet testnet, as a "buggy" storage miner actor implementation.
 	// We include them in our builtin bundle, but intentionally omit from metadata.
 	if NetworkBundle == "calibrationnet" {
+		/* inline-gen template
+		{{range .calibnetBuggyActors}}
+		AddActorMeta({{printf "%q" .name}}, cid.MustParse({{printf "%q" .cid}}), actorstypes.Version{{.version}}){{end}}
+
+		/* inline-gen start */
+
 		AddActorMeta("storageminer", cid.MustParse("bafk2bzacecnh2ouohmonvebq7uughh4h3ppmg4cjsk74dzxlbbtlcij4xbzxq"), actorstypes.Version12)
 		AddActorMeta("storageminer", cid.MustParse("bafk2bzaced7emkbbnrewv5uvrokxpf5tlm4jslu2jsv77ofw2yqdglg657uie"), actorstypes.Version12)
 		AddActorMeta("verifiedregistry", cid.MustParse("bafk2bzacednskl3bykz5qpo54z2j2p4q44t5of4ktd6vs6ymmg2zebsbxazkm"), actorstypes.Version13)
+
+		/* inline-gen end */
 	}
 
 	return nil
@@ -171,11 +195,7 @@ func ReadEmbeddedBuiltinActorsMetadata() ([]*BuiltinActorsMetadata, error) {
 }
 
 func readEmbeddedBuiltinActorsMetadata(bundle string) ([]*BuiltinActorsMetadata, error) {
-	const (
-		archiveExt   = ".tar.zst"
-		bundleExt    = ".car"
-		bundlePrefix = "builtin-actors-"
-	)
+	const archiveExt = ".tar.zst"
 
 	if !strings.HasPrefix(bundle, "v") {
 		return nil, fmt.Errorf("bundle bundle '%q' doesn't start with a 'v'", bundle)
@@ -193,7 +213,18 @@ func readEmbeddedBuiltinActorsMetadata(bundle string) ([]*BuiltinActorsMetadata,
 	}
 	defer fi.Close() //nolint
 
-	uncompressed := zstd.NewReader(fi)
+	return ReadBuiltinActorsMetadata(actorstypes.Version(version), fi)
+}
+
+// ReadBuiltinActorsMetadata reads the metadata of the bundles of a release, the zstd compressed tarfile holding one
+// bundle per network. Every bundle is checked to hold its manifest and all the actors of the manifest.
+func ReadBuiltinActorsMetadata(version actorstypes.Version, r io.Reader) ([]*BuiltinActorsMetadata, error) {
+	const (
+		bundleExt    = ".car"
+		bundlePrefix = "builtin-actors-"
+	)
+
+	uncompressed := zstd.NewReader(r)
 	defer uncompressed.Close() //nolint
 
 	var bundles []*BuiltinActorsMetadata
@@ -227,14 +258,12 @@ func readEmbeddedBuiltinActorsMetadata(bundle string) ([]*BuiltinActorsMetadata,
 
 		// The following manifest cids existed temporarily on the calibnet testnet
 		// We include them in our builtin bundle, but intentionally omit from metadata
-		if root == cid.MustParse("bafy2bzacedrunxfqta5skb7q7x32lnp4efz2oq7fn226ffm7fu5iqs62jkmvs") ||
-			root == cid.MustParse("bafy2bzacebl4w5ptfvuw6746w7ev562idkbf5ppq72e6zub22435ws2rukzru") ||
-			root == cid.MustParse("bafy2bzacea4firkyvt2zzdwqjrws5pyeluaesh6uaid246tommayr4337xpmi") {
+		if _, ok := calibnetBuggyManifests[root]; ok {
 			continue
 		}
 		bundles = append(bundles, &BuiltinActorsMetadata{
 			Network:     name,
-			Version:     actorstypes.Version(version),
+			Version:     version,
 			ManifestCid: root,
 			Actors:      actorCids,
 		})
@@ -37,3 +37,28 @@ func TestRegistration(t *testing.T) {
 		require.Equal(t, key, name)
 	}
 }
+
+func TestLookupActorCode(t *testing.T) {
+	for _, m := range EmbeddedBuiltinActorsMetadata {
+		if m.Network != "mainnet" || m.Version != actorstypes.Version12 {
+			continue
+		}
+		code, found := LookupActorCode(m.Actors[manifest.MinerKey])
+		require.True(t, found)
+		require.Equal(t, manifest.MinerKey, code.Name)
+		require.Equal(t, actorstypes.Version12, code.Version)
+		require.Contains(t, code.Networks, "mainnet")
+	}
+
+	// the actors before the bundles
+	minerV0, found := GetActorCodeID(actorstypes.Version0, manifest.MinerKey)
+	require.True(t, found)
+	code, found := LookupActorCode(minerV0)
+	require.True(t, found)
+	require.Equal(t, manifest.MinerKey, code.Name)
+	require.Equal(t, actorstypes.Version0, code.Version)
+	require.Empty(t, code.Networks)
+
+	_, found = LookupActorCode(EmbeddedBuiltinActorsMetadata[0].ManifestCid)
+	require.False(t, found)
+}
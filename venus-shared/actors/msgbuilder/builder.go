@@ -0,0 +1,99 @@
+// Package msgbuilder makes the messages calling the methods of the builtin actors. The params of a method are encoded
+// for the actors of the network version the builder is made for, and the methods the actors of that version don't
+// have are refused before a message is sent.
+//
+//	msg, err := msgbuilder.New(nv, owner).Miner(maddr).ChangeWorkerAddress(worker, controls)
+package msgbuilder
+
+import (
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/types"
+)
+
+// Builder holds the sender and the network version of the messages, it is copied by the With methods so that a
+// builder can be shared
+type Builder struct {
+	from     address.Address
+	nv       network.Version
+	av       actorstypes.Version
+	value    abi.TokenAmount
+	gasLimit int64
+	err      error
+}
+
+// New creates a builder of the messages sent by from at the network version nv
+func New(nv network.Version, from address.Address) Builder {
+	av, err := actorstypes.VersionForNetwork(nv)
+	return Builder{from: from, nv: nv, av: av, value: big.Zero(), err: err}
+}
+
+// From returns a builder for the messages sent by from
+func (b Builder) From(from address.Address) Builder {
+	b.from = from
+	return b
+}
+
+// WithValue returns a builder for the messages sending value along
+func (b Builder) WithValue(value abi.TokenAmount) Builder {
+	b.value = value
+	return b
+}
+
+// WithGasLimit returns a builder for the messages with the gas limit set, 0 leaves it to the gas estimation
+func (b Builder) WithGasLimit(gasLimit int64) Builder {
+	b.gasLimit = gasLimit
+	return b
+}
+
+// NetworkVersion returns the network version the params are encoded for
+func (b Builder) NetworkVersion() network.Version {
+	return b.nv
+}
+
+// ActorsVersion returns the version of the actors at the network version of the builder
+func (b Builder) ActorsVersion() actorstypes.Version {
+	return b.av
+}
+
+// since refuses a method added to the actors at version av
+func (b Builder) since(av actorstypes.Version, method string) error {
+	if b.err != nil {
+		return b.err
+	}
+	if b.av < av {
+		return fmt.Errorf("%s is not supported by the actors of network version %d, it needs actors v%d", method, b.nv, av)
+	}
+	return nil
+}
+
+func (b Builder) message(to address.Address, method abi.MethodNum, params cbg.CBORMarshaler) (*types.Message, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	var enc []byte
+	if params != nil {
+		var aerr error
+		if enc, aerr = actors.SerializeParams(params); aerr != nil {
+			return nil, fmt.Errorf("serializing params of method %d: %w", method, aerr)
+		}
+	}
+
+	return &types.Message{
+		To:       to,
+		From:     b.from,
+		Value:    b.value,
+		GasLimit: b.gasLimit,
+		Method:   method,
+		Params:   enc,
+	}, nil
+}
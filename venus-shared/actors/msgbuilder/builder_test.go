@@ -0,0 +1,140 @@
+package msgbuilder
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	datacap13 "github.com/filecoin-project/go-state-types/builtin/v13/datacap"
+	power13 "github.com/filecoin-project/go-state-types/builtin/v13/power"
+	verifreg13 "github.com/filecoin-project/go-state-types/builtin/v13/verifreg"
+	"github.com/filecoin-project/go-state-types/network"
+	power0 "github.com/filecoin-project/specs-actors/actors/builtin/power"
+	miner2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/miner"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/venus-shared/actors"
+)
+
+func TestMinerMessages(t *testing.T) {
+	owner, _ := address.NewIDAddress(100)
+	worker, _ := address.NewIDAddress(101)
+	maddr, _ := address.NewIDAddress(1000)
+
+	msg, err := New(network.Version21, owner).WithGasLimit(1000).Miner(maddr).ChangeWorkerAddress(worker, []address.Address{owner})
+	require.NoError(t, err)
+	require.Equal(t, maddr, msg.To)
+	require.Equal(t, owner, msg.From)
+	require.Equal(t, builtintypes.MethodsMiner.ChangeWorkerAddress, msg.Method)
+	require.Equal(t, int64(1000), msg.GasLimit)
+	require.True(t, msg.Value.IsZero())
+
+	// the same encoding as the params of the actors v2
+	enc, err := actors.SerializeParams(&miner2.ChangeWorkerAddressParams{NewWorker: worker, NewControlAddrs: []address.Address{owner}})
+	require.NoError(t, err)
+	require.Equal(t, enc, msg.Params)
+
+	msg, err = New(network.Version21, worker).Miner(maddr).RepayDebt(abi.NewTokenAmount(10))
+	require.NoError(t, err)
+	require.Equal(t, abi.NewTokenAmount(10), msg.Value)
+	require.Nil(t, msg.Params)
+
+	// the beneficiary came with the actors v9, the dispute with the actors v3
+	_, err = New(network.Version16, owner).Miner(maddr).ChangeBeneficiary(worker, big.Zero(), 10)
+	require.Error(t, err)
+	_, err = New(network.Version17, owner).Miner(maddr).ChangeBeneficiary(worker, big.Zero(), 10)
+	require.NoError(t, err)
+	_, err = New(network.Version9, owner).Miner(maddr).DisputeWindowedPoSt(1, 0)
+	require.Error(t, err)
+
+	msg, err = New(network.Version21, worker).Miner(maddr).ReportConsensusFault([]byte{1}, []byte{2}, nil)
+	require.NoError(t, err)
+	require.Equal(t, builtintypes.MethodsMiner.ReportConsensusFault, msg.Method)
+
+	_, err = New(network.Version(10000), owner).Miner(maddr).ConfirmChangeWorkerAddress()
+	require.Error(t, err)
+}
+
+func TestMarketMessages(t *testing.T) {
+	client, _ := address.NewIDAddress(100)
+
+	b := New(network.Version21, client)
+	msg, err := b.Market().AddBalance(client, abi.NewTokenAmount(5))
+	require.NoError(t, err)
+	require.Equal(t, builtintypes.StorageMarketActorAddr, msg.To)
+	require.Equal(t, abi.NewTokenAmount(5), msg.Value)
+
+	// the value of a message doesn't leak to the builder it was made from
+	msg, err = b.Market().WithdrawBalance(client, abi.NewTokenAmount(5))
+	require.NoError(t, err)
+	require.True(t, msg.Value.IsZero())
+	require.Equal(t, builtintypes.MethodsMarket.WithdrawBalance, msg.Method)
+}
+
+func TestCreateMiner(t *testing.T) {
+	owner, _ := address.NewIDAddress(100)
+
+	msg, err := New(network.Version21, owner).Power().CreateMiner(owner, owner, abi.SectorSize(32<<30), "", nil)
+	require.NoError(t, err)
+	require.Equal(t, builtintypes.StoragePowerActorAddr, msg.To)
+	var params power13.CreateMinerParams
+	require.NoError(t, params.UnmarshalCBOR(bytes.NewReader(msg.Params)))
+	require.Equal(t, abi.RegisteredPoStProof_StackedDrgWindow32GiBV1_1, params.WindowPoStProofType)
+
+	// the seal proof up to the actors v2
+	msg, err = New(network.Version5, owner).Power().CreateMiner(owner, owner, abi.SectorSize(32<<30), "", nil)
+	require.NoError(t, err)
+	var params0 power0.CreateMinerParams
+	require.NoError(t, params0.UnmarshalCBOR(bytes.NewReader(msg.Params)))
+	require.Equal(t, abi.RegisteredSealProof_StackedDrg32GiBV1, params0.SealProofType)
+}
+
+func TestDatacapMessages(t *testing.T) {
+	client, _ := address.NewIDAddress(100)
+	other, _ := address.NewIDAddress(101)
+	mh, err := multihash.Sum([]byte("piece"), multihash.SHA2_256, -1)
+	require.NoError(t, err)
+	testCid := cid.NewCidV1(cid.Raw, mh)
+
+	msg, err := New(network.Version21, client).Datacap().Transfer(other, abi.NewStoragePower(1<<30))
+	require.NoError(t, err)
+	require.Equal(t, builtintypes.DatacapActorAddr, msg.To)
+	require.Equal(t, builtintypes.MethodsDatacap.TransferExported, msg.Method)
+	var params datacap13.TransferParams
+	require.NoError(t, params.UnmarshalCBOR(bytes.NewReader(msg.Params)))
+	require.Equal(t, other, params.To)
+	require.Equal(t, big.Mul(abi.NewStoragePower(1<<30), builtintypes.TokenPrecision), params.Amount)
+
+	// the datacap is held by the verified registry before the actors v9
+	_, err = New(network.Version16, client).Datacap().Transfer(other, abi.NewStoragePower(1))
+	require.Error(t, err)
+	_, err = New(network.Version21, client).Datacap().Transfer(builtintypes.VerifiedRegistryActorAddr, abi.NewStoragePower(1))
+	require.Error(t, err)
+
+	// the allocations take their size, the extensions the size of the claims
+	reqs := &verifreg13.AllocationRequests{
+		Allocations: []verifreg13.AllocationRequest{
+			{Provider: 1000, Data: testCid, Size: 2048, TermMin: 10, TermMax: 20, Expiration: 30},
+			{Provider: 1000, Data: testCid, Size: 1024, TermMin: 10, TermMax: 20, Expiration: 30},
+		},
+		Extensions: []verifreg13.ClaimExtensionRequest{{Provider: 1000, Claim: 1, TermMax: 40}},
+	}
+	msg, err = New(network.Version21, client).Datacap().Allocate(reqs, abi.NewStoragePower(512))
+	require.NoError(t, err)
+	require.NoError(t, params.UnmarshalCBOR(bytes.NewReader(msg.Params)))
+	require.Equal(t, builtintypes.VerifiedRegistryActorAddr, params.To)
+	require.Equal(t, DataCapTokens(abi.NewStoragePower(2048+1024+512)), params.Amount)
+	var decoded verifreg13.AllocationRequests
+	require.NoError(t, decoded.UnmarshalCBOR(bytes.NewReader(params.OperatorData)))
+	require.Equal(t, *reqs, decoded)
+
+	_, err = New(network.Version21, client).Datacap().Allocate(reqs, big.Zero())
+	require.Error(t, err)
+	_, err = New(network.Version21, client).Datacap().Allocate(&verifreg13.AllocationRequests{}, big.Zero())
+	require.Error(t, err)
+}
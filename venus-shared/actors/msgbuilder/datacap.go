@@ -0,0 +1,84 @@
+package msgbuilder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	datacap13 "github.com/filecoin-project/go-state-types/builtin/v13/datacap"
+	verifreg13 "github.com/filecoin-project/go-state-types/builtin/v13/verifreg"
+
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/types"
+)
+
+// DatacapMessages makes the messages to the datacap actor, which holds the datacap of the clients as tokens from the
+// actors v9 (FIP-0045)
+type DatacapMessages struct {
+	b Builder
+}
+
+// Datacap returns the messages to the datacap actor
+func (b Builder) Datacap() DatacapMessages {
+	return DatacapMessages{b: b}
+}
+
+// DataCapTokens converts bytes of datacap to the datacap tokens, a byte is a whole token
+func DataCapTokens(dcap abi.StoragePower) abi.TokenAmount {
+	return big.Mul(dcap, verifreg13.DataCapGranularity)
+}
+
+// Transfer transfers dcap bytes of datacap of the sender to another client. The datacap transferred to the verified
+// registry makes allocations, see Allocate.
+func (m DatacapMessages) Transfer(to address.Address, dcap abi.StoragePower) (*types.Message, error) {
+	if to == builtintypes.VerifiedRegistryActorAddr {
+		return nil, errors.New("the datacap transferred to the verified registry makes allocations, they must be requested")
+	}
+	return m.transfer(to, dcap, nil)
+}
+
+// Allocate transfers the datacap of the allocations requested to the verified registry, which makes them for the
+// providers. Extending the claims of the sender also takes the datacap of the claims, extended is their total size.
+func (m DatacapMessages) Allocate(reqs *verifreg13.AllocationRequests, extended abi.StoragePower) (*types.Message, error) {
+	if len(reqs.Allocations) == 0 && len(reqs.Extensions) == 0 {
+		return nil, errors.New("no allocation or extension requested")
+	}
+	if len(reqs.Extensions) > 0 && !extended.GreaterThan(big.Zero()) {
+		return nil, errors.New("the size of the claims extended is needed")
+	}
+
+	dcap := big.Zero()
+	if len(reqs.Extensions) > 0 {
+		dcap = extended
+	}
+	for _, alloc := range reqs.Allocations {
+		if alloc.TermMin > alloc.TermMax {
+			return nil, fmt.Errorf("allocation of %s has a min term %d above its max term %d", alloc.Data, alloc.TermMin, alloc.TermMax)
+		}
+		dcap = big.Add(dcap, big.NewIntUnsigned(uint64(alloc.Size)))
+	}
+
+	operatorData, err := actors.SerializeParams(reqs)
+	if err != nil {
+		return nil, fmt.Errorf("serializing allocation requests: %w", err)
+	}
+	return m.transfer(builtintypes.VerifiedRegistryActorAddr, dcap, operatorData)
+}
+
+func (m DatacapMessages) transfer(to address.Address, dcap abi.StoragePower, operatorData []byte) (*types.Message, error) {
+	if err := m.b.since(actorstypes.Version9, "datacap transfer"); err != nil {
+		return nil, err
+	}
+	if !dcap.GreaterThan(big.Zero()) {
+		return nil, fmt.Errorf("invalid datacap %s to transfer", dcap)
+	}
+	return m.b.message(builtintypes.DatacapActorAddr, builtintypes.MethodsDatacap.TransferExported, &datacap13.TransferParams{
+		To:           to,
+		Amount:       DataCapTokens(dcap),
+		OperatorData: operatorData,
+	})
+}
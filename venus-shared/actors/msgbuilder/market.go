@@ -0,0 +1,33 @@
+package msgbuilder
+
+import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	market13 "github.com/filecoin-project/go-state-types/builtin/v13/market"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/types"
+)
+
+// MarketMessages makes the messages to the storage market actor
+type MarketMessages struct {
+	b Builder
+}
+
+// Market returns the messages to the storage market actor
+func (b Builder) Market() MarketMessages {
+	return MarketMessages{b: b}
+}
+
+// AddBalance adds amount, sent as the value of the message, to the escrow of addr
+func (m MarketMessages) AddBalance(addr address.Address, amount abi.TokenAmount) (*types.Message, error) {
+	return m.b.WithValue(amount).message(builtintypes.StorageMarketActorAddr, builtintypes.MethodsMarket.AddBalance, &addr)
+}
+
+// WithdrawBalance withdraws amount of the escrow of addr which is not locked
+func (m MarketMessages) WithdrawBalance(addr address.Address, amount abi.TokenAmount) (*types.Message, error) {
+	return m.b.message(builtintypes.StorageMarketActorAddr, builtintypes.MethodsMarket.WithdrawBalance, &market13.WithdrawBalanceParams{
+		ProviderOrClientAddress: addr,
+		Amount:                  amount,
+	})
+}
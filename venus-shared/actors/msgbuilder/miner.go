@@ -0,0 +1,107 @@
+package msgbuilder
+
+import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	miner13 "github.com/filecoin-project/go-state-types/builtin/v13/miner"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/types"
+)
+
+// MinerMessages makes the messages to a miner actor, the params of these methods are encoded the same since the
+// actors v0
+type MinerMessages struct {
+	b     Builder
+	maddr address.Address
+}
+
+// Miner returns the messages to the miner actor maddr
+func (b Builder) Miner(maddr address.Address) MinerMessages {
+	return MinerMessages{b: b, maddr: maddr}
+}
+
+// ChangeWorkerAddress proposes a new worker and sets the control addresses, it is sent by the owner
+func (m MinerMessages) ChangeWorkerAddress(worker address.Address, controls []address.Address) (*types.Message, error) {
+	return m.b.message(m.maddr, builtintypes.MethodsMiner.ChangeWorkerAddress, &miner13.ChangeWorkerAddressParams{
+		NewWorker:       worker,
+		NewControlAddrs: controls,
+	})
+}
+
+// ConfirmChangeWorkerAddress completes the change of the worker once its epoch is reached
+func (m MinerMessages) ConfirmChangeWorkerAddress() (*types.Message, error) {
+	return m.b.message(m.maddr, builtintypes.MethodsMiner.ConfirmChangeWorkerAddress, nil)
+}
+
+// ChangeOwnerAddress proposes a new owner when sent by the owner, and accepts it when sent by the new owner
+func (m MinerMessages) ChangeOwnerAddress(owner address.Address) (*types.Message, error) {
+	if err := m.b.since(actorstypes.Version2, "ChangeOwnerAddress"); err != nil {
+		return nil, err
+	}
+	return m.b.message(m.maddr, builtintypes.MethodsMiner.ChangeOwnerAddress, &owner)
+}
+
+// ChangeBeneficiary proposes a new beneficiary when sent by the owner, and accepts it when sent by the nominee
+func (m MinerMessages) ChangeBeneficiary(beneficiary address.Address, quota abi.TokenAmount, expiration abi.ChainEpoch) (*types.Message, error) {
+	if err := m.b.since(actorstypes.Version9, "ChangeBeneficiary"); err != nil {
+		return nil, err
+	}
+	return m.b.message(m.maddr, builtintypes.MethodsMiner.ChangeBeneficiary, &miner13.ChangeBeneficiaryParams{
+		NewBeneficiary: beneficiary,
+		NewQuota:       quota,
+		NewExpiration:  expiration,
+	})
+}
+
+// WithdrawBalance withdraws amount of the available balance to the beneficiary
+func (m MinerMessages) WithdrawBalance(amount abi.TokenAmount) (*types.Message, error) {
+	return m.b.message(m.maddr, builtintypes.MethodsMiner.WithdrawBalance, &miner13.WithdrawBalanceParams{
+		AmountRequested: amount,
+	})
+}
+
+// RepayDebt repays the fee debt of the miner with amount, sent as the value of the message
+func (m MinerMessages) RepayDebt(amount abi.TokenAmount) (*types.Message, error) {
+	if err := m.b.since(actorstypes.Version2, "RepayDebt"); err != nil {
+		return nil, err
+	}
+	return m.b.WithValue(amount).message(m.maddr, builtintypes.MethodsMiner.RepayDebt, nil)
+}
+
+// ChangePeerID sets the peer id of the miner
+func (m MinerMessages) ChangePeerID(pid peer.ID) (*types.Message, error) {
+	return m.b.message(m.maddr, builtintypes.MethodsMiner.ChangePeerID, &miner13.ChangePeerIDParams{
+		NewID: abi.PeerID(pid),
+	})
+}
+
+// ChangeMultiaddrs sets the multiaddrs of the miner
+func (m MinerMessages) ChangeMultiaddrs(addrs []abi.Multiaddrs) (*types.Message, error) {
+	return m.b.message(m.maddr, builtintypes.MethodsMiner.ChangeMultiaddrs, &miner13.ChangeMultiaddrsParams{
+		NewMultiaddrs: addrs,
+	})
+}
+
+// DisputeWindowedPoSt disputes the window post postIndex of the deadline
+func (m MinerMessages) DisputeWindowedPoSt(deadline, postIndex uint64) (*types.Message, error) {
+	if err := m.b.since(actorstypes.Version3, "DisputeWindowedPoSt"); err != nil {
+		return nil, err
+	}
+	return m.b.message(m.maddr, builtintypes.MethodsMiner.DisputeWindowedPoSt, &miner13.DisputeWindowedPoStParams{
+		Deadline:  deadline,
+		PoStIndex: postIndex,
+	})
+}
+
+// ReportConsensusFault reports the consensus fault revealed by the serialized block headers, extra is the witness
+// of a parent-grinding fault and nil for the other faults
+func (m MinerMessages) ReportConsensusFault(header1, header2, extra []byte) (*types.Message, error) {
+	return m.b.message(m.maddr, builtintypes.MethodsMiner.ReportConsensusFault, &miner13.ReportConsensusFaultParams{
+		BlockHeader1:     header1,
+		BlockHeader2:     header2,
+		BlockHeaderExtra: extra,
+	})
+}
@@ -0,0 +1,58 @@
+package msgbuilder
+
+import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	power13 "github.com/filecoin-project/go-state-types/builtin/v13/power"
+	power0 "github.com/filecoin-project/specs-actors/actors/builtin/power"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	"github.com/filecoin-project/venus/venus-shared/actors/types"
+)
+
+// PowerMessages makes the messages to the storage power actor
+type PowerMessages struct {
+	b Builder
+}
+
+// Power returns the messages to the storage power actor
+func (b Builder) Power() PowerMessages {
+	return PowerMessages{b: b}
+}
+
+// CreateMiner creates a miner with sectors of ssize. Up to the actors v2 the miner is created with its seal proof,
+// and with its window post proof from the actors v3.
+func (m PowerMessages) CreateMiner(owner, worker address.Address, ssize abi.SectorSize, pid peer.ID, addrs []abi.Multiaddrs) (*types.Message, error) {
+	if m.b.err != nil {
+		return nil, m.b.err
+	}
+
+	if m.b.av < actorstypes.Version3 {
+		spt, err := miner.SealProofTypeFromSectorSize(ssize, m.b.nv, false)
+		if err != nil {
+			return nil, err
+		}
+		return m.b.message(builtintypes.StoragePowerActorAddr, builtintypes.MethodsPower.CreateMiner, &power0.CreateMinerParams{
+			Owner:         owner,
+			Worker:        worker,
+			SealProofType: spt,
+			Peer:          abi.PeerID(pid),
+			Multiaddrs:    addrs,
+		})
+	}
+
+	spt, err := miner.WindowPoStProofTypeFromSectorSize(ssize, m.b.nv)
+	if err != nil {
+		return nil, err
+	}
+	return m.b.message(builtintypes.StoragePowerActorAddr, builtintypes.MethodsPower.CreateMiner, &power13.CreateMinerParams{
+		Owner:               owner,
+		Worker:              worker,
+		WindowPoStProofType: spt,
+		Peer:                abi.PeerID(pid),
+		Multiaddrs:          addrs,
+	})
+}
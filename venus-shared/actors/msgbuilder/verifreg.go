@@ -0,0 +1,41 @@
+package msgbuilder
+
+import (
+	"github.com/filecoin-project/go-address"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	verifreg13 "github.com/filecoin-project/go-state-types/builtin/v13/verifreg"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/types"
+)
+
+// VerifregMessages makes the messages to the verified registry actor
+type VerifregMessages struct {
+	b Builder
+}
+
+// Verifreg returns the messages to the verified registry actor
+func (b Builder) Verifreg() VerifregMessages {
+	return VerifregMessages{b: b}
+}
+
+// AddVerifier adds a verifier with the allowance, it is sent by the root key holder
+func (m VerifregMessages) AddVerifier(verifier address.Address, allowance verifreg13.DataCap) (*types.Message, error) {
+	return m.b.message(builtintypes.VerifiedRegistryActorAddr, builtintypes.MethodsVerifiedRegistry.AddVerifier, &verifreg13.AddVerifierParams{
+		Address:   verifier,
+		Allowance: allowance,
+	})
+}
+
+// RemoveVerifier removes a verifier, it is sent by the root key holder
+func (m VerifregMessages) RemoveVerifier(verifier address.Address) (*types.Message, error) {
+	return m.b.message(builtintypes.VerifiedRegistryActorAddr, builtintypes.MethodsVerifiedRegistry.RemoveVerifier, &verifier)
+}
+
+// AddVerifiedClient grants the allowance to a client out of the allowance of the verifier sending the message. From
+// the actors v9 the allowance is minted as datacap tokens to the client.
+func (m VerifregMessages) AddVerifiedClient(client address.Address, allowance verifreg13.DataCap) (*types.Message, error) {
+	return m.b.message(builtintypes.VerifiedRegistryActorAddr, builtintypes.MethodsVerifiedRegistry.AddVerifiedClient, &verifreg13.AddVerifiedClientParams{
+		Address:   client,
+		Allowance: allowance,
+	})
+}
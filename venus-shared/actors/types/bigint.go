@@ -3,6 +3,8 @@ package types
 import (
 	"fmt"
 	"math/big"
+	"strconv"
+	"strings"
 
 	big2 "github.com/filecoin-project/go-state-types/big"
 )
@@ -29,6 +31,85 @@ func BigFromString(s string) (BigInt, error) {
 	return BigInt{Int: v}, nil
 }
 
+// maxDecimalExponent bounds the exponent of the numbers parsed in scientific notation, so that a short input does
+// not expand to a huge number, 10^50 attoFIL is far beyond the total supply
+const maxDecimalExponent = 50
+
+// parseDecimal parses a decimal number, in scientific notation or not, exactly
+func parseDecimal(s string) (*big.Rat, error) {
+	mantissa, exponent := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa = s[:i]
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent in %q", s)
+		}
+		if exp > maxDecimalExponent || exp < -maxDecimalExponent {
+			return nil, fmt.Errorf("exponent of %q is out of range [-%d, %d]", s, maxDecimalExponent, maxDecimalExponent)
+		}
+		exponent = exp
+	}
+	// big.Rat also accepts fractions and base prefixes, only plain decimals are left to it
+	if mantissa == "" || strings.TrimLeft(mantissa, "+-.0123456789") != "" {
+		return nil, fmt.Errorf("failed to parse %q as a decimal number", s)
+	}
+	r, ok := new(big.Rat).SetString(mantissa)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse %q as a decimal number", s)
+	}
+
+	pow := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exponent))), nil))
+	if exponent < 0 {
+		return r.Quo(r, pow), nil
+	}
+	return r.Mul(r, pow), nil
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// ParseBigInt parses an integer in the forms third-party clients send them: a decimal such as "1000", in scientific
+// notation such as "1e18" or "1.5E3", optionally quoted. Unlike a float, it fails on a value with a fractional part
+// rather than rounding it.
+//
+// BigInt is the big.Int of go-state-types, whose JSON decoding only accepts decimal strings. The amounts of the
+// messages are decoded with ParseBigInt, see Message.UnmarshalJSON, as the max fee of a MessageSendSpec and the
+// BigInt params of the rpc api of the node. The FIL amounts are decoded with ParseFIL.
+func ParseBigInt(s string) (BigInt, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	if len(s) > 100 {
+		return BigInt{}, fmt.Errorf("string length too large: %d", len(s))
+	}
+
+	r, err := parseDecimal(s)
+	if err != nil {
+		return BigInt{}, err
+	}
+	if !r.IsInt() {
+		return BigInt{}, fmt.Errorf("%q is not an integer, it would lose precision as a big int", s)
+	}
+	return BigInt{Int: new(big.Int).Set(r.Num())}, nil
+}
+
+// lenientBigInt is a BigInt decoded from JSON with ParseBigInt
+type lenientBigInt BigInt
+
+func (bi *lenientBigInt) UnmarshalJSON(b []byte) error {
+	v, err := ParseBigInt(string(b))
+	if err != nil {
+		return err
+	}
+	bi.Int = v.Int
+	return nil
+}
+
 func BigMul(a, b BigInt) BigInt {
 	return BigInt{Int: big.NewInt(0).Mul(a.Int, b.Int)}
 }
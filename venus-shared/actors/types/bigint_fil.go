@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"encoding"
 	"encoding/json"
 	"fmt"
@@ -98,8 +99,27 @@ func (f FIL) MarshalJSON() ([]byte, error) {
 	return []byte("\"" + f.String() + "\""), nil
 }
 
+// UnmarshalJSON accepts the amount as a string, "1.5 FIL", "1e18 aFIL" or "0.5", or as a JSON number of FIL,
+// which is parsed exactly rather than as a float. null is the zero amount, so that the FIL can be printed.
 func (f *FIL) UnmarshalJSON(by []byte) error {
-	p, err := ParseFIL(strings.Trim(string(by), "\""))
+	by = bytes.TrimSpace(by)
+	if string(by) == "null" {
+		f.Int = big.NewInt(0)
+		return nil
+	}
+	var s string
+	if len(by) > 0 && by[0] == '"' {
+		if err := json.Unmarshal(by, &s); err != nil {
+			return fmt.Errorf("invalid FIL amount %s: %w", by, err)
+		}
+	} else {
+		var n json.Number
+		if err := json.Unmarshal(by, &n); err != nil {
+			return fmt.Errorf("invalid FIL amount %s: %w", by, err)
+		}
+		s = n.String()
+	}
+	p, err := ParseFIL(s)
 	if err != nil {
 		return err
 	}
@@ -112,8 +132,11 @@ func (f *FIL) UnmarshalJSON(by []byte) error {
 	return nil
 }
 
+// ParseFIL parses an amount of FIL, or of attoFIL with the aFIL or attoFIL suffix, as a decimal or in scientific
+// notation. It fails when the amount is more precise than an attoFIL rather than rounding it.
 func ParseFIL(s string) (FIL, error) {
-	suffix := strings.TrimLeft(s, "-.1234567890")
+	s = strings.TrimSpace(s)
+	suffix := strings.TrimLeft(s, "+-.1234567890eE")
 	s = s[:len(s)-len(suffix)]
 	var attofil bool
 	if suffix != "" {
@@ -131,9 +154,9 @@ func ParseFIL(s string) (FIL, error) {
 		return FIL{}, fmt.Errorf("string length too large: %d", len(s))
 	}
 
-	r, ok := new(big.Rat).SetString(s)
-	if !ok {
-		return FIL{}, fmt.Errorf("failed to parse %q as a decimal number", s)
+	r, err := parseDecimal(s)
+	if err != nil {
+		return FIL{}, err
 	}
 
 	if !attofil {
@@ -145,7 +168,7 @@ func ParseFIL(s string) (FIL, error) {
 		if attofil {
 			pref = "atto"
 		}
-		return FIL{}, fmt.Errorf("invalid %sFIL value: %q", pref, s)
+		return FIL{}, fmt.Errorf("invalid %sFIL value: %q, it is more precise than an attoFIL", pref, s)
 	}
 
 	return FIL{r.Num()}, nil
@@ -1088,3 +1088,84 @@ type EthCreateTraceResult struct {
 	GasUsed EthUint64   `json:"gasUsed"`
 	Code    EthBytes    `json:"code"`
 }
+
+type EthTraceTransaction struct {
+	*EthTrace
+	BlockHash           EthHash `json:"blockHash"`
+	BlockNumber         int64   `json:"blockNumber"`
+	TransactionHash     EthHash `json:"transactionHash"`
+	TransactionPosition int     `json:"transactionPosition"`
+}
+
+// EthTraceConfig selects the tracer of the debug_trace methods, only the "callTracer" is supported and used when the
+// tracer is not set
+type EthTraceConfig struct {
+	Tracer       string               `json:"tracer,omitempty"`
+	TracerConfig *EthCallTracerConfig `json:"tracerConfig,omitempty"`
+}
+
+// EthDebugTraceParams handles raw jsonrpc params for debug_traceTransaction and debug_traceBlockByNumber, the trace
+// config following the transaction hash or the block number is optional
+type EthDebugTraceParams struct {
+	// Target is the transaction hash of debug_traceTransaction, or the block number of debug_traceBlockByNumber
+	Target string
+	Config *EthTraceConfig
+}
+
+func (e *EthDebugTraceParams) UnmarshalJSON(b []byte) error {
+	var params []json.RawMessage
+	err := json.Unmarshal(b, &params)
+	if err != nil {
+		return err
+	}
+
+	switch len(params) {
+	case 2:
+		err = json.Unmarshal(params[1], &e.Config)
+		if err != nil {
+			return err
+		}
+		fallthrough
+	case 1:
+		err = json.Unmarshal(params[0], &e.Target)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("expected 1 or 2 params, got %d", len(params))
+	}
+
+	return nil
+}
+
+func (e EthDebugTraceParams) MarshalJSON() ([]byte, error) {
+	if e.Config != nil {
+		return json.Marshal([]interface{}{e.Target, e.Config})
+	}
+	return json.Marshal([]interface{}{e.Target})
+}
+
+type EthCallTracerConfig struct {
+	// OnlyTopCall leaves out the calls made by the transaction
+	OnlyTopCall bool `json:"onlyTopCall,omitempty"`
+}
+
+// EthCallFrame is a call in the format of the "callTracer" of geth
+type EthCallFrame struct {
+	Type    string          `json:"type"`
+	From    EthAddress      `json:"from"`
+	To      *EthAddress     `json:"to,omitempty"`
+	Value   *EthBigInt      `json:"value,omitempty"`
+	Gas     EthUint64       `json:"gas"`
+	GasUsed EthUint64       `json:"gasUsed"`
+	Input   EthBytes        `json:"input"`
+	Output  EthBytes        `json:"output,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Calls   []*EthCallFrame `json:"calls,omitempty"`
+}
+
+// EthTxTraceResult is the call frame of a transaction of a block
+type EthTxTraceResult struct {
+	TxHash EthHash       `json:"txHash"`
+	Result *EthCallFrame `json:"result"`
+}
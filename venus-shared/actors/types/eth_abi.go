@@ -0,0 +1,372 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// ethAbiWord is the size of the slots of the solidity abi encoding
+const ethAbiWord = 32
+
+// EthAbiParseSignature parses a function signature such as `transfer(address,uint256)` and returns the name and
+// the argument types, tuples are not supported
+func EthAbiParseSignature(sig string) (string, []string, error) {
+	sig = strings.ReplaceAll(sig, " ", "")
+	open := strings.IndexByte(sig, '(')
+	if open < 0 || !strings.HasSuffix(sig, ")") {
+		return "", nil, fmt.Errorf("invalid function signature %q", sig)
+	}
+
+	name := sig[:open]
+	args, err := EthAbiParseTypes(sig[open+1 : len(sig)-1])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid function signature %q: %w", sig, err)
+	}
+	return name, args, nil
+}
+
+// EthAbiParseTypes parses a comma separated list of abi types, the list may be wrapped in parentheses
+func EthAbiParseTypes(list string) ([]string, error) {
+	list = strings.ReplaceAll(list, " ", "")
+	if strings.HasPrefix(list, "(") && strings.HasSuffix(list, ")") {
+		list = list[1 : len(list)-1]
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	typs := strings.Split(list, ",")
+	for i, typ := range typs {
+		typ, err := canonicalAbiType(typ)
+		if err != nil {
+			return nil, err
+		}
+		typs[i] = typ
+	}
+	return typs, nil
+}
+
+// EthAbiSelector returns the 4 bytes identifying the function in the calldata
+func EthAbiSelector(name string, args []string) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(name + "(" + strings.Join(args, ",") + ")"))
+	return hasher.Sum(nil)[:4]
+}
+
+// EthAbiEncodeCall builds the calldata calling the function of signature sig with the arguments given as strings
+func EthAbiEncodeCall(sig string, values []string) ([]byte, error) {
+	name, args, err := EthAbiParseSignature(sig)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := EthAbiEncode(args, values)
+	if err != nil {
+		return nil, err
+	}
+	return append(EthAbiSelector(name, args), encoded...), nil
+}
+
+// EthAbiEncode encodes the values, given as strings, as the abi types. Integers are decimal or 0x prefixed hex,
+// bytes are hex, arrays are written as [v1,v2]
+func EthAbiEncode(typs []string, values []string) ([]byte, error) {
+	if len(typs) != len(values) {
+		return nil, fmt.Errorf("expected %d values, got %d", len(typs), len(values))
+	}
+
+	var head, tail []byte
+	for i, typ := range typs {
+		if isDynamicAbiType(typ) {
+			head = append(head, abiUint(big.NewInt(int64(len(typs)*ethAbiWord+len(tail))))...)
+			data, err := encodeDynamicAbiValue(typ, values[i])
+			if err != nil {
+				return nil, fmt.Errorf("argument %d: %w", i, err)
+			}
+			tail = append(tail, data...)
+			continue
+		}
+
+		word, err := encodeStaticAbiValue(typ, values[i])
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		head = append(head, word...)
+	}
+	return append(head, tail...), nil
+}
+
+// EthAbiDecode decodes data, such as the return of a contract call, as the abi types and formats each value
+// the way EthAbiEncode reads it
+func EthAbiDecode(typs []string, data []byte) ([]string, error) {
+	values := make([]string, len(typs))
+	for i, typ := range typs {
+		word, err := abiWordAt(data, i*ethAbiWord)
+		if err != nil {
+			return nil, err
+		}
+		if !isDynamicAbiType(typ) {
+			values[i], err = decodeStaticAbiValue(typ, word)
+			if err != nil {
+				return nil, fmt.Errorf("value %d: %w", i, err)
+			}
+			continue
+		}
+
+		offset, err := abiOffset(word, len(data))
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		values[i], err = decodeDynamicAbiValue(typ, data[offset:])
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+	}
+	return values, nil
+}
+
+func canonicalAbiType(typ string) (string, error) {
+	if elem, ok := strings.CutSuffix(typ, "[]"); ok {
+		elem, err := canonicalAbiType(elem)
+		if err != nil {
+			return "", err
+		}
+		if isDynamicAbiType(elem) {
+			return "", fmt.Errorf("arrays of %s are not supported", elem)
+		}
+		return elem + "[]", nil
+	}
+
+	switch typ {
+	case "address", "bool", "bytes", "string":
+		return typ, nil
+	case "uint", "int":
+		return typ + "256", nil
+	}
+	if _, err := abiTypeSize(typ); err != nil {
+		return "", err
+	}
+	return typ, nil
+}
+
+// abiTypeSize returns the bit size of the intN and uintN types and the byte size of the bytesN types
+func abiTypeSize(typ string) (int, error) {
+	var prefix string
+	var maxSize, step int
+	switch {
+	case strings.HasPrefix(typ, "uint"):
+		prefix, maxSize, step = "uint", 256, 8
+	case strings.HasPrefix(typ, "int"):
+		prefix, maxSize, step = "int", 256, 8
+	case strings.HasPrefix(typ, "bytes"):
+		prefix, maxSize, step = "bytes", 32, 1
+	default:
+		return 0, fmt.Errorf("unsupported abi type %q", typ)
+	}
+
+	size, err := strconv.Atoi(strings.TrimPrefix(typ, prefix))
+	if err != nil || size <= 0 || size > maxSize || size%step != 0 {
+		return 0, fmt.Errorf("unsupported abi type %q", typ)
+	}
+	return size, nil
+}
+
+func isDynamicAbiType(typ string) bool {
+	return typ == "bytes" || typ == "string" || strings.HasSuffix(typ, "[]")
+}
+
+func encodeStaticAbiValue(typ, value string) ([]byte, error) {
+	switch typ {
+	case "address":
+		addr, err := ParseEthAddress(value)
+		if err != nil {
+			return nil, err
+		}
+		return leftPad32(addr[:]), nil
+	case "bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+		if b {
+			return abiUint(big.NewInt(1)), nil
+		}
+		return abiUint(big.NewInt(0)), nil
+	}
+
+	size, err := abiTypeSize(typ)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(typ, "bytes") {
+		b, err := DecodeHexString(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != size {
+			return nil, fmt.Errorf("%s needs %d bytes, got %d", typ, size, len(b))
+		}
+		return rightPad32(b), nil
+	}
+
+	n, ok := new(big.Int).SetString(value, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", value)
+	}
+	if strings.HasPrefix(typ, "uint") {
+		if n.Sign() < 0 || n.BitLen() > size {
+			return nil, fmt.Errorf("%s does not fit in %s", value, typ)
+		}
+		return abiUint(n), nil
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(size-1))
+	if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return nil, fmt.Errorf("%s does not fit in %s", value, typ)
+	}
+	if n.Sign() < 0 {
+		// two's complement on 256 bits
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return abiUint(n), nil
+}
+
+func encodeDynamicAbiValue(typ, value string) ([]byte, error) {
+	switch typ {
+	case "string":
+		return abiBytes([]byte(value)), nil
+	case "bytes":
+		b, err := DecodeHexString(value)
+		if err != nil {
+			return nil, err
+		}
+		return abiBytes(b), nil
+	}
+
+	elem := strings.TrimSuffix(typ, "[]")
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return nil, fmt.Errorf("%s value must be written as [v1,v2]", typ)
+	}
+	var items []string
+	if inner := strings.TrimSpace(value[1 : len(value)-1]); len(inner) > 0 {
+		items = strings.Split(inner, ",")
+	}
+
+	out := abiUint(big.NewInt(int64(len(items))))
+	for _, item := range items {
+		word, err := encodeStaticAbiValue(elem, strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, word...)
+	}
+	return out, nil
+}
+
+func decodeStaticAbiValue(typ string, word []byte) (string, error) {
+	switch typ {
+	case "address":
+		addr, err := CastEthAddress(word[12:])
+		if err != nil {
+			return "", err
+		}
+		return addr.String(), nil
+	case "bool":
+		return strconv.FormatBool(new(big.Int).SetBytes(word).Sign() != 0), nil
+	}
+
+	size, err := abiTypeSize(typ)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(typ, "bytes") {
+		return "0x" + hex.EncodeToString(word[:size]), nil
+	}
+
+	n := new(big.Int).SetBytes(word)
+	if strings.HasPrefix(typ, "int") && word[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return n.String(), nil
+}
+
+func decodeDynamicAbiValue(typ string, data []byte) (string, error) {
+	lenWord, err := abiWordAt(data, 0)
+	if err != nil {
+		return "", err
+	}
+	length := new(big.Int).SetBytes(lenWord)
+
+	if typ == "bytes" || typ == "string" {
+		if !length.IsInt64() || length.Int64() > int64(len(data)-ethAbiWord) {
+			return "", fmt.Errorf("%s of %s bytes does not fit in the data", typ, length)
+		}
+		b := data[ethAbiWord : ethAbiWord+int(length.Int64())]
+		if typ == "string" {
+			return strconv.Quote(string(b)), nil
+		}
+		return "0x" + hex.EncodeToString(b), nil
+	}
+
+	if !length.IsInt64() || length.Int64() > int64(len(data)/ethAbiWord-1) {
+		return "", fmt.Errorf("%s of %s items does not fit in the data", typ, length)
+	}
+	elem := strings.TrimSuffix(typ, "[]")
+	items := make([]string, length.Int64())
+	for i := range items {
+		word, err := abiWordAt(data, (i+1)*ethAbiWord)
+		if err != nil {
+			return "", err
+		}
+		if items[i], err = decodeStaticAbiValue(elem, word); err != nil {
+			return "", err
+		}
+	}
+	return "[" + strings.Join(items, ",") + "]", nil
+}
+
+func abiWordAt(data []byte, offset int) ([]byte, error) {
+	if offset+ethAbiWord > len(data) {
+		return nil, fmt.Errorf("abi data too short, %d bytes for a word at %d", len(data), offset)
+	}
+	return data[offset : offset+ethAbiWord], nil
+}
+
+func abiOffset(word []byte, dataLen int) (int, error) {
+	offset := new(big.Int).SetBytes(word)
+	if !offset.IsInt64() || offset.Int64() > int64(dataLen) {
+		return 0, fmt.Errorf("abi offset %s out of the data", offset)
+	}
+	return int(offset.Int64()), nil
+}
+
+func abiUint(n *big.Int) []byte {
+	return leftPad32(n.Bytes())
+}
+
+func abiBytes(b []byte) []byte {
+	out := abiUint(big.NewInt(int64(len(b))))
+	for i := 0; i < len(b); i += ethAbiWord {
+		end := i + ethAbiWord
+		if end > len(b) {
+			end = len(b)
+		}
+		out = append(out, rightPad32(b[i:end])...)
+	}
+	return out
+}
+
+func leftPad32(b []byte) []byte {
+	out := make([]byte, ethAbiWord)
+	copy(out[ethAbiWord-len(b):], b)
+	return out
+}
+
+func rightPad32(b []byte) []byte {
+	out := make([]byte, ethAbiWord)
+	copy(out, b)
+	return out
+}
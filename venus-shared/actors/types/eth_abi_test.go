@@ -0,0 +1,56 @@
+package types
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestEthAbiEncodeCall(t *testing.T) {
+	tf.UnitTest(t)
+
+	data, err := EthAbiEncodeCall("transfer(address, uint256)", []string{"0xff00000000000000000000000000000000000064", "1000"})
+	require.NoError(t, err)
+	require.Equal(t, "a9059cbb"+
+		"000000000000000000000000ff00000000000000000000000000000000000064"+
+		"00000000000000000000000000000000000000000000000000000000000003e8", hex.EncodeToString(data))
+
+	// the erc20 balanceOf selector
+	data, err = EthAbiEncodeCall("balanceOf(address)", []string{"0xff00000000000000000000000000000000000064"})
+	require.NoError(t, err)
+	require.Equal(t, "70a08231", hex.EncodeToString(data[:4]))
+
+	_, err = EthAbiEncodeCall("transfer(address,uint8)", []string{"0xff00000000000000000000000000000000000064", "256"})
+	require.Error(t, err)
+	_, err = EthAbiEncodeCall("transfer(address,uint256)", []string{"0xff00000000000000000000000000000000000064"})
+	require.Error(t, err)
+	_, err = EthAbiEncodeCall("f((uint256,bool))", []string{"(1,true)"})
+	require.Error(t, err)
+}
+
+func TestEthAbiRoundTrip(t *testing.T) {
+	tf.UnitTest(t)
+
+	typs, err := EthAbiParseTypes("(uint, int32, bool, bytes4, string, bytes, uint64[])")
+	require.NoError(t, err)
+	require.Equal(t, []string{"uint256", "int32", "bool", "bytes4", "string", "bytes", "uint64[]"}, typs)
+
+	values := []string{"0x10", "-5", "true", "0x01020304", "hello", "0x" + strings.Repeat("ab", 40), "[1,2,3]"}
+	data, err := EthAbiEncode(typs, values)
+	require.NoError(t, err)
+	require.Zero(t, len(data)%ethAbiWord)
+
+	// the negative int is sign extended on the whole word
+	require.Equal(t, strings.Repeat("ff", 31)+"fb", hex.EncodeToString(data[ethAbiWord:2*ethAbiWord]))
+
+	decoded, err := EthAbiDecode(typs, data)
+	require.NoError(t, err)
+	require.Equal(t, []string{"16", "-5", "true", "0x01020304", `"hello"`, "0x" + strings.Repeat("ab", 40), "[1,2,3]"}, decoded)
+
+	_, err = EthAbiDecode(typs, data[:len(data)-ethAbiWord*5])
+	require.Error(t, err)
+}
@@ -19,3 +19,25 @@ func (m *Message) MarshalJSON() ([]byte, error) {
 		CID:        m.Cid(),
 	})
 }
+
+// UnmarshalJSON decodes the amounts of the message with ParseBigInt, so that the values in scientific notation or
+// as JSON numbers sent by third-party clients are accepted, the CID field is ignored
+func (m *Message) UnmarshalJSON(b []byte) error {
+	// the amounts are values rather than pointers to the fields, so that null fails as for a BigInt
+	aux := struct {
+		*RawMessage
+		Value      lenientBigInt
+		GasFeeCap  lenientBigInt
+		GasPremium lenientBigInt
+	}{
+		RawMessage: (*RawMessage)(m),
+		Value:      lenientBigInt(m.Value),
+		GasFeeCap:  lenientBigInt(m.GasFeeCap),
+		GasPremium: lenientBigInt(m.GasPremium),
+	}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	m.Value, m.GasFeeCap, m.GasPremium = BigInt(aux.Value), BigInt(aux.GasFeeCap), BigInt(aux.GasPremium)
+	return nil
+}
@@ -0,0 +1,20 @@
+package v0
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type IAuth interface {
+	// AuthNew issues a new token carrying `perms` which never expires, it is named after a random uuid
+	AuthNew(ctx context.Context, perms []auth.Permission) ([]byte, error) //perm:admin
+	// AuthVerify returns the permissions carried by the token, revoked or expired tokens are rejected
+	AuthVerify(ctx context.Context, token string) ([]auth.Permission, error) //perm:read
+	// AuthList lists all the tokens issued by the node, including revoked ones
+	AuthList(ctx context.Context) ([]*types.AuthTokenInfo, error) //perm:admin
+	// AuthRevoke revokes the token named `name`, it will be rejected by all subsequent requests
+	AuthRevoke(ctx context.Context, name string) error //perm:admin
+}
@@ -32,6 +32,8 @@ type IAccount interface {
 type IActor interface {
 	StateGetActor(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.Actor, error) //perm:read
 	ListActor(ctx context.Context) (map[address.Address]*types.Actor, error)                             //perm:read
+	// StateActorStatObj returns the number of blocks and bytes reachable from the head of the actor, links deeper than maxDepth are not followed, 0 is unlimited
+	StateActorStatObj(ctx context.Context, actor address.Address, maxDepth uint64, tsk types.TipSetKey) (types.ObjStatWithDepth, error) //perm:read
 }
 
 type IBeacon interface {
@@ -39,9 +41,12 @@ type IBeacon interface {
 }
 
 type IChainInfo interface {
-	BlockTime(ctx context.Context) time.Duration                                                                                                                                          //perm:read
-	ChainList(ctx context.Context, tsKey types.TipSetKey, count int) ([]types.TipSetKey, error)                                                                                           //perm:read
-	ChainHead(ctx context.Context) (*types.TipSet, error)                                                                                                                                 //perm:read
+	BlockTime(ctx context.Context) time.Duration                                                //perm:read
+	ChainList(ctx context.Context, tsKey types.TipSetKey, count int) ([]types.TipSetKey, error) //perm:read
+	ChainHead(ctx context.Context) (*types.TipSet, error)                                       //perm:read
+	// ChainGetFinalizedHead returns the latest tipset the node considers final, which is ChainFinality epochs
+	// below the head
+	ChainGetFinalizedHead(ctx context.Context) (*types.FinalizedHead, error)                                                                                                              //perm:read
 	ChainSetHead(ctx context.Context, key types.TipSetKey) error                                                                                                                          //perm:admin
 	ChainGetTipSet(ctx context.Context, key types.TipSetKey) (*types.TipSet, error)                                                                                                       //perm:read
 	ChainGetTipSetByHeight(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                        //perm:read
@@ -77,6 +82,8 @@ type IChainInfo interface {
 	StateGetNetworkParams(ctx context.Context) (*types.NetworkParams, error) //perm:read
 	// StateActorCodeCIDs returns the CIDs of all the builtin actors for the given network version
 	StateActorCodeCIDs(context.Context, network.Version) (map[string]cid.Cid, error) //perm:read
+	// StateActorNames returns the name and version of the builtin actors of the codes, nil for an unknown code
+	StateActorNames(ctx context.Context, codes []cid.Cid) ([]*types.ActorCodeName, error) //perm:read
 	// ChainGetGenesis returns the genesis tipset.
 	ChainGetGenesis(context.Context) (*types.TipSet, error) //perm:read
 	// StateActorManifestCID returns the CID of the builtin actors manifest for the given network version
@@ -141,6 +148,7 @@ type IMinerState interface {
 	// StateAllMinerFaults returns all non-expired Faults that occur within lookback epochs of the given tipset
 	StateAllMinerFaults(ctx context.Context, lookback abi.ChainEpoch, ts types.TipSetKey) ([]*types.Fault, error)                                        //perm:read
 	StateMinerProvingDeadline(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*dline.Info, error)                                      //perm:read
+	StateMinerProvingDeadlineWithPartitions(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*types.ProvingDeadline, error)             //perm:read
 	StateMinerPartitions(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error)                       //perm:read
 	StateMinerDeadlines(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.Deadline, error)                                       //perm:read
 	StateMinerSectors(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error) //perm:read
@@ -155,11 +163,13 @@ type IMinerState interface {
 	// StateGetClaim returns the claim for a given address and claim ID.
 	StateGetClaim(ctx context.Context, providerAddr address.Address, claimID types.ClaimId, tsk types.TipSetKey) (*types.Claim, error) //perm:read
 	// StateGetClaims returns the all the claims for a given provider.
-	StateGetClaims(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) (map[types.ClaimId]types.Claim, error)                            //perm:read
-	StateMinerPreCommitDepositForPower(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)      //perm:read
-	StateMinerInitialPledgeCollateral(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)       //perm:read
-	StateVMCirculatingSupplyInternal(ctx context.Context, tsk types.TipSetKey) (types.CirculatingSupply, error)                                              //perm:read
-	StateCirculatingSupply(ctx context.Context, tsk types.TipSetKey) (abi.TokenAmount, error)                                                                //perm:read
+	StateGetClaims(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) (map[types.ClaimId]types.Claim, error)                       //perm:read
+	StateMinerPreCommitDepositForPower(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error) //perm:read
+	StateMinerInitialPledgeCollateral(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)  //perm:read
+	StateVMCirculatingSupplyInternal(ctx context.Context, tsk types.TipSetKey) (types.CirculatingSupply, error)                                         //perm:read
+	StateCirculatingSupply(ctx context.Context, tsk types.TipSetKey) (abi.TokenAmount, error)                                                           //perm:read
+	// StateSupplyHistory returns the circulating supply and the burnt funds every step epochs from from to to, on the chain of tsk
+	StateSupplyHistory(ctx context.Context, from, to, step abi.ChainEpoch, tsk types.TipSetKey) ([]*types.SupplyPoint, error)                                //perm:read
 	StateMarketDeals(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error)                                                         //perm:read
 	StateMinerActiveSectors(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)                             //perm:read
 	StateLookupID(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)                                                   //perm:read
@@ -1,6 +1,7 @@
 package v0
 
 type FullNode interface {
+	IAuth
 	IBlockStore
 	IChain
 	IMarket
@@ -54,6 +54,65 @@ func (m *MockFullNode) EXPECT() *MockFullNodeMockRecorder {
 	return m.recorder
 }
 
+// AuthList mocks base method.
+func (m *MockFullNode) AuthList(arg0 context.Context) ([]*types0.AuthTokenInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthList", arg0)
+	ret0, _ := ret[0].([]*types0.AuthTokenInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthList indicates an expected call of AuthList.
+func (mr *MockFullNodeMockRecorder) AuthList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthList", reflect.TypeOf((*MockFullNode)(nil).AuthList), arg0)
+}
+
+// AuthNew mocks base method.
+func (m *MockFullNode) AuthNew(arg0 context.Context, arg1 []string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthNew", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthNew indicates an expected call of AuthNew.
+func (mr *MockFullNodeMockRecorder) AuthNew(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthNew", reflect.TypeOf((*MockFullNode)(nil).AuthNew), arg0, arg1)
+}
+
+// AuthRevoke mocks base method.
+func (m *MockFullNode) AuthRevoke(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthRevoke", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthRevoke indicates an expected call of AuthRevoke.
+func (mr *MockFullNodeMockRecorder) AuthRevoke(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthRevoke", reflect.TypeOf((*MockFullNode)(nil).AuthRevoke), arg0, arg1)
+}
+
+// AuthVerify mocks base method.
+func (m *MockFullNode) AuthVerify(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthVerify", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthVerify indicates an expected call of AuthVerify.
+func (mr *MockFullNodeMockRecorder) AuthVerify(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthVerify", reflect.TypeOf((*MockFullNode)(nil).AuthVerify), arg0, arg1)
+}
+
 // BeaconGetEntry mocks base method.
 func (m *MockFullNode) BeaconGetEntry(arg0 context.Context, arg1 abi.ChainEpoch) (*types0.BeaconEntry, error) {
 	m.ctrl.T.Helper()
@@ -142,6 +201,21 @@ func (mr *MockFullNodeMockRecorder) ChainGetBlockMessages(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetBlockMessages", reflect.TypeOf((*MockFullNode)(nil).ChainGetBlockMessages), arg0, arg1)
 }
 
+// ChainGetFinalizedHead mocks base method.
+func (m *MockFullNode) ChainGetFinalizedHead(arg0 context.Context) (*types0.FinalizedHead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetFinalizedHead", arg0)
+	ret0, _ := ret[0].(*types0.FinalizedHead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetFinalizedHead indicates an expected call of ChainGetFinalizedHead.
+func (mr *MockFullNodeMockRecorder) ChainGetFinalizedHead(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetFinalizedHead", reflect.TypeOf((*MockFullNode)(nil).ChainGetFinalizedHead), arg0)
+}
+
 // ChainGetGenesis mocks base method.
 func (m *MockFullNode) ChainGetGenesis(arg0 context.Context) (*types0.TipSet, error) {
 	m.ctrl.T.Helper()
@@ -425,6 +499,21 @@ func (mr *MockFullNodeMockRecorder) ChainStatObj(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainStatObj", reflect.TypeOf((*MockFullNode)(nil).ChainStatObj), arg0, arg1, arg2)
 }
 
+// ChainStatObjWithDepth mocks base method.
+func (m *MockFullNode) ChainStatObjWithDepth(arg0 context.Context, arg1, arg2 cid.Cid, arg3 uint64) (types0.ObjStatWithDepth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainStatObjWithDepth", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types0.ObjStatWithDepth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainStatObjWithDepth indicates an expected call of ChainStatObjWithDepth.
+func (mr *MockFullNodeMockRecorder) ChainStatObjWithDepth(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainStatObjWithDepth", reflect.TypeOf((*MockFullNode)(nil).ChainStatObjWithDepth), arg0, arg1, arg2, arg3)
+}
+
 // ChainSyncHandleNewTipSet mocks base method.
 func (m *MockFullNode) ChainSyncHandleNewTipSet(arg0 context.Context, arg1 *types0.ChainInfo) error {
 	m.ctrl.T.Helper()
@@ -468,6 +557,36 @@ func (mr *MockFullNodeMockRecorder) Concurrent(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Concurrent", reflect.TypeOf((*MockFullNode)(nil).Concurrent), arg0)
 }
 
+// DatastoreGC mocks base method.
+func (m *MockFullNode) DatastoreGC(arg0 context.Context) (*types0.DatastoreGCResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DatastoreGC", arg0)
+	ret0, _ := ret[0].(*types0.DatastoreGCResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DatastoreGC indicates an expected call of DatastoreGC.
+func (mr *MockFullNodeMockRecorder) DatastoreGC(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DatastoreGC", reflect.TypeOf((*MockFullNode)(nil).DatastoreGC), arg0)
+}
+
+// DatastoreScrub mocks base method.
+func (m *MockFullNode) DatastoreScrub(arg0 context.Context, arg1 *types0.DatastoreScrubOptions) (<-chan *types0.DatastoreScrubProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DatastoreScrub", arg0, arg1)
+	ret0, _ := ret[0].(<-chan *types0.DatastoreScrubProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DatastoreScrub indicates an expected call of DatastoreScrub.
+func (mr *MockFullNodeMockRecorder) DatastoreScrub(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DatastoreScrub", reflect.TypeOf((*MockFullNode)(nil).DatastoreScrub), arg0, arg1)
+}
+
 // GasBatchEstimateMessageGas mocks base method.
 func (m *MockFullNode) GasBatchEstimateMessageGas(arg0 context.Context, arg1 []*types0.EstimateMessage, arg2 uint64, arg3 types0.TipSetKey) ([]*types0.EstimateResult, error) {
 	m.ctrl.T.Helper()
@@ -764,6 +883,21 @@ func (mr *MockFullNodeMockRecorder) MpoolDeleteByAdress(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolDeleteByAdress", reflect.TypeOf((*MockFullNode)(nil).MpoolDeleteByAdress), arg0, arg1)
 }
 
+// MpoolEstimateInclusion mocks base method.
+func (m *MockFullNode) MpoolEstimateInclusion(arg0 context.Context, arg1 *types.Message, arg2 uint64) (*types0.InclusionEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolEstimateInclusion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.InclusionEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolEstimateInclusion indicates an expected call of MpoolEstimateInclusion.
+func (mr *MockFullNodeMockRecorder) MpoolEstimateInclusion(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolEstimateInclusion", reflect.TypeOf((*MockFullNode)(nil).MpoolEstimateInclusion), arg0, arg1, arg2)
+}
+
 // MpoolGetConfig mocks base method.
 func (m *MockFullNode) MpoolGetConfig(arg0 context.Context) (*types0.MpoolConfig, error) {
 	m.ctrl.T.Helper()
@@ -867,6 +1001,21 @@ func (mr *MockFullNodeMockRecorder) MpoolPushMessage(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPushMessage", reflect.TypeOf((*MockFullNode)(nil).MpoolPushMessage), arg0, arg1, arg2)
 }
 
+// MpoolPushMessageWithID mocks base method.
+func (m *MockFullNode) MpoolPushMessageWithID(arg0 context.Context, arg1 types0.UUID, arg2 *types.Message, arg3 *types0.MessageSendSpec) (*types.SignedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolPushMessageWithID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types.SignedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolPushMessageWithID indicates an expected call of MpoolPushMessageWithID.
+func (mr *MockFullNodeMockRecorder) MpoolPushMessageWithID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPushMessageWithID", reflect.TypeOf((*MockFullNode)(nil).MpoolPushMessageWithID), arg0, arg1, arg2, arg3)
+}
+
 // MpoolPushUntrusted mocks base method.
 func (m *MockFullNode) MpoolPushUntrusted(arg0 context.Context, arg1 *types.SignedMessage) (cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -941,6 +1090,21 @@ func (mr *MockFullNodeMockRecorder) MpoolSub(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolSub", reflect.TypeOf((*MockFullNode)(nil).MpoolSub), arg0)
 }
 
+// MpoolSubFiltered mocks base method.
+func (m *MockFullNode) MpoolSubFiltered(arg0 context.Context, arg1 *types0.MpoolSubFilter) (<-chan types0.MpoolUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolSubFiltered", arg0, arg1)
+	ret0, _ := ret[0].(<-chan types0.MpoolUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolSubFiltered indicates an expected call of MpoolSubFiltered.
+func (mr *MockFullNodeMockRecorder) MpoolSubFiltered(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolSubFiltered", reflect.TypeOf((*MockFullNode)(nil).MpoolSubFiltered), arg0, arg1)
+}
+
 // NetAddrsListen mocks base method.
 func (m *MockFullNode) NetAddrsListen(arg0 context.Context) (peer.AddrInfo, error) {
 	m.ctrl.T.Helper()
@@ -1221,6 +1385,21 @@ func (mr *MockFullNodeMockRecorder) NetPubsubScores(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPubsubScores", reflect.TypeOf((*MockFullNode)(nil).NetPubsubScores), arg0)
 }
 
+// NetPubsubTopics mocks base method.
+func (m *MockFullNode) NetPubsubTopics(arg0 context.Context) ([]types0.PubsubTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetPubsubTopics", arg0)
+	ret0, _ := ret[0].([]types0.PubsubTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetPubsubTopics indicates an expected call of NetPubsubTopics.
+func (mr *MockFullNodeMockRecorder) NetPubsubTopics(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPubsubTopics", reflect.TypeOf((*MockFullNode)(nil).NetPubsubTopics), arg0)
+}
+
 // PaychAllocateLane mocks base method.
 func (m *MockFullNode) PaychAllocateLane(arg0 context.Context, arg1 address.Address) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -1578,6 +1757,36 @@ func (mr *MockFullNodeMockRecorder) StateActorManifestCID(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateActorManifestCID", reflect.TypeOf((*MockFullNode)(nil).StateActorManifestCID), arg0, arg1)
 }
 
+// StateActorNames mocks base method.
+func (m *MockFullNode) StateActorNames(arg0 context.Context, arg1 []cid.Cid) ([]*types0.ActorCodeName, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateActorNames", arg0, arg1)
+	ret0, _ := ret[0].([]*types0.ActorCodeName)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateActorNames indicates an expected call of StateActorNames.
+func (mr *MockFullNodeMockRecorder) StateActorNames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateActorNames", reflect.TypeOf((*MockFullNode)(nil).StateActorNames), arg0, arg1)
+}
+
+// StateActorStatObj mocks base method.
+func (m *MockFullNode) StateActorStatObj(arg0 context.Context, arg1 address.Address, arg2 uint64, arg3 types0.TipSetKey) (types0.ObjStatWithDepth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateActorStatObj", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types0.ObjStatWithDepth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateActorStatObj indicates an expected call of StateActorStatObj.
+func (mr *MockFullNodeMockRecorder) StateActorStatObj(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateActorStatObj", reflect.TypeOf((*MockFullNode)(nil).StateActorStatObj), arg0, arg1, arg2, arg3)
+}
+
 // StateAllMinerFaults mocks base method.
 func (m *MockFullNode) StateAllMinerFaults(arg0 context.Context, arg1 abi.ChainEpoch, arg2 types0.TipSetKey) ([]*types0.Fault, error) {
 	m.ctrl.T.Helper()
@@ -2103,6 +2312,21 @@ func (mr *MockFullNodeMockRecorder) StateMinerProvingDeadline(arg0, arg1, arg2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerProvingDeadline", reflect.TypeOf((*MockFullNode)(nil).StateMinerProvingDeadline), arg0, arg1, arg2)
 }
 
+// StateMinerProvingDeadlineWithPartitions mocks base method.
+func (m *MockFullNode) StateMinerProvingDeadlineWithPartitions(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (*types0.ProvingDeadline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMinerProvingDeadlineWithPartitions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.ProvingDeadline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMinerProvingDeadlineWithPartitions indicates an expected call of StateMinerProvingDeadlineWithPartitions.
+func (mr *MockFullNodeMockRecorder) StateMinerProvingDeadlineWithPartitions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerProvingDeadlineWithPartitions", reflect.TypeOf((*MockFullNode)(nil).StateMinerProvingDeadlineWithPartitions), arg0, arg1, arg2)
+}
+
 // StateMinerRecoveries mocks base method.
 func (m *MockFullNode) StateMinerRecoveries(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (bitfield.BitField, error) {
 	m.ctrl.T.Helper()
@@ -2343,6 +2567,21 @@ func (mr *MockFullNodeMockRecorder) StateSectorPreCommitInfo(arg0, arg1, arg2, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSectorPreCommitInfo", reflect.TypeOf((*MockFullNode)(nil).StateSectorPreCommitInfo), arg0, arg1, arg2, arg3)
 }
 
+// StateSupplyHistory mocks base method.
+func (m *MockFullNode) StateSupplyHistory(arg0 context.Context, arg1, arg2, arg3 abi.ChainEpoch, arg4 types0.TipSetKey) ([]*types0.SupplyPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateSupplyHistory", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*types0.SupplyPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateSupplyHistory indicates an expected call of StateSupplyHistory.
+func (mr *MockFullNodeMockRecorder) StateSupplyHistory(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSupplyHistory", reflect.TypeOf((*MockFullNode)(nil).StateSupplyHistory), arg0, arg1, arg2, arg3, arg4)
+}
+
 // StateVMCirculatingSupplyInternal mocks base method.
 func (m *MockFullNode) StateVMCirculatingSupplyInternal(arg0 context.Context, arg1 types0.TipSetKey) (types0.CirculatingSupply, error) {
 	m.ctrl.T.Helper()
@@ -2477,6 +2716,21 @@ func (mr *MockFullNodeMockRecorder) SyncSubmitBlock(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncSubmitBlock", reflect.TypeOf((*MockFullNode)(nil).SyncSubmitBlock), arg0, arg1)
 }
 
+// SyncSubmitBlockChecked mocks base method.
+func (m *MockFullNode) SyncSubmitBlockChecked(arg0 context.Context, arg1 *types0.BlockMsg) (*types0.SubmitBlockResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncSubmitBlockChecked", arg0, arg1)
+	ret0, _ := ret[0].(*types0.SubmitBlockResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncSubmitBlockChecked indicates an expected call of SyncSubmitBlockChecked.
+func (mr *MockFullNodeMockRecorder) SyncSubmitBlockChecked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncSubmitBlockChecked", reflect.TypeOf((*MockFullNode)(nil).SyncSubmitBlockChecked), arg0, arg1)
+}
+
 // SyncerTracker mocks base method.
 func (m *MockFullNode) SyncerTracker(arg0 context.Context) *types0.TargetTracker {
 	m.ctrl.T.Helper()
@@ -27,7 +27,9 @@ type INetwork interface {
 	NetDisconnect(ctx context.Context, p peer.ID) error                                     //perm:admin
 	NetAutoNatStatus(context.Context) (types.NatInfo, error)                                //perm:read
 	NetPubsubScores(context.Context) ([]types.PubsubScore, error)                           //perm:read
-	ID(ctx context.Context) (peer.ID, error)                                                //perm:read
+	// NetPubsubTopics returns the peers subscribed to the pubsub topics, their mesh and their message counts
+	NetPubsubTopics(context.Context) ([]types.PubsubTopic, error) //perm:read
+	ID(ctx context.Context) (peer.ID, error)                      //perm:read
 
 	// NetBandwidthStats returns statistics about the nodes total bandwidth
 	// usage and current rate across all peers and protocols.
@@ -7,6 +7,7 @@ import (
 
 	address "github.com/filecoin-project/go-address"
 	bitfield "github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-jsonrpc/auth"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/crypto"
@@ -23,13 +24,38 @@ import (
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
+type IAuthStruct struct {
+	Internal struct {
+		AuthList   func(ctx context.Context) ([]*types.AuthTokenInfo, error)          `perm:"admin"`
+		AuthNew    func(ctx context.Context, perms []auth.Permission) ([]byte, error) `perm:"admin"`
+		AuthRevoke func(ctx context.Context, name string) error                       `perm:"admin"`
+		AuthVerify func(ctx context.Context, token string) ([]auth.Permission, error) `perm:"read"`
+	}
+}
+
+func (s *IAuthStruct) AuthList(p0 context.Context) ([]*types.AuthTokenInfo, error) {
+	return s.Internal.AuthList(p0)
+}
+func (s *IAuthStruct) AuthNew(p0 context.Context, p1 []auth.Permission) ([]byte, error) {
+	return s.Internal.AuthNew(p0, p1)
+}
+func (s *IAuthStruct) AuthRevoke(p0 context.Context, p1 string) error {
+	return s.Internal.AuthRevoke(p0, p1)
+}
+func (s *IAuthStruct) AuthVerify(p0 context.Context, p1 string) ([]auth.Permission, error) {
+	return s.Internal.AuthVerify(p0, p1)
+}
+
 type IBlockStoreStruct struct {
 	Internal struct {
-		ChainDeleteObj func(ctx context.Context, obj cid.Cid) error                                `perm:"admin"`
-		ChainHasObj    func(ctx context.Context, obj cid.Cid) (bool, error)                        `perm:"read"`
-		ChainPutObj    func(context.Context, blocks.Block) error                                   `perm:"admin"`
-		ChainReadObj   func(ctx context.Context, cid cid.Cid) ([]byte, error)                      `perm:"read"`
-		ChainStatObj   func(ctx context.Context, obj cid.Cid, base cid.Cid) (types.ObjStat, error) `perm:"read"`
+		ChainDeleteObj        func(ctx context.Context, obj cid.Cid) error                                                               `perm:"admin"`
+		ChainHasObj           func(ctx context.Context, obj cid.Cid) (bool, error)                                                       `perm:"read"`
+		ChainPutObj           func(context.Context, blocks.Block) error                                                                  `perm:"admin"`
+		ChainReadObj          func(ctx context.Context, cid cid.Cid) ([]byte, error)                                                     `perm:"read"`
+		ChainStatObj          func(ctx context.Context, obj cid.Cid, base cid.Cid) (types.ObjStat, error)                                `perm:"read"`
+		ChainStatObjWithDepth func(ctx context.Context, obj cid.Cid, base cid.Cid, maxDepth uint64) (types.ObjStatWithDepth, error)      `perm:"read"`
+		DatastoreGC           func(ctx context.Context) (*types.DatastoreGCResult, error)                                                `perm:"admin"`
+		DatastoreScrub        func(ctx context.Context, opts *types.DatastoreScrubOptions) (<-chan *types.DatastoreScrubProgress, error) `perm:"admin"`
 	}
 }
 
@@ -48,6 +74,15 @@ func (s *IBlockStoreStruct) ChainReadObj(p0 context.Context, p1 cid.Cid) ([]byte
 func (s *IBlockStoreStruct) ChainStatObj(p0 context.Context, p1 cid.Cid, p2 cid.Cid) (types.ObjStat, error) {
 	return s.Internal.ChainStatObj(p0, p1, p2)
 }
+func (s *IBlockStoreStruct) ChainStatObjWithDepth(p0 context.Context, p1 cid.Cid, p2 cid.Cid, p3 uint64) (types.ObjStatWithDepth, error) {
+	return s.Internal.ChainStatObjWithDepth(p0, p1, p2, p3)
+}
+func (s *IBlockStoreStruct) DatastoreGC(p0 context.Context) (*types.DatastoreGCResult, error) {
+	return s.Internal.DatastoreGC(p0)
+}
+func (s *IBlockStoreStruct) DatastoreScrub(p0 context.Context, p1 *types.DatastoreScrubOptions) (<-chan *types.DatastoreScrubProgress, error) {
+	return s.Internal.DatastoreScrub(p0, p1)
+}
 
 type IAccountStruct struct {
 	Internal struct {
@@ -61,14 +96,18 @@ func (s *IAccountStruct) StateAccountKey(p0 context.Context, p1 address.Address,
 
 type IActorStruct struct {
 	Internal struct {
-		ListActor     func(ctx context.Context) (map[address.Address]*types.Actor, error)                         `perm:"read"`
-		StateGetActor func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.Actor, error) `perm:"read"`
+		ListActor         func(ctx context.Context) (map[address.Address]*types.Actor, error)                                                    `perm:"read"`
+		StateActorStatObj func(ctx context.Context, actor address.Address, maxDepth uint64, tsk types.TipSetKey) (types.ObjStatWithDepth, error) `perm:"read"`
+		StateGetActor     func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.Actor, error)                            `perm:"read"`
 	}
 }
 
 func (s *IActorStruct) ListActor(p0 context.Context) (map[address.Address]*types.Actor, error) {
 	return s.Internal.ListActor(p0)
 }
+func (s *IActorStruct) StateActorStatObj(p0 context.Context, p1 address.Address, p2 uint64, p3 types.TipSetKey) (types.ObjStatWithDepth, error) {
+	return s.Internal.StateActorStatObj(p0, p1, p2, p3)
+}
 func (s *IActorStruct) StateGetActor(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*types.Actor, error) {
 	return s.Internal.StateGetActor(p0, p1, p2)
 }
@@ -85,46 +124,48 @@ func (s *IBeaconStruct) BeaconGetEntry(p0 context.Context, p1 abi.ChainEpoch) (*
 
 type IMinerStateStruct struct {
 	Internal struct {
-		StateAllMinerFaults                func(ctx context.Context, lookback abi.ChainEpoch, ts types.TipSetKey) ([]*types.Fault, error)                                          `perm:"read"`
-		StateChangedActors                 func(context.Context, cid.Cid, cid.Cid) (map[string]types.Actor, error)                                                                 `perm:"read"`
-		StateCirculatingSupply             func(ctx context.Context, tsk types.TipSetKey) (abi.TokenAmount, error)                                                                 `perm:"read"`
-		StateDealProviderCollateralBounds  func(ctx context.Context, size abi.PaddedPieceSize, verified bool, tsk types.TipSetKey) (types.DealCollateralBounds, error)             `perm:"read"`
-		StateDecodeParams                  func(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, tsk types.TipSetKey) (interface{}, error)        `perm:"read"`
-		StateGetAllocation                 func(ctx context.Context, clientAddr address.Address, allocationID types.AllocationId, tsk types.TipSetKey) (*types.Allocation, error)  `perm:"read"`
-		StateGetAllocationForPendingDeal   func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.Allocation, error)                                            `perm:"read"`
-		StateGetAllocations                func(ctx context.Context, clientAddr address.Address, tsk types.TipSetKey) (map[types.AllocationId]types.Allocation, error)             `perm:"read"`
-		StateGetClaim                      func(ctx context.Context, providerAddr address.Address, claimID types.ClaimId, tsk types.TipSetKey) (*types.Claim, error)               `perm:"read"`
-		StateGetClaims                     func(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) (map[types.ClaimId]types.Claim, error)                     `perm:"read"`
-		StateListActors                    func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                               `perm:"read"`
-		StateListMessages                  func(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch) ([]cid.Cid, error)                       `perm:"read"`
-		StateListMiners                    func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                               `perm:"read"`
-		StateLookupID                      func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)                                           `perm:"read"`
-		StateMarketBalance                 func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MarketBalance, error)                                       `perm:"read"`
-		StateMarketDeals                   func(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error)                                                    `perm:"read"`
-		StateMarketStorageDeal             func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.MarketDeal, error)                                            `perm:"read"`
-		StateMinerActiveSectors            func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)                               `perm:"read"`
-		StateMinerAvailableBalance         func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error)                                                  `perm:"read"`
-		StateMinerDeadlines                func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.Deadline, error)                                         `perm:"read"`
-		StateMinerFaults                   func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                        `perm:"read"`
-		StateMinerInfo                     func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (types.MinerInfo, error)                                          `perm:"read"`
-		StateMinerInitialPledgeCollateral  func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                   `perm:"read"`
-		StateMinerPartitions               func(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error)                          `perm:"read"`
-		StateMinerPower                    func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*types.MinerPower, error)                                         `perm:"read"`
-		StateMinerPreCommitDepositForPower func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                   `perm:"read"`
-		StateMinerProvingDeadline          func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*dline.Info, error)                                              `perm:"read"`
-		StateMinerRecoveries               func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                        `perm:"read"`
-		StateMinerSectorAllocated          func(ctx context.Context, maddr address.Address, s abi.SectorNumber, tsk types.TipSetKey) (bool, error)                                 `perm:"read"`
-		StateMinerSectorCount              func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MinerSectors, error)                                        `perm:"read"`
-		StateMinerSectorSize               func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (abi.SectorSize, error)                                           `perm:"read"`
-		StateMinerSectors                  func(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error) `perm:"read"`
-		StateMinerWorkerAddress            func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (address.Address, error)                                          `perm:"read"`
-		StateReadState                     func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.ActorState, error)                                        `perm:"read"`
-		StateSectorExpiration              func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorExpiration, error)  `perm:"read"`
-		StateSectorGetInfo                 func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*types.SectorOnChainInfo, error)             `perm:"read"`
-		StateSectorPartition               func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorLocation, error)    `perm:"read"`
-		StateSectorPreCommitInfo           func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (types.SectorPreCommitOnChainInfo, error)     `perm:"read"`
-		StateVMCirculatingSupplyInternal   func(ctx context.Context, tsk types.TipSetKey) (types.CirculatingSupply, error)                                                         `perm:"read"`
-		StateVerifiedClientStatus          func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                         `perm:"read"`
+		StateAllMinerFaults                     func(ctx context.Context, lookback abi.ChainEpoch, ts types.TipSetKey) ([]*types.Fault, error)                                          `perm:"read"`
+		StateChangedActors                      func(context.Context, cid.Cid, cid.Cid) (map[string]types.Actor, error)                                                                 `perm:"read"`
+		StateCirculatingSupply                  func(ctx context.Context, tsk types.TipSetKey) (abi.TokenAmount, error)                                                                 `perm:"read"`
+		StateDealProviderCollateralBounds       func(ctx context.Context, size abi.PaddedPieceSize, verified bool, tsk types.TipSetKey) (types.DealCollateralBounds, error)             `perm:"read"`
+		StateDecodeParams                       func(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, tsk types.TipSetKey) (interface{}, error)        `perm:"read"`
+		StateGetAllocation                      func(ctx context.Context, clientAddr address.Address, allocationID types.AllocationId, tsk types.TipSetKey) (*types.Allocation, error)  `perm:"read"`
+		StateGetAllocationForPendingDeal        func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.Allocation, error)                                            `perm:"read"`
+		StateGetAllocations                     func(ctx context.Context, clientAddr address.Address, tsk types.TipSetKey) (map[types.AllocationId]types.Allocation, error)             `perm:"read"`
+		StateGetClaim                           func(ctx context.Context, providerAddr address.Address, claimID types.ClaimId, tsk types.TipSetKey) (*types.Claim, error)               `perm:"read"`
+		StateGetClaims                          func(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) (map[types.ClaimId]types.Claim, error)                     `perm:"read"`
+		StateListActors                         func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                               `perm:"read"`
+		StateListMessages                       func(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch) ([]cid.Cid, error)                       `perm:"read"`
+		StateListMiners                         func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                               `perm:"read"`
+		StateLookupID                           func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)                                           `perm:"read"`
+		StateMarketBalance                      func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MarketBalance, error)                                       `perm:"read"`
+		StateMarketDeals                        func(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error)                                                    `perm:"read"`
+		StateMarketStorageDeal                  func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.MarketDeal, error)                                            `perm:"read"`
+		StateMinerActiveSectors                 func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)                               `perm:"read"`
+		StateMinerAvailableBalance              func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error)                                                  `perm:"read"`
+		StateMinerDeadlines                     func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.Deadline, error)                                         `perm:"read"`
+		StateMinerFaults                        func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                        `perm:"read"`
+		StateMinerInfo                          func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (types.MinerInfo, error)                                          `perm:"read"`
+		StateMinerInitialPledgeCollateral       func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                   `perm:"read"`
+		StateMinerPartitions                    func(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error)                          `perm:"read"`
+		StateMinerPower                         func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*types.MinerPower, error)                                         `perm:"read"`
+		StateMinerPreCommitDepositForPower      func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                   `perm:"read"`
+		StateMinerProvingDeadline               func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*dline.Info, error)                                              `perm:"read"`
+		StateMinerProvingDeadlineWithPartitions func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*types.ProvingDeadline, error)                                   `perm:"read"`
+		StateMinerRecoveries                    func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                        `perm:"read"`
+		StateMinerSectorAllocated               func(ctx context.Context, maddr address.Address, s abi.SectorNumber, tsk types.TipSetKey) (bool, error)                                 `perm:"read"`
+		StateMinerSectorCount                   func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MinerSectors, error)                                        `perm:"read"`
+		StateMinerSectorSize                    func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (abi.SectorSize, error)                                           `perm:"read"`
+		StateMinerSectors                       func(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error) `perm:"read"`
+		StateMinerWorkerAddress                 func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (address.Address, error)                                          `perm:"read"`
+		StateReadState                          func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.ActorState, error)                                        `perm:"read"`
+		StateSectorExpiration                   func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorExpiration, error)  `perm:"read"`
+		StateSectorGetInfo                      func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*types.SectorOnChainInfo, error)             `perm:"read"`
+		StateSectorPartition                    func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorLocation, error)    `perm:"read"`
+		StateSectorPreCommitInfo                func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (types.SectorPreCommitOnChainInfo, error)     `perm:"read"`
+		StateSupplyHistory                      func(ctx context.Context, from, to, step abi.ChainEpoch, tsk types.TipSetKey) ([]*types.SupplyPoint, error)                             `perm:"read"`
+		StateVMCirculatingSupplyInternal        func(ctx context.Context, tsk types.TipSetKey) (types.CirculatingSupply, error)                                                         `perm:"read"`
+		StateVerifiedClientStatus               func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                         `perm:"read"`
 	}
 }
 
@@ -209,6 +250,9 @@ func (s *IMinerStateStruct) StateMinerPreCommitDepositForPower(p0 context.Contex
 func (s *IMinerStateStruct) StateMinerProvingDeadline(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*dline.Info, error) {
 	return s.Internal.StateMinerProvingDeadline(p0, p1, p2)
 }
+func (s *IMinerStateStruct) StateMinerProvingDeadlineWithPartitions(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*types.ProvingDeadline, error) {
+	return s.Internal.StateMinerProvingDeadlineWithPartitions(p0, p1, p2)
+}
 func (s *IMinerStateStruct) StateMinerRecoveries(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (bitfield.BitField, error) {
 	return s.Internal.StateMinerRecoveries(p0, p1, p2)
 }
@@ -242,6 +286,9 @@ func (s *IMinerStateStruct) StateSectorPartition(p0 context.Context, p1 address.
 func (s *IMinerStateStruct) StateSectorPreCommitInfo(p0 context.Context, p1 address.Address, p2 abi.SectorNumber, p3 types.TipSetKey) (types.SectorPreCommitOnChainInfo, error) {
 	return s.Internal.StateSectorPreCommitInfo(p0, p1, p2, p3)
 }
+func (s *IMinerStateStruct) StateSupplyHistory(p0 context.Context, p1, p2, p3 abi.ChainEpoch, p4 types.TipSetKey) ([]*types.SupplyPoint, error) {
+	return s.Internal.StateSupplyHistory(p0, p1, p2, p3, p4)
+}
 func (s *IMinerStateStruct) StateVMCirculatingSupplyInternal(p0 context.Context, p1 types.TipSetKey) (types.CirculatingSupply, error) {
 	return s.Internal.StateVMCirculatingSupplyInternal(p0, p1)
 }
@@ -255,6 +302,7 @@ type IChainInfoStruct struct {
 		ChainExport                   func(context.Context, abi.ChainEpoch, bool, types.TipSetKey) (<-chan []byte, error)                                                                          `perm:"read"`
 		ChainGetBlock                 func(ctx context.Context, id cid.Cid) (*types.BlockHeader, error)                                                                                            `perm:"read"`
 		ChainGetBlockMessages         func(ctx context.Context, bid cid.Cid) (*types.BlockMessages, error)                                                                                         `perm:"read"`
+		ChainGetFinalizedHead         func(ctx context.Context) (*types.FinalizedHead, error)                                                                                                      `perm:"read"`
 		ChainGetGenesis               func(context.Context) (*types.TipSet, error)                                                                                                                 `perm:"read"`
 		ChainGetMessage               func(ctx context.Context, msgID cid.Cid) (*types.Message, error)                                                                                             `perm:"read"`
 		ChainGetMessagesInTipset      func(ctx context.Context, key types.TipSetKey) ([]types.MessageCID, error)                                                                                   `perm:"read"`
@@ -278,6 +326,7 @@ type IChainInfoStruct struct {
 		ResolveToKeyAddr              func(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error)                                                                   `perm:"read"`
 		StateActorCodeCIDs            func(context.Context, network.Version) (map[string]cid.Cid, error)                                                                                           `perm:"read"`
 		StateActorManifestCID         func(context.Context, network.Version) (cid.Cid, error)                                                                                                      `perm:"read"`
+		StateActorNames               func(ctx context.Context, codes []cid.Cid) ([]*types.ActorCodeName, error)                                                                                   `perm:"read"`
 		StateCall                     func(ctx context.Context, msg *types.Message, tsk types.TipSetKey) (*types.InvocResult, error)                                                               `perm:"read"`
 		StateCompute                  func(context.Context, abi.ChainEpoch, []*types.Message, types.TipSetKey) (*types.ComputeStateOutput, error)                                                  `perm:"read"`
 		StateGetNetworkParams         func(ctx context.Context) (*types.NetworkParams, error)                                                                                                      `perm:"read"`
@@ -309,6 +358,9 @@ func (s *IChainInfoStruct) ChainGetBlock(p0 context.Context, p1 cid.Cid) (*types
 func (s *IChainInfoStruct) ChainGetBlockMessages(p0 context.Context, p1 cid.Cid) (*types.BlockMessages, error) {
 	return s.Internal.ChainGetBlockMessages(p0, p1)
 }
+func (s *IChainInfoStruct) ChainGetFinalizedHead(p0 context.Context) (*types.FinalizedHead, error) {
+	return s.Internal.ChainGetFinalizedHead(p0)
+}
 func (s *IChainInfoStruct) ChainGetGenesis(p0 context.Context) (*types.TipSet, error) {
 	return s.Internal.ChainGetGenesis(p0)
 }
@@ -378,6 +430,9 @@ func (s *IChainInfoStruct) StateActorCodeCIDs(p0 context.Context, p1 network.Ver
 func (s *IChainInfoStruct) StateActorManifestCID(p0 context.Context, p1 network.Version) (cid.Cid, error) {
 	return s.Internal.StateActorManifestCID(p0, p1)
 }
+func (s *IChainInfoStruct) StateActorNames(p0 context.Context, p1 []cid.Cid) ([]*types.ActorCodeName, error) {
+	return s.Internal.StateActorNames(p0, p1)
+}
 func (s *IChainInfoStruct) StateCall(p0 context.Context, p1 *types.Message, p2 types.TipSetKey) (*types.InvocResult, error) {
 	return s.Internal.StateCall(p0, p1, p2)
 }
@@ -468,9 +523,10 @@ type IMessagePoolStruct struct {
 		GasEstimateMessageGas      func(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec, tsk types.TipSetKey) (*types.Message, error)                      `perm:"read"`
 		MpoolBatchPush             func(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                   `perm:"write"`
 		MpoolBatchPushMessage      func(ctx context.Context, msgs []*types.Message, spec *types.MessageSendSpec) ([]*types.SignedMessage, error)                                `perm:"sign"`
-		MpoolBatchPushUntrusted    func(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                   `perm:"write"`
+		MpoolBatchPushUntrusted    func(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                   `perm:"read"`
 		MpoolClear                 func(ctx context.Context, local bool) error                                                                                                  `perm:"write"`
 		MpoolDeleteByAdress        func(ctx context.Context, addr address.Address) error                                                                                        `perm:"admin"`
+		MpoolEstimateInclusion     func(ctx context.Context, msg *types.Message, nblocksincl uint64) (*types.InclusionEstimate, error)                                          `perm:"read"`
 		MpoolGetConfig             func(context.Context) (*types.MpoolConfig, error)                                                                                            `perm:"read"`
 		MpoolGetNonce              func(ctx context.Context, addr address.Address) (uint64, error)                                                                              `perm:"read"`
 		MpoolPending               func(ctx context.Context, tsk types.TipSetKey) ([]*types.SignedMessage, error)                                                               `perm:"read"`
@@ -478,11 +534,13 @@ type IMessagePoolStruct struct {
 		MpoolPublishMessage        func(ctx context.Context, smsg *types.SignedMessage) error                                                                                   `perm:"admin"`
 		MpoolPush                  func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                        `perm:"write"`
 		MpoolPushMessage           func(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error)                                     `perm:"sign"`
-		MpoolPushUntrusted         func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                        `perm:"write"`
+		MpoolPushMessageWithID     func(ctx context.Context, id types.UUID, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error)                      `perm:"sign"`
+		MpoolPushUntrusted         func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                        `perm:"read"`
 		MpoolSelect                func(context.Context, types.TipSetKey, float64) ([]*types.SignedMessage, error)                                                              `perm:"read"`
 		MpoolSelects               func(context.Context, types.TipSetKey, []float64) ([][]*types.SignedMessage, error)                                                          `perm:"read"`
 		MpoolSetConfig             func(ctx context.Context, cfg *types.MpoolConfig) error                                                                                      `perm:"admin"`
 		MpoolSub                   func(ctx context.Context) (<-chan types.MpoolUpdate, error)                                                                                  `perm:"read"`
+		MpoolSubFiltered           func(ctx context.Context, filter *types.MpoolSubFilter) (<-chan types.MpoolUpdate, error)                                                    `perm:"read"`
 	}
 }
 
@@ -516,6 +574,9 @@ func (s *IMessagePoolStruct) MpoolClear(p0 context.Context, p1 bool) error {
 func (s *IMessagePoolStruct) MpoolDeleteByAdress(p0 context.Context, p1 address.Address) error {
 	return s.Internal.MpoolDeleteByAdress(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolEstimateInclusion(p0 context.Context, p1 *types.Message, p2 uint64) (*types.InclusionEstimate, error) {
+	return s.Internal.MpoolEstimateInclusion(p0, p1, p2)
+}
 func (s *IMessagePoolStruct) MpoolGetConfig(p0 context.Context) (*types.MpoolConfig, error) {
 	return s.Internal.MpoolGetConfig(p0)
 }
@@ -537,6 +598,9 @@ func (s *IMessagePoolStruct) MpoolPush(p0 context.Context, p1 *types.SignedMessa
 func (s *IMessagePoolStruct) MpoolPushMessage(p0 context.Context, p1 *types.Message, p2 *types.MessageSendSpec) (*types.SignedMessage, error) {
 	return s.Internal.MpoolPushMessage(p0, p1, p2)
 }
+func (s *IMessagePoolStruct) MpoolPushMessageWithID(p0 context.Context, p1 types.UUID, p2 *types.Message, p3 *types.MessageSendSpec) (*types.SignedMessage, error) {
+	return s.Internal.MpoolPushMessageWithID(p0, p1, p2, p3)
+}
 func (s *IMessagePoolStruct) MpoolPushUntrusted(p0 context.Context, p1 *types.SignedMessage) (cid.Cid, error) {
 	return s.Internal.MpoolPushUntrusted(p0, p1)
 }
@@ -552,6 +616,9 @@ func (s *IMessagePoolStruct) MpoolSetConfig(p0 context.Context, p1 *types.MpoolC
 func (s *IMessagePoolStruct) MpoolSub(p0 context.Context) (<-chan types.MpoolUpdate, error) {
 	return s.Internal.MpoolSub(p0)
 }
+func (s *IMessagePoolStruct) MpoolSubFiltered(p0 context.Context, p1 *types.MpoolSubFilter) (<-chan types.MpoolUpdate, error) {
+	return s.Internal.MpoolSubFiltered(p0, p1)
+}
 
 type INetworkStruct struct {
 	Internal struct {
@@ -575,6 +642,7 @@ type INetworkStruct struct {
 		NetProtectList              func(ctx context.Context) ([]peer.ID, error)                           `perm:"read"`
 		NetProtectRemove            func(ctx context.Context, acl []peer.ID) error                         `perm:"admin"`
 		NetPubsubScores             func(context.Context) ([]types.PubsubScore, error)                     `perm:"read"`
+		NetPubsubTopics             func(context.Context) ([]types.PubsubTopic, error)                     `perm:"read"`
 	}
 }
 
@@ -636,6 +704,9 @@ func (s *INetworkStruct) NetProtectRemove(p0 context.Context, p1 []peer.ID) erro
 func (s *INetworkStruct) NetPubsubScores(p0 context.Context) ([]types.PubsubScore, error) {
 	return s.Internal.NetPubsubScores(p0)
 }
+func (s *INetworkStruct) NetPubsubTopics(p0 context.Context) ([]types.PubsubTopic, error) {
+	return s.Internal.NetPubsubTopics(p0)
+}
 
 type IPaychanStruct struct {
 	Internal struct {
@@ -709,14 +780,15 @@ func (s *IPaychanStruct) PaychVoucherSubmit(p0 context.Context, p1 address.Addre
 
 type ISyncerStruct struct {
 	Internal struct {
-		ChainSyncHandleNewTipSet func(ctx context.Context, ci *types.ChainInfo) error            `perm:"write"`
-		ChainTipSetWeight        func(ctx context.Context, tsk types.TipSetKey) (big.Int, error) `perm:"read"`
-		Concurrent               func(ctx context.Context) int64                                 `perm:"read"`
-		SetConcurrent            func(ctx context.Context, concurrent int64) error               `perm:"admin"`
-		SyncIncomingBlocks       func(ctx context.Context) (<-chan *types.BlockHeader, error)    `perm:"read"`
-		SyncState                func(ctx context.Context) (*types.SyncState, error)             `perm:"read"`
-		SyncSubmitBlock          func(ctx context.Context, blk *types.BlockMsg) error            `perm:"write"`
-		SyncerTracker            func(ctx context.Context) *types.TargetTracker                  `perm:"read"`
+		ChainSyncHandleNewTipSet func(ctx context.Context, ci *types.ChainInfo) error                             `perm:"write"`
+		ChainTipSetWeight        func(ctx context.Context, tsk types.TipSetKey) (big.Int, error)                  `perm:"read"`
+		Concurrent               func(ctx context.Context) int64                                                  `perm:"read"`
+		SetConcurrent            func(ctx context.Context, concurrent int64) error                                `perm:"admin"`
+		SyncIncomingBlocks       func(ctx context.Context) (<-chan *types.BlockHeader, error)                     `perm:"read"`
+		SyncState                func(ctx context.Context) (*types.SyncState, error)                              `perm:"read"`
+		SyncSubmitBlock          func(ctx context.Context, blk *types.BlockMsg) error                             `perm:"write"`
+		SyncSubmitBlockChecked   func(ctx context.Context, blk *types.BlockMsg) (*types.SubmitBlockResult, error) `perm:"write"`
+		SyncerTracker            func(ctx context.Context) *types.TargetTracker                                   `perm:"read"`
 	}
 }
 
@@ -739,6 +811,9 @@ func (s *ISyncerStruct) SyncState(p0 context.Context) (*types.SyncState, error)
 func (s *ISyncerStruct) SyncSubmitBlock(p0 context.Context, p1 *types.BlockMsg) error {
 	return s.Internal.SyncSubmitBlock(p0, p1)
 }
+func (s *ISyncerStruct) SyncSubmitBlockChecked(p0 context.Context, p1 *types.BlockMsg) (*types.SubmitBlockResult, error) {
+	return s.Internal.SyncSubmitBlockChecked(p0, p1)
+}
 func (s *ISyncerStruct) SyncerTracker(p0 context.Context) *types.TargetTracker {
 	return s.Internal.SyncerTracker(p0)
 }
@@ -822,6 +897,7 @@ func (s *ICommonStruct) Version(p0 context.Context) (types.Version, error) {
 }
 
 type FullNodeStruct struct {
+	IAuthStruct
 	IBlockStoreStruct
 	IChainStruct
 	IMarketStruct
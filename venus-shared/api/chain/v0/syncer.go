@@ -15,7 +15,10 @@ type ISyncer interface {
 	Concurrent(ctx context.Context) int64                                        //perm:read
 	ChainTipSetWeight(ctx context.Context, tsk types.TipSetKey) (big.Int, error) //perm:read
 	SyncSubmitBlock(ctx context.Context, blk *types.BlockMsg) error              //perm:write
-	SyncState(ctx context.Context) (*types.SyncState, error)                     //perm:read
+	// SyncSubmitBlockChecked validates the block and submits it unless the miner has another block at the epoch among
+	// the blocks seen by the node or the slash filter finds it would be slashed, the result telling why it is refused.
+	SyncSubmitBlockChecked(ctx context.Context, blk *types.BlockMsg) (*types.SubmitBlockResult, error) //perm:write
+	SyncState(ctx context.Context) (*types.SyncState, error)                                           //perm:read
 	// SyncIncomingBlocks returns a channel streaming incoming, potentially not
 	// yet synced block headers.
 	SyncIncomingBlocks(ctx context.Context) (<-chan *types.BlockHeader, error) //perm:read
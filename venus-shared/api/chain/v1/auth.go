@@ -0,0 +1,23 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type IAuth interface {
+	// AuthNew issues a new token carrying `perms` which never expires, it is named after a random uuid
+	AuthNew(ctx context.Context, perms []auth.Permission) ([]byte, error) //perm:admin
+	// AuthNewNamed issues a new token named `name` carrying `perms`, an `expiry` of zero means the token never expires
+	AuthNewNamed(ctx context.Context, name string, perms []auth.Permission, expiry time.Duration) ([]byte, error) //perm:admin
+	// AuthVerify returns the permissions carried by the token, revoked or expired tokens are rejected
+	AuthVerify(ctx context.Context, token string) ([]auth.Permission, error) //perm:read
+	// AuthList lists all the tokens issued by the node, including revoked ones
+	AuthList(ctx context.Context) ([]*types.AuthTokenInfo, error) //perm:admin
+	// AuthRevoke revokes the token named `name`, it will be rejected by all subsequent requests
+	AuthRevoke(ctx context.Context, name string) error //perm:admin
+}
@@ -13,6 +13,19 @@ type IBlockStore interface {
 	ChainDeleteObj(ctx context.Context, obj cid.Cid) error                              //perm:admin
 	ChainHasObj(ctx context.Context, obj cid.Cid) (bool, error)                         //perm:read
 	ChainStatObj(ctx context.Context, obj cid.Cid, base cid.Cid) (types.ObjStat, error) //perm:read
-	// ChainPutObj puts a given object into the block store
+	// ChainStatObjWithDepth is like ChainStatObj, but does not follow the links deeper than maxDepth, 0 is unlimited
+	ChainStatObjWithDepth(ctx context.Context, obj cid.Cid, base cid.Cid, maxDepth uint64) (types.ObjStatWithDepth, error) //perm:read
+	// ChainPutObj puts a given object into the block store. The data must hash to the cid of the object, and an object
+	// the block store did not have counts against the stagedObjQuota of the datastore config until it is deleted.
 	ChainPutObj(context.Context, blocks.Block) error //perm:admin
+	// ChainStageObj puts the raw data of the object into the block store, it must hash to the cid. The objects the
+	// block store did not have count against the stagedObjQuota of the datastore config until they are deleted.
+	ChainStageObj(ctx context.Context, obj cid.Cid, data []byte) error //perm:admin
+	// ChainStagedObjs counts the objects staged with ChainPutObj and ChainStageObj
+	ChainStagedObjs(ctx context.Context) (*types.StagedObjStat, error) //perm:admin
+	// DatastoreGC runs a value log garbage collection of the blockstore and reports the space reclaimed
+	DatastoreGC(ctx context.Context) (*types.DatastoreGCResult, error) //perm:admin
+	// DatastoreScrub hashes every block of the blockstore again to find the corrupt ones, and sends its progress until
+	// it is done. Only one scrub runs at once.
+	DatastoreScrub(ctx context.Context, opts *types.DatastoreScrubOptions) (<-chan *types.DatastoreScrubProgress, error) //perm:admin
 }
@@ -28,21 +28,32 @@ type IChain interface {
 
 type IAccount interface {
 	StateAccountKey(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error) //perm:read
+	// StateAccountKeyBySelector is StateAccountKey at the tipset selected by tss
+	StateAccountKeyBySelector(ctx context.Context, addr address.Address, tss types.TipSetSelector) (address.Address, error) //perm:read
 }
 
 type IActor interface {
 	StateGetActor(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.Actor, error) //perm:read
-	ListActor(ctx context.Context) (map[address.Address]*types.Actor, error)                             //perm:read
+	// StateGetActorBySelector is StateGetActor at the tipset selected by tss
+	StateGetActorBySelector(ctx context.Context, actor address.Address, tss types.TipSetSelector) (*types.Actor, error) //perm:read
+	ListActor(ctx context.Context) (map[address.Address]*types.Actor, error)                                            //perm:read
+	// StateActorStatObj returns the number of blocks and bytes reachable from the head of the actor, links deeper than maxDepth are not followed, 0 is unlimited
+	StateActorStatObj(ctx context.Context, actor address.Address, maxDepth uint64, tsk types.TipSetKey) (types.ObjStatWithDepth, error) //perm:read
 }
 
 type IChainInfo interface {
-	BlockTime(ctx context.Context) time.Duration                                                                                                                                          //perm:read
-	ChainList(ctx context.Context, tsKey types.TipSetKey, count int) ([]types.TipSetKey, error)                                                                                           //perm:read
-	ChainHead(ctx context.Context) (*types.TipSet, error)                                                                                                                                 //perm:read
-	ChainSetHead(ctx context.Context, key types.TipSetKey) error                                                                                                                          //perm:admin
-	ChainGetTipSet(ctx context.Context, key types.TipSetKey) (*types.TipSet, error)                                                                                                       //perm:read
-	ChainGetTipSetByHeight(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                        //perm:read
-	ChainGetTipSetAfterHeight(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                     //perm:read
+	BlockTime(ctx context.Context) time.Duration                                                //perm:read
+	ChainList(ctx context.Context, tsKey types.TipSetKey, count int) ([]types.TipSetKey, error) //perm:read
+	ChainHead(ctx context.Context) (*types.TipSet, error)                                       //perm:read
+	// ChainGetFinalizedHead returns the latest tipset the node considers final, which is ChainFinality epochs
+	// below the head
+	ChainGetFinalizedHead(ctx context.Context) (*types.FinalizedHead, error)                                          //perm:read
+	ChainSetHead(ctx context.Context, key types.TipSetKey) error                                                      //perm:admin
+	ChainGetTipSet(ctx context.Context, key types.TipSetKey) (*types.TipSet, error)                                   //perm:read
+	ChainGetTipSetByHeight(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)    //perm:read
+	ChainGetTipSetAfterHeight(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error) //perm:read
+	// ChainGetTipSetBySelector returns the tipset selected by its key, its height or a tag such as finalized
+	ChainGetTipSetBySelector(ctx context.Context, tss types.TipSetSelector) (*types.TipSet, error)                                                                                        //perm:read
 	StateGetRandomnessFromTickets(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) //perm:read
 	StateGetRandomnessFromBeacon(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error)  //perm:read
 	// StateGetRandomnessDigestFromTickets is used to sample the chain for randomness.
@@ -52,11 +63,14 @@ type IChainInfo interface {
 	// StateGetBeaconEntry returns the beacon entry for the given filecoin epoch. If
 	// the entry has not yet been produced, the call will block until the entry
 	// becomes available
-	StateGetBeaconEntry(ctx context.Context, epoch abi.ChainEpoch) (*types.BeaconEntry, error)                     //perm:read
-	ChainGetBlock(ctx context.Context, id cid.Cid) (*types.BlockHeader, error)                                     //perm:read
-	ChainGetMessage(ctx context.Context, msgID cid.Cid) (*types.Message, error)                                    //perm:read
-	ChainGetBlockMessages(ctx context.Context, bid cid.Cid) (*types.BlockMessages, error)                          //perm:read
-	ChainGetMessagesInTipset(ctx context.Context, key types.TipSetKey) ([]types.MessageCID, error)                 //perm:read
+	StateGetBeaconEntry(ctx context.Context, epoch abi.ChainEpoch) (*types.BeaconEntry, error)     //perm:read
+	ChainGetBlock(ctx context.Context, id cid.Cid) (*types.BlockHeader, error)                     //perm:read
+	ChainGetMessage(ctx context.Context, msgID cid.Cid) (*types.Message, error)                    //perm:read
+	ChainGetBlockMessages(ctx context.Context, bid cid.Cid) (*types.BlockMessages, error)          //perm:read
+	ChainGetMessagesInTipset(ctx context.Context, key types.TipSetKey) ([]types.MessageCID, error) //perm:read
+	// ChainGetResolvedMessagesInTipset returns the deduplicated messages of the tipset, in the order of execution,
+	// with the ID addresses of their senders and receivers
+	ChainGetResolvedMessagesInTipset(ctx context.Context, key types.TipSetKey) ([]types.ResolvedMessage, error)    //perm:read
 	ChainGetReceipts(ctx context.Context, id cid.Cid) ([]types.MessageReceipt, error)                              //perm:read
 	ChainGetParentMessages(ctx context.Context, bcid cid.Cid) ([]types.MessageCID, error)                          //perm:read
 	ChainGetParentReceipts(ctx context.Context, bcid cid.Cid) ([]*types.MessageReceipt, error)                     //perm:read
@@ -112,14 +126,24 @@ type IChainInfo interface {
 	ChainGetPath(ctx context.Context, from types.TipSetKey, to types.TipSetKey) ([]*types.HeadChange, error)                              //perm:read
 	// StateGetNetworkParams return current network params
 	StateGetNetworkParams(ctx context.Context) (*types.NetworkParams, error) //perm:read
+	// StateNetworkUpgradeSchedule returns the network versions the chain goes through, in order, with their first
+	// epoch, their actors version and the cid of their actors bundle
+	StateNetworkUpgradeSchedule(ctx context.Context) ([]*types.NetworkUpgrade, error) //perm:read
+	// StateNetworkVersionAt returns the network version of the epoch from the upgrade schedule, the epoch does not
+	// need to be on the chain
+	StateNetworkVersionAt(ctx context.Context, height abi.ChainEpoch) (network.Version, error) //perm:read
 	// StateActorCodeCIDs returns the CIDs of all the builtin actors for the given network version
 	StateActorCodeCIDs(context.Context, network.Version) (map[string]cid.Cid, error) //perm:read
+	// StateActorNames returns the name and version of the builtin actors of the codes, nil for an unknown code
+	StateActorNames(ctx context.Context, codes []cid.Cid) ([]*types.ActorCodeName, error) //perm:read
 	// ChainGetGenesis returns the genesis tipset.
 	ChainGetGenesis(context.Context) (*types.TipSet, error) //perm:read
 	// StateActorManifestCID returns the CID of the builtin actors manifest for the given network version
 	StateActorManifestCID(context.Context, network.Version) (cid.Cid, error)                            //perm:read
 	StateCall(ctx context.Context, msg *types.Message, tsk types.TipSetKey) (*types.InvocResult, error) //perm:read
-	StateReplay(context.Context, types.TipSetKey, cid.Cid) (*types.InvocResult, error)                  //perm:read
+	// StateCallBySelector is StateCall at the tipset selected by tss
+	StateCallBySelector(ctx context.Context, msg *types.Message, tss types.TipSetSelector) (*types.InvocResult, error) //perm:read
+	StateReplay(context.Context, types.TipSetKey, cid.Cid) (*types.InvocResult, error)                                 //perm:read
 	// ChainGetEvents returns the events under an event AMT root CID.
 	ChainGetEvents(context.Context, cid.Cid) ([]types.Event, error) //perm:read
 	// StateCompute is a flexible command that applies the given messages on the given tipset.
@@ -155,14 +179,26 @@ type IChainInfo interface {
 	// Messages in the `apply` parameter must have the correct nonces, and gas
 	// values set.
 	StateCompute(context.Context, abi.ChainEpoch, []*types.Message, types.TipSetKey) (*types.ComputeStateOutput, error) //perm:read
+	// ChainGCStatus returns the state of the garbage collection of the orphaned chain branches
+	ChainGCStatus(ctx context.Context) (types.ChainGCStatus, error) //perm:read
+	// ChainOffloadStatus returns the state of the offload of the old chain data to the object storage
+	ChainOffloadStatus(ctx context.Context) (types.ChainOffloadStatus, error) //perm:read
+	// ChainHotGC offloads the chain data deeper than the offload depth, then reclaims its space in the hot blockstore
+	ChainHotGC(ctx context.Context) (*types.ChainOffloadStats, error) //perm:admin
+	// ChainOffloadRestore copies the offloaded chain data of the canonical tipsets between the heights from and to,
+	// included, back to the hot blockstore
+	ChainOffloadRestore(ctx context.Context, from, to abi.ChainEpoch) (*types.ChainOffloadStats, error) //perm:admin
 }
 
 type IMinerState interface {
-	StateReadState(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.ActorState, error)                                    //perm:read
-	StateListMessages(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch) ([]cid.Cid, error)                //perm:read
-	StateDecodeParams(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, tsk types.TipSetKey) (interface{}, error) //perm:read
-	StateEncodeParams(ctx context.Context, toActCode cid.Cid, method abi.MethodNum, params json.RawMessage) ([]byte, error)                       //perm:read
-	StateMinerSectorAllocated(ctx context.Context, maddr address.Address, s abi.SectorNumber, tsk types.TipSetKey) (bool, error)                  //perm:read
+	StateReadState(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.ActorState, error)                     //perm:read
+	StateListMessages(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch) ([]cid.Cid, error) //perm:read
+	// StateListMatchedMessages looks back from the tipset to the given height like StateListMessages, and returns the
+	// matching messages with their tipset and, when decodeParams is set, their decoded params.
+	StateListMatchedMessages(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch, decodeParams bool) ([]*types.MatchedMessage, error) //perm:read
+	StateDecodeParams(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, tsk types.TipSetKey) (interface{}, error)                          //perm:read
+	StateEncodeParams(ctx context.Context, toActCode cid.Cid, method abi.MethodNum, params json.RawMessage) ([]byte, error)                                                //perm:read
+	StateMinerSectorAllocated(ctx context.Context, maddr address.Address, s abi.SectorNumber, tsk types.TipSetKey) (bool, error)                                           //perm:read
 	// StateSectorPreCommitInfo returns the PreCommit info for the specified miner's sector.
 	// Returns nil and no error if the sector isn't precommitted.
 	//
@@ -179,10 +215,14 @@ type IMinerState interface {
 	StateAllMinerFaults(ctx context.Context, lookback abi.ChainEpoch, ts types.TipSetKey) ([]*types.Fault, error)                                            //perm:read
 	StateMinerRecoveries(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                         //perm:read
 	StateMinerProvingDeadline(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*dline.Info, error)                                          //perm:read
+	StateMinerProvingDeadlineWithPartitions(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*types.ProvingDeadline, error)                 //perm:read
 	StateMinerPartitions(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error)                           //perm:read
 	StateMinerDeadlines(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.Deadline, error)                                           //perm:read
 	StateMinerSectors(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)     //perm:read
 	StateMarketStorageDeal(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.MarketDeal, error)                                           //perm:read
+	// SubscribeDealUpdates emits the activation, slashing and expiry of the given deals as the chain advances,
+	// and the same updates marked as reverted when a reorg undoes them.
+	SubscribeDealUpdates(ctx context.Context, dealIDs []abi.DealID) (<-chan []*types.DealUpdate, error) //perm:read
 	// StateGetAllocationForPendingDeal returns the allocation for a given deal ID of a pending deal. Returns nil if
 	// pending allocation is not found.
 	StateGetAllocationForPendingDeal(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.Allocation, error) //perm:read
@@ -204,11 +244,19 @@ type IMinerState interface {
 	StateComputeDataCID(ctx context.Context, maddr address.Address, sectorType abi.RegisteredSealProof, deals []abi.DealID, tsk types.TipSetKey) (cid.Cid, error) //perm:read
 	StateMinerPreCommitDepositForPower(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)           //perm:read
 	StateMinerInitialPledgeCollateral(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)            //perm:read
-	StateVMCirculatingSupplyInternal(ctx context.Context, tsk types.TipSetKey) (types.CirculatingSupply, error)                                                   //perm:read
-	StateCirculatingSupply(ctx context.Context, tsk types.TipSetKey) (abi.TokenAmount, error)                                                                     //perm:read
-	StateMarketDeals(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error)                                                              //perm:read
-	StateMinerActiveSectors(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)                                  //perm:read
-	StateLookupID(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)                                                        //perm:read
+	// StateMinerSectorCollateral returns the deposit, the pledge and the termination penalties of a sector sealed at tsk
+	StateMinerSectorCollateral(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (*types.SectorCollateral, error) //perm:read
+	// StateSectorBatchEstimate returns the bounds and the network fee of a batch of size sectors messages of the given kind
+	StateSectorBatchEstimate(ctx context.Context, kind types.SectorBatchKind, size int, tsk types.TipSetKey) (*types.SectorBatchEstimate, error) //perm:read
+	StateVMCirculatingSupplyInternal(ctx context.Context, tsk types.TipSetKey) (types.CirculatingSupply, error)                                  //perm:read
+	StateCirculatingSupply(ctx context.Context, tsk types.TipSetKey) (abi.TokenAmount, error)                                                    //perm:read
+	// StateSupplyHistory returns the circulating supply and the burnt funds every step epochs from from to to, on the chain of tsk
+	StateSupplyHistory(ctx context.Context, from, to, step abi.ChainEpoch, tsk types.TipSetKey) ([]*types.SupplyPoint, error)    //perm:read
+	StateMarketDeals(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error)                             //perm:read
+	StateMinerActiveSectors(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error) //perm:read
+	StateLookupID(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)                       //perm:read
+	// StateLookupIDBySelector is StateLookupID at the tipset selected by tss
+	StateLookupIDBySelector(ctx context.Context, addr address.Address, tss types.TipSetSelector) (address.Address, error) //perm:read
 	// StateLookupRobustAddress returns the public key address of the given ID address for non-account addresses (multisig, miners etc)
 	StateLookupRobustAddress(context.Context, address.Address, types.TipSetKey) (address.Address, error)                                                     //perm:read
 	StateListMiners(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                     //perm:read
@@ -221,6 +269,12 @@ type IMinerState interface {
 	StateMarketBalance(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MarketBalance, error)                                          //perm:read
 	StateDealProviderCollateralBounds(ctx context.Context, size abi.PaddedPieceSize, verified bool, tsk types.TipSetKey) (types.DealCollateralBounds, error) //perm:read
 	StateVerifiedClientStatus(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                     //perm:read
+	// StateListVerifiers returns the verifiers with their remaining allowance, ordered by address
+	StateListVerifiers(ctx context.Context, tsk types.TipSetKey) ([]types.DataCapEntry, error) //perm:read
+	// StateListVerifiedClients returns the verified clients with their datacap, ordered by address
+	StateListVerifiedClients(ctx context.Context, tsk types.TipSetKey) ([]types.DataCapEntry, error) //perm:read
+	// StateRemoveDataCapProposalID returns the id the next proposal of verifier to remove datacap of client must have
+	StateRemoveDataCapProposalID(ctx context.Context, verifier address.Address, client address.Address, tsk types.TipSetKey) (uint64, error) //perm:read
 	// StateMinerAllocated returns a bitfield containing all sector numbers marked as allocated in miner state
 	StateMinerAllocated(context.Context, address.Address, types.TipSetKey) (*bitfield.BitField, error) //perm:read
 }
@@ -85,6 +85,16 @@ type IETH interface {
 	EthTraceBlock(ctx context.Context, blkNum string) ([]*types.EthTraceBlock, error) //perm:read
 	// Replays all transactions in a block returning the requested traces for each transaction
 	EthTraceReplayBlockTransactions(ctx context.Context, blkNum string, traceTypes []string) ([]*types.EthTraceReplayBlockTransaction, error) //perm:read
+	// Returns an OpenEthereum-compatible trace of the given transaction (implementing `trace_transaction`)
+	EthTraceTransaction(ctx context.Context, txHash string) ([]*types.EthTraceTransaction, error) //perm:read
+	// Returns the calls of the given transaction in the format of the "callTracer" of geth (implementing
+	// `debug_traceTransaction`), built from the same traces as `trace_transaction`. The opcode level traces of the
+	// default geth tracer are not available from the FVM, so "callTracer" is the only tracer supported.
+	// The params are the transaction hash and an optional tracer config, see types.EthDebugTraceParams.
+	EthDebugTraceTransaction(ctx context.Context, p jsonrpc.RawParams) (*types.EthCallFrame, error) //perm:read
+	// Returns the calls of each transaction of the given block in the format of the "callTracer" of geth
+	// (implementing `debug_traceBlockByNumber`). The params are the block number and an optional tracer config.
+	EthDebugTraceBlockByNumber(ctx context.Context, p jsonrpc.RawParams) ([]*types.EthTxTraceResult, error) //perm:read
 }
 
 type IETHEvent interface {
@@ -0,0 +1,111 @@
+// Package failover builds v1 FullNode clients served by several equivalent venus nodes
+package failover
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc"
+
+	"github.com/filecoin-project/venus/venus-shared/api"
+	v1 "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+)
+
+// HealthCheck treats a node as healthy as long as it is able to report its chain head
+func HealthCheck(ctx context.Context, node v1.FullNode) error {
+	_, err := node.ChainHead(ctx)
+	return err
+}
+
+// NewFullNode builds a FullNode served by several equivalent nodes, calls stick to a healthy node and
+// fall over to the next one when it becomes unreachable, the health of the nodes is checked every checkInterval
+func NewFullNode(ctx context.Context, nodes []v1.FullNode, checkInterval time.Duration) (v1.FullNode, *api.Failover[v1.FullNode], error) {
+	failover, err := api.NewFailover(nodes, HealthCheck)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var res v1.FullNodeStruct
+	if err := failover.Proxy(&res); err != nil {
+		return nil, nil, err
+	}
+	failover.Start(ctx, checkInterval)
+
+	return &res, failover, nil
+}
+
+// NewBalancedFullNode builds a FullNode like NewFullNode, except that the read calls are spread over the healthy
+// nodes in turn, the subscriptions and the other calls stick to a healthy node
+func NewBalancedFullNode(ctx context.Context, nodes []v1.FullNode, checkInterval time.Duration) (v1.FullNode, *api.Failover[v1.FullNode], error) {
+	failover, err := api.NewFailover(nodes, HealthCheck)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var res v1.FullNodeStruct
+	if err := failover.LoadBalance(&res); err != nil {
+		return nil, nil, err
+	}
+	failover.Start(ctx, checkInterval)
+
+	return &res, failover, nil
+}
+
+// DialFullNodeRPC dials every node of apiInfos, in the `token:addr` format, and combines them with NewFullNode. The
+// closer stops the health checks and closes the connections.
+func DialFullNodeRPC(ctx context.Context, apiInfos []string, requestHeader http.Header, checkInterval time.Duration, opts ...v1.FullNodeOption) (v1.FullNode, jsonrpc.ClientCloser, error) {
+	return dialFullNodeRPC(ctx, apiInfos, requestHeader, checkInterval, NewFullNode, opts...)
+}
+
+// DialBalancedFullNodeRPC dials every node of apiInfos, in the `token:addr` format, and combines them with
+// NewBalancedFullNode
+func DialBalancedFullNodeRPC(ctx context.Context, apiInfos []string, requestHeader http.Header, checkInterval time.Duration, opts ...v1.FullNodeOption) (v1.FullNode, jsonrpc.ClientCloser, error) {
+	return dialFullNodeRPC(ctx, apiInfos, requestHeader, checkInterval, NewBalancedFullNode, opts...)
+}
+
+type combineFunc func(ctx context.Context, nodes []v1.FullNode, checkInterval time.Duration) (v1.FullNode, *api.Failover[v1.FullNode], error)
+
+func dialFullNodeRPC(ctx context.Context,
+	apiInfos []string,
+	requestHeader http.Header,
+	checkInterval time.Duration,
+	combine combineFunc,
+	opts ...v1.FullNodeOption,
+) (v1.FullNode, jsonrpc.ClientCloser, error) {
+	// the health checks run until the client is closed
+	ctx, cancel := context.WithCancel(ctx)
+	nodes := make([]v1.FullNode, 0, len(apiInfos))
+	closers := make([]jsonrpc.ClientCloser, 0, len(apiInfos))
+	closeAll := func() {
+		cancel()
+		for _, closer := range closers {
+			closer()
+		}
+	}
+
+	for _, s := range apiInfos {
+		ainfo := api.ParseApiInfo(s)
+
+		header := http.Header{}
+		for k, v := range requestHeader {
+			header[k] = v
+		}
+		node, closer, err := v1.DialFullNodeRPC(ctx, ainfo.Addr, string(ainfo.Token), header, opts...)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("dial %s: %w", ainfo.Addr, err)
+		}
+		nodes = append(nodes, node)
+		closers = append(closers, closer)
+	}
+
+	node, _, err := combine(ctx, nodes, checkInterval)
+	if err != nil {
+		closeAll()
+		return nil, nil, err
+	}
+
+	return node, closeAll, nil
+}
@@ -0,0 +1,62 @@
+package failover
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/api"
+	v1 "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/api/chain/v1/mock"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestFailoverFullNode(t *testing.T) {
+	tf.UnitTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctrl := gomock.NewController(t)
+	n1, n2 := mock.NewMockFullNode(ctrl), mock.NewMockFullNode(ctrl)
+
+	// n1 has gone away, every call is served by n2
+	n1.EXPECT().ChainHead(gomock.Any()).Return(nil, &jsonrpc.RPCConnectionError{}).AnyTimes()
+	n2.EXPECT().ChainHead(gomock.Any()).Return(nil, nil).AnyTimes()
+	n1.EXPECT().StateGetRandomnessFromBeacon(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, &jsonrpc.RPCConnectionError{}).MaxTimes(1)
+	n2.EXPECT().StateGetRandomnessFromBeacon(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(abi.Randomness("rand"), nil).Times(2)
+
+	node, failover, err := NewFullNode(ctx, []v1.FullNode{n1, n2}, time.Hour)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		rand, err := node.StateGetRandomnessFromBeacon(ctx, 0, 0, nil, types.EmptyTSK)
+		require.NoError(t, err)
+		require.Equal(t, abi.Randomness("rand"), rand)
+	}
+	require.Equal(t, []bool{false, true}, failover.Healthy())
+}
+
+func TestDialFullNodeRPCCloser(t *testing.T) {
+	tf.UnitTest(t)
+
+	var checkCtx context.Context
+	combine := func(ctx context.Context, nodes []v1.FullNode, checkInterval time.Duration) (v1.FullNode, *api.Failover[v1.FullNode], error) {
+		checkCtx = ctx
+		return nil, nil, nil
+	}
+	_, closer, err := dialFullNodeRPC(context.Background(), nil, nil, time.Hour, combine)
+	require.NoError(t, err)
+	require.NoError(t, checkCtx.Err())
+
+	// closing the client stops the health checks
+	closer()
+	require.ErrorIs(t, checkCtx.Err(), context.Canceled)
+}
@@ -1,6 +1,7 @@
 package v1
 
 type FullNode interface {
+	IAuth
 	IBlockStore
 	IChain
 	IMarket
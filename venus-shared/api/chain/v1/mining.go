@@ -12,4 +12,7 @@ import (
 type IMining interface {
 	MinerGetBaseInfo(ctx context.Context, maddr address.Address, round abi.ChainEpoch, tsk types.TipSetKey) (*types.MiningBaseInfo, error) //perm:read
 	MinerCreateBlock(ctx context.Context, bt *types.BlockTemplate) (*types.BlockMsg, error)                                                //perm:write
+	// MineOne produces the next block of the miner on the head right away, the rounds it loses are left null. It is
+	// only available with the devnet mining enabled, on the local networks.
+	MineOne(ctx context.Context, maddr address.Address) (*types.MinedBlock, error) //perm:admin
 }
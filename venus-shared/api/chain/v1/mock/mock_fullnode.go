@@ -24,6 +24,7 @@ import (
 	miner0 "github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
 	types "github.com/filecoin-project/venus/venus-shared/actors/types"
 	types0 "github.com/filecoin-project/venus/venus-shared/types"
+	wallet "github.com/filecoin-project/venus/venus-shared/types/wallet"
 	gomock "github.com/golang/mock/gomock"
 	blocks "github.com/ipfs/go-block-format"
 	cid "github.com/ipfs/go-cid"
@@ -56,6 +57,80 @@ func (m *MockFullNode) EXPECT() *MockFullNodeMockRecorder {
 	return m.recorder
 }
 
+// AuthList mocks base method.
+func (m *MockFullNode) AuthList(arg0 context.Context) ([]*types0.AuthTokenInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthList", arg0)
+	ret0, _ := ret[0].([]*types0.AuthTokenInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthList indicates an expected call of AuthList.
+func (mr *MockFullNodeMockRecorder) AuthList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthList", reflect.TypeOf((*MockFullNode)(nil).AuthList), arg0)
+}
+
+// AuthNew mocks base method.
+func (m *MockFullNode) AuthNew(arg0 context.Context, arg1 []string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthNew", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthNew indicates an expected call of AuthNew.
+func (mr *MockFullNodeMockRecorder) AuthNew(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthNew", reflect.TypeOf((*MockFullNode)(nil).AuthNew), arg0, arg1)
+}
+
+// AuthNewNamed mocks base method.
+func (m *MockFullNode) AuthNewNamed(arg0 context.Context, arg1 string, arg2 []string, arg3 time.Duration) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthNewNamed", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthNewNamed indicates an expected call of AuthNewNamed.
+func (mr *MockFullNodeMockRecorder) AuthNewNamed(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthNewNamed", reflect.TypeOf((*MockFullNode)(nil).AuthNewNamed), arg0, arg1, arg2, arg3)
+}
+
+// AuthRevoke mocks base method.
+func (m *MockFullNode) AuthRevoke(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthRevoke", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthRevoke indicates an expected call of AuthRevoke.
+func (mr *MockFullNodeMockRecorder) AuthRevoke(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthRevoke", reflect.TypeOf((*MockFullNode)(nil).AuthRevoke), arg0, arg1)
+}
+
+// AuthVerify mocks base method.
+func (m *MockFullNode) AuthVerify(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthVerify", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthVerify indicates an expected call of AuthVerify.
+func (mr *MockFullNodeMockRecorder) AuthVerify(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthVerify", reflect.TypeOf((*MockFullNode)(nil).AuthVerify), arg0, arg1)
+}
+
 // BlockTime mocks base method.
 func (m *MockFullNode) BlockTime(arg0 context.Context) time.Duration {
 	m.ctrl.T.Helper()
@@ -99,6 +174,21 @@ func (mr *MockFullNodeMockRecorder) ChainExport(arg0, arg1, arg2, arg3 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainExport", reflect.TypeOf((*MockFullNode)(nil).ChainExport), arg0, arg1, arg2, arg3)
 }
 
+// ChainGCStatus mocks base method.
+func (m *MockFullNode) ChainGCStatus(arg0 context.Context) (types0.ChainGCStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGCStatus", arg0)
+	ret0, _ := ret[0].(types0.ChainGCStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGCStatus indicates an expected call of ChainGCStatus.
+func (mr *MockFullNodeMockRecorder) ChainGCStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGCStatus", reflect.TypeOf((*MockFullNode)(nil).ChainGCStatus), arg0)
+}
+
 // ChainGetBlock mocks base method.
 func (m *MockFullNode) ChainGetBlock(arg0 context.Context, arg1 cid.Cid) (*types0.BlockHeader, error) {
 	m.ctrl.T.Helper()
@@ -144,6 +234,21 @@ func (mr *MockFullNodeMockRecorder) ChainGetEvents(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetEvents", reflect.TypeOf((*MockFullNode)(nil).ChainGetEvents), arg0, arg1)
 }
 
+// ChainGetFinalizedHead mocks base method.
+func (m *MockFullNode) ChainGetFinalizedHead(arg0 context.Context) (*types0.FinalizedHead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetFinalizedHead", arg0)
+	ret0, _ := ret[0].(*types0.FinalizedHead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetFinalizedHead indicates an expected call of ChainGetFinalizedHead.
+func (mr *MockFullNodeMockRecorder) ChainGetFinalizedHead(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetFinalizedHead", reflect.TypeOf((*MockFullNode)(nil).ChainGetFinalizedHead), arg0)
+}
+
 // ChainGetGenesis mocks base method.
 func (m *MockFullNode) ChainGetGenesis(arg0 context.Context) (*types0.TipSet, error) {
 	m.ctrl.T.Helper()
@@ -249,6 +354,21 @@ func (mr *MockFullNodeMockRecorder) ChainGetReceipts(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetReceipts", reflect.TypeOf((*MockFullNode)(nil).ChainGetReceipts), arg0, arg1)
 }
 
+// ChainGetResolvedMessagesInTipset mocks base method.
+func (m *MockFullNode) ChainGetResolvedMessagesInTipset(arg0 context.Context, arg1 types0.TipSetKey) ([]types0.ResolvedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetResolvedMessagesInTipset", arg0, arg1)
+	ret0, _ := ret[0].([]types0.ResolvedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetResolvedMessagesInTipset indicates an expected call of ChainGetResolvedMessagesInTipset.
+func (mr *MockFullNodeMockRecorder) ChainGetResolvedMessagesInTipset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetResolvedMessagesInTipset", reflect.TypeOf((*MockFullNode)(nil).ChainGetResolvedMessagesInTipset), arg0, arg1)
+}
+
 // ChainGetTipSet mocks base method.
 func (m *MockFullNode) ChainGetTipSet(arg0 context.Context, arg1 types0.TipSetKey) (*types0.TipSet, error) {
 	m.ctrl.T.Helper()
@@ -294,6 +414,21 @@ func (mr *MockFullNodeMockRecorder) ChainGetTipSetByHeight(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetTipSetByHeight", reflect.TypeOf((*MockFullNode)(nil).ChainGetTipSetByHeight), arg0, arg1, arg2)
 }
 
+// ChainGetTipSetBySelector mocks base method.
+func (m *MockFullNode) ChainGetTipSetBySelector(arg0 context.Context, arg1 types0.TipSetSelector) (*types0.TipSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetTipSetBySelector", arg0, arg1)
+	ret0, _ := ret[0].(*types0.TipSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetTipSetBySelector indicates an expected call of ChainGetTipSetBySelector.
+func (mr *MockFullNodeMockRecorder) ChainGetTipSetBySelector(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetTipSetBySelector", reflect.TypeOf((*MockFullNode)(nil).ChainGetTipSetBySelector), arg0, arg1)
+}
+
 // ChainHasObj mocks base method.
 func (m *MockFullNode) ChainHasObj(arg0 context.Context, arg1 cid.Cid) (bool, error) {
 	m.ctrl.T.Helper()
@@ -324,6 +459,21 @@ func (mr *MockFullNodeMockRecorder) ChainHead(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainHead", reflect.TypeOf((*MockFullNode)(nil).ChainHead), arg0)
 }
 
+// ChainHotGC mocks base method.
+func (m *MockFullNode) ChainHotGC(arg0 context.Context) (*types0.ChainOffloadStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainHotGC", arg0)
+	ret0, _ := ret[0].(*types0.ChainOffloadStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainHotGC indicates an expected call of ChainHotGC.
+func (mr *MockFullNodeMockRecorder) ChainHotGC(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainHotGC", reflect.TypeOf((*MockFullNode)(nil).ChainHotGC), arg0)
+}
+
 // ChainList mocks base method.
 func (m *MockFullNode) ChainList(arg0 context.Context, arg1 types0.TipSetKey, arg2 int) ([]types0.TipSetKey, error) {
 	m.ctrl.T.Helper()
@@ -354,6 +504,36 @@ func (mr *MockFullNodeMockRecorder) ChainNotify(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainNotify", reflect.TypeOf((*MockFullNode)(nil).ChainNotify), arg0)
 }
 
+// ChainOffloadRestore mocks base method.
+func (m *MockFullNode) ChainOffloadRestore(arg0 context.Context, arg1, arg2 abi.ChainEpoch) (*types0.ChainOffloadStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainOffloadRestore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.ChainOffloadStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainOffloadRestore indicates an expected call of ChainOffloadRestore.
+func (mr *MockFullNodeMockRecorder) ChainOffloadRestore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainOffloadRestore", reflect.TypeOf((*MockFullNode)(nil).ChainOffloadRestore), arg0, arg1, arg2)
+}
+
+// ChainOffloadStatus mocks base method.
+func (m *MockFullNode) ChainOffloadStatus(arg0 context.Context) (types0.ChainOffloadStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainOffloadStatus", arg0)
+	ret0, _ := ret[0].(types0.ChainOffloadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainOffloadStatus indicates an expected call of ChainOffloadStatus.
+func (mr *MockFullNodeMockRecorder) ChainOffloadStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainOffloadStatus", reflect.TypeOf((*MockFullNode)(nil).ChainOffloadStatus), arg0)
+}
+
 // ChainPutObj mocks base method.
 func (m *MockFullNode) ChainPutObj(arg0 context.Context, arg1 blocks.Block) error {
 	m.ctrl.T.Helper()
@@ -397,6 +577,35 @@ func (mr *MockFullNodeMockRecorder) ChainSetHead(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainSetHead", reflect.TypeOf((*MockFullNode)(nil).ChainSetHead), arg0, arg1)
 }
 
+// ChainStageObj mocks base method.
+func (m *MockFullNode) ChainStageObj(arg0 context.Context, arg1 cid.Cid, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainStageObj", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChainStageObj indicates an expected call of ChainStageObj.
+func (mr *MockFullNodeMockRecorder) ChainStageObj(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainStageObj", reflect.TypeOf((*MockFullNode)(nil).ChainStageObj), arg0, arg1, arg2)
+}
+
+// ChainStagedObjs mocks base method.
+func (m *MockFullNode) ChainStagedObjs(arg0 context.Context) (*types0.StagedObjStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainStagedObjs", arg0)
+	ret0, _ := ret[0].(*types0.StagedObjStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainStagedObjs indicates an expected call of ChainStagedObjs.
+func (mr *MockFullNodeMockRecorder) ChainStagedObjs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainStagedObjs", reflect.TypeOf((*MockFullNode)(nil).ChainStagedObjs), arg0)
+}
+
 // ChainStatObj mocks base method.
 func (m *MockFullNode) ChainStatObj(arg0 context.Context, arg1, arg2 cid.Cid) (types0.ObjStat, error) {
 	m.ctrl.T.Helper()
@@ -412,6 +621,21 @@ func (mr *MockFullNodeMockRecorder) ChainStatObj(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainStatObj", reflect.TypeOf((*MockFullNode)(nil).ChainStatObj), arg0, arg1, arg2)
 }
 
+// ChainStatObjWithDepth mocks base method.
+func (m *MockFullNode) ChainStatObjWithDepth(arg0 context.Context, arg1, arg2 cid.Cid, arg3 uint64) (types0.ObjStatWithDepth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainStatObjWithDepth", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types0.ObjStatWithDepth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainStatObjWithDepth indicates an expected call of ChainStatObjWithDepth.
+func (mr *MockFullNodeMockRecorder) ChainStatObjWithDepth(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainStatObjWithDepth", reflect.TypeOf((*MockFullNode)(nil).ChainStatObjWithDepth), arg0, arg1, arg2, arg3)
+}
+
 // ChainSyncHandleNewTipSet mocks base method.
 func (m *MockFullNode) ChainSyncHandleNewTipSet(arg0 context.Context, arg1 *types0.ChainInfo) error {
 	m.ctrl.T.Helper()
@@ -455,6 +679,36 @@ func (mr *MockFullNodeMockRecorder) Concurrent(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Concurrent", reflect.TypeOf((*MockFullNode)(nil).Concurrent), arg0)
 }
 
+// DatastoreGC mocks base method.
+func (m *MockFullNode) DatastoreGC(arg0 context.Context) (*types0.DatastoreGCResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DatastoreGC", arg0)
+	ret0, _ := ret[0].(*types0.DatastoreGCResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DatastoreGC indicates an expected call of DatastoreGC.
+func (mr *MockFullNodeMockRecorder) DatastoreGC(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DatastoreGC", reflect.TypeOf((*MockFullNode)(nil).DatastoreGC), arg0)
+}
+
+// DatastoreScrub mocks base method.
+func (m *MockFullNode) DatastoreScrub(arg0 context.Context, arg1 *types0.DatastoreScrubOptions) (<-chan *types0.DatastoreScrubProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DatastoreScrub", arg0, arg1)
+	ret0, _ := ret[0].(<-chan *types0.DatastoreScrubProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DatastoreScrub indicates an expected call of DatastoreScrub.
+func (mr *MockFullNodeMockRecorder) DatastoreScrub(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DatastoreScrub", reflect.TypeOf((*MockFullNode)(nil).DatastoreScrub), arg0, arg1)
+}
+
 // EthAccounts mocks base method.
 func (m *MockFullNode) EthAccounts(arg0 context.Context) ([]types.EthAddress, error) {
 	m.ctrl.T.Helper()
@@ -530,6 +784,36 @@ func (mr *MockFullNodeMockRecorder) EthChainId(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthChainId", reflect.TypeOf((*MockFullNode)(nil).EthChainId), arg0)
 }
 
+// EthDebugTraceBlockByNumber mocks base method.
+func (m *MockFullNode) EthDebugTraceBlockByNumber(arg0 context.Context, arg1 jsonrpc.RawParams) ([]*types.EthTxTraceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthDebugTraceBlockByNumber", arg0, arg1)
+	ret0, _ := ret[0].([]*types.EthTxTraceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthDebugTraceBlockByNumber indicates an expected call of EthDebugTraceBlockByNumber.
+func (mr *MockFullNodeMockRecorder) EthDebugTraceBlockByNumber(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthDebugTraceBlockByNumber", reflect.TypeOf((*MockFullNode)(nil).EthDebugTraceBlockByNumber), arg0, arg1)
+}
+
+// EthDebugTraceTransaction mocks base method.
+func (m *MockFullNode) EthDebugTraceTransaction(arg0 context.Context, arg1 jsonrpc.RawParams) (*types.EthCallFrame, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthDebugTraceTransaction", arg0, arg1)
+	ret0, _ := ret[0].(*types.EthCallFrame)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthDebugTraceTransaction indicates an expected call of EthDebugTraceTransaction.
+func (mr *MockFullNodeMockRecorder) EthDebugTraceTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthDebugTraceTransaction", reflect.TypeOf((*MockFullNode)(nil).EthDebugTraceTransaction), arg0, arg1)
+}
+
 // EthEstimateGas mocks base method.
 func (m *MockFullNode) EthEstimateGas(arg0 context.Context, arg1 jsonrpc.RawParams) (types.EthUint64, error) {
 	m.ctrl.T.Helper()
@@ -1010,6 +1294,21 @@ func (mr *MockFullNodeMockRecorder) EthTraceReplayBlockTransactions(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTraceReplayBlockTransactions", reflect.TypeOf((*MockFullNode)(nil).EthTraceReplayBlockTransactions), arg0, arg1, arg2)
 }
 
+// EthTraceTransaction mocks base method.
+func (m *MockFullNode) EthTraceTransaction(arg0 context.Context, arg1 string) ([]*types.EthTraceTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthTraceTransaction", arg0, arg1)
+	ret0, _ := ret[0].([]*types.EthTraceTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthTraceTransaction indicates an expected call of EthTraceTransaction.
+func (mr *MockFullNodeMockRecorder) EthTraceTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTraceTransaction", reflect.TypeOf((*MockFullNode)(nil).EthTraceTransaction), arg0, arg1)
+}
+
 // EthUninstallFilter mocks base method.
 func (m *MockFullNode) EthUninstallFilter(arg0 context.Context, arg1 types.EthFilterID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1130,6 +1429,21 @@ func (mr *MockFullNodeMockRecorder) GasEstimateMessageGas(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateMessageGas", reflect.TypeOf((*MockFullNode)(nil).GasEstimateMessageGas), arg0, arg1, arg2, arg3)
 }
 
+// GasStats mocks base method.
+func (m *MockFullNode) GasStats(arg0 context.Context) (*types0.GasStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GasStats", arg0)
+	ret0, _ := ret[0].(*types0.GasStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GasStats indicates an expected call of GasStats.
+func (mr *MockFullNodeMockRecorder) GasStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasStats", reflect.TypeOf((*MockFullNode)(nil).GasStats), arg0)
+}
+
 // GetActor mocks base method.
 func (m *MockFullNode) GetActor(arg0 context.Context, arg1 address.Address) (*types.ActorV5, error) {
 	m.ctrl.T.Helper()
@@ -1263,6 +1577,21 @@ func (mr *MockFullNodeMockRecorder) LockWallet(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockWallet", reflect.TypeOf((*MockFullNode)(nil).LockWallet), arg0)
 }
 
+// MineOne mocks base method.
+func (m *MockFullNode) MineOne(arg0 context.Context, arg1 address.Address) (*types0.MinedBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MineOne", arg0, arg1)
+	ret0, _ := ret[0].(*types0.MinedBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MineOne indicates an expected call of MineOne.
+func (mr *MockFullNodeMockRecorder) MineOne(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MineOne", reflect.TypeOf((*MockFullNode)(nil).MineOne), arg0, arg1)
+}
+
 // MinerCreateBlock mocks base method.
 func (m *MockFullNode) MinerCreateBlock(arg0 context.Context, arg1 *types0.BlockTemplate) (*types0.BlockMsg, error) {
 	m.ctrl.T.Helper()
@@ -1411,6 +1740,21 @@ func (mr *MockFullNodeMockRecorder) MpoolDeleteByAdress(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolDeleteByAdress", reflect.TypeOf((*MockFullNode)(nil).MpoolDeleteByAdress), arg0, arg1)
 }
 
+// MpoolEstimateInclusion mocks base method.
+func (m *MockFullNode) MpoolEstimateInclusion(arg0 context.Context, arg1 *types.Message, arg2 uint64) (*types0.InclusionEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolEstimateInclusion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.InclusionEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolEstimateInclusion indicates an expected call of MpoolEstimateInclusion.
+func (mr *MockFullNodeMockRecorder) MpoolEstimateInclusion(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolEstimateInclusion", reflect.TypeOf((*MockFullNode)(nil).MpoolEstimateInclusion), arg0, arg1, arg2)
+}
+
 // MpoolGetConfig mocks base method.
 func (m *MockFullNode) MpoolGetConfig(arg0 context.Context) (*types0.MpoolConfig, error) {
 	m.ctrl.T.Helper()
@@ -1456,6 +1800,21 @@ func (mr *MockFullNodeMockRecorder) MpoolPending(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPending", reflect.TypeOf((*MockFullNode)(nil).MpoolPending), arg0, arg1)
 }
 
+// MpoolPreviewMessage mocks base method.
+func (m *MockFullNode) MpoolPreviewMessage(arg0 context.Context, arg1 *types.Message) (*types0.InvocResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolPreviewMessage", arg0, arg1)
+	ret0, _ := ret[0].(*types0.InvocResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolPreviewMessage indicates an expected call of MpoolPreviewMessage.
+func (mr *MockFullNodeMockRecorder) MpoolPreviewMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPreviewMessage", reflect.TypeOf((*MockFullNode)(nil).MpoolPreviewMessage), arg0, arg1)
+}
+
 // MpoolPublishByAddr mocks base method.
 func (m *MockFullNode) MpoolPublishByAddr(arg0 context.Context, arg1 address.Address) error {
 	m.ctrl.T.Helper()
@@ -1514,6 +1873,21 @@ func (mr *MockFullNodeMockRecorder) MpoolPushMessage(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPushMessage", reflect.TypeOf((*MockFullNode)(nil).MpoolPushMessage), arg0, arg1, arg2)
 }
 
+// MpoolPushMessageWithID mocks base method.
+func (m *MockFullNode) MpoolPushMessageWithID(arg0 context.Context, arg1 types0.UUID, arg2 *types.Message, arg3 *types0.MessageSendSpec) (*types.SignedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolPushMessageWithID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types.SignedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolPushMessageWithID indicates an expected call of MpoolPushMessageWithID.
+func (mr *MockFullNodeMockRecorder) MpoolPushMessageWithID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolPushMessageWithID", reflect.TypeOf((*MockFullNode)(nil).MpoolPushMessageWithID), arg0, arg1, arg2, arg3)
+}
+
 // MpoolPushUntrusted mocks base method.
 func (m *MockFullNode) MpoolPushUntrusted(arg0 context.Context, arg1 *types.SignedMessage) (cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -1588,6 +1962,21 @@ func (mr *MockFullNodeMockRecorder) MpoolSub(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolSub", reflect.TypeOf((*MockFullNode)(nil).MpoolSub), arg0)
 }
 
+// MpoolSubFiltered mocks base method.
+func (m *MockFullNode) MpoolSubFiltered(arg0 context.Context, arg1 *types0.MpoolSubFilter) (<-chan types0.MpoolUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolSubFiltered", arg0, arg1)
+	ret0, _ := ret[0].(<-chan types0.MpoolUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolSubFiltered indicates an expected call of MpoolSubFiltered.
+func (mr *MockFullNodeMockRecorder) MpoolSubFiltered(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolSubFiltered", reflect.TypeOf((*MockFullNode)(nil).MpoolSubFiltered), arg0, arg1)
+}
+
 // NetAddrsListen mocks base method.
 func (m *MockFullNode) NetAddrsListen(arg0 context.Context) (peer.AddrInfo, error) {
 	m.ctrl.T.Helper()
@@ -1883,6 +2272,21 @@ func (mr *MockFullNodeMockRecorder) NetPubsubScores(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPubsubScores", reflect.TypeOf((*MockFullNode)(nil).NetPubsubScores), arg0)
 }
 
+// NetPubsubTopics mocks base method.
+func (m *MockFullNode) NetPubsubTopics(arg0 context.Context) ([]types0.PubsubTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetPubsubTopics", arg0)
+	ret0, _ := ret[0].([]types0.PubsubTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetPubsubTopics indicates an expected call of NetPubsubTopics.
+func (mr *MockFullNodeMockRecorder) NetPubsubTopics(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPubsubTopics", reflect.TypeOf((*MockFullNode)(nil).NetPubsubTopics), arg0)
+}
+
 // NetVersion mocks base method.
 func (m *MockFullNode) NetVersion(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -2255,6 +2659,21 @@ func (mr *MockFullNodeMockRecorder) StateAccountKey(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateAccountKey", reflect.TypeOf((*MockFullNode)(nil).StateAccountKey), arg0, arg1, arg2)
 }
 
+// StateAccountKeyBySelector mocks base method.
+func (m *MockFullNode) StateAccountKeyBySelector(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetSelector) (address.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateAccountKeyBySelector", arg0, arg1, arg2)
+	ret0, _ := ret[0].(address.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateAccountKeyBySelector indicates an expected call of StateAccountKeyBySelector.
+func (mr *MockFullNodeMockRecorder) StateAccountKeyBySelector(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateAccountKeyBySelector", reflect.TypeOf((*MockFullNode)(nil).StateAccountKeyBySelector), arg0, arg1, arg2)
+}
+
 // StateActorCodeCIDs mocks base method.
 func (m *MockFullNode) StateActorCodeCIDs(arg0 context.Context, arg1 network.Version) (map[string]cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -2285,6 +2704,36 @@ func (mr *MockFullNodeMockRecorder) StateActorManifestCID(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateActorManifestCID", reflect.TypeOf((*MockFullNode)(nil).StateActorManifestCID), arg0, arg1)
 }
 
+// StateActorNames mocks base method.
+func (m *MockFullNode) StateActorNames(arg0 context.Context, arg1 []cid.Cid) ([]*types0.ActorCodeName, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateActorNames", arg0, arg1)
+	ret0, _ := ret[0].([]*types0.ActorCodeName)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateActorNames indicates an expected call of StateActorNames.
+func (mr *MockFullNodeMockRecorder) StateActorNames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateActorNames", reflect.TypeOf((*MockFullNode)(nil).StateActorNames), arg0, arg1)
+}
+
+// StateActorStatObj mocks base method.
+func (m *MockFullNode) StateActorStatObj(arg0 context.Context, arg1 address.Address, arg2 uint64, arg3 types0.TipSetKey) (types0.ObjStatWithDepth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateActorStatObj", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types0.ObjStatWithDepth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateActorStatObj indicates an expected call of StateActorStatObj.
+func (mr *MockFullNodeMockRecorder) StateActorStatObj(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateActorStatObj", reflect.TypeOf((*MockFullNode)(nil).StateActorStatObj), arg0, arg1, arg2, arg3)
+}
+
 // StateAllMinerFaults mocks base method.
 func (m *MockFullNode) StateAllMinerFaults(arg0 context.Context, arg1 abi.ChainEpoch, arg2 types0.TipSetKey) ([]*types0.Fault, error) {
 	m.ctrl.T.Helper()
@@ -2315,6 +2764,21 @@ func (mr *MockFullNodeMockRecorder) StateCall(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateCall", reflect.TypeOf((*MockFullNode)(nil).StateCall), arg0, arg1, arg2)
 }
 
+// StateCallBySelector mocks base method.
+func (m *MockFullNode) StateCallBySelector(arg0 context.Context, arg1 *types0.Message, arg2 types0.TipSetSelector) (*types0.InvocResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateCallBySelector", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.InvocResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateCallBySelector indicates an expected call of StateCallBySelector.
+func (mr *MockFullNodeMockRecorder) StateCallBySelector(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateCallBySelector", reflect.TypeOf((*MockFullNode)(nil).StateCallBySelector), arg0, arg1, arg2)
+}
+
 // StateChangedActors mocks base method.
 func (m *MockFullNode) StateChangedActors(arg0 context.Context, arg1, arg2 cid.Cid) (map[string]types.ActorV5, error) {
 	m.ctrl.T.Helper()
@@ -2435,6 +2899,21 @@ func (mr *MockFullNodeMockRecorder) StateGetActor(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetActor", reflect.TypeOf((*MockFullNode)(nil).StateGetActor), arg0, arg1, arg2)
 }
 
+// StateGetActorBySelector mocks base method.
+func (m *MockFullNode) StateGetActorBySelector(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetSelector) (*types0.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateGetActorBySelector", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateGetActorBySelector indicates an expected call of StateGetActorBySelector.
+func (mr *MockFullNodeMockRecorder) StateGetActorBySelector(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGetActorBySelector", reflect.TypeOf((*MockFullNode)(nil).StateGetActorBySelector), arg0, arg1, arg2)
+}
+
 // StateGetAllAllocations mocks base method.
 func (m *MockFullNode) StateGetAllAllocations(arg0 context.Context, arg1 types0.TipSetKey) (map[verifreg.AllocationId]verifreg.Allocation, error) {
 	m.ctrl.T.Helper()
@@ -2660,6 +3139,21 @@ func (mr *MockFullNodeMockRecorder) StateListActors(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListActors", reflect.TypeOf((*MockFullNode)(nil).StateListActors), arg0, arg1)
 }
 
+// StateListMatchedMessages mocks base method.
+func (m *MockFullNode) StateListMatchedMessages(arg0 context.Context, arg1 *types0.MessageMatch, arg2 types0.TipSetKey, arg3 abi.ChainEpoch, arg4 bool) ([]*types0.MatchedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateListMatchedMessages", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*types0.MatchedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateListMatchedMessages indicates an expected call of StateListMatchedMessages.
+func (mr *MockFullNodeMockRecorder) StateListMatchedMessages(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListMatchedMessages", reflect.TypeOf((*MockFullNode)(nil).StateListMatchedMessages), arg0, arg1, arg2, arg3, arg4)
+}
+
 // StateListMessages mocks base method.
 func (m *MockFullNode) StateListMessages(arg0 context.Context, arg1 *types0.MessageMatch, arg2 types0.TipSetKey, arg3 abi.ChainEpoch) ([]cid.Cid, error) {
 	m.ctrl.T.Helper()
@@ -2690,6 +3184,36 @@ func (mr *MockFullNodeMockRecorder) StateListMiners(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListMiners", reflect.TypeOf((*MockFullNode)(nil).StateListMiners), arg0, arg1)
 }
 
+// StateListVerifiedClients mocks base method.
+func (m *MockFullNode) StateListVerifiedClients(arg0 context.Context, arg1 types0.TipSetKey) ([]types0.DataCapEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateListVerifiedClients", arg0, arg1)
+	ret0, _ := ret[0].([]types0.DataCapEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateListVerifiedClients indicates an expected call of StateListVerifiedClients.
+func (mr *MockFullNodeMockRecorder) StateListVerifiedClients(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListVerifiedClients", reflect.TypeOf((*MockFullNode)(nil).StateListVerifiedClients), arg0, arg1)
+}
+
+// StateListVerifiers mocks base method.
+func (m *MockFullNode) StateListVerifiers(arg0 context.Context, arg1 types0.TipSetKey) ([]types0.DataCapEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateListVerifiers", arg0, arg1)
+	ret0, _ := ret[0].([]types0.DataCapEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateListVerifiers indicates an expected call of StateListVerifiers.
+func (mr *MockFullNodeMockRecorder) StateListVerifiers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateListVerifiers", reflect.TypeOf((*MockFullNode)(nil).StateListVerifiers), arg0, arg1)
+}
+
 // StateLookupID mocks base method.
 func (m *MockFullNode) StateLookupID(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (address.Address, error) {
 	m.ctrl.T.Helper()
@@ -2705,6 +3229,21 @@ func (mr *MockFullNodeMockRecorder) StateLookupID(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateLookupID", reflect.TypeOf((*MockFullNode)(nil).StateLookupID), arg0, arg1, arg2)
 }
 
+// StateLookupIDBySelector mocks base method.
+func (m *MockFullNode) StateLookupIDBySelector(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetSelector) (address.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateLookupIDBySelector", arg0, arg1, arg2)
+	ret0, _ := ret[0].(address.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateLookupIDBySelector indicates an expected call of StateLookupIDBySelector.
+func (mr *MockFullNodeMockRecorder) StateLookupIDBySelector(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateLookupIDBySelector", reflect.TypeOf((*MockFullNode)(nil).StateLookupIDBySelector), arg0, arg1, arg2)
+}
+
 // StateLookupRobustAddress mocks base method.
 func (m *MockFullNode) StateLookupRobustAddress(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (address.Address, error) {
 	m.ctrl.T.Helper()
@@ -2945,6 +3484,21 @@ func (mr *MockFullNodeMockRecorder) StateMinerProvingDeadline(arg0, arg1, arg2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerProvingDeadline", reflect.TypeOf((*MockFullNode)(nil).StateMinerProvingDeadline), arg0, arg1, arg2)
 }
 
+// StateMinerProvingDeadlineWithPartitions mocks base method.
+func (m *MockFullNode) StateMinerProvingDeadlineWithPartitions(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (*types0.ProvingDeadline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMinerProvingDeadlineWithPartitions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.ProvingDeadline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMinerProvingDeadlineWithPartitions indicates an expected call of StateMinerProvingDeadlineWithPartitions.
+func (mr *MockFullNodeMockRecorder) StateMinerProvingDeadlineWithPartitions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerProvingDeadlineWithPartitions", reflect.TypeOf((*MockFullNode)(nil).StateMinerProvingDeadlineWithPartitions), arg0, arg1, arg2)
+}
+
 // StateMinerRecoveries mocks base method.
 func (m *MockFullNode) StateMinerRecoveries(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (bitfield.BitField, error) {
 	m.ctrl.T.Helper()
@@ -2975,6 +3529,21 @@ func (mr *MockFullNodeMockRecorder) StateMinerSectorAllocated(arg0, arg1, arg2,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerSectorAllocated", reflect.TypeOf((*MockFullNode)(nil).StateMinerSectorAllocated), arg0, arg1, arg2, arg3)
 }
 
+// StateMinerSectorCollateral mocks base method.
+func (m *MockFullNode) StateMinerSectorCollateral(arg0 context.Context, arg1 address.Address, arg2 miner.SectorPreCommitInfo, arg3 types0.TipSetKey) (*types0.SectorCollateral, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateMinerSectorCollateral", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.SectorCollateral)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateMinerSectorCollateral indicates an expected call of StateMinerSectorCollateral.
+func (mr *MockFullNodeMockRecorder) StateMinerSectorCollateral(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateMinerSectorCollateral", reflect.TypeOf((*MockFullNode)(nil).StateMinerSectorCollateral), arg0, arg1, arg2, arg3)
+}
+
 // StateMinerSectorCount mocks base method.
 func (m *MockFullNode) StateMinerSectorCount(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (types0.MinerSectors, error) {
 	m.ctrl.T.Helper()
@@ -3050,6 +3619,21 @@ func (mr *MockFullNodeMockRecorder) StateNetworkName(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateNetworkName", reflect.TypeOf((*MockFullNode)(nil).StateNetworkName), arg0)
 }
 
+// StateNetworkUpgradeSchedule mocks base method.
+func (m *MockFullNode) StateNetworkUpgradeSchedule(arg0 context.Context) ([]*types0.NetworkUpgrade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateNetworkUpgradeSchedule", arg0)
+	ret0, _ := ret[0].([]*types0.NetworkUpgrade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateNetworkUpgradeSchedule indicates an expected call of StateNetworkUpgradeSchedule.
+func (mr *MockFullNodeMockRecorder) StateNetworkUpgradeSchedule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateNetworkUpgradeSchedule", reflect.TypeOf((*MockFullNode)(nil).StateNetworkUpgradeSchedule), arg0)
+}
+
 // StateNetworkVersion mocks base method.
 func (m *MockFullNode) StateNetworkVersion(arg0 context.Context, arg1 types0.TipSetKey) (network.Version, error) {
 	m.ctrl.T.Helper()
@@ -3065,6 +3649,21 @@ func (mr *MockFullNodeMockRecorder) StateNetworkVersion(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateNetworkVersion", reflect.TypeOf((*MockFullNode)(nil).StateNetworkVersion), arg0, arg1)
 }
 
+// StateNetworkVersionAt mocks base method.
+func (m *MockFullNode) StateNetworkVersionAt(arg0 context.Context, arg1 abi.ChainEpoch) (network.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateNetworkVersionAt", arg0, arg1)
+	ret0, _ := ret[0].(network.Version)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateNetworkVersionAt indicates an expected call of StateNetworkVersionAt.
+func (mr *MockFullNodeMockRecorder) StateNetworkVersionAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateNetworkVersionAt", reflect.TypeOf((*MockFullNode)(nil).StateNetworkVersionAt), arg0, arg1)
+}
+
 // StateReadState mocks base method.
 func (m *MockFullNode) StateReadState(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (*types0.ActorState, error) {
 	m.ctrl.T.Helper()
@@ -3080,6 +3679,21 @@ func (mr *MockFullNodeMockRecorder) StateReadState(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateReadState", reflect.TypeOf((*MockFullNode)(nil).StateReadState), arg0, arg1, arg2)
 }
 
+// StateRemoveDataCapProposalID mocks base method.
+func (m *MockFullNode) StateRemoveDataCapProposalID(arg0 context.Context, arg1, arg2 address.Address, arg3 types0.TipSetKey) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateRemoveDataCapProposalID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateRemoveDataCapProposalID indicates an expected call of StateRemoveDataCapProposalID.
+func (mr *MockFullNodeMockRecorder) StateRemoveDataCapProposalID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateRemoveDataCapProposalID", reflect.TypeOf((*MockFullNode)(nil).StateRemoveDataCapProposalID), arg0, arg1, arg2, arg3)
+}
+
 // StateReplay mocks base method.
 func (m *MockFullNode) StateReplay(arg0 context.Context, arg1 types0.TipSetKey, arg2 cid.Cid) (*types0.InvocResult, error) {
 	m.ctrl.T.Helper()
@@ -3110,6 +3724,21 @@ func (mr *MockFullNodeMockRecorder) StateSearchMsg(arg0, arg1, arg2, arg3, arg4
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSearchMsg", reflect.TypeOf((*MockFullNode)(nil).StateSearchMsg), arg0, arg1, arg2, arg3, arg4)
 }
 
+// StateSectorBatchEstimate mocks base method.
+func (m *MockFullNode) StateSectorBatchEstimate(arg0 context.Context, arg1 types0.SectorBatchKind, arg2 int, arg3 types0.TipSetKey) (*types0.SectorBatchEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateSectorBatchEstimate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.SectorBatchEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateSectorBatchEstimate indicates an expected call of StateSectorBatchEstimate.
+func (mr *MockFullNodeMockRecorder) StateSectorBatchEstimate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSectorBatchEstimate", reflect.TypeOf((*MockFullNode)(nil).StateSectorBatchEstimate), arg0, arg1, arg2, arg3)
+}
+
 // StateSectorExpiration mocks base method.
 func (m *MockFullNode) StateSectorExpiration(arg0 context.Context, arg1 address.Address, arg2 abi.SectorNumber, arg3 types0.TipSetKey) (*miner0.SectorExpiration, error) {
 	m.ctrl.T.Helper()
@@ -3170,6 +3799,21 @@ func (mr *MockFullNodeMockRecorder) StateSectorPreCommitInfo(arg0, arg1, arg2, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSectorPreCommitInfo", reflect.TypeOf((*MockFullNode)(nil).StateSectorPreCommitInfo), arg0, arg1, arg2, arg3)
 }
 
+// StateSupplyHistory mocks base method.
+func (m *MockFullNode) StateSupplyHistory(arg0 context.Context, arg1, arg2, arg3 abi.ChainEpoch, arg4 types0.TipSetKey) ([]*types0.SupplyPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateSupplyHistory", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*types0.SupplyPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateSupplyHistory indicates an expected call of StateSupplyHistory.
+func (mr *MockFullNodeMockRecorder) StateSupplyHistory(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateSupplyHistory", reflect.TypeOf((*MockFullNode)(nil).StateSupplyHistory), arg0, arg1, arg2, arg3, arg4)
+}
+
 // StateVMCirculatingSupplyInternal mocks base method.
 func (m *MockFullNode) StateVMCirculatingSupplyInternal(arg0 context.Context, arg1 types0.TipSetKey) (types0.CirculatingSupply, error) {
 	m.ctrl.T.Helper()
@@ -3260,6 +3904,66 @@ func (mr *MockFullNodeMockRecorder) SubscribeActorEventsRaw(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeActorEventsRaw", reflect.TypeOf((*MockFullNode)(nil).SubscribeActorEventsRaw), arg0, arg1)
 }
 
+// SubscribeDealUpdates mocks base method.
+func (m *MockFullNode) SubscribeDealUpdates(arg0 context.Context, arg1 []abi.DealID) (<-chan []*types0.DealUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeDealUpdates", arg0, arg1)
+	ret0, _ := ret[0].(<-chan []*types0.DealUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeDealUpdates indicates an expected call of SubscribeDealUpdates.
+func (mr *MockFullNodeMockRecorder) SubscribeDealUpdates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeDealUpdates", reflect.TypeOf((*MockFullNode)(nil).SubscribeDealUpdates), arg0, arg1)
+}
+
+// SyncCheckBad mocks base method.
+func (m *MockFullNode) SyncCheckBad(arg0 context.Context, arg1 types0.TipSetKey) (*types0.BadTipSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncCheckBad", arg0, arg1)
+	ret0, _ := ret[0].(*types0.BadTipSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncCheckBad indicates an expected call of SyncCheckBad.
+func (mr *MockFullNodeMockRecorder) SyncCheckBad(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncCheckBad", reflect.TypeOf((*MockFullNode)(nil).SyncCheckBad), arg0, arg1)
+}
+
+// SyncConsensusFaults mocks base method.
+func (m *MockFullNode) SyncConsensusFaults(arg0 context.Context) ([]*types0.ConsensusFault, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncConsensusFaults", arg0)
+	ret0, _ := ret[0].([]*types0.ConsensusFault)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncConsensusFaults indicates an expected call of SyncConsensusFaults.
+func (mr *MockFullNodeMockRecorder) SyncConsensusFaults(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncConsensusFaults", reflect.TypeOf((*MockFullNode)(nil).SyncConsensusFaults), arg0)
+}
+
+// SyncForkAlerts mocks base method.
+func (m *MockFullNode) SyncForkAlerts(arg0 context.Context) ([]*types0.ForkAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncForkAlerts", arg0)
+	ret0, _ := ret[0].([]*types0.ForkAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncForkAlerts indicates an expected call of SyncForkAlerts.
+func (mr *MockFullNodeMockRecorder) SyncForkAlerts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncForkAlerts", reflect.TypeOf((*MockFullNode)(nil).SyncForkAlerts), arg0)
+}
+
 // SyncIncomingBlocks mocks base method.
 func (m *MockFullNode) SyncIncomingBlocks(arg0 context.Context) (<-chan *types0.BlockHeader, error) {
 	m.ctrl.T.Helper()
@@ -3275,6 +3979,35 @@ func (mr *MockFullNodeMockRecorder) SyncIncomingBlocks(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncIncomingBlocks", reflect.TypeOf((*MockFullNode)(nil).SyncIncomingBlocks), arg0)
 }
 
+// SyncListBad mocks base method.
+func (m *MockFullNode) SyncListBad(arg0 context.Context) ([]*types0.BadTipSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncListBad", arg0)
+	ret0, _ := ret[0].([]*types0.BadTipSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncListBad indicates an expected call of SyncListBad.
+func (mr *MockFullNodeMockRecorder) SyncListBad(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncListBad", reflect.TypeOf((*MockFullNode)(nil).SyncListBad), arg0)
+}
+
+// SyncMarkBad mocks base method.
+func (m *MockFullNode) SyncMarkBad(arg0 context.Context, arg1 types0.TipSetKey, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncMarkBad", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncMarkBad indicates an expected call of SyncMarkBad.
+func (mr *MockFullNodeMockRecorder) SyncMarkBad(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncMarkBad", reflect.TypeOf((*MockFullNode)(nil).SyncMarkBad), arg0, arg1, arg2)
+}
+
 // SyncState mocks base method.
 func (m *MockFullNode) SyncState(arg0 context.Context) (*types0.SyncState, error) {
 	m.ctrl.T.Helper()
@@ -3304,6 +4037,49 @@ func (mr *MockFullNodeMockRecorder) SyncSubmitBlock(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncSubmitBlock", reflect.TypeOf((*MockFullNode)(nil).SyncSubmitBlock), arg0, arg1)
 }
 
+// SyncSubmitBlockChecked mocks base method.
+func (m *MockFullNode) SyncSubmitBlockChecked(arg0 context.Context, arg1 *types0.BlockMsg) (*types0.SubmitBlockResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncSubmitBlockChecked", arg0, arg1)
+	ret0, _ := ret[0].(*types0.SubmitBlockResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncSubmitBlockChecked indicates an expected call of SyncSubmitBlockChecked.
+func (mr *MockFullNodeMockRecorder) SyncSubmitBlockChecked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncSubmitBlockChecked", reflect.TypeOf((*MockFullNode)(nil).SyncSubmitBlockChecked), arg0, arg1)
+}
+
+// SyncUnmarkAllBad mocks base method.
+func (m *MockFullNode) SyncUnmarkAllBad(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncUnmarkAllBad", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncUnmarkAllBad indicates an expected call of SyncUnmarkAllBad.
+func (mr *MockFullNodeMockRecorder) SyncUnmarkAllBad(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncUnmarkAllBad", reflect.TypeOf((*MockFullNode)(nil).SyncUnmarkAllBad), arg0)
+}
+
+// SyncUnmarkBad mocks base method.
+func (m *MockFullNode) SyncUnmarkBad(arg0 context.Context, arg1 types0.TipSetKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncUnmarkBad", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncUnmarkBad indicates an expected call of SyncUnmarkBad.
+func (mr *MockFullNodeMockRecorder) SyncUnmarkBad(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncUnmarkBad", reflect.TypeOf((*MockFullNode)(nil).SyncUnmarkBad), arg0, arg1)
+}
+
 // SyncerTracker mocks base method.
 func (m *MockFullNode) SyncerTracker(arg0 context.Context) *types0.TargetTracker {
 	m.ctrl.T.Helper()
@@ -3361,6 +4137,21 @@ func (mr *MockFullNodeMockRecorder) Version(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockFullNode)(nil).Version), arg0)
 }
 
+// WalletAddSignRule mocks base method.
+func (m *MockFullNode) WalletAddSignRule(arg0 context.Context, arg1 address.Address, arg2 string) (*wallet.SignRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletAddSignRule", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*wallet.SignRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletAddSignRule indicates an expected call of WalletAddSignRule.
+func (mr *MockFullNodeMockRecorder) WalletAddSignRule(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletAddSignRule", reflect.TypeOf((*MockFullNode)(nil).WalletAddSignRule), arg0, arg1, arg2)
+}
+
 // WalletAddresses mocks base method.
 func (m *MockFullNode) WalletAddresses(arg0 context.Context) []address.Address {
 	m.ctrl.T.Helper()
@@ -3479,6 +4270,20 @@ func (mr *MockFullNodeMockRecorder) WalletNewAddress(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletNewAddress", reflect.TypeOf((*MockFullNode)(nil).WalletNewAddress), arg0, arg1)
 }
 
+// WalletRemoveSignRule mocks base method.
+func (m *MockFullNode) WalletRemoveSignRule(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletRemoveSignRule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WalletRemoveSignRule indicates an expected call of WalletRemoveSignRule.
+func (mr *MockFullNodeMockRecorder) WalletRemoveSignRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletRemoveSignRule", reflect.TypeOf((*MockFullNode)(nil).WalletRemoveSignRule), arg0, arg1)
+}
+
 // WalletSetDefault mocks base method.
 func (m *MockFullNode) WalletSetDefault(arg0 context.Context, arg1 address.Address) error {
 	m.ctrl.T.Helper()
@@ -3508,6 +4313,21 @@ func (mr *MockFullNodeMockRecorder) WalletSign(arg0, arg1, arg2, arg3 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletSign", reflect.TypeOf((*MockFullNode)(nil).WalletSign), arg0, arg1, arg2, arg3)
 }
 
+// WalletSignDenials mocks base method.
+func (m *MockFullNode) WalletSignDenials(arg0 context.Context, arg1 int) ([]wallet.SignDenial, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletSignDenials", arg0, arg1)
+	ret0, _ := ret[0].([]wallet.SignDenial)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletSignDenials indicates an expected call of WalletSignDenials.
+func (mr *MockFullNodeMockRecorder) WalletSignDenials(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletSignDenials", reflect.TypeOf((*MockFullNode)(nil).WalletSignDenials), arg0, arg1)
+}
+
 // WalletSignMessage mocks base method.
 func (m *MockFullNode) WalletSignMessage(arg0 context.Context, arg1 address.Address, arg2 *types.Message) (*types.SignedMessage, error) {
 	m.ctrl.T.Helper()
@@ -3523,6 +4343,21 @@ func (mr *MockFullNodeMockRecorder) WalletSignMessage(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletSignMessage", reflect.TypeOf((*MockFullNode)(nil).WalletSignMessage), arg0, arg1, arg2)
 }
 
+// WalletSignRules mocks base method.
+func (m *MockFullNode) WalletSignRules(arg0 context.Context) ([]*wallet.SignRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletSignRules", arg0)
+	ret0, _ := ret[0].([]*wallet.SignRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletSignRules indicates an expected call of WalletSignRules.
+func (mr *MockFullNodeMockRecorder) WalletSignRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletSignRules", reflect.TypeOf((*MockFullNode)(nil).WalletSignRules), arg0)
+}
+
 // WalletState mocks base method.
 func (m *MockFullNode) WalletState(arg0 context.Context) int {
 	m.ctrl.T.Helper()
@@ -3537,6 +4372,21 @@ func (mr *MockFullNodeMockRecorder) WalletState(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletState", reflect.TypeOf((*MockFullNode)(nil).WalletState), arg0)
 }
 
+// WalletTxHistory mocks base method.
+func (m *MockFullNode) WalletTxHistory(arg0 context.Context, arg1 address.Address, arg2, arg3 abi.ChainEpoch) ([]*wallet.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletTxHistory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*wallet.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletTxHistory indicates an expected call of WalletTxHistory.
+func (mr *MockFullNodeMockRecorder) WalletTxHistory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletTxHistory", reflect.TypeOf((*MockFullNode)(nil).WalletTxHistory), arg0, arg1, arg2, arg3)
+}
+
 // Web3ClientVersion mocks base method.
 func (m *MockFullNode) Web3ClientVersion(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -11,28 +11,41 @@ import (
 )
 
 type IMessagePool interface {
-	MpoolDeleteByAdress(ctx context.Context, addr address.Address) error                                                                                               //perm:admin
-	MpoolPublishByAddr(context.Context, address.Address) error                                                                                                         //perm:write
-	MpoolPublishMessage(ctx context.Context, smsg *types.SignedMessage) error                                                                                          //perm:write
-	MpoolPush(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                                         //perm:write
-	MpoolGetConfig(context.Context) (*types.MpoolConfig, error)                                                                                                        //perm:read
-	MpoolSetConfig(ctx context.Context, cfg *types.MpoolConfig) error                                                                                                  //perm:admin
-	MpoolSelect(context.Context, types.TipSetKey, float64) ([]*types.SignedMessage, error)                                                                             //perm:read
-	MpoolSelects(context.Context, types.TipSetKey, []float64) ([][]*types.SignedMessage, error)                                                                        //perm:read
-	MpoolPending(ctx context.Context, tsk types.TipSetKey) ([]*types.SignedMessage, error)                                                                             //perm:read
-	MpoolClear(ctx context.Context, local bool) error                                                                                                                  //perm:write
-	MpoolPushUntrusted(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                                //perm:write
-	MpoolPushMessage(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error)                                               //perm:sign
-	MpoolBatchPush(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                               //perm:write
-	MpoolBatchPushUntrusted(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                      //perm:write
-	MpoolBatchPushMessage(ctx context.Context, msgs []*types.Message, spec *types.MessageSendSpec) ([]*types.SignedMessage, error)                                     //perm:sign
-	MpoolGetNonce(ctx context.Context, addr address.Address) (uint64, error)                                                                                           //perm:read
-	MpoolSub(ctx context.Context) (<-chan types.MpoolUpdate, error)                                                                                                    //perm:read
+	MpoolDeleteByAdress(ctx context.Context, addr address.Address) error                                                 //perm:admin
+	MpoolPublishByAddr(context.Context, address.Address) error                                                           //perm:write
+	MpoolPublishMessage(ctx context.Context, smsg *types.SignedMessage) error                                            //perm:write
+	MpoolPush(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                           //perm:write
+	MpoolGetConfig(context.Context) (*types.MpoolConfig, error)                                                          //perm:read
+	MpoolSetConfig(ctx context.Context, cfg *types.MpoolConfig) error                                                    //perm:admin
+	MpoolSelect(context.Context, types.TipSetKey, float64) ([]*types.SignedMessage, error)                               //perm:read
+	MpoolSelects(context.Context, types.TipSetKey, []float64) ([][]*types.SignedMessage, error)                          //perm:read
+	MpoolPending(ctx context.Context, tsk types.TipSetKey) ([]*types.SignedMessage, error)                               //perm:read
+	MpoolClear(ctx context.Context, local bool) error                                                                    //perm:write
+	MpoolPushUntrusted(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                  //perm:read
+	MpoolPushMessage(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error) //perm:sign
+	// MpoolPushMessageWithID is MpoolPushMessage with an idempotency key, the pushes retried with the same id
+	// return the message signed by the first one instead of using another nonce
+	MpoolPushMessageWithID(ctx context.Context, id types.UUID, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error) //perm:sign
+	MpoolBatchPush(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                      //perm:write
+	MpoolBatchPushUntrusted(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                             //perm:read
+	MpoolBatchPushMessage(ctx context.Context, msgs []*types.Message, spec *types.MessageSendSpec) ([]*types.SignedMessage, error)            //perm:sign
+	MpoolGetNonce(ctx context.Context, addr address.Address) (uint64, error)                                                                  //perm:read
+	MpoolSub(ctx context.Context) (<-chan types.MpoolUpdate, error)                                                                           //perm:read
+	// MpoolSubFiltered is like MpoolSub but only sends the updates matching the filter
+	MpoolSubFiltered(ctx context.Context, filter *types.MpoolSubFilter) (<-chan types.MpoolUpdate, error)                                                              //perm:read
 	GasEstimateMessageGas(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec, tsk types.TipSetKey) (*types.Message, error)                           //perm:read
 	GasBatchEstimateMessageGas(ctx context.Context, estimateMessages []*types.EstimateMessage, fromNonce uint64, tsk types.TipSetKey) ([]*types.EstimateResult, error) //perm:read
 	GasEstimateFeeCap(ctx context.Context, msg *types.Message, maxqueueblks int64, tsk types.TipSetKey) (big.Int, error)                                               //perm:read
 	GasEstimateGasPremium(ctx context.Context, nblocksincl uint64, sender address.Address, gaslimit int64, tsk types.TipSetKey) (big.Int, error)                       //perm:read
 	GasEstimateGasLimit(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (int64, error)                                                                 //perm:read
+	// GasStats returns the gas used per actor code and method by the messages of the recent epochs, when the
+	// collection is enabled by the gasStatsEpochs of the mpool config
+	GasStats(ctx context.Context) (*types.GasStats, error) //perm:read
+	// MpoolEstimateInclusion simulates whether the message with its current fee is likely included within nblocksincl epochs
+	MpoolEstimateInclusion(ctx context.Context, msg *types.Message, nblocksincl uint64) (*types.InclusionEstimate, error) //perm:read
+	// MpoolPreviewMessage executes the message on the head state after the pending messages of its sender, the
+	// unset gas limit and fee cap do not limit the execution
+	MpoolPreviewMessage(ctx context.Context, msg *types.Message) (*types.InvocResult, error) //perm:read
 	// MpoolCheckMessages performs logical checks on a batch of messages
 	MpoolCheckMessages(ctx context.Context, protos []*types.MessagePrototype) ([][]types.MessageCheckStatus, error) //perm:read
 	// MpoolCheckPendingMessages performs logical checks for all pending messages from a given address
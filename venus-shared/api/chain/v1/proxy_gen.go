@@ -9,6 +9,7 @@ import (
 	address "github.com/filecoin-project/go-address"
 	bitfield "github.com/filecoin-project/go-bitfield"
 	jsonrpc "github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-jsonrpc/auth"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/crypto"
@@ -24,15 +25,47 @@ import (
 	lminer "github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/verifreg"
 	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/wallet"
 )
 
+type IAuthStruct struct {
+	Internal struct {
+		AuthList     func(ctx context.Context) ([]*types.AuthTokenInfo, error)                                             `perm:"admin"`
+		AuthNew      func(ctx context.Context, perms []auth.Permission) ([]byte, error)                                    `perm:"admin"`
+		AuthNewNamed func(ctx context.Context, name string, perms []auth.Permission, expiry time.Duration) ([]byte, error) `perm:"admin"`
+		AuthRevoke   func(ctx context.Context, name string) error                                                          `perm:"admin"`
+		AuthVerify   func(ctx context.Context, token string) ([]auth.Permission, error)                                    `perm:"read"`
+	}
+}
+
+func (s *IAuthStruct) AuthList(p0 context.Context) ([]*types.AuthTokenInfo, error) {
+	return s.Internal.AuthList(p0)
+}
+func (s *IAuthStruct) AuthNew(p0 context.Context, p1 []auth.Permission) ([]byte, error) {
+	return s.Internal.AuthNew(p0, p1)
+}
+func (s *IAuthStruct) AuthNewNamed(p0 context.Context, p1 string, p2 []auth.Permission, p3 time.Duration) ([]byte, error) {
+	return s.Internal.AuthNewNamed(p0, p1, p2, p3)
+}
+func (s *IAuthStruct) AuthRevoke(p0 context.Context, p1 string) error {
+	return s.Internal.AuthRevoke(p0, p1)
+}
+func (s *IAuthStruct) AuthVerify(p0 context.Context, p1 string) ([]auth.Permission, error) {
+	return s.Internal.AuthVerify(p0, p1)
+}
+
 type IBlockStoreStruct struct {
 	Internal struct {
-		ChainDeleteObj func(ctx context.Context, obj cid.Cid) error                                `perm:"admin"`
-		ChainHasObj    func(ctx context.Context, obj cid.Cid) (bool, error)                        `perm:"read"`
-		ChainPutObj    func(context.Context, blocks.Block) error                                   `perm:"admin"`
-		ChainReadObj   func(ctx context.Context, cid cid.Cid) ([]byte, error)                      `perm:"read"`
-		ChainStatObj   func(ctx context.Context, obj cid.Cid, base cid.Cid) (types.ObjStat, error) `perm:"read"`
+		ChainDeleteObj        func(ctx context.Context, obj cid.Cid) error                                                               `perm:"admin"`
+		ChainHasObj           func(ctx context.Context, obj cid.Cid) (bool, error)                                                       `perm:"read"`
+		ChainPutObj           func(context.Context, blocks.Block) error                                                                  `perm:"admin"`
+		ChainReadObj          func(ctx context.Context, cid cid.Cid) ([]byte, error)                                                     `perm:"read"`
+		ChainStageObj         func(ctx context.Context, obj cid.Cid, data []byte) error                                                  `perm:"admin"`
+		ChainStagedObjs       func(ctx context.Context) (*types.StagedObjStat, error)                                                    `perm:"admin"`
+		ChainStatObj          func(ctx context.Context, obj cid.Cid, base cid.Cid) (types.ObjStat, error)                                `perm:"read"`
+		ChainStatObjWithDepth func(ctx context.Context, obj cid.Cid, base cid.Cid, maxDepth uint64) (types.ObjStatWithDepth, error)      `perm:"read"`
+		DatastoreGC           func(ctx context.Context) (*types.DatastoreGCResult, error)                                                `perm:"admin"`
+		DatastoreScrub        func(ctx context.Context, opts *types.DatastoreScrubOptions) (<-chan *types.DatastoreScrubProgress, error) `perm:"admin"`
 	}
 }
 
@@ -48,83 +81,120 @@ func (s *IBlockStoreStruct) ChainPutObj(p0 context.Context, p1 blocks.Block) err
 func (s *IBlockStoreStruct) ChainReadObj(p0 context.Context, p1 cid.Cid) ([]byte, error) {
 	return s.Internal.ChainReadObj(p0, p1)
 }
+func (s *IBlockStoreStruct) ChainStageObj(p0 context.Context, p1 cid.Cid, p2 []byte) error {
+	return s.Internal.ChainStageObj(p0, p1, p2)
+}
+func (s *IBlockStoreStruct) ChainStagedObjs(p0 context.Context) (*types.StagedObjStat, error) {
+	return s.Internal.ChainStagedObjs(p0)
+}
 func (s *IBlockStoreStruct) ChainStatObj(p0 context.Context, p1 cid.Cid, p2 cid.Cid) (types.ObjStat, error) {
 	return s.Internal.ChainStatObj(p0, p1, p2)
 }
+func (s *IBlockStoreStruct) ChainStatObjWithDepth(p0 context.Context, p1 cid.Cid, p2 cid.Cid, p3 uint64) (types.ObjStatWithDepth, error) {
+	return s.Internal.ChainStatObjWithDepth(p0, p1, p2, p3)
+}
+func (s *IBlockStoreStruct) DatastoreGC(p0 context.Context) (*types.DatastoreGCResult, error) {
+	return s.Internal.DatastoreGC(p0)
+}
+func (s *IBlockStoreStruct) DatastoreScrub(p0 context.Context, p1 *types.DatastoreScrubOptions) (<-chan *types.DatastoreScrubProgress, error) {
+	return s.Internal.DatastoreScrub(p0, p1)
+}
 
 type IAccountStruct struct {
 	Internal struct {
-		StateAccountKey func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error) `perm:"read"`
+		StateAccountKey           func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)      `perm:"read"`
+		StateAccountKeyBySelector func(ctx context.Context, addr address.Address, tss types.TipSetSelector) (address.Address, error) `perm:"read"`
 	}
 }
 
 func (s *IAccountStruct) StateAccountKey(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (address.Address, error) {
 	return s.Internal.StateAccountKey(p0, p1, p2)
 }
+func (s *IAccountStruct) StateAccountKeyBySelector(p0 context.Context, p1 address.Address, p2 types.TipSetSelector) (address.Address, error) {
+	return s.Internal.StateAccountKeyBySelector(p0, p1, p2)
+}
 
 type IActorStruct struct {
 	Internal struct {
-		ListActor     func(ctx context.Context) (map[address.Address]*types.Actor, error)                         `perm:"read"`
-		StateGetActor func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.Actor, error) `perm:"read"`
+		ListActor               func(ctx context.Context) (map[address.Address]*types.Actor, error)                                                    `perm:"read"`
+		StateActorStatObj       func(ctx context.Context, actor address.Address, maxDepth uint64, tsk types.TipSetKey) (types.ObjStatWithDepth, error) `perm:"read"`
+		StateGetActor           func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.Actor, error)                            `perm:"read"`
+		StateGetActorBySelector func(ctx context.Context, actor address.Address, tss types.TipSetSelector) (*types.Actor, error)                       `perm:"read"`
 	}
 }
 
 func (s *IActorStruct) ListActor(p0 context.Context) (map[address.Address]*types.Actor, error) {
 	return s.Internal.ListActor(p0)
 }
+func (s *IActorStruct) StateActorStatObj(p0 context.Context, p1 address.Address, p2 uint64, p3 types.TipSetKey) (types.ObjStatWithDepth, error) {
+	return s.Internal.StateActorStatObj(p0, p1, p2, p3)
+}
 func (s *IActorStruct) StateGetActor(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*types.Actor, error) {
 	return s.Internal.StateGetActor(p0, p1, p2)
 }
+func (s *IActorStruct) StateGetActorBySelector(p0 context.Context, p1 address.Address, p2 types.TipSetSelector) (*types.Actor, error) {
+	return s.Internal.StateGetActorBySelector(p0, p1, p2)
+}
 
 type IMinerStateStruct struct {
 	Internal struct {
-		StateAllMinerFaults                func(ctx context.Context, lookback abi.ChainEpoch, ts types.TipSetKey) ([]*types.Fault, error)                                                 `perm:"read"`
-		StateChangedActors                 func(context.Context, cid.Cid, cid.Cid) (map[string]types.Actor, error)                                                                        `perm:"read"`
-		StateCirculatingSupply             func(ctx context.Context, tsk types.TipSetKey) (abi.TokenAmount, error)                                                                        `perm:"read"`
-		StateComputeDataCID                func(ctx context.Context, maddr address.Address, sectorType abi.RegisteredSealProof, deals []abi.DealID, tsk types.TipSetKey) (cid.Cid, error) `perm:"read"`
-		StateDealProviderCollateralBounds  func(ctx context.Context, size abi.PaddedPieceSize, verified bool, tsk types.TipSetKey) (types.DealCollateralBounds, error)                    `perm:"read"`
-		StateDecodeParams                  func(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, tsk types.TipSetKey) (interface{}, error)               `perm:"read"`
-		StateEncodeParams                  func(ctx context.Context, toActCode cid.Cid, method abi.MethodNum, params json.RawMessage) ([]byte, error)                                     `perm:"read"`
-		StateGetAllAllocations             func(ctx context.Context, tsk types.TipSetKey) (map[types.AllocationId]types.Allocation, error)                                                `perm:"read"`
-		StateGetAllClaims                  func(ctx context.Context, tsk types.TipSetKey) (map[types.ClaimId]types.Claim, error)                                                          `perm:"read"`
-		StateGetAllocation                 func(ctx context.Context, clientAddr address.Address, allocationID types.AllocationId, tsk types.TipSetKey) (*types.Allocation, error)         `perm:"read"`
-		StateGetAllocationForPendingDeal   func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.Allocation, error)                                                   `perm:"read"`
-		StateGetAllocationIdForPendingDeal func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (verifreg.AllocationId, error)                                               `perm:"read"`
-		StateGetAllocations                func(ctx context.Context, clientAddr address.Address, tsk types.TipSetKey) (map[types.AllocationId]types.Allocation, error)                    `perm:"read"`
-		StateGetClaim                      func(ctx context.Context, providerAddr address.Address, claimID types.ClaimId, tsk types.TipSetKey) (*types.Claim, error)                      `perm:"read"`
-		StateGetClaims                     func(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) (map[types.ClaimId]types.Claim, error)                            `perm:"read"`
-		StateListActors                    func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                      `perm:"read"`
-		StateListMessages                  func(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch) ([]cid.Cid, error)                              `perm:"read"`
-		StateListMiners                    func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                      `perm:"read"`
-		StateLookupID                      func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)                                                  `perm:"read"`
-		StateLookupRobustAddress           func(context.Context, address.Address, types.TipSetKey) (address.Address, error)                                                               `perm:"read"`
-		StateMarketBalance                 func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MarketBalance, error)                                              `perm:"read"`
-		StateMarketDeals                   func(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error)                                                           `perm:"read"`
-		StateMarketStorageDeal             func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.MarketDeal, error)                                                   `perm:"read"`
-		StateMinerActiveSectors            func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)                                      `perm:"read"`
-		StateMinerAllocated                func(context.Context, address.Address, types.TipSetKey) (*bitfield.BitField, error)                                                            `perm:"read"`
-		StateMinerAvailableBalance         func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error)                                                         `perm:"read"`
-		StateMinerDeadlines                func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.Deadline, error)                                                `perm:"read"`
-		StateMinerFaults                   func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                               `perm:"read"`
-		StateMinerInfo                     func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (types.MinerInfo, error)                                                 `perm:"read"`
-		StateMinerInitialPledgeCollateral  func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                          `perm:"read"`
-		StateMinerPartitions               func(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error)                                 `perm:"read"`
-		StateMinerPower                    func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*types.MinerPower, error)                                                `perm:"read"`
-		StateMinerPreCommitDepositForPower func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                          `perm:"read"`
-		StateMinerProvingDeadline          func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*dline.Info, error)                                                     `perm:"read"`
-		StateMinerRecoveries               func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                               `perm:"read"`
-		StateMinerSectorAllocated          func(ctx context.Context, maddr address.Address, s abi.SectorNumber, tsk types.TipSetKey) (bool, error)                                        `perm:"read"`
-		StateMinerSectorCount              func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MinerSectors, error)                                               `perm:"read"`
-		StateMinerSectorSize               func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (abi.SectorSize, error)                                                  `perm:"read"`
-		StateMinerSectors                  func(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)        `perm:"read"`
-		StateMinerWorkerAddress            func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (address.Address, error)                                                 `perm:"read"`
-		StateReadState                     func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.ActorState, error)                                               `perm:"read"`
-		StateSectorExpiration              func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorExpiration, error)         `perm:"read"`
-		StateSectorGetInfo                 func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*types.SectorOnChainInfo, error)                    `perm:"read"`
-		StateSectorPartition               func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorLocation, error)           `perm:"read"`
-		StateSectorPreCommitInfo           func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*types.SectorPreCommitOnChainInfo, error)           `perm:"read"`
-		StateVMCirculatingSupplyInternal   func(ctx context.Context, tsk types.TipSetKey) (types.CirculatingSupply, error)                                                                `perm:"read"`
-		StateVerifiedClientStatus          func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                                `perm:"read"`
+		StateAllMinerFaults                     func(ctx context.Context, lookback abi.ChainEpoch, ts types.TipSetKey) ([]*types.Fault, error)                                                     `perm:"read"`
+		StateChangedActors                      func(context.Context, cid.Cid, cid.Cid) (map[string]types.Actor, error)                                                                            `perm:"read"`
+		StateCirculatingSupply                  func(ctx context.Context, tsk types.TipSetKey) (abi.TokenAmount, error)                                                                            `perm:"read"`
+		StateComputeDataCID                     func(ctx context.Context, maddr address.Address, sectorType abi.RegisteredSealProof, deals []abi.DealID, tsk types.TipSetKey) (cid.Cid, error)     `perm:"read"`
+		StateDealProviderCollateralBounds       func(ctx context.Context, size abi.PaddedPieceSize, verified bool, tsk types.TipSetKey) (types.DealCollateralBounds, error)                        `perm:"read"`
+		StateDecodeParams                       func(ctx context.Context, toAddr address.Address, method abi.MethodNum, params []byte, tsk types.TipSetKey) (interface{}, error)                   `perm:"read"`
+		StateEncodeParams                       func(ctx context.Context, toActCode cid.Cid, method abi.MethodNum, params json.RawMessage) ([]byte, error)                                         `perm:"read"`
+		StateGetAllAllocations                  func(ctx context.Context, tsk types.TipSetKey) (map[types.AllocationId]types.Allocation, error)                                                    `perm:"read"`
+		StateGetAllClaims                       func(ctx context.Context, tsk types.TipSetKey) (map[types.ClaimId]types.Claim, error)                                                              `perm:"read"`
+		StateGetAllocation                      func(ctx context.Context, clientAddr address.Address, allocationID types.AllocationId, tsk types.TipSetKey) (*types.Allocation, error)             `perm:"read"`
+		StateGetAllocationForPendingDeal        func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.Allocation, error)                                                       `perm:"read"`
+		StateGetAllocationIdForPendingDeal      func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (verifreg.AllocationId, error)                                                   `perm:"read"`
+		StateGetAllocations                     func(ctx context.Context, clientAddr address.Address, tsk types.TipSetKey) (map[types.AllocationId]types.Allocation, error)                        `perm:"read"`
+		StateGetClaim                           func(ctx context.Context, providerAddr address.Address, claimID types.ClaimId, tsk types.TipSetKey) (*types.Claim, error)                          `perm:"read"`
+		StateGetClaims                          func(ctx context.Context, providerAddr address.Address, tsk types.TipSetKey) (map[types.ClaimId]types.Claim, error)                                `perm:"read"`
+		StateListActors                         func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                          `perm:"read"`
+		StateListMatchedMessages                func(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch, decodeParams bool) ([]*types.MatchedMessage, error) `perm:"read"`
+		StateListMessages                       func(ctx context.Context, match *types.MessageMatch, tsk types.TipSetKey, toht abi.ChainEpoch) ([]cid.Cid, error)                                  `perm:"read"`
+		StateListMiners                         func(ctx context.Context, tsk types.TipSetKey) ([]address.Address, error)                                                                          `perm:"read"`
+		StateListVerifiedClients                func(ctx context.Context, tsk types.TipSetKey) ([]types.DataCapEntry, error)                                                                       `perm:"read"`
+		StateListVerifiers                      func(ctx context.Context, tsk types.TipSetKey) ([]types.DataCapEntry, error)                                                                       `perm:"read"`
+		StateLookupID                           func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error)                                                      `perm:"read"`
+		StateLookupIDBySelector                 func(ctx context.Context, addr address.Address, tss types.TipSetSelector) (address.Address, error)                                                 `perm:"read"`
+		StateLookupRobustAddress                func(context.Context, address.Address, types.TipSetKey) (address.Address, error)                                                                   `perm:"read"`
+		StateMarketBalance                      func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MarketBalance, error)                                                  `perm:"read"`
+		StateMarketDeals                        func(ctx context.Context, tsk types.TipSetKey) (map[string]*types.MarketDeal, error)                                                               `perm:"read"`
+		StateMarketStorageDeal                  func(ctx context.Context, dealID abi.DealID, tsk types.TipSetKey) (*types.MarketDeal, error)                                                       `perm:"read"`
+		StateMinerActiveSectors                 func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)                                          `perm:"read"`
+		StateMinerAllocated                     func(context.Context, address.Address, types.TipSetKey) (*bitfield.BitField, error)                                                                `perm:"read"`
+		StateMinerAvailableBalance              func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (big.Int, error)                                                             `perm:"read"`
+		StateMinerDeadlines                     func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) ([]types.Deadline, error)                                                    `perm:"read"`
+		StateMinerFaults                        func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                                   `perm:"read"`
+		StateMinerInfo                          func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (types.MinerInfo, error)                                                     `perm:"read"`
+		StateMinerInitialPledgeCollateral       func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                              `perm:"read"`
+		StateMinerPartitions                    func(ctx context.Context, maddr address.Address, dlIdx uint64, tsk types.TipSetKey) ([]types.Partition, error)                                     `perm:"read"`
+		StateMinerPower                         func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*types.MinerPower, error)                                                    `perm:"read"`
+		StateMinerPreCommitDepositForPower      func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (big.Int, error)                              `perm:"read"`
+		StateMinerProvingDeadline               func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*dline.Info, error)                                                         `perm:"read"`
+		StateMinerProvingDeadlineWithPartitions func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (*types.ProvingDeadline, error)                                              `perm:"read"`
+		StateMinerRecoveries                    func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (bitfield.BitField, error)                                                   `perm:"read"`
+		StateMinerSectorAllocated               func(ctx context.Context, maddr address.Address, s abi.SectorNumber, tsk types.TipSetKey) (bool, error)                                            `perm:"read"`
+		StateMinerSectorCollateral              func(ctx context.Context, maddr address.Address, pci types.SectorPreCommitInfo, tsk types.TipSetKey) (*types.SectorCollateral, error)              `perm:"read"`
+		StateMinerSectorCount                   func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MinerSectors, error)                                                   `perm:"read"`
+		StateMinerSectorSize                    func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (abi.SectorSize, error)                                                      `perm:"read"`
+		StateMinerSectors                       func(ctx context.Context, maddr address.Address, sectorNos *bitfield.BitField, tsk types.TipSetKey) ([]*types.SectorOnChainInfo, error)            `perm:"read"`
+		StateMinerWorkerAddress                 func(ctx context.Context, maddr address.Address, tsk types.TipSetKey) (address.Address, error)                                                     `perm:"read"`
+		StateReadState                          func(ctx context.Context, actor address.Address, tsk types.TipSetKey) (*types.ActorState, error)                                                   `perm:"read"`
+		StateRemoveDataCapProposalID            func(ctx context.Context, verifier address.Address, client address.Address, tsk types.TipSetKey) (uint64, error)                                   `perm:"read"`
+		StateSectorBatchEstimate                func(ctx context.Context, kind types.SectorBatchKind, size int, tsk types.TipSetKey) (*types.SectorBatchEstimate, error)                           `perm:"read"`
+		StateSectorExpiration                   func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorExpiration, error)             `perm:"read"`
+		StateSectorGetInfo                      func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*types.SectorOnChainInfo, error)                        `perm:"read"`
+		StateSectorPartition                    func(ctx context.Context, maddr address.Address, sectorNumber abi.SectorNumber, tsk types.TipSetKey) (*lminer.SectorLocation, error)               `perm:"read"`
+		StateSectorPreCommitInfo                func(ctx context.Context, maddr address.Address, n abi.SectorNumber, tsk types.TipSetKey) (*types.SectorPreCommitOnChainInfo, error)               `perm:"read"`
+		StateSupplyHistory                      func(ctx context.Context, from, to, step abi.ChainEpoch, tsk types.TipSetKey) ([]*types.SupplyPoint, error)                                        `perm:"read"`
+		StateVMCirculatingSupplyInternal        func(ctx context.Context, tsk types.TipSetKey) (types.CirculatingSupply, error)                                                                    `perm:"read"`
+		StateVerifiedClientStatus               func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*abi.StoragePower, error)                                                    `perm:"read"`
+		SubscribeDealUpdates                    func(ctx context.Context, dealIDs []abi.DealID) (<-chan []*types.DealUpdate, error)                                                                `perm:"read"`
 	}
 }
 
@@ -176,15 +246,27 @@ func (s *IMinerStateStruct) StateGetClaims(p0 context.Context, p1 address.Addres
 func (s *IMinerStateStruct) StateListActors(p0 context.Context, p1 types.TipSetKey) ([]address.Address, error) {
 	return s.Internal.StateListActors(p0, p1)
 }
+func (s *IMinerStateStruct) StateListMatchedMessages(p0 context.Context, p1 *types.MessageMatch, p2 types.TipSetKey, p3 abi.ChainEpoch, p4 bool) ([]*types.MatchedMessage, error) {
+	return s.Internal.StateListMatchedMessages(p0, p1, p2, p3, p4)
+}
 func (s *IMinerStateStruct) StateListMessages(p0 context.Context, p1 *types.MessageMatch, p2 types.TipSetKey, p3 abi.ChainEpoch) ([]cid.Cid, error) {
 	return s.Internal.StateListMessages(p0, p1, p2, p3)
 }
 func (s *IMinerStateStruct) StateListMiners(p0 context.Context, p1 types.TipSetKey) ([]address.Address, error) {
 	return s.Internal.StateListMiners(p0, p1)
 }
+func (s *IMinerStateStruct) StateListVerifiedClients(p0 context.Context, p1 types.TipSetKey) ([]types.DataCapEntry, error) {
+	return s.Internal.StateListVerifiedClients(p0, p1)
+}
+func (s *IMinerStateStruct) StateListVerifiers(p0 context.Context, p1 types.TipSetKey) ([]types.DataCapEntry, error) {
+	return s.Internal.StateListVerifiers(p0, p1)
+}
 func (s *IMinerStateStruct) StateLookupID(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (address.Address, error) {
 	return s.Internal.StateLookupID(p0, p1, p2)
 }
+func (s *IMinerStateStruct) StateLookupIDBySelector(p0 context.Context, p1 address.Address, p2 types.TipSetSelector) (address.Address, error) {
+	return s.Internal.StateLookupIDBySelector(p0, p1, p2)
+}
 func (s *IMinerStateStruct) StateLookupRobustAddress(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (address.Address, error) {
 	return s.Internal.StateLookupRobustAddress(p0, p1, p2)
 }
@@ -230,12 +312,18 @@ func (s *IMinerStateStruct) StateMinerPreCommitDepositForPower(p0 context.Contex
 func (s *IMinerStateStruct) StateMinerProvingDeadline(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*dline.Info, error) {
 	return s.Internal.StateMinerProvingDeadline(p0, p1, p2)
 }
+func (s *IMinerStateStruct) StateMinerProvingDeadlineWithPartitions(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*types.ProvingDeadline, error) {
+	return s.Internal.StateMinerProvingDeadlineWithPartitions(p0, p1, p2)
+}
 func (s *IMinerStateStruct) StateMinerRecoveries(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (bitfield.BitField, error) {
 	return s.Internal.StateMinerRecoveries(p0, p1, p2)
 }
 func (s *IMinerStateStruct) StateMinerSectorAllocated(p0 context.Context, p1 address.Address, p2 abi.SectorNumber, p3 types.TipSetKey) (bool, error) {
 	return s.Internal.StateMinerSectorAllocated(p0, p1, p2, p3)
 }
+func (s *IMinerStateStruct) StateMinerSectorCollateral(p0 context.Context, p1 address.Address, p2 types.SectorPreCommitInfo, p3 types.TipSetKey) (*types.SectorCollateral, error) {
+	return s.Internal.StateMinerSectorCollateral(p0, p1, p2, p3)
+}
 func (s *IMinerStateStruct) StateMinerSectorCount(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (types.MinerSectors, error) {
 	return s.Internal.StateMinerSectorCount(p0, p1, p2)
 }
@@ -251,6 +339,12 @@ func (s *IMinerStateStruct) StateMinerWorkerAddress(p0 context.Context, p1 addre
 func (s *IMinerStateStruct) StateReadState(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*types.ActorState, error) {
 	return s.Internal.StateReadState(p0, p1, p2)
 }
+func (s *IMinerStateStruct) StateRemoveDataCapProposalID(p0 context.Context, p1 address.Address, p2 address.Address, p3 types.TipSetKey) (uint64, error) {
+	return s.Internal.StateRemoveDataCapProposalID(p0, p1, p2, p3)
+}
+func (s *IMinerStateStruct) StateSectorBatchEstimate(p0 context.Context, p1 types.SectorBatchKind, p2 int, p3 types.TipSetKey) (*types.SectorBatchEstimate, error) {
+	return s.Internal.StateSectorBatchEstimate(p0, p1, p2, p3)
+}
 func (s *IMinerStateStruct) StateSectorExpiration(p0 context.Context, p1 address.Address, p2 abi.SectorNumber, p3 types.TipSetKey) (*lminer.SectorExpiration, error) {
 	return s.Internal.StateSectorExpiration(p0, p1, p2, p3)
 }
@@ -263,20 +357,28 @@ func (s *IMinerStateStruct) StateSectorPartition(p0 context.Context, p1 address.
 func (s *IMinerStateStruct) StateSectorPreCommitInfo(p0 context.Context, p1 address.Address, p2 abi.SectorNumber, p3 types.TipSetKey) (*types.SectorPreCommitOnChainInfo, error) {
 	return s.Internal.StateSectorPreCommitInfo(p0, p1, p2, p3)
 }
+func (s *IMinerStateStruct) StateSupplyHistory(p0 context.Context, p1, p2, p3 abi.ChainEpoch, p4 types.TipSetKey) ([]*types.SupplyPoint, error) {
+	return s.Internal.StateSupplyHistory(p0, p1, p2, p3, p4)
+}
 func (s *IMinerStateStruct) StateVMCirculatingSupplyInternal(p0 context.Context, p1 types.TipSetKey) (types.CirculatingSupply, error) {
 	return s.Internal.StateVMCirculatingSupplyInternal(p0, p1)
 }
 func (s *IMinerStateStruct) StateVerifiedClientStatus(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (*abi.StoragePower, error) {
 	return s.Internal.StateVerifiedClientStatus(p0, p1, p2)
 }
+func (s *IMinerStateStruct) SubscribeDealUpdates(p0 context.Context, p1 []abi.DealID) (<-chan []*types.DealUpdate, error) {
+	return s.Internal.SubscribeDealUpdates(p0, p1)
+}
 
 type IChainInfoStruct struct {
 	Internal struct {
 		BlockTime                           func(ctx context.Context) time.Duration                                                                                                                      `perm:"read"`
 		ChainExport                         func(context.Context, abi.ChainEpoch, bool, types.TipSetKey) (<-chan []byte, error)                                                                          `perm:"read"`
+		ChainGCStatus                       func(ctx context.Context) (types.ChainGCStatus, error)                                                                                                       `perm:"read"`
 		ChainGetBlock                       func(ctx context.Context, id cid.Cid) (*types.BlockHeader, error)                                                                                            `perm:"read"`
 		ChainGetBlockMessages               func(ctx context.Context, bid cid.Cid) (*types.BlockMessages, error)                                                                                         `perm:"read"`
 		ChainGetEvents                      func(context.Context, cid.Cid) ([]types.Event, error)                                                                                                        `perm:"read"`
+		ChainGetFinalizedHead               func(ctx context.Context) (*types.FinalizedHead, error)                                                                                                      `perm:"read"`
 		ChainGetGenesis                     func(context.Context) (*types.TipSet, error)                                                                                                                 `perm:"read"`
 		ChainGetMessage                     func(ctx context.Context, msgID cid.Cid) (*types.Message, error)                                                                                             `perm:"read"`
 		ChainGetMessagesInTipset            func(ctx context.Context, key types.TipSetKey) ([]types.MessageCID, error)                                                                                   `perm:"read"`
@@ -284,12 +386,17 @@ type IChainInfoStruct struct {
 		ChainGetParentReceipts              func(ctx context.Context, bcid cid.Cid) ([]*types.MessageReceipt, error)                                                                                     `perm:"read"`
 		ChainGetPath                        func(ctx context.Context, from types.TipSetKey, to types.TipSetKey) ([]*types.HeadChange, error)                                                             `perm:"read"`
 		ChainGetReceipts                    func(ctx context.Context, id cid.Cid) ([]types.MessageReceipt, error)                                                                                        `perm:"read"`
+		ChainGetResolvedMessagesInTipset    func(ctx context.Context, key types.TipSetKey) ([]types.ResolvedMessage, error)                                                                              `perm:"read"`
 		ChainGetTipSet                      func(ctx context.Context, key types.TipSetKey) (*types.TipSet, error)                                                                                        `perm:"read"`
 		ChainGetTipSetAfterHeight           func(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                 `perm:"read"`
 		ChainGetTipSetByHeight              func(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                 `perm:"read"`
+		ChainGetTipSetBySelector            func(ctx context.Context, tss types.TipSetSelector) (*types.TipSet, error)                                                                                   `perm:"read"`
 		ChainHead                           func(ctx context.Context) (*types.TipSet, error)                                                                                                             `perm:"read"`
+		ChainHotGC                          func(ctx context.Context) (*types.ChainOffloadStats, error)                                                                                                  `perm:"admin"`
 		ChainList                           func(ctx context.Context, tsKey types.TipSetKey, count int) ([]types.TipSetKey, error)                                                                       `perm:"read"`
 		ChainNotify                         func(ctx context.Context) (<-chan []*types.HeadChange, error)                                                                                                `perm:"read"`
+		ChainOffloadRestore                 func(ctx context.Context, from, to abi.ChainEpoch) (*types.ChainOffloadStats, error)                                                                         `perm:"admin"`
+		ChainOffloadStatus                  func(ctx context.Context) (types.ChainOffloadStatus, error)                                                                                                  `perm:"read"`
 		ChainSetHead                        func(ctx context.Context, key types.TipSetKey) error                                                                                                         `perm:"admin"`
 		GetActor                            func(ctx context.Context, addr address.Address) (*types.Actor, error)                                                                                        `perm:"read"`
 		GetEntry                            func(ctx context.Context, height abi.ChainEpoch, round uint64) (*types.BeaconEntry, error)                                                                   `perm:"read"`
@@ -299,7 +406,9 @@ type IChainInfoStruct struct {
 		ResolveToKeyAddr                    func(ctx context.Context, addr address.Address, ts *types.TipSet) (address.Address, error)                                                                   `perm:"read"`
 		StateActorCodeCIDs                  func(context.Context, network.Version) (map[string]cid.Cid, error)                                                                                           `perm:"read"`
 		StateActorManifestCID               func(context.Context, network.Version) (cid.Cid, error)                                                                                                      `perm:"read"`
+		StateActorNames                     func(ctx context.Context, codes []cid.Cid) ([]*types.ActorCodeName, error)                                                                                   `perm:"read"`
 		StateCall                           func(ctx context.Context, msg *types.Message, tsk types.TipSetKey) (*types.InvocResult, error)                                                               `perm:"read"`
+		StateCallBySelector                 func(ctx context.Context, msg *types.Message, tss types.TipSetSelector) (*types.InvocResult, error)                                                          `perm:"read"`
 		StateCompute                        func(context.Context, abi.ChainEpoch, []*types.Message, types.TipSetKey) (*types.ComputeStateOutput, error)                                                  `perm:"read"`
 		StateGetBeaconEntry                 func(ctx context.Context, epoch abi.ChainEpoch) (*types.BeaconEntry, error)                                                                                  `perm:"read"`
 		StateGetNetworkParams               func(ctx context.Context) (*types.NetworkParams, error)                                                                                                      `perm:"read"`
@@ -308,7 +417,9 @@ type IChainInfoStruct struct {
 		StateGetRandomnessFromBeacon        func(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) `perm:"read"`
 		StateGetRandomnessFromTickets       func(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) `perm:"read"`
 		StateNetworkName                    func(ctx context.Context) (types.NetworkName, error)                                                                                                         `perm:"read"`
+		StateNetworkUpgradeSchedule         func(ctx context.Context) ([]*types.NetworkUpgrade, error)                                                                                                   `perm:"read"`
 		StateNetworkVersion                 func(ctx context.Context, tsk types.TipSetKey) (network.Version, error)                                                                                      `perm:"read"`
+		StateNetworkVersionAt               func(ctx context.Context, height abi.ChainEpoch) (network.Version, error)                                                                                    `perm:"read"`
 		StateReplay                         func(context.Context, types.TipSetKey, cid.Cid) (*types.InvocResult, error)                                                                                  `perm:"read"`
 		StateSearchMsg                      func(ctx context.Context, from types.TipSetKey, msg cid.Cid, limit abi.ChainEpoch, allowReplaced bool) (*types.MsgLookup, error)                             `perm:"read"`
 		StateVerifiedRegistryRootKey        func(ctx context.Context, tsk types.TipSetKey) (address.Address, error)                                                                                      `perm:"read"`
@@ -324,6 +435,9 @@ func (s *IChainInfoStruct) BlockTime(p0 context.Context) time.Duration {
 func (s *IChainInfoStruct) ChainExport(p0 context.Context, p1 abi.ChainEpoch, p2 bool, p3 types.TipSetKey) (<-chan []byte, error) {
 	return s.Internal.ChainExport(p0, p1, p2, p3)
 }
+func (s *IChainInfoStruct) ChainGCStatus(p0 context.Context) (types.ChainGCStatus, error) {
+	return s.Internal.ChainGCStatus(p0)
+}
 func (s *IChainInfoStruct) ChainGetBlock(p0 context.Context, p1 cid.Cid) (*types.BlockHeader, error) {
 	return s.Internal.ChainGetBlock(p0, p1)
 }
@@ -333,6 +447,9 @@ func (s *IChainInfoStruct) ChainGetBlockMessages(p0 context.Context, p1 cid.Cid)
 func (s *IChainInfoStruct) ChainGetEvents(p0 context.Context, p1 cid.Cid) ([]types.Event, error) {
 	return s.Internal.ChainGetEvents(p0, p1)
 }
+func (s *IChainInfoStruct) ChainGetFinalizedHead(p0 context.Context) (*types.FinalizedHead, error) {
+	return s.Internal.ChainGetFinalizedHead(p0)
+}
 func (s *IChainInfoStruct) ChainGetGenesis(p0 context.Context) (*types.TipSet, error) {
 	return s.Internal.ChainGetGenesis(p0)
 }
@@ -354,6 +471,9 @@ func (s *IChainInfoStruct) ChainGetPath(p0 context.Context, p1 types.TipSetKey,
 func (s *IChainInfoStruct) ChainGetReceipts(p0 context.Context, p1 cid.Cid) ([]types.MessageReceipt, error) {
 	return s.Internal.ChainGetReceipts(p0, p1)
 }
+func (s *IChainInfoStruct) ChainGetResolvedMessagesInTipset(p0 context.Context, p1 types.TipSetKey) ([]types.ResolvedMessage, error) {
+	return s.Internal.ChainGetResolvedMessagesInTipset(p0, p1)
+}
 func (s *IChainInfoStruct) ChainGetTipSet(p0 context.Context, p1 types.TipSetKey) (*types.TipSet, error) {
 	return s.Internal.ChainGetTipSet(p0, p1)
 }
@@ -363,15 +483,27 @@ func (s *IChainInfoStruct) ChainGetTipSetAfterHeight(p0 context.Context, p1 abi.
 func (s *IChainInfoStruct) ChainGetTipSetByHeight(p0 context.Context, p1 abi.ChainEpoch, p2 types.TipSetKey) (*types.TipSet, error) {
 	return s.Internal.ChainGetTipSetByHeight(p0, p1, p2)
 }
+func (s *IChainInfoStruct) ChainGetTipSetBySelector(p0 context.Context, p1 types.TipSetSelector) (*types.TipSet, error) {
+	return s.Internal.ChainGetTipSetBySelector(p0, p1)
+}
 func (s *IChainInfoStruct) ChainHead(p0 context.Context) (*types.TipSet, error) {
 	return s.Internal.ChainHead(p0)
 }
+func (s *IChainInfoStruct) ChainHotGC(p0 context.Context) (*types.ChainOffloadStats, error) {
+	return s.Internal.ChainHotGC(p0)
+}
 func (s *IChainInfoStruct) ChainList(p0 context.Context, p1 types.TipSetKey, p2 int) ([]types.TipSetKey, error) {
 	return s.Internal.ChainList(p0, p1, p2)
 }
 func (s *IChainInfoStruct) ChainNotify(p0 context.Context) (<-chan []*types.HeadChange, error) {
 	return s.Internal.ChainNotify(p0)
 }
+func (s *IChainInfoStruct) ChainOffloadRestore(p0 context.Context, p1, p2 abi.ChainEpoch) (*types.ChainOffloadStats, error) {
+	return s.Internal.ChainOffloadRestore(p0, p1, p2)
+}
+func (s *IChainInfoStruct) ChainOffloadStatus(p0 context.Context) (types.ChainOffloadStatus, error) {
+	return s.Internal.ChainOffloadStatus(p0)
+}
 func (s *IChainInfoStruct) ChainSetHead(p0 context.Context, p1 types.TipSetKey) error {
 	return s.Internal.ChainSetHead(p0, p1)
 }
@@ -399,9 +531,15 @@ func (s *IChainInfoStruct) StateActorCodeCIDs(p0 context.Context, p1 network.Ver
 func (s *IChainInfoStruct) StateActorManifestCID(p0 context.Context, p1 network.Version) (cid.Cid, error) {
 	return s.Internal.StateActorManifestCID(p0, p1)
 }
+func (s *IChainInfoStruct) StateActorNames(p0 context.Context, p1 []cid.Cid) ([]*types.ActorCodeName, error) {
+	return s.Internal.StateActorNames(p0, p1)
+}
 func (s *IChainInfoStruct) StateCall(p0 context.Context, p1 *types.Message, p2 types.TipSetKey) (*types.InvocResult, error) {
 	return s.Internal.StateCall(p0, p1, p2)
 }
+func (s *IChainInfoStruct) StateCallBySelector(p0 context.Context, p1 *types.Message, p2 types.TipSetSelector) (*types.InvocResult, error) {
+	return s.Internal.StateCallBySelector(p0, p1, p2)
+}
 func (s *IChainInfoStruct) StateCompute(p0 context.Context, p1 abi.ChainEpoch, p2 []*types.Message, p3 types.TipSetKey) (*types.ComputeStateOutput, error) {
 	return s.Internal.StateCompute(p0, p1, p2, p3)
 }
@@ -426,9 +564,15 @@ func (s *IChainInfoStruct) StateGetRandomnessFromTickets(p0 context.Context, p1
 func (s *IChainInfoStruct) StateNetworkName(p0 context.Context) (types.NetworkName, error) {
 	return s.Internal.StateNetworkName(p0)
 }
+func (s *IChainInfoStruct) StateNetworkUpgradeSchedule(p0 context.Context) ([]*types.NetworkUpgrade, error) {
+	return s.Internal.StateNetworkUpgradeSchedule(p0)
+}
 func (s *IChainInfoStruct) StateNetworkVersion(p0 context.Context, p1 types.TipSetKey) (network.Version, error) {
 	return s.Internal.StateNetworkVersion(p0, p1)
 }
+func (s *IChainInfoStruct) StateNetworkVersionAt(p0 context.Context, p1 abi.ChainEpoch) (network.Version, error) {
+	return s.Internal.StateNetworkVersionAt(p0, p1)
+}
 func (s *IChainInfoStruct) StateReplay(p0 context.Context, p1 types.TipSetKey, p2 cid.Cid) (*types.InvocResult, error) {
 	return s.Internal.StateReplay(p0, p1, p2)
 }
@@ -467,11 +611,15 @@ func (s *IMarketStruct) StateMarketParticipants(p0 context.Context, p1 types.Tip
 
 type IMiningStruct struct {
 	Internal struct {
+		MineOne          func(ctx context.Context, maddr address.Address) (*types.MinedBlock, error)                                                `perm:"admin"`
 		MinerCreateBlock func(ctx context.Context, bt *types.BlockTemplate) (*types.BlockMsg, error)                                                `perm:"write"`
 		MinerGetBaseInfo func(ctx context.Context, maddr address.Address, round abi.ChainEpoch, tsk types.TipSetKey) (*types.MiningBaseInfo, error) `perm:"read"`
 	}
 }
 
+func (s *IMiningStruct) MineOne(p0 context.Context, p1 address.Address) (*types.MinedBlock, error) {
+	return s.Internal.MineOne(p0, p1)
+}
 func (s *IMiningStruct) MinerCreateBlock(p0 context.Context, p1 *types.BlockTemplate) (*types.BlockMsg, error) {
 	return s.Internal.MinerCreateBlock(p0, p1)
 }
@@ -486,26 +634,31 @@ type IMessagePoolStruct struct {
 		GasEstimateGasLimit        func(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (int64, error)                                                          `perm:"read"`
 		GasEstimateGasPremium      func(ctx context.Context, nblocksincl uint64, sender address.Address, gaslimit int64, tsk types.TipSetKey) (big.Int, error)                  `perm:"read"`
 		GasEstimateMessageGas      func(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec, tsk types.TipSetKey) (*types.Message, error)                      `perm:"read"`
+		GasStats                   func(ctx context.Context) (*types.GasStats, error)                                                                                           `perm:"read"`
 		MpoolBatchPush             func(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                   `perm:"write"`
 		MpoolBatchPushMessage      func(ctx context.Context, msgs []*types.Message, spec *types.MessageSendSpec) ([]*types.SignedMessage, error)                                `perm:"sign"`
-		MpoolBatchPushUntrusted    func(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                   `perm:"write"`
+		MpoolBatchPushUntrusted    func(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                   `perm:"read"`
 		MpoolCheckMessages         func(ctx context.Context, protos []*types.MessagePrototype) ([][]types.MessageCheckStatus, error)                                            `perm:"read"`
 		MpoolCheckPendingMessages  func(ctx context.Context, addr address.Address) ([][]types.MessageCheckStatus, error)                                                        `perm:"read"`
 		MpoolCheckReplaceMessages  func(ctx context.Context, msg []*types.Message) ([][]types.MessageCheckStatus, error)                                                        `perm:"read"`
 		MpoolClear                 func(ctx context.Context, local bool) error                                                                                                  `perm:"write"`
 		MpoolDeleteByAdress        func(ctx context.Context, addr address.Address) error                                                                                        `perm:"admin"`
+		MpoolEstimateInclusion     func(ctx context.Context, msg *types.Message, nblocksincl uint64) (*types.InclusionEstimate, error)                                          `perm:"read"`
 		MpoolGetConfig             func(context.Context) (*types.MpoolConfig, error)                                                                                            `perm:"read"`
 		MpoolGetNonce              func(ctx context.Context, addr address.Address) (uint64, error)                                                                              `perm:"read"`
 		MpoolPending               func(ctx context.Context, tsk types.TipSetKey) ([]*types.SignedMessage, error)                                                               `perm:"read"`
+		MpoolPreviewMessage        func(ctx context.Context, msg *types.Message) (*types.InvocResult, error)                                                                    `perm:"read"`
 		MpoolPublishByAddr         func(context.Context, address.Address) error                                                                                                 `perm:"write"`
 		MpoolPublishMessage        func(ctx context.Context, smsg *types.SignedMessage) error                                                                                   `perm:"write"`
 		MpoolPush                  func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                        `perm:"write"`
 		MpoolPushMessage           func(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error)                                     `perm:"sign"`
-		MpoolPushUntrusted         func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                        `perm:"write"`
+		MpoolPushMessageWithID     func(ctx context.Context, id types.UUID, msg *types.Message, spec *types.MessageSendSpec) (*types.SignedMessage, error)                      `perm:"sign"`
+		MpoolPushUntrusted         func(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error)                                                                        `perm:"read"`
 		MpoolSelect                func(context.Context, types.TipSetKey, float64) ([]*types.SignedMessage, error)                                                              `perm:"read"`
 		MpoolSelects               func(context.Context, types.TipSetKey, []float64) ([][]*types.SignedMessage, error)                                                          `perm:"read"`
 		MpoolSetConfig             func(ctx context.Context, cfg *types.MpoolConfig) error                                                                                      `perm:"admin"`
 		MpoolSub                   func(ctx context.Context) (<-chan types.MpoolUpdate, error)                                                                                  `perm:"read"`
+		MpoolSubFiltered           func(ctx context.Context, filter *types.MpoolSubFilter) (<-chan types.MpoolUpdate, error)                                                    `perm:"read"`
 	}
 }
 
@@ -524,6 +677,9 @@ func (s *IMessagePoolStruct) GasEstimateGasPremium(p0 context.Context, p1 uint64
 func (s *IMessagePoolStruct) GasEstimateMessageGas(p0 context.Context, p1 *types.Message, p2 *types.MessageSendSpec, p3 types.TipSetKey) (*types.Message, error) {
 	return s.Internal.GasEstimateMessageGas(p0, p1, p2, p3)
 }
+func (s *IMessagePoolStruct) GasStats(p0 context.Context) (*types.GasStats, error) {
+	return s.Internal.GasStats(p0)
+}
 func (s *IMessagePoolStruct) MpoolBatchPush(p0 context.Context, p1 []*types.SignedMessage) ([]cid.Cid, error) {
 	return s.Internal.MpoolBatchPush(p0, p1)
 }
@@ -548,6 +704,9 @@ func (s *IMessagePoolStruct) MpoolClear(p0 context.Context, p1 bool) error {
 func (s *IMessagePoolStruct) MpoolDeleteByAdress(p0 context.Context, p1 address.Address) error {
 	return s.Internal.MpoolDeleteByAdress(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolEstimateInclusion(p0 context.Context, p1 *types.Message, p2 uint64) (*types.InclusionEstimate, error) {
+	return s.Internal.MpoolEstimateInclusion(p0, p1, p2)
+}
 func (s *IMessagePoolStruct) MpoolGetConfig(p0 context.Context) (*types.MpoolConfig, error) {
 	return s.Internal.MpoolGetConfig(p0)
 }
@@ -557,6 +716,9 @@ func (s *IMessagePoolStruct) MpoolGetNonce(p0 context.Context, p1 address.Addres
 func (s *IMessagePoolStruct) MpoolPending(p0 context.Context, p1 types.TipSetKey) ([]*types.SignedMessage, error) {
 	return s.Internal.MpoolPending(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolPreviewMessage(p0 context.Context, p1 *types.Message) (*types.InvocResult, error) {
+	return s.Internal.MpoolPreviewMessage(p0, p1)
+}
 func (s *IMessagePoolStruct) MpoolPublishByAddr(p0 context.Context, p1 address.Address) error {
 	return s.Internal.MpoolPublishByAddr(p0, p1)
 }
@@ -569,6 +731,9 @@ func (s *IMessagePoolStruct) MpoolPush(p0 context.Context, p1 *types.SignedMessa
 func (s *IMessagePoolStruct) MpoolPushMessage(p0 context.Context, p1 *types.Message, p2 *types.MessageSendSpec) (*types.SignedMessage, error) {
 	return s.Internal.MpoolPushMessage(p0, p1, p2)
 }
+func (s *IMessagePoolStruct) MpoolPushMessageWithID(p0 context.Context, p1 types.UUID, p2 *types.Message, p3 *types.MessageSendSpec) (*types.SignedMessage, error) {
+	return s.Internal.MpoolPushMessageWithID(p0, p1, p2, p3)
+}
 func (s *IMessagePoolStruct) MpoolPushUntrusted(p0 context.Context, p1 *types.SignedMessage) (cid.Cid, error) {
 	return s.Internal.MpoolPushUntrusted(p0, p1)
 }
@@ -584,6 +749,9 @@ func (s *IMessagePoolStruct) MpoolSetConfig(p0 context.Context, p1 *types.MpoolC
 func (s *IMessagePoolStruct) MpoolSub(p0 context.Context) (<-chan types.MpoolUpdate, error) {
 	return s.Internal.MpoolSub(p0)
 }
+func (s *IMessagePoolStruct) MpoolSubFiltered(p0 context.Context, p1 *types.MpoolSubFilter) (<-chan types.MpoolUpdate, error) {
+	return s.Internal.MpoolSubFiltered(p0, p1)
+}
 
 type INetworkStruct struct {
 	Internal struct {
@@ -607,6 +775,7 @@ type INetworkStruct struct {
 		NetProtectList              func(ctx context.Context) ([]peer.ID, error)                           `perm:"read"`
 		NetProtectRemove            func(ctx context.Context, acl []peer.ID) error                         `perm:"admin"`
 		NetPubsubScores             func(context.Context) ([]types.PubsubScore, error)                     `perm:"read"`
+		NetPubsubTopics             func(context.Context) ([]types.PubsubTopic, error)                     `perm:"read"`
 	}
 }
 
@@ -668,6 +837,9 @@ func (s *INetworkStruct) NetProtectRemove(p0 context.Context, p1 []peer.ID) erro
 func (s *INetworkStruct) NetPubsubScores(p0 context.Context) ([]types.PubsubScore, error) {
 	return s.Internal.NetPubsubScores(p0)
 }
+func (s *INetworkStruct) NetPubsubTopics(p0 context.Context) ([]types.PubsubTopic, error) {
+	return s.Internal.NetPubsubTopics(p0)
+}
 
 type IPaychanStruct struct {
 	Internal struct {
@@ -745,14 +917,22 @@ func (s *IPaychanStruct) PaychVoucherSubmit(p0 context.Context, p1 address.Addre
 
 type ISyncerStruct struct {
 	Internal struct {
-		ChainSyncHandleNewTipSet func(ctx context.Context, ci *types.ChainInfo) error            `perm:"write"`
-		ChainTipSetWeight        func(ctx context.Context, tsk types.TipSetKey) (big.Int, error) `perm:"read"`
-		Concurrent               func(ctx context.Context) int64                                 `perm:"read"`
-		SetConcurrent            func(ctx context.Context, concurrent int64) error               `perm:"admin"`
-		SyncIncomingBlocks       func(ctx context.Context) (<-chan *types.BlockHeader, error)    `perm:"read"`
-		SyncState                func(ctx context.Context) (*types.SyncState, error)             `perm:"read"`
-		SyncSubmitBlock          func(ctx context.Context, blk *types.BlockMsg) error            `perm:"write"`
-		SyncerTracker            func(ctx context.Context) *types.TargetTracker                  `perm:"read"`
+		ChainSyncHandleNewTipSet func(ctx context.Context, ci *types.ChainInfo) error                             `perm:"write"`
+		ChainTipSetWeight        func(ctx context.Context, tsk types.TipSetKey) (big.Int, error)                  `perm:"read"`
+		Concurrent               func(ctx context.Context) int64                                                  `perm:"read"`
+		SetConcurrent            func(ctx context.Context, concurrent int64) error                                `perm:"admin"`
+		SyncCheckBad             func(ctx context.Context, tsk types.TipSetKey) (*types.BadTipSet, error)         `perm:"read"`
+		SyncConsensusFaults      func(ctx context.Context) ([]*types.ConsensusFault, error)                       `perm:"read"`
+		SyncForkAlerts           func(ctx context.Context) ([]*types.ForkAlert, error)                            `perm:"read"`
+		SyncIncomingBlocks       func(ctx context.Context) (<-chan *types.BlockHeader, error)                     `perm:"read"`
+		SyncListBad              func(ctx context.Context) ([]*types.BadTipSet, error)                            `perm:"read"`
+		SyncMarkBad              func(ctx context.Context, tsk types.TipSetKey, reason string) error              `perm:"admin"`
+		SyncState                func(ctx context.Context) (*types.SyncState, error)                              `perm:"read"`
+		SyncSubmitBlock          func(ctx context.Context, blk *types.BlockMsg) error                             `perm:"write"`
+		SyncSubmitBlockChecked   func(ctx context.Context, blk *types.BlockMsg) (*types.SubmitBlockResult, error) `perm:"write"`
+		SyncUnmarkAllBad         func(ctx context.Context) error                                                  `perm:"admin"`
+		SyncUnmarkBad            func(ctx context.Context, tsk types.TipSetKey) error                             `perm:"admin"`
+		SyncerTracker            func(ctx context.Context) *types.TargetTracker                                   `perm:"read"`
 	}
 }
 
@@ -766,15 +946,39 @@ func (s *ISyncerStruct) Concurrent(p0 context.Context) int64 { return s.Internal
 func (s *ISyncerStruct) SetConcurrent(p0 context.Context, p1 int64) error {
 	return s.Internal.SetConcurrent(p0, p1)
 }
+func (s *ISyncerStruct) SyncCheckBad(p0 context.Context, p1 types.TipSetKey) (*types.BadTipSet, error) {
+	return s.Internal.SyncCheckBad(p0, p1)
+}
+func (s *ISyncerStruct) SyncConsensusFaults(p0 context.Context) ([]*types.ConsensusFault, error) {
+	return s.Internal.SyncConsensusFaults(p0)
+}
+func (s *ISyncerStruct) SyncForkAlerts(p0 context.Context) ([]*types.ForkAlert, error) {
+	return s.Internal.SyncForkAlerts(p0)
+}
 func (s *ISyncerStruct) SyncIncomingBlocks(p0 context.Context) (<-chan *types.BlockHeader, error) {
 	return s.Internal.SyncIncomingBlocks(p0)
 }
+func (s *ISyncerStruct) SyncListBad(p0 context.Context) ([]*types.BadTipSet, error) {
+	return s.Internal.SyncListBad(p0)
+}
+func (s *ISyncerStruct) SyncMarkBad(p0 context.Context, p1 types.TipSetKey, p2 string) error {
+	return s.Internal.SyncMarkBad(p0, p1, p2)
+}
 func (s *ISyncerStruct) SyncState(p0 context.Context) (*types.SyncState, error) {
 	return s.Internal.SyncState(p0)
 }
 func (s *ISyncerStruct) SyncSubmitBlock(p0 context.Context, p1 *types.BlockMsg) error {
 	return s.Internal.SyncSubmitBlock(p0, p1)
 }
+func (s *ISyncerStruct) SyncSubmitBlockChecked(p0 context.Context, p1 *types.BlockMsg) (*types.SubmitBlockResult, error) {
+	return s.Internal.SyncSubmitBlockChecked(p0, p1)
+}
+func (s *ISyncerStruct) SyncUnmarkAllBad(p0 context.Context) error {
+	return s.Internal.SyncUnmarkAllBad(p0)
+}
+func (s *ISyncerStruct) SyncUnmarkBad(p0 context.Context, p1 types.TipSetKey) error {
+	return s.Internal.SyncUnmarkBad(p0, p1)
+}
 func (s *ISyncerStruct) SyncerTracker(p0 context.Context) *types.TargetTracker {
 	return s.Internal.SyncerTracker(p0)
 }
@@ -785,6 +989,7 @@ type IWalletStruct struct {
 		LockWallet           func(ctx context.Context) error                                                                         `perm:"admin"`
 		SetPassword          func(ctx context.Context, password []byte) error                                                        `perm:"admin"`
 		UnLockWallet         func(ctx context.Context, password []byte) error                                                        `perm:"admin"`
+		WalletAddSignRule    func(ctx context.Context, signer address.Address, expr string) (*wallet.SignRule, error)                `perm:"admin"`
 		WalletAddresses      func(ctx context.Context) []address.Address                                                             `perm:"admin"`
 		WalletBalance        func(ctx context.Context, addr address.Address) (abi.TokenAmount, error)                                `perm:"read"`
 		WalletDefaultAddress func(ctx context.Context) (address.Address, error)                                                      `perm:"write"`
@@ -793,10 +998,14 @@ type IWalletStruct struct {
 		WalletHas            func(ctx context.Context, addr address.Address) (bool, error)                                           `perm:"write"`
 		WalletImport         func(ctx context.Context, key *types.KeyInfo) (address.Address, error)                                  `perm:"admin"`
 		WalletNewAddress     func(ctx context.Context, protocol address.Protocol) (address.Address, error)                           `perm:"write"`
+		WalletRemoveSignRule func(ctx context.Context, id string) error                                                              `perm:"admin"`
 		WalletSetDefault     func(ctx context.Context, addr address.Address) error                                                   `perm:"write"`
 		WalletSign           func(ctx context.Context, k address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) `perm:"sign"`
+		WalletSignDenials    func(ctx context.Context, limit int) ([]wallet.SignDenial, error)                                       `perm:"admin"`
 		WalletSignMessage    func(ctx context.Context, k address.Address, msg *types.Message) (*types.SignedMessage, error)          `perm:"sign"`
+		WalletSignRules      func(ctx context.Context) ([]*wallet.SignRule, error)                                                   `perm:"admin"`
 		WalletState          func(ctx context.Context) int                                                                           `perm:"admin"`
+		WalletTxHistory      func(ctx context.Context, addr address.Address, from, to abi.ChainEpoch) ([]*wallet.Tx, error)          `perm:"write"`
 	}
 }
 
@@ -808,6 +1017,9 @@ func (s *IWalletStruct) SetPassword(p0 context.Context, p1 []byte) error {
 func (s *IWalletStruct) UnLockWallet(p0 context.Context, p1 []byte) error {
 	return s.Internal.UnLockWallet(p0, p1)
 }
+func (s *IWalletStruct) WalletAddSignRule(p0 context.Context, p1 address.Address, p2 string) (*wallet.SignRule, error) {
+	return s.Internal.WalletAddSignRule(p0, p1, p2)
+}
 func (s *IWalletStruct) WalletAddresses(p0 context.Context) []address.Address {
 	return s.Internal.WalletAddresses(p0)
 }
@@ -832,16 +1044,28 @@ func (s *IWalletStruct) WalletImport(p0 context.Context, p1 *types.KeyInfo) (add
 func (s *IWalletStruct) WalletNewAddress(p0 context.Context, p1 address.Protocol) (address.Address, error) {
 	return s.Internal.WalletNewAddress(p0, p1)
 }
+func (s *IWalletStruct) WalletRemoveSignRule(p0 context.Context, p1 string) error {
+	return s.Internal.WalletRemoveSignRule(p0, p1)
+}
 func (s *IWalletStruct) WalletSetDefault(p0 context.Context, p1 address.Address) error {
 	return s.Internal.WalletSetDefault(p0, p1)
 }
 func (s *IWalletStruct) WalletSign(p0 context.Context, p1 address.Address, p2 []byte, p3 types.MsgMeta) (*crypto.Signature, error) {
 	return s.Internal.WalletSign(p0, p1, p2, p3)
 }
+func (s *IWalletStruct) WalletSignDenials(p0 context.Context, p1 int) ([]wallet.SignDenial, error) {
+	return s.Internal.WalletSignDenials(p0, p1)
+}
 func (s *IWalletStruct) WalletSignMessage(p0 context.Context, p1 address.Address, p2 *types.Message) (*types.SignedMessage, error) {
 	return s.Internal.WalletSignMessage(p0, p1, p2)
 }
+func (s *IWalletStruct) WalletSignRules(p0 context.Context) ([]*wallet.SignRule, error) {
+	return s.Internal.WalletSignRules(p0)
+}
 func (s *IWalletStruct) WalletState(p0 context.Context) int { return s.Internal.WalletState(p0) }
+func (s *IWalletStruct) WalletTxHistory(p0 context.Context, p1 address.Address, p2, p3 abi.ChainEpoch) ([]*wallet.Tx, error) {
+	return s.Internal.WalletTxHistory(p0, p1, p2, p3)
+}
 
 type ICommonStruct struct {
 	Internal struct {
@@ -868,6 +1092,8 @@ type IETHStruct struct {
 		EthBlockNumber                         func(ctx context.Context) (types.EthUint64, error)                                                                                        `perm:"read"`
 		EthCall                                func(ctx context.Context, tx types.EthCall, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error)                                  `perm:"read"`
 		EthChainId                             func(ctx context.Context) (types.EthUint64, error)                                                                                        `perm:"read"`
+		EthDebugTraceBlockByNumber             func(ctx context.Context, p jsonrpc.RawParams) ([]*types.EthTxTraceResult, error)                                                         `perm:"read"`
+		EthDebugTraceTransaction               func(ctx context.Context, p jsonrpc.RawParams) (*types.EthCallFrame, error)                                                               `perm:"read"`
 		EthEstimateGas                         func(ctx context.Context, p jsonrpc.RawParams) (types.EthUint64, error)                                                                   `perm:"read"`
 		EthFeeHistory                          func(ctx context.Context, p jsonrpc.RawParams) (types.EthFeeHistory, error)                                                               `perm:"read"`
 		EthGasPrice                            func(ctx context.Context) (types.EthBigInt, error)                                                                                        `perm:"read"`
@@ -893,6 +1119,7 @@ type IETHStruct struct {
 		EthSyncing                             func(ctx context.Context) (types.EthSyncingResult, error)                                                                                 `perm:"read"`
 		EthTraceBlock                          func(ctx context.Context, blkNum string) ([]*types.EthTraceBlock, error)                                                                  `perm:"read"`
 		EthTraceReplayBlockTransactions        func(ctx context.Context, blkNum string, traceTypes []string) ([]*types.EthTraceReplayBlockTransaction, error)                            `perm:"read"`
+		EthTraceTransaction                    func(ctx context.Context, txHash string) ([]*types.EthTraceTransaction, error)                                                            `perm:"read"`
 		FilecoinAddressToEthAddress            func(ctx context.Context, filecoinAddress address.Address) (types.EthAddress, error)                                                      `perm:"read"`
 		NetListening                           func(ctx context.Context) (bool, error)                                                                                                   `perm:"read"`
 		NetVersion                             func(ctx context.Context) (string, error)                                                                                                 `perm:"read"`
@@ -915,6 +1142,12 @@ func (s *IETHStruct) EthCall(p0 context.Context, p1 types.EthCall, p2 types.EthB
 func (s *IETHStruct) EthChainId(p0 context.Context) (types.EthUint64, error) {
 	return s.Internal.EthChainId(p0)
 }
+func (s *IETHStruct) EthDebugTraceBlockByNumber(p0 context.Context, p1 jsonrpc.RawParams) ([]*types.EthTxTraceResult, error) {
+	return s.Internal.EthDebugTraceBlockByNumber(p0, p1)
+}
+func (s *IETHStruct) EthDebugTraceTransaction(p0 context.Context, p1 jsonrpc.RawParams) (*types.EthCallFrame, error) {
+	return s.Internal.EthDebugTraceTransaction(p0, p1)
+}
 func (s *IETHStruct) EthEstimateGas(p0 context.Context, p1 jsonrpc.RawParams) (types.EthUint64, error) {
 	return s.Internal.EthEstimateGas(p0, p1)
 }
@@ -990,6 +1223,9 @@ func (s *IETHStruct) EthTraceBlock(p0 context.Context, p1 string) ([]*types.EthT
 func (s *IETHStruct) EthTraceReplayBlockTransactions(p0 context.Context, p1 string, p2 []string) ([]*types.EthTraceReplayBlockTransaction, error) {
 	return s.Internal.EthTraceReplayBlockTransactions(p0, p1, p2)
 }
+func (s *IETHStruct) EthTraceTransaction(p0 context.Context, p1 string) ([]*types.EthTraceTransaction, error) {
+	return s.Internal.EthTraceTransaction(p0, p1)
+}
 func (s *IETHStruct) FilecoinAddressToEthAddress(p0 context.Context, p1 address.Address) (types.EthAddress, error) {
 	return s.Internal.FilecoinAddressToEthAddress(p0, p1)
 }
@@ -1063,6 +1299,7 @@ func (s *IActorEventStruct) SubscribeActorEventsRaw(p0 context.Context, p1 *type
 }
 
 type FullNodeStruct struct {
+	IAuthStruct
 	IBlockStoreStruct
 	IChainStruct
 	IMarketStruct
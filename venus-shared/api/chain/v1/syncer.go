@@ -15,8 +15,28 @@ type ISyncer interface {
 	Concurrent(ctx context.Context) int64                                        //perm:read
 	ChainTipSetWeight(ctx context.Context, tsk types.TipSetKey) (big.Int, error) //perm:read
 	SyncSubmitBlock(ctx context.Context, blk *types.BlockMsg) error              //perm:write
-	SyncState(ctx context.Context) (*types.SyncState, error)                     //perm:read
+	// SyncSubmitBlockChecked validates the block and submits it unless the miner has another block at the epoch among
+	// the blocks seen by the node or the slash filter finds it would be slashed, the result telling why it is refused.
+	SyncSubmitBlockChecked(ctx context.Context, blk *types.BlockMsg) (*types.SubmitBlockResult, error) //perm:write
+	SyncState(ctx context.Context) (*types.SyncState, error)                                           //perm:read
 	// SyncIncomingBlocks returns a channel streaming incoming, potentially not
 	// yet synced block headers.
 	SyncIncomingBlocks(ctx context.Context) (<-chan *types.BlockHeader, error) //perm:read
+	// SyncConsensusFaults returns the latest consensus faults found by the consensus fault reporter, the oldest
+	// first. It fails when the reporter is not enabled.
+	SyncConsensusFaults(ctx context.Context) ([]*types.ConsensusFault, error) //perm:read
+	// SyncForkAlerts returns the latest heads received competing with the local chain from a fork at least as deep
+	// as the alarm depth, the oldest first. It fails when the fork alarm is not enabled.
+	SyncForkAlerts(ctx context.Context) ([]*types.ForkAlert, error) //perm:read
+	// SyncMarkBad marks the tipset as bad so that the syncer does not sync to it nor to its descendants, until it is
+	// unmarked. The reason is recorded along.
+	SyncMarkBad(ctx context.Context, tsk types.TipSetKey, reason string) error //perm:admin
+	// SyncUnmarkBad unmarks the tipset, whether it was marked by an operator or rejected by the syncer.
+	SyncUnmarkBad(ctx context.Context, tsk types.TipSetKey) error //perm:admin
+	// SyncUnmarkAllBad unmarks all the bad tipsets.
+	SyncUnmarkAllBad(ctx context.Context) error //perm:admin
+	// SyncCheckBad returns why the tipset is bad, nil when it is not.
+	SyncCheckBad(ctx context.Context, tsk types.TipSetKey) (*types.BadTipSet, error) //perm:read
+	// SyncListBad returns the bad tipsets which did not expire, the latest marked first.
+	SyncListBad(ctx context.Context) ([]*types.BadTipSet, error) //perm:read
 }
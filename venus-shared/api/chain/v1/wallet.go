@@ -8,6 +8,7 @@ import (
 	"github.com/filecoin-project/go-state-types/crypto"
 
 	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/wallet"
 )
 
 type IWallet interface {
@@ -27,4 +28,16 @@ type IWallet interface {
 	SetPassword(ctx context.Context, password []byte) error                                                       //perm:admin
 	HasPassword(ctx context.Context) bool                                                                         //perm:admin
 	WalletState(ctx context.Context) int                                                                          //perm:admin
+
+	// WalletSignRules returns the rules the messages signed by the wallet are checked against
+	WalletSignRules(ctx context.Context) ([]*wallet.SignRule, error) //perm:admin
+	// WalletAddSignRule parses and adds a rule restricting the messages signed by signer, every signer when undefined
+	WalletAddSignRule(ctx context.Context, signer address.Address, expr string) (*wallet.SignRule, error) //perm:admin
+	WalletRemoveSignRule(ctx context.Context, id string) error                                            //perm:admin
+	// WalletSignDenials returns the last sign requests denied by the rules, the most recent first
+	WalletSignDenials(ctx context.Context, limit int) ([]wallet.SignDenial, error) //perm:admin
+	// WalletTxHistory returns the messages sent by and to the wallet address addr included from the epoch from to the
+	// epoch to, the head when to is 0, with the balance of addr once they are executed. It is only recorded when
+	// walletModule.txHistory is enabled.
+	WalletTxHistory(ctx context.Context, addr address.Address, from, to abi.ChainEpoch) ([]*wallet.Tx, error) //perm:write
 }
@@ -0,0 +1,279 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	logging "github.com/ipfs/go-log/v2"
+)
+
+var failoverLog = logging.Logger("api-failover")
+
+// ErrNoUpstreamNode is returned by a failover proxy which has no node to route the call to
+var ErrNoUpstreamNode = errors.New("no upstream node available")
+
+// HealthChecker reports an error if the node is not able to serve requests
+type HealthChecker[T any] func(ctx context.Context, node T) error
+
+// Failover routes calls to one of several equivalent upstream nodes. Calls stick to the node which served the
+// last successful call, so that chain dependent calls observe a consistent chain, and fall over to the next
+// healthy node when the connection to the current one fails.
+type Failover[T any] struct {
+	nodes   []T
+	check   HealthChecker[T]
+	healthy []atomic.Bool
+	current atomic.Int32
+	// next is the node the next balanced call starts from
+	next atomic.Uint32
+
+	startOnce sync.Once
+}
+
+// NewFailover creates a failover over nodes, every node is considered healthy until checked otherwise
+func NewFailover[T any](nodes []T, check HealthChecker[T]) (*Failover[T], error) {
+	if len(nodes) == 0 {
+		return nil, ErrNoUpstreamNode
+	}
+
+	f := &Failover[T]{
+		nodes:   nodes,
+		check:   check,
+		healthy: make([]atomic.Bool, len(nodes)),
+	}
+	for i := range f.healthy {
+		f.healthy[i].Store(true)
+	}
+
+	return f, nil
+}
+
+// Start checks the health of every node once per interval until ctx is done
+func (f *Failover[T]) Start(ctx context.Context, interval time.Duration) {
+	if f.check == nil {
+		return
+	}
+
+	f.startOnce.Do(func() {
+		go func() {
+			tick := time.NewTicker(interval)
+			defer tick.Stop()
+
+			for {
+				f.CheckHealth(ctx, interval)
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-tick.C:
+				}
+			}
+		}()
+	})
+}
+
+// CheckHealth runs the health checker against every node, each check is bounded by timeout
+func (f *Failover[T]) CheckHealth(ctx context.Context, timeout time.Duration) {
+	if f.check == nil {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := range f.nodes {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+
+			cctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			err := f.check(cctx, f.nodes[idx])
+			if ctx.Err() != nil {
+				return
+			}
+			f.setHealthy(idx, err)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// Healthy returns the health of every node, in the order the nodes were given
+func (f *Failover[T]) Healthy() []bool {
+	out := make([]bool, len(f.healthy))
+	for i := range f.healthy {
+		out[i] = f.healthy[i].Load()
+	}
+	return out
+}
+
+func (f *Failover[T]) setHealthy(idx int, err error) {
+	healthy := err == nil
+	if f.healthy[idx].Swap(healthy) != healthy {
+		if healthy {
+			failoverLog.Infof("upstream node %d is back to healthy", idx)
+		} else {
+			failoverLog.Warnf("upstream node %d is unhealthy: %v", idx, err)
+		}
+	}
+}
+
+// pick returns the first untried node starting from start, healthy nodes are preferred, the unhealthy
+// ones are only tried as a last resort since they may have recovered since the last check
+func (f *Failover[T]) pick(start int, tried []bool) (int, bool) {
+	for _, wantHealthy := range []bool{true, false} {
+		for i := 0; i < len(f.nodes); i++ {
+			idx := (start + i) % len(f.nodes)
+			if !tried[idx] && f.healthy[idx].Load() == wantHealthy {
+				return idx, true
+			}
+		}
+	}
+	return -1, false
+}
+
+// Do calls fn with the upstream nodes until one of them is reachable, errors returned by a reachable
+// node are not retried
+func (f *Failover[T]) Do(ctx context.Context, fn func(node T) error) error {
+	return f.do(ctx, false, func(idx int) error {
+		return fn(f.nodes[idx])
+	})
+}
+
+// do calls fn from the current node, or from the next node in turn when balanced, until a node is reachable.
+// Balanced calls do not move the current node.
+func (f *Failover[T]) do(ctx context.Context, balanced bool, fn func(idx int) error) error {
+	start := int(f.current.Load())
+	if balanced {
+		start = int(f.next.Add(1)-1) % len(f.nodes)
+	}
+
+	tried := make([]bool, len(f.nodes))
+	lastErr := ErrNoUpstreamNode
+	for {
+		idx, ok := f.pick(start, tried)
+		if !ok {
+			return lastErr
+		}
+		tried[idx] = true
+
+		err := fn(idx)
+		if err == nil || !IsConnectionError(err) {
+			if !balanced {
+				f.current.Store(int32(idx))
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+
+		f.setHealthy(idx, err)
+		lastErr = err
+	}
+}
+
+// Proxy fills the `Internal` structs of the proxy struct pointed by out, so that every method is served by
+// the failover, the nodes must implement all the methods of the proxy
+func (f *Failover[T]) Proxy(out interface{}) error {
+	return f.proxy(out, false)
+}
+
+// LoadBalance fills the proxy struct pointed by out like Proxy, but spreads the read calls over the healthy nodes
+// in turn. The subscriptions, which return a channel, and the calls changing the state of a node, such as pushing
+// messages, still stick to the current node so that they are served by the same node.
+func (f *Failover[T]) LoadBalance(out interface{}) error {
+	return f.proxy(out, true)
+}
+
+func (f *Failover[T]) proxy(out interface{}, balance bool) error {
+	rNodes := make([]reflect.Value, 0, len(f.nodes))
+	for _, node := range f.nodes {
+		rNodes = append(rNodes, reflect.ValueOf(node))
+	}
+
+	for _, internal := range GetInternalStructs(out) {
+		rInternal := reflect.ValueOf(internal).Elem()
+		for i := 0; i < rInternal.NumField(); i++ {
+			field := rInternal.Type().Field(i)
+			if field.Type.Kind() != reflect.Func {
+				continue
+			}
+
+			methods := make([]reflect.Value, 0, len(rNodes))
+			for idx, rNode := range rNodes {
+				method := rNode.MethodByName(field.Name)
+				if !method.IsValid() {
+					return fmt.Errorf("upstream node %d does not implement %s", idx, field.Name)
+				}
+				methods = append(methods, method)
+			}
+
+			balanced := balance && field.Tag.Get("perm") == "read" && !returnsChan(field.Type)
+			rInternal.Field(i).Set(f.makeFunc(field.Type, methods, balanced))
+		}
+	}
+
+	return nil
+}
+
+func (f *Failover[T]) makeFunc(typ reflect.Type, methods []reflect.Value, balanced bool) reflect.Value {
+	hasCtx := typ.NumIn() > 0 && typ.In(0) == contextType
+	hasErr := typ.NumOut() > 0 && typ.Out(typ.NumOut()-1) == errorType
+
+	return reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+		if !hasErr {
+			// nothing to decide a failover on
+			return methods[f.current.Load()].Call(args)
+		}
+
+		ctx := context.Background()
+		if hasCtx {
+			ctx = args[0].Interface().(context.Context)
+		}
+
+		var out []reflect.Value
+		err := f.do(ctx, balanced, func(idx int) error {
+			out = methods[idx].Call(args)
+			if rErr := out[len(out)-1]; !rErr.IsNil() {
+				return rErr.Interface().(error)
+			}
+			return nil
+		})
+		if out == nil {
+			// no node has been called, fill the results with zero values
+			out = make([]reflect.Value, typ.NumOut())
+			for i := range out {
+				out[i] = reflect.Zero(typ.Out(i))
+			}
+			out[len(out)-1] = reflect.ValueOf(&err).Elem()
+		}
+		return out
+	})
+}
+
+func returnsChan(typ reflect.Type) bool {
+	for i := 0; i < typ.NumOut(); i++ {
+		if typ.Out(i).Kind() == reflect.Chan {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// IsConnectionError reports whether err is caused by failing to reach the node rather than by the node
+// handling the request
+func IsConnectionError(err error) bool {
+	var connErr *jsonrpc.RPCConnectionError
+	var clientErr *jsonrpc.ErrClient
+	return errors.As(err, &connErr) || errors.As(err, &clientErr)
+}
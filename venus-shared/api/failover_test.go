@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+type testNode struct {
+	name  string
+	down  bool
+	calls int
+}
+
+func (n *testNode) Name(ctx context.Context) (string, error) {
+	n.calls++
+	if n.down {
+		return "", &jsonrpc.RPCConnectionError{}
+	}
+	return n.name, nil
+}
+
+func (n *testNode) Fail(ctx context.Context) error {
+	n.calls++
+	return errors.New("handled by " + n.name)
+}
+
+type testNodeStruct struct {
+	Internal struct {
+		Name func(ctx context.Context) (string, error)
+		Fail func(ctx context.Context) error
+	}
+}
+
+func TestFailoverProxy(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	n1, n2 := &testNode{name: "n1"}, &testNode{name: "n2"}
+	f, err := NewFailover([]*testNode{n1, n2}, func(ctx context.Context, n *testNode) error {
+		_, err := n.Name(ctx)
+		return err
+	})
+	require.NoError(t, err)
+
+	var proxy testNodeStruct
+	require.NoError(t, f.Proxy(&proxy))
+
+	name, err := proxy.Internal.Name(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "n1", name)
+
+	// errors returned by a reachable node are not retried on other nodes
+	require.EqualError(t, proxy.Internal.Fail(ctx), "handled by n1")
+	require.Equal(t, 0, n2.calls)
+
+	// fall over to n2 when n1 is unreachable, and stick to it afterwards
+	n1.down = true
+	name, err = proxy.Internal.Name(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "n2", name)
+	require.Equal(t, []bool{false, true}, f.Healthy())
+
+	n1.down = false
+	name, err = proxy.Internal.Name(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "n2", name)
+
+	// health checks bring n1 back
+	f.CheckHealth(ctx, time.Second)
+	require.Equal(t, []bool{true, true}, f.Healthy())
+
+	// every node down, the connection error is returned
+	n1.down, n2.down = true, true
+	_, err = proxy.Internal.Name(ctx)
+	require.True(t, IsConnectionError(err))
+
+	_, err = NewFailover[*testNode](nil, nil)
+	require.ErrorIs(t, err, ErrNoUpstreamNode)
+}
+
+func (n *testNode) Sub(ctx context.Context) (<-chan string, error) {
+	n.calls++
+	if n.down {
+		return nil, &jsonrpc.RPCConnectionError{}
+	}
+	ch := make(chan string, 1)
+	ch <- n.name
+	return ch, nil
+}
+
+type testBalancedStruct struct {
+	Internal struct {
+		Name func(ctx context.Context) (string, error)        `perm:"read"`
+		Sub  func(ctx context.Context) (<-chan string, error) `perm:"read"`
+		Fail func(ctx context.Context) error                  `perm:"write"`
+	}
+}
+
+func TestFailoverLoadBalance(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	n1, n2, n3 := &testNode{name: "n1"}, &testNode{name: "n2"}, &testNode{name: "n3"}
+	f, err := NewFailover([]*testNode{n1, n2, n3}, nil)
+	require.NoError(t, err)
+
+	var proxy testBalancedStruct
+	require.NoError(t, f.LoadBalance(&proxy))
+
+	// the read calls go to the nodes in turn
+	var names []string
+	for i := 0; i < 4; i++ {
+		name, err := proxy.Internal.Name(ctx)
+		require.NoError(t, err)
+		names = append(names, name)
+	}
+	require.Equal(t, []string{"n1", "n2", "n3", "n1"}, names)
+
+	// the subscriptions and the writes stick to the current node
+	for i := 0; i < 2; i++ {
+		ch, err := proxy.Internal.Sub(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "n1", <-ch)
+		require.EqualError(t, proxy.Internal.Fail(ctx), "handled by n1")
+	}
+
+	// an unreachable node is skipped by the balanced calls, and the sticky calls fall over
+	n2.down = true
+	names = names[:0]
+	for i := 0; i < 3; i++ {
+		name, err := proxy.Internal.Name(ctx)
+		require.NoError(t, err)
+		names = append(names, name)
+	}
+	require.Equal(t, []string{"n3", "n3", "n1"}, names)
+	require.Equal(t, []bool{true, false, true}, f.Healthy())
+
+	n1.down = true
+	ch, err := proxy.Internal.Sub(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "n3", <-ch)
+	require.EqualError(t, proxy.Internal.Fail(ctx), "handled by n3")
+}
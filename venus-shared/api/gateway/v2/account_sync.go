@@ -0,0 +1,19 @@
+package gateway
+
+import (
+	"context"
+
+	gtypes "github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+type IAccountSync interface {
+	// ForceSyncAccounts synchronizes the account bindings with the auth service now, instead of waiting for the
+	// next period, and returns the changes applied to the routing
+	ForceSyncAccounts(ctx context.Context) (*gtypes.AccountSyncResult, error) //perm:admin
+	// AccountSyncState returns the state of the synchronization of the account bindings
+	AccountSyncState(ctx context.Context) (*gtypes.AccountSyncState, error) //perm:admin
+	// ListBoundAccounts returns the account bindings the requests are routed by
+	ListBoundAccounts(ctx context.Context) ([]*gtypes.AccountBinding, error) //perm:admin
+	// ListenAccountChanges sends the changes of the account bindings found by the synchronizations
+	ListenAccountChanges(ctx context.Context) (<-chan *gtypes.AccountChange, error) //perm:admin
+}
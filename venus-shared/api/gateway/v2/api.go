@@ -8,7 +8,12 @@ type IGateway interface {
 	IProofEvent
 	IWalletEvent
 	IMarketEvent
+	IRetrievalEvent
 	IProxy
+	IRegistry
+	IMaintenance
+	IAccountSync
+	ITenantSLO
 
 	api.Version
 }
@@ -0,0 +1,19 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+
+	gtypes "github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+type IMaintenance interface {
+	// SetMaintenanceWindow declares a maintenance window of the miner, replacing its previous one. The proof and
+	// market requests of the miner received during the window are queued and forwarded once it ends.
+	SetMaintenanceWindow(ctx context.Context, window *gtypes.MaintenanceWindow) error //perm:admin
+	// RemoveMaintenanceWindow ends the maintenance window of the miner now, forwarding its queued requests
+	RemoveMaintenanceWindow(ctx context.Context, miner address.Address) error //perm:admin
+	// ListMaintenanceWindows returns the maintenance windows which have not ended yet
+	ListMaintenanceWindows(ctx context.Context) ([]*gtypes.MaintenanceState, error) //perm:admin
+}
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	address "github.com/filecoin-project/go-address"
+	retrievalmarket "github.com/filecoin-project/go-fil-markets/retrievalmarket"
 	abi "github.com/filecoin-project/go-state-types/abi"
 	crypto "github.com/filecoin-project/go-state-types/crypto"
 	network "github.com/filecoin-project/go-state-types/network"
@@ -17,6 +18,7 @@ import (
 	gateway "github.com/filecoin-project/venus/venus-shared/types/gateway"
 	gomock "github.com/golang/mock/gomock"
 	cid "github.com/ipfs/go-cid"
+	peer "github.com/libp2p/go-libp2p/core/peer"
 )
 
 // MockIGateway is a mock of IGateway interface.
@@ -42,6 +44,21 @@ func (m *MockIGateway) EXPECT() *MockIGatewayMockRecorder {
 	return m.recorder
 }
 
+// AccountSyncState mocks base method.
+func (m *MockIGateway) AccountSyncState(arg0 context.Context) (*gateway.AccountSyncState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountSyncState", arg0)
+	ret0, _ := ret[0].(*gateway.AccountSyncState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountSyncState indicates an expected call of AccountSyncState.
+func (mr *MockIGatewayMockRecorder) AccountSyncState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountSyncState", reflect.TypeOf((*MockIGateway)(nil).AccountSyncState), arg0)
+}
+
 // AddNewAddress mocks base method.
 func (m *MockIGateway) AddNewAddress(arg0 context.Context, arg1 types.UUID, arg2 []address.Address) error {
 	m.ctrl.T.Helper()
@@ -71,6 +88,66 @@ func (mr *MockIGatewayMockRecorder) ComputeProof(arg0, arg1, arg2, arg3, arg4, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputeProof", reflect.TypeOf((*MockIGateway)(nil).ComputeProof), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// ForceSyncAccounts mocks base method.
+func (m *MockIGateway) ForceSyncAccounts(arg0 context.Context) (*gateway.AccountSyncResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceSyncAccounts", arg0)
+	ret0, _ := ret[0].(*gateway.AccountSyncResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceSyncAccounts indicates an expected call of ForceSyncAccounts.
+func (mr *MockIGatewayMockRecorder) ForceSyncAccounts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceSyncAccounts", reflect.TypeOf((*MockIGateway)(nil).ForceSyncAccounts), arg0)
+}
+
+// GetAddressProofPolicy mocks base method.
+func (m *MockIGateway) GetAddressProofPolicy(arg0 context.Context, arg1 string) (*gateway.AddressProofPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddressProofPolicy", arg0, arg1)
+	ret0, _ := ret[0].(*gateway.AddressProofPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddressProofPolicy indicates an expected call of GetAddressProofPolicy.
+func (mr *MockIGatewayMockRecorder) GetAddressProofPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressProofPolicy", reflect.TypeOf((*MockIGateway)(nil).GetAddressProofPolicy), arg0, arg1)
+}
+
+// GetWalletSignPolicy mocks base method.
+func (m *MockIGateway) GetWalletSignPolicy(arg0 context.Context, arg1 string) (*gateway.WalletSignPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletSignPolicy", arg0, arg1)
+	ret0, _ := ret[0].(*gateway.WalletSignPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletSignPolicy indicates an expected call of GetWalletSignPolicy.
+func (mr *MockIGatewayMockRecorder) GetWalletSignPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletSignPolicy", reflect.TypeOf((*MockIGateway)(nil).GetWalletSignPolicy), arg0, arg1)
+}
+
+// ListBoundAccounts mocks base method.
+func (m *MockIGateway) ListBoundAccounts(arg0 context.Context) ([]*gateway.AccountBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBoundAccounts", arg0)
+	ret0, _ := ret[0].([]*gateway.AccountBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBoundAccounts indicates an expected call of ListBoundAccounts.
+func (mr *MockIGatewayMockRecorder) ListBoundAccounts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBoundAccounts", reflect.TypeOf((*MockIGateway)(nil).ListBoundAccounts), arg0)
+}
+
 // ListConnectedMiners mocks base method.
 func (m *MockIGateway) ListConnectedMiners(arg0 context.Context) ([]address.Address, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +163,36 @@ func (mr *MockIGatewayMockRecorder) ListConnectedMiners(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConnectedMiners", reflect.TypeOf((*MockIGateway)(nil).ListConnectedMiners), arg0)
 }
 
+// ListGatewayInstances mocks base method.
+func (m *MockIGateway) ListGatewayInstances(arg0 context.Context) ([]*gateway.GatewayInstance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGatewayInstances", arg0)
+	ret0, _ := ret[0].([]*gateway.GatewayInstance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGatewayInstances indicates an expected call of ListGatewayInstances.
+func (mr *MockIGatewayMockRecorder) ListGatewayInstances(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGatewayInstances", reflect.TypeOf((*MockIGateway)(nil).ListGatewayInstances), arg0)
+}
+
+// ListMaintenanceWindows mocks base method.
+func (m *MockIGateway) ListMaintenanceWindows(arg0 context.Context) ([]*gateway.MaintenanceState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMaintenanceWindows", arg0)
+	ret0, _ := ret[0].([]*gateway.MaintenanceState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMaintenanceWindows indicates an expected call of ListMaintenanceWindows.
+func (mr *MockIGatewayMockRecorder) ListMaintenanceWindows(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMaintenanceWindows", reflect.TypeOf((*MockIGateway)(nil).ListMaintenanceWindows), arg0)
+}
+
 // ListMarketConnectionsState mocks base method.
 func (m *MockIGateway) ListMarketConnectionsState(arg0 context.Context) ([]gateway.MarketConnectionState, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +223,66 @@ func (mr *MockIGatewayMockRecorder) ListMinerConnection(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMinerConnection", reflect.TypeOf((*MockIGateway)(nil).ListMinerConnection), arg0, arg1)
 }
 
+// ListRetrievalConnectionsState mocks base method.
+func (m *MockIGateway) ListRetrievalConnectionsState(arg0 context.Context) ([]gateway.RetrievalConnectionState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRetrievalConnectionsState", arg0)
+	ret0, _ := ret[0].([]gateway.RetrievalConnectionState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRetrievalConnectionsState indicates an expected call of ListRetrievalConnectionsState.
+func (mr *MockIGatewayMockRecorder) ListRetrievalConnectionsState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRetrievalConnectionsState", reflect.TypeOf((*MockIGateway)(nil).ListRetrievalConnectionsState), arg0)
+}
+
+// ListShadowSignReports mocks base method.
+func (m *MockIGateway) ListShadowSignReports(arg0 context.Context) ([]*gateway.ShadowSignReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShadowSignReports", arg0)
+	ret0, _ := ret[0].([]*gateway.ShadowSignReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShadowSignReports indicates an expected call of ListShadowSignReports.
+func (mr *MockIGatewayMockRecorder) ListShadowSignReports(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShadowSignReports", reflect.TypeOf((*MockIGateway)(nil).ListShadowSignReports), arg0)
+}
+
+// ListTenantSLO mocks base method.
+func (m *MockIGateway) ListTenantSLO(arg0 context.Context) ([]*gateway.TenantSLO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTenantSLO", arg0)
+	ret0, _ := ret[0].([]*gateway.TenantSLO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTenantSLO indicates an expected call of ListTenantSLO.
+func (mr *MockIGatewayMockRecorder) ListTenantSLO(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTenantSLO", reflect.TypeOf((*MockIGateway)(nil).ListTenantSLO), arg0)
+}
+
+// ListThresholdSignPolicies mocks base method.
+func (m *MockIGateway) ListThresholdSignPolicies(arg0 context.Context) ([]*gateway.ThresholdSignPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListThresholdSignPolicies", arg0)
+	ret0, _ := ret[0].([]*gateway.ThresholdSignPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListThresholdSignPolicies indicates an expected call of ListThresholdSignPolicies.
+func (mr *MockIGatewayMockRecorder) ListThresholdSignPolicies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListThresholdSignPolicies", reflect.TypeOf((*MockIGateway)(nil).ListThresholdSignPolicies), arg0)
+}
+
 // ListWalletInfo mocks base method.
 func (m *MockIGateway) ListWalletInfo(arg0 context.Context) ([]*gateway.WalletDetail, error) {
 	m.ctrl.T.Helper()
@@ -146,6 +313,21 @@ func (mr *MockIGatewayMockRecorder) ListWalletInfoByWallet(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWalletInfoByWallet", reflect.TypeOf((*MockIGateway)(nil).ListWalletInfoByWallet), arg0, arg1)
 }
 
+// ListenAccountChanges mocks base method.
+func (m *MockIGateway) ListenAccountChanges(arg0 context.Context) (<-chan *gateway.AccountChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenAccountChanges", arg0)
+	ret0, _ := ret[0].(<-chan *gateway.AccountChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListenAccountChanges indicates an expected call of ListenAccountChanges.
+func (mr *MockIGatewayMockRecorder) ListenAccountChanges(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenAccountChanges", reflect.TypeOf((*MockIGateway)(nil).ListenAccountChanges), arg0)
+}
+
 // ListenMarketEvent mocks base method.
 func (m *MockIGateway) ListenMarketEvent(arg0 context.Context, arg1 *gateway.MarketRegisterPolicy) (<-chan *gateway.RequestEvent, error) {
 	m.ctrl.T.Helper()
@@ -176,6 +358,21 @@ func (mr *MockIGatewayMockRecorder) ListenProofEvent(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenProofEvent", reflect.TypeOf((*MockIGateway)(nil).ListenProofEvent), arg0, arg1)
 }
 
+// ListenRetrievalEvent mocks base method.
+func (m *MockIGateway) ListenRetrievalEvent(arg0 context.Context, arg1 *gateway.RetrievalRegisterPolicy) (<-chan *gateway.RequestEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenRetrievalEvent", arg0, arg1)
+	ret0, _ := ret[0].(<-chan *gateway.RequestEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListenRetrievalEvent indicates an expected call of ListenRetrievalEvent.
+func (mr *MockIGatewayMockRecorder) ListenRetrievalEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenRetrievalEvent", reflect.TypeOf((*MockIGateway)(nil).ListenRetrievalEvent), arg0, arg1)
+}
+
 // ListenWalletEvent mocks base method.
 func (m *MockIGateway) ListenWalletEvent(arg0 context.Context, arg1 *gateway.WalletRegisterPolicy) (<-chan *gateway.RequestEvent, error) {
 	m.ctrl.T.Helper()
@@ -191,6 +388,51 @@ func (mr *MockIGatewayMockRecorder) ListenWalletEvent(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenWalletEvent", reflect.TypeOf((*MockIGateway)(nil).ListenWalletEvent), arg0, arg1)
 }
 
+// LookupClientRoutes mocks base method.
+func (m *MockIGateway) LookupClientRoutes(arg0 context.Context, arg1, arg2 string) ([]*gateway.ClientRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupClientRoutes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*gateway.ClientRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupClientRoutes indicates an expected call of LookupClientRoutes.
+func (mr *MockIGatewayMockRecorder) LookupClientRoutes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupClientRoutes", reflect.TypeOf((*MockIGateway)(nil).LookupClientRoutes), arg0, arg1, arg2)
+}
+
+// ProofQueueState mocks base method.
+func (m *MockIGateway) ProofQueueState(arg0 context.Context, arg1 address.Address) ([]*gateway.ProofQueueState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProofQueueState", arg0, arg1)
+	ret0, _ := ret[0].([]*gateway.ProofQueueState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProofQueueState indicates an expected call of ProofQueueState.
+func (mr *MockIGatewayMockRecorder) ProofQueueState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProofQueueState", reflect.TypeOf((*MockIGateway)(nil).ProofQueueState), arg0, arg1)
+}
+
+// ProofVerifyState mocks base method.
+func (m *MockIGateway) ProofVerifyState(arg0 context.Context, arg1 address.Address) ([]*gateway.ProofVerifyState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProofVerifyState", arg0, arg1)
+	ret0, _ := ret[0].([]*gateway.ProofVerifyState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProofVerifyState indicates an expected call of ProofVerifyState.
+func (mr *MockIGatewayMockRecorder) ProofVerifyState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProofVerifyState", reflect.TypeOf((*MockIGateway)(nil).ProofVerifyState), arg0, arg1)
+}
+
 // RegisterReverse mocks base method.
 func (m *MockIGateway) RegisterReverse(arg0 context.Context, arg1 gateway.HostKey, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -219,6 +461,48 @@ func (mr *MockIGatewayMockRecorder) RemoveAddress(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAddress", reflect.TypeOf((*MockIGateway)(nil).RemoveAddress), arg0, arg1, arg2)
 }
 
+// RemoveMaintenanceWindow mocks base method.
+func (m *MockIGateway) RemoveMaintenanceWindow(arg0 context.Context, arg1 address.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMaintenanceWindow", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMaintenanceWindow indicates an expected call of RemoveMaintenanceWindow.
+func (mr *MockIGatewayMockRecorder) RemoveMaintenanceWindow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMaintenanceWindow", reflect.TypeOf((*MockIGateway)(nil).RemoveMaintenanceWindow), arg0, arg1)
+}
+
+// RemoveThresholdSignPolicy mocks base method.
+func (m *MockIGateway) RemoveThresholdSignPolicy(arg0 context.Context, arg1 address.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveThresholdSignPolicy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveThresholdSignPolicy indicates an expected call of RemoveThresholdSignPolicy.
+func (mr *MockIGatewayMockRecorder) RemoveThresholdSignPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveThresholdSignPolicy", reflect.TypeOf((*MockIGateway)(nil).RemoveThresholdSignPolicy), arg0, arg1)
+}
+
+// ResetShadowSignReports mocks base method.
+func (m *MockIGateway) ResetShadowSignReports(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetShadowSignReports", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetShadowSignReports indicates an expected call of ResetShadowSignReports.
+func (mr *MockIGatewayMockRecorder) ResetShadowSignReports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetShadowSignReports", reflect.TypeOf((*MockIGateway)(nil).ResetShadowSignReports), arg0, arg1)
+}
+
 // ResponseMarketEvent mocks base method.
 func (m *MockIGateway) ResponseMarketEvent(arg0 context.Context, arg1 *gateway.ResponseEvent) error {
 	m.ctrl.T.Helper()
@@ -247,6 +531,20 @@ func (mr *MockIGatewayMockRecorder) ResponseProofEvent(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResponseProofEvent", reflect.TypeOf((*MockIGateway)(nil).ResponseProofEvent), arg0, arg1)
 }
 
+// ResponseRetrievalEvent mocks base method.
+func (m *MockIGateway) ResponseRetrievalEvent(arg0 context.Context, arg1 *gateway.ResponseEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResponseRetrievalEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResponseRetrievalEvent indicates an expected call of ResponseRetrievalEvent.
+func (mr *MockIGatewayMockRecorder) ResponseRetrievalEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResponseRetrievalEvent", reflect.TypeOf((*MockIGateway)(nil).ResponseRetrievalEvent), arg0, arg1)
+}
+
 // ResponseWalletEvent mocks base method.
 func (m *MockIGateway) ResponseWalletEvent(arg0 context.Context, arg1 *gateway.ResponseEvent) error {
 	m.ctrl.T.Helper()
@@ -261,6 +559,36 @@ func (mr *MockIGatewayMockRecorder) ResponseWalletEvent(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResponseWalletEvent", reflect.TypeOf((*MockIGateway)(nil).ResponseWalletEvent), arg0, arg1)
 }
 
+// RetrievalDealProposal mocks base method.
+func (m *MockIGateway) RetrievalDealProposal(arg0 context.Context, arg1 address.Address, arg2 peer.ID, arg3 *retrievalmarket.DealProposal) (*gateway.RetrievalDealResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrievalDealProposal", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*gateway.RetrievalDealResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrievalDealProposal indicates an expected call of RetrievalDealProposal.
+func (mr *MockIGatewayMockRecorder) RetrievalDealProposal(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrievalDealProposal", reflect.TypeOf((*MockIGateway)(nil).RetrievalDealProposal), arg0, arg1, arg2, arg3)
+}
+
+// RetrievalQuery mocks base method.
+func (m *MockIGateway) RetrievalQuery(arg0 context.Context, arg1 address.Address, arg2 retrievalmarket.Query) (*retrievalmarket.QueryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrievalQuery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*retrievalmarket.QueryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrievalQuery indicates an expected call of RetrievalQuery.
+func (mr *MockIGatewayMockRecorder) RetrievalQuery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrievalQuery", reflect.TypeOf((*MockIGateway)(nil).RetrievalQuery), arg0, arg1, arg2)
+}
+
 // SectorsUnsealPiece mocks base method.
 func (m *MockIGateway) SectorsUnsealPiece(arg0 context.Context, arg1 address.Address, arg2 cid.Cid, arg3 abi.SectorNumber, arg4 types.UnpaddedByteIndex, arg5 abi.UnpaddedPieceSize, arg6 string) (gateway.UnsealState, error) {
 	m.ctrl.T.Helper()
@@ -276,6 +604,90 @@ func (mr *MockIGatewayMockRecorder) SectorsUnsealPiece(arg0, arg1, arg2, arg3, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SectorsUnsealPiece", reflect.TypeOf((*MockIGateway)(nil).SectorsUnsealPiece), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// SetAddressProofPolicy mocks base method.
+func (m *MockIGateway) SetAddressProofPolicy(arg0 context.Context, arg1 string, arg2 *gateway.AddressProofPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAddressProofPolicy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAddressProofPolicy indicates an expected call of SetAddressProofPolicy.
+func (mr *MockIGatewayMockRecorder) SetAddressProofPolicy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAddressProofPolicy", reflect.TypeOf((*MockIGateway)(nil).SetAddressProofPolicy), arg0, arg1, arg2)
+}
+
+// SetMaintenanceWindow mocks base method.
+func (m *MockIGateway) SetMaintenanceWindow(arg0 context.Context, arg1 *gateway.MaintenanceWindow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaintenanceWindow", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMaintenanceWindow indicates an expected call of SetMaintenanceWindow.
+func (mr *MockIGatewayMockRecorder) SetMaintenanceWindow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaintenanceWindow", reflect.TypeOf((*MockIGateway)(nil).SetMaintenanceWindow), arg0, arg1)
+}
+
+// SetProofConcurrency mocks base method.
+func (m *MockIGateway) SetProofConcurrency(arg0 context.Context, arg1 address.Address, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProofConcurrency", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProofConcurrency indicates an expected call of SetProofConcurrency.
+func (mr *MockIGatewayMockRecorder) SetProofConcurrency(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProofConcurrency", reflect.TypeOf((*MockIGateway)(nil).SetProofConcurrency), arg0, arg1, arg2)
+}
+
+// SetProofVerification mocks base method.
+func (m *MockIGateway) SetProofVerification(arg0 context.Context, arg1 address.Address, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProofVerification", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProofVerification indicates an expected call of SetProofVerification.
+func (mr *MockIGatewayMockRecorder) SetProofVerification(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProofVerification", reflect.TypeOf((*MockIGateway)(nil).SetProofVerification), arg0, arg1, arg2)
+}
+
+// SetThresholdSignPolicy mocks base method.
+func (m *MockIGateway) SetThresholdSignPolicy(arg0 context.Context, arg1 *gateway.ThresholdSignPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetThresholdSignPolicy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetThresholdSignPolicy indicates an expected call of SetThresholdSignPolicy.
+func (mr *MockIGatewayMockRecorder) SetThresholdSignPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetThresholdSignPolicy", reflect.TypeOf((*MockIGateway)(nil).SetThresholdSignPolicy), arg0, arg1)
+}
+
+// SetWalletSignPolicy mocks base method.
+func (m *MockIGateway) SetWalletSignPolicy(arg0 context.Context, arg1 string, arg2 *gateway.WalletSignPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWalletSignPolicy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWalletSignPolicy indicates an expected call of SetWalletSignPolicy.
+func (mr *MockIGatewayMockRecorder) SetWalletSignPolicy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWalletSignPolicy", reflect.TypeOf((*MockIGateway)(nil).SetWalletSignPolicy), arg0, arg1, arg2)
+}
+
 // SupportNewAccount mocks base method.
 func (m *MockIGateway) SupportNewAccount(arg0 context.Context, arg1 types.UUID, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -320,6 +732,21 @@ func (mr *MockIGatewayMockRecorder) WalletHas(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletHas", reflect.TypeOf((*MockIGateway)(nil).WalletHas), arg0, arg1, arg2)
 }
 
+// WalletHasMany mocks base method.
+func (m *MockIGateway) WalletHasMany(arg0 context.Context, arg1 []address.Address, arg2 []string) ([]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletHasMany", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletHasMany indicates an expected call of WalletHasMany.
+func (mr *MockIGatewayMockRecorder) WalletHasMany(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletHasMany", reflect.TypeOf((*MockIGateway)(nil).WalletHasMany), arg0, arg1, arg2)
+}
+
 // WalletSign mocks base method.
 func (m *MockIGateway) WalletSign(arg0 context.Context, arg1 address.Address, arg2 []string, arg3 []byte, arg4 types.MsgMeta) (*crypto.Signature, error) {
 	m.ctrl.T.Helper()
@@ -334,3 +761,18 @@ func (mr *MockIGatewayMockRecorder) WalletSign(arg0, arg1, arg2, arg3, arg4 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletSign", reflect.TypeOf((*MockIGateway)(nil).WalletSign), arg0, arg1, arg2, arg3, arg4)
 }
+
+// WalletSignBatch mocks base method.
+func (m *MockIGateway) WalletSignBatch(arg0 context.Context, arg1 []string, arg2 []*gateway.WalletSignRequest) ([]*gateway.WalletSignResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletSignBatch", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*gateway.WalletSignResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletSignBatch indicates an expected call of WalletSignBatch.
+func (mr *MockIGatewayMockRecorder) WalletSignBatch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletSignBatch", reflect.TypeOf((*MockIGateway)(nil).WalletSignBatch), arg0, arg1, arg2)
+}
@@ -17,8 +17,18 @@ type IProofEvent interface {
 }
 
 type IProofClient interface {
-	ListConnectedMiners(ctx context.Context) ([]address.Address, error)                                                                                                                                        //perm:admin
-	ListMinerConnection(ctx context.Context, addr address.Address) (*gtypes.MinerState, error)                                                                                                                 //perm:admin
+	ListConnectedMiners(ctx context.Context) ([]address.Address, error)                        //perm:admin
+	ListMinerConnection(ctx context.Context, addr address.Address) (*gtypes.MinerState, error) //perm:admin
+	// ProofQueueState returns the ComputeProof requests forwarded and queued for the miner, or for every miner when it is undef
+	ProofQueueState(ctx context.Context, miner address.Address) ([]*gtypes.ProofQueueState, error) //perm:admin
+	// SetProofConcurrency sets the number of ComputeProof requests of the miner forwarded at once, 0 is unlimited and
+	// a negative limit restores the configured default
+	SetProofConcurrency(ctx context.Context, miner address.Address, limit int) error //perm:admin
+	// SetProofVerification sets whether the proofs computed for the miner are verified before being returned, the
+	// provers registered with VerifyProofs are verified either way
+	SetProofVerification(ctx context.Context, miner address.Address, enable bool) error //perm:admin
+	// ProofVerifyState returns the counts of the verified proofs of the miner, or of every miner when it is undef
+	ProofVerifyState(ctx context.Context, miner address.Address) ([]*gtypes.ProofVerifyState, error)                                                                                                           //perm:admin
 	ComputeProof(ctx context.Context, miner address.Address, sectorInfos []builtin.ExtendedSectorInfo, rand abi.PoStRandomness, height abi.ChainEpoch, nwVersion network.Version) ([]builtin.PoStProof, error) //perm:admin
 }
 
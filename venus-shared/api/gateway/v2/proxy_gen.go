@@ -5,10 +5,12 @@ import (
 	"context"
 
 	address "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-fil-markets/retrievalmarket"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/network"
 	cid "github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
 	"github.com/filecoin-project/venus/venus-shared/types"
@@ -18,9 +20,13 @@ import (
 
 type IProofClientStruct struct {
 	Internal struct {
-		ComputeProof        func(ctx context.Context, miner address.Address, sectorInfos []builtin.ExtendedSectorInfo, rand abi.PoStRandomness, height abi.ChainEpoch, nwVersion network.Version) ([]builtin.PoStProof, error) `perm:"admin"`
-		ListConnectedMiners func(ctx context.Context) ([]address.Address, error)                                                                                                                                               `perm:"admin"`
-		ListMinerConnection func(ctx context.Context, addr address.Address) (*gtypes.MinerState, error)                                                                                                                        `perm:"admin"`
+		ComputeProof         func(ctx context.Context, miner address.Address, sectorInfos []builtin.ExtendedSectorInfo, rand abi.PoStRandomness, height abi.ChainEpoch, nwVersion network.Version) ([]builtin.PoStProof, error) `perm:"admin"`
+		ListConnectedMiners  func(ctx context.Context) ([]address.Address, error)                                                                                                                                               `perm:"admin"`
+		ListMinerConnection  func(ctx context.Context, addr address.Address) (*gtypes.MinerState, error)                                                                                                                        `perm:"admin"`
+		ProofQueueState      func(ctx context.Context, miner address.Address) ([]*gtypes.ProofQueueState, error)                                                                                                                `perm:"admin"`
+		ProofVerifyState     func(ctx context.Context, miner address.Address) ([]*gtypes.ProofVerifyState, error)                                                                                                               `perm:"admin"`
+		SetProofConcurrency  func(ctx context.Context, miner address.Address, limit int) error                                                                                                                                  `perm:"admin"`
+		SetProofVerification func(ctx context.Context, miner address.Address, enable bool) error                                                                                                                                `perm:"admin"`
 	}
 }
 
@@ -33,6 +39,18 @@ func (s *IProofClientStruct) ListConnectedMiners(p0 context.Context) ([]address.
 func (s *IProofClientStruct) ListMinerConnection(p0 context.Context, p1 address.Address) (*gtypes.MinerState, error) {
 	return s.Internal.ListMinerConnection(p0, p1)
 }
+func (s *IProofClientStruct) ProofQueueState(p0 context.Context, p1 address.Address) ([]*gtypes.ProofQueueState, error) {
+	return s.Internal.ProofQueueState(p0, p1)
+}
+func (s *IProofClientStruct) ProofVerifyState(p0 context.Context, p1 address.Address) ([]*gtypes.ProofVerifyState, error) {
+	return s.Internal.ProofVerifyState(p0, p1)
+}
+func (s *IProofClientStruct) SetProofConcurrency(p0 context.Context, p1 address.Address, p2 int) error {
+	return s.Internal.SetProofConcurrency(p0, p1, p2)
+}
+func (s *IProofClientStruct) SetProofVerification(p0 context.Context, p1 address.Address, p2 bool) error {
+	return s.Internal.SetProofVerification(p0, p1, p2)
+}
 
 type IProofServiceProviderStruct struct {
 	Internal struct {
@@ -55,25 +73,69 @@ type IProofEventStruct struct {
 
 type IWalletClientStruct struct {
 	Internal struct {
-		ListWalletInfo         func(ctx context.Context) ([]*gtypes.WalletDetail, error)                                                                        `perm:"admin"`
-		ListWalletInfoByWallet func(ctx context.Context, wallet string) (*gtypes.WalletDetail, error)                                                           `perm:"admin"`
-		WalletHas              func(ctx context.Context, addr address.Address, accounts []string) (bool, error)                                                 `perm:"admin"`
-		WalletSign             func(ctx context.Context, addr address.Address, accounts []string, toSign []byte, meta types.MsgMeta) (*crypto.Signature, error) `perm:"admin"`
+		GetAddressProofPolicy     func(ctx context.Context, account string) (*gtypes.AddressProofPolicy, error)                                                    `perm:"admin"`
+		GetWalletSignPolicy       func(ctx context.Context, account string) (*gtypes.WalletSignPolicy, error)                                                      `perm:"admin"`
+		ListShadowSignReports     func(ctx context.Context) ([]*gtypes.ShadowSignReport, error)                                                                    `perm:"admin"`
+		ListThresholdSignPolicies func(ctx context.Context) ([]*gtypes.ThresholdSignPolicy, error)                                                                 `perm:"admin"`
+		ListWalletInfo            func(ctx context.Context) ([]*gtypes.WalletDetail, error)                                                                        `perm:"admin"`
+		ListWalletInfoByWallet    func(ctx context.Context, wallet string) (*gtypes.WalletDetail, error)                                                           `perm:"admin"`
+		RemoveThresholdSignPolicy func(ctx context.Context, signer address.Address) error                                                                          `perm:"admin"`
+		ResetShadowSignReports    func(ctx context.Context, account string) error                                                                                  `perm:"admin"`
+		SetAddressProofPolicy     func(ctx context.Context, account string, policy *gtypes.AddressProofPolicy) error                                               `perm:"admin"`
+		SetThresholdSignPolicy    func(ctx context.Context, policy *gtypes.ThresholdSignPolicy) error                                                              `perm:"admin"`
+		SetWalletSignPolicy       func(ctx context.Context, account string, policy *gtypes.WalletSignPolicy) error                                                 `perm:"admin"`
+		WalletHas                 func(ctx context.Context, addr address.Address, accounts []string) (bool, error)                                                 `perm:"admin"`
+		WalletHasMany             func(ctx context.Context, addrs []address.Address, accounts []string) ([]bool, error)                                            `perm:"admin"`
+		WalletSign                func(ctx context.Context, addr address.Address, accounts []string, toSign []byte, meta types.MsgMeta) (*crypto.Signature, error) `perm:"admin"`
+		WalletSignBatch           func(ctx context.Context, accounts []string, reqs []*gtypes.WalletSignRequest) ([]*gtypes.WalletSignResult, error)               `perm:"admin"`
 	}
 }
 
+func (s *IWalletClientStruct) GetAddressProofPolicy(p0 context.Context, p1 string) (*gtypes.AddressProofPolicy, error) {
+	return s.Internal.GetAddressProofPolicy(p0, p1)
+}
+func (s *IWalletClientStruct) GetWalletSignPolicy(p0 context.Context, p1 string) (*gtypes.WalletSignPolicy, error) {
+	return s.Internal.GetWalletSignPolicy(p0, p1)
+}
+func (s *IWalletClientStruct) ListShadowSignReports(p0 context.Context) ([]*gtypes.ShadowSignReport, error) {
+	return s.Internal.ListShadowSignReports(p0)
+}
+func (s *IWalletClientStruct) ListThresholdSignPolicies(p0 context.Context) ([]*gtypes.ThresholdSignPolicy, error) {
+	return s.Internal.ListThresholdSignPolicies(p0)
+}
 func (s *IWalletClientStruct) ListWalletInfo(p0 context.Context) ([]*gtypes.WalletDetail, error) {
 	return s.Internal.ListWalletInfo(p0)
 }
 func (s *IWalletClientStruct) ListWalletInfoByWallet(p0 context.Context, p1 string) (*gtypes.WalletDetail, error) {
 	return s.Internal.ListWalletInfoByWallet(p0, p1)
 }
+func (s *IWalletClientStruct) RemoveThresholdSignPolicy(p0 context.Context, p1 address.Address) error {
+	return s.Internal.RemoveThresholdSignPolicy(p0, p1)
+}
+func (s *IWalletClientStruct) ResetShadowSignReports(p0 context.Context, p1 string) error {
+	return s.Internal.ResetShadowSignReports(p0, p1)
+}
+func (s *IWalletClientStruct) SetAddressProofPolicy(p0 context.Context, p1 string, p2 *gtypes.AddressProofPolicy) error {
+	return s.Internal.SetAddressProofPolicy(p0, p1, p2)
+}
+func (s *IWalletClientStruct) SetThresholdSignPolicy(p0 context.Context, p1 *gtypes.ThresholdSignPolicy) error {
+	return s.Internal.SetThresholdSignPolicy(p0, p1)
+}
+func (s *IWalletClientStruct) SetWalletSignPolicy(p0 context.Context, p1 string, p2 *gtypes.WalletSignPolicy) error {
+	return s.Internal.SetWalletSignPolicy(p0, p1, p2)
+}
 func (s *IWalletClientStruct) WalletHas(p0 context.Context, p1 address.Address, p2 []string) (bool, error) {
 	return s.Internal.WalletHas(p0, p1, p2)
 }
+func (s *IWalletClientStruct) WalletHasMany(p0 context.Context, p1 []address.Address, p2 []string) ([]bool, error) {
+	return s.Internal.WalletHasMany(p0, p1, p2)
+}
 func (s *IWalletClientStruct) WalletSign(p0 context.Context, p1 address.Address, p2 []string, p3 []byte, p4 types.MsgMeta) (*crypto.Signature, error) {
 	return s.Internal.WalletSign(p0, p1, p2, p3, p4)
 }
+func (s *IWalletClientStruct) WalletSignBatch(p0 context.Context, p1 []string, p2 []*gtypes.WalletSignRequest) ([]*gtypes.WalletSignResult, error) {
+	return s.Internal.WalletSignBatch(p0, p1, p2)
+}
 
 type IWalletServiceProviderStruct struct {
 	Internal struct {
@@ -139,6 +201,43 @@ type IMarketEventStruct struct {
 	IMarketServiceProviderStruct
 }
 
+type IRetrievalClientStruct struct {
+	Internal struct {
+		ListRetrievalConnectionsState func(ctx context.Context) ([]gtypes.RetrievalConnectionState, error)                                                                            `perm:"admin"`
+		RetrievalDealProposal         func(ctx context.Context, miner address.Address, client peer.ID, proposal *retrievalmarket.DealProposal) (*gtypes.RetrievalDealResponse, error) `perm:"admin"`
+		RetrievalQuery                func(ctx context.Context, miner address.Address, query retrievalmarket.Query) (*retrievalmarket.QueryResponse, error)                           `perm:"admin"`
+	}
+}
+
+func (s *IRetrievalClientStruct) ListRetrievalConnectionsState(p0 context.Context) ([]gtypes.RetrievalConnectionState, error) {
+	return s.Internal.ListRetrievalConnectionsState(p0)
+}
+func (s *IRetrievalClientStruct) RetrievalDealProposal(p0 context.Context, p1 address.Address, p2 peer.ID, p3 *retrievalmarket.DealProposal) (*gtypes.RetrievalDealResponse, error) {
+	return s.Internal.RetrievalDealProposal(p0, p1, p2, p3)
+}
+func (s *IRetrievalClientStruct) RetrievalQuery(p0 context.Context, p1 address.Address, p2 retrievalmarket.Query) (*retrievalmarket.QueryResponse, error) {
+	return s.Internal.RetrievalQuery(p0, p1, p2)
+}
+
+type IRetrievalServiceProviderStruct struct {
+	Internal struct {
+		ListenRetrievalEvent   func(ctx context.Context, policy *gtypes.RetrievalRegisterPolicy) (<-chan *gtypes.RequestEvent, error) `perm:"read"`
+		ResponseRetrievalEvent func(ctx context.Context, resp *gtypes.ResponseEvent) error                                            `perm:"read"`
+	}
+}
+
+func (s *IRetrievalServiceProviderStruct) ListenRetrievalEvent(p0 context.Context, p1 *gtypes.RetrievalRegisterPolicy) (<-chan *gtypes.RequestEvent, error) {
+	return s.Internal.ListenRetrievalEvent(p0, p1)
+}
+func (s *IRetrievalServiceProviderStruct) ResponseRetrievalEvent(p0 context.Context, p1 *gtypes.ResponseEvent) error {
+	return s.Internal.ResponseRetrievalEvent(p0, p1)
+}
+
+type IRetrievalEventStruct struct {
+	IRetrievalClientStruct
+	IRetrievalServiceProviderStruct
+}
+
 type IProxyStruct struct {
 	Internal struct {
 		RegisterReverse func(ctx context.Context, hostKey gatewayTypes.HostKey, address string) error `perm:"admin"`
@@ -149,11 +248,80 @@ func (s *IProxyStruct) RegisterReverse(p0 context.Context, p1 gatewayTypes.HostK
 	return s.Internal.RegisterReverse(p0, p1, p2)
 }
 
+type IRegistryStruct struct {
+	Internal struct {
+		ListGatewayInstances func(ctx context.Context) ([]*gtypes.GatewayInstance, error)                    `perm:"admin"`
+		LookupClientRoutes   func(ctx context.Context, namespace, key string) ([]*gtypes.ClientRoute, error) `perm:"admin"`
+	}
+}
+
+func (s *IRegistryStruct) ListGatewayInstances(p0 context.Context) ([]*gtypes.GatewayInstance, error) {
+	return s.Internal.ListGatewayInstances(p0)
+}
+func (s *IRegistryStruct) LookupClientRoutes(p0 context.Context, p1, p2 string) ([]*gtypes.ClientRoute, error) {
+	return s.Internal.LookupClientRoutes(p0, p1, p2)
+}
+
+type IMaintenanceStruct struct {
+	Internal struct {
+		ListMaintenanceWindows  func(ctx context.Context) ([]*gtypes.MaintenanceState, error)     `perm:"admin"`
+		RemoveMaintenanceWindow func(ctx context.Context, miner address.Address) error            `perm:"admin"`
+		SetMaintenanceWindow    func(ctx context.Context, window *gtypes.MaintenanceWindow) error `perm:"admin"`
+	}
+}
+
+func (s *IMaintenanceStruct) ListMaintenanceWindows(p0 context.Context) ([]*gtypes.MaintenanceState, error) {
+	return s.Internal.ListMaintenanceWindows(p0)
+}
+func (s *IMaintenanceStruct) RemoveMaintenanceWindow(p0 context.Context, p1 address.Address) error {
+	return s.Internal.RemoveMaintenanceWindow(p0, p1)
+}
+func (s *IMaintenanceStruct) SetMaintenanceWindow(p0 context.Context, p1 *gtypes.MaintenanceWindow) error {
+	return s.Internal.SetMaintenanceWindow(p0, p1)
+}
+
+type IAccountSyncStruct struct {
+	Internal struct {
+		AccountSyncState     func(ctx context.Context) (*gtypes.AccountSyncState, error)     `perm:"admin"`
+		ForceSyncAccounts    func(ctx context.Context) (*gtypes.AccountSyncResult, error)    `perm:"admin"`
+		ListBoundAccounts    func(ctx context.Context) ([]*gtypes.AccountBinding, error)     `perm:"admin"`
+		ListenAccountChanges func(ctx context.Context) (<-chan *gtypes.AccountChange, error) `perm:"admin"`
+	}
+}
+
+func (s *IAccountSyncStruct) AccountSyncState(p0 context.Context) (*gtypes.AccountSyncState, error) {
+	return s.Internal.AccountSyncState(p0)
+}
+func (s *IAccountSyncStruct) ForceSyncAccounts(p0 context.Context) (*gtypes.AccountSyncResult, error) {
+	return s.Internal.ForceSyncAccounts(p0)
+}
+func (s *IAccountSyncStruct) ListBoundAccounts(p0 context.Context) ([]*gtypes.AccountBinding, error) {
+	return s.Internal.ListBoundAccounts(p0)
+}
+func (s *IAccountSyncStruct) ListenAccountChanges(p0 context.Context) (<-chan *gtypes.AccountChange, error) {
+	return s.Internal.ListenAccountChanges(p0)
+}
+
+type ITenantSLOStruct struct {
+	Internal struct {
+		ListTenantSLO func(ctx context.Context) ([]*gtypes.TenantSLO, error) `perm:"admin"`
+	}
+}
+
+func (s *ITenantSLOStruct) ListTenantSLO(p0 context.Context) ([]*gtypes.TenantSLO, error) {
+	return s.Internal.ListTenantSLO(p0)
+}
+
 type IGatewayStruct struct {
 	IProofEventStruct
 	IWalletEventStruct
 	IMarketEventStruct
+	IRetrievalEventStruct
 	IProxyStruct
+	IRegistryStruct
+	IMaintenanceStruct
+	IAccountSyncStruct
+	ITenantSLOStruct
 
 	Internal struct {
 		Version func(ctx context.Context) (types.Version, error) `perm:"read"`
@@ -0,0 +1,15 @@
+package gateway
+
+import (
+	"context"
+
+	gtypes "github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+type IRegistry interface {
+	// ListGatewayInstances returns the live instances sharing the registry of the gateway
+	ListGatewayInstances(ctx context.Context) ([]*gtypes.GatewayInstance, error) //perm:admin
+	// LookupClientRoutes returns the routes to the connections of the key in the namespace, the miner of a prover or
+	// the account of a wallet, across the instances
+	LookupClientRoutes(ctx context.Context, namespace, key string) ([]*gtypes.ClientRoute, error) //perm:admin
+}
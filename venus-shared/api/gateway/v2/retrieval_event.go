@@ -0,0 +1,29 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-fil-markets/retrievalmarket"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	gtypes "github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+type IRetrievalEvent interface {
+	IRetrievalClient
+	IRetrievalServiceProvider
+}
+
+type IRetrievalClient interface {
+	ListRetrievalConnectionsState(ctx context.Context) ([]gtypes.RetrievalConnectionState, error) //perm:admin
+	// RetrievalQuery forwards the query to the retrieval provider registered for the miner
+	RetrievalQuery(ctx context.Context, miner address.Address, query retrievalmarket.Query) (*retrievalmarket.QueryResponse, error) //perm:admin
+	// RetrievalDealProposal forwards the deal proposal of the client to the retrieval provider registered for the miner
+	RetrievalDealProposal(ctx context.Context, miner address.Address, client peer.ID, proposal *retrievalmarket.DealProposal) (*gtypes.RetrievalDealResponse, error) //perm:admin
+}
+
+type IRetrievalServiceProvider interface {
+	ResponseRetrievalEvent(ctx context.Context, resp *gtypes.ResponseEvent) error                                          //perm:read
+	ListenRetrievalEvent(ctx context.Context, policy *gtypes.RetrievalRegisterPolicy) (<-chan *gtypes.RequestEvent, error) //perm:read
+}
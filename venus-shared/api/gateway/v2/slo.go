@@ -0,0 +1,13 @@
+package gateway
+
+import (
+	"context"
+
+	gtypes "github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+type ITenantSLO interface {
+	// ListTenantSLO returns the success rate and the p99 latency of the sign, proof and market requests of each
+	// account over the sliding windows of the gateway config
+	ListTenantSLO(ctx context.Context) ([]*gtypes.TenantSLO, error) //perm:admin
+}
@@ -20,6 +20,29 @@ type IWalletClient interface {
 	ListWalletInfoByWallet(ctx context.Context, wallet string) (*gtypes.WalletDetail, error)                                               //perm:admin
 	WalletHas(ctx context.Context, addr address.Address, accounts []string) (bool, error)                                                  //perm:admin
 	WalletSign(ctx context.Context, addr address.Address, accounts []string, toSign []byte, meta types.MsgMeta) (*crypto.Signature, error) //perm:admin
+	// WalletHasMany tells for each address whether a wallet of the accounts has it
+	WalletHasMany(ctx context.Context, addrs []address.Address, accounts []string) ([]bool, error) //perm:admin
+	// WalletSignBatch signs the payloads with the wallets of the accounts, the results are in the order of the requests
+	WalletSignBatch(ctx context.Context, accounts []string, reqs []*gtypes.WalletSignRequest) ([]*gtypes.WalletSignResult, error) //perm:admin
+	// GetWalletSignPolicy returns the policy the WalletSign requests of the account are checked against
+	GetWalletSignPolicy(ctx context.Context, account string) (*gtypes.WalletSignPolicy, error) //perm:admin
+	// SetWalletSignPolicy replaces the sign policy of the account, a nil policy only checks the meta of the requests
+	SetWalletSignPolicy(ctx context.Context, account string, policy *gtypes.WalletSignPolicy) error //perm:admin
+	// ListThresholdSignPolicies returns the signers whose requests are fanned out to co-signers
+	ListThresholdSignPolicies(ctx context.Context) ([]*gtypes.ThresholdSignPolicy, error) //perm:admin
+	// SetThresholdSignPolicy makes the requests of policy.Signer need the partial signatures of policy.Threshold co-signers
+	SetThresholdSignPolicy(ctx context.Context, policy *gtypes.ThresholdSignPolicy) error //perm:admin
+	// RemoveThresholdSignPolicy forwards the requests of the signer to its wallets again
+	RemoveThresholdSignPolicy(ctx context.Context, signer address.Address) error //perm:admin
+	// ListShadowSignReports compares the responses of the wallets registered in shadow mode with the ones of the primary wallets
+	ListShadowSignReports(ctx context.Context) ([]*gtypes.ShadowSignReport, error) //perm:admin
+	// ResetShadowSignReports drops the reports of the account, or every report when account is empty
+	ResetShadowSignReports(ctx context.Context, account string) error //perm:admin
+	// GetAddressProofPolicy returns how the wallets of the account prove their addresses, the empty account is the
+	// default of the accounts without their own policy
+	GetAddressProofPolicy(ctx context.Context, account string) (*gtypes.AddressProofPolicy, error) //perm:admin
+	// SetAddressProofPolicy replaces the address proof policy of the account, a nil policy restores the default
+	SetAddressProofPolicy(ctx context.Context, account string, policy *gtypes.AddressProofPolicy) error //perm:admin
 }
 
 type IWalletServiceProvider interface {
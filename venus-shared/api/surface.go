@@ -0,0 +1,60 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ProxySurface lists the methods of the proxy struct along with their permission and signature, ordered by name.
+// It is what the clients of an api depend on, a version frozen keeps the same surface.
+func ProxySurface(proxy interface{}) []string {
+	var surface []string
+	for _, in := range GetInternalStructs(proxy) {
+		rt := reflect.TypeOf(in).Elem()
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			if field.Type.Kind() != reflect.Func {
+				continue
+			}
+			surface = append(surface, fmt.Sprintf("%s:\tperm=%s,\t%s", field.Name, field.Tag.Get("perm"), formatType(field.Type)))
+		}
+	}
+	sort.Strings(surface)
+	return surface
+}
+
+func formatType(rt reflect.Type) string {
+	if rt.Name() != "" {
+		if p := rt.PkgPath(); p != "" {
+			return p + "." + rt.Name()
+		}
+		return rt.Name()
+	}
+
+	switch rt.Kind() {
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", rt.Len(), formatType(rt.Elem()))
+	case reflect.Chan:
+		return fmt.Sprintf("%s %s", rt.ChanDir(), formatType(rt.Elem()))
+	case reflect.Func:
+		ins := make([]string, rt.NumIn())
+		for i := range ins {
+			ins[i] = formatType(rt.In(i))
+		}
+		outs := make([]string, rt.NumOut())
+		for i := range outs {
+			outs[i] = formatType(rt.Out(i))
+		}
+		return fmt.Sprintf("func(%s) (%s)", strings.Join(ins, ", "), strings.Join(outs, ", "))
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", formatType(rt.Key()), formatType(rt.Elem()))
+	case reflect.Ptr:
+		return fmt.Sprintf("*%s", formatType(rt.Elem()))
+	case reflect.Slice:
+		return fmt.Sprintf("[]%s", formatType(rt.Elem()))
+	default:
+		return rt.String()
+	}
+}
@@ -0,0 +1,42 @@
+package api_test
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/api"
+	v0 "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
+)
+
+var updateSurface = flag.Bool("update-surface", false, "regenerate the surface of the frozen v0 api")
+
+const v0SurfaceFile = "../compatible-checks/api-v0-surface.txt"
+
+// TestV0APIFrozen checks the v0 api against the surface generated by `make api-v0-surface`, the clients of the v0
+// api must keep working with the nodes which are upgraded
+func TestV0APIFrozen(t *testing.T) {
+	tf.UnitTest(t)
+
+	surface := api.ProxySurface(&v0.FullNodeStruct{})
+	if *updateSurface {
+		data := "v0api.FullNode:\n\t" + strings.Join(surface, "\n\t") + "\n"
+		require.NoError(t, os.WriteFile(v0SurfaceFile, []byte(data), 0644))
+	}
+
+	data, err := os.ReadFile(v0SurfaceFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Equal(t, "v0api.FullNode:", lines[0])
+
+	frozen := make([]string, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		frozen = append(frozen, strings.TrimPrefix(line, "\t"))
+	}
+	require.Equal(t, frozen, surface,
+		"the v0 api is frozen, add the method to v1 instead or run `make api-v0-surface` when the change is meant")
+}
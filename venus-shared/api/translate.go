@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// TranslateProxy fills the methods of the proxy struct `to` which are not implemented with those of the proxy struct
+// `from`, so that a version of an api is served by the implementation of another one. A method is taken from the
+// method of the same name, or of its new name in renames, when both require the same permission. It is used as is
+// when the signatures are identical, otherwise its arguments and results are converted through their json encoding,
+// which is what the clients of both versions exchange anyway. It returns the methods translated and those left
+// unimplemented.
+func TranslateProxy(from, to interface{}, renames map[string]string) (translated []string, missing []string) {
+	type method struct {
+		fn   reflect.Value
+		perm string
+	}
+	methods := map[string]method{}
+	for _, in := range GetInternalStructs(from) {
+		rv := reflect.ValueOf(in).Elem()
+		for i := 0; i < rv.NumField(); i++ {
+			fv := rv.Field(i)
+			if fv.Kind() != reflect.Func || fv.IsNil() {
+				continue
+			}
+			field := rv.Type().Field(i)
+			methods[field.Name] = method{fn: fv, perm: field.Tag.Get("perm")}
+		}
+	}
+
+	for _, out := range GetInternalStructs(to) {
+		rv := reflect.ValueOf(out).Elem()
+		for i := 0; i < rv.NumField(); i++ {
+			fv := rv.Field(i)
+			field := rv.Type().Field(i)
+			if fv.Kind() != reflect.Func || !fv.IsNil() {
+				continue
+			}
+
+			name := field.Name
+			if renamed, ok := renames[name]; ok {
+				name = renamed
+			}
+			m, ok := methods[name]
+			if !ok || m.perm != field.Tag.Get("perm") {
+				missing = append(missing, field.Name)
+				continue
+			}
+			fn, ok := adaptFunc(m.fn, field.Type)
+			if !ok {
+				missing = append(missing, field.Name)
+				continue
+			}
+			fv.Set(fn)
+			translated = append(translated, field.Name)
+		}
+	}
+
+	return translated, missing
+}
+
+// adaptFunc returns fn as a function of type typ, converting the arguments and the results which differ
+func adaptFunc(fn reflect.Value, typ reflect.Type) (reflect.Value, bool) {
+	ftyp := fn.Type()
+	if ftyp == typ {
+		return fn, true
+	}
+	if ftyp.NumIn() != typ.NumIn() || ftyp.NumOut() != typ.NumOut() || typ.NumOut() == 0 ||
+		typ.Out(typ.NumOut()-1) != errorType || ftyp.Out(ftyp.NumOut()-1) != errorType {
+		return reflect.Value{}, false
+	}
+	for i := 0; i < typ.NumIn(); i++ {
+		if !translatable(typ.In(i), ftyp.In(i)) {
+			return reflect.Value{}, false
+		}
+	}
+	for i := 0; i < typ.NumOut(); i++ {
+		if !translatable(ftyp.Out(i), typ.Out(i)) {
+			return reflect.Value{}, false
+		}
+	}
+
+	fail := func(err error) []reflect.Value {
+		results := make([]reflect.Value, typ.NumOut())
+		for i := range results[:len(results)-1] {
+			results[i] = reflect.Zero(typ.Out(i))
+		}
+		rerr := reflect.New(errorType).Elem()
+		rerr.Set(reflect.ValueOf(err))
+		results[len(results)-1] = rerr
+		return results
+	}
+
+	return reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+		in := make([]reflect.Value, len(args))
+		for i, arg := range args {
+			v, err := convertValue(arg, ftyp.In(i))
+			if err != nil {
+				return fail(fmt.Errorf("translate argument %d: %w", i, err))
+			}
+			in[i] = v
+		}
+
+		out := fn.Call(in)
+		if rerr := out[len(out)-1]; !rerr.IsNil() {
+			return fail(rerr.Interface().(error))
+		}
+		results := make([]reflect.Value, len(out))
+		for i, res := range out {
+			v, err := convertValue(res, typ.Out(i))
+			if err != nil {
+				return fail(fmt.Errorf("translate result %d: %w", i, err))
+			}
+			results[i] = v
+		}
+		return results
+	}), true
+}
+
+// translatable reports whether a value of type from can be converted to the type to through its json encoding
+func translatable(from, to reflect.Type) bool {
+	if from == to {
+		return true
+	}
+	for _, t := range []reflect.Type{from, to} {
+		switch t.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+			return false
+		}
+	}
+	return true
+}
+
+func convertValue(v reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	if v.Type() == typ {
+		return v, nil
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	out := reflect.New(typ)
+	if err := json.Unmarshal(data, out.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return out.Elem(), nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+type newHead struct {
+	Height int64
+	Cids   []string
+}
+
+type oldHead struct {
+	Height int64
+	Cids   []string
+	Parent string `json:",omitempty"`
+}
+
+type newAPIStruct struct {
+	Internal struct {
+		Head      func(ctx context.Context, height int64) (*newHead, error) `perm:"read"`
+		HeadCount func(ctx context.Context) (int64, error)                  `perm:"read"`
+		Export    func(ctx context.Context) (<-chan []byte, error)          `perm:"read"`
+		Remove    func(ctx context.Context, height int64) error             `perm:"admin"`
+	}
+}
+
+type oldAPIStruct struct {
+	Internal struct {
+		Head      func(ctx context.Context, height int32) (oldHead, error) `perm:"read"`
+		Count     func(ctx context.Context) (int64, error)                 `perm:"read"`
+		Export    func(ctx context.Context) (<-chan string, error)         `perm:"read"`
+		Remove    func(ctx context.Context, height int64) error            `perm:"read"`
+		Implement func(ctx context.Context) error                          `perm:"read"`
+		Missing   func(ctx context.Context) error                          `perm:"read"`
+	}
+}
+
+func TestTranslateProxy(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	var from newAPIStruct
+	from.Internal.Head = func(ctx context.Context, height int64) (*newHead, error) {
+		if height < 0 {
+			return nil, errors.New("negative height")
+		}
+		return &newHead{Height: height, Cids: []string{"a", "b"}}, nil
+	}
+	from.Internal.HeadCount = func(ctx context.Context) (int64, error) { return 7, nil }
+	from.Internal.Export = func(ctx context.Context) (<-chan []byte, error) { return nil, nil }
+	from.Internal.Remove = func(ctx context.Context, height int64) error { return nil }
+
+	var to oldAPIStruct
+	to.Internal.Implement = func(ctx context.Context) error { return nil }
+	translated, missing := TranslateProxy(&from, &to, map[string]string{"Count": "HeadCount"})
+	require.ElementsMatch(t, []string{"Head", "Count"}, translated)
+	// the channels are not converted and the permissions must match
+	require.ElementsMatch(t, []string{"Export", "Remove", "Missing"}, missing)
+	require.Nil(t, to.Internal.Export)
+	require.Nil(t, to.Internal.Remove)
+
+	head, err := to.Internal.Head(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, oldHead{Height: 10, Cids: []string{"a", "b"}}, head)
+	_, err = to.Internal.Head(ctx, -1)
+	require.EqualError(t, err, "negative height")
+
+	count, err := to.Internal.Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), count)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
 	"sync/atomic"
 
 	"github.com/dgraph-io/badger/v2"
@@ -84,8 +85,8 @@ func BadgerBlockstoreOptions(path string, readonly bool) (Options, error) {
 	// Default table size is already 64MiB. This is here to make it explicit.
 	opts.MaxTableSize = 64 << 20
 
-	// NOTE: The chain blockstore doesn't require any GC (blocks are never
-	// deleted). This will change if we move to a tiered blockstore.
+	// NOTE: The blocks are seldom deleted, the value log GC only reclaims the
+	// space of the deleted and of the rewritten blocks, see CollectGarbage.
 
 	opts.ReadOnly = readonly
 
@@ -126,6 +127,10 @@ type BadgerBlockstore struct {
 	keyTransform *keytransform.PrefixTransform
 
 	cache IBlockCache
+
+	valueDir string
+	// gcLk serializes the garbage collections, Close waits for the one running
+	gcLk sync.Mutex
 }
 
 var (
@@ -153,6 +158,7 @@ func Open(opts Options) (*BadgerBlockstore, error) {
 		DB:           db,
 		keyTransform: keyTransform,
 		cache:        cache,
+		valueDir:     opts.ValueDir,
 	}
 	return bs, nil
 }
@@ -165,6 +171,8 @@ func (b *BadgerBlockstore) Close() error {
 	}
 
 	defer atomic.StoreInt64(&b.state, stateClosed)
+	b.gcLk.Lock()
+	defer b.gcLk.Unlock()
 	return b.DB.Close()
 }
 
@@ -0,0 +1,133 @@
+package blockstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+)
+
+// The badger profiles tune the blockstore for the disk and the use of the node, the default options are kept when
+// the profile is empty.
+const (
+	BadgerProfileDefault = ""
+	// BadgerProfileSSDLarge is a fast disk and a machine with plenty of memory
+	BadgerProfileSSDLarge = "ssd-large"
+	// BadgerProfileSSDSmall keeps the memory and the disk used low
+	BadgerProfileSSDSmall = "ssd-small"
+	// BadgerProfileArchival is a blockstore keeping the whole chain, mostly written to and seldom read
+	BadgerProfileArchival = "archival"
+)
+
+// DefaultGCDiscardRatio is the part of a value log file which must be garbage for the GC to rewrite it
+const DefaultGCDiscardRatio = 0.5
+
+// ApplyBadgerProfile tunes the options for the profile
+func ApplyBadgerProfile(opts *Options, profile string) error {
+	switch profile {
+	case BadgerProfileDefault:
+	case BadgerProfileSSDLarge:
+		opts.ValueLogFileSize = 1 << 30
+		opts.MaxTableSize = 128 << 20
+		opts.NumCompactors = 8
+		opts.NumMemtables = 8
+		opts.BlockCacheSize = 1 << 30
+	case BadgerProfileSSDSmall:
+		opts.ValueLogFileSize = 256 << 20
+		opts.MaxTableSize = 32 << 20
+		opts.NumCompactors = 2
+		opts.NumMemtables = 3
+		opts.BlockCacheSize = 64 << 20
+	case BadgerProfileArchival:
+		// fewer and larger files, the reads do not need a large cache
+		opts.ValueLogFileSize = 1<<31 - 1
+		opts.MaxTableSize = 256 << 20
+		opts.NumCompactors = 4
+		opts.BlockCacheSize = 256 << 20
+	default:
+		return fmt.Errorf("unknown badger profile %q, expect one of %s, %s, %s", profile,
+			BadgerProfileSSDLarge, BadgerProfileSSDSmall, BadgerProfileArchival)
+	}
+	return nil
+}
+
+// GCResult reports a value log garbage collection
+type GCResult struct {
+	// Rewritten is the number of value log files rewritten
+	Rewritten int
+	// SizeBefore and SizeAfter are the bytes of the value log files
+	SizeBefore int64
+	SizeAfter  int64
+	Reclaimed  int64
+	Duration   time.Duration
+}
+
+// CollectGarbage rewrites the value log files with at least discardRatio of garbage until none is left or ctx is
+// done, and reports the space reclaimed. Only one collection runs at once.
+func (b *BadgerBlockstore) CollectGarbage(ctx context.Context, discardRatio float64) (*GCResult, error) {
+	if discardRatio <= 0 || discardRatio >= 1 {
+		discardRatio = DefaultGCDiscardRatio
+	}
+
+	b.gcLk.Lock()
+	defer b.gcLk.Unlock()
+
+	start := time.Now()
+	res := &GCResult{}
+	var err error
+	if res.SizeBefore, err = b.valueLogSize(); err != nil {
+		return nil, err
+	}
+	for ctx.Err() == nil {
+		if atomic.LoadInt64(&b.state) != stateOpen {
+			return nil, ErrBlockstoreClosed
+		}
+		err := b.DB.RunValueLogGC(discardRatio)
+		if errors.Is(err, badger.ErrNoRewrite) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("value log gc: %w", err)
+		}
+		res.Rewritten++
+	}
+
+	if res.SizeAfter, err = b.valueLogSize(); err != nil {
+		return nil, err
+	}
+	res.Reclaimed = res.SizeBefore - res.SizeAfter
+	res.Duration = time.Since(start)
+	return res, ctx.Err()
+}
+
+// valueLogSize sums the sizes of the value log files, badger only refreshes its own size every minute
+func (b *BadgerBlockstore) valueLogSize() (int64, error) {
+	dir := b.valueDir
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("read value log dir: %w", err)
+	}
+
+	var size int64
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".vlog") {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			// the file was removed by a rewrite
+			if os.IsNotExist(err) {
+				continue
+			}
+			return 0, err
+		}
+		size += info.Size()
+	}
+	return size, nil
+}
@@ -0,0 +1,55 @@
+package blockstore
+
+import (
+	"context"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestBadgerProfiles(t *testing.T) {
+	tf.UnitTest(t)
+
+	for _, profile := range []string{BadgerProfileDefault, BadgerProfileSSDLarge, BadgerProfileSSDSmall, BadgerProfileArchival} {
+		opts, err := BadgerBlockstoreOptions(t.TempDir(), false)
+		require.NoError(t, err)
+		require.NoError(t, ApplyBadgerProfile(&opts, profile))
+
+		bs, err := Open(opts)
+		require.NoError(t, err, profile)
+		require.NoError(t, bs.Close())
+	}
+
+	opts := DefaultOptions(t.TempDir())
+	assert.Error(t, ApplyBadgerProfile(&opts, "hdd"))
+}
+
+func TestBadgerCollectGarbage(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	opts, err := BadgerBlockstoreOptions(t.TempDir(), false)
+	require.NoError(t, err)
+	require.NoError(t, ApplyBadgerProfile(&opts, BadgerProfileSSDSmall))
+	bs, err := Open(opts)
+	require.NoError(t, err)
+
+	for i := 0; i < 16; i++ {
+		blk := blocks.NewBlock(append(make([]byte, 4096), byte(i)))
+		require.NoError(t, bs.Put(ctx, blk))
+		require.NoError(t, bs.DeleteBlock(ctx, blk.Cid()))
+	}
+
+	res, err := bs.CollectGarbage(ctx, 0)
+	require.NoError(t, err)
+	assert.Positive(t, res.SizeBefore)
+	assert.Equal(t, res.SizeBefore-res.SizeAfter, res.Reclaimed)
+
+	require.NoError(t, bs.Close())
+	_, err = bs.CollectGarbage(ctx, 0.5)
+	assert.ErrorIs(t, err, ErrBlockstoreClosed)
+}
@@ -51,9 +51,29 @@ func linksForObj(blk blocks.Block, cb func(cid.Cid)) error {
 }
 
 func CopyParticial(ctx context.Context, from, to Blockstore, root cid.Cid) error {
+	_, err := CopyParticialWithStats(ctx, from, to, root)
+	return err
+}
+
+// CopyStats describes the blocks written by a copy
+type CopyStats struct {
+	Blocks int
+	Bytes  int
+}
+
+// CopyParticialWithStats copies the blocks reachable from root that to does not have, in batches, and returns
+// how many blocks and bytes were written. Nothing is copied when to has root already, from may not have it then, as
+// the write layer of a buffer holding an unchanged state.
+func CopyParticialWithStats(ctx context.Context, from, to Blockstore, root cid.Cid) (CopyStats, error) {
 	ctx, span := trace.StartSpan(ctx, "vm.Copy") // nolint
 	defer span.End()
 
+	if has, err := to.Has(ctx, root); err != nil {
+		return CopyStats{}, fmt.Errorf("has: %v", err)
+	} else if has {
+		return CopyStats{}, nil
+	}
+
 	var numBlocks int
 	var totalCopySize int
 
@@ -99,7 +119,7 @@ func CopyParticial(ctx context.Context, from, to Blockstore, root cid.Cid) error
 	}
 
 	if err := copyRec(ctx, from, to, root, batchCp); err != nil {
-		return fmt.Errorf("copyRec: %v", err)
+		return CopyStats{}, fmt.Errorf("copyRec: %v", err)
 	}
 
 	if len(batch) > 0 {
@@ -108,14 +128,14 @@ func CopyParticial(ctx context.Context, from, to Blockstore, root cid.Cid) error
 	close(toFlush)        // close the toFlush triggering the loop to end
 	err := <-errFlushChan // get error out or get nil if it was closed
 	if err != nil {
-		return err
+		return CopyStats{}, err
 	}
 
 	span.AddAttributes(
 		trace.Int64Attribute("numBlocks", int64(numBlocks)),
 		trace.Int64Attribute("copySize", int64(totalCopySize)),
 	)
-	return nil
+	return CopyStats{Blocks: numBlocks, Bytes: totalCopySize}, nil
 }
 
 func copyRec(ctx context.Context, from, to Blockstore, root cid.Cid, cp func(blocks.Block) error) error {
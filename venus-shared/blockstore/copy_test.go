@@ -0,0 +1,62 @@
+package blockstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestCopyParticialWithStats(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	from, to := NewMemory(), NewMemory()
+	put := func(bs Blockstore, obj interface{}) (cid.Cid, int) {
+		c, err := cbor.NewCborStore(bs).Put(ctx, obj)
+		require.NoError(t, err)
+		blk, err := bs.Get(ctx, c)
+		require.NoError(t, err)
+		return c, len(blk.RawData())
+	}
+
+	shared, _ := put(from, "shared")
+	_, _ = put(to, "shared")
+	leaf, leafSize := put(from, "leaf")
+	root, rootSize := put(from, map[string]cid.Cid{"leaf": leaf, "shared": shared})
+	garbage, _ := put(from, "garbage")
+
+	stats, err := CopyParticialWithStats(ctx, from, to, root)
+	require.NoError(t, err)
+	require.Equal(t, CopyStats{Blocks: 2, Bytes: leafSize + rootSize}, stats)
+
+	// the blocks which are not reachable from the root are not copied
+	has, err := to.Has(ctx, garbage)
+	require.NoError(t, err)
+	require.False(t, has)
+	has, err = to.Has(ctx, leaf)
+	require.NoError(t, err)
+	require.True(t, has)
+}
+
+func TestCopyParticialUnchangedRoot(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	// the state is unchanged, its root is in the base store and not in the write layer of the buffer
+	base := NewMemory()
+	leaf, err := cbor.NewCborStore(base).Put(ctx, "leaf")
+	require.NoError(t, err)
+	root, err := cbor.NewCborStore(base).Put(ctx, map[string]cid.Cid{"leaf": leaf})
+	require.NoError(t, err)
+	buf := NewBufferedBstore(base)
+
+	stats, err := CopyParticialWithStats(ctx, buf.Write(), base, root)
+	require.NoError(t, err)
+	require.Equal(t, CopyStats{}, stats)
+	require.NoError(t, CopyParticial(ctx, buf.Write(), buf.Read(), root))
+}
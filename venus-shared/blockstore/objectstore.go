@@ -0,0 +1,21 @@
+package blockstore
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrObjectNotFound is returned by an ObjectStore for a key it does not have
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectStore is a flat store of immutable objects by key, such as a bucket of an S3-compatible object storage
+type ObjectStore interface {
+	PutObject(ctx context.Context, key string, data []byte) error
+	// GetObject returns ErrObjectNotFound when there is no object at key
+	GetObject(ctx context.Context, key string) ([]byte, error)
+	HasObject(ctx context.Context, key string) (bool, error)
+	// DeleteObject does not fail when there is no object at key
+	DeleteObject(ctx context.Context, key string) error
+	// ListObjects calls fn with the keys starting with prefix, in lexicographic order, until fn fails
+	ListObjects(ctx context.Context, prefix string, fn func(key string) error) error
+}
@@ -0,0 +1,361 @@
+package blockstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	ipld "github.com/ipfs/go-ipld-format"
+	"golang.org/x/sync/errgroup"
+)
+
+// offloadConcurrency is the number of objects written to or read from the object storage at once
+const offloadConcurrency = 16
+
+var _ Blockstore = (*OffloadBlockstore)(nil)
+
+// OffloadStats counts the blocks moved between the hot blockstore and the object storage
+type OffloadStats struct {
+	Blocks int
+	Bytes  int64
+}
+
+// offloadIndexBuilt marks an index holding every object of the cold tier
+var offloadIndexBuilt = datastore.NewKey("/built")
+
+// OffloadBlockstore is a hot blockstore whose blocks can be offloaded to an object storage, the cold tier. The blocks
+// missing from the hot blockstore are read through from the cold tier, and copied back to the hot one when the
+// blockstore promotes them. The blocks are always written to the hot blockstore, and AllKeysChan only lists its
+// keys.
+//
+// A local index records the offloaded blocks, only the blocks it has are read from the cold tier: the blocks missing
+// from both tiers, which the chain asks for all the time, do not cost a request to the object storage.
+type OffloadBlockstore struct {
+	hot     Blockstore
+	cold    ObjectStore
+	index   datastore.Batching
+	promote bool
+
+	coldReads atomic.Int64
+}
+
+// NewOffloadBlockstore offloads the blocks of hot to cold, the blocks read from cold are written back to hot when
+// promote is set. The offloaded blocks are recorded in index, which is built from the objects of cold the first time.
+func NewOffloadBlockstore(ctx context.Context, hot Blockstore, cold ObjectStore, index datastore.Batching, promote bool) (*OffloadBlockstore, error) {
+	bs := &OffloadBlockstore{hot: hot, cold: cold, index: index, promote: promote}
+	built, err := index.Has(ctx, offloadIndexBuilt)
+	if err != nil {
+		return nil, fmt.Errorf("read the offload index: %w", err)
+	}
+	if !built {
+		if err := bs.buildIndex(ctx); err != nil {
+			return nil, fmt.Errorf("build the offload index: %w", err)
+		}
+	}
+	return bs, nil
+}
+
+func offloadIndexKey(c cid.Cid) datastore.Key {
+	return datastore.NewKey("/blocks/" + c.String())
+}
+
+func (bs *OffloadBlockstore) buildIndex(ctx context.Context) error {
+	batch, err := bs.index.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	var count int
+	err = bs.cold.ListObjects(ctx, "", func(key string) error {
+		c, err := cid.Decode(key)
+		if err != nil {
+			return nil
+		}
+		count++
+		return batch.Put(ctx, offloadIndexKey(c), nil)
+	})
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(ctx, offloadIndexBuilt, nil); err != nil {
+		return err
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return err
+	}
+	log.Infof("indexed %d offloaded blocks", count)
+	return nil
+}
+
+// offloaded reports whether the index has the block, the blocks it does not have are not read from the cold tier
+func (bs *OffloadBlockstore) offloaded(ctx context.Context, c cid.Cid) (bool, error) {
+	return bs.index.Has(ctx, offloadIndexKey(c))
+}
+
+// Hot returns the hot blockstore
+func (bs *OffloadBlockstore) Hot() Blockstore {
+	return bs.hot
+}
+
+// ColdReads returns the number of blocks read from the cold tier
+func (bs *OffloadBlockstore) ColdReads() int64 {
+	return bs.coldReads.Load()
+}
+
+// GetCold reads the block from the cold tier, without promoting it
+func (bs *OffloadBlockstore) GetCold(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	data, err := bs.cold.GetObject(ctx, c.String())
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil, ipld.ErrNotFound{Cid: c}
+		}
+		return nil, err
+	}
+	blk, err := coldBlock(c, data)
+	if err != nil {
+		return nil, err
+	}
+	bs.coldReads.Add(1)
+	return blk, nil
+}
+
+func (bs *OffloadBlockstore) getCold(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	offloaded, err := bs.offloaded(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if !offloaded {
+		return nil, ipld.ErrNotFound{Cid: c}
+	}
+	blk, err := bs.GetCold(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if bs.promote {
+		if err := bs.hot.Put(ctx, blk); err != nil {
+			log.Warnf("promote the offloaded block %s: %v", c, err)
+		}
+	}
+	return blk, nil
+}
+
+// coldBlock checks the object read from the cold tier is the block of the cid, the object storage is not trusted more
+// than the peers
+func coldBlock(c cid.Cid, data []byte) (blocks.Block, error) {
+	sum, err := c.Prefix().Sum(data)
+	if err != nil {
+		return nil, err
+	}
+	if !sum.Equals(c) {
+		return nil, fmt.Errorf("the offloaded block %s is corrupt", c)
+	}
+	return blocks.NewBlockWithCid(data, c)
+}
+
+func (bs *OffloadBlockstore) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	blk, err := bs.hot.Get(ctx, c)
+	if ipld.IsNotFound(err) {
+		return bs.getCold(ctx, c)
+	}
+	return blk, err
+}
+
+func (bs *OffloadBlockstore) View(ctx context.Context, c cid.Cid, callback func([]byte) error) error {
+	err := bs.hot.View(ctx, c, callback)
+	if !ipld.IsNotFound(err) {
+		return err
+	}
+	blk, err := bs.getCold(ctx, c)
+	if err != nil {
+		return err
+	}
+	return callback(blk.RawData())
+}
+
+func (bs *OffloadBlockstore) Has(ctx context.Context, c cid.Cid) (bool, error) {
+	has, err := bs.hot.Has(ctx, c)
+	if err != nil || has {
+		return has, err
+	}
+	return bs.offloaded(ctx, c)
+}
+
+func (bs *OffloadBlockstore) GetSize(ctx context.Context, c cid.Cid) (int, error) {
+	size, err := bs.hot.GetSize(ctx, c)
+	if !ipld.IsNotFound(err) {
+		return size, err
+	}
+	blk, err := bs.getCold(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+	return len(blk.RawData()), nil
+}
+
+func (bs *OffloadBlockstore) Put(ctx context.Context, blk blocks.Block) error {
+	return bs.hot.Put(ctx, blk)
+}
+
+func (bs *OffloadBlockstore) PutMany(ctx context.Context, blks []blocks.Block) error {
+	return bs.hot.PutMany(ctx, blks)
+}
+
+// DeleteBlock deletes the hot copy of the block, the cold tier is an archive the garbage collection of the hot
+// blockstore does not touch
+func (bs *OffloadBlockstore) DeleteBlock(ctx context.Context, c cid.Cid) error {
+	return bs.hot.DeleteBlock(ctx, c)
+}
+
+// DeleteMany deletes the hot copies of the blocks, as DeleteBlock
+func (bs *OffloadBlockstore) DeleteMany(ctx context.Context, cids []cid.Cid) error {
+	return bs.hot.DeleteMany(ctx, cids)
+}
+
+func (bs *OffloadBlockstore) AllKeysChan(ctx context.Context) (<-chan cid.Cid, error) {
+	return bs.hot.AllKeysChan(ctx)
+}
+
+func (bs *OffloadBlockstore) HashOnRead(enabled bool) {
+	bs.hot.HashOnRead(enabled)
+}
+
+func (bs *OffloadBlockstore) Flush(ctx context.Context) error {
+	return bs.hot.Flush(ctx)
+}
+
+// Offload writes the blocks of the hot blockstore to the cold tier and records them in the index, then deletes them
+// from the hot blockstore. The blocks the hot blockstore does not have are skipped, and none is deleted when one
+// fails to be written.
+func (bs *OffloadBlockstore) Offload(ctx context.Context, cids []cid.Cid) (OffloadStats, error) {
+	var stats OffloadStats
+	moved := make([]bool, len(cids))
+	sizes := make([]int, len(cids))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(offloadConcurrency)
+	for i, c := range cids {
+		i, c := i, c
+		g.Go(func() error {
+			blk, err := bs.hot.Get(gctx, c)
+			if ipld.IsNotFound(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := bs.cold.PutObject(gctx, c.String(), blk.RawData()); err != nil {
+				return err
+			}
+			moved[i], sizes[i] = true, len(blk.RawData())
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return stats, fmt.Errorf("offload blocks: %w", err)
+	}
+
+	batch, err := bs.index.Batch(ctx)
+	if err != nil {
+		return stats, fmt.Errorf("index the offloaded blocks: %w", err)
+	}
+	toDelete := make([]cid.Cid, 0, len(cids))
+	for i, c := range cids {
+		if moved[i] {
+			if err := batch.Put(ctx, offloadIndexKey(c), nil); err != nil {
+				return stats, fmt.Errorf("index the offloaded blocks: %w", err)
+			}
+			toDelete = append(toDelete, c)
+			stats.Blocks++
+			stats.Bytes += int64(sizes[i])
+		}
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return stats, fmt.Errorf("index the offloaded blocks: %w", err)
+	}
+	if err := bs.hot.DeleteMany(ctx, toDelete); err != nil {
+		return stats, fmt.Errorf("delete the offloaded blocks: %w", err)
+	}
+	return stats, nil
+}
+
+// Restore copies the offloaded blocks back to the hot blockstore, the blocks it has already are skipped. The cold
+// tier keeps its copies.
+func (bs *OffloadBlockstore) Restore(ctx context.Context, cids []cid.Cid) (OffloadStats, error) {
+	var stats OffloadStats
+	restored := make([]blocks.Block, len(cids))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(offloadConcurrency)
+	for i, c := range cids {
+		i, c := i, c
+		g.Go(func() error {
+			has, err := bs.hot.Has(gctx, c)
+			if err != nil || has {
+				return err
+			}
+			data, err := bs.cold.GetObject(gctx, c.String())
+			if errors.Is(err, ErrObjectNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			blk, err := coldBlock(c, data)
+			if err != nil {
+				return err
+			}
+			restored[i] = blk
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return stats, fmt.Errorf("restore blocks: %w", err)
+	}
+
+	toPut := make([]blocks.Block, 0, len(cids))
+	for _, blk := range restored {
+		if blk != nil {
+			toPut = append(toPut, blk)
+			stats.Blocks++
+			stats.Bytes += int64(len(blk.RawData()))
+		}
+	}
+	if err := bs.hot.PutMany(ctx, toPut); err != nil {
+		return stats, fmt.Errorf("write the restored blocks: %w", err)
+	}
+	return stats, nil
+}
+
+// RestoreAll copies every block of the cold tier back to the hot blockstore
+func (bs *OffloadBlockstore) RestoreAll(ctx context.Context) (OffloadStats, error) {
+	const batchSize = 1024
+
+	var total OffloadStats
+	batch := make([]cid.Cid, 0, batchSize)
+	flush := func() error {
+		stats, err := bs.Restore(ctx, batch)
+		total.Blocks += stats.Blocks
+		total.Bytes += stats.Bytes
+		batch = batch[:0]
+		return err
+	}
+	err := bs.cold.ListObjects(ctx, "", func(key string) error {
+		c, err := cid.Decode(key)
+		if err != nil {
+			log.Warnf("skip the object %s of the cold tier, it is not a cid", key)
+			return nil
+		}
+		batch = append(batch, c)
+		if len(batch) == batchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return total, err
+	}
+	return total, flush()
+}
@@ -0,0 +1,154 @@
+package blockstore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestOffloadBlockstore(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	fake, cold := newFakeS3(t)
+
+	hot := NewMemory()
+	bs, err := NewOffloadBlockstore(ctx, hot, cold, datastore.NewMapDatastore(), false)
+	require.NoError(t, err)
+
+	var blks []blocks.Block
+	var cids []cid.Cid
+	for i := 0; i < 5; i++ {
+		blk := blocks.NewBlock([]byte(fmt.Sprintf("block %d", i)))
+		blks = append(blks, blk)
+		cids = append(cids, blk.Cid())
+	}
+	require.NoError(t, bs.PutMany(ctx, blks))
+	fake.lk.Lock()
+	fake.requests = 0
+	fake.lk.Unlock()
+
+	missing := blocks.NewBlock([]byte("missing")).Cid()
+	stats, err := bs.Offload(ctx, append(cids[:3:3], missing))
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Blocks)
+	assert.Equal(t, int64(3*len("block 0")), stats.Bytes)
+	assert.Len(t, fake.objects, 3)
+
+	// offloaded blocks are gone from the hot blockstore, and read through
+	has, err := hot.Has(ctx, cids[0])
+	require.NoError(t, err)
+	assert.False(t, has)
+	has, err = bs.Has(ctx, cids[0])
+	require.NoError(t, err)
+	assert.True(t, has)
+	blk, err := bs.Get(ctx, cids[1])
+	require.NoError(t, err)
+	assert.Equal(t, blks[1].RawData(), blk.RawData())
+	require.NoError(t, bs.View(ctx, cids[2], func(data []byte) error {
+		assert.Equal(t, blks[2].RawData(), data)
+		return nil
+	}))
+	size, err := bs.GetSize(ctx, cids[0])
+	require.NoError(t, err)
+	assert.Equal(t, len("block 0"), size)
+	assert.Equal(t, int64(3), bs.ColdReads())
+	// the blocks missing from both tiers are not looked up in the object storage
+	fake.lk.Lock()
+	requests := fake.requests
+	fake.lk.Unlock()
+	_, err = bs.Get(ctx, missing)
+	assert.True(t, ipld.IsNotFound(err))
+	has, err = bs.Has(ctx, missing)
+	require.NoError(t, err)
+	assert.False(t, has)
+	_, err = bs.GetSize(ctx, missing)
+	assert.True(t, ipld.IsNotFound(err))
+	fake.lk.Lock()
+	assert.Equal(t, requests, fake.requests)
+	fake.lk.Unlock()
+
+	// a corrupt object of the cold tier is refused
+	fake.objects["chain/"+cids[0].String()] = []byte("corrupt")
+	_, err = bs.Get(ctx, cids[0])
+	assert.ErrorContains(t, err, "corrupt")
+	fake.objects["chain/"+cids[0].String()] = blks[0].RawData()
+
+	stats, err = bs.RestoreAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Blocks)
+	for _, c := range cids {
+		has, err := hot.Has(ctx, c)
+		require.NoError(t, err)
+		assert.True(t, has, c)
+	}
+	// deleting a block only deletes its hot copy, the cold tier keeps it
+	assert.Len(t, fake.objects, 3)
+	require.NoError(t, bs.DeleteMany(ctx, cids[:2]))
+	require.NoError(t, bs.DeleteBlock(ctx, cids[2]))
+	assert.Len(t, fake.objects, 3)
+	has, err = hot.Has(ctx, cids[0])
+	require.NoError(t, err)
+	assert.False(t, has)
+	blk, err = bs.Get(ctx, cids[0])
+	require.NoError(t, err)
+	assert.Equal(t, blks[0].RawData(), blk.RawData())
+}
+
+func TestOffloadBlockstoreBuildIndex(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	_, cold := newFakeS3(t)
+
+	blk := blocks.NewBlock([]byte("block"))
+	require.NoError(t, cold.PutObject(ctx, blk.Cid().String(), blk.RawData()))
+
+	// the objects of the cold tier are indexed the first time
+	index := datastore.NewMapDatastore()
+	bs, err := NewOffloadBlockstore(ctx, NewMemory(), cold, index, false)
+	require.NoError(t, err)
+	has, err := bs.Has(ctx, blk.Cid())
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	// and not listed again afterwards
+	other := blocks.NewBlock([]byte("other"))
+	require.NoError(t, cold.PutObject(ctx, other.Cid().String(), other.RawData()))
+	bs, err = NewOffloadBlockstore(ctx, NewMemory(), cold, index, false)
+	require.NoError(t, err)
+	has, err = bs.Has(ctx, other.Cid())
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestOffloadBlockstorePromote(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	_, cold := newFakeS3(t)
+
+	hot := NewMemory()
+	bs, err := NewOffloadBlockstore(ctx, hot, cold, datastore.NewMapDatastore(), true)
+	require.NoError(t, err)
+	blk := blocks.NewBlock([]byte("block"))
+	require.NoError(t, bs.Put(ctx, blk))
+	_, err = bs.Offload(ctx, []cid.Cid{blk.Cid()})
+	require.NoError(t, err)
+
+	_, err = bs.Get(ctx, blk.Cid())
+	require.NoError(t, err)
+	has, err := hot.Has(ctx, blk.Cid())
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	_, err = bs.Get(ctx, blk.Cid())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), bs.ColdReads())
+}
@@ -0,0 +1,257 @@
+package blockstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/filecoin-project/venus/venus-shared/utils/sigv4"
+)
+
+const (
+	s3Service        = "s3"
+	s3DefaultRegion  = "us-east-1"
+	s3DefaultTimeout = time.Minute
+	// s3MaxObjectSize bounds the objects read back, far above the largest block of the chain
+	s3MaxObjectSize = 64 << 20
+)
+
+// S3Options are the bucket of an S3-compatible object storage and the credentials to access it
+type S3Options struct {
+	// Endpoint is the url of the storage, such as https://s3.us-east-1.amazonaws.com or http://127.0.0.1:9000
+	Endpoint string
+	Region   string
+	Bucket   string
+	// Prefix is prepended to the keys of the objects
+	Prefix string
+	// AccessKeyID and SecretAccessKey are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY when empty
+	AccessKeyID     string
+	SecretAccessKey string
+	// StorageClass is the storage class the objects are written with, such as STANDARD_IA, the default of the
+	// bucket when empty
+	StorageClass string
+	// PathStyle addresses the bucket in the path rather than in the host name, which most self-hosted storages need
+	PathStyle bool
+	// Timeout bounds a request, a minute when 0
+	Timeout time.Duration
+}
+
+// S3Store is an ObjectStore on a bucket of an S3-compatible object storage, the requests are signed with the
+// signature version 4
+type S3Store struct {
+	opts   S3Options
+	creds  sigv4.Credentials
+	base   *url.URL
+	client *http.Client
+	now    func() time.Time
+}
+
+var _ ObjectStore = (*S3Store)(nil)
+
+// NewS3Store creates the store of the bucket, it does not check the bucket is reachable
+func NewS3Store(opts S3Options) (*S3Store, error) {
+	if opts.Bucket == "" {
+		return nil, fmt.Errorf("no s3 bucket")
+	}
+	if opts.Endpoint == "" {
+		return nil, fmt.Errorf("no s3 endpoint")
+	}
+	base, err := url.Parse(opts.Endpoint)
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", opts.Endpoint)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q, expect http or https", opts.Endpoint)
+	}
+	if !opts.PathStyle {
+		base.Host = opts.Bucket + "." + base.Host
+	}
+	if opts.Region == "" {
+		opts.Region = s3DefaultRegion
+	}
+	if opts.AccessKeyID == "" {
+		opts.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+	}
+	if opts.SecretAccessKey == "" {
+		opts.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	if opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+		return nil, fmt.Errorf("no s3 credentials")
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = s3DefaultTimeout
+	}
+	return &S3Store{
+		opts:   opts,
+		creds:  sigv4.Credentials{AccessKeyID: opts.AccessKeyID, SecretAccessKey: opts.SecretAccessKey},
+		base:   base,
+		client: &http.Client{Timeout: opts.Timeout},
+		now:    time.Now,
+	}, nil
+}
+
+// objectURL returns the url of the object at key, or of the bucket when key is empty
+func (s *S3Store) objectURL(key string, query url.Values) *url.URL {
+	u := *s.base
+	p := strings.TrimSuffix(u.Path, "/")
+	if s.opts.PathStyle {
+		p += "/" + s.opts.Bucket
+	}
+	p += "/" + key
+	u.Path = p
+	u.RawPath = ""
+	u.RawQuery = query.Encode()
+	return &u
+}
+
+func (s *S3Store) do(ctx context.Context, method, key string, query url.Values, body []byte, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key, query).String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	s.sign(req, sigv4.PayloadHash(body), s.now())
+	return s.client.Do(req)
+}
+
+func (s *S3Store) key(key string) string {
+	return s.opts.Prefix + key
+}
+
+// PutObject writes the object at key
+func (s *S3Store) PutObject(ctx context.Context, key string, data []byte) error {
+	header := http.Header{}
+	if s.opts.StorageClass != "" {
+		header.Set("X-Amz-Storage-Class", s.opts.StorageClass)
+	}
+	resp, err := s.do(ctx, http.MethodPut, s.key(key), nil, data, header)
+	if err != nil {
+		return fmt.Errorf("put object %s: %w", key, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(resp, "put object "+key)
+	}
+	return nil
+}
+
+// GetObject reads the object at key
+func (s *S3Store) GetObject(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, s.key(key), nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get object %s: %w", key, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrObjectNotFound
+	default:
+		return nil, s3Error(resp, "get object "+key)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, s3MaxObjectSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read object %s: %w", key, err)
+	}
+	if len(data) > s3MaxObjectSize {
+		return nil, fmt.Errorf("object %s is larger than %d bytes", key, s3MaxObjectSize)
+	}
+	return data, nil
+}
+
+// HasObject checks whether there is an object at key
+func (s *S3Store) HasObject(ctx context.Context, key string) (bool, error) {
+	resp, err := s.do(ctx, http.MethodHead, s.key(key), nil, nil, nil)
+	if err != nil {
+		return false, fmt.Errorf("head object %s: %w", key, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, s3Error(resp, "head object "+key)
+	}
+}
+
+// DeleteObject deletes the object at key
+func (s *S3Store) DeleteObject(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, s.key(key), nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete object %s: %w", key, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s3Error(resp, "delete object "+key)
+	}
+	return nil
+}
+
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// ListObjects calls fn with the keys starting with prefix, the prefix of the store excluded
+func (s *S3Store) ListObjects(ctx context.Context, prefix string, fn func(key string) error) error {
+	query := url.Values{"list-type": {"2"}, "prefix": {s.key(prefix)}}
+	for {
+		resp, err := s.do(ctx, http.MethodGet, "", query, nil, nil)
+		if err != nil {
+			return fmt.Errorf("list objects: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := s3Error(resp, "list objects")
+			_ = resp.Body.Close()
+			return err
+		}
+		var res s3ListResult
+		err = xml.NewDecoder(io.LimitReader(resp.Body, s3MaxObjectSize)).Decode(&res)
+		_ = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("decode the objects listed: %w", err)
+		}
+
+		for _, c := range res.Contents {
+			if err := fn(strings.TrimPrefix(c.Key, s.opts.Prefix)); err != nil {
+				return err
+			}
+		}
+		if !res.IsTruncated || res.NextContinuationToken == "" {
+			return nil
+		}
+		query.Set("continuation-token", res.NextContinuationToken)
+	}
+}
+
+func s3Error(resp *http.Response, op string) error {
+	var res struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if xml.Unmarshal(body, &res) == nil && res.Code != "" {
+		return fmt.Errorf("%s: %s: %s: %s", op, resp.Status, res.Code, res.Message)
+	}
+	return fmt.Errorf("%s: %s", op, resp.Status)
+}
+
+// sign adds the signature version 4 of the request to its headers
+func (s *S3Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	sigv4.Sign(req, payloadHash, s.creds, s.opts.Region, s3Service, now)
+}
@@ -0,0 +1,162 @@
+package blockstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/multiformats/go-multihash"
+	"golang.org/x/time/rate"
+)
+
+// MaxScrubReported bounds the cids of the corrupt blocks kept in a scrub result, the corrupt blocks are all counted
+const MaxScrubReported = 1000
+
+// scrubBurst is the largest read allowed at once by the rate limit, larger than any block
+const scrubBurst = 8 << 20
+
+// ScrubOptions sets how a blockstore is scrubbed
+type ScrubOptions struct {
+	// BytesPerSecond limits the reads of the scrub, 0 is unlimited
+	BytesPerSecond int64
+	// Fetch gets a sound copy of a corrupt block to replace it with, the corrupt blocks are only reported when it is nil
+	Fetch func(context.Context, cid.Cid) (blocks.Block, error)
+	// Progress is called with the result so far every ProgressEvery blocks and for every corrupt block
+	Progress      func(*ScrubResult)
+	ProgressEvery int64
+}
+
+// ScrubResult reports a scrub of a blockstore
+type ScrubResult struct {
+	// Checked is the number of blocks hashed again and Bytes their size
+	Checked int64
+	Bytes   int64
+	// Largest is the size of the largest block
+	Largest int64
+	// Skipped is the number of blocks whose hash function is not supported
+	Skipped int64
+	// Corrupt is the number of blocks whose data does not match their cid or can't be read, CorruptCids the first
+	// MaxScrubReported of them
+	Corrupt     int64
+	CorruptCids []cid.Cid
+	// Repaired are the corrupt blocks replaced by a sound copy
+	Repaired int64
+	Duration time.Duration
+}
+
+// Scrub hashes every block of bs again and compares the digest with its cid, the corrupt blocks are replaced with
+// the copy returned by opts.Fetch when it is set and the copy is sound. It returns the result so far when ctx is done.
+func Scrub(ctx context.Context, bs Blockstore, opts ScrubOptions) (*ScrubResult, error) {
+	start := time.Now()
+	res := &ScrubResult{}
+
+	var limiter *rate.Limiter
+	if opts.BytesPerSecond > 0 {
+		burst := scrubBurst
+		if opts.BytesPerSecond > scrubBurst {
+			burst = int(opts.BytesPerSecond)
+		}
+		limiter = rate.NewLimiter(rate.Limit(opts.BytesPerSecond), burst)
+	}
+	progress := func() {
+		if opts.Progress != nil {
+			res.Duration = time.Since(start)
+			opts.Progress(res)
+		}
+	}
+
+	keys, err := bs.AllKeysChan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list the blocks: %w", err)
+	}
+	for c := range keys {
+		var size int
+		sound, supported := true, true
+		err := bs.View(ctx, c, func(data []byte) error {
+			size = len(data)
+			sound, supported = checkBlock(c, data)
+			return nil
+		})
+		if ipld.IsNotFound(err) {
+			// deleted since it was listed
+			continue
+		}
+		if errors.Is(err, ErrBlockstoreClosed) {
+			return nil, err
+		}
+
+		res.Checked++
+		res.Bytes += int64(size)
+		if int64(size) > res.Largest {
+			res.Largest = int64(size)
+		}
+		switch {
+		case err != nil:
+			log.Warnf("scrub: read block %s: %v", c, err)
+			sound = false
+		case !supported:
+			res.Skipped++
+		}
+		if !sound {
+			res.Corrupt++
+			if len(res.CorruptCids) < MaxScrubReported {
+				res.CorruptCids = append(res.CorruptCids, c)
+			}
+			if opts.Fetch != nil {
+				if err := repairBlock(ctx, bs, c, opts.Fetch); err != nil {
+					log.Warnf("scrub: repair block %s: %v", c, err)
+				} else {
+					res.Repaired++
+				}
+			}
+			progress()
+		} else if opts.ProgressEvery > 0 && res.Checked%opts.ProgressEvery == 0 {
+			progress()
+		}
+
+		if limiter != nil && size > 0 {
+			if err := limiter.WaitN(ctx, size); err != nil {
+				break
+			}
+		}
+	}
+
+	res.Duration = time.Since(start)
+	return res, ctx.Err()
+}
+
+// checkBlock reports whether the data has the digest of the cid, supported is false when the hash function is unknown
+func checkBlock(c cid.Cid, data []byte) (sound bool, supported bool) {
+	prefix := c.Prefix()
+	sum, err := multihash.Sum(data, prefix.MhType, prefix.MhLength)
+	if err != nil {
+		return true, false
+	}
+	return bytes.Equal(sum, c.Hash()), true
+}
+
+// repairBlock replaces the corrupt block with the copy fetched, once it is checked
+func repairBlock(ctx context.Context, bs Blockstore, c cid.Cid, fetch func(context.Context, cid.Cid) (blocks.Block, error)) error {
+	blk, err := fetch(ctx, c)
+	if err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+	if sound, _ := checkBlock(c, blk.RawData()); !sound {
+		return fmt.Errorf("the copy fetched is corrupt too")
+	}
+
+	// the put is skipped while the block is stored
+	if err := bs.DeleteBlock(ctx, c); err != nil {
+		return fmt.Errorf("delete the corrupt block: %w", err)
+	}
+	sound, err := blocks.NewBlockWithCid(blk.RawData(), c)
+	if err != nil {
+		return err
+	}
+	return bs.Put(ctx, sound)
+}
@@ -0,0 +1,76 @@
+package blockstore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestScrub(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	opts, err := BadgerBlockstoreOptions(t.TempDir(), false)
+	require.NoError(t, err)
+	bs, err := Open(opts)
+	require.NoError(t, err)
+	defer bs.Close() //nolint:errcheck
+
+	var size int64
+	for i := 0; i < 10; i++ {
+		blk := blocks.NewBlock([]byte(fmt.Sprintf("block %d", i)))
+		size += int64(len(blk.RawData()))
+		require.NoError(t, bs.Put(ctx, blk))
+	}
+	// the data of the block is damaged on the disk
+	sound := blocks.NewBlock([]byte("sound block"))
+	rotten, err := blocks.NewBlockWithCid([]byte("sound blocc"), sound.Cid())
+	require.NoError(t, err)
+	require.NoError(t, bs.Put(ctx, rotten))
+	size += int64(len(rotten.RawData()))
+
+	var reports int
+	res, err := Scrub(ctx, bs, ScrubOptions{Progress: func(*ScrubResult) { reports++ }})
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), res.Checked)
+	assert.Equal(t, size, res.Bytes)
+	assert.Equal(t, int64(1), res.Corrupt)
+	require.Len(t, res.CorruptCids, 1)
+	assert.Equal(t, sound.Cid().Hash(), res.CorruptCids[0].Hash())
+	assert.Zero(t, res.Repaired)
+	assert.Equal(t, 1, reports)
+
+	// the copy fetched is checked before it replaces the block
+	fetch := func(_ context.Context, c cid.Cid) (blocks.Block, error) { return rotten, nil }
+	res, err = Scrub(ctx, bs, ScrubOptions{Fetch: fetch, BytesPerSecond: 1 << 20})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.Corrupt)
+	assert.Zero(t, res.Repaired)
+
+	fetch = func(_ context.Context, c cid.Cid) (blocks.Block, error) { return sound, nil }
+	res, err = Scrub(ctx, bs, ScrubOptions{Fetch: fetch})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.Corrupt)
+	assert.Equal(t, int64(1), res.Repaired)
+
+	res, err = Scrub(ctx, bs, ScrubOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), res.Checked)
+	assert.Zero(t, res.Corrupt)
+	blk, err := bs.Get(ctx, sound.Cid())
+	require.NoError(t, err)
+	assert.Equal(t, sound.RawData(), blk.RawData())
+
+	// the scrub stops with the context
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = Scrub(cctx, bs, ScrubOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -39,8 +39,13 @@ func (txBlockstore *TxBlockstore) Has(ctx context.Context, cid cid.Cid) (bool, e
 			return true, nil
 		}
 	}
+	return txBlockstore.HasInTx(ctx, cid)
+}
 
-	_, err := txBlockstore.tx.Get(key.Bytes())
+// HasInTx tells whether the block is in the transaction. Unlike Has it skips the cache, which is shared with the
+// blockstore and also holds the blocks written after the transaction was opened.
+func (txBlockstore *TxBlockstore) HasInTx(ctx context.Context, cid cid.Cid) (bool, error) {
+	_, err := txBlockstore.tx.Get(txBlockstore.ConvertKey(cid).Bytes())
 	switch err {
 	case badger.ErrKeyNotFound:
 		return false, nil
@@ -197,3 +202,8 @@ func (txBlockstore *TxBlockstore) ConvertKey(cid cid.Cid) datastore.Key {
 func (txBlockstore *TxBlockstore) HashOnRead(enabled bool) {
 	log.Warnf("called HashOnRead on badger blockstore; function not supported; ignoring")
 }
+
+// Discard releases the underlying badger transaction, the blockstore must not be used afterwards
+func (txBlockstore *TxBlockstore) Discard() {
+	txBlockstore.tx.Discard()
+}
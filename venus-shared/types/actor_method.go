@@ -0,0 +1,41 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// ActorMethod describes a method exported by a builtin actor, Params and Ret are the pointer types its
+// parameters and return value decode into, they are nil for the deprecated methods
+type ActorMethod struct {
+	Name   string
+	Params reflect.Type
+	Ret    reflect.Type
+}
+
+// DecodeParams decodes the parameters of a call to the method
+func (am ActorMethod) DecodeParams(raw []byte) (interface{}, error) {
+	return am.decode(am.Params, raw)
+}
+
+// DecodeReturn decodes the return value of a call to the method
+func (am ActorMethod) DecodeReturn(raw []byte) (interface{}, error) {
+	return am.decode(am.Ret, raw)
+}
+
+func (am ActorMethod) decode(typ reflect.Type, raw []byte) (interface{}, error) {
+	if typ == nil {
+		return nil, fmt.Errorf("method %s is deprecated", am.Name)
+	}
+	v, ok := reflect.New(typ.Elem()).Interface().(cbg.CBORUnmarshaler)
+	if !ok {
+		return nil, fmt.Errorf("%s of method %s can not be decoded", typ, am.Name)
+	}
+	if err := v.UnmarshalCBOR(bytes.NewReader(raw)); err != nil {
+		return nil, fmt.Errorf("decode %s of method %s: %w", typ, am.Name, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v9/market"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestActorMethodDecode(t *testing.T) {
+	tf.UnitTest(t)
+
+	withdraw, ok := MarketMethods[builtin.MethodsMarket.WithdrawBalance]
+	require.True(t, ok)
+	require.Equal(t, "WithdrawBalance", withdraw.Name)
+
+	addr, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	params := market.WithdrawBalanceParams{
+		ProviderOrClientAddress: addr,
+		Amount:                  abi.NewTokenAmount(10),
+	}
+	var buf bytes.Buffer
+	require.NoError(t, params.MarshalCBOR(&buf))
+
+	decoded, err := withdraw.DecodeParams(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, &params, decoded)
+
+	ret := abi.NewTokenAmount(10)
+	buf.Reset()
+	require.NoError(t, ret.MarshalCBOR(&buf))
+	decoded, err = withdraw.DecodeReturn(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, &ret, decoded)
+
+	_, err = withdraw.DecodeParams([]byte{0x01})
+	require.Error(t, err)
+
+	// deprecated methods have no types
+	_, err = VerifregMethods[5].DecodeParams(nil)
+	require.Error(t, err)
+}
@@ -1,13 +1,17 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-bitfield"
 	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/dline"
+	"github.com/filecoin-project/go-state-types/network"
 	"github.com/ipfs/go-cid"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -43,6 +47,59 @@ type ObjStat struct {
 	Links uint64
 }
 
+// ObjStatWithDepth is the ObjStat of a dag walked down to a depth limit
+type ObjStatWithDepth struct {
+	ObjStat
+	// Truncated is set when some links were not followed because of the depth limit
+	Truncated bool
+}
+
+// StagedObjStat counts the objects staged in the blockstore through the api, against the quota of the datastore
+// config
+type StagedObjStat struct {
+	Count int64
+	Bytes int64
+	// Quota is the bytes the staged objects may use, 0 is unlimited
+	Quota int64
+}
+
+// DatastoreGCResult reports a value log garbage collection of the blockstore
+type DatastoreGCResult struct {
+	// Rewritten is the number of value log files rewritten
+	Rewritten int
+	// SizeBefore and SizeAfter are the bytes of the value log files
+	SizeBefore int64
+	SizeAfter  int64
+	Reclaimed  int64
+	Duration   time.Duration
+}
+
+// DatastoreScrubOptions sets how the blockstore is scrubbed
+type DatastoreScrubOptions struct {
+	// BytesPerSecond limits the reads of the scrub, 0 is unlimited
+	BytesPerSecond int64
+	// Repair fetches the corrupt blocks from the peers to replace them
+	Repair bool
+}
+
+// DatastoreScrubProgress reports a scrub of the blockstore, the blocks are hashed again and compared with their cid
+type DatastoreScrubProgress struct {
+	// Checked is the number of blocks hashed again and Bytes their size
+	Checked int64
+	Bytes   int64
+	Largest int64
+	// Skipped is the number of blocks whose hash function is not supported
+	Skipped int64
+	// Corrupt is the number of corrupt blocks, CorruptCids the first of them
+	Corrupt     int64
+	CorruptCids []cid.Cid
+	Repaired    int64
+	Duration    time.Duration
+	// Done is set on the last progress of the scrub, with Error when it failed
+	Done  bool
+	Error string `json:",omitempty"`
+}
+
 // ChainMessage is an on-chain message with its block and receipt.
 type ChainMessage struct { //nolint
 	TS      *TipSet
@@ -64,6 +121,42 @@ type MessageCID struct {
 	Message *Message
 }
 
+// ResolvedMessage is a message of a tipset with the ID addresses of its sender and receiver, resolved in the state
+// the message is applied on. ToID is undef when the receiver has no actor yet.
+type ResolvedMessage struct {
+	Cid     cid.Cid
+	Message *Message
+	FromID  address.Address
+	ToID    address.Address
+}
+
+type FinalitySource string
+
+// FinalityEC finalizes the tipsets ChainFinality epochs below the head, the expected consensus finality
+const FinalityEC FinalitySource = "ec"
+
+// FinalizedHead is the latest tipset the node considers final, from the head it is computed from
+type FinalizedHead struct {
+	TipSet *TipSet
+	Source FinalitySource
+	Head   TipSetKey
+	// Depth is the number of epochs between the finalized tipset and the head
+	Depth abi.ChainEpoch
+}
+
+// ActorCodeName is the builtin actor of a code, eg. storageminer v12. Networks are those of the embedded bundles
+// the code is in.
+type ActorCodeName struct {
+	Code     cid.Cid
+	Name     string
+	Version  actorstypes.Version
+	Networks []string
+}
+
+func (a *ActorCodeName) String() string {
+	return fmt.Sprintf("%s v%d", a.Name, a.Version)
+}
+
 type ActorState struct {
 	Balance BigInt
 	Code    cid.Cid
@@ -102,6 +195,19 @@ type PubsubScore struct {
 	Score *pubsub.PeerScoreSnapshot
 }
 
+// PubsubTopic is the state of a pubsub topic seen by the node
+type PubsubTopic struct {
+	Topic string
+	// Peers subscribed to the topic
+	Peers []peer.ID
+	// Mesh is the peers the messages of the topic are exchanged with
+	Mesh []peer.ID
+	// messages delivered, rejected or ignored, and dropped as duplicates since the node started
+	Delivered uint64
+	Rejected  uint64
+	Duplicate uint64
+}
+
 type Partition struct {
 	AllSectors        bitfield.BitField
 	FaultySectors     bitfield.BitField
@@ -118,6 +224,41 @@ type Fault struct {
 type MessageMatch struct {
 	To   address.Address
 	From address.Address
+
+	// Address matches the messages of an address in the Direction, along with To and From
+	Address   address.Address  `json:",omitempty"`
+	Direction MessageDirection `json:",omitempty"`
+	// Methods matches the messages calling one of the methods, any method when empty
+	Methods []abi.MethodNum `json:",omitempty"`
+	// MinValue and MaxValue bound the value of the messages, both included, nil is unbounded
+	MinValue *abi.TokenAmount `json:",omitempty"`
+	MaxValue *abi.TokenAmount `json:",omitempty"`
+	// MaxHeight skips the tipsets above it when not nil
+	MaxHeight *abi.ChainEpoch `json:",omitempty"`
+}
+
+// MessageDirection is how MessageMatch.Address takes part in the messages
+type MessageDirection string
+
+const (
+	// MessageDirectionBoth matches the messages sent or received by the address
+	MessageDirectionBoth MessageDirection = ""
+	// MessageDirectionIn matches the messages received by the address
+	MessageDirectionIn MessageDirection = "in"
+	// MessageDirectionOut matches the messages sent by the address
+	MessageDirectionOut MessageDirection = "out"
+)
+
+// MatchedMessage is a message found by StateListMatchedMessages along with the tipset including it
+type MatchedMessage struct {
+	Cid     cid.Cid
+	Height  abi.ChainEpoch
+	TipSet  TipSetKey
+	Message *Message
+	// DecodedParams are the params decoded against the recipient actor, when asked for
+	DecodedParams interface{} `json:",omitempty"`
+	// DecodeErr is why the params could not be decoded
+	DecodeErr string `json:",omitempty"`
 }
 
 type MsigTransaction struct {
@@ -169,6 +310,20 @@ type Deadline struct {
 	DisputableProofCount uint64
 }
 
+// PartitionProvingWindow is the challenge window in which the window post of a partition is accepted
+type PartitionProvingWindow struct {
+	Index       uint64
+	Open        abi.ChainEpoch // first epoch from which a proof may be submitted
+	Close       abi.ChainEpoch // first epoch from which a proof may no longer be submitted
+	FaultCutoff abi.ChainEpoch // first epoch from which fault declarations for the partition are rejected
+}
+
+// ProvingDeadline is the current deadline of a miner along with the proving windows of its partitions
+type ProvingDeadline struct {
+	dline.Info
+	Partitions []PartitionProvingWindow
+}
+
 var MarketBalanceNil = MarketBalance{}
 
 type MarketDealState struct {
@@ -214,6 +369,80 @@ type MarketDeal struct {
 	State    MarketDealState
 }
 
+type DealUpdateType string
+
+const (
+	DealActivated DealUpdateType = "activated"
+	DealSlashed   DealUpdateType = "slashed"
+	DealExpired   DealUpdateType = "expired"
+)
+
+// DealUpdate is a change of the on chain state of a deal
+type DealUpdate struct {
+	DealID abi.DealID
+	Type   DealUpdateType
+	// Reverted is set when the change is undone by a reorg
+	Reverted bool
+	// the tipset which parent state holds the change
+	TipSet TipSetKey
+	Height abi.ChainEpoch
+	// nil once the deal is removed from the market actor
+	Deal *MarketDeal
+}
+
+// ChainGCStats counts the orphaned objects found by the chain garbage collection
+type ChainGCStats struct {
+	Tipsets    int
+	Blocks     int
+	StateRoots int
+	// Bytes is the size of the blocks and state roots
+	Bytes int64
+}
+
+// ChainGCStatus is the state of the garbage collection of the orphaned chain branches
+type ChainGCStatus struct {
+	Enabled bool
+	// DryRun is set when the orphaned objects are only counted, not deleted
+	DryRun bool
+	// Depth is the number of epochs below the head the orphaned tipsets are kept
+	Depth   abi.ChainEpoch
+	Running bool
+
+	// LastRun is the end of the last collection, zero before the first one
+	LastRun   time.Time
+	LastHead  abi.ChainEpoch
+	LastError string `json:",omitempty"`
+	Last      ChainGCStats
+	// Total sums the collections since the node started
+	Total ChainGCStats
+}
+
+// ChainOffloadStats counts the chain objects moved between the local blockstore and the object storage
+type ChainOffloadStats struct {
+	Tipsets int
+	Objects int
+	Bytes   int64
+}
+
+// ChainOffloadStatus is the state of the offload of the chain data to the object storage
+type ChainOffloadStatus struct {
+	Enabled bool
+	// Depth is the number of epochs below the head the chain data is kept locally
+	Depth   abi.ChainEpoch
+	Running bool
+	// OffloadedHeight is the height up to which the chain data is offloaded
+	OffloadedHeight abi.ChainEpoch
+	// ColdReads is the number of objects read back from the object storage since the node started
+	ColdReads int64
+
+	// LastRun is the end of the last offload, zero before the first one
+	LastRun   time.Time
+	LastError string `json:",omitempty"`
+	Last      ChainOffloadStats
+	// Total sums the offloads since the node started
+	Total ChainOffloadStats
+}
+
 type MinerPower struct {
 	MinerPower  power.Claim
 	TotalPower  power.Claim
@@ -239,6 +468,47 @@ type DealCollateralBounds struct {
 	Max abi.TokenAmount
 }
 
+// SectorBatchKind is the kind of sector messages a batch aggregates
+type SectorBatchKind string
+
+const (
+	SectorBatchPreCommit   SectorBatchKind = "precommit"
+	SectorBatchProveCommit SectorBatchKind = "provecommit"
+)
+
+// SectorBatchEstimate is the cost of a batch of sector messages at a tipset
+type SectorBatchEstimate struct {
+	Kind           SectorBatchKind
+	NetworkVersion network.Version
+	Size           int
+	// MinSize and MaxSize bound the number of sectors of a batch
+	MinSize int
+	MaxSize int
+	// MaxProofSize is the largest aggregate proof accepted, 0 for the precommit batches
+	MaxProofSize int
+	BaseFee      abi.TokenAmount
+	// NetworkFee is burnt by the batch message on top of its gas
+	NetworkFee          abi.TokenAmount
+	NetworkFeePerSector abi.TokenAmount
+}
+
+// SectorCollateral is the collateral of a sector which would be sealed at a tipset
+type SectorCollateral struct {
+	// PreCommitDeposit and InitialPledge are the amounts the node estimates for the messages, with a margin
+	PreCommitDeposit abi.TokenAmount
+	InitialPledge    abi.TokenAmount
+	// QAPower is the quality adjusted power of the sector
+	QAPower abi.StoragePower
+	// ExpectedDayReward and ExpectedStoragePledge are the rewards the sector is expected to earn in a day and in the
+	// initial pledge projection period
+	ExpectedDayReward     abi.TokenAmount
+	ExpectedStoragePledge abi.TokenAmount
+	// TerminationPenalty is the fee burnt when the sector is terminated right after its activation,
+	// MaxTerminationPenalty when it is terminated at its expiration or at the lifetime cap of the penalty
+	TerminationPenalty    abi.TokenAmount
+	MaxTerminationPenalty abi.TokenAmount
+}
+
 type MsgLookup struct {
 	Message   cid.Cid // Can be different than requested, in case it was replaced, but only gas values changed
 	Receipt   MessageReceipt
@@ -270,6 +540,21 @@ type BlockTemplate struct {
 	WinningPoStProof []builtin.PoStProof
 }
 
+// DataCapEntry is the allowance of a verifier or the datacap of a verified client, in bytes
+type DataCapEntry struct {
+	Address address.Address
+	DataCap abi.StoragePower
+}
+
+// MinedBlock reports a block produced by the devnet mining
+type MinedBlock struct {
+	Block  cid.Cid
+	Height abi.ChainEpoch
+	// NullRounds is the number of rounds the miner lost before the block, they are left empty in the chain
+	NullRounds abi.ChainEpoch
+	Messages   int
+}
+
 type EstimateMessage struct {
 	Msg  *Message
 	Spec *MessageSendSpec
@@ -284,6 +569,52 @@ type MessageSendSpec struct {
 	MaxFee            abi.TokenAmount
 	GasOverEstimation float64
 	GasOverPremium    float64
+	// Preview executes the message on the head state before it is signed, see ExecPreview
+	Preview ExecPreview `json:",omitempty"`
+}
+
+// UnmarshalJSON decodes MaxFee with ParseBigInt, as the amounts of a Message, an absent MaxFee is left unset
+func (spec *MessageSendSpec) UnmarshalJSON(b []byte) error {
+	type rawSpec MessageSendSpec
+	aux := struct {
+		*rawSpec
+		MaxFee json.RawMessage
+	}{
+		rawSpec: (*rawSpec)(spec),
+	}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	if aux.MaxFee == nil {
+		return nil
+	}
+	maxFee, err := ParseBigInt(string(aux.MaxFee))
+	if err != nil {
+		return fmt.Errorf("decoding max fee: %w", err)
+	}
+	spec.MaxFee = maxFee
+	return nil
+}
+
+// ExecPreview is what a push does when the preview of the execution of its message fails
+type ExecPreview string
+
+const (
+	// ExecPreviewOff does not preview the execution
+	ExecPreviewOff ExecPreview = ""
+	// ExecPreviewWarn logs the failures and pushes the message anyway
+	ExecPreviewWarn ExecPreview = "warn"
+	// ExecPreviewReject rejects the messages whose execution fails
+	ExecPreviewReject ExecPreview = "reject"
+)
+
+// Validate checks that p is a known mode
+func (p ExecPreview) Validate() error {
+	switch p {
+	case ExecPreviewOff, ExecPreviewWarn, ExecPreviewReject:
+		return nil
+	}
+	return fmt.Errorf("unknown exec preview %q, expected %s or %s", p, ExecPreviewWarn, ExecPreviewReject)
 }
 
 // Version provides various build-time information
@@ -375,14 +706,20 @@ type ActiveSync struct {
 }
 
 type Target struct {
-	State   SyncStateStage
+	State SyncStateStage
+	// Stage is the step of a target in syncing: StageHeaders, StageFetchingMessages or StageMessages
+	Stage   SyncStateStage
 	Base    *TipSet
 	Current *TipSet
+	// Fetched is the highest tipset whose messages are fetched
+	Fetched *TipSet
 	Start   time.Time
 	End     time.Time
 	Err     error
 	Head    *TipSet
 	Sender  peer.ID
+	// Preempted is set when the sync is canceled for a heavier target
+	Preempted bool
 }
 
 func (target *Target) String() string {
@@ -394,6 +731,109 @@ type TargetTracker struct {
 	Buckets []*Target
 }
 
+// ConsensusFaultType is the kind of a consensus fault of a miner
+type ConsensusFaultType string
+
+const (
+	// DoubleForkMiningFault is two blocks of a miner at one epoch
+	DoubleForkMiningFault ConsensusFaultType = "double-fork mining"
+	// TimeOffsetMiningFault is two blocks of a miner with the same parents
+	TimeOffsetMiningFault ConsensusFaultType = "time-offset mining"
+	// ParentGrindingFault is a block of a miner omitting its own block at the parent epoch from its parents
+	ParentGrindingFault ConsensusFaultType = "parent-grinding"
+)
+
+// ConsensusFault is a consensus fault found in the incoming blocks by the consensus fault reporter
+type ConsensusFault struct {
+	Type  ConsensusFaultType
+	Miner address.Address
+	Epoch abi.ChainEpoch
+	// Block1 is the block seen first, Block2 the one revealing the fault
+	Block1 cid.Cid
+	Block2 cid.Cid
+	// Extra is the witness of a parent-grinding fault, a sibling of Block1 in the parents of Block2
+	Extra *cid.Cid `json:",omitempty"`
+	Found time.Time
+	// Message is the ReportConsensusFault message once it is pushed to the message pool
+	Message *cid.Cid `json:",omitempty"`
+	// Err is why the fault could not be reported
+	Err string `json:",omitempty"`
+}
+
+// BlockRejectReason is why a submitted block was not sent to the network
+type BlockRejectReason string
+
+const (
+	// BlockInvalid is a block failing validation
+	BlockInvalid BlockRejectReason = "invalid"
+	// BlockEquivocation is a block of a miner which has another block at the same epoch among the blocks seen
+	BlockEquivocation BlockRejectReason = "equivocation"
+	// BlockSlashable is a block which the slash filter finds would make the miner commit a consensus fault
+	BlockSlashable BlockRejectReason = "slashable"
+)
+
+// BlockRejection is a reason for refusing to submit a block
+type BlockRejection struct {
+	Reason BlockRejectReason
+	// Fault is the consensus fault the block would be slashed for
+	Fault ConsensusFaultType `json:",omitempty"`
+	// Witness is the other block of the miner revealing the fault
+	Witness *cid.Cid `json:",omitempty"`
+	Detail  string   `json:",omitempty"`
+}
+
+func (r *BlockRejection) String() string {
+	s := string(r.Reason)
+	if r.Fault != "" {
+		s += fmt.Sprintf(" (%s)", r.Fault)
+	}
+	if r.Witness != nil {
+		s += fmt.Sprintf(" with block %s", r.Witness)
+	}
+	if r.Detail != "" {
+		s += ": " + r.Detail
+	}
+	return s
+}
+
+// SubmitBlockResult is the outcome of SyncSubmitBlockChecked, Rejections is empty when the block was submitted
+type SubmitBlockResult struct {
+	Block      cid.Cid
+	Submitted  bool
+	Rejections []*BlockRejection
+}
+
+// BadTipSet is a tipset the syncer refuses to sync, and the chains built on it
+type BadTipSet struct {
+	Key TipSetKey
+	// Height is unknown, 0, for the tipsets marked by an operator
+	Height abi.ChainEpoch
+	Reason string
+	// Manual is set for the tipsets marked by an operator, which do not expire
+	Manual bool `json:",omitempty"`
+	Added  time.Time
+	// Expires is when the tipset is dropped from the cache and tried again, zero for never
+	Expires time.Time
+}
+
+// ForkAlert is a head competing with the local chain from a fork at least as deep as the alarm depth
+type ForkAlert struct {
+	Head   TipSetKey
+	Height abi.ChainEpoch
+	// Weight and LocalWeight are the parent weights of the heads
+	Weight      BigInt
+	LocalHead   TipSetKey
+	LocalHeight abi.ChainEpoch
+	LocalWeight BigInt
+	// Base is the common ancestor of the heads, empty when it is below the finality window
+	Base TipSetKey
+	// Depth is the number of epochs both branches have above the base
+	Depth abi.ChainEpoch
+	// Peers are the peers which sent the head
+	Peers []peer.ID
+	Found time.Time
+}
+
 type MsgGasCost struct {
 	Message            cid.Cid // Can be different than requested, in case it was replaced, but only gas values changed
 	GasUsed            abi.TokenAmount
@@ -441,6 +881,24 @@ type NetworkParams struct {
 	PreCommitChallengeDelay abi.ChainEpoch
 	ForkUpgradeParams       ForkUpgradeParams
 	Eip155ChainID           int
+	// SectorSizes are the sizes of the SupportedProofTypes
+	SectorSizes []abi.SectorSize
+	// NetworkVersions are the network versions the chain goes through, in order, those of the disabled upgrades left out
+	NetworkVersions []NetworkVersionEpoch
+}
+
+// NetworkVersionEpoch is a network version and the first epoch it applies to, the epoch after its upgrade
+type NetworkVersionEpoch struct {
+	Version network.Version
+	Epoch   abi.ChainEpoch
+}
+
+// NetworkUpgrade is a network version of the upgrade schedule, with the builtin actors it runs
+type NetworkUpgrade struct {
+	NetworkVersionEpoch
+	ActorsVersion actorstypes.Version
+	// Manifest is the cid of the bundle of the builtin actors, undef for the actors versions before the bundles
+	Manifest cid.Cid
 }
 
 type ForkUpgradeParams struct {
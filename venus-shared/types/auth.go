@@ -0,0 +1,23 @@
+package types
+
+import (
+	"time"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+)
+
+// AuthTokenInfo describes a named api token issued by the node, the token itself is never stored
+type AuthTokenInfo struct {
+	Name  string
+	Perms []auth.Permission
+	// CreatedAt is the time the token was issued
+	CreatedAt time.Time
+	// ExpiresAt is the time after which the token is rejected, zero value means the token never expires
+	ExpiresAt time.Time
+	Revoked   bool
+}
+
+// Expired returns whether the token is expired at the given time
+func (info *AuthTokenInfo) Expired(now time.Time) bool {
+	return !info.ExpiresAt.IsZero() && !now.Before(info.ExpiresAt)
+}
@@ -24,4 +24,5 @@ var (
 	BigMul        = types.BigMul
 	BigSub        = types.BigSub
 	NewInt        = types.NewInt
+	ParseBigInt   = types.ParseBigInt
 )
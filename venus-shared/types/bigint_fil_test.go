@@ -268,3 +268,80 @@ func TestTextUnMarshal(t *testing.T) {
 		require.Equal(t, a.Fil.String(), s.expect.String())
 	}
 }
+
+func TestParseFILForms(t *testing.T) {
+	tf.UnitTest(t)
+	for in, expect := range map[string]string{
+		"1.5":           "1500000000000000000",
+		" 2 FIL ":       "2000000000000000000",
+		"1e-18 FIL":     "1",
+		"1.5e3 fil":     "1500000000000000000000",
+		"1e18 aFIL":     "1000000000000000000",
+		"-2E-1":         "-200000000000000000",
+		"+3 attoFIL":    "3",
+		"0.000000001e9": "1000000000000000000",
+	} {
+		f, err := ParseFIL(in)
+		require.NoError(t, err, in)
+		require.Equal(t, expect, f.Int.String(), in)
+	}
+
+	for _, in := range []string{
+		"1e-19 FIL", "0.5 aFIL", "1e FIL", "1e51", "1/2", "0x10", "e5", "1.2.3e4",
+	} {
+		_, err := ParseFIL(in)
+		require.Error(t, err, in)
+	}
+	_, err := ParseFIL("0.0000000000000000001")
+	require.ErrorContains(t, err, "more precise than an attoFIL")
+}
+
+func TestFILUnmarshalJSONForms(t *testing.T) {
+	tf.UnitTest(t)
+	type A struct {
+		Fil FIL
+	}
+	for in, expect := range map[string]string{
+		`{"Fil": "1 FIL"}`:         "1000000000000000000",
+		`{"Fil": 1.5}`:             "1500000000000000000",
+		`{"Fil": 1e-18}`:           "1",
+		`{"Fil": "25e17 attoFIL"}`: "2500000000000000000",
+		// far beyond the precision of a float64
+		`{"Fil": 123456789.123456789123456789}`: "123456789123456789123456789",
+	} {
+		var a A
+		require.NoError(t, json.Unmarshal([]byte(in), &a), in)
+		require.Equal(t, expect, a.Fil.Int.String(), in)
+	}
+
+	var a A
+	require.NoError(t, json.Unmarshal([]byte(`{"Fil": null}`), &a))
+	require.Equal(t, "0 FIL", a.Fil.String())
+	for _, in := range []string{`{"Fil": 1e-19}`, `{"Fil": true}`, `{"Fil": "1 nFIL"}`} {
+		require.Error(t, json.Unmarshal([]byte(in), &a), in)
+	}
+}
+
+func FuzzParseFIL(f *testing.F) {
+	for _, seed := range []string{
+		"0", "1 FIL", "1.5", "-0.001 FIL", "1e18 aFIL", "1.5E-3 fil", "+2 attoFIL", "1e-19", "1e51", "0x10", "",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		fil, err := ParseFIL(s)
+		if err != nil {
+			return
+		}
+		// what is parsed always round trips through its string and json forms
+		again, err := ParseFIL(fil.String())
+		require.NoError(t, err, s)
+		require.Equal(t, fil.Int.String(), again.Int.String(), s)
+
+		data, err := json.Marshal(fil)
+		require.NoError(t, err)
+		var out FIL
+		require.NoError(t, json.Unmarshal(data, &out), s)
+		require.Equal(t, fil.Int.String(), out.Int.String(), s)
+	})
+}
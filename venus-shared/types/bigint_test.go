@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"encoding/json"
 	"testing"
 
 	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
@@ -92,3 +93,68 @@ func TestBigIntCalculating(t *testing.T) {
 		require.True(t, BigMod(abs, BigAdd(abs, NewInt(1))).Equals(abs))
 	}
 }
+
+func TestParseBigInt(t *testing.T) {
+	tf.UnitTest(t)
+	for in, expect := range map[string]string{
+		"1000":                  "1000",
+		`"1000"`:                "1000",
+		" -12 ":                 "-12",
+		"1e18":                  "1000000000000000000",
+		`"1.5E3"`:               "1500",
+		"123456789012345678e10": "1234567890123456780000000000",
+	} {
+		bi, err := ParseBigInt(in)
+		require.NoError(t, err, in)
+		require.Equal(t, expect, bi.String(), in)
+	}
+
+	for _, in := range []string{"1.5", "1e-1", "1e51", "25e-1e0", "abc", "0x10", "1/1", `""`, ""} {
+		_, err := ParseBigInt(in)
+		require.Error(t, err, in)
+	}
+	_, err := ParseBigInt("2.5e0")
+	require.ErrorContains(t, err, "lose precision")
+}
+
+func TestMessageSendSpecMaxFeeJSON(t *testing.T) {
+	tf.UnitTest(t)
+
+	for _, maxFee := range []string{`"1000000000000000000"`, `"1e18"`, `"1.0E18"`, `1e18`, `1000000000000000000`} {
+		var spec MessageSendSpec
+		require.NoError(t, json.Unmarshal([]byte(`{"MaxFee":`+maxFee+`,"GasOverEstimation":1.25}`), &spec), maxFee)
+		require.True(t, spec.MaxFee.Equals(NewInt(1e18)), maxFee)
+		require.Equal(t, 1.25, spec.GasOverEstimation)
+	}
+
+	// an absent max fee is left unset, the fractional ones are refused rather than rounded
+	var spec MessageSendSpec
+	require.NoError(t, json.Unmarshal([]byte(`{"GasOverPremium":2}`), &spec))
+	require.True(t, spec.MaxFee.Nil())
+	require.Equal(t, 2.0, spec.GasOverPremium)
+	require.Error(t, json.Unmarshal([]byte(`{"MaxFee":"1.5"}`), &spec))
+
+	// the encoded spec decodes to itself
+	in := MessageSendSpec{MaxFee: NewInt(12345), GasOverEstimation: 1.5, Preview: ExecPreviewWarn}
+	b, err := json.Marshal(in)
+	require.NoError(t, err)
+	var out MessageSendSpec
+	require.NoError(t, json.Unmarshal(b, &out))
+	require.Equal(t, in, out)
+}
+
+func FuzzParseBigInt(f *testing.F) {
+	for _, seed := range []string{"0", "-1", `"42"`, "1e18", "1.5E3", "1.5", "1e51", "0b11", ""} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		bi, err := ParseBigInt(s)
+		if err != nil {
+			return
+		}
+		// what is parsed is an integer its decimal form parses back to
+		again, err := BigFromString(bi.String())
+		require.NoError(t, err, s)
+		require.True(t, bi.Equals(again), s)
+	})
+}
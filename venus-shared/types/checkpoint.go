@@ -0,0 +1,62 @@
+package types
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// Checkpoint is a recent tipset a trusted service vouches for, the nodes bootstrap from it without validating the
+// chain before it
+type Checkpoint struct {
+	TipSetKey TipSetKey
+	Height    abi.ChainEpoch
+	// StateRoot is the state after the messages of the tipset are executed
+	StateRoot cid.Cid
+	// Snapshot is the url of a chain export holding the tipset and its state
+	Snapshot string
+}
+
+// SigningBytes are the bytes of the checkpoint the signer signs, the CBOR encoding of the tuple (TipSetKey, Height,
+// StateRoot, Snapshot), so that the signature does not depend on how a service encodes the checkpoint
+func (c *Checkpoint) SigningBytes() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	cw := cbg.NewCborWriter(buf)
+	if err := cw.WriteMajorTypeHeader(cbg.MajArray, 4); err != nil {
+		return nil, err
+	}
+	if err := c.TipSetKey.MarshalCBOR(cw); err != nil {
+		return nil, err
+	}
+	if c.Height >= 0 {
+		if err := cw.WriteMajorTypeHeader(cbg.MajUnsignedInt, uint64(c.Height)); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := cw.WriteMajorTypeHeader(cbg.MajNegativeInt, uint64(-c.Height-1)); err != nil {
+			return nil, err
+		}
+	}
+	if err := cbg.WriteCid(cw, c.StateRoot); err != nil {
+		return nil, err
+	}
+	if err := cw.WriteMajorTypeHeader(cbg.MajTextString, uint64(len(c.Snapshot))); err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(cw, c.Snapshot); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SignedCheckpoint is the checkpoint served by the trusted services
+type SignedCheckpoint struct {
+	Checkpoint
+	Signer    address.Address
+	Signature crypto.Signature
+}
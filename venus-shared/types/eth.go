@@ -54,6 +54,12 @@ type (
 	EthTrace                       = types.EthTrace
 	EthTraceBlock                  = types.EthTraceBlock
 	EthTraceReplayBlockTransaction = types.EthTraceReplayBlockTransaction
+	EthTraceTransaction            = types.EthTraceTransaction
+	EthTraceConfig                 = types.EthTraceConfig
+	EthDebugTraceParams            = types.EthDebugTraceParams
+	EthCallTracerConfig            = types.EthCallTracerConfig
+	EthCallFrame                   = types.EthCallFrame
+	EthTxTraceResult               = types.EthTxTraceResult
 	EthTxReceipt                   = types.EthTxReceipt
 	EthUint64                      = types.EthUint64
 )
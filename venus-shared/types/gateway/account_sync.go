@@ -0,0 +1,135 @@
+package gateway
+
+import (
+	"sort"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+)
+
+// AccountBinding is what the auth service binds to an account, the requests of its miners and signers are routed to
+// the connections of the account
+type AccountBinding struct {
+	Account string
+	Miners  []address.Address
+	Signers []address.Address
+}
+
+type AccountChangeType string
+
+const (
+	AccountAdded   AccountChangeType = "added"
+	AccountRemoved AccountChangeType = "removed"
+	AccountUpdated AccountChangeType = "updated"
+)
+
+// AccountChange is a change of the bindings of an account found by a synchronization with the auth service
+type AccountChange struct {
+	Account        string
+	Type           AccountChangeType
+	AddedMiners    []address.Address `json:",omitempty"`
+	RemovedMiners  []address.Address `json:",omitempty"`
+	AddedSigners   []address.Address `json:",omitempty"`
+	RemovedSigners []address.Address `json:",omitempty"`
+	Time           time.Time
+}
+
+// AccountSyncResult is the outcome of a synchronization of the account bindings
+type AccountSyncResult struct {
+	Started  time.Time
+	Finished time.Time
+	// Accounts is the number of accounts bound by the auth service
+	Accounts int
+	Changes  []*AccountChange
+}
+
+// AccountSyncState is the state of the synchronization of the account bindings with the auth service
+type AccountSyncState struct {
+	Enabled  bool
+	Interval time.Duration
+	// LastSync is the last synchronization which succeeded, nil when none did yet
+	LastSync  *AccountSyncResult
+	LastError string `json:",omitempty"`
+	// LastErrorTime is when the last synchronization failed, zero when it succeeded
+	LastErrorTime time.Time
+	NextSync      time.Time
+}
+
+// AccountSyncConfig sets how the gateway synchronizes the account bindings with the auth service, the bindings
+// are only those of the connections of the clients when it is disabled
+type AccountSyncConfig struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+const DefaultAccountSyncInterval = 5 * time.Minute
+
+func DefaultAccountSyncConfig() AccountSyncConfig {
+	return AccountSyncConfig{
+		Enabled:  true,
+		Interval: DefaultAccountSyncInterval,
+	}
+}
+
+// DiffAccountBindings returns the changes from the bindings prev to next, ordered by account
+func DiffAccountBindings(prev, next []*AccountBinding, now time.Time) []*AccountChange {
+	byAccount := make(map[string]*AccountBinding, len(prev))
+	for _, b := range prev {
+		byAccount[b.Account] = b
+	}
+
+	var changes []*AccountChange
+	for _, b := range next {
+		old, ok := byAccount[b.Account]
+		delete(byAccount, b.Account)
+		if !ok {
+			changes = append(changes, &AccountChange{
+				Account:      b.Account,
+				Type:         AccountAdded,
+				AddedMiners:  b.Miners,
+				AddedSigners: b.Signers,
+				Time:         now,
+			})
+			continue
+		}
+
+		change := &AccountChange{Account: b.Account, Type: AccountUpdated, Time: now}
+		change.AddedMiners, change.RemovedMiners = diffAddresses(old.Miners, b.Miners)
+		change.AddedSigners, change.RemovedSigners = diffAddresses(old.Signers, b.Signers)
+		if len(change.AddedMiners)+len(change.RemovedMiners)+len(change.AddedSigners)+len(change.RemovedSigners) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	for _, b := range byAccount {
+		changes = append(changes, &AccountChange{
+			Account:        b.Account,
+			Type:           AccountRemoved,
+			RemovedMiners:  b.Miners,
+			RemovedSigners: b.Signers,
+			Time:           now,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Account < changes[j].Account })
+	return changes
+}
+
+func diffAddresses(prev, next []address.Address) (added, removed []address.Address) {
+	had := make(map[address.Address]struct{}, len(prev))
+	for _, a := range prev {
+		had[a] = struct{}{}
+	}
+	for _, a := range next {
+		if _, ok := had[a]; ok {
+			delete(had, a)
+			continue
+		}
+		added = append(added, a)
+	}
+	for _, a := range prev {
+		if _, ok := had[a]; ok {
+			removed = append(removed, a)
+		}
+	}
+	return added, removed
+}
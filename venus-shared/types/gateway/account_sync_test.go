@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestDiffAccountBindings(t *testing.T) {
+	tf.UnitTest(t)
+
+	now := time.Unix(1_700_000_000, 0)
+	addr := func(id uint64) address.Address {
+		a, err := address.NewIDAddress(id)
+		require.NoError(t, err)
+		return a
+	}
+	m1, m2, m3 := addr(1001), addr(1002), addr(1003)
+	s1, s2 := addr(2001), addr(2002)
+
+	for _, tc := range []struct {
+		name       string
+		prev, next []*AccountBinding
+		changes    []*AccountChange
+	}{
+		{
+			name: "no bindings",
+		},
+		{
+			name: "unchanged, whatever the order of the addresses",
+			prev: []*AccountBinding{{Account: "alice", Miners: []address.Address{m1, m2}, Signers: []address.Address{s1}}},
+			next: []*AccountBinding{{Account: "alice", Miners: []address.Address{m2, m1}, Signers: []address.Address{s1}}},
+		},
+		{
+			name: "added",
+			next: []*AccountBinding{{Account: "alice", Miners: []address.Address{m1}, Signers: []address.Address{s1}}},
+			changes: []*AccountChange{
+				{Account: "alice", Type: AccountAdded, AddedMiners: []address.Address{m1}, AddedSigners: []address.Address{s1}, Time: now},
+			},
+		},
+		{
+			name: "removed",
+			prev: []*AccountBinding{{Account: "alice", Miners: []address.Address{m1}, Signers: []address.Address{s1}}},
+			changes: []*AccountChange{
+				{Account: "alice", Type: AccountRemoved, RemovedMiners: []address.Address{m1}, RemovedSigners: []address.Address{s1}, Time: now},
+			},
+		},
+		{
+			name: "updated miners and signers",
+			prev: []*AccountBinding{{Account: "alice", Miners: []address.Address{m1, m2}, Signers: []address.Address{s1}}},
+			next: []*AccountBinding{{Account: "alice", Miners: []address.Address{m2, m3}, Signers: []address.Address{s2}}},
+			changes: []*AccountChange{
+				{
+					Account:        "alice",
+					Type:           AccountUpdated,
+					AddedMiners:    []address.Address{m3},
+					RemovedMiners:  []address.Address{m1},
+					AddedSigners:   []address.Address{s2},
+					RemovedSigners: []address.Address{s1},
+					Time:           now,
+				},
+			},
+		},
+		{
+			name: "a miner moved between accounts, ordered by account",
+			prev: []*AccountBinding{
+				{Account: "carol", Miners: []address.Address{m3}},
+				{Account: "alice", Miners: []address.Address{m1}},
+			},
+			next: []*AccountBinding{
+				{Account: "bob", Miners: []address.Address{m1}},
+				{Account: "carol", Miners: []address.Address{m3}},
+			},
+			changes: []*AccountChange{
+				{Account: "alice", Type: AccountRemoved, RemovedMiners: []address.Address{m1}, Time: now},
+				{Account: "bob", Type: AccountAdded, AddedMiners: []address.Address{m1}, Time: now},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.changes, DiffAccountBindings(tc.prev, tc.next, now))
+		})
+	}
+}
@@ -0,0 +1,501 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const (
+	// DefaultEventQueueSize is the number of events waiting to be sent on a channel
+	DefaultEventQueueSize = 256
+	// DefaultBreakerFailures is the number of consecutive failures of a client which opens its circuit breaker
+	DefaultBreakerFailures = 5
+	// DefaultBreakerCooldown is how long an open circuit breaker stops forwarding before trying the client again
+	DefaultBreakerCooldown = 30 * time.Second
+)
+
+var ErrQueueClosed = errors.New("event queue closed")
+
+// QueueStrategy is what a full event queue does with a new event
+type QueueStrategy string
+
+const (
+	// QueueDropOldest drops the oldest event waiting, which is answered with a temporary error
+	QueueDropOldest QueueStrategy = "drop-oldest"
+	// QueueBlock makes the sender wait for room, until BlockTimeout
+	QueueBlock QueueStrategy = "block"
+)
+
+// BackpressureConfig bounds the events waiting on a channel and how long a failing client keeps receiving them
+type BackpressureConfig struct {
+	QueueSize int
+	Strategy  QueueStrategy
+	// BlockTimeout bounds the wait of a sender with QueueBlock, 0 waits until its context is done
+	BlockTimeout time.Duration
+	// BreakerFailures is the number of consecutive failures which opens the circuit breaker, 0 disables it
+	BreakerFailures int
+	// BreakerCooldown is how long the circuit breaker stays open before one event is sent to try the client again
+	BreakerCooldown time.Duration
+	// Prioritized sends the events of the highest priority first, it is set for the channels registered with
+	// EventPriority by ProofRegisterPolicy.Backpressure. A full queue with QueueDropOldest then drops the oldest event
+	// of the lowest priority.
+	Prioritized bool
+}
+
+// DefaultBackpressureConfig returns the default config, which drops the oldest events
+func DefaultBackpressureConfig() BackpressureConfig {
+	return BackpressureConfig{
+		QueueSize:       DefaultEventQueueSize,
+		Strategy:        QueueDropOldest,
+		BreakerFailures: DefaultBreakerFailures,
+		BreakerCooldown: DefaultBreakerCooldown,
+	}
+}
+
+// Validate checks the config
+func (c BackpressureConfig) Validate() error {
+	if c.QueueSize <= 0 {
+		return fmt.Errorf("event queue size must be positive, got %d", c.QueueSize)
+	}
+	if c.Strategy != QueueDropOldest && c.Strategy != QueueBlock {
+		return fmt.Errorf("unknown event queue strategy %q", c.Strategy)
+	}
+	if c.BlockTimeout < 0 || c.BreakerCooldown < 0 {
+		return fmt.Errorf("event queue durations must not be negative")
+	}
+	if c.BreakerFailures < 0 {
+		return fmt.Errorf("circuit breaker failures must not be negative, got %d", c.BreakerFailures)
+	}
+	return nil
+}
+
+// EventQueueState shows the events waiting on a channel
+type EventQueueState struct {
+	Strategy QueueStrategy
+	// Prioritized is set when the events are sent by priority
+	Prioritized bool `json:",omitempty"`
+	Size        int
+	Len         int
+	// Dropped is the number of events dropped with QueueDropOldest
+	Dropped uint64
+	// Blocked is the number of sends which waited for room with QueueBlock, TimedOut the ones which gave up
+	Blocked  uint64
+	TimedOut uint64
+}
+
+// EventQueue is a bounded queue of the events sent on a channel, the sender is not held up by a slow client beyond
+// what the strategy allows
+type EventQueue struct {
+	cfg BackpressureConfig
+
+	lk       sync.Mutex
+	events   []*RequestEvent
+	closed   bool
+	state    EventQueueState
+	notEmpty chan struct{}
+	notFull  chan struct{}
+	done     chan struct{}
+}
+
+// NewEventQueue creates a queue of the size and the strategy of cfg
+func NewEventQueue(cfg BackpressureConfig) *EventQueue {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultEventQueueSize
+	}
+	if cfg.Strategy == "" {
+		cfg.Strategy = QueueDropOldest
+	}
+	return &EventQueue{
+		cfg:      cfg,
+		state:    EventQueueState{Strategy: cfg.Strategy, Prioritized: cfg.Prioritized, Size: cfg.QueueSize},
+		notEmpty: make(chan struct{}, 1),
+		notFull:  make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// Push queues the event. A full queue drops its oldest event with QueueDropOldest, or with Prioritized the oldest of
+// the lowest priority, which is req when the events queued all have a higher priority. With QueueBlock it waits for
+// room, and fails with a temporary error when the block timeout or ctx ends first.
+func (q *EventQueue) Push(ctx context.Context, req *RequestEvent) error {
+	var timeout <-chan time.Time
+	blocked := false
+	for {
+		q.lk.Lock()
+		if q.closed {
+			q.lk.Unlock()
+			return ErrQueueClosed
+		}
+		if len(q.events) < q.cfg.QueueSize || q.cfg.Strategy == QueueDropOldest {
+			var dropped *RequestEvent
+			if len(q.events) == q.cfg.QueueSize {
+				q.state.Dropped++
+				if idx := q.dropIndex(); !q.cfg.Prioritized || q.events[idx].Priority <= req.Priority {
+					dropped = q.remove(idx)
+				} else {
+					q.lk.Unlock()
+					failEvent(req, NewResponseError(ErrCodeTemporary, "event dropped, the queue of the channel is full"))
+					return nil
+				}
+			}
+			q.events = append(q.events, req)
+			if len(q.events) < q.cfg.QueueSize {
+				signal(q.notFull)
+			}
+			q.lk.Unlock()
+			signal(q.notEmpty)
+			if dropped != nil {
+				failEvent(dropped, NewResponseError(ErrCodeTemporary, "event dropped, the queue of the channel is full"))
+			}
+			return nil
+		}
+		if !blocked {
+			blocked = true
+			q.state.Blocked++
+			if q.cfg.BlockTimeout > 0 {
+				timer := time.NewTimer(q.cfg.BlockTimeout)
+				defer timer.Stop()
+				timeout = timer.C
+			}
+		}
+		q.lk.Unlock()
+
+		select {
+		case <-q.notFull:
+		case <-q.done:
+		case <-timeout:
+			q.timedOut()
+			return NewResponseError(ErrCodeTemporary, "the queue of the channel is full")
+		case <-ctx.Done():
+			q.timedOut()
+			return NewResponseError(ErrorCodeOf(ctx.Err()), "the queue of the channel is full: %v", ctx.Err())
+		}
+	}
+}
+
+// dropIndex returns the index of the event a full queue drops, the oldest one or with Prioritized the oldest of the
+// lowest priority
+func (q *EventQueue) dropIndex() int {
+	idx := 0
+	if q.cfg.Prioritized {
+		for i, ev := range q.events {
+			if ev.Priority < q.events[idx].Priority {
+				idx = i
+			}
+		}
+	}
+	return idx
+}
+
+// popIndex returns the index of the event sent next, the oldest one or with Prioritized the oldest of the highest
+// priority
+func (q *EventQueue) popIndex() int {
+	idx := 0
+	if q.cfg.Prioritized {
+		for i, ev := range q.events {
+			if ev.Priority > q.events[idx].Priority {
+				idx = i
+			}
+		}
+	}
+	return idx
+}
+
+func (q *EventQueue) remove(idx int) *RequestEvent {
+	req := q.events[idx]
+	if idx == 0 {
+		q.events[0] = nil
+		q.events = q.events[1:]
+		return req
+	}
+	copy(q.events[idx:], q.events[idx+1:])
+	q.events[len(q.events)-1] = nil
+	q.events = q.events[:len(q.events)-1]
+	return req
+}
+
+func (q *EventQueue) timedOut() {
+	q.lk.Lock()
+	q.state.TimedOut++
+	q.lk.Unlock()
+}
+
+// failEvent answers the request with err, without waiting for its caller
+func failEvent(req *RequestEvent, err error) {
+	if req.Result == nil {
+		return
+	}
+	select {
+	case req.Result <- NewResponseEvent(req.ID, nil, err):
+	default:
+	}
+}
+
+// Pop returns the oldest event, or with Prioritized the oldest of the highest priority, waiting for one until ctx is
+// done. The events left are returned after Close, then
+// it fails with ErrQueueClosed.
+func (q *EventQueue) Pop(ctx context.Context) (*RequestEvent, error) {
+	for {
+		q.lk.Lock()
+		if len(q.events) > 0 {
+			req := q.remove(q.popIndex())
+			if len(q.events) > 0 {
+				signal(q.notEmpty)
+			}
+			q.lk.Unlock()
+			signal(q.notFull)
+			return req, nil
+		}
+		if q.closed {
+			q.lk.Unlock()
+			return nil, ErrQueueClosed
+		}
+		q.lk.Unlock()
+
+		select {
+		case <-q.notEmpty:
+		case <-q.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Forward sends the events of the queue to out until ctx is done or the queue is closed and empty
+func (q *EventQueue) Forward(ctx context.Context, out chan<- *RequestEvent) {
+	for {
+		req, err := q.Pop(ctx)
+		if err != nil {
+			return
+		}
+		select {
+		case out <- req:
+		case <-ctx.Done():
+			failEvent(req, NewResponseError(ErrCodeTemporary, "channel closed"))
+			return
+		}
+	}
+}
+
+// Close stops the queue, the senders waiting fail with ErrQueueClosed
+func (q *EventQueue) Close() {
+	q.lk.Lock()
+	defer q.lk.Unlock()
+	if !q.closed {
+		q.closed = true
+		close(q.done)
+	}
+}
+
+// State returns the state of the queue
+func (q *EventQueue) State() EventQueueState {
+	q.lk.Lock()
+	defer q.lk.Unlock()
+	state := q.state
+	state.Len = len(q.events)
+	return state
+}
+
+// BreakerState is the state of a circuit breaker
+type BreakerState string
+
+const (
+	// BreakerClosed forwards the events
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen stops forwarding the events until the cooldown ends
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen forwards a single event, which closes the breaker when it succeeds and opens it again otherwise
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// CircuitBreakerState shows the circuit breaker of a channel
+type CircuitBreakerState struct {
+	State BreakerState
+	// Failures is the number of consecutive failures
+	Failures int
+	// Trips is the number of times the breaker opened
+	Trips uint64
+	// OpenUntil is the end of the cooldown of an open breaker
+	OpenUntil time.Time
+}
+
+// CircuitBreaker temporarily stops forwarding the events to a client which keeps failing them
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	lk       sync.Mutex
+	state    CircuitBreakerState
+	probing  bool
+	openedAt time.Time
+}
+
+// NewCircuitBreaker creates a breaker opening after threshold consecutive failures, for cooldown. A threshold of 0
+// never opens it.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if cooldown <= 0 {
+		cooldown = DefaultBreakerCooldown
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		state:     CircuitBreakerState{State: BreakerClosed},
+	}
+}
+
+// Allow reports whether an event can be forwarded. Once the cooldown of an open breaker ends, a single event is
+// allowed until Success or Failure is reported for it.
+func (b *CircuitBreaker) Allow() bool {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	switch b.state.State {
+	case BreakerOpen:
+		if b.now().Before(b.openedAt.Add(b.cooldown)) {
+			return false
+		}
+		b.state.State = BreakerHalfOpen
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Success reports an event handled by the client, which closes the breaker
+func (b *CircuitBreaker) Success() {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	b.state.State = BreakerClosed
+	b.state.Failures = 0
+	b.probing = false
+}
+
+// Failure reports an event the client failed, the breaker opens at the threshold or when it is half open
+func (b *CircuitBreaker) Failure() {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	b.state.Failures++
+	b.probing = false
+	if b.threshold <= 0 {
+		return
+	}
+	if b.state.State == BreakerHalfOpen || b.state.Failures >= b.threshold {
+		if b.state.State != BreakerOpen {
+			b.state.Trips++
+		}
+		b.state.State = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// Report reports the result of an event, only the retryable errors count as failures as the others are caused by
+// the request rather than by the client
+func (b *CircuitBreaker) Report(err error) {
+	if err != nil && ErrorCodeOf(err).Retryable() {
+		b.Failure()
+		return
+	}
+	b.Success()
+}
+
+// State returns the state of the breaker
+func (b *CircuitBreaker) State() CircuitBreakerState {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	state := b.state
+	if state.State == BreakerOpen {
+		state.OpenUntil = b.openedAt.Add(b.cooldown)
+	}
+	return state
+}
+
+// ChannelFlowState shows the backpressure and the circuit breaker of a channel
+type ChannelFlowState struct {
+	Queue   EventQueueState
+	Breaker CircuitBreakerState
+}
+
+// ChannelFlow is the backpressure and the circuit breaker of the events sent on a channel
+type ChannelFlow struct {
+	ChannelID types.UUID
+	Queue     *EventQueue
+	Breaker   *CircuitBreaker
+}
+
+// NewChannelFlow creates the queue and the circuit breaker of the channel from cfg
+func NewChannelFlow(channelID types.UUID, cfg BackpressureConfig) *ChannelFlow {
+	return &ChannelFlow{
+		ChannelID: channelID,
+		Queue:     NewEventQueue(cfg),
+		Breaker:   NewCircuitBreaker(cfg.BreakerFailures, cfg.BreakerCooldown),
+	}
+}
+
+// Send queues the event unless the circuit breaker is open, which fails it with a temporary error so that the
+// caller can pick another channel. The event queued answers to the breaker first: the response written to the Result
+// of the event popped from the queue is reported with Report, then passed to req.Result. An event without Result is
+// reported as a success once queued.
+func (f *ChannelFlow) Send(ctx context.Context, req *RequestEvent) error {
+	if !f.Breaker.Allow() {
+		return NewResponseError(ErrCodeTemporary, "circuit breaker of channel %s is open until %s", f.ChannelID,
+			f.Breaker.State().OpenUntil.Format(time.RFC3339))
+	}
+	queued := req
+	if req.Result != nil {
+		cp := *req
+		cp.Result = make(chan *ResponseEvent, 1)
+		queued = &cp
+	}
+	if err := f.Queue.Push(ctx, queued); err != nil {
+		// a client which does not keep up with its queue is failing
+		if !errors.Is(err, ErrQueueClosed) {
+			f.Breaker.Failure()
+		}
+		return err
+	}
+	if req.Result == nil {
+		f.Breaker.Success()
+		return nil
+	}
+	go f.relay(ctx, queued.Result, req.Result)
+	return nil
+}
+
+// relay reports the response of an event to the breaker and passes it on, the breaker is left as is when ctx is done
+// before the response
+func (f *ChannelFlow) relay(ctx context.Context, in <-chan *ResponseEvent, out chan<- *ResponseEvent) {
+	select {
+	case resp := <-in:
+		f.Breaker.Report(resp.Err())
+		select {
+		case out <- resp:
+		case <-ctx.Done():
+		}
+	case <-ctx.Done():
+	}
+}
+
+// State returns the state of the queue and of the circuit breaker
+func (f *ChannelFlow) State() *ChannelFlowState {
+	return &ChannelFlowState{Queue: f.Queue.State(), Breaker: f.Breaker.State()}
+}
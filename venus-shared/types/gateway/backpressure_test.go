@@ -0,0 +1,271 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	tf.UnitTest(t)
+
+	now := time.Unix(1000, 0)
+	b := NewCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	// the failures below the threshold keep it closed, a success resets them
+	assert.True(t, b.Allow())
+	b.Failure()
+	b.Success()
+	b.Failure()
+	assert.Equal(t, BreakerClosed, b.State().State)
+	assert.Equal(t, 1, b.State().Failures)
+
+	// it trips at the threshold until the cooldown ends
+	b.Failure()
+	state := b.State()
+	assert.Equal(t, BreakerOpen, state.State)
+	assert.Equal(t, uint64(1), state.Trips)
+	assert.Equal(t, now.Add(time.Minute), state.OpenUntil)
+	assert.False(t, b.Allow())
+
+	// a single event probes the client once the cooldown ends, its failure opens the breaker again
+	now = now.Add(time.Minute)
+	assert.True(t, b.Allow())
+	assert.Equal(t, BreakerHalfOpen, b.State().State)
+	assert.False(t, b.Allow())
+	b.Failure()
+	assert.Equal(t, BreakerOpen, b.State().State)
+	assert.Equal(t, uint64(2), b.State().Trips)
+	assert.False(t, b.Allow())
+
+	// the success of the probe closes it
+	now = now.Add(time.Minute)
+	assert.True(t, b.Allow())
+	b.Success()
+	state = b.State()
+	assert.Equal(t, BreakerClosed, state.State)
+	assert.Zero(t, state.Failures)
+	assert.True(t, state.OpenUntil.IsZero())
+	assert.True(t, b.Allow())
+	assert.True(t, b.Allow())
+
+	// only the retryable errors are failures
+	b.Report(NewResponseError(ErrCodePermanent, "bad request"))
+	b.Report(NewResponseError(ErrCodePermanent, "bad request"))
+	assert.Equal(t, BreakerClosed, b.State().State)
+	b.Report(NewResponseError(ErrCodeTemporary, "busy"))
+	b.Report(NewResponseError(ErrCodeTimeout, "slow"))
+	assert.Equal(t, BreakerOpen, b.State().State)
+
+	// a threshold of 0 never opens it
+	b = NewCircuitBreaker(0, time.Minute)
+	for i := 0; i < 10; i++ {
+		b.Failure()
+	}
+	assert.Equal(t, BreakerClosed, b.State().State)
+	assert.True(t, b.Allow())
+}
+
+func newResultEvent(priority EventPriority) *RequestEvent {
+	return &RequestEvent{ID: types.NewUUID(), Priority: priority, Result: make(chan *ResponseEvent, 1)}
+}
+
+func TestEventQueueDropOldest(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	q := NewEventQueue(BackpressureConfig{QueueSize: 2, Strategy: QueueDropOldest})
+	first, second, third := newResultEvent(0), newResultEvent(0), newResultEvent(0)
+	for _, req := range []*RequestEvent{first, second, third} {
+		require.NoError(t, q.Push(ctx, req))
+	}
+
+	// the oldest event is answered with a temporary error
+	resp := <-first.Result
+	assert.Equal(t, first.ID, resp.ID)
+	assert.Equal(t, ErrCodeTemporary, resp.ErrorCode)
+	state := q.State()
+	assert.Equal(t, 2, state.Len)
+	assert.Equal(t, uint64(1), state.Dropped)
+
+	for _, want := range []*RequestEvent{second, third} {
+		req, err := q.Pop(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, want, req)
+	}
+}
+
+func TestEventQueueBlock(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	q := NewEventQueue(BackpressureConfig{QueueSize: 1, Strategy: QueueBlock, BlockTimeout: 10 * time.Millisecond})
+	require.NoError(t, q.Push(ctx, newResultEvent(0)))
+
+	err := q.Push(ctx, newResultEvent(0))
+	assert.Equal(t, ErrCodeTemporary, ErrorCodeOf(err))
+	state := q.State()
+	assert.Equal(t, uint64(1), state.Blocked)
+	assert.Equal(t, uint64(1), state.TimedOut)
+
+	// a sender waits for the room made by Pop
+	q = NewEventQueue(BackpressureConfig{QueueSize: 1, Strategy: QueueBlock})
+	queued, waiting := newResultEvent(0), newResultEvent(0)
+	require.NoError(t, q.Push(ctx, queued))
+	pushed := make(chan error, 1)
+	go func() { pushed <- q.Push(ctx, waiting) }()
+
+	req, err := q.Pop(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, queued, req)
+	require.NoError(t, <-pushed)
+	req, err = q.Pop(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, waiting, req)
+
+	// the senders waiting fail once the queue is closed, the events left are still popped
+	require.NoError(t, q.Push(ctx, queued))
+	go func() { pushed <- q.Push(ctx, waiting) }()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	assert.ErrorIs(t, <-pushed, ErrQueueClosed)
+	req, err = q.Pop(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, queued, req)
+	_, err = q.Pop(ctx)
+	assert.ErrorIs(t, err, ErrQueueClosed)
+}
+
+func TestEventQueuePrioritized(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	q := NewEventQueue(BackpressureConfig{QueueSize: 3, Strategy: QueueDropOldest, Prioritized: true})
+	background := newResultEvent(PriorityBackground)
+	unseal := newResultEvent(PriorityUnseal)
+	winning := newResultEvent(PriorityWinningPoSt)
+	lateUnseal := newResultEvent(PriorityUnseal)
+	for _, req := range []*RequestEvent{background, unseal, winning} {
+		require.NoError(t, q.Push(ctx, req))
+	}
+
+	// the full queue drops the oldest event of the lowest priority
+	require.NoError(t, q.Push(ctx, lateUnseal))
+	assert.Equal(t, background.ID, (<-background.Result).ID)
+
+	// an event of a lower priority than every event queued is the one dropped
+	late := newResultEvent(PriorityBackground)
+	require.NoError(t, q.Push(ctx, late))
+	assert.Equal(t, late.ID, (<-late.Result).ID)
+	assert.Equal(t, uint64(2), q.State().Dropped)
+
+	// the events are sent by priority
+	for _, want := range []*RequestEvent{winning, unseal, lateUnseal} {
+		req, err := q.Pop(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, want, req)
+	}
+}
+
+func TestChannelFlowRegisteredPriority(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	send := func(cfg BackpressureConfig) []string {
+		flow := NewChannelFlow(types.NewUUID(), cfg)
+		for _, method := range []string{"WalletSign", "SectorsUnsealPiece", "ComputeProof"} {
+			require.NoError(t, flow.Send(ctx, NewRequestEvent(method, nil)))
+		}
+		var methods []string
+		for i := 0; i < 3; i++ {
+			req, err := flow.Queue.Pop(ctx)
+			require.NoError(t, err)
+			methods = append(methods, req.Method)
+		}
+		return methods
+	}
+
+	// a prover registered with EventPriority receives the winning PoSts first
+	proof := &ProofRegisterPolicy{EventPriority: true}
+	assert.Equal(t, []string{"ComputeProof", "SectorsUnsealPiece", "WalletSign"}, send(proof.Backpressure(DefaultBackpressureConfig())))
+	market := &MarketRegisterPolicy{EventPriority: true}
+	assert.Equal(t, []string{"ComputeProof", "SectorsUnsealPiece", "WalletSign"}, send(market.Backpressure(DefaultBackpressureConfig())))
+
+	// the other channels receive the events in order
+	assert.Equal(t, []string{"WalletSign", "SectorsUnsealPiece", "ComputeProof"}, send((&ProofRegisterPolicy{}).Backpressure(DefaultBackpressureConfig())))
+	var noPolicy *MarketRegisterPolicy
+	assert.False(t, noPolicy.Backpressure(DefaultBackpressureConfig()).Prioritized)
+}
+
+func TestChannelFlowSend(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	flow := NewChannelFlow(types.NewUUID(), BackpressureConfig{
+		QueueSize:       1,
+		Strategy:        QueueBlock,
+		BlockTimeout:    time.Millisecond,
+		BreakerFailures: 2,
+		BreakerCooldown: time.Hour,
+	})
+	require.NoError(t, flow.Send(ctx, newResultEvent(0)))
+
+	// a client which does not keep up with its queue trips the breaker, the events are then refused at once
+	for i := 0; i < 2; i++ {
+		assert.Error(t, flow.Send(ctx, newResultEvent(0)))
+	}
+	state := flow.State()
+	assert.Equal(t, BreakerOpen, state.Breaker.State)
+	assert.Equal(t, uint64(2), state.Queue.TimedOut)
+
+	err := flow.Send(ctx, newResultEvent(0))
+	assert.Equal(t, ErrCodeTemporary, ErrorCodeOf(err))
+	assert.Contains(t, err.Error(), "circuit breaker")
+	assert.Equal(t, uint64(2), flow.State().Queue.TimedOut)
+}
+
+func TestChannelFlowReportsResponses(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	flow := NewChannelFlow(types.NewUUID(), BackpressureConfig{
+		QueueSize:       4,
+		Strategy:        QueueDropOldest,
+		BreakerFailures: 1,
+		BreakerCooldown: time.Minute,
+	})
+	flow.Breaker.now = func() time.Time { return now }
+
+	// the client answers the event it pops, the sender receives the response on the event it sent
+	exchange := func(err error) *ResponseEvent {
+		req := newResultEvent(0)
+		require.NoError(t, flow.Send(ctx, req))
+		popped, popErr := flow.Queue.Pop(ctx)
+		require.NoError(t, popErr)
+		assert.Equal(t, req.ID, popped.ID)
+		popped.Result <- NewResponseEvent(popped.ID, nil, err)
+		return <-req.Result
+	}
+
+	resp := exchange(NewResponseError(ErrCodeTemporary, "busy"))
+	assert.Equal(t, ErrCodeTemporary, resp.ErrorCode)
+	assert.Equal(t, BreakerOpen, flow.State().Breaker.State)
+
+	// the probe after the cooldown succeeds, which closes the breaker without the caller reporting it
+	now = now.Add(time.Minute)
+	resp = exchange(nil)
+	assert.NoError(t, resp.Err())
+	assert.Equal(t, BreakerClosed, flow.State().Breaker.State)
+
+	// a permanent error is caused by the request, the breaker stays closed
+	exchange(NewResponseError(ErrCodePermanent, "bad params"))
+	assert.Equal(t, BreakerClosed, flow.State().Breaker.State)
+}
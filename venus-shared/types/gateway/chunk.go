@@ -0,0 +1,353 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const (
+	// DefaultMaxPayloadSize bounds the payload of an event once reassembled
+	DefaultMaxPayloadSize = 64 << 20
+	// DefaultChunkSize is the largest payload sent in a single event, below the websocket frame limits of the
+	// gateway and of the proxies in front of it
+	DefaultChunkSize = 1 << 20
+	// DefaultChunkTimeout is how long the chunks of a payload are kept waiting for the missing ones
+	DefaultChunkTimeout = 5 * time.Minute
+	// DefaultMaxPendingPayloads bounds the payloads of a channel waiting for their missing chunks
+	DefaultMaxPendingPayloads = 256
+)
+
+// EventChunk locates a part of a payload split across the events sharing an id
+type EventChunk struct {
+	Index int
+	Total int
+}
+
+// PayloadLimits bounds the payloads of the request and response events
+type PayloadLimits struct {
+	// MaxPayloadSize is the largest payload accepted, chunked or not
+	MaxPayloadSize int
+	// ChunkSize is the size above which a payload is split, 0 disables the chunking. Both sides of a channel are
+	// expected to use the same, a payload split in more than MaxPayloadSize/ChunkSize chunks is rejected
+	// (DefaultChunkSize is assumed when the chunking is disabled).
+	ChunkSize int
+	// ChunkTimeout drops the chunks of a payload not completed in time
+	ChunkTimeout time.Duration
+	// MaxPendingPayloads bounds the payloads waiting for their missing chunks, DefaultMaxPendingPayloads when 0
+	MaxPendingPayloads int
+}
+
+// DefaultPayloadLimits returns the default limits
+func DefaultPayloadLimits() PayloadLimits {
+	return PayloadLimits{
+		MaxPayloadSize:     DefaultMaxPayloadSize,
+		ChunkSize:          DefaultChunkSize,
+		ChunkTimeout:       DefaultChunkTimeout,
+		MaxPendingPayloads: DefaultMaxPendingPayloads,
+	}
+}
+
+// Validate checks that a payload of the max size can be sent
+func (l PayloadLimits) Validate() error {
+	if l.MaxPayloadSize <= 0 {
+		return fmt.Errorf("max payload size must be positive, got %d", l.MaxPayloadSize)
+	}
+	if l.ChunkSize < 0 {
+		return fmt.Errorf("chunk size must not be negative, got %d", l.ChunkSize)
+	}
+	if l.ChunkSize > l.MaxPayloadSize {
+		return fmt.Errorf("chunk size %d is above the max payload size %d", l.ChunkSize, l.MaxPayloadSize)
+	}
+	if l.MaxPendingPayloads < 0 {
+		return fmt.Errorf("max pending payloads must not be negative, got %d", l.MaxPendingPayloads)
+	}
+	return nil
+}
+
+// maxChunks is the largest number of chunks a payload within the limits is split in
+func (l PayloadLimits) maxChunks() int {
+	maxSize, chunkSize := l.MaxPayloadSize, l.ChunkSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxPayloadSize
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	return (maxSize + chunkSize - 1) / chunkSize
+}
+
+// CheckPayload returns an error when the payload can't be sent within the limits
+func (l PayloadLimits) CheckPayload(payload []byte) error {
+	if l.MaxPayloadSize > 0 && len(payload) > l.MaxPayloadSize {
+		return NewResponseError(ErrCodePermanent, "payload of %d bytes is above the limit of %d bytes", len(payload), l.MaxPayloadSize)
+	}
+	return nil
+}
+
+func splitPayload(payload []byte, chunkSize int) [][]byte {
+	if chunkSize <= 0 || len(payload) <= chunkSize {
+		return nil
+	}
+	parts := make([][]byte, 0, (len(payload)+chunkSize-1)/chunkSize)
+	for len(payload) > chunkSize {
+		parts = append(parts, payload[:chunkSize])
+		payload = payload[chunkSize:]
+	}
+	return append(parts, payload)
+}
+
+// SplitRequestEvent splits the payload of the request into events of at most chunkSize bytes, a request which fits
+// is returned as is
+func SplitRequestEvent(req *RequestEvent, chunkSize int) []*RequestEvent {
+	parts := splitPayload(req.Payload, chunkSize)
+	if parts == nil {
+		return []*RequestEvent{req}
+	}
+
+	events := make([]*RequestEvent, len(parts))
+	for i, part := range parts {
+		events[i] = &RequestEvent{
+			ID:         req.ID,
+			Method:     req.Method,
+			Payload:    part,
+			Priority:   req.Priority,
+			Chunk:      &EventChunk{Index: i, Total: len(parts)},
+			CreateTime: req.CreateTime,
+			Result:     req.Result,
+		}
+	}
+	return events
+}
+
+// SplitResponseEvent splits the payload of the response into events of at most chunkSize bytes, the error is carried
+// by the last one. A response which fits is returned as is.
+func SplitResponseEvent(resp *ResponseEvent, chunkSize int) []*ResponseEvent {
+	parts := splitPayload(resp.Payload, chunkSize)
+	if parts == nil {
+		return []*ResponseEvent{resp}
+	}
+
+	events := make([]*ResponseEvent, len(parts))
+	for i, part := range parts {
+		events[i] = &ResponseEvent{
+			ID:      resp.ID,
+			Payload: part,
+			Chunk:   &EventChunk{Index: i, Total: len(parts)},
+		}
+	}
+	events[len(parts)-1].Error = resp.Error
+	events[len(parts)-1].ErrorCode = resp.ErrorCode
+	return events
+}
+
+type pendingPayload struct {
+	parts    [][]byte
+	received int
+	size     int
+	first    time.Time
+	// the error of a response, carried by its last chunk
+	errMsg  string
+	errCode ErrorCode
+}
+
+// PayloadAssembler reassembles the payloads split by SplitRequestEvent and SplitResponseEvent
+type PayloadAssembler struct {
+	limits PayloadLimits
+
+	lk      sync.Mutex
+	pending map[types.UUID]*pendingPayload
+	// rejected holds the time the payloads were rejected at, their remaining chunks are dropped until the chunk
+	// timeout so that a payload is rejected once
+	rejected map[types.UUID]time.Time
+}
+
+// NewPayloadAssembler creates an assembler enforcing the max payload size and the chunk timeout of limits
+func NewPayloadAssembler(limits PayloadLimits) *PayloadAssembler {
+	if limits.ChunkTimeout <= 0 {
+		limits.ChunkTimeout = DefaultChunkTimeout
+	}
+	if limits.MaxPendingPayloads <= 0 {
+		limits.MaxPendingPayloads = DefaultMaxPendingPayloads
+	}
+	return &PayloadAssembler{
+		limits:   limits,
+		pending:  map[types.UUID]*pendingPayload{},
+		rejected: map[types.UUID]time.Time{},
+	}
+}
+
+// add stores a chunk of the payload of id, the whole payload is returned once every chunk is received. The chunks
+// of a rejected payload are dropped without an error.
+func (pa *PayloadAssembler) add(id types.UUID, chunk *EventChunk, payload []byte, errMsg string, errCode ErrorCode) (*pendingPayload, error) {
+	pa.lk.Lock()
+	defer pa.lk.Unlock()
+
+	now := time.Now()
+	for pid, p := range pa.pending {
+		if now.Sub(p.first) > pa.limits.ChunkTimeout {
+			delete(pa.pending, pid)
+		}
+	}
+	for pid, at := range pa.rejected {
+		if now.Sub(at) > pa.limits.ChunkTimeout {
+			delete(pa.rejected, pid)
+		}
+	}
+	if _, ok := pa.rejected[id]; ok {
+		return nil, nil
+	}
+
+	// the total comes from the peer, it is checked before it sizes the payload
+	if chunk.Total <= 0 || chunk.Index < 0 || chunk.Index >= chunk.Total {
+		return nil, pa.reject(id, now, "invalid chunk %d/%d of %s", chunk.Index, chunk.Total, id)
+	}
+	if maxChunks := pa.limits.maxChunks(); chunk.Total > maxChunks {
+		return nil, pa.reject(id, now, "payload of %s is split in %d chunks, above the limit of %d", id, chunk.Total, maxChunks)
+	}
+
+	p, ok := pa.pending[id]
+	if !ok {
+		if len(pa.pending) >= pa.limits.MaxPendingPayloads {
+			// not recorded as rejected, the payload may be sent again once the others completed
+			return nil, NewResponseError(ErrCodeTemporary, "too many payloads waiting for their chunks, %s dropped", id)
+		}
+		p = &pendingPayload{parts: make([][]byte, chunk.Total), first: now}
+		pa.pending[id] = p
+	}
+	if len(p.parts) != chunk.Total {
+		return nil, pa.reject(id, now, "chunk %d of %s expects %d chunks, got %d before", chunk.Index, id, chunk.Total, len(p.parts))
+	}
+	if p.parts[chunk.Index] != nil {
+		// a chunk sent again
+		return nil, nil
+	}
+
+	p.size += len(payload)
+	if pa.limits.MaxPayloadSize > 0 && p.size > pa.limits.MaxPayloadSize {
+		return nil, pa.reject(id, now, "payload of %s is above the limit of %d bytes", id, pa.limits.MaxPayloadSize)
+	}
+	// keep an empty chunk apart from a missing one
+	p.parts[chunk.Index] = append([]byte{}, payload...)
+	if chunk.Index == chunk.Total-1 {
+		p.errMsg, p.errCode = errMsg, errCode
+	}
+	p.received++
+	if p.received < chunk.Total {
+		return nil, nil
+	}
+
+	delete(pa.pending, id)
+	return p, nil
+}
+
+// reject drops the chunks of id received so far and those which follow, it is called with the lock held. The
+// rejected ids are bounded like the pending ones, the chunks following a rejection are rejected again when the
+// bound is reached.
+func (pa *PayloadAssembler) reject(id types.UUID, now time.Time, format string, args ...interface{}) error {
+	delete(pa.pending, id)
+	if len(pa.rejected) < pa.limits.MaxPendingPayloads {
+		pa.rejected[id] = now
+	}
+	return NewResponseError(ErrCodePermanent, format, args...)
+}
+
+func (p *pendingPayload) payload() []byte {
+	full := make([]byte, 0, p.size)
+	for _, part := range p.parts {
+		full = append(full, part...)
+	}
+	return full
+}
+
+// AddRequest adds a request event, the request with the whole payload is returned once every chunk is received
+func (pa *PayloadAssembler) AddRequest(req *RequestEvent) (*RequestEvent, bool, error) {
+	if req.Chunk == nil {
+		if err := pa.limits.CheckPayload(req.Payload); err != nil {
+			return nil, false, err
+		}
+		return req, true, nil
+	}
+
+	p, err := pa.add(req.ID, req.Chunk, req.Payload, "", ErrCodeNone)
+	if err != nil || p == nil {
+		return nil, false, err
+	}
+	full := *req
+	full.Payload = p.payload()
+	full.Chunk = nil
+	return &full, true, nil
+}
+
+// AddResponse adds a response event, the response with the whole payload is returned once every chunk is received
+func (pa *PayloadAssembler) AddResponse(resp *ResponseEvent) (*ResponseEvent, bool, error) {
+	if resp.Chunk == nil {
+		if err := pa.limits.CheckPayload(resp.Payload); err != nil {
+			return nil, false, err
+		}
+		return resp, true, nil
+	}
+
+	p, err := pa.add(resp.ID, resp.Chunk, resp.Payload, resp.Error, resp.ErrorCode)
+	if err != nil || p == nil {
+		return nil, false, err
+	}
+	return &ResponseEvent{ID: resp.ID, Payload: p.payload(), Error: p.errMsg, ErrorCode: p.errCode}, true, nil
+}
+
+// ReassembleRequestEvents passes on the requests received from in with their whole payload, the chunks of a payload
+// are held until it is complete. The requests which can't be reassembled are answered with an error through respond.
+func ReassembleRequestEvents(ctx context.Context, in <-chan *RequestEvent, limits PayloadLimits, respond func(context.Context, *ResponseEvent) error) <-chan *RequestEvent {
+	out := make(chan *RequestEvent)
+	pa := NewPayloadAssembler(limits)
+	go func() {
+		defer close(out)
+		for {
+			var req *RequestEvent
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case req, ok = <-in:
+				if !ok {
+					return
+				}
+			}
+
+			full, done, err := pa.AddRequest(req)
+			if err != nil {
+				if respond != nil {
+					_ = respond(ctx, NewResponseEvent(req.ID, nil, err))
+				}
+				continue
+			}
+			if !done {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- full:
+			}
+		}
+	}()
+	return out
+}
+
+// SendResponseEvent sends the response through respond, in chunks of the size of limits when its payload is too
+// large for a single event
+func SendResponseEvent(ctx context.Context, resp *ResponseEvent, limits PayloadLimits, respond func(context.Context, *ResponseEvent) error) error {
+	if err := limits.CheckPayload(resp.Payload); err != nil {
+		return respond(ctx, NewResponseEvent(resp.ID, nil, err))
+	}
+	for _, event := range SplitResponseEvent(resp, limits.ChunkSize) {
+		if err := respond(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
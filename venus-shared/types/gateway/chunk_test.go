@@ -0,0 +1,188 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestSplitPayload(t *testing.T) {
+	tf.UnitTest(t)
+
+	req := &RequestEvent{ID: types.NewUUID(), Method: "ComputeProof", Payload: []byte("0123456789")}
+	assert.Equal(t, []*RequestEvent{req}, SplitRequestEvent(req, 10))
+	assert.Equal(t, []*RequestEvent{req}, SplitRequestEvent(req, 0))
+
+	events := SplitRequestEvent(req, 4)
+	require.Len(t, events, 3)
+	for i, event := range events {
+		assert.Equal(t, req.ID, event.ID)
+		assert.Equal(t, req.Method, event.Method)
+		assert.Equal(t, &EventChunk{Index: i, Total: 3}, event.Chunk)
+	}
+	assert.Equal(t, []byte("89"), events[2].Payload)
+
+	// the error is carried by the last chunk
+	resp := &ResponseEvent{ID: req.ID, Payload: []byte("0123456789"), Error: "boom", ErrorCode: ErrCodeTemporary}
+	parts := SplitResponseEvent(resp, 5)
+	require.Len(t, parts, 2)
+	assert.Empty(t, parts[0].Error)
+	assert.Equal(t, "boom", parts[1].Error)
+	assert.Equal(t, ErrCodeTemporary, parts[1].ErrorCode)
+}
+
+func TestPayloadAssembler(t *testing.T) {
+	tf.UnitTest(t)
+
+	limits := PayloadLimits{MaxPayloadSize: 16, ChunkSize: 4, ChunkTimeout: time.Minute}
+
+	t.Run("out of order and repeated chunks", func(t *testing.T) {
+		pa := NewPayloadAssembler(limits)
+		resp := &ResponseEvent{ID: types.NewUUID(), Payload: []byte("0123456789"), Error: "boom", ErrorCode: ErrCodePermanent}
+		parts := SplitResponseEvent(resp, limits.ChunkSize)
+		require.Len(t, parts, 3)
+
+		for _, part := range []*ResponseEvent{parts[2], parts[0], parts[2]} {
+			full, done, err := pa.AddResponse(part)
+			require.NoError(t, err)
+			assert.False(t, done)
+			assert.Nil(t, full)
+		}
+		full, done, err := pa.AddResponse(parts[1])
+		require.NoError(t, err)
+		require.True(t, done)
+		assert.Equal(t, resp, full)
+	})
+
+	t.Run("the chunks of a rejected payload are dropped", func(t *testing.T) {
+		pa := NewPayloadAssembler(limits)
+		// the payload of larger chunks is rejected at its third chunk, the chunks following would exceed the
+		// limit again
+		req := &RequestEvent{ID: types.NewUUID(), Payload: bytes.Repeat([]byte("a"), 32)}
+		parts := SplitRequestEvent(req, 8)
+		require.Len(t, parts, 4)
+		// the payload split in more chunks than the limits allow is rejected at its first one
+		tooMany := SplitRequestEvent(&RequestEvent{ID: types.NewUUID(), Payload: bytes.Repeat([]byte("a"), 20)}, limits.ChunkSize)
+		require.Len(t, tooMany, 5)
+
+		for _, parts := range [][]*RequestEvent{parts, tooMany} {
+			var errs int
+			for _, part := range parts {
+				full, done, err := pa.AddRequest(part)
+				if err != nil {
+					errs++
+					assert.Equal(t, ErrCodePermanent, ErrorCodeOf(err))
+				}
+				assert.False(t, done)
+				assert.Nil(t, full)
+			}
+			assert.Equal(t, 1, errs)
+		}
+		_, _, err := pa.AddRequest(&RequestEvent{ID: types.NewUUID(), Chunk: &EventChunk{Index: 0, Total: 1 << 40}})
+		assert.Error(t, err)
+
+		// a sequence with a wrong total is rejected, the chunks following too
+		id := types.NewUUID()
+		_, _, err = pa.AddRequest(&RequestEvent{ID: id, Payload: []byte("a"), Chunk: &EventChunk{Index: 0, Total: 2}})
+		require.NoError(t, err)
+		_, _, err = pa.AddRequest(&RequestEvent{ID: id, Payload: []byte("b"), Chunk: &EventChunk{Index: 1, Total: 3}})
+		assert.Error(t, err)
+		full, done, err := pa.AddRequest(&RequestEvent{ID: id, Payload: []byte("b"), Chunk: &EventChunk{Index: 1, Total: 2}})
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.Nil(t, full)
+
+		_, _, err = pa.AddRequest(&RequestEvent{ID: types.NewUUID(), Chunk: &EventChunk{Index: 2, Total: 2}})
+		assert.Error(t, err)
+	})
+
+	t.Run("incomplete payloads time out", func(t *testing.T) {
+		pa := NewPayloadAssembler(PayloadLimits{MaxPayloadSize: 16, ChunkSize: 4, ChunkTimeout: 10 * time.Millisecond})
+		id := types.NewUUID()
+		_, _, err := pa.AddRequest(&RequestEvent{ID: id, Payload: []byte("a"), Chunk: &EventChunk{Index: 0, Total: 2}})
+		require.NoError(t, err)
+
+		time.Sleep(20 * time.Millisecond)
+		// the first chunk is dropped, the payload waits for it again
+		full, done, err := pa.AddRequest(&RequestEvent{ID: id, Payload: []byte("b"), Chunk: &EventChunk{Index: 1, Total: 2}})
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.Nil(t, full)
+	})
+
+	t.Run("the pending payloads are bounded", func(t *testing.T) {
+		pa := NewPayloadAssembler(PayloadLimits{MaxPayloadSize: 16, ChunkSize: 4, ChunkTimeout: time.Minute, MaxPendingPayloads: 2})
+		first := types.NewUUID()
+		for _, id := range []types.UUID{first, types.NewUUID()} {
+			_, _, err := pa.AddRequest(&RequestEvent{ID: id, Payload: []byte("a"), Chunk: &EventChunk{Index: 0, Total: 2}})
+			require.NoError(t, err)
+		}
+		_, _, err := pa.AddRequest(&RequestEvent{ID: types.NewUUID(), Payload: []byte("a"), Chunk: &EventChunk{Index: 0, Total: 2}})
+		assert.Equal(t, ErrCodeTemporary, ErrorCodeOf(err))
+
+		// a completed payload makes room for another
+		_, done, err := pa.AddRequest(&RequestEvent{ID: first, Payload: []byte("b"), Chunk: &EventChunk{Index: 1, Total: 2}})
+		require.NoError(t, err)
+		assert.True(t, done)
+		_, _, err = pa.AddRequest(&RequestEvent{ID: types.NewUUID(), Payload: []byte("a"), Chunk: &EventChunk{Index: 0, Total: 2}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("payloads without chunks", func(t *testing.T) {
+		pa := NewPayloadAssembler(limits)
+		req := &RequestEvent{ID: types.NewUUID(), Payload: []byte("small")}
+		full, done, err := pa.AddRequest(req)
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, req, full)
+
+		_, _, err = pa.AddRequest(&RequestEvent{ID: types.NewUUID(), Payload: bytes.Repeat([]byte("a"), 17)})
+		assert.Error(t, err)
+	})
+}
+
+func TestReassembleRequestEvents(t *testing.T) {
+	tf.UnitTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	limits := PayloadLimits{MaxPayloadSize: 8, ChunkSize: 4, ChunkTimeout: time.Minute}
+	in := make(chan *RequestEvent)
+	responses := make(chan *ResponseEvent, 10)
+	out := ReassembleRequestEvents(ctx, in, limits, func(ctx context.Context, resp *ResponseEvent) error {
+		responses <- resp
+		return nil
+	})
+
+	tooLarge := &RequestEvent{ID: types.NewUUID(), Payload: []byte("0123456789abcdef")}
+	req := &RequestEvent{ID: types.NewUUID(), Method: "ComputeProof", Payload: []byte("012345")}
+	go func() {
+		events := append(SplitRequestEvent(tooLarge, limits.ChunkSize), SplitRequestEvent(req, limits.ChunkSize)...)
+		for _, event := range events {
+			in <- event
+		}
+		close(in)
+	}()
+
+	var got []*RequestEvent
+	for full := range out {
+		got = append(got, full)
+	}
+	require.Len(t, got, 1)
+	assert.Equal(t, req.ID, got[0].ID)
+	assert.Equal(t, req.Payload, got[0].Payload)
+	assert.Nil(t, got[0].Chunk)
+
+	// the request too large is answered once
+	require.Len(t, responses, 1)
+	resp := <-responses
+	assert.Equal(t, tooLarge.ID, resp.ID)
+	assert.Equal(t, ErrCodePermanent, resp.ErrorCode)
+}
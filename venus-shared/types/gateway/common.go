@@ -1,16 +1,29 @@
 package gateway
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-jsonrpc"
+
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
 type RequestEvent struct {
-	ID         types.UUID `json:"Id"`
-	Method     string
-	Payload    []byte
+	ID      types.UUID `json:"Id"`
+	Method  string
+	Payload []byte
+	// Priority orders the event in the queue of the channels which registered with EventPriority
+	Priority EventPriority `json:",omitempty"`
+	// Chunk is set when the payload is split across several events with the same id
+	Chunk *EventChunk `json:",omitempty"`
+	// SignedAt and MAC authenticate the event on the channels which agreed on a secret at registration
+	SignedAt   time.Time           `json:",omitempty"`
+	MAC        []byte              `json:"Mac,omitempty"`
 	CreateTime time.Time           `json:"-"`
 	Result     chan *ResponseEvent `json:"-"`
 }
@@ -19,6 +32,120 @@ type ResponseEvent struct {
 	ID      types.UUID `json:"Id"`
 	Payload []byte
 	Error   string
+	// ErrorCode classifies Error, responders which do not classify their errors leave it empty
+	ErrorCode ErrorCode `json:",omitempty"`
+	// Chunk is set when the payload is split across several events with the same id
+	Chunk *EventChunk `json:",omitempty"`
+	// MAC authenticates the event on the channels which agreed on a secret at registration
+	MAC []byte `json:"Mac,omitempty"`
+}
+
+// NewResponseEvent builds the response to the request id, from the result of handling it
+func NewResponseEvent(id types.UUID, payload []byte, err error) *ResponseEvent {
+	resp := &ResponseEvent{ID: id, Payload: payload}
+	if err != nil {
+		resp.Error = err.Error()
+		resp.ErrorCode = ErrorCodeOf(err)
+		var respErr *ResponseError
+		if errors.As(err, &respErr) {
+			// the code is carried by the field, not by the message
+			resp.Error = respErr.Message
+		}
+	}
+	return resp
+}
+
+// Err returns the error of the response as a *ResponseError, or nil if the request succeeded
+func (re *ResponseEvent) Err() error {
+	if len(re.Error) == 0 {
+		return nil
+	}
+	code := re.ErrorCode
+	if code == ErrCodeNone {
+		code = ErrCodePermanent
+	}
+	return &ResponseError{Code: code, Message: re.Error}
+}
+
+// ErrorCode classifies the errors of the requests forwarded by the gateway, so that the callers can decide
+// whether to retry them
+type ErrorCode string
+
+const (
+	ErrCodeNone ErrorCode = ""
+	// ErrCodeTemporary is a failure which may not happen again, such as the responder being disconnected
+	ErrCodeTemporary ErrorCode = "temporary"
+	// ErrCodePermanent is a failure which happens again for the same request, such as invalid params
+	ErrCodePermanent ErrorCode = "permanent"
+	// ErrCodeUnauthorized is a request the responder refuses to handle for the caller
+	ErrCodeUnauthorized ErrorCode = "unauthorized"
+	// ErrCodeTimeout is a request which was not answered in time
+	ErrCodeTimeout ErrorCode = "timeout"
+)
+
+// Retryable reports whether a request failing with the code may succeed when retried
+func (c ErrorCode) Retryable() bool {
+	return c == ErrCodeTemporary || c == ErrCodeTimeout
+}
+
+// ResponseError is a classified error of a forwarded request. Its message starts with the code, so that
+// the classification survives the json rpc errors which only carry messages.
+type ResponseError struct {
+	Code    ErrorCode
+	Message string
+}
+
+// NewResponseError returns a ResponseError of the code
+func NewResponseError(code ErrorCode, format string, args ...interface{}) error {
+	return &ResponseError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *ResponseError) Error() string {
+	return "[" + string(e.Code) + "] " + e.Message
+}
+
+// Temporary reports whether the request may succeed when retried
+func (e *ResponseError) Temporary() bool {
+	return e.Code.Retryable()
+}
+
+// ErrorCodeOf classifies err. A ResponseError keeps its code, also when err only carries its message
+// after going through a json rpc call, the context errors are timeouts and the connection errors are
+// temporary, any other error is permanent.
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ErrCodeNone
+	}
+
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.Code
+	}
+	if code, ok := parseErrorCode(err.Error()); ok {
+		return code
+	}
+
+	var connErr *jsonrpc.RPCConnectionError
+	var clientErr *jsonrpc.ErrClient
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrCodeTimeout
+	case errors.Is(err, context.Canceled), errors.As(err, &connErr), errors.As(err, &clientErr):
+		return ErrCodeTemporary
+	default:
+		return ErrCodePermanent
+	}
+}
+
+// parseErrorCode finds the outermost code of a ResponseError message, the json rpc errors may wrap it
+func parseErrorCode(msg string) (ErrorCode, bool) {
+	found, at := ErrCodeNone, -1
+	for _, code := range []ErrorCode{ErrCodeTemporary, ErrCodePermanent, ErrCodeUnauthorized, ErrCodeTimeout} {
+		if idx := strings.Index(msg, "["+string(code)+"] "); idx >= 0 && (at < 0 || idx < at) {
+			found, at = code, idx
+		}
+	}
+	return found, at >= 0
 }
 
 type ConnectionStates struct {
@@ -32,10 +159,23 @@ type ConnectState struct {
 	IP           string     `json:"Ip"`
 	RequestCount int
 	CreateTime   time.Time
+	// Shadow is set for a wallet registered in shadow mode
+	Shadow bool `json:",omitempty"`
+	// UnprovenAddrs are the addresses of a wallet which did not sign their challenge, see AddressProofMode
+	UnprovenAddrs []address.Address `json:",omitempty"`
+	// Flow is the backpressure and the circuit breaker of the events sent on the channel, nil when the gateway does not
+	// bound them
+	Flow *ChannelFlowState `json:",omitempty"`
 }
 
 type ConnectedCompleted struct {
 	ChannelId types.UUID // nolint
+	// EventKey is the X25519 public key of the gateway, sent when the service provider registered with an EventKey,
+	// the events of the channel are then authenticated with the secret derived from both keys
+	EventKey []byte `json:",omitempty"`
+	// EventKeyMAC is the MAC of EventKey by the token the service provider registered with, the service provider
+	// refuses a key without it
+	EventKeyMAC []byte `json:",omitempty"`
 }
 
 type HostKey string
@@ -0,0 +1,42 @@
+package gateway
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+)
+
+// MaintenanceWindow is a planned downtime of the services of a miner, the proof and market requests of the miner
+// are queued until it ends instead of failing
+type MaintenanceWindow struct {
+	Miner  address.Address
+	Start  time.Time
+	End    time.Time
+	Reason string `json:",omitempty"`
+}
+
+func (w *MaintenanceWindow) Validate(now time.Time) error {
+	if w.Miner == address.Undef {
+		return fmt.Errorf("no miner for the maintenance window")
+	}
+	if !w.End.After(w.Start) {
+		return fmt.Errorf("maintenance window of %s ends at %s, before it starts at %s", w.Miner, w.End, w.Start)
+	}
+	if !w.End.After(now) {
+		return fmt.Errorf("maintenance window of %s already ended at %s", w.Miner, w.End)
+	}
+	return nil
+}
+
+// Active returns whether the window is ongoing at t
+func (w *MaintenanceWindow) Active(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// MaintenanceState is a declared maintenance window with the requests waiting for its end
+type MaintenanceState struct {
+	MaintenanceWindow
+	Active bool
+	Queued int
+}
@@ -11,6 +11,8 @@ import (
 
 type MarketRegisterPolicy struct {
 	Miner address.Address
+	// EventPriority tells the gateway the market handles the events out of order, see ProofRegisterPolicy
+	EventPriority bool `json:",omitempty"`
 }
 
 type UnsealRequest struct {
@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// EventPriority orders the events waiting on the channels which registered with EventPriority, the time-critical
+// proofs are sent before the events queued earlier
+type EventPriority int
+
+const (
+	// PriorityBackground is the priority of the events without a deadline, such as the wallet and retrieval ones
+	PriorityBackground EventPriority = iota
+	// PriorityUnseal is the priority of the unseal requests of the market
+	PriorityUnseal
+	// PriorityWinningPoSt is the priority of the winning PoSt requests, which must be answered within the block time
+	PriorityWinningPoSt
+)
+
+var priorityNames = map[EventPriority]string{
+	PriorityBackground:  "background",
+	PriorityUnseal:      "unseal",
+	PriorityWinningPoSt: "winning-post",
+}
+
+func (p EventPriority) String() string {
+	if name, ok := priorityNames[p]; ok {
+		return name
+	}
+	return "priority(" + strconv.Itoa(int(p)) + ")"
+}
+
+// MethodPriorities are the priorities of the events forwarded by the gateway, by method. The methods not listed are
+// background ones. The window PoSts are computed by the provers themselves, no event of the gateway requests them.
+var MethodPriorities = map[string]EventPriority{
+	"ComputeProof":       PriorityWinningPoSt,
+	"SectorsUnsealPiece": PriorityUnseal,
+}
+
+// EventPriorityOf returns the priority of the events of method
+func EventPriorityOf(method string) EventPriority {
+	return MethodPriorities[method]
+}
+
+// NewRequestEvent creates the event forwarding a request of method, with the priority of the method
+func NewRequestEvent(method string, payload []byte) *RequestEvent {
+	return &RequestEvent{
+		ID:         types.NewUUID(),
+		Method:     method,
+		Payload:    payload,
+		Priority:   EventPriorityOf(method),
+		CreateTime: time.Now(),
+		Result:     make(chan *ResponseEvent, 1),
+	}
+}
+
+// Backpressure returns cfg for a channel registered with the policy, the events are sent by priority when the prover
+// registered with EventPriority
+func (p *ProofRegisterPolicy) Backpressure(cfg BackpressureConfig) BackpressureConfig {
+	cfg.Prioritized = p != nil && p.EventPriority
+	return cfg
+}
+
+// Backpressure returns cfg for a channel registered with the policy, see ProofRegisterPolicy.Backpressure
+func (p *MarketRegisterPolicy) Backpressure(cfg BackpressureConfig) BackpressureConfig {
+	cfg.Prioritized = p != nil && p.EventPriority
+	return cfg
+}
@@ -1,20 +1,47 @@
 package gateway
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/network"
+	"github.com/filecoin-project/go-state-types/proof"
 
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
 type MinerState struct {
-	Connections     []*ConnectState
+	Connections     []*MinerConnectState
 	ConnectionCount int
 }
 
+// MinerConnectState is a channel of a prover of the miner, with what tells it apart from the other channels of the
+// miner. ConnectState is embedded, so that it decodes as a ConnectState for the clients which do not know the rest.
+type MinerConnectState struct {
+	ConnectState
+	// RemoteAddr is the address the channel connects from, with its port
+	RemoteAddr string `json:",omitempty"`
+	// Policy is what the prover registered the channel with
+	Policy *ProofRegisterPolicy `json:",omitempty"`
+	// EventAuth is set when the events of the channel are authenticated with a secret agreed at registration
+	EventAuth bool `json:",omitempty"`
+	// ErrorCount is the number of requests sent on the channel which failed or were not answered in time
+	ErrorCount int
+	// LastError is the error of the latest request which failed, empty when none did
+	LastError string `json:",omitempty"`
+}
+
 type ProofRegisterPolicy struct {
 	MinerAddress address.Address
+	// VerifyProofs makes the gateway verify the proofs of the prover before returning them, a request answered with
+	// an invalid proof fails instead
+	VerifyProofs bool `json:",omitempty"`
+	// EventPriority tells the gateway the prover handles the events out of order, the events of the channel are then
+	// sent by priority instead of in the order they were queued
+	EventPriority bool `json:",omitempty"`
 }
 
 type ComputeProofRequest struct {
@@ -23,3 +50,62 @@ type ComputeProofRequest struct {
 	Height      abi.ChainEpoch
 	NWVersion   network.Version
 }
+
+// WinningPoStVerifyInfo returns what verifies the proofs computed for the request of the miner
+func (req *ComputeProofRequest) WinningPoStVerifyInfo(miner address.Address, proofs []builtin.PoStProof) (proof.WinningPoStVerifyInfo, error) {
+	mid, err := address.IDFromAddress(miner)
+	if err != nil {
+		return proof.WinningPoStVerifyInfo{}, fmt.Errorf("getting ID from miner address %s: %w", miner, err)
+	}
+
+	sectors := make([]proof.SectorInfo, len(req.SectorInfos))
+	for i, xsi := range req.SectorInfos {
+		sectors[i] = proof.SectorInfo{
+			SealProof:    xsi.SealProof,
+			SectorNumber: xsi.SectorNumber,
+			SealedCID:    xsi.SealedCID,
+		}
+	}
+
+	return proof.WinningPoStVerifyInfo{
+		Randomness:        req.Rand,
+		Proofs:            proofs,
+		ChallengedSectors: sectors,
+		Prover:            abi.ActorID(mid),
+	}, nil
+}
+
+// ProofVerifyState counts the proofs of a miner verified by the gateway before returning them
+type ProofVerifyState struct {
+	Miner address.Address
+	// Enabled is whether the proofs are verified, set for the miner or asked by the policy of one of its provers
+	Enabled  bool
+	Verified uint64
+	Invalid  uint64
+	// LastInvalid is the latest proof which failed the verification, nil when none did
+	LastInvalid *InvalidProof `json:",omitempty"`
+}
+
+// InvalidProof is a proof of a prover refused by the gateway
+type InvalidProof struct {
+	Height    abi.ChainEpoch
+	Time      time.Time
+	ChannelID types.UUID
+	Err       string
+}
+
+// ProofQueueState shows the ComputeProof requests of a miner forwarded to its provers and waiting for a slot
+type ProofQueueState struct {
+	Miner address.Address
+	// Limit is the number of requests forwarded at once, 0 is unlimited
+	Limit  int
+	Active []*ProofRequestState
+	Queued []*ProofRequestState
+}
+
+type ProofRequestState struct {
+	Height     abi.ChainEpoch
+	CreateTime time.Time
+	// StartTime is when the request was forwarded, zero while it is queued
+	StartTime time.Time
+}
@@ -0,0 +1,93 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// The namespaces of the client routes, one per event api
+const (
+	RouteNamespaceProof     = "proof"
+	RouteNamespaceWallet    = "wallet"
+	RouteNamespaceMarket    = "market"
+	RouteNamespaceRetrieval = "retrieval"
+)
+
+var ErrInstanceNotFound = errors.New("gateway instance not found")
+
+// GatewayInstance is one of the gateways sharing a registry behind a load balancer
+type GatewayInstance struct { // nolint
+	ID types.UUID `json:"Id"`
+	// Address is the url of the api of the instance, which the other instances forward the requests of its clients to
+	Address  string
+	Started  time.Time
+	LastSeen time.Time
+}
+
+// ClientRoute tells which instance holds the connection of a client
+type ClientRoute struct {
+	ChannelID types.UUID `json:"ChannelId"`
+	Instance  types.UUID
+	Namespace string
+	// Keys are what the requests are routed by, the miners of a prover or the accounts of a wallet
+	Keys  []string
+	Since time.Time
+}
+
+// Registry is shared by the gateway instances, which register the connections of their clients so that the other
+// instances forward them the requests for these clients. A request is forwarded at most once, to an instance found
+// by Lookup, which handles it with its own connections only.
+//
+// An instance is live while its heartbeats come within the ttl of the registry, the routes of an instance which is
+// not live are dropped, so the clients of a stopped instance are routed again once they reconnect elsewhere.
+type Registry interface {
+	// Heartbeat registers the instance, or refreshes it, LastSeen is set by the registry
+	Heartbeat(ctx context.Context, instance *GatewayInstance) error
+	// Leave drops the instance and its routes
+	Leave(ctx context.Context, instance types.UUID) error
+	// Instances returns the live instances
+	Instances(ctx context.Context) ([]*GatewayInstance, error)
+	// Instance returns the live instance, ErrInstanceNotFound when it is not
+	Instance(ctx context.Context, id types.UUID) (*GatewayInstance, error)
+
+	PutRoute(ctx context.Context, route *ClientRoute) error
+	DeleteRoute(ctx context.Context, channelID types.UUID) error
+	// Lookup returns the routes of the key in the namespace, of the live instances only
+	Lookup(ctx context.Context, namespace, key string) ([]*ClientRoute, error)
+
+	Close() error
+}
+
+// RegistryConfig selects the registry of the gateway, the memory registry is only shared by a single instance
+type RegistryConfig struct {
+	// Backend is memory or redis
+	Backend string
+	// RedisEndpoint is the address of the redis server, host:port
+	RedisEndpoint string
+	RedisPassword string
+	RedisDB       int
+	// Prefix is prepended to the keys, so that several gateway clusters may share a redis server
+	Prefix string
+	// TTL is how long an instance stays live without heartbeat
+	TTL time.Duration
+}
+
+const (
+	RegistryMemory = "memory"
+	RegistryRedis  = "redis"
+
+	DefaultRegistryTTL    = 30 * time.Second
+	DefaultRegistryPrefix = "venus-gateway:"
+)
+
+// DefaultRegistryConfig is the registry of a single gateway instance
+func DefaultRegistryConfig() RegistryConfig {
+	return RegistryConfig{
+		Backend: RegistryMemory,
+		Prefix:  DefaultRegistryPrefix,
+		TTL:     DefaultRegistryTTL,
+	}
+}
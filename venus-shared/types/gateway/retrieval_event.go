@@ -0,0 +1,36 @@
+package gateway
+
+import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-fil-markets/retrievalmarket"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+type RetrievalRegisterPolicy struct {
+	Miner address.Address
+}
+
+// RetrievalQueryRequest asks the retrieval provider of Miner the terms of retrieving a payload
+type RetrievalQueryRequest struct {
+	Miner address.Address
+	Query retrievalmarket.Query
+}
+
+// RetrievalDealRequest asks the retrieval provider of Miner whether to accept the deal proposal of Client
+type RetrievalDealRequest struct {
+	Miner    address.Address
+	Client   peer.ID
+	Proposal retrievalmarket.DealProposal
+}
+
+// RetrievalDealResponse is the decision of a retrieval provider about a deal proposal
+type RetrievalDealResponse struct {
+	Accepted bool
+	// Message explains a rejection
+	Message string `json:",omitempty"`
+}
+
+type RetrievalConnectionState struct {
+	Addr address.Address
+	Conn ConnectionStates
+}
@@ -0,0 +1,409 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DefaultSLOBucket is the resolution of the sliding windows
+	DefaultSLOBucket = time.Minute
+	// DefaultSLOAlertMinRequests is the number of requests in the alert window below which no alert is sent
+	DefaultSLOAlertMinRequests = 10
+	// DefaultSLOAlertCooldown is the minimum delay between two alerts of the same account and kind
+	DefaultSLOAlertCooldown = 15 * time.Minute
+)
+
+// SLOKind is the kind of requests the SLOs of an account are computed for
+type SLOKind string
+
+const (
+	SLOSign   SLOKind = "sign"
+	SLOProof  SLOKind = "proof"
+	SLOMarket SLOKind = "market"
+)
+
+// sloLatencyBuckets is the number of latency buckets, the last one has no bound
+const sloLatencyBuckets = 22
+
+// the upper bounds of the latency buckets, from 1ms doubling up to about 17 minutes
+var sloLatencyBounds = func() []time.Duration {
+	bounds := make([]time.Duration, sloLatencyBuckets-1)
+	for i := range bounds {
+		bounds[i] = time.Millisecond << i
+	}
+	return bounds
+}()
+
+// SLOConfig sets the sliding windows of the SLOs of the accounts and when the gateway alerts on them
+type SLOConfig struct {
+	// Windows are the sliding windows the SLOs are computed over, the longest one bounds the history kept
+	Windows []time.Duration
+	// Bucket is the resolution of the windows, which are rounded up to a number of buckets
+	Bucket time.Duration
+
+	// AlertThreshold is the success rate below which an alert is posted, 0 disables the alerts
+	AlertThreshold float64
+	// AlertWindow is the window the threshold applies to, the shortest one when 0
+	AlertWindow time.Duration
+	// AlertKinds are the kinds of requests alerted on
+	AlertKinds []SLOKind
+	// AlertMinRequests avoids alerting on the few requests of an idle account
+	AlertMinRequests int64
+	AlertCooldown    time.Duration
+	// AlertWebhook is the url the SLOAlert are posted to as json
+	AlertWebhook string
+}
+
+// DefaultSLOConfig returns the default config, over 5 minutes, 1 hour and 1 day, without alerts
+func DefaultSLOConfig() SLOConfig {
+	return SLOConfig{
+		Windows:          []time.Duration{5 * time.Minute, time.Hour, 24 * time.Hour},
+		Bucket:           DefaultSLOBucket,
+		AlertKinds:       []SLOKind{SLOSign, SLOProof},
+		AlertMinRequests: DefaultSLOAlertMinRequests,
+		AlertCooldown:    DefaultSLOAlertCooldown,
+	}
+}
+
+// Validate checks the config
+func (c SLOConfig) Validate() error {
+	if c.Bucket <= 0 {
+		return fmt.Errorf("slo bucket must be positive, got %s", c.Bucket)
+	}
+	if len(c.Windows) == 0 {
+		return fmt.Errorf("no slo window")
+	}
+	for _, w := range c.Windows {
+		if w < c.Bucket {
+			return fmt.Errorf("slo window %s is shorter than the bucket %s", w, c.Bucket)
+		}
+	}
+	if c.AlertThreshold < 0 || c.AlertThreshold > 1 {
+		return fmt.Errorf("slo alert threshold must be between 0 and 1, got %v", c.AlertThreshold)
+	}
+	if c.AlertThreshold > 0 {
+		if len(c.AlertWebhook) == 0 {
+			return fmt.Errorf("slo alerts without webhook")
+		}
+		if c.AlertWindow != 0 && !c.hasWindow(c.AlertWindow) {
+			return fmt.Errorf("slo alert window %s is not one of the windows", c.AlertWindow)
+		}
+	}
+	if c.AlertMinRequests < 0 || c.AlertCooldown < 0 {
+		return fmt.Errorf("slo alert min requests and cooldown must not be negative")
+	}
+	return nil
+}
+
+func (c SLOConfig) hasWindow(w time.Duration) bool {
+	for _, window := range c.Windows {
+		if window == w {
+			return true
+		}
+	}
+	return false
+}
+
+func (c SLOConfig) alertWindow() time.Duration {
+	if c.AlertWindow != 0 {
+		return c.AlertWindow
+	}
+	shortest := c.Windows[0]
+	for _, w := range c.Windows[1:] {
+		if w < shortest {
+			shortest = w
+		}
+	}
+	return shortest
+}
+
+// TenantSLO is the SLO of the requests of a kind of an account over a sliding window
+type TenantSLO struct {
+	Account  string
+	Kind     SLOKind
+	Window   time.Duration
+	Requests int64
+	Failures int64
+	// SuccessRate is 1 without requests
+	SuccessRate float64
+	// P99Latency is the upper bound of the latency bucket of the 99th percentile, the requests slower than the last
+	// bucket count as the double of its bound
+	P99Latency time.Duration
+}
+
+type sloKey struct {
+	account string
+	kind    SLOKind
+}
+
+type sloBucket struct {
+	start    int64
+	requests int64
+	failures int64
+	latency  [sloLatencyBuckets]int64
+}
+
+type sloSeries struct {
+	buckets []sloBucket
+	last    int64
+}
+
+// SLOTracker computes the TenantSLO of the requests observed by the gateway. The requests are counted in buckets of
+// the config resolution, the memory used by an account does not depend on its number of requests.
+type SLOTracker struct {
+	cfg     SLOConfig
+	nBucket int64
+
+	lk     sync.Mutex
+	series map[sloKey]*sloSeries
+	now    func() time.Time
+}
+
+// NewSLOTracker checks the config and returns a tracker without requests
+func NewSLOTracker(cfg SLOConfig) (*SLOTracker, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	var longest time.Duration
+	for _, w := range cfg.Windows {
+		if w > longest {
+			longest = w
+		}
+	}
+	return &SLOTracker{
+		cfg:     cfg,
+		nBucket: int64((longest + cfg.Bucket - 1) / cfg.Bucket),
+		series:  make(map[sloKey]*sloSeries),
+		now:     time.Now,
+	}, nil
+}
+
+// Observe counts a request of the account which took latency, and failed when err is not nil
+func (t *SLOTracker) Observe(account string, kind SLOKind, latency time.Duration, err error) {
+	idx := t.now().UnixNano() / int64(t.cfg.Bucket)
+
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	key := sloKey{account: account, kind: kind}
+	s, ok := t.series[key]
+	if !ok {
+		s = &sloSeries{buckets: make([]sloBucket, t.nBucket)}
+		t.series[key] = s
+	}
+	b := &s.buckets[idx%t.nBucket]
+	if b.start != idx {
+		*b = sloBucket{start: idx}
+	}
+	b.requests++
+	if err != nil {
+		b.failures++
+	}
+	b.latency[latencyBucket(latency)]++
+	s.last = idx
+}
+
+func latencyBucket(latency time.Duration) int {
+	return sort.Search(len(sloLatencyBounds), func(i int) bool {
+		return latency <= sloLatencyBounds[i]
+	})
+}
+
+// List returns the SLOs of every account and kind over every window, sorted by account, kind and window. The
+// accounts without requests over the longest window are forgotten.
+func (t *SLOTracker) List() []*TenantSLO {
+	now := t.now().UnixNano() / int64(t.cfg.Bucket)
+	windows := append([]time.Duration{}, t.cfg.Windows...)
+	sort.Slice(windows, func(i, j int) bool { return windows[i] < windows[j] })
+
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	out := make([]*TenantSLO, 0, len(t.series)*len(windows))
+	for key, s := range t.series {
+		if now-s.last >= t.nBucket {
+			delete(t.series, key)
+			continue
+		}
+		for _, w := range windows {
+			out = append(out, s.slo(key, w, now, int64((w+t.cfg.Bucket-1)/t.cfg.Bucket)))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Account != out[j].Account {
+			return out[i].Account < out[j].Account
+		}
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		return out[i].Window < out[j].Window
+	})
+	return out
+}
+
+// slo sums the n buckets up to now
+func (s *sloSeries) slo(key sloKey, window time.Duration, now, n int64) *TenantSLO {
+	slo := &TenantSLO{Account: key.account, Kind: key.kind, Window: window, SuccessRate: 1}
+	var latency [sloLatencyBuckets]int64
+	for i := range s.buckets {
+		b := &s.buckets[i]
+		if b.requests == 0 || b.start <= now-n || b.start > now {
+			continue
+		}
+		slo.Requests += b.requests
+		slo.Failures += b.failures
+		for j, count := range b.latency {
+			latency[j] += count
+		}
+	}
+	if slo.Requests == 0 {
+		return slo
+	}
+	slo.SuccessRate = float64(slo.Requests-slo.Failures) / float64(slo.Requests)
+
+	// the rank of the 99th percentile, rounded up
+	rank := (slo.Requests*99 + 99) / 100
+	var seen int64
+	for j, count := range latency {
+		seen += count
+		if seen >= rank {
+			if j < len(sloLatencyBounds) {
+				slo.P99Latency = sloLatencyBounds[j]
+			} else {
+				slo.P99Latency = 2 * sloLatencyBounds[len(sloLatencyBounds)-1]
+			}
+			break
+		}
+	}
+	return slo
+}
+
+var (
+	sloSuccessRateDesc = prometheus.NewDesc("venus_gateway_tenant_slo_success_rate",
+		"The success rate of the requests of the account over the window", []string{"account", "kind", "window"}, nil)
+	sloP99LatencyDesc = prometheus.NewDesc("venus_gateway_tenant_slo_p99_latency_seconds",
+		"The 99th percentile of the latency of the requests of the account over the window", []string{"account", "kind", "window"}, nil)
+	sloRequestsDesc = prometheus.NewDesc("venus_gateway_tenant_slo_requests",
+		"The number of requests of the account over the window", []string{"account", "kind", "window"}, nil)
+)
+
+// Describe implements prometheus.Collector, so that the gateway exports the SLOs on its metrics endpoint
+func (t *SLOTracker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sloSuccessRateDesc
+	ch <- sloP99LatencyDesc
+	ch <- sloRequestsDesc
+}
+
+// Collect implements prometheus.Collector
+func (t *SLOTracker) Collect(ch chan<- prometheus.Metric) {
+	for _, slo := range t.List() {
+		labels := []string{slo.Account, string(slo.Kind), slo.Window.String()}
+		ch <- prometheus.MustNewConstMetric(sloSuccessRateDesc, prometheus.GaugeValue, slo.SuccessRate, labels...)
+		ch <- prometheus.MustNewConstMetric(sloP99LatencyDesc, prometheus.GaugeValue, slo.P99Latency.Seconds(), labels...)
+		ch <- prometheus.MustNewConstMetric(sloRequestsDesc, prometheus.GaugeValue, float64(slo.Requests), labels...)
+	}
+}
+
+// SLOAlert is posted to the alert webhook when the success rate of an account drops below the threshold
+type SLOAlert struct {
+	TenantSLO
+	Threshold float64
+	Time      time.Time
+}
+
+// SLOAlerter posts the alerts of the SLOs to the webhook of the config, an account and kind is alerted again once the
+// cooldown has passed if its success rate is still below the threshold
+type SLOAlerter struct {
+	cfg    SLOConfig
+	client *http.Client
+
+	lk   sync.Mutex
+	sent map[sloKey]time.Time
+}
+
+// NewSLOAlerter returns an alerter posting with client, http.DefaultClient when nil
+func NewSLOAlerter(cfg SLOConfig, client *http.Client) *SLOAlerter {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &SLOAlerter{cfg: cfg, client: client, sent: make(map[sloKey]time.Time)}
+}
+
+// Alerts returns the alerts due at now for slos, without recording them as sent
+func (a *SLOAlerter) Alerts(slos []*TenantSLO, now time.Time) []*SLOAlert {
+	if a.cfg.AlertThreshold == 0 {
+		return nil
+	}
+	window := a.cfg.alertWindow()
+
+	a.lk.Lock()
+	defer a.lk.Unlock()
+	var alerts []*SLOAlert
+	for _, slo := range slos {
+		if slo.Window != window || !a.alertKind(slo.Kind) {
+			continue
+		}
+		if slo.Requests == 0 || slo.Requests < a.cfg.AlertMinRequests || slo.SuccessRate >= a.cfg.AlertThreshold {
+			continue
+		}
+		if sent, ok := a.sent[sloKey{account: slo.Account, kind: slo.Kind}]; ok && now.Sub(sent) < a.cfg.AlertCooldown {
+			continue
+		}
+		alerts = append(alerts, &SLOAlert{TenantSLO: *slo, Threshold: a.cfg.AlertThreshold, Time: now})
+	}
+	return alerts
+}
+
+func (a *SLOAlerter) alertKind(kind SLOKind) bool {
+	for _, k := range a.cfg.AlertKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Check posts the alerts due at now for slos, the alerts which fail to be posted are retried by the next check
+func (a *SLOAlerter) Check(ctx context.Context, slos []*TenantSLO, now time.Time) error {
+	var errs []error
+	for _, alert := range a.Alerts(slos, now) {
+		if err := a.Post(ctx, alert); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		a.lk.Lock()
+		a.sent[sloKey{account: alert.Account, kind: alert.Kind}] = now
+		a.lk.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// Post posts the alert to the webhook as json
+func (a *SLOAlerter) Post(ctx context.Context, alert *SLOAlert) error {
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.cfg.AlertWebhook, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post the slo alert of %s: %w", alert.Account, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("post the slo alert of %s: %s", alert.Account, resp.Status)
+	}
+	return nil
+}
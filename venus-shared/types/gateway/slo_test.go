@@ -0,0 +1,150 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func newTestSLOTracker(t *testing.T, now *time.Time) *SLOTracker {
+	tracker, err := NewSLOTracker(SLOConfig{Windows: []time.Duration{time.Hour, 5 * time.Minute}, Bucket: time.Minute})
+	require.NoError(t, err)
+	tracker.now = func() time.Time { return *now }
+	return tracker
+}
+
+func TestSLOTrackerWindows(t *testing.T) {
+	tf.UnitTest(t)
+
+	now := time.Unix(60_000_000, 0)
+	tracker := newTestSLOTracker(t, &now)
+	failed := errors.New("failed")
+
+	// two failures 10 minutes ago, these are out of the short window
+	for _, err := range []error{failed, failed, nil} {
+		tracker.Observe("alice", SLOSign, time.Millisecond, err)
+	}
+	now = now.Add(10 * time.Minute)
+	for _, err := range []error{nil, nil, nil, failed} {
+		tracker.Observe("alice", SLOSign, time.Millisecond, err)
+	}
+	tracker.Observe("alice", SLOProof, time.Millisecond, nil)
+	tracker.Observe("bob", SLOSign, time.Millisecond, nil)
+
+	slos := tracker.List()
+	require.Len(t, slos, 6)
+	// sorted by account, kind and window
+	assert.Equal(t, TenantSLO{Account: "alice", Kind: SLOProof, Window: 5 * time.Minute, Requests: 1, SuccessRate: 1, P99Latency: time.Millisecond}, *slos[0])
+	assert.Equal(t, TenantSLO{Account: "alice", Kind: SLOSign, Window: 5 * time.Minute, Requests: 4, Failures: 1, SuccessRate: 0.75, P99Latency: time.Millisecond}, *slos[2])
+	assert.Equal(t, TenantSLO{Account: "alice", Kind: SLOSign, Window: time.Hour, Requests: 7, Failures: 3, SuccessRate: 4.0 / 7, P99Latency: time.Millisecond}, *slos[3])
+	assert.Equal(t, "bob", slos[4].Account)
+
+	// the requests leave the short window, the long one keeps them
+	now = now.Add(5 * time.Minute)
+	slos = tracker.List()
+	require.Len(t, slos, 6)
+	assert.Equal(t, TenantSLO{Account: "alice", Kind: SLOSign, Window: 5 * time.Minute, SuccessRate: 1}, *slos[2])
+	assert.Equal(t, int64(7), slos[3].Requests)
+
+	// the bucket of the requests made an hour ago is reused, it starts from zero
+	now = now.Add(55 * time.Minute)
+	tracker.Observe("alice", SLOSign, time.Millisecond, nil)
+	slos = tracker.List()
+	// the accounts without requests over the longest window are forgotten
+	require.Len(t, slos, 2)
+	assert.Equal(t, SLOSign, slos[0].Kind)
+	assert.Equal(t, int64(1), slos[0].Requests)
+	assert.Equal(t, int64(1), slos[1].Requests)
+
+	now = now.Add(time.Hour)
+	assert.Empty(t, tracker.List())
+}
+
+func TestSLOP99Latency(t *testing.T) {
+	tf.UnitTest(t)
+
+	now := time.Unix(60_000_000, 0)
+	for name, tc := range map[string]struct {
+		latencies map[time.Duration]int
+		p99       time.Duration
+	}{
+		"the slowest percent":     {latencies: map[time.Duration]int{time.Millisecond: 98, 100 * time.Millisecond: 2}, p99: 128 * time.Millisecond},
+		"below the slowest":       {latencies: map[time.Duration]int{time.Millisecond: 100, time.Second: 1}, p99: time.Millisecond},
+		"the bound of the bucket": {latencies: map[time.Duration]int{1500 * time.Microsecond: 10}, p99: 2 * time.Millisecond},
+		"over the last bucket":    {latencies: map[time.Duration]int{time.Hour: 1}, p99: 2 * sloLatencyBounds[len(sloLatencyBounds)-1]},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tracker := newTestSLOTracker(t, &now)
+			for latency, count := range tc.latencies {
+				for i := 0; i < count; i++ {
+					tracker.Observe("alice", SLOProof, latency, nil)
+				}
+			}
+			assert.Equal(t, tc.p99, tracker.List()[0].P99Latency)
+		})
+	}
+}
+
+func TestSLOAlerter(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	var posted []SLOAlert
+	status := http.StatusOK
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert SLOAlert
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		posted = append(posted, alert)
+		w.WriteHeader(status)
+	}))
+	defer webhook.Close()
+
+	cfg := DefaultSLOConfig()
+	cfg.AlertThreshold = 0.9
+	cfg.AlertWebhook = webhook.URL
+	require.NoError(t, cfg.Validate())
+	alerter := NewSLOAlerter(cfg, nil)
+
+	low := &TenantSLO{Account: "alice", Kind: SLOSign, Window: 5 * time.Minute, Requests: 20, Failures: 4, SuccessRate: 0.8}
+	slos := []*TenantSLO{
+		low,
+		// over the threshold, of a kind not alerted on, with too few requests and over another window
+		{Account: "bob", Kind: SLOSign, Window: 5 * time.Minute, Requests: 20, Failures: 1, SuccessRate: 0.95},
+		{Account: "bob", Kind: SLOMarket, Window: 5 * time.Minute, Requests: 20, Failures: 20},
+		{Account: "carol", Kind: SLOSign, Window: 5 * time.Minute, Requests: 5, Failures: 5},
+		{Account: "alice", Kind: SLOSign, Window: time.Hour, Requests: 20, Failures: 20},
+	}
+
+	now := time.Unix(60_000_000, 0)
+	require.NoError(t, alerter.Check(ctx, slos, now))
+	require.Len(t, posted, 1)
+	assert.Equal(t, *low, posted[0].TenantSLO)
+	assert.Equal(t, 0.9, posted[0].Threshold)
+
+	// the account is alerted again once the cooldown has passed
+	require.NoError(t, alerter.Check(ctx, slos, now.Add(cfg.AlertCooldown-time.Second)))
+	assert.Len(t, posted, 1)
+	require.NoError(t, alerter.Check(ctx, slos, now.Add(cfg.AlertCooldown)))
+	assert.Len(t, posted, 2)
+
+	// an alert which could not be posted is retried by the next check
+	status = http.StatusInternalServerError
+	now = now.Add(2 * cfg.AlertCooldown)
+	assert.Error(t, alerter.Check(ctx, slos, now))
+	status = http.StatusOK
+	require.NoError(t, alerter.Check(ctx, slos, now.Add(time.Second)))
+	assert.Len(t, posted, 4)
+
+	// no alert without threshold
+	cfg.AlertThreshold = 0
+	assert.Empty(t, NewSLOAlerter(cfg, nil).Alerts(slos, now))
+}
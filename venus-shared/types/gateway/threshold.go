@@ -0,0 +1,73 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+)
+
+// WalletSignPartialMethod is the method of the request events asking a co-signer for its share of a threshold signature,
+// the payload is a PartialSignRequest and the response payload a PartialSignature
+const WalletSignPartialMethod = "WalletSignPartial"
+
+// ThresholdSignPolicy makes the gateway sign for Signer by collecting Threshold partial signatures from CoSigners
+// and aggregating them, instead of forwarding the request to a single wallet
+type ThresholdSignPolicy struct {
+	Signer address.Address
+	// Threshold is the number of partial signatures needed, the M of M-of-N
+	Threshold uint
+	// CoSigners are the accounts of the wallets holding a share of the key, their position is the share index
+	CoSigners []string
+}
+
+// Validate checks that the threshold can be reached by distinct co-signers
+func (p *ThresholdSignPolicy) Validate() error {
+	if p.Signer == address.Undef {
+		return fmt.Errorf("threshold policy without signer")
+	}
+	if p.Threshold == 0 {
+		return fmt.Errorf("threshold of %s must be at least 1", p.Signer)
+	}
+	if int(p.Threshold) > len(p.CoSigners) {
+		return fmt.Errorf("threshold %d of %s is above the %d co-signers", p.Threshold, p.Signer, len(p.CoSigners))
+	}
+
+	seen := make(map[string]struct{}, len(p.CoSigners))
+	for _, account := range p.CoSigners {
+		if len(account) == 0 {
+			return fmt.Errorf("empty co-signer account for %s", p.Signer)
+		}
+		if _, ok := seen[account]; ok {
+			return fmt.Errorf("duplicate co-signer %s for %s", account, p.Signer)
+		}
+		seen[account] = struct{}{}
+	}
+	return nil
+}
+
+// CoSignerIndex returns the share index of the account, or -1 when it is not a co-signer
+func (p *ThresholdSignPolicy) CoSignerIndex(account string) int {
+	for i, a := range p.CoSigners {
+		if a == account {
+			return i
+		}
+	}
+	return -1
+}
+
+// PartialSignRequest is sent to each co-signer of a threshold signer
+type PartialSignRequest struct {
+	WalletSignRequest
+	// Index is the share index of the co-signer in the policy
+	Index uint
+	// Threshold and CoSigners let the co-signer compute its share coefficient
+	Threshold uint
+	CoSigners uint
+}
+
+// PartialSignature is the share of a threshold signature produced by a co-signer
+type PartialSignature struct {
+	CoSigner string
+	Index    uint
+	Data     []byte
+}
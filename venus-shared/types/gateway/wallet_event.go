@@ -3,8 +3,11 @@ package gateway
 import (
 	"crypto/rand"
 	"fmt"
+	"time"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
 
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
@@ -19,6 +22,14 @@ type WalletRegisterPolicy struct {
 	SupportAccounts []string
 	// a slice byte provide by wallet, using to verify address is really exist
 	SignBytes []byte
+	// Shadow registers the wallet in shadow mode: it receives copies of the sign requests of its accounts, and its
+	// responses are only compared with the ones of the primary wallets, never returned to the callers
+	Shadow bool `json:",omitempty"`
+	// EventKey is the X25519 public key of the wallet, setting it makes the gateway authenticate the events of the
+	// channel, see ConnectedCompleted
+	EventKey []byte `json:",omitempty"`
+	// EventKeyMAC is the MAC of EventKey by the token the wallet registers with, the gateway refuses a key without it
+	EventKeyMAC []byte `json:",omitempty"`
 }
 
 type WalletSignRequest struct {
@@ -27,6 +38,112 @@ type WalletSignRequest struct {
 	Meta   types.MsgMeta
 }
 
+// MaxWalletBatchSize is the max number of addresses or payloads of a WalletHasMany or WalletSignBatch request
+const MaxWalletBatchSize = 1024
+
+// WalletSignResult is the response to a request of a WalletSignBatch, a failed request does not fail the others
+type WalletSignResult struct {
+	Signature *crypto.Signature `json:",omitempty"`
+	Error     string            `json:",omitempty"`
+}
+
+// WalletSignPolicy restricts what the gateway forwards to the wallets of an account
+type WalletSignPolicy struct {
+	// RejectUnknown rejects the payloads of MTUnknown type, which can not be validated against their meta
+	RejectUnknown bool
+	// AllowedDestinations lists the actors messages may be sent to, every actor is allowed when empty
+	AllowedDestinations []SignDestination
+}
+
+// SignDestination allows messages to an actor, addresses are compared as they are, without resolving them
+type SignDestination struct {
+	To address.Address
+	// Methods which may be called on To, every method is allowed when empty
+	Methods []abi.MethodNum
+}
+
+// AllowMessage returns an error if the policy does not allow signing a message to the actor and method
+func (p *WalletSignPolicy) AllowMessage(to address.Address, method abi.MethodNum) error {
+	if p == nil || len(p.AllowedDestinations) == 0 {
+		return nil
+	}
+
+	for _, dest := range p.AllowedDestinations {
+		if dest.To != to {
+			continue
+		}
+		if len(dest.Methods) == 0 {
+			return nil
+		}
+		for _, m := range dest.Methods {
+			if m == method {
+				return nil
+			}
+		}
+		return fmt.Errorf("method %d of %s is not allowed", method, to)
+	}
+	return fmt.Errorf("destination %s is not allowed", to)
+}
+
+// AddressProofMode is how the gateway enforces that the wallets prove they hold the keys of the addresses they
+// register, by signing a challenge of the gateway for each of them
+type AddressProofMode string
+
+const (
+	// AddressProofOptional challenges the addresses but routes the sign requests to the wallets which have not
+	// proven them yet, they are only reported in ConnectState.UnprovenAddrs
+	AddressProofOptional AddressProofMode = "optional"
+	// AddressProofMandatory only routes the sign requests of an address to the wallets which proved it
+	AddressProofMandatory AddressProofMode = "mandatory"
+)
+
+// Validate returns an error for an unknown mode, the empty mode is AddressProofOptional
+func (m AddressProofMode) Validate() error {
+	switch m {
+	case "", AddressProofOptional, AddressProofMandatory:
+		return nil
+	default:
+		return fmt.Errorf("unknown address proof mode %q", m)
+	}
+}
+
+// AddressProofPolicy is how the addresses registered by the wallets of an account are proven
+type AddressProofPolicy struct {
+	Mode AddressProofMode
+}
+
+// ShadowSignReport compares the responses of a wallet in shadow mode with the ones of the primary wallets
+type ShadowSignReport struct {
+	ChannelID types.UUID `json:"ChannelId"`
+	Account   string
+	Since     time.Time
+	// Requests is the number of copies of the sign requests sent to the wallet
+	Requests int
+	// Matched counts the signatures equal to, or verified as well as, the ones of the primary wallets
+	Matched int
+	// Mismatched counts the signatures which differ from the ones of the primary wallets and could not be verified
+	Mismatched int
+	// ShadowFailed counts the requests the primary wallets signed and the wallet in shadow mode failed
+	ShadowFailed int
+	// PrimaryFailed counts the requests the wallet in shadow mode signed and the primary wallets failed
+	PrimaryFailed int
+	// BothFailed counts the requests both failed
+	BothFailed int
+	// Missed counts the requests the wallet in shadow mode did not answer in time
+	Missed int
+	// Mismatches are the latest differences, the oldest ones are dropped
+	Mismatches []ShadowSignMismatch
+}
+
+// ShadowSignMismatch is a request the wallet in shadow mode answered differently than the primary wallets
+type ShadowSignMismatch struct {
+	Time    time.Time
+	Signer  address.Address
+	Type    types.MsgType
+	Primary string
+	Shadow  string
+}
+
 var RandomBytes = func() []byte {
 	buf := make([]byte, 32)
 	if _, err := rand.Read(buf); err != nil {
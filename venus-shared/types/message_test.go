@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"encoding/json"
 	"testing"
 
 	"github.com/filecoin-project/go-address"
@@ -274,3 +275,27 @@ func TestMessageValidForBlockInclusion(t *testing.T) {
 		testutil.ValueSetNReset(t, c.name, onSet, onReset, c.sets...)
 	}
 }
+
+func TestMessageJSONAmounts(t *testing.T) {
+	tf.UnitTest(t)
+
+	to, err := address.NewIDAddress(1)
+	require.NoError(t, err)
+	msg := &Message{To: to, From: to, Value: big.NewInt(10), GasFeeCap: big.NewInt(100), GasPremium: big.NewInt(1)}
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	var decoded Message
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, msg.Cid(), decoded.Cid())
+
+	// the amounts sent by third-party clients, as numbers or in scientific notation
+	in := `{"To":"f01","From":"f01","Value":"1e18","GasFeeCap":1000,"GasPremium":"1.5E3"}`
+	require.NoError(t, json.Unmarshal([]byte(in), &decoded))
+	require.Equal(t, "1000000000000000000", decoded.Value.String())
+	require.Equal(t, "1000", decoded.GasFeeCap.String())
+	require.Equal(t, "1500", decoded.GasPremium.String())
+
+	for _, in := range []string{`{"Value":"1.5"}`, `{"Value":null}`, `{"GasFeeCap":"0x10"}`} {
+		require.Error(t, json.Unmarshal([]byte(in), &decoded), in)
+	}
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/ipfs/go-cid"
 )
 
@@ -46,9 +48,61 @@ type MpoolChange int
 const (
 	MpoolAdd MpoolChange = iota
 	MpoolRemove
+	// MpoolExpire is sent instead of MpoolRemove for a message dropped after staying pending longer than the ttl
+	MpoolExpire
 )
 
 type MpoolUpdate struct {
 	Type    MpoolChange
 	Message *SignedMessage
 }
+
+// MpoolSubFilter selects the updates of the message pool sent to a subscriber, an empty field matches any value
+type MpoolSubFilter struct {
+	// From matches the messages sent by one of the addresses
+	From []address.Address `json:",omitempty"`
+	// To matches the messages sent to one of the addresses
+	To []address.Address `json:",omitempty"`
+	// Methods matches the messages calling one of the methods
+	Methods []abi.MethodNum `json:",omitempty"`
+	// Types matches the updates of one of the changes
+	Types []MpoolChange `json:",omitempty"`
+	// LocalOnly matches the messages of the local addresses only
+	LocalOnly bool `json:",omitempty"`
+}
+
+// InclusionEstimate is the result of simulating the inclusion of a message with its current fee
+type InclusionEstimate struct {
+	// Probability that the message is included within the requested epochs
+	Probability float64
+	// GasAhead is the gas limit of the pending messages which would be selected before the message
+	GasAhead int64
+	// ProjectedBaseFee is the base fee projected for the last of the requested epochs
+	ProjectedBaseFee abi.TokenAmount
+	// SuggestedGasPremium is the premium at which the message is likely included within the requested epochs
+	SuggestedGasPremium abi.TokenAmount
+	// SuggestedGasFeeCap covers the highest projected base fee plus the suggested premium
+	SuggestedGasFeeCap abi.TokenAmount
+}
+
+// GasStat sums up the gas used by the calls of a method of an actor
+type GasStat struct {
+	Code cid.Cid
+	// Actor is the name of the builtin actor, <unknown> for the other actors
+	Actor  string
+	Method abi.MethodNum
+	Count  int
+	Min    int64
+	Mean   int64
+	P50    int64
+	P90    int64
+	P99    int64
+	Max    int64
+}
+
+// GasStats is the gas used by the messages executed in the epochs from From to To
+type GasStats struct {
+	From  abi.ChainEpoch
+	To    abi.ChainEpoch
+	Stats []GasStat
+}
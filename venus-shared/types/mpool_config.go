@@ -13,4 +13,6 @@ type MpoolConfig struct {
 	ReplaceByFeeRatio      Percent
 	PruneCooldown          time.Duration
 	GasLimitOverestimation float64
+	// PendingTTL is how long a message may stay pending before being dropped, 0 keeps it until it is included
+	PendingTTL time.Duration
 }
@@ -10,3 +10,10 @@ type CirculatingSupply struct {
 	FilCirculating      abi.TokenAmount
 	FilReserveDisbursed abi.TokenAmount
 }
+
+// SupplyPoint is the supply at a tipset of a supply history
+type SupplyPoint struct {
+	Epoch  abi.ChainEpoch
+	TipSet TipSetKey
+	CirculatingSupply
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// TipSetTag names a tipset relative to the head of the chain
+type TipSetTag string
+
+const (
+	// TipSetTagLatest selects the head of the chain
+	TipSetTagLatest TipSetTag = "latest"
+	// TipSetTagFinalized selects the tipset a chain finality below the head
+	TipSetTagFinalized TipSetTag = "finalized"
+)
+
+// TipSetHeight selects the tipset at a height of the chain of Anchor, the head when Anchor is nil.
+// When the height is a null round, Previous selects the tipset before it instead of the one after it.
+type TipSetHeight struct {
+	At       abi.ChainEpoch
+	Previous bool       `json:",omitempty"`
+	Anchor   *TipSetKey `json:",omitempty"`
+}
+
+// TipSetSelector selects a tipset by its key, its height or a tag, exactly one of the fields is set. The node
+// takes its json in place of the TipSetKey param of any api and resolves it, the *BySelector state apis take it
+// as a typed param for the go clients, ChainGetTipSetBySelector returns the tipset it selects.
+type TipSetSelector struct {
+	Key    *TipSetKey    `json:",omitempty"`
+	Height *TipSetHeight `json:",omitempty"`
+	Tag    *TipSetTag    `json:",omitempty"`
+}
+
+// SelectTipSetByKey returns a selector of the tipset of tsk
+func SelectTipSetByKey(tsk TipSetKey) TipSetSelector {
+	return TipSetSelector{Key: &tsk}
+}
+
+// SelectTipSetByHeight returns a selector of the tipset at height h of the chain of anchor, nil for the head
+func SelectTipSetByHeight(h abi.ChainEpoch, prev bool, anchor *TipSetKey) TipSetSelector {
+	return TipSetSelector{Height: &TipSetHeight{At: h, Previous: prev, Anchor: anchor}}
+}
+
+// SelectTipSetByTag returns a selector of the tipset named by tag
+func SelectTipSetByTag(tag TipSetTag) TipSetSelector {
+	return TipSetSelector{Tag: &tag}
+}
+
+// Validate checks that exactly one criterion is set and that it is valid
+func (tss TipSetSelector) Validate() error {
+	set := 0
+	if tss.Key != nil {
+		set++
+	}
+	if tss.Height != nil {
+		set++
+		if tss.Height.At < 0 {
+			return fmt.Errorf("height %d is negative", tss.Height.At)
+		}
+	}
+	if tss.Tag != nil {
+		set++
+		switch *tss.Tag {
+		case TipSetTagLatest, TipSetTagFinalized:
+		default:
+			return fmt.Errorf("unknown tipset tag %q", *tss.Tag)
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("a tipset selector must set exactly one of key, height and tag, got %d", set)
+	}
+	return nil
+}
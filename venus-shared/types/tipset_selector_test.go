@@ -0,0 +1,51 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/testutil"
+)
+
+func TestTipSetSelector(t *testing.T) {
+	tf.UnitTest(t)
+
+	var cids []cid.Cid
+	testutil.Provide(t, &cids, testutil.WithSliceLen(2))
+	tsk := NewTipSetKey(cids...)
+
+	for _, tss := range []TipSetSelector{
+		SelectTipSetByKey(tsk),
+		SelectTipSetByTag(TipSetTagLatest),
+		SelectTipSetByTag(TipSetTagFinalized),
+		SelectTipSetByHeight(10, true, nil),
+		SelectTipSetByHeight(10, false, &tsk),
+	} {
+		require.NoError(t, tss.Validate())
+
+		data, err := json.Marshal(tss)
+		require.NoError(t, err)
+		var decoded TipSetSelector
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.Equal(t, tss, decoded)
+	}
+
+	// the fields not set are omitted
+	data, err := json.Marshal(SelectTipSetByTag(TipSetTagFinalized))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Tag":"finalized"}`, string(data))
+
+	unknown := TipSetTag("safe")
+	for _, tss := range []TipSetSelector{
+		{},
+		{Key: &tsk, Tag: &unknown},
+		{Tag: &unknown},
+		SelectTipSetByHeight(-1, false, nil),
+	} {
+		require.Error(t, tss.Validate())
+	}
+}
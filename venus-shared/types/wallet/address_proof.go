@@ -0,0 +1,142 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+// addressProofDomain separates the address challenges from the other payloads signed as MTVerifyAddress
+var addressProofDomain = []byte("venus-gateway/address-proof/v1")
+
+type channelProofs struct {
+	// pending are the payloads of the challenges not answered yet
+	pending map[address.Address][]byte
+	proven  map[address.Address]struct{}
+}
+
+// AddressProofs keeps the challenges sent to the wallets for the addresses they register, and the addresses each
+// connection proved it holds the key of by signing their challenge
+type AddressProofs struct {
+	verify SignatureVerifier
+
+	lk       sync.Mutex
+	channels map[types.UUID]*channelProofs
+}
+
+// NewAddressProofs creates the address proofs checking the signatures of the challenges with verify
+func NewAddressProofs(verify SignatureVerifier) *AddressProofs {
+	return &AddressProofs{verify: verify, channels: map[types.UUID]*channelProofs{}}
+}
+
+// Challenge returns the payload and the meta of the WalletSign request asking the wallet of the connection to prove
+// it holds the key of addr, signBytes are the WalletRegisterPolicy.SignBytes of the wallet. The payload is bound to
+// the connection and the address, a new challenge replaces the previous one of the address.
+func (ap *AddressProofs) Challenge(channelID types.UUID, signBytes []byte, addr address.Address) ([]byte, types.MsgMeta, error) {
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, types.MsgMeta{}, fmt.Errorf("read challenge nonce: %w", err)
+	}
+	extra := bytes.Join([][]byte{addressProofDomain, channelID[:], addr.Bytes(), nonce}, nil)
+	toSign := GetSignData(extra, signBytes)
+
+	ap.lk.Lock()
+	defer ap.lk.Unlock()
+
+	ch, ok := ap.channels[channelID]
+	if !ok {
+		ch = &channelProofs{pending: map[address.Address][]byte{}, proven: map[address.Address]struct{}{}}
+		ap.channels[channelID] = ch
+	}
+	ch.pending[addr] = toSign
+
+	return toSign, types.MsgMeta{Type: types.MTVerifyAddress, Extra: extra}, nil
+}
+
+// Prove checks the signature answering the challenge of addr, the address is proven for the connection from then on
+func (ap *AddressProofs) Prove(channelID types.UUID, addr address.Address, sig *crypto.Signature) error {
+	ap.lk.Lock()
+	ch, ok := ap.channels[channelID]
+	var toSign []byte
+	if ok {
+		toSign, ok = ch.pending[addr]
+	}
+	ap.lk.Unlock()
+	if !ok {
+		return fmt.Errorf("no challenge of %s for the connection %s", addr, channelID)
+	}
+	if sig == nil {
+		return fmt.Errorf("no signature of the challenge of %s", addr)
+	}
+	if err := ap.verify(sig, addr, toSign); err != nil {
+		return fmt.Errorf("invalid proof of %s: %w", addr, err)
+	}
+
+	ap.lk.Lock()
+	defer ap.lk.Unlock()
+	// the connection may be gone, or the address challenged again, meanwhile
+	if ch, ok := ap.channels[channelID]; ok && bytes.Equal(ch.pending[addr], toSign) {
+		delete(ch.pending, addr)
+		ch.proven[addr] = struct{}{}
+	}
+	return nil
+}
+
+// Routable reports whether the sign requests of addr may be routed to the wallet of the connection under the mode
+func (ap *AddressProofs) Routable(channelID types.UUID, addr address.Address, mode gateway.AddressProofMode) bool {
+	if mode != gateway.AddressProofMandatory {
+		return true
+	}
+
+	ap.lk.Lock()
+	defer ap.lk.Unlock()
+	ch, ok := ap.channels[channelID]
+	if !ok {
+		return false
+	}
+	_, proven := ch.proven[addr]
+	return proven
+}
+
+// Unproven returns the addresses of the connection which were challenged and are not proven, sorted
+func (ap *AddressProofs) Unproven(channelID types.UUID) []address.Address {
+	ap.lk.Lock()
+	defer ap.lk.Unlock()
+
+	ch, ok := ap.channels[channelID]
+	if !ok {
+		return nil
+	}
+	out := make([]address.Address, 0, len(ch.pending))
+	for addr := range ch.pending {
+		out = append(out, addr)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].String() < out[j].String() })
+	return out
+}
+
+// Forget drops the addresses removed from the wallet of the connection, or the whole connection when no address is
+// given, once it is closed
+func (ap *AddressProofs) Forget(channelID types.UUID, addrs ...address.Address) {
+	ap.lk.Lock()
+	defer ap.lk.Unlock()
+
+	if len(addrs) == 0 {
+		delete(ap.channels, channelID)
+		return
+	}
+	if ch, ok := ap.channels[channelID]; ok {
+		for _, addr := range addrs {
+			delete(ch.pending, addr)
+			delete(ch.proven, addr)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+func TestAddressProofs(t *testing.T) {
+	tf.UnitTest(t)
+
+	addr, err := address.NewSecp256k1Address(bytes.Repeat([]byte{1}, 65))
+	require.NoError(t, err)
+	other, err := address.NewSecp256k1Address(bytes.Repeat([]byte{2}, 65))
+	require.NoError(t, err)
+
+	// a signature is the address followed by the payload
+	verify := func(sig *crypto.Signature, signer address.Address, payload []byte) error {
+		if !bytes.Equal(sig.Data, append(signer.Bytes(), payload...)) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+	sign := func(signer address.Address, payload []byte) *crypto.Signature {
+		return &crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: append(signer.Bytes(), payload...)}
+	}
+	proofs := NewAddressProofs(verify)
+	ch1, ch2 := types.NewUUID(), types.NewUUID()
+	// the sign bytes of a wallet of this process
+	signBytes := gateway.RandomBytes
+
+	toSign, meta, err := proofs.Challenge(ch1, signBytes, addr)
+	require.NoError(t, err)
+	assert.Equal(t, types.MTVerifyAddress, meta.Type)
+	// what the wallet checks before signing
+	assert.NoError(t, CheckSignMeta(addr, toSign, meta))
+
+	// the challenges differ by connection
+	toSign2, _, err := proofs.Challenge(ch2, signBytes, addr)
+	require.NoError(t, err)
+	assert.NotEqual(t, toSign, toSign2)
+
+	assert.True(t, proofs.Routable(ch1, addr, gateway.AddressProofOptional))
+	assert.False(t, proofs.Routable(ch1, addr, gateway.AddressProofMandatory))
+	assert.Equal(t, []address.Address{addr}, proofs.Unproven(ch1))
+
+	// the signature of another connection's challenge, or by another key, does not prove the address
+	assert.Error(t, proofs.Prove(ch1, addr, sign(addr, toSign2)))
+	assert.Error(t, proofs.Prove(ch1, addr, sign(other, toSign)))
+	assert.Error(t, proofs.Prove(ch1, other, sign(other, toSign)))
+	assert.False(t, proofs.Routable(ch1, addr, gateway.AddressProofMandatory))
+
+	require.NoError(t, proofs.Prove(ch1, addr, sign(addr, toSign)))
+	assert.True(t, proofs.Routable(ch1, addr, gateway.AddressProofMandatory))
+	assert.False(t, proofs.Routable(ch2, addr, gateway.AddressProofMandatory))
+	assert.Empty(t, proofs.Unproven(ch1))
+	// a challenge is answered once
+	assert.Error(t, proofs.Prove(ch1, addr, sign(addr, toSign)))
+
+	proofs.Forget(ch1, addr)
+	assert.False(t, proofs.Routable(ch1, addr, gateway.AddressProofMandatory))
+	proofs.Forget(ch2)
+	assert.Empty(t, proofs.Unproven(ch2))
+
+	assert.NoError(t, gateway.AddressProofMode("").Validate())
+	assert.Error(t, gateway.AddressProofMode("always").Validate())
+}
@@ -0,0 +1,90 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+
+	"github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+// DefaultBatchParallel is the number of requests of a batch forwarded at once to the wallets
+const DefaultBatchParallel = 16
+
+func checkBatchSize(n int) error {
+	if n > gateway.MaxWalletBatchSize {
+		return fmt.Errorf("batch of %d requests is larger than %d", n, gateway.MaxWalletBatchSize)
+	}
+	return nil
+}
+
+// HasMany answers a WalletHasMany request with has, which looks up a single address
+func HasMany(ctx context.Context, addrs []address.Address, has func(context.Context, address.Address) (bool, error)) ([]bool, error) {
+	if err := checkBatchSize(len(addrs)); err != nil {
+		return nil, err
+	}
+
+	out := make([]bool, len(addrs))
+	known := make(map[address.Address]bool, len(addrs))
+	for i, addr := range addrs {
+		ok, seen := known[addr]
+		if !seen {
+			var err error
+			if ok, err = has(ctx, addr); err != nil {
+				return nil, fmt.Errorf("looking up %s: %w", addr, err)
+			}
+			known[addr] = ok
+		}
+		out[i] = ok
+	}
+	return out, nil
+}
+
+// SignBatch answers a WalletSignBatch request with sign, which forwards a single request to a wallet. At most
+// parallel requests are forwarded at once, a request failing does not fail the others.
+func SignBatch(ctx context.Context,
+	reqs []*gateway.WalletSignRequest,
+	parallel int,
+	sign func(context.Context, *gateway.WalletSignRequest) (*crypto.Signature, error),
+) ([]*gateway.WalletSignResult, error) {
+	if err := checkBatchSize(len(reqs)); err != nil {
+		return nil, err
+	}
+	if parallel <= 0 {
+		parallel = DefaultBatchParallel
+	}
+
+	out := make([]*gateway.WalletSignResult, len(reqs))
+	throttle := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		if req == nil {
+			out[i] = &gateway.WalletSignResult{Error: "nil request"}
+			continue
+		}
+		select {
+		case throttle <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+		wg.Add(1)
+		go func(i int, req *gateway.WalletSignRequest) {
+			defer func() {
+				<-throttle
+				wg.Done()
+			}()
+			sig, err := sign(ctx, req)
+			if err != nil {
+				out[i] = &gateway.WalletSignResult{Error: err.Error()}
+				return
+			}
+			out[i] = &gateway.WalletSignResult{Signature: sig}
+		}(i, req)
+	}
+	wg.Wait()
+	return out, nil
+}
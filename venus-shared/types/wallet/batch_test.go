@@ -0,0 +1,80 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+func TestHasMany(t *testing.T) {
+	tf.UnitTest(t)
+
+	a1, _ := address.NewIDAddress(1000)
+	a2, _ := address.NewIDAddress(1001)
+	calls := 0
+	has := func(_ context.Context, addr address.Address) (bool, error) {
+		calls++
+		return addr == a1, nil
+	}
+
+	found, err := HasMany(context.Background(), []address.Address{a1, a2, a1}, has)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, found)
+	// the duplicated address is looked up once
+	assert.Equal(t, 2, calls)
+
+	_, err = HasMany(context.Background(), make([]address.Address, gateway.MaxWalletBatchSize+1), has)
+	assert.Error(t, err)
+}
+
+func TestSignBatch(t *testing.T) {
+	tf.UnitTest(t)
+
+	a1, _ := address.NewIDAddress(1000)
+	a2, _ := address.NewIDAddress(1001)
+	var inFlight, maxInFlight int32
+	sign := func(_ context.Context, req *gateway.WalletSignRequest) (*crypto.Signature, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		if req.Signer == a2 {
+			return nil, errors.New("no wallet for the signer")
+		}
+		return &crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: req.ToSign}, nil
+	}
+
+	reqs := []*gateway.WalletSignRequest{
+		{Signer: a1, ToSign: []byte("m1")},
+		{Signer: a2, ToSign: []byte("m2")},
+		nil,
+		{Signer: a1, ToSign: []byte("m3")},
+	}
+	res, err := SignBatch(context.Background(), reqs, 2, sign)
+	require.NoError(t, err)
+	require.Len(t, res, 4)
+	assert.Equal(t, []byte("m1"), res[0].Signature.Data)
+	assert.Equal(t, "no wallet for the signer", res[1].Error)
+	assert.Nil(t, res[1].Signature)
+	assert.NotEmpty(t, res[2].Error)
+	assert.Equal(t, []byte("m3"), res[3].Signature.Data)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = SignBatch(ctx, reqs, 1, sign)
+	assert.Error(t, err)
+}
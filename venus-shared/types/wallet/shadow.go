@@ -0,0 +1,157 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+// MaxShadowMismatches bounds the mismatches kept in a shadow report
+const MaxShadowMismatches = 20
+
+// SignatureVerifier checks a signature of the signer over the payload
+type SignatureVerifier func(sig *crypto.Signature, signer address.Address, payload []byte) error
+
+// ShadowSignRecorder builds the reports of the wallets in shadow mode, one per connection
+type ShadowSignRecorder struct {
+	// verify accepts a signature with other bytes than the one of the primary wallets, nil compares the bytes only
+	verify SignatureVerifier
+
+	lk      sync.Mutex
+	reports map[types.UUID]*gateway.ShadowSignReport
+}
+
+// NewShadowSignRecorder creates a recorder checking the signatures which differ with verify, which may be nil
+func NewShadowSignRecorder(verify SignatureVerifier) *ShadowSignRecorder {
+	return &ShadowSignRecorder{verify: verify, reports: map[types.UUID]*gateway.ShadowSignReport{}}
+}
+
+func (r *ShadowSignRecorder) report(channelID types.UUID, account string) *gateway.ShadowSignReport {
+	rp, ok := r.reports[channelID]
+	if !ok {
+		rp = &gateway.ShadowSignReport{ChannelID: channelID, Account: account, Since: time.Now()}
+		r.reports[channelID] = rp
+	}
+	return rp
+}
+
+// Record compares the response of the wallet in shadow mode of the connection with the one of the primary wallets
+func (r *ShadowSignRecorder) Record(channelID types.UUID, account string, req *gateway.WalletSignRequest,
+	primary *crypto.Signature, primaryErr error, shadow *crypto.Signature, shadowErr error,
+) {
+	matched := true
+	if primaryErr == nil && shadowErr == nil {
+		matched = r.sameSignature(req, primary, shadow)
+	}
+
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	rp := r.report(channelID, account)
+	rp.Requests++
+	switch {
+	case primaryErr != nil && shadowErr != nil:
+		rp.BothFailed++
+		return
+	case primaryErr != nil:
+		rp.PrimaryFailed++
+	case shadowErr != nil:
+		rp.ShadowFailed++
+	case matched:
+		rp.Matched++
+		return
+	default:
+		rp.Mismatched++
+	}
+
+	rp.Mismatches = append(rp.Mismatches, gateway.ShadowSignMismatch{
+		Time:    time.Now(),
+		Signer:  req.Signer,
+		Type:    req.Meta.Type,
+		Primary: describeSignResult(primary, primaryErr),
+		Shadow:  describeSignResult(shadow, shadowErr),
+	})
+	if len(rp.Mismatches) > MaxShadowMismatches {
+		rp.Mismatches = rp.Mismatches[len(rp.Mismatches)-MaxShadowMismatches:]
+	}
+}
+
+// RecordMissed counts a request the wallet in shadow mode of the connection did not answer in time
+func (r *ShadowSignRecorder) RecordMissed(channelID types.UUID, account string) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	rp := r.report(channelID, account)
+	rp.Requests++
+	rp.Missed++
+}
+
+func (r *ShadowSignRecorder) sameSignature(req *gateway.WalletSignRequest, primary, shadow *crypto.Signature) bool {
+	if primary == nil || shadow == nil {
+		return primary == shadow
+	}
+	if primary.Type != shadow.Type {
+		return false
+	}
+	if bytes.Equal(primary.Data, shadow.Data) {
+		return true
+	}
+	// the signatures of a scheme with random nonces differ while both being valid
+	return r.verify != nil && r.verify(shadow, req.Signer, req.ToSign) == nil
+}
+
+func describeSignResult(sig *crypto.Signature, err error) string {
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	if sig == nil {
+		return "no signature"
+	}
+
+	data := sig.Data
+	suffix := ""
+	if len(data) > 16 {
+		data, suffix = data[:16], "..."
+	}
+	return fmt.Sprintf("type %d %x%s", sig.Type, data, suffix)
+}
+
+// Reports returns copies of the reports, ordered by account
+func (r *ShadowSignRecorder) Reports() []*gateway.ShadowSignReport {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	out := make([]*gateway.ShadowSignReport, 0, len(r.reports))
+	for _, rp := range r.reports {
+		cp := *rp
+		cp.Mismatches = append([]gateway.ShadowSignMismatch(nil), rp.Mismatches...)
+		out = append(out, &cp)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Account != out[j].Account {
+			return out[i].Account < out[j].Account
+		}
+		return out[i].Since.Before(out[j].Since)
+	})
+	return out
+}
+
+// Reset drops the reports of the account, or every report when account is empty
+func (r *ShadowSignRecorder) Reset(account string) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	for id, rp := range r.reports {
+		if len(account) == 0 || rp.Account == account {
+			delete(r.reports, id)
+		}
+	}
+}
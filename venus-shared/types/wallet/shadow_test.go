@@ -0,0 +1,81 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+func TestShadowSignRecorder(t *testing.T) {
+	tf.UnitTest(t)
+
+	signer, err := address.NewSecp256k1Address(bytes.Repeat([]byte{1}, 65))
+	require.NoError(t, err)
+	req := &gateway.WalletSignRequest{Signer: signer, ToSign: []byte("msg"), Meta: types.MsgMeta{Type: types.MTChainMsg}}
+
+	// accept the signatures ending with the payload
+	verify := func(sig *crypto.Signature, _ address.Address, payload []byte) error {
+		if !bytes.HasSuffix(sig.Data, payload) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+	rec := NewShadowSignRecorder(verify)
+
+	sig := func(data string) *crypto.Signature {
+		return &crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: []byte(data)}
+	}
+	ch1, ch2 := types.NewUUID(), types.NewUUID()
+
+	rec.Record(ch1, "new", req, sig("a-msg"), nil, sig("a-msg"), nil)
+	// other bytes, still valid
+	rec.Record(ch1, "new", req, sig("a-msg"), nil, sig("b-msg"), nil)
+	rec.Record(ch1, "new", req, sig("a-msg"), nil, sig("bad"), nil)
+	rec.Record(ch1, "new", req, sig("a-msg"), nil, nil, errors.New("locked"))
+	rec.Record(ch1, "new", req, nil, errors.New("rejected"), sig("a-msg"), nil)
+	rec.Record(ch1, "new", req, nil, errors.New("rejected"), nil, errors.New("rejected"))
+	rec.RecordMissed(ch1, "new")
+	rec.RecordMissed(ch2, "another")
+
+	reports := rec.Reports()
+	require.Len(t, reports, 2)
+	require.Equal(t, "another", reports[0].Account)
+	require.Equal(t, 1, reports[0].Missed)
+
+	rp := reports[1]
+	require.Equal(t, ch1, rp.ChannelID)
+	require.Equal(t, 7, rp.Requests)
+	require.Equal(t, 2, rp.Matched)
+	require.Equal(t, 1, rp.Mismatched)
+	require.Equal(t, 1, rp.ShadowFailed)
+	require.Equal(t, 1, rp.PrimaryFailed)
+	require.Equal(t, 1, rp.BothFailed)
+	require.Equal(t, 1, rp.Missed)
+	require.Len(t, rp.Mismatches, 3)
+	require.Equal(t, "error: locked", rp.Mismatches[1].Shadow)
+	require.Equal(t, types.MTChainMsg, rp.Mismatches[0].Type)
+
+	// the reports are copies
+	rp.Mismatches[0].Shadow = "changed"
+	require.NotEqual(t, "changed", rec.Reports()[1].Mismatches[0].Shadow)
+
+	for i := 0; i < MaxShadowMismatches+5; i++ {
+		rec.Record(ch1, "new", req, sig("a-msg"), nil, sig("bad"), nil)
+	}
+	require.Len(t, rec.Reports()[1].Mismatches, MaxShadowMismatches)
+
+	rec.Reset("new")
+	reports = rec.Reports()
+	require.Len(t, reports, 1)
+	require.Equal(t, "another", reports[0].Account)
+	rec.Reset("")
+	require.Empty(t, rec.Reports())
+}
@@ -0,0 +1,140 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	cborutil "github.com/filecoin-project/go-cbor-util"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/specs-actors/v2/actors/builtin/market"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+// CheckSignMeta checks that meta describes what is really signed, so that a wallet approving a sign request
+// by its meta can not be tricked into signing something else. Messages are decoded and serialized back,
+// and deal proposals are checked against the proposal cid when it is given in meta.Extra.
+func CheckSignMeta(signer address.Address, toSign []byte, meta types.MsgMeta) error {
+	switch meta.Type {
+	case types.MTUndefined:
+		return fmt.Errorf("sign request without msg type")
+	case types.MTUnknown, types.MTVerifyAddress:
+		// raw bytes, nothing to check them against
+		_, _, err := GetSignBytesAndObj(toSign, meta)
+		return err
+	case types.MTChainMsg:
+		_, err := checkChainMsg(signer, toSign, meta)
+		return err
+	case types.MTDealProposal, types.MTClientDeal:
+		return checkDealProposal(toSign, meta)
+	}
+
+	obj, _, err := GetSignBytesAndObj(toSign, meta)
+	if err != nil {
+		return err
+	}
+	// trailing or non canonical bytes could be read differently by the wallet
+	if m, ok := obj.(cbor.Marshaler); ok {
+		buf := new(bytes.Buffer)
+		if err := m.MarshalCBOR(buf); err != nil {
+			return err
+		}
+		if !bytes.Equal(buf.Bytes(), toSign) {
+			return fmt.Errorf("%s payload is not canonically encoded", meta.Type)
+		}
+	}
+	return nil
+}
+
+// CheckSignRequest checks the meta of the sign request and that the policy allows it
+func CheckSignRequest(req *gateway.WalletSignRequest, policy *gateway.WalletSignPolicy) error {
+	if err := CheckSignMeta(req.Signer, req.ToSign, req.Meta); err != nil {
+		return err
+	}
+	if policy == nil {
+		return nil
+	}
+
+	switch req.Meta.Type {
+	case types.MTUnknown:
+		if policy.RejectUnknown {
+			return fmt.Errorf("signing %s payloads is not allowed", types.MTUnknown)
+		}
+	case types.MTChainMsg:
+		msg, err := types.DecodeMessage(req.Meta.Extra)
+		if err != nil {
+			return err
+		}
+		return policy.AllowMessage(msg.To, msg.Method)
+	}
+	return nil
+}
+
+func checkChainMsg(signer address.Address, toSign []byte, meta types.MsgMeta) (*types.Message, error) {
+	if len(meta.Extra) == 0 {
+		return nil, fmt.Errorf("msg type must contain extra data")
+	}
+	msg, err := types.DecodeMessage(meta.Extra)
+	if err != nil {
+		return nil, fmt.Errorf("decode message: %w", err)
+	}
+
+	raw, err := msg.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(raw, meta.Extra) {
+		return nil, fmt.Errorf("message is not canonically encoded")
+	}
+
+	// the sender may be given by its id address, which can not be checked without the state
+	if msg.From.Protocol() != address.ID && msg.From != signer {
+		return nil, fmt.Errorf("message is sent from %s, not by the signer %s", msg.From, signer)
+	}
+
+	signBytes, err := msg.SigningBytes(types.AddressProtocol2SignType(signer.Protocol()))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(signBytes, toSign) {
+		return nil, fmt.Errorf("sign data does not match the message %s", msg.Cid())
+	}
+	return msg, nil
+}
+
+func checkDealProposal(toSign []byte, meta types.MsgMeta) error {
+	var obj cbor.Marshaler = &market.DealProposal{}
+	if meta.Type == types.MTClientDeal {
+		obj = &market.ClientDealProposal{}
+	}
+	if err := CborDecodeInto(toSign, obj); err != nil {
+		return err
+	}
+
+	raw, err := cborutil.Dump(obj)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(raw, toSign) {
+		return fmt.Errorf("%s payload is not canonically encoded", meta.Type)
+	}
+
+	if len(meta.Extra) == 0 {
+		return nil
+	}
+	expected, err := cid.Cast(meta.Extra)
+	if err != nil {
+		return fmt.Errorf("extra data of %s must be the proposal cid: %w", meta.Type, err)
+	}
+	nd, err := cborutil.AsIpld(obj)
+	if err != nil {
+		return err
+	}
+	if !nd.Cid().Equals(expected) {
+		return fmt.Errorf("proposal cid %s does not match %s", nd.Cid(), expected)
+	}
+	return nil
+}
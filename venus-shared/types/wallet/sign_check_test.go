@@ -0,0 +1,97 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	cborutil "github.com/filecoin-project/go-cbor-util"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/specs-actors/v2/actors/builtin/market"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/testutil"
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+func TestCheckSignRequest(t *testing.T) {
+	tf.UnitTest(t)
+
+	signer, err := address.NewSecp256k1Address([]byte("signer"))
+	require.NoError(t, err)
+	to, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	msg := &types.Message{
+		To:         to,
+		From:       signer,
+		Value:      big.NewInt(1),
+		GasFeeCap:  big.NewInt(1),
+		GasPremium: big.NewInt(1),
+		Method:     2,
+	}
+	extra, err := msg.Serialize()
+	require.NoError(t, err)
+
+	req := &gateway.WalletSignRequest{Signer: signer, ToSign: msg.Cid().Bytes(), Meta: types.MsgMeta{Type: types.MTChainMsg, Extra: extra}}
+	require.NoError(t, CheckSignRequest(req, nil))
+
+	// the bytes to sign are not the ones of the message
+	other := testutil.BytesFixedProvider(32)(t)
+	require.Error(t, CheckSignRequest(&gateway.WalletSignRequest{Signer: signer, ToSign: other, Meta: req.Meta}, nil))
+
+	// the message is announced as something else
+	require.Error(t, CheckSignRequest(&gateway.WalletSignRequest{Signer: signer, ToSign: extra, Meta: types.MsgMeta{Type: types.MTDealProposal}}, nil))
+	require.Error(t, CheckSignRequest(&gateway.WalletSignRequest{Signer: signer, ToSign: extra}, nil))
+
+	// the message is sent from someone else
+	sender, err := address.NewSecp256k1Address([]byte("sender"))
+	require.NoError(t, err)
+	require.Error(t, CheckSignRequest(&gateway.WalletSignRequest{Signer: sender, ToSign: req.ToSign, Meta: req.Meta}, nil))
+
+	policy := &gateway.WalletSignPolicy{AllowedDestinations: []gateway.SignDestination{{To: to, Methods: []abi.MethodNum{2}}}}
+	require.NoError(t, CheckSignRequest(req, policy))
+	policy.AllowedDestinations[0].Methods = []abi.MethodNum{3}
+	require.Error(t, CheckSignRequest(req, policy))
+	policy.AllowedDestinations[0].To = signer
+	require.Error(t, CheckSignRequest(req, policy))
+
+	unknown := &gateway.WalletSignRequest{Signer: signer, ToSign: other, Meta: types.MsgMeta{Type: types.MTUnknown}}
+	require.NoError(t, CheckSignRequest(unknown, policy))
+	require.Error(t, CheckSignRequest(unknown, &gateway.WalletSignPolicy{RejectUnknown: true}))
+}
+
+func TestCheckDealProposalMeta(t *testing.T) {
+	tf.UnitTest(t)
+
+	client, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	provider, err := address.NewIDAddress(1001)
+	require.NoError(t, err)
+
+	proposal := &market.DealProposal{
+		PieceCID:             testutil.CidProvider(32)(t),
+		PieceSize:            2048,
+		Client:               client,
+		Provider:             provider,
+		StoragePricePerEpoch: big.Zero(),
+		ProviderCollateral:   big.Zero(),
+		ClientCollateral:     big.Zero(),
+	}
+	toSign, err := cborutil.Dump(proposal)
+	require.NoError(t, err)
+	nd, err := cborutil.AsIpld(proposal)
+	require.NoError(t, err)
+
+	require.NoError(t, CheckSignMeta(client, toSign, types.MsgMeta{Type: types.MTDealProposal}))
+	require.NoError(t, CheckSignMeta(client, toSign, types.MsgMeta{Type: types.MTDealProposal, Extra: nd.Cid().Bytes()}))
+
+	// the proposal cid announced does not match
+	other := testutil.CidProvider(32)(t)
+	require.Error(t, CheckSignMeta(client, toSign, types.MsgMeta{Type: types.MTDealProposal, Extra: other.Bytes()}))
+
+	// trailing bytes
+	require.Error(t, CheckSignMeta(client, append(toSign, 0), types.MsgMeta{Type: types.MTDealProposal}))
+}
@@ -0,0 +1,155 @@
+package wallet
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/google/uuid"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// MaxSignDenials is the number of denials kept for the audit
+const MaxSignDenials = 256
+
+type epochSpend struct {
+	epoch abi.ChainEpoch
+	value abi.TokenAmount
+}
+
+// SignPolicy evaluates the sign rules before signing and keeps the recent denials
+type SignPolicy struct {
+	lk      sync.Mutex
+	rules   []*SignRule
+	spent   map[address.Address]epochSpend
+	denials []SignDenial
+}
+
+// NewSignPolicy creates a policy with the rules, which must be valid
+func NewSignPolicy(rules []*SignRule) *SignPolicy {
+	return &SignPolicy{
+		rules: rules,
+		spent: make(map[address.Address]epochSpend),
+	}
+}
+
+// Rules returns the rules in the order they were added
+func (p *SignPolicy) Rules() []*SignRule {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	return append([]*SignRule(nil), p.rules...)
+}
+
+// AddRule adds a rule, it is given an id when it has none
+func (p *SignPolicy) AddRule(rule *SignRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	if len(rule.ID) == 0 {
+		rule.ID = uuid.NewString()
+	}
+	for _, r := range p.rules {
+		if r.ID == rule.ID {
+			return fmt.Errorf("rule %s already exists", rule.ID)
+		}
+	}
+	p.rules = append(p.rules, rule)
+	return nil
+}
+
+// RemoveRule removes the rule of the id
+func (p *SignPolicy) RemoveRule(id string) error {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	for i, r := range p.rules {
+		if r.ID == id {
+			p.rules = append(p.rules[:i], p.rules[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("rule %s not found", id)
+}
+
+// Denials returns the last denials, the most recent first, limit 0 returns all the kept denials
+func (p *SignPolicy) Denials(limit int) []SignDenial {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	if limit <= 0 || limit > len(p.denials) {
+		limit = len(p.denials)
+	}
+	out := make([]SignDenial, 0, limit)
+	for i := len(p.denials) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, p.denials[i])
+	}
+	return out
+}
+
+// Check evaluates the rules of the signer for the payload signed at epoch. The messages allowed are accounted in
+// the value signed in the epoch. A signer with rules may only sign the payloads CheckSignMeta can validate, so
+// that a message can not be signed as raw bytes to get around the rules.
+func (p *SignPolicy) Check(signer address.Address, toSign []byte, meta types.MsgMeta, epoch abi.ChainEpoch) error {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+
+	var rules []*SignRule
+	for _, r := range p.rules {
+		if r.AppliesTo(signer) {
+			rules = append(rules, r)
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	if meta.Type == types.MTUnknown {
+		return p.deny(signer, "", nil, fmt.Errorf("signing %s payloads is not allowed to a signer with rules", types.MTUnknown))
+	}
+	if err := CheckSignMeta(signer, toSign, meta); err != nil {
+		return p.deny(signer, "", nil, err)
+	}
+	if meta.Type != types.MTChainMsg {
+		return nil
+	}
+
+	msg, err := types.DecodeMessage(meta.Extra)
+	if err != nil {
+		return p.deny(signer, "", nil, err)
+	}
+	spent := p.spent[signer]
+	if spent.epoch != epoch || spent.value.Int == nil {
+		spent = epochSpend{epoch: epoch, value: big.Zero()}
+	}
+	for _, r := range rules {
+		if err := r.Allow(msg, spent.value); err != nil {
+			return p.deny(signer, r.ID, msg, err)
+		}
+	}
+
+	spent.value = big.Add(spent.value, msg.Value)
+	p.spent[signer] = spent
+	return nil
+}
+
+func (p *SignPolicy) deny(signer address.Address, ruleID string, msg *types.Message, reason error) error {
+	p.denials = append(p.denials, SignDenial{
+		Time:   time.Now(),
+		Signer: signer,
+		RuleID: ruleID,
+		Msg:    msg,
+		Reason: reason.Error(),
+	})
+	if len(p.denials) > MaxSignDenials {
+		p.denials = p.denials[len(p.denials)-MaxSignDenials:]
+	}
+	if len(ruleID) > 0 {
+		return fmt.Errorf("denied by sign rule %s: %w", ruleID, reason)
+	}
+	return fmt.Errorf("denied by sign policy: %w", reason)
+}
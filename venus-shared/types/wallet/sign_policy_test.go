@@ -0,0 +1,89 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestParseSignRule(t *testing.T) {
+	tf.UnitTest(t)
+
+	rule, err := ParseSignRule(address.Undef, "value-per-epoch <= 1.5 FIL")
+	require.NoError(t, err)
+	require.Equal(t, SignRuleMaxValue, rule.Kind)
+	require.Equal(t, types.MustParseFIL("1.5").String(), types.FIL(rule.MaxValue).String())
+
+	rule, err = ParseSignRule(address.Undef, "to in [f01000, f01001]")
+	require.NoError(t, err)
+	require.Len(t, rule.Destinations, 2)
+	require.Equal(t, "to in [f01000, f01001]", rule.Expr)
+
+	rule, err = ParseSignRule(address.Undef, "method in [0,2]")
+	require.NoError(t, err)
+	require.Equal(t, []abi.MethodNum{0, 2}, rule.Methods)
+
+	for _, expr := range []string{"", "value-per-epoch < 1", "method in 2", "method in []", "to in [f0x]", "nonce in [1]"} {
+		_, err := ParseSignRule(address.Undef, expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestSignPolicy(t *testing.T) {
+	tf.UnitTest(t)
+
+	signer, err := address.NewSecp256k1Address([]byte("signer"))
+	require.NoError(t, err)
+	other, err := address.NewSecp256k1Address([]byte("other"))
+	require.NoError(t, err)
+	to, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	signReq := func(from address.Address, value int64, method abi.MethodNum) ([]byte, types.MsgMeta) {
+		msg := &types.Message{From: from, To: to, Value: types.NewInt(uint64(value)), GasFeeCap: big.Zero(), GasPremium: big.Zero(), Method: method}
+		extra, err := msg.Serialize()
+		require.NoError(t, err)
+		toSign, err := msg.SigningBytes(types.AddressProtocol2SignType(from.Protocol()))
+		require.NoError(t, err)
+		return toSign, types.MsgMeta{Type: types.MTChainMsg, Extra: extra}
+	}
+
+	policy := NewSignPolicy(nil)
+	for _, expr := range []string{"value-per-epoch <= 10afil", "method in [0]"} {
+		rule, err := ParseSignRule(signer, expr)
+		require.NoError(t, err)
+		require.NoError(t, policy.AddRule(rule))
+	}
+	require.Len(t, policy.Rules(), 2)
+
+	toSign, meta := signReq(signer, 6, 0)
+	require.NoError(t, policy.Check(signer, toSign, meta, 1))
+	// the value signed in the epoch is accounted
+	require.Error(t, policy.Check(signer, toSign, meta, 1))
+	require.NoError(t, policy.Check(signer, toSign, meta, 2))
+
+	toSign, meta = signReq(signer, 1, 2)
+	require.Error(t, policy.Check(signer, toSign, meta, 2))
+
+	// raw bytes can not be signed by a signer with rules
+	require.Error(t, policy.Check(signer, toSign, types.MsgMeta{Type: types.MTUnknown}, 2))
+	require.NoError(t, policy.Check(other, toSign, types.MsgMeta{Type: types.MTUnknown}, 2))
+
+	denials := policy.Denials(0)
+	require.Len(t, denials, 3)
+	require.Contains(t, denials[0].Reason, string(types.MTUnknown))
+	require.Empty(t, denials[0].RuleID)
+	require.Equal(t, policy.Rules()[1].ID, denials[1].RuleID)
+	require.Equal(t, policy.Rules()[0].ID, denials[2].RuleID)
+	require.Len(t, policy.Denials(1), 1)
+
+	require.NoError(t, policy.RemoveRule(policy.Rules()[1].ID))
+	require.NoError(t, policy.Check(signer, toSign, meta, 2))
+	require.Error(t, policy.RemoveRule("unknown"))
+}
@@ -0,0 +1,171 @@
+package wallet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type SignRuleKind string
+
+const (
+	// SignRuleMaxValue limits the value of the messages signed in an epoch
+	SignRuleMaxValue SignRuleKind = "value-per-epoch"
+	// SignRuleDestinations lists the actors messages may be sent to
+	SignRuleDestinations SignRuleKind = "to"
+	// SignRuleMethods lists the methods messages may call
+	SignRuleMethods SignRuleKind = "method"
+)
+
+// SignRule restricts the messages a signer may sign, a message is signed when every rule of its signer allows it
+type SignRule struct {
+	ID string
+	// Signer the rule applies to, every signer when undefined
+	Signer address.Address
+	// Expr is the expression the rule is parsed from
+	Expr string
+
+	Kind         SignRuleKind
+	MaxValue     abi.TokenAmount
+	Destinations []address.Address `json:",omitempty"`
+	Methods      []abi.MethodNum   `json:",omitempty"`
+}
+
+// SignDenial records a sign request refused by a rule
+type SignDenial struct {
+	Time   time.Time
+	Signer address.Address
+	// RuleID is the rule which denied the request, empty when the request could not be checked
+	RuleID string
+	Msg    *types.Message `json:",omitempty"`
+	Reason string
+}
+
+// ParseSignRule parses the rule of a signer from an expression, one of
+//
+//	value-per-epoch <= 10FIL
+//	to in [f01000, f410f...]
+//	method in [0, 2]
+func ParseSignRule(signer address.Address, expr string) (*SignRule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("invalid rule %q, expected `<field> <op> <value>`", expr)
+	}
+	kind, op, value := SignRuleKind(fields[0]), fields[1], strings.Join(fields[2:], "")
+
+	rule := &SignRule{Signer: signer, Expr: strings.Join(fields, " "), Kind: kind}
+	switch kind {
+	case SignRuleMaxValue:
+		if op != "<=" {
+			return nil, fmt.Errorf("invalid rule %q, %s expects <=", expr, kind)
+		}
+		fil, err := types.ParseFIL(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %q: %w", expr, err)
+		}
+		rule.MaxValue = abi.TokenAmount(fil)
+	case SignRuleDestinations, SignRuleMethods:
+		if op != "in" {
+			return nil, fmt.Errorf("invalid rule %q, %s expects in", expr, kind)
+		}
+		items, err := parseRuleList(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %q: %w", expr, err)
+		}
+		for _, item := range items {
+			if kind == SignRuleMethods {
+				method, err := strconv.ParseUint(item, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid rule %q, method %q: %w", expr, item, err)
+				}
+				rule.Methods = append(rule.Methods, abi.MethodNum(method))
+				continue
+			}
+			to, err := address.NewFromString(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rule %q, address %q: %w", expr, item, err)
+			}
+			rule.Destinations = append(rule.Destinations, to)
+		}
+	default:
+		return nil, fmt.Errorf("invalid rule %q, unknown field %s", expr, kind)
+	}
+	return rule, rule.Validate()
+}
+
+// Validate checks the rule is usable
+func (r *SignRule) Validate() error {
+	switch r.Kind {
+	case SignRuleMaxValue:
+		if r.MaxValue.Int == nil || r.MaxValue.Sign() < 0 {
+			return fmt.Errorf("%s rule needs a positive value", r.Kind)
+		}
+	case SignRuleDestinations:
+		if len(r.Destinations) == 0 {
+			return fmt.Errorf("%s rule needs at least one address", r.Kind)
+		}
+	case SignRuleMethods:
+		if len(r.Methods) == 0 {
+			return fmt.Errorf("%s rule needs at least one method", r.Kind)
+		}
+	default:
+		return fmt.Errorf("unknown rule kind %q", r.Kind)
+	}
+	return nil
+}
+
+// AppliesTo returns whether the rule restricts the signer
+func (r *SignRule) AppliesTo(signer address.Address) bool {
+	return r.Signer == address.Undef || r.Signer == signer
+}
+
+// Allow checks the message against the rule, spent is the value already signed by the signer in the epoch.
+// Addresses are compared as they are, without resolving them.
+func (r *SignRule) Allow(msg *types.Message, spent abi.TokenAmount) error {
+	switch r.Kind {
+	case SignRuleMaxValue:
+		if total := big.Add(spent, msg.Value); total.GreaterThan(r.MaxValue) {
+			return fmt.Errorf("signing %s would exceed %s per epoch, %s already signed", types.FIL(msg.Value), types.FIL(r.MaxValue), types.FIL(spent))
+		}
+	case SignRuleDestinations:
+		for _, to := range r.Destinations {
+			if to == msg.To {
+				return nil
+			}
+		}
+		return fmt.Errorf("destination %s is not allowed", msg.To)
+	case SignRuleMethods:
+		for _, method := range r.Methods {
+			if method == msg.Method {
+				return nil
+			}
+		}
+		return fmt.Errorf("method %d is not allowed", msg.Method)
+	default:
+		return fmt.Errorf("unknown rule kind %q", r.Kind)
+	}
+	return nil
+}
+
+func parseRuleList(value string) ([]string, error) {
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return nil, fmt.Errorf("list must be written as [v1, v2]")
+	}
+	var items []string
+	for _, item := range strings.Split(value[1:len(value)-1], ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("empty list")
+	}
+	return items, nil
+}
@@ -0,0 +1,125 @@
+package wallet
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+// ThresholdAggregator combines the partial signatures of the co-signers of a threshold signer, which depends
+// on the scheme the shares were produced with, into a signature checkable against the signer address
+type ThresholdAggregator interface {
+	Aggregate(policy *gateway.ThresholdSignPolicy, toSign []byte, partials []gateway.PartialSignature) (*crypto.Signature, error)
+}
+
+var (
+	aggregatorsLk sync.RWMutex
+	aggregators   = map[crypto.SigType]ThresholdAggregator{}
+)
+
+// RegisterThresholdAggregator sets the aggregator used for the signers of the signature type, a nil aggregator
+// removes it
+func RegisterThresholdAggregator(sigType crypto.SigType, agg ThresholdAggregator) {
+	aggregatorsLk.Lock()
+	defer aggregatorsLk.Unlock()
+	if agg == nil {
+		delete(aggregators, sigType)
+		return
+	}
+	aggregators[sigType] = agg
+}
+
+// GetThresholdAggregator returns the aggregator registered for the signature type of the signer
+func GetThresholdAggregator(signer address.Address) (ThresholdAggregator, error) {
+	sigType := types.AddressProtocol2SignType(signer.Protocol())
+
+	aggregatorsLk.RLock()
+	defer aggregatorsLk.RUnlock()
+	agg, ok := aggregators[sigType]
+	if !ok {
+		return nil, fmt.Errorf("no threshold aggregator for signature type %d of %s", sigType, signer)
+	}
+	return agg, nil
+}
+
+// PartialSignatures collects the responses of the co-signers of a sign request
+type PartialSignatures struct {
+	policy   *gateway.ThresholdSignPolicy
+	lk       sync.Mutex
+	partials map[uint]gateway.PartialSignature
+}
+
+// NewPartialSignatures returns a collector for the co-signers of the policy
+func NewPartialSignatures(policy *gateway.ThresholdSignPolicy) (*PartialSignatures, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &PartialSignatures{policy: policy, partials: make(map[uint]gateway.PartialSignature)}, nil
+}
+
+// Requests returns the request to send to each co-signer, in the order of the policy
+func (ps *PartialSignatures) Requests(req *gateway.WalletSignRequest) []*gateway.PartialSignRequest {
+	reqs := make([]*gateway.PartialSignRequest, len(ps.policy.CoSigners))
+	for i := range ps.policy.CoSigners {
+		reqs[i] = &gateway.PartialSignRequest{
+			WalletSignRequest: *req,
+			Index:             uint(i),
+			Threshold:         ps.policy.Threshold,
+			CoSigners:         uint(len(ps.policy.CoSigners)),
+		}
+	}
+	return reqs
+}
+
+// Add records the partial signature of a co-signer and returns whether the threshold is reached
+func (ps *PartialSignatures) Add(partial gateway.PartialSignature) (bool, error) {
+	idx := ps.policy.CoSignerIndex(partial.CoSigner)
+	if idx < 0 {
+		return false, fmt.Errorf("%s is not a co-signer of %s", partial.CoSigner, ps.policy.Signer)
+	}
+	if uint(idx) != partial.Index {
+		return false, fmt.Errorf("co-signer %s sent share %d instead of %d", partial.CoSigner, partial.Index, idx)
+	}
+	if len(partial.Data) == 0 {
+		return false, fmt.Errorf("empty partial signature from %s", partial.CoSigner)
+	}
+
+	ps.lk.Lock()
+	defer ps.lk.Unlock()
+	if _, ok := ps.partials[partial.Index]; ok {
+		return false, fmt.Errorf("duplicate partial signature from %s", partial.CoSigner)
+	}
+	ps.partials[partial.Index] = partial
+	return uint(len(ps.partials)) >= ps.policy.Threshold, nil
+}
+
+// Aggregate combines the Threshold shares with the lowest indexes once enough of them are collected
+func (ps *PartialSignatures) Aggregate(toSign []byte) (*crypto.Signature, error) {
+	ps.lk.Lock()
+	partials := make([]gateway.PartialSignature, 0, len(ps.partials))
+	for _, p := range ps.partials {
+		partials = append(partials, p)
+	}
+	ps.lk.Unlock()
+
+	if uint(len(partials)) < ps.policy.Threshold {
+		return nil, fmt.Errorf("%d of %d partial signatures collected for %s", len(partials), ps.policy.Threshold, ps.policy.Signer)
+	}
+	sort.Slice(partials, func(i, j int) bool { return partials[i].Index < partials[j].Index })
+
+	agg, err := GetThresholdAggregator(ps.policy.Signer)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := agg.Aggregate(ps.policy, toSign, partials[:ps.policy.Threshold])
+	if err != nil {
+		return nil, fmt.Errorf("aggregate partial signatures of %s: %w", ps.policy.Signer, err)
+	}
+	return sig, nil
+}
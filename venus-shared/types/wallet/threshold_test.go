@@ -0,0 +1,73 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+// concatAggregator joins the shares, which is enough to check what the collector passes to the aggregator
+type concatAggregator struct{}
+
+func (concatAggregator) Aggregate(_ *gateway.ThresholdSignPolicy, toSign []byte, partials []gateway.PartialSignature) (*crypto.Signature, error) {
+	data := append([]byte{}, toSign...)
+	for _, p := range partials {
+		data = append(data, p.Data...)
+	}
+	return &crypto.Signature{Type: crypto.SigTypeBLS, Data: data}, nil
+}
+
+func TestPartialSignatures(t *testing.T) {
+	tf.UnitTest(t)
+
+	signer, err := address.NewBLSAddress(bytes.Repeat([]byte{1}, address.BlsPublicKeyBytes))
+	require.NoError(t, err)
+
+	require.Error(t, (&gateway.ThresholdSignPolicy{Signer: signer, Threshold: 3, CoSigners: []string{"a", "b"}}).Validate())
+	require.Error(t, (&gateway.ThresholdSignPolicy{Signer: signer, Threshold: 0, CoSigners: []string{"a", "b"}}).Validate())
+	require.Error(t, (&gateway.ThresholdSignPolicy{Signer: signer, Threshold: 2, CoSigners: []string{"a", "a"}}).Validate())
+
+	policy := &gateway.ThresholdSignPolicy{Signer: signer, Threshold: 2, CoSigners: []string{"a", "b", "c"}}
+	ps, err := NewPartialSignatures(policy)
+	require.NoError(t, err)
+
+	reqs := ps.Requests(&gateway.WalletSignRequest{Signer: signer, ToSign: []byte("msg")})
+	require.Len(t, reqs, 3)
+	require.Equal(t, uint(2), reqs[2].Index)
+	require.Equal(t, uint(3), reqs[2].CoSigners)
+
+	_, err = ps.Aggregate([]byte("msg"))
+	require.Error(t, err)
+
+	done, err := ps.Add(gateway.PartialSignature{CoSigner: "c", Index: 2, Data: []byte("C")})
+	require.NoError(t, err)
+	require.False(t, done)
+
+	// unknown, misplaced and duplicate shares are rejected
+	_, err = ps.Add(gateway.PartialSignature{CoSigner: "d", Index: 3, Data: []byte("D")})
+	require.Error(t, err)
+	_, err = ps.Add(gateway.PartialSignature{CoSigner: "a", Index: 1, Data: []byte("A")})
+	require.Error(t, err)
+	_, err = ps.Add(gateway.PartialSignature{CoSigner: "c", Index: 2, Data: []byte("C")})
+	require.Error(t, err)
+
+	done, err = ps.Add(gateway.PartialSignature{CoSigner: "a", Index: 0, Data: []byte("A")})
+	require.NoError(t, err)
+	require.True(t, done)
+
+	// no aggregator for bls yet
+	_, err = ps.Aggregate([]byte("msg"))
+	require.Error(t, err)
+
+	RegisterThresholdAggregator(crypto.SigTypeBLS, concatAggregator{})
+	defer RegisterThresholdAggregator(crypto.SigTypeBLS, nil)
+	sig, err := ps.Aggregate([]byte("msg"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("msgAC"), sig.Data)
+}
@@ -0,0 +1,69 @@
+package wallet
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type TxDirection string
+
+const (
+	TxIn  TxDirection = "in"
+	TxOut TxDirection = "out"
+)
+
+// Tx is a message on chain sent by or to a wallet address, a message between two wallet addresses is recorded by both
+type Tx struct {
+	// Address is the key address of the wallet
+	Address   address.Address
+	Direction TxDirection
+	Message   cid.Cid
+	// Height is the epoch of the tipset including the message, TipSet the tipset executing it
+	Height abi.ChainEpoch
+	TipSet types.TipSetKey
+	// Counterparty is the receiver of the messages sent and the sender of the messages received, as written in the message
+	Counterparty address.Address
+	Method       abi.MethodNum
+	Value        abi.TokenAmount
+	ExitCode     exitcode.ExitCode
+	GasUsed      int64
+	// Balance is the balance of the wallet address once the tipset including the message is executed
+	Balance abi.TokenAmount
+}
+
+var txCSVHeader = []string{"height", "message", "address", "direction", "counterparty", "method", "value", "exit_code", "gas_used", "balance"}
+
+// WriteTxsCSV writes the txs as csv with a header, the amounts in attoFIL
+func WriteTxsCSV(w io.Writer, txs []*Tx) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(txCSVHeader); err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		err := cw.Write([]string{
+			strconv.FormatInt(int64(tx.Height), 10),
+			tx.Message.String(),
+			tx.Address.String(),
+			string(tx.Direction),
+			tx.Counterparty.String(),
+			strconv.FormatUint(uint64(tx.Method), 10),
+			tx.Value.String(),
+			strconv.FormatInt(int64(tx.ExitCode), 10),
+			strconv.FormatInt(tx.GasUsed, 10),
+			tx.Balance.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// CanonicalJSON marshals v as its canonical json: the keys of every object, the fields of the structs included, are
+// sorted by bytes, the whitespace is left out, the html characters are not escaped and the numbers are normalized.
+// The big ints and the cids keep their json encodings, the decimal string and {"/": cid} with the default base of
+// the cid version, so the same value always gives the same bytes, whatever the order of its fields or map keys.
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return CanonicalizeJSON(raw)
+}
+
+// CanonicalizeJSON rewrites the json document raw as its canonical json, see CanonicalJSON
+func CanonicalizeJSON(raw []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("decode json: unexpected data after the document")
+	}
+
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// CanonicalJSONEqual tells whether a and b have the same canonical json
+func CanonicalJSONEqual(a, b interface{}) (bool, error) {
+	ca, err := CanonicalJSON(a)
+	if err != nil {
+		return false, err
+	}
+	cb, err := CanonicalJSON(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(ca, cb), nil
+}
+
+func writeCanonicalJSON(buf *bytes.Buffer, v interface{}) error {
+	switch val := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		if val {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case json.Number:
+		num, err := canonicalNumber(val)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(num)
+	case string:
+		writeCanonicalString(buf, val)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range val {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, k)
+			buf.WriteByte(':')
+			if err := writeCanonicalJSON(buf, val[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected json value %T", v)
+	}
+	return nil
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	// encoding a string can't fail
+	_ = enc.Encode(s)
+	// the encoder ends the value with a newline
+	buf.Truncate(buf.Len() - 1)
+}
+
+// canonicalNumber keeps the integers as they are, which may not fit in a float64, without their sign when they
+// are zero, and formats the other numbers as the shortest float64 encoding
+func canonicalNumber(n json.Number) (string, error) {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		if strings.TrimLeft(s, "-0") == "" {
+			return "0", nil
+		}
+		return s, nil
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return "", fmt.Errorf("invalid json number %s: %w", s, err)
+	}
+	out, err := json.Marshal(f)
+	if err != nil {
+		return "", fmt.Errorf("invalid json number %s: %w", s, err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	tf.UnitTest(t)
+
+	type ab struct {
+		B string
+		A types.BigInt
+		C cid.Cid
+		D map[string]int
+	}
+	type ba struct {
+		D map[string]int
+		C cid.Cid
+		A types.BigInt
+		B string
+	}
+
+	c, err := cid.Decode("bafy2bzacea3wsdh6y3a36tb3skempjoxqpuyompjbmfeyf34fi3uy6uue42v4")
+	require.NoError(t, err)
+
+	out, err := CanonicalJSON(ab{B: "<a&b>", A: types.NewInt(1234), C: c, D: map[string]int{"y": 1, "x": 2}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"A":"1234","B":"<a&b>","C":{"/":"bafy2bzacea3wsdh6y3a36tb3skempjoxqpuyompjbmfeyf34fi3uy6uue42v4"},"D":{"x":2,"y":1}}`, string(out))
+
+	// the order of the fields doesn't matter
+	eq, err := CanonicalJSONEqual(ab{B: "b", A: types.NewInt(1), C: c}, ba{B: "b", A: types.NewInt(1), C: c})
+	require.NoError(t, err)
+	assert.True(t, eq)
+	eq, err = CanonicalJSONEqual(ab{B: "b", A: types.NewInt(1)}, ba{B: "b", A: types.NewInt(2)})
+	require.NoError(t, err)
+	assert.False(t, eq)
+}
+
+func TestCanonicalizeJSON(t *testing.T) {
+	tf.UnitTest(t)
+
+	cases := map[string]string{
+		`{ "b": [1, 2.50, 1e3, -0], "a": null }`: `{"a":null,"b":[1,2.5,1000,0]}`,
+		`123456789012345678901234567890`:         `123456789012345678901234567890`,
+		`"<é"`:                                   `"<é"`,
+	}
+	for in, want := range cases {
+		out, err := CanonicalizeJSON([]byte(in))
+		require.NoError(t, err, in)
+		assert.Equal(t, want, string(out), in)
+	}
+
+	_, err := CanonicalizeJSON([]byte(`{"a": 1} {}`))
+	assert.Error(t, err)
+	_, err = CanonicalizeJSON([]byte(`{"a":`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,192 @@
+package utils
+
+import (
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+	gtypes "github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+// DefaultReplayWindow is how old a request event may be, and how long its id is remembered to refuse it again
+const DefaultReplayWindow = time.Minute
+
+var (
+	ErrEventKey      = errors.New("event key is not authenticated by the registration token")
+	ErrEventUnsigned = errors.New("event is not authenticated")
+	ErrEventMAC      = errors.New("event authentication failed")
+	ErrEventExpired  = errors.New("event is outside of the replay window")
+	ErrEventReplayed = errors.New("event was already received")
+)
+
+// NewEventKey generates the X25519 key a side of a channel sends at registration, see
+// WalletRegisterPolicy.EventKey and ConnectedCompleted.EventKey
+func NewEventKey() (*ecdh.PrivateKey, error) {
+	return ecdh.X25519().GenerateKey(rand.Reader)
+}
+
+// SignEventKey returns the MAC of the public event key by the token the service provider registered with, it is sent
+// along the key so that the other side can tell the key was not swapped on the way
+func SignEventKey(token string, pub *ecdh.PublicKey) []byte {
+	h := hmac.New(sha256.New, []byte(token))
+	writeMACFields(h, []byte("venus-gateway-event-key"), pub.Bytes())
+	return h.Sum(nil)
+}
+
+// EventAuthenticator signs and verifies the events of a channel with the secret derived from the keys exchanged at
+// registration, so that the events injected or replayed between the gateway and a service provider are refused.
+// Both sides sign their events, the request events are refused once outside of the replay window or when
+// received twice.
+type EventAuthenticator struct {
+	secret []byte
+	window time.Duration
+	now    func() time.Time
+
+	lk sync.Mutex
+	// seen are the request events verified, until they are outside of the replay window
+	seen map[string]time.Time
+}
+
+// NewEventAuthenticator derives the secret of the channel from the private key of this side and the public key of
+// the other one, window defaults to DefaultReplayWindow. The public key must come with its MAC by the registration
+// token, see SignEventKey: an active man in the middle could otherwise swap the keys of both sides with its own and
+// sign the events it injects.
+func NewEventAuthenticator(priv *ecdh.PrivateKey, peerKey, peerKeyMAC []byte, token string, channelID types.UUID,
+	window time.Duration,
+) (*EventAuthenticator, error) {
+	pub, err := ecdh.X25519().NewPublicKey(peerKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event key: %w", err)
+	}
+	if len(token) == 0 || !hmac.Equal(peerKeyMAC, SignEventKey(token, pub)) {
+		return nil, ErrEventKey
+	}
+	shared, err := priv.ECDH(pub)
+	if err != nil {
+		return nil, fmt.Errorf("derive event secret: %w", err)
+	}
+	if window <= 0 {
+		window = DefaultReplayWindow
+	}
+
+	// bind the secret to the channel, a key reused for another channel gives another secret
+	h := hmac.New(sha256.New, shared)
+	_, _ = h.Write([]byte("venus-gateway-event"))
+	_, _ = h.Write(channelID[:])
+	return &EventAuthenticator{
+		secret: h.Sum(nil),
+		window: window,
+		now:    time.Now,
+		seen:   make(map[string]time.Time),
+	}, nil
+}
+
+// SignRequest sets the signing time and the MAC of the request
+func (a *EventAuthenticator) SignRequest(req *gtypes.RequestEvent) {
+	req.SignedAt = a.now()
+	req.MAC = a.requestMAC(req)
+}
+
+// VerifyRequest checks the MAC of the request and that it is neither too old nor received before
+func (a *EventAuthenticator) VerifyRequest(req *gtypes.RequestEvent) error {
+	if len(req.MAC) == 0 {
+		return ErrEventUnsigned
+	}
+	if !hmac.Equal(req.MAC, a.requestMAC(req)) {
+		return ErrEventMAC
+	}
+
+	a.lk.Lock()
+	defer a.lk.Unlock()
+
+	now := a.now()
+	for key, expire := range a.seen {
+		if now.After(expire) {
+			delete(a.seen, key)
+		}
+	}
+	if age := now.Sub(req.SignedAt); age > a.window || age < -a.window {
+		return fmt.Errorf("%w: signed at %s", ErrEventExpired, req.SignedAt)
+	}
+
+	key := eventKey(req.ID, req.Chunk)
+	if _, ok := a.seen[key]; ok {
+		return fmt.Errorf("%w: %s", ErrEventReplayed, req.ID)
+	}
+	a.seen[key] = req.SignedAt.Add(a.window)
+	return nil
+}
+
+// SignResponse sets the MAC of the response
+func (a *EventAuthenticator) SignResponse(resp *gtypes.ResponseEvent) {
+	resp.MAC = a.responseMAC(resp)
+}
+
+// VerifyResponse checks the MAC of the response. Its replays are refused by the gateway, which only accepts the
+// responses of the requests waiting for one.
+func (a *EventAuthenticator) VerifyResponse(resp *gtypes.ResponseEvent) error {
+	if len(resp.MAC) == 0 {
+		return ErrEventUnsigned
+	}
+	if !hmac.Equal(resp.MAC, a.responseMAC(resp)) {
+		return ErrEventMAC
+	}
+	return nil
+}
+
+func (a *EventAuthenticator) requestMAC(req *gtypes.RequestEvent) []byte {
+	h := hmac.New(sha256.New, a.secret)
+	writeMACFields(h,
+		[]byte("request"),
+		req.ID[:],
+		[]byte(req.Method),
+		req.Payload,
+		chunkBytes(req.Chunk),
+		binary.BigEndian.AppendUint64(nil, uint64(req.SignedAt.UnixNano())),
+	)
+	return h.Sum(nil)
+}
+
+func (a *EventAuthenticator) responseMAC(resp *gtypes.ResponseEvent) []byte {
+	h := hmac.New(sha256.New, a.secret)
+	writeMACFields(h,
+		[]byte("response"),
+		resp.ID[:],
+		resp.Payload,
+		[]byte(resp.Error),
+		[]byte(resp.ErrorCode),
+		chunkBytes(resp.Chunk),
+	)
+	return h.Sum(nil)
+}
+
+// writeMACFields prefixes every field with its length, so that the bytes of a field can not be moved to another
+func writeMACFields(h hash.Hash, fields ...[]byte) {
+	for _, f := range fields {
+		_, _ = h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(f))))
+		_, _ = h.Write(f)
+	}
+}
+
+func chunkBytes(chunk *gtypes.EventChunk) []byte {
+	if chunk == nil {
+		return nil
+	}
+	b := binary.BigEndian.AppendUint64(nil, uint64(chunk.Index))
+	return binary.BigEndian.AppendUint64(b, uint64(chunk.Total))
+}
+
+func eventKey(id types.UUID, chunk *gtypes.EventChunk) string {
+	if chunk == nil {
+		return id.String()
+	}
+	return fmt.Sprintf("%s/%d", id, chunk.Index)
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+	gtypes "github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+func TestEventAuthenticator(t *testing.T) {
+	tf.UnitTest(t)
+
+	walletKey, err := NewEventKey()
+	require.NoError(t, err)
+	gatewayKey, err := NewEventKey()
+	require.NoError(t, err)
+	channelID := types.NewUUID()
+	token := "registration token"
+	walletMAC := SignEventKey(token, walletKey.PublicKey())
+	gatewayMAC := SignEventKey(token, gatewayKey.PublicKey())
+
+	gateway, err := NewEventAuthenticator(gatewayKey, walletKey.PublicKey().Bytes(), walletMAC, token, channelID, time.Minute)
+	require.NoError(t, err)
+	wallet, err := NewEventAuthenticator(walletKey, gatewayKey.PublicKey().Bytes(), gatewayMAC, token, channelID, time.Minute)
+	require.NoError(t, err)
+	other, err := NewEventAuthenticator(walletKey, gatewayKey.PublicKey().Bytes(), gatewayMAC, token, types.NewUUID(), time.Minute)
+	require.NoError(t, err)
+
+	_, err = NewEventAuthenticator(walletKey, []byte("short"), gatewayMAC, token, channelID, 0)
+	assert.Error(t, err)
+
+	req := &gtypes.RequestEvent{ID: types.NewUUID(), Method: "WalletSign", Payload: []byte("payload")}
+	assert.ErrorIs(t, wallet.VerifyRequest(req), ErrEventUnsigned)
+	gateway.SignRequest(req)
+
+	// the event goes through the json rpc
+	b, err := json.Marshal(req)
+	require.NoError(t, err)
+	var received gtypes.RequestEvent
+	require.NoError(t, json.Unmarshal(b, &received))
+
+	assert.ErrorIs(t, other.VerifyRequest(&received), ErrEventMAC)
+	require.NoError(t, wallet.VerifyRequest(&received))
+	assert.ErrorIs(t, wallet.VerifyRequest(&received), ErrEventReplayed)
+
+	forged := received
+	forged.Payload = []byte("forged")
+	assert.ErrorIs(t, wallet.VerifyRequest(&forged), ErrEventMAC)
+
+	// the chunks of a payload share the id of the request
+	chunk := &gtypes.RequestEvent{ID: req.ID, Method: req.Method, Payload: []byte("pay"), Chunk: &gtypes.EventChunk{Index: 1, Total: 2}}
+	gateway.SignRequest(chunk)
+	require.NoError(t, wallet.VerifyRequest(chunk))
+
+	old := &gtypes.RequestEvent{ID: types.NewUUID(), Method: "WalletSign"}
+	gateway.now = func() time.Time { return time.Now().Add(-2 * time.Minute) }
+	gateway.SignRequest(old)
+	assert.ErrorIs(t, wallet.VerifyRequest(old), ErrEventExpired)
+
+	// the ids are forgotten once outside of the replay window
+	wallet.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	assert.ErrorIs(t, wallet.VerifyRequest(&received), ErrEventExpired)
+	wallet.lk.Lock()
+	assert.Empty(t, wallet.seen)
+	wallet.lk.Unlock()
+
+	resp := gtypes.NewResponseEvent(req.ID, []byte("signature"), nil)
+	wallet.SignResponse(resp)
+	require.NoError(t, gateway.VerifyResponse(resp))
+	resp.Error = "injected"
+	assert.ErrorIs(t, gateway.VerifyResponse(resp), ErrEventMAC)
+	assert.ErrorIs(t, gateway.VerifyResponse(&gtypes.ResponseEvent{ID: req.ID}), ErrEventUnsigned)
+}
+
+func TestEventAuthenticatorSwappedKey(t *testing.T) {
+	tf.UnitTest(t)
+
+	walletKey, err := NewEventKey()
+	require.NoError(t, err)
+	gatewayKey, err := NewEventKey()
+	require.NoError(t, err)
+	channelID := types.NewUUID()
+	token := "registration token"
+
+	// a man in the middle swaps the key of the gateway with its own, it does not know the token to sign it
+	attackerKey, err := NewEventKey()
+	require.NoError(t, err)
+	_, err = NewEventAuthenticator(walletKey, attackerKey.PublicKey().Bytes(), SignEventKey("guessed token", attackerKey.PublicKey()),
+		token, channelID, 0)
+	assert.ErrorIs(t, err, ErrEventKey)
+
+	// the MAC of the genuine key does not authenticate another key
+	_, err = NewEventAuthenticator(walletKey, attackerKey.PublicKey().Bytes(), SignEventKey(token, gatewayKey.PublicKey()),
+		token, channelID, 0)
+	assert.ErrorIs(t, err, ErrEventKey)
+
+	// a key sent without its MAC, or a side without a token, is refused as well
+	_, err = NewEventAuthenticator(walletKey, gatewayKey.PublicKey().Bytes(), nil, token, channelID, 0)
+	assert.ErrorIs(t, err, ErrEventKey)
+	_, err = NewEventAuthenticator(walletKey, gatewayKey.PublicKey().Bytes(), SignEventKey("", gatewayKey.PublicKey()), "", channelID, 0)
+	assert.ErrorIs(t, err, ErrEventKey)
+
+	_, err = NewEventAuthenticator(walletKey, gatewayKey.PublicKey().Bytes(), SignEventKey(token, gatewayKey.PublicKey()), token, channelID, 0)
+	require.NoError(t, err)
+}
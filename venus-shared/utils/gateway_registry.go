@@ -0,0 +1,467 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v7"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+// NewGatewayRegistry creates the registry of the config
+func NewGatewayRegistry(cfg gateway.RegistryConfig) (gateway.Registry, error) {
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = gateway.DefaultRegistryTTL
+	}
+
+	switch cfg.Backend {
+	case "", gateway.RegistryMemory:
+		return NewMemGatewayRegistry(ttl), nil
+	case gateway.RegistryRedis:
+		if len(cfg.RedisEndpoint) == 0 {
+			return nil, fmt.Errorf("no redis endpoint for the gateway registry")
+		}
+		client := redis.NewClient(&redis.Options{Addr: cfg.RedisEndpoint, Password: cfg.RedisPassword, DB: cfg.RedisDB})
+		return NewRedisGatewayRegistry(client, cfg.Prefix, ttl), nil
+	default:
+		return nil, fmt.Errorf("unknown gateway registry backend %q", cfg.Backend)
+	}
+}
+
+func routeIndexKey(namespace, key string) string {
+	return namespace + "/" + key
+}
+
+// MemGatewayRegistry keeps the registry in memory, for a gateway running alone
+type MemGatewayRegistry struct {
+	ttl time.Duration
+	now func() time.Time
+
+	lk        sync.Mutex
+	instances map[types.UUID]*gateway.GatewayInstance
+	routes    map[types.UUID]*gateway.ClientRoute
+	// index are the channels of the routes by namespace and key
+	index map[string]map[types.UUID]struct{}
+}
+
+var _ gateway.Registry = (*MemGatewayRegistry)(nil)
+
+// NewMemGatewayRegistry creates an empty registry, the instances being live for ttl after their heartbeats
+func NewMemGatewayRegistry(ttl time.Duration) *MemGatewayRegistry {
+	return &MemGatewayRegistry{
+		ttl:       ttl,
+		now:       time.Now,
+		instances: make(map[types.UUID]*gateway.GatewayInstance),
+		routes:    make(map[types.UUID]*gateway.ClientRoute),
+		index:     make(map[string]map[types.UUID]struct{}),
+	}
+}
+
+func (r *MemGatewayRegistry) Heartbeat(ctx context.Context, instance *gateway.GatewayInstance) error {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	cp := *instance
+	cp.LastSeen = r.now()
+	r.instances[instance.ID] = &cp
+	return nil
+}
+
+func (r *MemGatewayRegistry) Leave(ctx context.Context, instance types.UUID) error {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	delete(r.instances, instance)
+	for id, route := range r.routes {
+		if route.Instance == instance {
+			r.deleteRoute(id)
+		}
+	}
+	return nil
+}
+
+func (r *MemGatewayRegistry) live(id types.UUID) (*gateway.GatewayInstance, bool) {
+	inst, ok := r.instances[id]
+	if !ok {
+		return nil, false
+	}
+	if r.now().Sub(inst.LastSeen) > r.ttl {
+		delete(r.instances, id)
+		return nil, false
+	}
+	return inst, true
+}
+
+func (r *MemGatewayRegistry) Instances(ctx context.Context) ([]*gateway.GatewayInstance, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	out := make([]*gateway.GatewayInstance, 0, len(r.instances))
+	for id := range r.instances {
+		if inst, ok := r.live(id); ok {
+			cp := *inst
+			out = append(out, &cp)
+		}
+	}
+	sortGatewayInstances(out)
+	return out, nil
+}
+
+func (r *MemGatewayRegistry) Instance(ctx context.Context, id types.UUID) (*gateway.GatewayInstance, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	inst, ok := r.live(id)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", id, gateway.ErrInstanceNotFound)
+	}
+	cp := *inst
+	return &cp, nil
+}
+
+func (r *MemGatewayRegistry) PutRoute(ctx context.Context, route *gateway.ClientRoute) error {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	r.deleteRoute(route.ChannelID)
+	cp := *route
+	cp.Keys = append([]string(nil), route.Keys...)
+	r.routes[route.ChannelID] = &cp
+	for _, key := range cp.Keys {
+		idx := routeIndexKey(cp.Namespace, key)
+		if r.index[idx] == nil {
+			r.index[idx] = make(map[types.UUID]struct{})
+		}
+		r.index[idx][cp.ChannelID] = struct{}{}
+	}
+	return nil
+}
+
+func (r *MemGatewayRegistry) DeleteRoute(ctx context.Context, channelID types.UUID) error {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	r.deleteRoute(channelID)
+	return nil
+}
+
+func (r *MemGatewayRegistry) deleteRoute(channelID types.UUID) {
+	route, ok := r.routes[channelID]
+	if !ok {
+		return
+	}
+	delete(r.routes, channelID)
+	for _, key := range route.Keys {
+		idx := routeIndexKey(route.Namespace, key)
+		delete(r.index[idx], channelID)
+		if len(r.index[idx]) == 0 {
+			delete(r.index, idx)
+		}
+	}
+}
+
+func (r *MemGatewayRegistry) Lookup(ctx context.Context, namespace, key string) ([]*gateway.ClientRoute, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	var out []*gateway.ClientRoute
+	for channelID := range r.index[routeIndexKey(namespace, key)] {
+		route := r.routes[channelID]
+		if _, ok := r.live(route.Instance); !ok {
+			r.deleteRoute(channelID)
+			continue
+		}
+		cp := *route
+		cp.Keys = append([]string(nil), route.Keys...)
+		out = append(out, &cp)
+	}
+	sortClientRoutes(out)
+	return out, nil
+}
+
+func (r *MemGatewayRegistry) Close() error {
+	return nil
+}
+
+// RedisGatewayRegistry keeps the registry in redis, to be shared by the instances behind a load balancer. All the
+// keys of an instance expire with the ttl, its heartbeats refresh the expiry of its routes and of their index, so
+// nothing is left behind in redis by an instance which stopped without leaving.
+type RedisGatewayRegistry struct {
+	client *redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+var _ gateway.Registry = (*RedisGatewayRegistry)(nil)
+
+// NewRedisGatewayRegistry creates a registry with the keys of the client starting with prefix
+func NewRedisGatewayRegistry(client *redis.Client, prefix string, ttl time.Duration) *RedisGatewayRegistry {
+	return &RedisGatewayRegistry{client: client, prefix: prefix, ttl: ttl}
+}
+
+func (r *RedisGatewayRegistry) instanceKey(id types.UUID) string {
+	return r.prefix + "instance:" + id.String()
+}
+
+func (r *RedisGatewayRegistry) routeKey(channelID types.UUID) string {
+	return r.prefix + "route:" + channelID.String()
+}
+
+func (r *RedisGatewayRegistry) indexKey(namespace, key string) string {
+	return r.prefix + "index:" + routeIndexKey(namespace, key)
+}
+
+// instanceRoutesKey is the set of the channels routed to the instance
+func (r *RedisGatewayRegistry) instanceRoutesKey(id types.UUID) string {
+	return r.prefix + "instance-routes:" + id.String()
+}
+
+// instanceRoutes returns the routes of the instance which are still set
+func (r *RedisGatewayRegistry) instanceRoutes(client *redis.Client, id types.UUID) ([]*gateway.ClientRoute, error) {
+	channels, err := client.SMembers(r.instanceRoutesKey(id)).Result()
+	if err != nil || len(channels) == 0 {
+		return nil, err
+	}
+	routeKeys := make([]string, len(channels))
+	for i, ch := range channels {
+		routeKeys[i] = r.prefix + "route:" + ch
+	}
+	values, err := client.MGet(routeKeys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	var out []*gateway.ClientRoute
+	for _, v := range values {
+		var route gateway.ClientRoute
+		if ok, err := decodeRedisValue(v, &route); err != nil {
+			return nil, err
+		} else if ok {
+			out = append(out, &route)
+		}
+	}
+	return out, nil
+}
+
+func (r *RedisGatewayRegistry) Heartbeat(ctx context.Context, instance *gateway.GatewayInstance) error {
+	cp := *instance
+	cp.LastSeen = time.Now()
+	data, err := json.Marshal(&cp)
+	if err != nil {
+		return err
+	}
+	client := r.client.WithContext(ctx)
+	routes, err := r.instanceRoutes(client, instance.ID)
+	if err != nil {
+		return err
+	}
+	_, err = client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Set(r.instanceKey(instance.ID), data, r.ttl)
+		pipe.Expire(r.instanceRoutesKey(instance.ID), r.ttl)
+		for _, route := range routes {
+			pipe.Expire(r.routeKey(route.ChannelID), r.ttl)
+			for _, key := range route.Keys {
+				pipe.Expire(r.indexKey(route.Namespace, key), r.ttl)
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *RedisGatewayRegistry) Leave(ctx context.Context, instance types.UUID) error {
+	client := r.client.WithContext(ctx)
+	routes, err := r.instanceRoutes(client, instance)
+	if err != nil {
+		return err
+	}
+	_, err = client.TxPipelined(func(pipe redis.Pipeliner) error {
+		for _, route := range routes {
+			for _, key := range route.Keys {
+				pipe.SRem(r.indexKey(route.Namespace, key), route.ChannelID.String())
+			}
+			pipe.Del(r.routeKey(route.ChannelID))
+		}
+		pipe.Del(r.instanceRoutesKey(instance), r.instanceKey(instance))
+		return nil
+	})
+	return err
+}
+
+func (r *RedisGatewayRegistry) Instances(ctx context.Context) ([]*gateway.GatewayInstance, error) {
+	client := r.client.WithContext(ctx)
+
+	var keys []string
+	iter := client.Scan(0, r.prefix+"instance:*", 100).Iterator()
+	for iter.Next() {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return []*gateway.GatewayInstance{}, nil
+	}
+
+	values, err := client.MGet(keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*gateway.GatewayInstance, 0, len(values))
+	for _, v := range values {
+		var inst gateway.GatewayInstance
+		if ok, err := decodeRedisValue(v, &inst); err != nil {
+			return nil, err
+		} else if ok {
+			out = append(out, &inst)
+		}
+	}
+	sortGatewayInstances(out)
+	return out, nil
+}
+
+func (r *RedisGatewayRegistry) Instance(ctx context.Context, id types.UUID) (*gateway.GatewayInstance, error) {
+	data, err := r.client.WithContext(ctx).Get(r.instanceKey(id)).Bytes()
+	if err == redis.Nil {
+		return nil, fmt.Errorf("%s: %w", id, gateway.ErrInstanceNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var inst gateway.GatewayInstance
+	if err := json.Unmarshal(data, &inst); err != nil {
+		return nil, err
+	}
+	return &inst, nil
+}
+
+func (r *RedisGatewayRegistry) PutRoute(ctx context.Context, route *gateway.ClientRoute) error {
+	if err := r.DeleteRoute(ctx, route.ChannelID); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(route)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.WithContext(ctx).TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Set(r.routeKey(route.ChannelID), data, r.ttl)
+		pipe.SAdd(r.instanceRoutesKey(route.Instance), route.ChannelID.String())
+		pipe.Expire(r.instanceRoutesKey(route.Instance), r.ttl)
+		for _, key := range route.Keys {
+			pipe.SAdd(r.indexKey(route.Namespace, key), route.ChannelID.String())
+			pipe.Expire(r.indexKey(route.Namespace, key), r.ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *RedisGatewayRegistry) DeleteRoute(ctx context.Context, channelID types.UUID) error {
+	client := r.client.WithContext(ctx)
+	data, err := client.Get(r.routeKey(channelID)).Bytes()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var route gateway.ClientRoute
+	if err := json.Unmarshal(data, &route); err != nil {
+		return err
+	}
+	_, err = client.TxPipelined(func(pipe redis.Pipeliner) error {
+		for _, key := range route.Keys {
+			pipe.SRem(r.indexKey(route.Namespace, key), channelID.String())
+		}
+		pipe.SRem(r.instanceRoutesKey(route.Instance), channelID.String())
+		pipe.Del(r.routeKey(channelID))
+		return nil
+	})
+	return err
+}
+
+func (r *RedisGatewayRegistry) Lookup(ctx context.Context, namespace, key string) ([]*gateway.ClientRoute, error) {
+	client := r.client.WithContext(ctx)
+	channels, err := client.SMembers(r.indexKey(namespace, key)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(channels) == 0 {
+		return nil, nil
+	}
+
+	routeKeys := make([]string, len(channels))
+	for i, ch := range channels {
+		routeKeys[i] = r.prefix + "route:" + ch
+	}
+	values, err := client.MGet(routeKeys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*gateway.ClientRoute
+	for i, v := range values {
+		var route gateway.ClientRoute
+		ok, err := decodeRedisValue(v, &route)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			// the route was deleted since
+			client.SRem(r.indexKey(namespace, key), channels[i])
+			continue
+		}
+
+		err = client.Get(r.instanceKey(route.Instance)).Err()
+		if err == redis.Nil {
+			if err := r.DeleteRoute(ctx, route.ChannelID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, &route)
+	}
+	sortClientRoutes(out)
+	return out, nil
+}
+
+func (r *RedisGatewayRegistry) Close() error {
+	return r.client.Close()
+}
+
+// decodeRedisValue decodes a value of MGet, false when the key was not set
+func decodeRedisValue(v interface{}, out interface{}) (bool, error) {
+	if v == nil {
+		return false, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return false, fmt.Errorf("unexpected redis value %T", v)
+	}
+	return true, json.Unmarshal([]byte(s), out)
+}
+
+func sortGatewayInstances(instances []*gateway.GatewayInstance) {
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Started.Before(instances[j].Started)
+	})
+}
+
+// sortClientRoutes puts the latest connections first
+func sortClientRoutes(routes []*gateway.ClientRoute) {
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Since.After(routes[j].Since)
+	})
+}
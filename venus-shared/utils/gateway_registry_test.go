@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+func TestMemGatewayRegistry(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	now := time.Now()
+	r := NewMemGatewayRegistry(time.Minute)
+	r.now = func() time.Time { return now }
+
+	inst1 := &gateway.GatewayInstance{ID: types.NewUUID(), Address: "http://gw1:45132/rpc/v2", Started: now}
+	inst2 := &gateway.GatewayInstance{ID: types.NewUUID(), Address: "http://gw2:45132/rpc/v2", Started: now.Add(time.Second)}
+	require.NoError(t, r.Heartbeat(ctx, inst1))
+	require.NoError(t, r.Heartbeat(ctx, inst2))
+
+	instances, err := r.Instances(ctx)
+	require.NoError(t, err)
+	require.Len(t, instances, 2)
+	assert.Equal(t, inst1.Address, instances[0].Address)
+
+	ch1, ch2 := types.NewUUID(), types.NewUUID()
+	require.NoError(t, r.PutRoute(ctx, &gateway.ClientRoute{
+		ChannelID: ch1, Instance: inst1.ID, Namespace: gateway.RouteNamespaceProof, Keys: []string{"f01000", "f01001"}, Since: now,
+	}))
+	require.NoError(t, r.PutRoute(ctx, &gateway.ClientRoute{
+		ChannelID: ch2, Instance: inst2.ID, Namespace: gateway.RouteNamespaceProof, Keys: []string{"f01000"}, Since: now.Add(time.Second),
+	}))
+
+	// the latest connection first
+	routes, err := r.Lookup(ctx, gateway.RouteNamespaceProof, "f01000")
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+	assert.Equal(t, ch2, routes[0].ChannelID)
+	assert.Equal(t, inst1.ID, routes[1].Instance)
+
+	routes, err = r.Lookup(ctx, gateway.RouteNamespaceWallet, "f01000")
+	require.NoError(t, err)
+	assert.Empty(t, routes)
+
+	// a route replaced with other keys leaves the old ones
+	require.NoError(t, r.PutRoute(ctx, &gateway.ClientRoute{
+		ChannelID: ch1, Instance: inst1.ID, Namespace: gateway.RouteNamespaceProof, Keys: []string{"f01002"}, Since: now,
+	}))
+	routes, err = r.Lookup(ctx, gateway.RouteNamespaceProof, "f01001")
+	require.NoError(t, err)
+	assert.Empty(t, routes)
+
+	// the second instance misses its heartbeats, its routes go away with it
+	now = now.Add(45 * time.Second)
+	require.NoError(t, r.Heartbeat(ctx, inst1))
+	now = now.Add(30 * time.Second)
+
+	_, err = r.Instance(ctx, inst2.ID)
+	assert.ErrorIs(t, err, gateway.ErrInstanceNotFound)
+	routes, err = r.Lookup(ctx, gateway.RouteNamespaceProof, "f01000")
+	require.NoError(t, err)
+	assert.Empty(t, routes)
+	routes, err = r.Lookup(ctx, gateway.RouteNamespaceProof, "f01002")
+	require.NoError(t, err)
+	assert.Len(t, routes, 1)
+
+	require.NoError(t, r.Leave(ctx, inst1.ID))
+	instances, err = r.Instances(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, instances)
+	assert.Empty(t, r.routes)
+	assert.Empty(t, r.index)
+
+	_, err = NewGatewayRegistry(gateway.RegistryConfig{Backend: gateway.RegistryRedis})
+	assert.Error(t, err)
+	_, err = NewGatewayRegistry(gateway.RegistryConfig{Backend: "etcd"})
+	assert.Error(t, err)
+}
+
+func TestRedisGatewayRegistry(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	r := NewRedisGatewayRegistry(redis.NewClient(&redis.Options{Addr: server.Addr()}), gateway.DefaultRegistryPrefix, time.Minute)
+	t.Cleanup(func() { _ = r.Close() })
+
+	now := time.Now()
+	inst1 := &gateway.GatewayInstance{ID: types.NewUUID(), Address: "http://gw1:45132/rpc/v2", Started: now}
+	inst2 := &gateway.GatewayInstance{ID: types.NewUUID(), Address: "http://gw2:45132/rpc/v2", Started: now.Add(time.Second)}
+	require.NoError(t, r.Heartbeat(ctx, inst1))
+	require.NoError(t, r.Heartbeat(ctx, inst2))
+
+	instances, err := r.Instances(ctx)
+	require.NoError(t, err)
+	require.Len(t, instances, 2)
+	assert.Equal(t, inst1.Address, instances[0].Address)
+
+	ch1, ch2 := types.NewUUID(), types.NewUUID()
+	require.NoError(t, r.PutRoute(ctx, &gateway.ClientRoute{
+		ChannelID: ch1, Instance: inst1.ID, Namespace: gateway.RouteNamespaceProof, Keys: []string{"f01000", "f01001"}, Since: now,
+	}))
+	require.NoError(t, r.PutRoute(ctx, &gateway.ClientRoute{
+		ChannelID: ch2, Instance: inst2.ID, Namespace: gateway.RouteNamespaceProof, Keys: []string{"f01000"}, Since: now.Add(time.Second),
+	}))
+
+	routes, err := r.Lookup(ctx, gateway.RouteNamespaceProof, "f01000")
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+	assert.Equal(t, ch2, routes[0].ChannelID)
+
+	// every key written expires
+	for _, key := range server.Keys() {
+		assert.Greater(t, server.TTL(key), time.Duration(0), key)
+	}
+
+	// the heartbeats of the first instance keep its routes, the keys of the second one expire
+	server.FastForward(45 * time.Second)
+	require.NoError(t, r.Heartbeat(ctx, inst1))
+	server.FastForward(30 * time.Second)
+
+	_, err = r.Instance(ctx, inst2.ID)
+	assert.ErrorIs(t, err, gateway.ErrInstanceNotFound)
+	routes, err = r.Lookup(ctx, gateway.RouteNamespaceProof, "f01000")
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+	assert.Equal(t, ch1, routes[0].ChannelID)
+	assert.False(t, server.Exists(r.routeKey(ch2)))
+	assert.False(t, server.Exists(r.instanceRoutesKey(inst2.ID)))
+
+	// leaving drops the routes of the instance at once
+	require.NoError(t, r.Leave(ctx, inst1.ID))
+	routes, err = r.Lookup(ctx, gateway.RouteNamespaceProof, "f01001")
+	require.NoError(t, err)
+	assert.Empty(t, routes)
+	assert.Empty(t, server.Keys())
+}
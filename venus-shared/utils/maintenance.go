@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+
+	gtypes "github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+var ErrNoMaintenanceWindow = errors.New("no maintenance window for the miner")
+
+// MaintenanceScheduler holds the requests of the miners in maintenance until their window ends, so that planned
+// restarts of their provers and markets do not fail the requests of the miner nodes.
+type MaintenanceScheduler struct {
+	now func() time.Time
+
+	lk      sync.Mutex
+	windows map[address.Address]*maintenance
+}
+
+type maintenance struct {
+	window gtypes.MaintenanceWindow
+	queued int
+	// closed when the window ends, is replaced or removed, so that its waiters check the window of the miner again
+	done  chan struct{}
+	timer *time.Timer
+}
+
+func NewMaintenanceScheduler() *MaintenanceScheduler {
+	return &MaintenanceScheduler{
+		now:     time.Now,
+		windows: make(map[address.Address]*maintenance),
+	}
+}
+
+// Set declares the window of the miner, replacing its previous one
+func (ms *MaintenanceScheduler) Set(window *gtypes.MaintenanceWindow) error {
+	if err := window.Validate(ms.now()); err != nil {
+		return err
+	}
+
+	ms.lk.Lock()
+	defer ms.lk.Unlock()
+
+	if old, ok := ms.windows[window.Miner]; ok {
+		ms.end(old)
+	}
+	m := &maintenance{window: *window, done: make(chan struct{})}
+	m.timer = time.AfterFunc(window.End.Sub(ms.now()), func() {
+		ms.lk.Lock()
+		defer ms.lk.Unlock()
+		ms.end(m)
+	})
+	ms.windows[window.Miner] = m
+	return nil
+}
+
+// Remove ends the window of the miner now, its queued requests are forwarded
+func (ms *MaintenanceScheduler) Remove(miner address.Address) error {
+	ms.lk.Lock()
+	defer ms.lk.Unlock()
+
+	m, ok := ms.windows[miner]
+	if !ok {
+		return ErrNoMaintenanceWindow
+	}
+	ms.end(m)
+	return nil
+}
+
+// end wakes up the waiters of the window, ms.lk must be held
+func (ms *MaintenanceScheduler) end(m *maintenance) {
+	if ms.windows[m.window.Miner] == m {
+		delete(ms.windows, m.window.Miner)
+	}
+	select {
+	case <-m.done:
+	default:
+		m.timer.Stop()
+		close(m.done)
+	}
+}
+
+// Wait returns once the miner is not in maintenance, at once when it isn't
+func (ms *MaintenanceScheduler) Wait(ctx context.Context, miner address.Address) error {
+	for {
+		ms.lk.Lock()
+		m, ok := ms.windows[miner]
+		if !ok || !m.window.Active(ms.now()) {
+			ms.lk.Unlock()
+			return nil
+		}
+		m.queued++
+		ms.lk.Unlock()
+
+		var err error
+		select {
+		case <-m.done:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+
+		ms.lk.Lock()
+		m.queued--
+		ms.lk.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// List returns the windows which have not ended yet, ordered by miner
+func (ms *MaintenanceScheduler) List() []*gtypes.MaintenanceState {
+	ms.lk.Lock()
+	defer ms.lk.Unlock()
+
+	now := ms.now()
+	out := make([]*gtypes.MaintenanceState, 0, len(ms.windows))
+	for _, m := range ms.windows {
+		out = append(out, &gtypes.MaintenanceState{
+			MaintenanceWindow: m.window,
+			Active:            m.window.Active(now),
+			Queued:            m.queued,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Miner.String() < out[j].Miner.String() })
+	return out
+}
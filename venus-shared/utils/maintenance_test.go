@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	gtypes "github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+func TestMaintenanceScheduler(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	miner, _ := address.NewIDAddress(1000)
+	other, _ := address.NewIDAddress(1001)
+	ms := NewMaintenanceScheduler()
+	now := time.Now()
+
+	require.Error(t, ms.Set(&gtypes.MaintenanceWindow{Miner: miner, Start: now, End: now.Add(-time.Second)}))
+	require.Error(t, ms.Set(&gtypes.MaintenanceWindow{Start: now, End: now.Add(time.Hour)}))
+	assert.ErrorIs(t, ms.Remove(miner), ErrNoMaintenanceWindow)
+
+	// not in maintenance yet
+	require.NoError(t, ms.Set(&gtypes.MaintenanceWindow{Miner: other, Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)}))
+	require.NoError(t, ms.Wait(ctx, other))
+
+	require.NoError(t, ms.Set(&gtypes.MaintenanceWindow{Miner: miner, Start: now, End: now.Add(time.Hour), Reason: "prover upgrade"}))
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() { done <- ms.Wait(ctx, miner) }()
+	}
+	require.Eventually(t, func() bool {
+		states := ms.List()
+		return len(states) == 2 && states[0].Queued == 2
+	}, time.Second, 10*time.Millisecond)
+
+	states := ms.List()
+	assert.Equal(t, miner, states[0].Miner)
+	assert.True(t, states[0].Active)
+	assert.False(t, states[1].Active)
+
+	// a request giving up leaves the queue
+	cctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, ms.Wait(cctx, miner), context.DeadlineExceeded)
+
+	// a replaced window keeps the requests queued
+	require.NoError(t, ms.Set(&gtypes.MaintenanceWindow{Miner: miner, Start: now, End: time.Now().Add(100 * time.Millisecond)}))
+	select {
+	case <-done:
+		t.Fatal("request forwarded before the end of the window")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// the queued requests are released once the window ends
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("request still queued after the end of the window")
+		}
+	}
+	states = ms.List()
+	require.Len(t, states, 1)
+	assert.Equal(t, other, states[0].Miner)
+
+	require.NoError(t, ms.Remove(other))
+	assert.Empty(t, ms.List())
+}
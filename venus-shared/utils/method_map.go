@@ -48,6 +48,13 @@ func loadMethodsMap() {
 	// TODO: combine with the runtime actor registry.
 	var actors []actorsWithVersion
 
+	/* inline-gen template
+	{{range .actorVersions}}{{if le . $.lastLegacyActorsVersion}}
+	actors = append(actors, actorsWithVersion{av: actorstypes.Version{{.}}, actors: builtin.MakeRegistryLegacy(exported{{.}}.BuiltinActors())}){{else}}
+	actors = append(actors, actorsWithVersion{av: actorstypes.Version{{.}}, actors: builtin.MakeRegistry(actorstypes.Version{{.}})}){{end}}{{end}}
+
+	/* inline-gen start */
+
 	actors = append(actors, actorsWithVersion{av: actorstypes.Version0, actors: builtin.MakeRegistryLegacy(exported0.BuiltinActors())})
 	actors = append(actors, actorsWithVersion{av: actorstypes.Version2, actors: builtin.MakeRegistryLegacy(exported2.BuiltinActors())})
 	actors = append(actors, actorsWithVersion{av: actorstypes.Version3, actors: builtin.MakeRegistryLegacy(exported3.BuiltinActors())})
@@ -62,6 +69,8 @@ func loadMethodsMap() {
 	actors = append(actors, actorsWithVersion{av: actorstypes.Version12, actors: builtin.MakeRegistry(actorstypes.Version12)})
 	actors = append(actors, actorsWithVersion{av: actorstypes.Version13, actors: builtin.MakeRegistry(actorstypes.Version13)})
 
+	/* inline-gen end */
+
 	for _, awv := range actors {
 		for _, actor := range awv.actors {
 			// necessary to make stuff work
@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	gtypes "github.com/filecoin-project/venus/venus-shared/types/gateway"
+)
+
+// ProofLimiter bounds the ComputeProof requests forwarded at once to the provers of each miner, the others
+// wait in a fifo queue, so that a slow prover does not get every request of a deadline at the same time
+type ProofLimiter struct {
+	lk           sync.Mutex
+	defaultLimit int
+	limits       map[address.Address]int
+	miners       map[address.Address]*proofQueue
+}
+
+type proofQueue struct {
+	active map[*gtypes.ProofRequestState]struct{}
+	queued []*proofWaiter
+}
+
+type proofWaiter struct {
+	state *gtypes.ProofRequestState
+	ready chan struct{}
+}
+
+// NewProofLimiter returns a limiter forwarding defaultLimit requests at once per miner, 0 is unlimited
+func NewProofLimiter(defaultLimit int) *ProofLimiter {
+	return &ProofLimiter{
+		defaultLimit: defaultLimit,
+		limits:       make(map[address.Address]int),
+		miners:       make(map[address.Address]*proofQueue),
+	}
+}
+
+// SetLimit sets the number of requests of the miner forwarded at once, a negative limit restores the default one
+func (pl *ProofLimiter) SetLimit(miner address.Address, limit int) {
+	pl.lk.Lock()
+	defer pl.lk.Unlock()
+
+	if limit < 0 {
+		delete(pl.limits, miner)
+	} else {
+		pl.limits[miner] = limit
+	}
+	if q, ok := pl.miners[miner]; ok {
+		pl.dispatch(miner, q)
+	}
+}
+
+// Acquire waits for a slot of the miner, the returned func releases it and must be called once the proof is computed
+func (pl *ProofLimiter) Acquire(ctx context.Context, miner address.Address, height abi.ChainEpoch) (func(), error) {
+	pl.lk.Lock()
+	q, ok := pl.miners[miner]
+	if !ok {
+		q = &proofQueue{active: make(map[*gtypes.ProofRequestState]struct{})}
+		pl.miners[miner] = q
+	}
+
+	w := &proofWaiter{
+		state: &gtypes.ProofRequestState{Height: height, CreateTime: time.Now()},
+		ready: make(chan struct{}),
+	}
+	q.queued = append(q.queued, w)
+	pl.dispatch(miner, q)
+	pl.lk.Unlock()
+
+	select {
+	case <-w.ready:
+	case <-ctx.Done():
+		pl.lk.Lock()
+		select {
+		case <-w.ready:
+			// got the slot meanwhile, give it back
+			pl.release(miner, q, w.state)
+		default:
+			for i, qw := range q.queued {
+				if qw == w {
+					q.queued = append(q.queued[:i], q.queued[i+1:]...)
+					break
+				}
+			}
+			pl.cleanup(miner, q)
+		}
+		pl.lk.Unlock()
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			pl.lk.Lock()
+			defer pl.lk.Unlock()
+			pl.release(miner, q, w.state)
+		})
+	}, nil
+}
+
+// State returns the queue of the miner, or of every miner with requests when miner is undef
+func (pl *ProofLimiter) State(miner address.Address) []*gtypes.ProofQueueState {
+	pl.lk.Lock()
+	defer pl.lk.Unlock()
+
+	var out []*gtypes.ProofQueueState
+	for addr, q := range pl.miners {
+		if miner != address.Undef && addr != miner {
+			continue
+		}
+		state := &gtypes.ProofQueueState{Miner: addr, Limit: pl.limit(addr)}
+		for s := range q.active {
+			cp := *s
+			state.Active = append(state.Active, &cp)
+		}
+		sort.Slice(state.Active, func(i, j int) bool { return state.Active[i].StartTime.Before(state.Active[j].StartTime) })
+		for _, w := range q.queued {
+			cp := *w.state
+			state.Queued = append(state.Queued, &cp)
+		}
+		out = append(out, state)
+	}
+	if miner != address.Undef && len(out) == 0 {
+		out = append(out, &gtypes.ProofQueueState{Miner: miner, Limit: pl.limit(miner)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Miner.String() < out[j].Miner.String() })
+	return out
+}
+
+func (pl *ProofLimiter) limit(miner address.Address) int {
+	if limit, ok := pl.limits[miner]; ok {
+		return limit
+	}
+	return pl.defaultLimit
+}
+
+// dispatch starts the queued requests while the miner has free slots, pl.lk must be held
+func (pl *ProofLimiter) dispatch(miner address.Address, q *proofQueue) {
+	limit := pl.limit(miner)
+	for len(q.queued) > 0 && (limit <= 0 || len(q.active) < limit) {
+		w := q.queued[0]
+		q.queued = q.queued[1:]
+		w.state.StartTime = time.Now()
+		q.active[w.state] = struct{}{}
+		close(w.ready)
+	}
+}
+
+func (pl *ProofLimiter) release(miner address.Address, q *proofQueue, state *gtypes.ProofRequestState) {
+	delete(q.active, state)
+	pl.dispatch(miner, q)
+	pl.cleanup(miner, q)
+}
+
+func (pl *ProofLimiter) cleanup(miner address.Address, q *proofQueue) {
+	if len(q.active) == 0 && len(q.queued) == 0 && pl.miners[miner] == q {
+		delete(pl.miners, miner)
+	}
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestProofLimiter(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	miner, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	other, err := address.NewIDAddress(1001)
+	require.NoError(t, err)
+
+	pl := NewProofLimiter(1)
+	release1, err := pl.Acquire(ctx, miner, 10)
+	require.NoError(t, err)
+
+	// the other miners are not limited by the requests of miner
+	releaseOther, err := pl.Acquire(ctx, other, 10)
+	require.NoError(t, err)
+	releaseOther()
+
+	acquired := make(chan func())
+	go func() {
+		release, err := pl.Acquire(ctx, miner, 11)
+		if err != nil {
+			t.Error(err)
+		}
+		acquired <- release
+	}()
+
+	require.Eventually(t, func() bool {
+		state := pl.State(miner)
+		return len(state) == 1 && len(state[0].Queued) == 1
+	}, time.Second, time.Millisecond)
+	state := pl.State(address.Undef)
+	require.Len(t, state, 1)
+	require.Equal(t, 1, state[0].Limit)
+	require.Len(t, state[0].Active, 1)
+
+	// a cancelled request leaves the queue
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = pl.Acquire(cctx, miner, 12)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Len(t, pl.State(miner)[0].Queued, 1)
+
+	release1()
+	release1()
+	release2 := <-acquired
+	state = pl.State(miner)
+	require.Len(t, state[0].Active, 1)
+	require.Len(t, state[0].Queued, 0)
+	release2()
+
+	require.Len(t, pl.State(address.Undef), 0)
+
+	// raising the limit starts the queued requests
+	release1, err = pl.Acquire(ctx, miner, 13)
+	require.NoError(t, err)
+	go func() {
+		release, err := pl.Acquire(ctx, miner, 14)
+		if err != nil {
+			t.Error(err)
+		}
+		acquired <- release
+	}()
+	require.Eventually(t, func() bool { return len(pl.State(miner)[0].Queued) == 1 }, time.Second, time.Millisecond)
+	pl.SetLimit(miner, 0)
+	release2 = <-acquired
+	require.Len(t, pl.State(miner)[0].Active, 2)
+	release1()
+	release2()
+}
@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/ipfs-force-community/metrics"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// DefaultSignCacheTTL is the default time a WalletSign response is kept
+const DefaultSignCacheTTL = 30 * time.Second
+
+var (
+	signCacheHits     = metrics.NewCounter("gateway/wallet_sign_cache_hit", "The number of WalletSign requests answered from the cache")
+	signCacheMisses   = metrics.NewCounter("gateway/wallet_sign_cache_miss", "The number of WalletSign requests forwarded to a wallet")
+	signCacheCoalesce = metrics.NewCounter("gateway/wallet_sign_cache_coalesce", "The number of WalletSign requests which waited for the same request in flight")
+	signCacheEntries  = metrics.NewInt64("gateway/wallet_sign_cache_entries", "The number of WalletSign responses cached", "")
+)
+
+// SignCacheStats counts the requests served by a SignCache
+type SignCacheStats struct {
+	Entries int
+	// Hits are the requests answered by a cached response or by the response of the same request in flight
+	Hits   uint64
+	Misses uint64
+}
+
+// SignCache keeps the WalletSign responses for a short time, so that retries and duplicated requests do not reach
+// the wallets twice. The requests are identified by their accounts, signer, payload and meta, concurrent
+// duplicates wait for the first one to be answered. Failed requests are not cached.
+type SignCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	lk        sync.Mutex
+	entries   map[signCacheKey]*signCacheEntry
+	lastPrune time.Time
+	stats     SignCacheStats
+}
+
+type signCacheKey struct {
+	accounts string
+	signer   address.Address
+	toSign   [sha256.Size]byte
+	metaType types.MsgType
+	extra    [sha256.Size]byte
+}
+
+type signCacheEntry struct {
+	done chan struct{}
+	sig  *crypto.Signature
+	err  error
+	// expire is set once answered, sc.lk protects it
+	expire time.Time
+}
+
+func (e *signCacheEntry) answered() bool {
+	select {
+	case <-e.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewSignCache returns a cache keeping the responses for ttl, a ttl of 0 disables the cache
+func NewSignCache(ttl time.Duration) *SignCache {
+	return &SignCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[signCacheKey]*signCacheEntry),
+	}
+}
+
+// Sign returns the cached signature of the request, or calls sign and caches its signature
+func (sc *SignCache) Sign(ctx context.Context,
+	accounts []string,
+	signer address.Address,
+	toSign []byte,
+	meta types.MsgMeta,
+	sign func(context.Context) (*crypto.Signature, error),
+) (*crypto.Signature, error) {
+	if sc.ttl <= 0 {
+		return sign(ctx)
+	}
+
+	key := newSignCacheKey(accounts, signer, toSign, meta)
+	for {
+		sc.lk.Lock()
+		now := sc.now()
+		sc.prune(now)
+		entry, ok := sc.entries[key]
+		if ok && (!entry.answered() || entry.expire.After(now)) {
+			sc.stats.Hits++
+			sc.lk.Unlock()
+
+			if entry.answered() {
+				signCacheHits.Tick(ctx)
+			} else {
+				signCacheCoalesce.Tick(ctx)
+				select {
+				case <-entry.done:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			if entry.err != nil {
+				if isContextErr(entry.err) {
+					// the first request was canceled, not answered
+					continue
+				}
+				return nil, entry.err
+			}
+			return copySignature(entry.sig), nil
+		}
+
+		entry = &signCacheEntry{done: make(chan struct{})}
+		sc.entries[key] = entry
+		sc.stats.Misses++
+		sc.lk.Unlock()
+		signCacheMisses.Tick(ctx)
+
+		entry.sig, entry.err = sign(ctx)
+		sc.lk.Lock()
+		if entry.err != nil {
+			// dropped before the waiters are woken up, so that a retry does not find it
+			if sc.entries[key] == entry {
+				delete(sc.entries, key)
+			}
+		}
+		entry.expire = sc.now().Add(sc.ttl)
+		close(entry.done)
+		sc.lk.Unlock()
+		if entry.err != nil {
+			return nil, entry.err
+		}
+		return copySignature(entry.sig), nil
+	}
+}
+
+// Stats returns the number of responses cached and the requests served
+func (sc *SignCache) Stats() SignCacheStats {
+	sc.lk.Lock()
+	defer sc.lk.Unlock()
+
+	stats := sc.stats
+	stats.Entries = len(sc.entries)
+	return stats
+}
+
+// prune drops the expired responses at most once per ttl, sc.lk must be held
+func (sc *SignCache) prune(now time.Time) {
+	if now.Sub(sc.lastPrune) < sc.ttl {
+		return
+	}
+	sc.lastPrune = now
+	for key, entry := range sc.entries {
+		// the requests in flight are kept
+		if entry.answered() && !entry.expire.After(now) {
+			delete(sc.entries, key)
+		}
+	}
+	signCacheEntries.Set(context.Background(), int64(len(sc.entries)))
+}
+
+func newSignCacheKey(accounts []string, signer address.Address, toSign []byte, meta types.MsgMeta) signCacheKey {
+	sorted := append([]string(nil), accounts...)
+	sort.Strings(sorted)
+	return signCacheKey{
+		accounts: strings.Join(sorted, "\x00"),
+		signer:   signer,
+		toSign:   sha256.Sum256(toSign),
+		metaType: meta.Type,
+		extra:    sha256.Sum256(meta.Extra),
+	}
+}
+
+func copySignature(sig *crypto.Signature) *crypto.Signature {
+	if sig == nil {
+		return nil
+	}
+	return &crypto.Signature{Type: sig.Type, Data: append([]byte(nil), sig.Data...)}
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
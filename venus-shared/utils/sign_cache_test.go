@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestSignCache(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	signer, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	meta := types.MsgMeta{Type: types.MTChainMsg, Extra: []byte("msg")}
+
+	now := time.Now()
+	sc := NewSignCache(time.Minute)
+	sc.now = func() time.Time { return now }
+
+	var calls int
+	sign := func(context.Context) (*crypto.Signature, error) {
+		calls++
+		return &crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: []byte{byte(calls)}}, nil
+	}
+
+	sig, err := sc.Sign(ctx, []string{"a", "b"}, signer, []byte("payload"), meta, sign)
+	require.NoError(t, err)
+	// the order of the accounts does not matter
+	sig2, err := sc.Sign(ctx, []string{"b", "a"}, signer, []byte("payload"), meta, sign)
+	require.NoError(t, err)
+	require.Equal(t, sig, sig2)
+	require.Equal(t, 1, calls)
+
+	// the cached signature is not shared with the callers
+	sig2.Data[0] = 0xff
+	sig3, err := sc.Sign(ctx, []string{"a", "b"}, signer, []byte("payload"), meta, sign)
+	require.NoError(t, err)
+	require.Equal(t, sig, sig3)
+
+	_, err = sc.Sign(ctx, []string{"a", "b"}, signer, []byte("payload"), types.MsgMeta{Type: types.MTUnknown}, sign)
+	require.NoError(t, err)
+	_, err = sc.Sign(ctx, []string{"a"}, signer, []byte("payload"), meta, sign)
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+
+	now = now.Add(2 * time.Minute)
+	sig4, err := sc.Sign(ctx, []string{"a", "b"}, signer, []byte("payload"), meta, sign)
+	require.NoError(t, err)
+	require.NotEqual(t, sig, sig4)
+	require.Equal(t, 4, calls)
+	require.Equal(t, SignCacheStats{Entries: 1, Hits: 2, Misses: 4}, sc.Stats())
+
+	// errors are not cached
+	failed := errors.New("wallet offline")
+	fail := func(context.Context) (*crypto.Signature, error) { return nil, failed }
+	_, err = sc.Sign(ctx, nil, signer, []byte("other"), meta, fail)
+	require.ErrorIs(t, err, failed)
+	_, err = sc.Sign(ctx, nil, signer, []byte("other"), meta, sign)
+	require.NoError(t, err)
+	require.Equal(t, 5, calls)
+}
+
+func TestSignCacheCoalesce(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	signer, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	sc := NewSignCache(time.Minute)
+
+	release := make(chan struct{})
+	var lk sync.Mutex
+	var calls int
+	sign := func(context.Context) (*crypto.Signature, error) {
+		lk.Lock()
+		calls++
+		lk.Unlock()
+		<-release
+		return &crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte("sig")}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sig, err := sc.Sign(ctx, []string{"a"}, signer, []byte("payload"), types.MsgMeta{}, sign)
+			if err != nil || string(sig.Data) != "sig" {
+				t.Errorf("unexpected response %v %v", sig, err)
+			}
+		}()
+	}
+	require.Eventually(t, func() bool { return sc.Stats().Hits == 3 }, 5*time.Second, 10*time.Millisecond)
+	close(release)
+	wg.Wait()
+	require.Equal(t, 1, calls)
+
+	// a disabled cache forwards every request
+	sc = NewSignCache(0)
+	for i := 0; i < 2; i++ {
+		_, err := sc.Sign(ctx, []string{"a"}, signer, []byte("payload"), types.MsgMeta{}, sign)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, calls)
+}
@@ -0,0 +1,40 @@
+package sigv4
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestSign(t *testing.T) {
+	tf.UnitTest(t)
+
+	// the get-vanilla case of the test suite of the signature version 4
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	Sign(req, PayloadHash(nil), Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"},
+		"us-east-1", "service", now)
+
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
+
+func TestSignSessionToken(t *testing.T) {
+	tf.UnitTest(t)
+
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/?b=2&a=1%202", nil)
+	require.NoError(t, err)
+	Sign(req, PayloadHash(nil), Credentials{AccessKeyID: "id", SecretAccessKey: "secret", SessionToken: "token"},
+		"us-east-1", "service", time.Now())
+
+	assert.Equal(t, "token", req.Header.Get("X-Amz-Security-Token"))
+	assert.Contains(t, req.Header.Get("Authorization"), "SignedHeaders=host;x-amz-date;x-amz-security-token,")
+	assert.Equal(t, "a=1%202&b=2", canonicalQuery(req.URL.Query()))
+}